@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/quangdangfit/gocommon/validation"
+
+	inventoryDomain "goshop/internal/inventory/domain"
+	inventoryRepository "goshop/internal/inventory/repository"
+	inventoryService "goshop/internal/inventory/service"
+	paymentService "goshop/internal/payment/service"
+	"goshop/pkg/config"
+	"goshop/pkg/dbs"
+	"goshop/pkg/stock"
+)
+
+// runCommand runs a one-off maintenance command, `api <command> [flags]`, instead of the
+// servers and returns the process exit code.
+func runCommand(ctx context.Context, db dbs.Database, args []string) int {
+	switch args[0] {
+	case "reconcile-reserved":
+		svc := inventoryService.NewReservedService(
+			db,
+			inventoryRepository.NewReservedRepository(db),
+			inventoryRepository.NewLedgerRepository(db),
+		)
+		return reconcileReserved(ctx, svc, args[1:], os.Stdout, os.Stderr)
+	case "reconcile-payments":
+		svc := newPaymentService(validation.New(), db)
+		olderThan := time.Duration(config.GetConfig().PaymentReconcileAfterMinutes) * time.Minute
+		return reconcilePayments(ctx, svc, olderThan, args[1:], os.Stdout, os.Stderr)
+	default:
+		fmt.Fprintf(os.Stderr, "unknown command %q; available: reconcile-reserved, reconcile-payments\n", args[0])
+		return 2
+	}
+}
+
+// reconcileReserved recomputes reserved_quantity from active stock reservations and prints
+// one line per drifted product. It exits 1 when drift remains afterwards, so a dry run
+// doubles as a monitoring check.
+func reconcileReserved(ctx context.Context, svc inventoryService.ReservedService, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("reconcile-reserved", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dryRun := fs.Bool("dry-run", false, "report drift without repairing it")
+	productID := fs.String("product", "", "check only this product ID")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	drifts, err := svc.ReconcileReserved(ctx, &inventoryDomain.ReconcileReservedReq{
+		ProductID: *productID,
+		DryRun:    *dryRun,
+		ActorID:   stock.ActorSystem,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "reconcile-reserved: %s\n", err)
+		return 1
+	}
+
+	remaining := 0
+	for _, d := range drifts {
+		status := "repaired"
+		if !d.Repaired {
+			status = "not repaired"
+			remaining++
+		}
+		if *dryRun {
+			status = "dry run"
+		}
+		fmt.Fprintf(stdout, "%s\treserved=%d\tactive=%d\t%s\n", d.ProductID, d.ReservedQuantity, d.ActiveReserved, status)
+	}
+	fmt.Fprintf(stdout, "%d product(s) drifted, %d repaired\n", len(drifts), len(drifts)-remaining)
+	if remaining > 0 {
+		return 1
+	}
+	return 0
+}
+
+// reconcilePayments checks stale unsettled payments against their providers and prints one
+// line per payment. It exits 1 when a payment couldn't be checked or updated.
+func reconcilePayments(ctx context.Context, svc paymentService.PaymentService, olderThan time.Duration, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("reconcile-payments", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dryRun := fs.Bool("dry-run", false, "report what would change without applying it")
+	fs.DurationVar(&olderThan, "older-than", olderThan, "only check payments unchanged for this long")
+	limit := fs.Int("limit", 100, "check at most this many payments")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	report, err := svc.ReconcilePayments(ctx, paymentService.ReconcileRequest{
+		OlderThan: olderThan,
+		Limit:     *limit,
+		DryRun:    *dryRun,
+	})
+	if err != nil {
+		fmt.Fprintf(stderr, "reconcile-payments: %s\n", err)
+		return 1
+	}
+
+	for _, e := range report.Entries {
+		fmt.Fprintf(stdout, "%s\torder=%s\t%s/%s\tlocal=%s\tprovider=%s\t%s",
+			e.PaymentID, e.OrderID, e.Provider, e.IntentID, e.LocalStatus, e.ProviderStatus, e.Action)
+		if e.Detail != "" {
+			fmt.Fprintf(stdout, ": %s", e.Detail)
+		}
+		fmt.Fprintln(stdout)
+	}
+	summary := fmt.Sprintf("%d payment(s) checked, %d updated, %d failed", report.Checked, report.Updated, report.Failed)
+	if report.DryRun {
+		summary += " (dry run)"
+	}
+	if report.RunID != "" {
+		summary += ", stored as run " + report.RunID
+	}
+	fmt.Fprintln(stdout, summary)
+	if report.Failed > 0 {
+		return 1
+	}
+	return 0
+}
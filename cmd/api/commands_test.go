@@ -0,0 +1,104 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	inventoryDomain "goshop/internal/inventory/domain"
+	inventoryModel "goshop/internal/inventory/model"
+	inventoryMocks "goshop/internal/inventory/service/mocks"
+	paymentModel "goshop/internal/payment/model"
+	paymentService "goshop/internal/payment/service"
+	paymentMocks "goshop/internal/payment/service/mocks"
+	"goshop/pkg/stock"
+)
+
+func TestReconcileReserved_DryRun(t *testing.T) {
+	svc := inventoryMocks.NewReservedService(t)
+	svc.On("ReconcileReserved", mock.Anything, &inventoryDomain.ReconcileReservedReq{
+		ProductID: "p1", DryRun: true, ActorID: stock.ActorSystem,
+	}).Return([]*inventoryModel.ReservedDrift{{ProductID: "p1", ReservedQuantity: 5, ActiveReserved: 2}}, nil).Once()
+
+	var stdout, stderr bytes.Buffer
+	code := reconcileReserved(context.Background(), svc, []string{"-dry-run", "-product", "p1"}, &stdout, &stderr)
+	require.Equal(t, 1, code)
+	require.Contains(t, stdout.String(), "p1\treserved=5\tactive=2\tdry run")
+	require.Contains(t, stdout.String(), "1 product(s) drifted, 0 repaired")
+}
+
+func TestReconcileReserved_RepairsEverything(t *testing.T) {
+	svc := inventoryMocks.NewReservedService(t)
+	svc.On("ReconcileReserved", mock.Anything, mock.Anything).
+		Return([]*inventoryModel.ReservedDrift{{ProductID: "p1", ReservedQuantity: 5, ActiveReserved: 2, Repaired: true}}, nil).Once()
+
+	var stdout, stderr bytes.Buffer
+	code := reconcileReserved(context.Background(), svc, nil, &stdout, &stderr)
+	require.Equal(t, 0, code)
+	require.Contains(t, stdout.String(), "1 product(s) drifted, 1 repaired")
+}
+
+func TestReconcileReserved_Error(t *testing.T) {
+	svc := inventoryMocks.NewReservedService(t)
+	svc.On("ReconcileReserved", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	var stdout, stderr bytes.Buffer
+	code := reconcileReserved(context.Background(), svc, nil, &stdout, &stderr)
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr.String(), "db down")
+}
+
+func TestReconcileReserved_BadFlag(t *testing.T) {
+	svc := inventoryMocks.NewReservedService(t)
+
+	var stdout, stderr bytes.Buffer
+	code := reconcileReserved(context.Background(), svc, []string{"-nope"}, &stdout, &stderr)
+	require.Equal(t, 2, code)
+}
+
+func TestReconcilePayments_PrintsReport(t *testing.T) {
+	svc := paymentMocks.NewPaymentService(t)
+	svc.On("ReconcilePayments", mock.Anything, paymentService.ReconcileRequest{OlderThan: 2 * time.Hour, Limit: 10, DryRun: true}).
+		Return(&paymentService.ReconcileReport{DryRun: true, Checked: 2, Updated: 1, Entries: []paymentService.ReconcileEntry{
+			{PaymentID: "p1", OrderID: "o1", Provider: "stripe", IntentID: "pi_1", LocalStatus: paymentModel.PaymentStatusPending,
+				ProviderStatus: "succeeded", Action: paymentService.ReconcileUpdated},
+			{PaymentID: "p2", OrderID: "o2", Provider: "bank_transfer", IntentID: "manual_o2", LocalStatus: paymentModel.PaymentStatusRequiresAction,
+				Action: paymentService.ReconcileSkipped, Detail: "confirmed by an admin"},
+		}}, nil).Once()
+
+	var stdout, stderr bytes.Buffer
+	code := reconcilePayments(context.Background(), svc, 30*time.Minute, []string{"-dry-run", "-older-than", "2h", "-limit", "10"}, &stdout, &stderr)
+	require.Equal(t, 0, code)
+	require.Contains(t, stdout.String(), "p1\torder=o1\tstripe/pi_1\tlocal=pending\tprovider=succeeded\tupdated\n")
+	require.Contains(t, stdout.String(), "skipped: confirmed by an admin")
+	require.Contains(t, stdout.String(), "2 payment(s) checked, 1 updated, 0 failed (dry run)")
+}
+
+func TestReconcilePayments_FailuresExitNonZero(t *testing.T) {
+	svc := paymentMocks.NewPaymentService(t)
+	svc.On("ReconcilePayments", mock.Anything, paymentService.ReconcileRequest{OlderThan: 30 * time.Minute, Limit: 100}).
+		Return(&paymentService.ReconcileReport{RunID: "run1", Checked: 1, Failed: 1, Entries: []paymentService.ReconcileEntry{
+			{PaymentID: "p1", Action: paymentService.ReconcileFailed, Detail: "stripe get intent: status=500"},
+		}}, nil).Once()
+
+	var stdout, stderr bytes.Buffer
+	code := reconcilePayments(context.Background(), svc, 30*time.Minute, nil, &stdout, &stderr)
+	require.Equal(t, 1, code)
+	require.Contains(t, stdout.String(), "failed: stripe get intent: status=500")
+	require.Contains(t, stdout.String(), "1 payment(s) checked, 0 updated, 1 failed, stored as run run1")
+}
+
+func TestReconcilePayments_Error(t *testing.T) {
+	svc := paymentMocks.NewPaymentService(t)
+	svc.On("ReconcilePayments", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	var stdout, stderr bytes.Buffer
+	code := reconcilePayments(context.Background(), svc, time.Minute, nil, &stdout, &stderr)
+	require.Equal(t, 1, code)
+	require.Contains(t, stderr.String(), "db down")
+}
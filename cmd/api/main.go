@@ -9,16 +9,32 @@ import (
 
 	"github.com/quangdangfit/gocommon/logger"
 	"github.com/quangdangfit/gocommon/validation"
+	goredis "github.com/redis/go-redis/v9"
 
+	cartRepository "goshop/internal/cart/repository"
+	cartService "goshop/internal/cart/service"
+	inventoryRepository "goshop/internal/inventory/repository"
+	notificationRepository "goshop/internal/notification/repository"
+	notificationSvc "goshop/internal/notification/service"
 	orderRepository "goshop/internal/order/repository"
 	orderService "goshop/internal/order/service"
+	outboxRepository "goshop/internal/outbox/repository"
+	outboxService "goshop/internal/outbox/service"
+	paymentRepository "goshop/internal/payment/repository"
+	paymentService "goshop/internal/payment/service"
 	grpcServer "goshop/internal/server/grpc"
 	httpServer "goshop/internal/server/http"
+	userRepository "goshop/internal/user/repository"
 	"goshop/pkg/config"
+	"goshop/pkg/currency"
 	"goshop/pkg/dbs"
 	"goshop/pkg/eventbus"
 	"goshop/pkg/notification"
+	"goshop/pkg/payment"
 	"goshop/pkg/redis"
+	"goshop/pkg/shipping"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
 )
 
 //	@title			GoShop Swagger API
@@ -41,6 +57,9 @@ import (
 func main() {
 	cfg := config.LoadConfig()
 	logger.Initialize(cfg.Environment)
+	if _, err := currency.ParseRates(cfg.BaseCurrency, cfg.ExchangeRates); err != nil {
+		logger.Fatal("Invalid currency configuration", err)
+	}
 
 	db, err := dbs.NewDatabase(cfg.DatabaseURI)
 	if err != nil {
@@ -48,17 +67,28 @@ func main() {
 	}
 	// Schema is managed externally via golang-migrate; see migrations/ and `make migrate-up`.
 
+	// `api <command>` runs a maintenance command, e.g. `api reconcile-reserved -dry-run`.
+	if len(os.Args) > 1 {
+		os.Exit(runCommand(context.Background(), db, os.Args[1:]))
+	}
+
 	validator := validation.New()
 
-	// Wire the process-wide event bus and subscribe a logger sink for LowStock alerts.
-	// Replace with an admin email channel once the notification service learns to consume
-	// inventory events.
-	bus := eventbus.New()
+	notifier := newNotifier(cfg, db)
+
+	// Wire the process-wide event bus: customer emails for order events (the payment confirmation
+	// carries the invoice) and admin emails for LowStock alerts. Domains don't publish directly;
+	// they record events in the outbox and the relay below delivers them here.
+	bus := newEventBus(cfg, db)
 	eventbus.SetDefault(bus)
-	bus.Subscribe(eventbus.TopicLowStock, func(_ context.Context, ev eventbus.Event) {
-		ls := ev.(eventbus.LowStock)
-		logger.Warnf("low stock: product=%s available=%d threshold=%d", ls.ProductID, ls.Available, ls.Threshold)
-	})
+	notificationSvc.SubscribeOrderEmails(bus, notifier, orderService.NewInvoices(cfg, db))
+	notificationSvc.SubscribeLowStockAlerts(
+		bus,
+		notificationSvc.NewAdminLookup(userRepository.NewUserRepository(db)),
+		notificationRepository.NewLowStockAlertRepository(db),
+		notifier,
+		time.Duration(cfg.LowStockAlertCooldownMinutes)*time.Minute,
+	)
 
 	cache := redis.New(redis.Config{
 		Address:  cfg.RedisURI,
@@ -85,6 +115,22 @@ func main() {
 	sweeperCtx, sweeperCancel := context.WithCancel(context.Background())
 	defer sweeperCancel()
 	go runReservationSweeper(sweeperCtx, validator, db)
+	// Background reminder: email users whose cart has been idle for AbandonedCartAfterMinutes.
+	go runAbandonedCartReminder(sweeperCtx, cfg, db, notifier)
+	// Background reconciler: settle payments whose webhooks never arrived.
+	go runPaymentReconciler(sweeperCtx, cfg, validator, db)
+	// Background inbox worker: retry webhook events that failed to apply.
+	go runWebhookInbox(sweeperCtx, validator, db)
+	// Background relay: publish committed outbox events to the bus.
+	go runOutboxRelay(sweeperCtx, db, bus)
+	// Durable buses feed subscribers from their store until shutdown.
+	busDone := make(chan struct{})
+	go func() {
+		defer close(busDone)
+		if runner, ok := bus.(eventbus.Runner); ok {
+			runner.Run(sweeperCtx)
+		}
+	}()
 
 	// Wait for interrupt signal
 	quit := make(chan os.Signal, 1)
@@ -102,33 +148,191 @@ func main() {
 	}
 
 	sweeperCancel()
+	<-busDone
 	logger.Info("Servers exited gracefully")
 }
 
 func runReservationSweeper(ctx context.Context, validator validation.Validation, db dbs.Database) {
-	svc := orderService.NewOrderService(
+	svc := newOrderService(validator, db)
+	ticker := time.NewTicker(60 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			released, err := svc.SweepExpiredReservations(ctx, 100)
+			if err != nil {
+				logger.Error("reservation sweeper: ", err)
+				continue
+			}
+			if released > 0 {
+				logger.Infof("reservation sweeper released %d expired reservations", released)
+			}
+		}
+	}
+}
+
+// newOrderService builds the OrderService the background jobs and commands drive.
+func newOrderService(validator validation.Validation, db dbs.Database) orderService.OrderService {
+	rates := currency.MustParseRates(config.GetConfig().BaseCurrency, config.GetConfig().ExchangeRates)
+	shippingSvc := orderService.NewShippingService(validator, orderRepository.NewShippingRateRepository(db), rates,
+		shipping.MustParseMethods(config.GetConfig().BaseCurrency, config.GetConfig().ShippingMethods))
+	return orderService.NewOrderService(
 		validator, db,
 		orderRepository.NewOrderRepository(db),
 		orderRepository.NewProductRepository(db),
 		orderRepository.NewUserRepository(db),
 		orderRepository.NewReservationRepository(db),
-		orderService.NewCouponService(validator, orderRepository.NewCouponRepository(db)),
-		notification.NewLoggerNotifier(),
+		orderService.NewCouponService(validator, orderRepository.NewCouponRepository(db), rates),
+		outboxRepository.NewOutboxRepository(db),
+		inventoryRepository.NewLedgerRepository(db),
+		orderRepository.NewWarehouseRepository(db),
+		stock.AllocationStrategy(config.GetConfig().WarehouseAllocation),
+		rates,
+		shippingSvc,
+		orderService.NewTaxService(validator, orderRepository.NewTaxRateRepository(db), tax.Mode(config.GetConfig().TaxMode)),
+		orderService.NewInvoices(config.GetConfig(), db),
+		paymentService.NewSettler(config.GetConfig(), db),
 	)
-	ticker := time.NewTicker(60 * time.Second)
+}
+
+// newPaymentService builds a PaymentService over the configured providers.
+func newPaymentService(validator validation.Validation, db dbs.Database) paymentService.PaymentService {
+	orderSvc := newOrderService(validator, db)
+	return paymentService.NewPaymentService(
+		db,
+		paymentService.NewProviders(config.GetConfig()),
+		paymentRepository.NewPaymentRepository(db),
+		paymentRepository.NewRefundRepository(db),
+		paymentRepository.NewPaymentMethodRepository(db),
+		orderSvc, orderSvc,
+		orderService.NewInvoices(config.GetConfig(), db),
+		payment.CaptureMode(config.GetConfig().PaymentCapture),
+	)
+}
+
+func newEventBus(cfg *config.Schema, db dbs.Database) eventbus.Bus {
+	opts := eventbus.DurableOptions{Group: cfg.EventBusGroup}
+	switch cfg.EventBusBackend {
+	case "", eventbus.BackendInproc:
+		return eventbus.New()
+	case eventbus.BackendPostgres:
+		return eventbus.NewPostgres(db.GetDB(), opts)
+	case eventbus.BackendRedis:
+		return eventbus.NewRedis(goredis.NewClient(&goredis.Options{
+			Addr:     cfg.RedisURI,
+			Password: cfg.RedisPassword,
+			DB:       cfg.RedisDB,
+		}), opts)
+	default:
+		logger.Fatal("Unknown eventbus_backend: ", cfg.EventBusBackend)
+		return nil
+	}
+}
+
+func newNotifier(cfg *config.Schema, db dbs.Database) notification.Notifier {
+	return notification.BuildDefault(notification.Settings{
+		SMTPHost:     cfg.SMTPHost,
+		SMTPPort:     cfg.SMTPPort,
+		SMTPUser:     cfg.SMTPUser,
+		SMTPPassword: cfg.SMTPPassword,
+		EmailFrom:    cfg.EmailFrom,
+		Prefs: notificationSvc.NewDBPreferenceChecker(
+			notificationSvc.NewUserRepoLookup(userRepository.NewUserRepository(db)),
+			notificationRepository.NewPreferenceRepository(db),
+		),
+		DLQ: notificationRepository.NewDeadLetterSink(db),
+	})
+}
+
+func runAbandonedCartReminder(ctx context.Context, cfg *config.Schema, db dbs.Database, notifier notification.Notifier) {
+	svc := cartService.NewReminderService(cartRepository.NewCartRepository(db), notifier)
+	idleFor := time.Duration(cfg.AbandonedCartAfterMinutes) * time.Minute
+	ticker := time.NewTicker(5 * time.Minute)
 	defer ticker.Stop()
 	for {
 		select {
 		case <-ctx.Done():
 			return
 		case <-ticker.C:
-			released, err := svc.SweepExpiredReservations(ctx, 100)
+			sent, err := svc.SendAbandonedCartReminders(ctx, idleFor, 100)
 			if err != nil {
-				logger.Error("reservation sweeper: ", err)
+				logger.Error("abandoned cart reminder: ", err)
 				continue
 			}
-			if released > 0 {
-				logger.Infof("reservation sweeper released %d expired reservations", released)
+			if sent > 0 {
+				logger.Infof("abandoned cart reminder sent %d reminders", sent)
+			}
+		}
+	}
+}
+
+func runPaymentReconciler(ctx context.Context, cfg *config.Schema, validator validation.Validation, db dbs.Database) {
+	svc := newPaymentService(validator, db)
+	olderThan := time.Duration(cfg.PaymentReconcileAfterMinutes) * time.Minute
+	ticker := time.NewTicker(5 * time.Minute)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := svc.ReconcilePayments(ctx, paymentService.ReconcileRequest{OlderThan: olderThan, Limit: 100})
+			if err != nil {
+				logger.Error("payment reconciler: ", err)
+				continue
+			}
+			for _, e := range report.Entries {
+				switch e.Action {
+				case paymentService.ReconcileUpdated:
+					logger.Infof("payment reconciler: payment %s of order %s moved %s -> %s (%s %s)",
+						e.PaymentID, e.OrderID, e.LocalStatus, e.ProviderStatus, e.Provider, e.IntentID)
+				case paymentService.ReconcileFailed:
+					logger.Errorf("payment reconciler: payment %s of order %s (%s %s): %s",
+						e.PaymentID, e.OrderID, e.Provider, e.IntentID, e.Detail)
+				}
+			}
+			if report.Updated > 0 || report.Failed > 0 {
+				logger.Infof("payment reconciler checked %d payments: %d updated, %d failed (run %s)",
+					report.Checked, report.Updated, report.Failed, report.RunID)
+			}
+		}
+	}
+}
+
+func runWebhookInbox(ctx context.Context, validator validation.Validation, db dbs.Database) {
+	svc := newPaymentService(validator, db)
+	ticker := time.NewTicker(10 * time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			processed, err := svc.ProcessPendingEvents(ctx, 100)
+			if err != nil {
+				logger.Error("webhook inbox: ", err)
+				continue
+			}
+			if processed > 0 {
+				logger.Infof("webhook inbox applied %d retried events", processed)
+			}
+		}
+	}
+}
+
+func runOutboxRelay(ctx context.Context, db dbs.Database, bus eventbus.Bus) {
+	svc := outboxService.NewOutboxService(outboxRepository.NewOutboxRepository(db), bus)
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := svc.RelayPending(ctx, 100); err != nil {
+				logger.Error("outbox relay: ", err)
 			}
 		}
 	}
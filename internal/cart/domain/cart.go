@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"time"
+
+	orderDomain "goshop/internal/order/domain"
+	"goshop/pkg/money"
+)
+
+type Cart struct {
+	ID         string      `json:"id"`
+	Items      []*CartItem `json:"items"`
+	TotalPrice money.Money `json:"total_price"`
+	ItemCount  int         `json:"item_count"`
+	// Valid is false when at least one line has an issue; checkout is rejected until the
+	// shopper reviews the cart.
+	Valid     bool      `json:"valid"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type CartItem struct {
+	ProductID    string      `json:"product_id"`
+	ProductName  string      `json:"product_name"`
+	Images       []string    `json:"images,omitempty"`
+	Quantity     int         `json:"quantity"`
+	UnitPrice    money.Money `json:"unit_price"`
+	CurrentPrice money.Money `json:"current_price"`
+	Available    int         `json:"available"`
+	Issue        string      `json:"issue,omitempty"`
+}
+
+// CartOwner identifies whose cart a request targets. Authenticated requests carry a UserID;
+// guests carry the ID of a previously created guest cart (empty on first visit).
+type CartOwner struct {
+	UserID      string
+	GuestCartID string
+}
+
+type AddCartItemReq struct {
+	ProductID string `json:"product_id" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"required,gt=0"`
+}
+
+type UpdateCartItemReq struct {
+	Quantity int `json:"quantity" validate:"gte=0"`
+}
+
+type MergeCartReq struct {
+	GuestCartID string `json:"guest_cart_id" validate:"required"`
+}
+
+type CheckoutCartReq struct {
+	CouponCode string `json:"coupon_code,omitempty"`
+	// PaymentMethod is passed through to the order: "online" (the default) or "cod".
+	PaymentMethod string `json:"payment_method,omitempty"`
+	// Currency is passed through to the order; empty means the base currency.
+	Currency string `json:"currency,omitempty"`
+	// The addresses and shipping method are passed through to the order, which falls back to
+	// the buyer's default address and the default method.
+	ShippingAddressID string                  `json:"shipping_address_id,omitempty"`
+	ShippingAddress   *orderDomain.AddressReq `json:"shipping_address,omitempty"`
+	BillingAddressID  string                  `json:"billing_address_id,omitempty"`
+	BillingAddress    *orderDomain.AddressReq `json:"billing_address,omitempty"`
+	ShippingMethod    string                  `json:"shipping_method,omitempty"`
+}
+
+// CartSnapshotReq is the FE-supplied copy of a logged-in user's cart. It replaces the
+// server cart wholesale, so a client that still keeps a local cart can sync it up.
+type CartSnapshotReq struct {
+	Items []CartSnapshotItem `json:"items" validate:"lte=100,dive"`
+}
+
+type CartSnapshotItem struct {
+	ProductID string `json:"product_id" validate:"required"`
+	Quantity  int    `json:"quantity" validate:"required,gt=0"`
+}
+
+// CartIssue is one line-level problem reported back when checkout is rejected.
+type CartIssue struct {
+	ProductID string `json:"product_id"`
+	Issue     string `json:"issue"`
+	Requested int    `json:"requested"`
+	Available int    `json:"available"`
+}
@@ -0,0 +1,44 @@
+package domain
+
+import "goshop/internal/cart/model"
+
+// CartFromModel returns the API DTO for a cart. Totals are computed from the live product
+// price so the shopper always sees what checkout will actually charge.
+func CartFromModel(m *model.Cart) *Cart {
+	if m == nil {
+		return nil
+	}
+	out := &Cart{
+		ID:        m.ID,
+		Items:     make([]*CartItem, 0, len(m.Items)),
+		Valid:     true,
+		UpdatedAt: m.UpdatedAt,
+	}
+	for _, it := range m.Items {
+		line := CartItemFromModel(it)
+		out.Items = append(out.Items, line)
+		out.TotalPrice = out.TotalPrice.Add(line.CurrentPrice.Mul(int64(line.Quantity)))
+		out.ItemCount += line.Quantity
+		if line.Issue != "" {
+			out.Valid = false
+		}
+	}
+	return out
+}
+
+func CartItemFromModel(m *model.CartItem) *CartItem {
+	out := &CartItem{
+		ProductID:    m.ProductID,
+		Quantity:     m.Quantity,
+		UnitPrice:    m.UnitPrice,
+		CurrentPrice: m.UnitPrice,
+		Available:    m.Available,
+		Issue:        string(m.Issue),
+	}
+	if m.Product != nil {
+		out.ProductName = m.Product.Name
+		out.Images = m.Product.Images
+		out.CurrentPrice = m.Product.Price
+	}
+	return out
+}
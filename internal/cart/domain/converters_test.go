@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"goshop/internal/cart/model"
+	"goshop/pkg/money"
+)
+
+func TestCartFromModel(t *testing.T) {
+	assert.Nil(t, CartFromModel(nil))
+
+	c := CartFromModel(&model.Cart{
+		ID: "c1",
+		Items: []*model.CartItem{
+			{ProductID: "p1", Quantity: 2, UnitPrice: money.New(500, "USD"), Available: 10, Product: &model.Product{Name: "n1", Price: money.New(600, "USD")}},
+			{ProductID: "p2", Quantity: 1, UnitPrice: money.New(300, "USD")},
+		},
+	})
+	assert.Equal(t, "c1", c.ID)
+	assert.Len(t, c.Items, 2)
+	assert.Equal(t, money.New(1500, "USD"), c.TotalPrice)
+	assert.Equal(t, 3, c.ItemCount)
+	assert.True(t, c.Valid)
+	assert.Equal(t, "n1", c.Items[0].ProductName)
+	assert.Equal(t, money.New(600, "USD"), c.Items[0].CurrentPrice)
+	assert.Equal(t, money.New(300, "USD"), c.Items[1].CurrentPrice)
+}
+
+func TestCartFromModel_InvalidWhenAnyLineHasIssue(t *testing.T) {
+	c := CartFromModel(&model.Cart{
+		Items: []*model.CartItem{
+			{ProductID: "p1", Quantity: 1, Issue: model.ItemIssueInsufficientStock},
+		},
+	})
+	assert.False(t, c.Valid)
+	assert.Equal(t, "insufficient_stock", c.Items[0].Issue)
+}
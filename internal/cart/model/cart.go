@@ -0,0 +1,98 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"goshop/pkg/currency"
+	"goshop/pkg/money"
+)
+
+// Cart is the server-side shopping cart. A cart either belongs to a user (UserID set, at most
+// one per user) or is a guest cart addressed only by its ID, which the FE keeps in local
+// storage and sends back in the X-Cart-ID header until the shopper logs in and merges it.
+//
+// Version increases on every change to the cart's lines. RemindedVersion is the version the
+// last abandoned-cart reminder was sent for, so each version is reminded at most once.
+type Cart struct {
+	ID              string      `json:"id" gorm:"primary_key"`
+	CreatedAt       time.Time   `json:"created_at"`
+	UpdatedAt       time.Time   `json:"updated_at"`
+	UserID          *string     `json:"user_id" gorm:"uniqueIndex"`
+	User            *User       `json:"-"`
+	Version         int         `json:"version" gorm:"not null;default:0"`
+	RemindedVersion int         `json:"-" gorm:"not null;default:0"`
+	Items           []*CartItem `json:"items"`
+}
+
+func (c *Cart) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == "" {
+		c.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// IsGuest reports whether the cart has not been claimed by a user yet.
+func (c *Cart) IsGuest() bool {
+	return c.UserID == nil
+}
+
+// ItemCount is the total number of units across all lines.
+func (c *Cart) ItemCount() int {
+	count := 0
+	for _, item := range c.Items {
+		count += item.Quantity
+	}
+	return count
+}
+
+// ItemIssue describes why a cart line can't be checked out as-is. Computed on read against
+// the live product row; never persisted.
+type ItemIssue string
+
+const (
+	ItemIssueUnavailable       ItemIssue = "unavailable"
+	ItemIssueInsufficientStock ItemIssue = "insufficient_stock"
+	ItemIssuePriceChanged      ItemIssue = "price_changed"
+)
+
+// CartItem is one product line. UnitPrice is the price the shopper saw when the line was
+// added; it is re-validated against Product.Price on every read and at checkout.
+type CartItem struct {
+	ID        string      `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	CartID    string      `json:"cart_id" gorm:"uniqueIndex:idx_cart_item_cart_product;not null"`
+	ProductID string      `json:"product_id" gorm:"uniqueIndex:idx_cart_item_cart_product;not null"`
+	Product   *Product    `json:"product,omitempty"`
+	Quantity  int         `json:"quantity" gorm:"not null"`
+	UnitPrice money.Money `json:"unit_price" gorm:"column:unit_price_minor"`
+	Currency  string      `json:"currency" gorm:"size:3;not null;default:USD"`
+
+	Issue     ItemIssue `json:"issue,omitempty" gorm:"-"`
+	Available int       `json:"available" gorm:"-"`
+}
+
+func (i *CartItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == "" {
+		i.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// BeforeSave keeps Currency in step with UnitPrice, which is re-priced on refresh.
+func (i *CartItem) BeforeSave(tx *gorm.DB) error {
+	i.Currency = i.UnitPrice.Currency()
+	if i.Currency == "" {
+		i.Currency = currency.Default
+	}
+	return nil
+}
+
+// AfterFind applies the line's currency to its unit price.
+func (i *CartItem) AfterFind(tx *gorm.DB) error {
+	i.UnitPrice = i.UnitPrice.WithCurrency(i.Currency)
+	return nil
+}
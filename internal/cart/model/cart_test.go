@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCart_BeforeCreate(t *testing.T) {
+	c := &Cart{}
+	assert.NoError(t, c.BeforeCreate(nil))
+	assert.NotEmpty(t, c.ID)
+
+	c = &Cart{ID: "fixed"}
+	assert.NoError(t, c.BeforeCreate(nil))
+	assert.Equal(t, "fixed", c.ID)
+}
+
+func TestCart_IsGuest(t *testing.T) {
+	uid := "u1"
+	assert.True(t, (&Cart{}).IsGuest())
+	assert.False(t, (&Cart{UserID: &uid}).IsGuest())
+}
+
+func TestCartItem_BeforeCreate(t *testing.T) {
+	i := &CartItem{}
+	assert.NoError(t, i.BeforeCreate(nil))
+	assert.NotEmpty(t, i.ID)
+
+	i = &CartItem{ID: "fixed"}
+	assert.NoError(t, i.BeforeCreate(nil))
+	assert.Equal(t, "fixed", i.ID)
+}
+
+func TestProduct_Available(t *testing.T) {
+	p := &Product{StockQuantity: 10, ReservedQuantity: 3}
+	assert.Equal(t, 7, p.Available())
+}
@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"goshop/pkg/money"
+)
+
+// Product mirrors the columns of the canonical products table that the cart needs to price
+// lines and check availability without crossing into the product domain.
+type Product struct {
+	ID               string      `json:"id" gorm:"primary_key"`
+	DeletedAt        *time.Time  `json:"deleted_at" gorm:"index"`
+	Code             string      `json:"code"`
+	Name             string      `json:"name"`
+	Price            money.Money `json:"price" gorm:"column:price_minor"`
+	Currency         string      `json:"currency"`
+	Active           bool        `json:"active"`
+	StockQuantity    int         `json:"stock_quantity"`
+	ReservedQuantity int         `json:"reserved_quantity"`
+	Images           []string    `json:"images" gorm:"serializer:json"`
+}
+
+// AfterFind applies the row's currency to Price.
+func (p *Product) AfterFind(tx *gorm.DB) error {
+	p.Price = p.Price.WithCurrency(p.Currency)
+	return nil
+}
+
+// Available is the number of units that can still be reserved by a new order.
+func (p *Product) Available() int {
+	return p.StockQuantity - p.ReservedQuantity
+}
@@ -0,0 +1,12 @@
+package model
+
+import (
+	"time"
+)
+
+// User mirrors the columns of the users table the cart needs to address reminders.
+type User struct {
+	ID        string     `json:"id" gorm:"primary_key"`
+	DeletedAt *time.Time `json:"deleted_at" gorm:"index"`
+	Email     string     `json:"email"`
+}
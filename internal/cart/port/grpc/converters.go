@@ -0,0 +1,55 @@
+package grpc
+
+import (
+	"goshop/internal/cart/domain"
+	"goshop/internal/cart/model"
+	orderModel "goshop/internal/order/model"
+	"goshop/pkg/money"
+	pb "goshop/proto/gen/go/cart"
+	moneypb "goshop/proto/gen/go/money"
+)
+
+// cartInfoFromModel builds the gRPC CartInfo from the same DTO the HTTP port renders, so
+// totals and line issues are computed in one place.
+func cartInfoFromModel(m *model.Cart) *pb.CartInfo {
+	cart := domain.CartFromModel(m)
+	if cart == nil {
+		return nil
+	}
+	info := &pb.CartInfo{
+		Id:              cart.ID,
+		Items:           make([]*pb.CartItemInfo, len(cart.Items)),
+		TotalPrice:      float32(cart.TotalPrice.Float64()),
+		TotalPriceMoney: moneyToPB(cart.TotalPrice),
+		ItemCount:       uint32(cart.ItemCount), //nolint:gosec // sum of small positive quantities
+		Valid:           cart.Valid,
+	}
+	for i, it := range cart.Items {
+		info.Items[i] = &pb.CartItemInfo{
+			ProductId:         it.ProductID,
+			ProductName:       it.ProductName,
+			Quantity:          uint32(it.Quantity), //nolint:gosec // quantity is a small positive integer
+			UnitPrice:         float32(it.UnitPrice.Float64()),
+			UnitPriceMoney:    moneyToPB(it.UnitPrice),
+			CurrentPrice:      float32(it.CurrentPrice.Float64()),
+			CurrentPriceMoney: moneyToPB(it.CurrentPrice),
+			Available:         int64(it.Available),
+			Issue:             it.Issue,
+		}
+	}
+	return info
+}
+
+func checkoutResFromOrder(m *orderModel.Order) *pb.CheckoutCartRes {
+	return &pb.CheckoutCartRes{
+		OrderId:         m.ID,
+		OrderCode:       m.Code,
+		TotalPrice:      float32(m.FinalPrice.Float64()),
+		TotalPriceMoney: moneyToPB(m.FinalPrice),
+		Status:          string(m.Status),
+	}
+}
+
+func moneyToPB(m money.Money) *moneypb.Money {
+	return &moneypb.Money{Amount: m.Amount(), Currency: m.Currency()}
+}
@@ -0,0 +1,129 @@
+package grpc
+
+import (
+	"context"
+
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/cart/domain"
+	"goshop/internal/cart/service"
+	"goshop/pkg/apperror"
+	pb "goshop/proto/gen/go/cart"
+)
+
+// CartHandler serves the cart over gRPC. Every gRPC call is authenticated, so only user
+// carts are reachable here; guest carts live on the HTTP port until they are merged.
+type CartHandler struct {
+	pb.UnimplementedCartServiceServer
+
+	service service.CartService
+}
+
+func NewCartHandler(service service.CartService) *CartHandler {
+	return &CartHandler{
+		service: service,
+	}
+}
+
+func (h *CartHandler) GetCart(ctx context.Context, _ *pb.GetCartReq) (*pb.GetCartRes, error) {
+	userID, _ := ctx.Value("userId").(string)
+	if userID == "" {
+		return nil, apperror.ErrUnauthorized.GRPCStatus()
+	}
+
+	cart, err := h.service.GetCart(ctx, domain.CartOwner{UserID: userID})
+	if err != nil {
+		logger.Error("Failed to get cart ", err)
+		return nil, apperror.ToGRPCStatus(err)
+	}
+
+	return &pb.GetCartRes{Cart: cartInfoFromModel(cart)}, nil
+}
+
+func (h *CartHandler) AddItem(ctx context.Context, req *pb.AddItemReq) (*pb.AddItemRes, error) {
+	userID, _ := ctx.Value("userId").(string)
+	if userID == "" {
+		return nil, apperror.ErrUnauthorized.GRPCStatus()
+	}
+
+	cart, err := h.service.AddItem(ctx, domain.CartOwner{UserID: userID}, &domain.AddCartItemReq{
+		ProductID: req.ProductId,
+		Quantity:  int(req.Quantity),
+	})
+	if err != nil {
+		logger.Error("Failed to add cart item ", err)
+		return nil, apperror.ToGRPCStatus(err)
+	}
+
+	return &pb.AddItemRes{Cart: cartInfoFromModel(cart)}, nil
+}
+
+func (h *CartHandler) UpdateItem(ctx context.Context, req *pb.UpdateItemReq) (*pb.UpdateItemRes, error) {
+	userID, _ := ctx.Value("userId").(string)
+	if userID == "" {
+		return nil, apperror.ErrUnauthorized.GRPCStatus()
+	}
+
+	if req.ProductId == "" {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "Product ID is required").GRPCStatus()
+	}
+
+	cart, err := h.service.UpdateItem(ctx, domain.CartOwner{UserID: userID}, req.ProductId, &domain.UpdateCartItemReq{
+		Quantity: int(req.Quantity),
+	})
+	if err != nil {
+		logger.Error("Failed to update cart item ", err)
+		return nil, apperror.ToGRPCStatus(err)
+	}
+
+	return &pb.UpdateItemRes{Cart: cartInfoFromModel(cart)}, nil
+}
+
+func (h *CartHandler) RemoveItem(ctx context.Context, req *pb.RemoveItemReq) (*pb.RemoveItemRes, error) {
+	userID, _ := ctx.Value("userId").(string)
+	if userID == "" {
+		return nil, apperror.ErrUnauthorized.GRPCStatus()
+	}
+
+	if req.ProductId == "" {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "Product ID is required").GRPCStatus()
+	}
+
+	cart, err := h.service.RemoveItem(ctx, domain.CartOwner{UserID: userID}, req.ProductId)
+	if err != nil {
+		logger.Error("Failed to remove cart item ", err)
+		return nil, apperror.ToGRPCStatus(err)
+	}
+
+	return &pb.RemoveItemRes{Cart: cartInfoFromModel(cart)}, nil
+}
+
+func (h *CartHandler) MergeCart(ctx context.Context, req *pb.MergeCartReq) (*pb.MergeCartRes, error) {
+	userID, _ := ctx.Value("userId").(string)
+	if userID == "" {
+		return nil, apperror.ErrUnauthorized.GRPCStatus()
+	}
+
+	cart, err := h.service.MergeGuestCart(ctx, userID, &domain.MergeCartReq{GuestCartID: req.GuestCartId})
+	if err != nil {
+		logger.Error("Failed to merge cart ", err)
+		return nil, apperror.ToGRPCStatus(err)
+	}
+
+	return &pb.MergeCartRes{Cart: cartInfoFromModel(cart)}, nil
+}
+
+func (h *CartHandler) CheckoutCart(ctx context.Context, req *pb.CheckoutCartReq) (*pb.CheckoutCartRes, error) {
+	userID, _ := ctx.Value("userId").(string)
+	if userID == "" {
+		return nil, apperror.ErrUnauthorized.GRPCStatus()
+	}
+
+	order, err := h.service.Checkout(ctx, userID, &domain.CheckoutCartReq{CouponCode: req.CouponCode})
+	if err != nil {
+		logger.Error("Failed to checkout cart ", err)
+		return nil, apperror.ToGRPCStatus(err)
+	}
+
+	return checkoutResFromOrder(order), nil
+}
@@ -0,0 +1,181 @@
+package grpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"goshop/internal/cart/domain"
+	"goshop/internal/cart/model"
+	"goshop/internal/cart/service/mocks"
+	orderModel "goshop/internal/order/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/money"
+	pb "goshop/proto/gen/go/cart"
+)
+
+type CartHandlerTestSuite struct {
+	suite.Suite
+	mockService *mocks.CartService
+	handler     *CartHandler
+}
+
+func (suite *CartHandlerTestSuite) SetupTest() {
+	logger.Initialize(config.ProductionEnv)
+
+	suite.mockService = mocks.NewCartService(suite.T())
+	suite.handler = NewCartHandler(suite.mockService)
+}
+
+func TestCartHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(CartHandlerTestSuite))
+}
+
+func userCtx() context.Context {
+	return context.WithValue(context.Background(), "userId", "u1")
+}
+
+func testCart() *model.Cart {
+	return &model.Cart{ID: "c1", Items: []*model.CartItem{
+		{ProductID: "p1", Quantity: 2, UnitPrice: money.New(1000, "USD"), Available: 5, Product: &model.Product{ID: "p1", Name: "P1", Price: money.New(1000, "USD"), Active: true, StockQuantity: 5}},
+	}}
+}
+
+func (suite *CartHandlerTestSuite) TestGetCart() {
+	suite.mockService.On("GetCart", mock.Anything, domain.CartOwner{UserID: "u1"}).Return(testCart(), nil).Once()
+
+	res, err := suite.handler.GetCart(userCtx(), &pb.GetCartReq{})
+	suite.NoError(err)
+	suite.Equal("c1", res.Cart.Id)
+	suite.Equal(float32(20), res.Cart.TotalPrice)
+	suite.Equal(uint32(2), res.Cart.ItemCount)
+	suite.Equal("P1", res.Cart.Items[0].ProductName)
+	suite.Equal(int64(5), res.Cart.Items[0].Available)
+}
+
+func (suite *CartHandlerTestSuite) TestGetCart_Unauthorized() {
+	res, err := suite.handler.GetCart(context.Background(), &pb.GetCartReq{})
+	suite.Nil(res)
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (suite *CartHandlerTestSuite) TestGetCart_Fail() {
+	suite.mockService.On("GetCart", mock.Anything, mock.Anything).Return(nil, errors.New("boom")).Once()
+
+	res, err := suite.handler.GetCart(userCtx(), &pb.GetCartReq{})
+	suite.Nil(res)
+	suite.Equal(codes.Internal, status.Code(err))
+}
+
+func (suite *CartHandlerTestSuite) TestAddItem() {
+	suite.mockService.On("AddItem", mock.Anything, domain.CartOwner{UserID: "u1"}, &domain.AddCartItemReq{ProductID: "p1", Quantity: 2}).
+		Return(testCart(), nil).Once()
+
+	res, err := suite.handler.AddItem(userCtx(), &pb.AddItemReq{ProductId: "p1", Quantity: 2})
+	suite.NoError(err)
+	suite.Len(res.Cart.Items, 1)
+}
+
+func (suite *CartHandlerTestSuite) TestAddItem_Unauthorized() {
+	_, err := suite.handler.AddItem(context.Background(), &pb.AddItemReq{ProductId: "p1", Quantity: 2})
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+}
+
+func (suite *CartHandlerTestSuite) TestAddItem_NotFound() {
+	suite.mockService.On("AddItem", mock.Anything, mock.Anything, mock.Anything).Return(nil, apperror.ErrNotFound).Once()
+
+	_, err := suite.handler.AddItem(userCtx(), &pb.AddItemReq{ProductId: "p1", Quantity: 2})
+	suite.Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *CartHandlerTestSuite) TestUpdateItem() {
+	suite.mockService.On("UpdateItem", mock.Anything, domain.CartOwner{UserID: "u1"}, "p1", &domain.UpdateCartItemReq{Quantity: 3}).
+		Return(testCart(), nil).Once()
+
+	res, err := suite.handler.UpdateItem(userCtx(), &pb.UpdateItemReq{ProductId: "p1", Quantity: 3})
+	suite.NoError(err)
+	suite.Equal("c1", res.Cart.Id)
+}
+
+func (suite *CartHandlerTestSuite) TestUpdateItem_Validation() {
+	_, err := suite.handler.UpdateItem(context.Background(), &pb.UpdateItemReq{ProductId: "p1"})
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+
+	_, err = suite.handler.UpdateItem(userCtx(), &pb.UpdateItemReq{})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *CartHandlerTestSuite) TestUpdateItem_Fail() {
+	suite.mockService.On("UpdateItem", mock.Anything, mock.Anything, "p1", mock.Anything).Return(nil, errors.New("boom")).Once()
+
+	_, err := suite.handler.UpdateItem(userCtx(), &pb.UpdateItemReq{ProductId: "p1", Quantity: 3})
+	suite.Equal(codes.Internal, status.Code(err))
+}
+
+func (suite *CartHandlerTestSuite) TestRemoveItem() {
+	suite.mockService.On("RemoveItem", mock.Anything, domain.CartOwner{UserID: "u1"}, "p1").Return(&model.Cart{ID: "c1"}, nil).Once()
+
+	res, err := suite.handler.RemoveItem(userCtx(), &pb.RemoveItemReq{ProductId: "p1"})
+	suite.NoError(err)
+	suite.Empty(res.Cart.Items)
+}
+
+func (suite *CartHandlerTestSuite) TestRemoveItem_Validation() {
+	_, err := suite.handler.RemoveItem(context.Background(), &pb.RemoveItemReq{ProductId: "p1"})
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+
+	_, err = suite.handler.RemoveItem(userCtx(), &pb.RemoveItemReq{})
+	suite.Equal(codes.InvalidArgument, status.Code(err))
+}
+
+func (suite *CartHandlerTestSuite) TestRemoveItem_Fail() {
+	suite.mockService.On("RemoveItem", mock.Anything, mock.Anything, "p1").Return(nil, apperror.ErrNotFound).Once()
+
+	_, err := suite.handler.RemoveItem(userCtx(), &pb.RemoveItemReq{ProductId: "p1"})
+	suite.Equal(codes.NotFound, status.Code(err))
+}
+
+func (suite *CartHandlerTestSuite) TestMergeCart() {
+	suite.mockService.On("MergeGuestCart", mock.Anything, "u1", &domain.MergeCartReq{GuestCartID: "g1"}).Return(testCart(), nil).Once()
+
+	res, err := suite.handler.MergeCart(userCtx(), &pb.MergeCartReq{GuestCartId: "g1"})
+	suite.NoError(err)
+	suite.Equal("c1", res.Cart.Id)
+}
+
+func (suite *CartHandlerTestSuite) TestMergeCart_Fail() {
+	_, err := suite.handler.MergeCart(context.Background(), &pb.MergeCartReq{GuestCartId: "g1"})
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+
+	suite.mockService.On("MergeGuestCart", mock.Anything, "u1", mock.Anything).Return(nil, apperror.ErrForbidden).Once()
+	_, err = suite.handler.MergeCart(userCtx(), &pb.MergeCartReq{GuestCartId: "g1"})
+	suite.Equal(codes.PermissionDenied, status.Code(err))
+}
+
+func (suite *CartHandlerTestSuite) TestCheckoutCart() {
+	suite.mockService.On("Checkout", mock.Anything, "u1", &domain.CheckoutCartReq{CouponCode: "SAVE10"}).
+		Return(&orderModel.Order{ID: "o1", Code: "SO-1", FinalPrice: money.New(1800, "USD"), Status: orderModel.OrderStatusPendingPayment}, nil).Once()
+
+	res, err := suite.handler.CheckoutCart(userCtx(), &pb.CheckoutCartReq{CouponCode: "SAVE10"})
+	suite.NoError(err)
+	suite.Equal("o1", res.OrderId)
+	suite.Equal("SO-1", res.OrderCode)
+	suite.Equal(float32(18), res.TotalPrice)
+	suite.Equal(string(orderModel.OrderStatusPendingPayment), res.Status)
+}
+
+func (suite *CartHandlerTestSuite) TestCheckoutCart_Fail() {
+	_, err := suite.handler.CheckoutCart(context.Background(), &pb.CheckoutCartReq{})
+	suite.Equal(codes.Unauthenticated, status.Code(err))
+
+	suite.mockService.On("Checkout", mock.Anything, "u1", mock.Anything).Return(nil, apperror.ErrCartEmpty).Once()
+	_, err = suite.handler.CheckoutCart(userCtx(), &pb.CheckoutCartReq{})
+	suite.Equal(codes.FailedPrecondition, status.Code(err))
+}
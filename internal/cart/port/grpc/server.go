@@ -0,0 +1,54 @@
+package grpc
+
+import (
+	"github.com/quangdangfit/gocommon/validation"
+	"google.golang.org/grpc"
+
+	"goshop/internal/cart/repository"
+	"goshop/internal/cart/service"
+	inventoryRepository "goshop/internal/inventory/repository"
+	orderRepository "goshop/internal/order/repository"
+	orderService "goshop/internal/order/service"
+	outboxRepository "goshop/internal/outbox/repository"
+	paymentService "goshop/internal/payment/service"
+	"goshop/pkg/config"
+	"goshop/pkg/currency"
+	"goshop/pkg/dbs"
+	"goshop/pkg/shipping"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
+	pb "goshop/proto/gen/go/cart"
+)
+
+func RegisterHandlers(svr *grpc.Server, db dbs.Database, validator validation.Validation) {
+	rates := currency.MustParseRates(config.GetConfig().BaseCurrency, config.GetConfig().ExchangeRates)
+	shippingSvc := orderService.NewShippingService(validator, orderRepository.NewShippingRateRepository(db), rates,
+		shipping.MustParseMethods(config.GetConfig().BaseCurrency, config.GetConfig().ShippingMethods))
+	orderSvc := orderService.NewOrderService(
+		validator, db,
+		orderRepository.NewOrderRepository(db),
+		orderRepository.NewProductRepository(db),
+		orderRepository.NewUserRepository(db),
+		orderRepository.NewReservationRepository(db),
+		orderService.NewCouponService(validator, orderRepository.NewCouponRepository(db), rates),
+		outboxRepository.NewOutboxRepository(db),
+		inventoryRepository.NewLedgerRepository(db),
+		orderRepository.NewWarehouseRepository(db),
+		stock.AllocationStrategy(config.GetConfig().WarehouseAllocation),
+		rates,
+		shippingSvc,
+		orderService.NewTaxService(validator, orderRepository.NewTaxRateRepository(db), tax.Mode(config.GetConfig().TaxMode)),
+		orderService.NewInvoices(config.GetConfig(), db),
+		paymentService.NewSettler(config.GetConfig(), db),
+	)
+
+	cartSvc := service.NewCartService(
+		validator, db,
+		repository.NewCartRepository(db),
+		repository.NewProductRepository(db),
+		orderSvc,
+	)
+	cartHandler := NewCartHandler(cartSvc)
+
+	pb.RegisterCartServiceServer(svr, cartHandler)
+}
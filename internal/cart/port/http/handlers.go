@@ -0,0 +1,277 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/cart/domain"
+	"goshop/internal/cart/service"
+	orderDomain "goshop/internal/order/domain"
+	orderService "goshop/internal/order/service"
+	"goshop/pkg/apperror"
+	"goshop/pkg/response"
+)
+
+// CartIDHeader carries a guest cart's ID. The FE stores the ID returned by the first
+// add-to-cart and sends it back on every cart call until the shopper logs in and merges.
+const CartIDHeader = "X-Cart-ID"
+
+// maxSnapshotItems caps PUT /me/cart-snapshot so a noisy/bot client can't rewrite a cart
+// with an unbounded number of lines.
+const maxSnapshotItems = 100
+
+type CartHandler struct {
+	service service.CartService
+}
+
+func NewCartHandler(service service.CartService) *CartHandler {
+	return &CartHandler{
+		service: service,
+	}
+}
+
+func cartOwner(c *gin.Context) domain.CartOwner {
+	return domain.CartOwner{
+		UserID:      c.GetString("userId"),
+		GuestCartID: c.GetHeader(CartIDHeader),
+	}
+}
+
+// GetCart godoc
+//
+//	@Summary	get current cart
+//	@Tags		cart
+//	@Produce	json
+//	@Param		X-Cart-ID	header		string	false	"Guest cart ID"
+//	@Success	200			{object}	domain.Cart
+//	@Router		/api/v1/cart [get]
+func (h *CartHandler) GetCart(c *gin.Context) {
+	cart, err := h.service.GetCart(c, cartOwner(c))
+	if err != nil {
+		logger.Error("Failed to get cart: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.CartFromModel(cart))
+}
+
+// AddItem godoc
+//
+//	@Summary	add product to cart
+//	@Tags		cart
+//	@Produce	json
+//	@Param		X-Cart-ID	header		string					false	"Guest cart ID"
+//	@Param		_			body		domain.AddCartItemReq	true	"Body"
+//	@Success	200			{object}	domain.Cart
+//	@Router		/api/v1/cart/items [post]
+func (h *CartHandler) AddItem(c *gin.Context) {
+	var req domain.AddCartItemReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to get body", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	cart, err := h.service.AddItem(c, cartOwner(c), &req)
+	if err != nil {
+		logger.Error("Failed to add cart item: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.CartFromModel(cart))
+}
+
+// UpdateItem godoc
+//
+//	@Summary	set cart line quantity (0 removes the line)
+//	@Tags		cart
+//	@Produce	json
+//	@Param		X-Cart-ID	header		string						false	"Guest cart ID"
+//	@Param		productId	path		string						true	"Product ID"
+//	@Param		_			body		domain.UpdateCartItemReq	true	"Body"
+//	@Success	200			{object}	domain.Cart
+//	@Router		/api/v1/cart/items/{productId} [put]
+func (h *CartHandler) UpdateItem(c *gin.Context) {
+	productID := c.Param("productId")
+	if productID == "" {
+		apperror.WrapMessage(apperror.ErrBadRequest, nil, "Missing product ID").HTTPError(c)
+		return
+	}
+
+	var req domain.UpdateCartItemReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to get body", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	cart, err := h.service.UpdateItem(c, cartOwner(c), productID, &req)
+	if err != nil {
+		logger.Error("Failed to update cart item: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.CartFromModel(cart))
+}
+
+// RemoveItem godoc
+//
+//	@Summary	remove product from cart
+//	@Tags		cart
+//	@Produce	json
+//	@Param		X-Cart-ID	header		string	false	"Guest cart ID"
+//	@Param		productId	path		string	true	"Product ID"
+//	@Success	200			{object}	domain.Cart
+//	@Router		/api/v1/cart/items/{productId} [delete]
+func (h *CartHandler) RemoveItem(c *gin.Context) {
+	productID := c.Param("productId")
+	if productID == "" {
+		apperror.WrapMessage(apperror.ErrBadRequest, nil, "Missing product ID").HTTPError(c)
+		return
+	}
+
+	cart, err := h.service.RemoveItem(c, cartOwner(c), productID)
+	if err != nil {
+		logger.Error("Failed to remove cart item: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.CartFromModel(cart))
+}
+
+// MergeCart godoc
+//
+//	@Summary	merge a guest cart into the current user's cart
+//	@Tags		cart
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	body		domain.MergeCartReq	true	"Body"
+//	@Success	200	{object}	domain.Cart
+//	@Router		/api/v1/cart/merge [post]
+func (h *CartHandler) MergeCart(c *gin.Context) {
+	userID := c.GetString("userId")
+	if userID == "" {
+		apperror.ErrUnauthorized.HTTPError(c)
+		return
+	}
+
+	var req domain.MergeCartReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to get body", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	cart, err := h.service.MergeGuestCart(c, userID, &req)
+	if err != nil {
+		logger.Error("Failed to merge cart: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.CartFromModel(cart))
+}
+
+// Checkout godoc
+//
+//	@Summary	place an order from the current cart
+//	@Tags		cart
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	body		domain.CheckoutCartReq	false	"Body"
+//	@Success	200	{object}	orderDomain.Order
+//	@Router		/api/v1/cart/checkout [post]
+func (h *CartHandler) Checkout(c *gin.Context) {
+	userID := c.GetString("userId")
+	if userID == "" {
+		apperror.ErrUnauthorized.HTTPError(c)
+		return
+	}
+
+	// The body is optional: it only carries a coupon code and payment method.
+	var req domain.CheckoutCartReq
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		logger.Error("Failed to get body", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	order, err := h.service.Checkout(c, userID, &req)
+	if err != nil {
+		var cartErr *service.CartValidationError
+		if errors.As(err, &cartErr) {
+			response.JSON(c, http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "CART_INVALID",
+					"message": cartErr.Error(),
+					"details": gin.H{
+						"issues": cartErr.Issues,
+					},
+				},
+			})
+			return
+		}
+		var stockErr *orderService.InsufficientStockError
+		if errors.As(err, &stockErr) {
+			response.JSON(c, http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "INSUFFICIENT_STOCK",
+					"message": stockErr.Error(),
+					"details": gin.H{
+						"product_id": stockErr.ProductID,
+						"requested":  stockErr.Requested,
+					},
+				},
+			})
+			return
+		}
+		logger.Error("Failed to checkout cart: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, orderDomain.OrderFromModel(order))
+}
+
+// PutCartSnapshot godoc
+//
+//	@Summary	replace the current user's cart with a client-side copy
+//	@Tags		cart
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	body	domain.CartSnapshotReq	true	"Body"
+//	@Success	204
+//	@Router		/api/v1/me/cart-snapshot [put]
+func (h *CartHandler) PutCartSnapshot(c *gin.Context) {
+	userID := c.GetString("userId")
+	if userID == "" {
+		apperror.ErrUnauthorized.HTTPError(c)
+		return
+	}
+
+	var req domain.CartSnapshotReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+	if len(req.Items) > maxSnapshotItems {
+		apperror.ErrBadRequest.HTTPError(c)
+		return
+	}
+
+	if err := h.service.ReplaceItems(c, userID, &req); err != nil {
+		logger.Error("Failed to store cart snapshot: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusNoContent, gin.H{})
+}
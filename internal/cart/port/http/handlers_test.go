@@ -0,0 +1,358 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"goshop/internal/cart/domain"
+	"goshop/internal/cart/model"
+	"goshop/internal/cart/service"
+	srvMocks "goshop/internal/cart/service/mocks"
+	orderModel "goshop/internal/order/model"
+	orderService "goshop/internal/order/service"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/money"
+)
+
+type CartHandlerTestSuite struct {
+	suite.Suite
+	mockService *srvMocks.CartService
+	handler     *CartHandler
+}
+
+func (suite *CartHandlerTestSuite) SetupTest() {
+	logger.Initialize(config.ProductionEnv)
+	gin.SetMode(gin.TestMode)
+	suite.mockService = srvMocks.NewCartService(suite.T())
+	suite.handler = NewCartHandler(suite.mockService)
+}
+
+func TestCartHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(CartHandlerTestSuite))
+}
+
+func (suite *CartHandlerTestSuite) prepareContext(method, path string, body any) (*gin.Context, *httptest.ResponseRecorder) {
+	var buf []byte
+	switch v := body.(type) {
+	case nil:
+	case string:
+		buf = []byte(v)
+	default:
+		buf, _ = json.Marshal(v)
+	}
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, bytes.NewBuffer(buf))
+	return c, w
+}
+
+func testCart() *model.Cart {
+	return &model.Cart{ID: "c1", Items: []*model.CartItem{
+		{ProductID: "p1", Quantity: 2, UnitPrice: money.New(1000, "USD"), Product: &model.Product{ID: "p1", Name: "P1", Price: money.New(1000, "USD"), Active: true, StockQuantity: 5}},
+	}}
+}
+
+func decodeCart(w *httptest.ResponseRecorder) *domain.Cart {
+	var res struct {
+		Result *domain.Cart `json:"result"`
+	}
+	_ = json.Unmarshal(w.Body.Bytes(), &res)
+	return res.Result
+}
+
+// GetCart
+// =================================================================================================
+
+func (suite *CartHandlerTestSuite) TestGetCart_Guest() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/cart", nil)
+	c.Request.Header.Set(CartIDHeader, "c1")
+	suite.mockService.On("GetCart", mock.Anything, domain.CartOwner{GuestCartID: "c1"}).Return(testCart(), nil).Once()
+
+	suite.handler.GetCart(c)
+	suite.Equal(http.StatusOK, w.Code)
+	got := decodeCart(w)
+	suite.Equal("c1", got.ID)
+	suite.Equal(money.New(2000, "USD"), got.TotalPrice)
+	suite.Equal(2, got.ItemCount)
+	suite.True(got.Valid)
+}
+
+func (suite *CartHandlerTestSuite) TestGetCart_User() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/cart", nil)
+	c.Set("userId", "u1")
+	suite.mockService.On("GetCart", mock.Anything, domain.CartOwner{UserID: "u1"}).Return(testCart(), nil).Once()
+
+	suite.handler.GetCart(c)
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestGetCart_Fail() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/cart", nil)
+	suite.mockService.On("GetCart", mock.Anything, mock.Anything).Return(nil, errors.New("boom")).Once()
+
+	suite.handler.GetCart(c)
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
+
+// AddItem
+// =================================================================================================
+
+func (suite *CartHandlerTestSuite) TestAddItem_Success() {
+	req := &domain.AddCartItemReq{ProductID: "p1", Quantity: 2}
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/items", req)
+	suite.mockService.On("AddItem", mock.Anything, domain.CartOwner{}, req).Return(testCart(), nil).Once()
+
+	suite.handler.AddItem(c)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Equal("c1", decodeCart(w).ID)
+}
+
+func (suite *CartHandlerTestSuite) TestAddItem_InvalidBody() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/items", "not-json")
+
+	suite.handler.AddItem(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestAddItem_NotFound() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/items", &domain.AddCartItemReq{ProductID: "p1", Quantity: 1})
+	suite.mockService.On("AddItem", mock.Anything, mock.Anything, mock.Anything).Return(nil, apperror.ErrNotFound).Once()
+
+	suite.handler.AddItem(c)
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+// UpdateItem / RemoveItem
+// =================================================================================================
+
+func (suite *CartHandlerTestSuite) TestUpdateItem_Success() {
+	req := &domain.UpdateCartItemReq{Quantity: 3}
+	c, w := suite.prepareContext(http.MethodPut, "/api/v1/cart/items/p1", req)
+	c.Params = gin.Params{{Key: "productId", Value: "p1"}}
+	c.Set("userId", "u1")
+	suite.mockService.On("UpdateItem", mock.Anything, domain.CartOwner{UserID: "u1"}, "p1", req).Return(testCart(), nil).Once()
+
+	suite.handler.UpdateItem(c)
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestUpdateItem_MissingProductID() {
+	c, w := suite.prepareContext(http.MethodPut, "/api/v1/cart/items/", &domain.UpdateCartItemReq{Quantity: 3})
+
+	suite.handler.UpdateItem(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestUpdateItem_InvalidBody() {
+	c, w := suite.prepareContext(http.MethodPut, "/api/v1/cart/items/p1", "not-json")
+	c.Params = gin.Params{{Key: "productId", Value: "p1"}}
+
+	suite.handler.UpdateItem(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestUpdateItem_Fail() {
+	c, w := suite.prepareContext(http.MethodPut, "/api/v1/cart/items/p1", &domain.UpdateCartItemReq{Quantity: 3})
+	c.Params = gin.Params{{Key: "productId", Value: "p1"}}
+	suite.mockService.On("UpdateItem", mock.Anything, mock.Anything, "p1", mock.Anything).Return(nil, errors.New("boom")).Once()
+
+	suite.handler.UpdateItem(c)
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestRemoveItem_Success() {
+	c, w := suite.prepareContext(http.MethodDelete, "/api/v1/cart/items/p1", nil)
+	c.Params = gin.Params{{Key: "productId", Value: "p1"}}
+	suite.mockService.On("RemoveItem", mock.Anything, domain.CartOwner{}, "p1").Return(&model.Cart{ID: "c1"}, nil).Once()
+
+	suite.handler.RemoveItem(c)
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestRemoveItem_MissingProductID() {
+	c, w := suite.prepareContext(http.MethodDelete, "/api/v1/cart/items/", nil)
+
+	suite.handler.RemoveItem(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestRemoveItem_NotFound() {
+	c, w := suite.prepareContext(http.MethodDelete, "/api/v1/cart/items/p1", nil)
+	c.Params = gin.Params{{Key: "productId", Value: "p1"}}
+	suite.mockService.On("RemoveItem", mock.Anything, mock.Anything, "p1").Return(nil, apperror.ErrNotFound).Once()
+
+	suite.handler.RemoveItem(c)
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+// MergeCart
+// =================================================================================================
+
+func (suite *CartHandlerTestSuite) TestMergeCart_Success() {
+	req := &domain.MergeCartReq{GuestCartID: "g1"}
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/merge", req)
+	c.Set("userId", "u1")
+	suite.mockService.On("MergeGuestCart", mock.Anything, "u1", req).Return(testCart(), nil).Once()
+
+	suite.handler.MergeCart(c)
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestMergeCart_Unauthorized() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/merge", &domain.MergeCartReq{GuestCartID: "g1"})
+
+	suite.handler.MergeCart(c)
+	suite.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestMergeCart_InvalidBody() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/merge", "not-json")
+	c.Set("userId", "u1")
+
+	suite.handler.MergeCart(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestMergeCart_Forbidden() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/merge", &domain.MergeCartReq{GuestCartID: "c2"})
+	c.Set("userId", "u1")
+	suite.mockService.On("MergeGuestCart", mock.Anything, "u1", mock.Anything).Return(nil, apperror.ErrForbidden).Once()
+
+	suite.handler.MergeCart(c)
+	suite.Equal(http.StatusForbidden, w.Code)
+}
+
+// Checkout
+// =================================================================================================
+
+func (suite *CartHandlerTestSuite) TestCheckout_Success() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/checkout", &domain.CheckoutCartReq{CouponCode: "SAVE10"})
+	c.Set("userId", "u1")
+	suite.mockService.On("Checkout", mock.Anything, "u1", &domain.CheckoutCartReq{CouponCode: "SAVE10"}).
+		Return(&orderModel.Order{ID: "o1", Code: "O1"}, nil).Once()
+
+	suite.handler.Checkout(c)
+	suite.Equal(http.StatusOK, w.Code)
+	suite.Contains(w.Body.String(), `"id":"o1"`)
+}
+
+func (suite *CartHandlerTestSuite) TestCheckout_EmptyBody() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/checkout", nil)
+	c.Set("userId", "u1")
+	suite.mockService.On("Checkout", mock.Anything, "u1", &domain.CheckoutCartReq{}).
+		Return(&orderModel.Order{ID: "o1"}, nil).Once()
+
+	suite.handler.Checkout(c)
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestCheckout_Unauthorized() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/checkout", nil)
+
+	suite.handler.Checkout(c)
+	suite.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestCheckout_InvalidBody() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/checkout", "not-json")
+	c.Set("userId", "u1")
+
+	suite.handler.Checkout(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestCheckout_CartInvalid() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/checkout", nil)
+	c.Set("userId", "u1")
+	suite.mockService.On("Checkout", mock.Anything, "u1", mock.Anything).Return(nil, &service.CartValidationError{
+		Issues: []domain.CartIssue{{ProductID: "p1", Issue: "insufficient_stock", Requested: 3, Available: 1}},
+	}).Once()
+
+	suite.handler.Checkout(c)
+	suite.Equal(http.StatusConflict, w.Code)
+	body := w.Body.String()
+	suite.Contains(body, `"code":"CART_INVALID"`)
+	suite.Contains(body, `"product_id":"p1"`)
+}
+
+func (suite *CartHandlerTestSuite) TestCheckout_InsufficientStock() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/checkout", nil)
+	c.Set("userId", "u1")
+	suite.mockService.On("Checkout", mock.Anything, "u1", mock.Anything).
+		Return(nil, &orderService.InsufficientStockError{ProductID: "p1", Requested: 3}).Once()
+
+	suite.handler.Checkout(c)
+	suite.Equal(http.StatusConflict, w.Code)
+	suite.Contains(w.Body.String(), `"code":"INSUFFICIENT_STOCK"`)
+}
+
+func (suite *CartHandlerTestSuite) TestCheckout_EmptyCart() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/cart/checkout", nil)
+	c.Set("userId", "u1")
+	suite.mockService.On("Checkout", mock.Anything, "u1", mock.Anything).Return(nil, apperror.ErrCartEmpty).Once()
+
+	suite.handler.Checkout(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+// PutCartSnapshot
+// =================================================================================================
+
+func (suite *CartHandlerTestSuite) TestPutCartSnapshot_Unauthorized() {
+	c, w := suite.prepareContext(http.MethodPut, "/api/v1/me/cart-snapshot", map[string]any{"items": []any{}})
+
+	suite.handler.PutCartSnapshot(c)
+	suite.Equal(http.StatusUnauthorized, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestPutCartSnapshot_BadBody() {
+	c, w := suite.prepareContext(http.MethodPut, "/api/v1/me/cart-snapshot", "not-json")
+	c.Set("userId", "u1")
+
+	suite.handler.PutCartSnapshot(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestPutCartSnapshot_TooManyItems() {
+	items := make([]map[string]any, 101)
+	for i := range items {
+		items[i] = map[string]any{"product_id": "p", "quantity": 1}
+	}
+	c, w := suite.prepareContext(http.MethodPut, "/api/v1/me/cart-snapshot", map[string]any{"items": items})
+	c.Set("userId", "u1")
+
+	suite.handler.PutCartSnapshot(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestPutCartSnapshot_Success() {
+	c, w := suite.prepareContext(http.MethodPut, "/api/v1/me/cart-snapshot", nil)
+	c.Request = httptest.NewRequest(http.MethodPut, "/api/v1/me/cart-snapshot", strings.NewReader(`{"items":[{"product_id":"p1","quantity":2}]}`))
+	c.Set("userId", "u1")
+	suite.mockService.On("ReplaceItems", mock.Anything, "u1", &domain.CartSnapshotReq{
+		Items: []domain.CartSnapshotItem{{ProductID: "p1", Quantity: 2}},
+	}).Return(nil).Once()
+
+	suite.handler.PutCartSnapshot(c)
+	suite.Equal(http.StatusNoContent, w.Code)
+}
+
+func (suite *CartHandlerTestSuite) TestPutCartSnapshot_Fail() {
+	c, w := suite.prepareContext(http.MethodPut, "/api/v1/me/cart-snapshot", map[string]any{"items": []any{}})
+	c.Set("userId", "u1")
+	suite.mockService.On("ReplaceItems", mock.Anything, "u1", mock.Anything).Return(errors.New("boom")).Once()
+
+	suite.handler.PutCartSnapshot(c)
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/validation"
+
+	"goshop/internal/cart/repository"
+	"goshop/internal/cart/service"
+	inventoryRepository "goshop/internal/inventory/repository"
+	orderRepository "goshop/internal/order/repository"
+	orderService "goshop/internal/order/service"
+	outboxRepository "goshop/internal/outbox/repository"
+	paymentService "goshop/internal/payment/service"
+	"goshop/pkg/config"
+	"goshop/pkg/currency"
+	"goshop/pkg/dbs"
+	"goshop/pkg/middleware"
+	"goshop/pkg/shipping"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
+)
+
+// Routes wires the cart domain. Cart reads and line edits accept guests (identified by the
+// X-Cart-ID header) as well as logged-in users; merge, checkout and the snapshot upload
+// need a user.
+func Routes(r *gin.RouterGroup, db dbs.Database, validator validation.Validation) {
+	// Checkout places the order through the regular OrderService so reservations, coupons
+	// and the order-created event behave exactly as for POST /orders.
+	rates := currency.MustParseRates(config.GetConfig().BaseCurrency, config.GetConfig().ExchangeRates)
+	shippingSvc := orderService.NewShippingService(validator, orderRepository.NewShippingRateRepository(db), rates,
+		shipping.MustParseMethods(config.GetConfig().BaseCurrency, config.GetConfig().ShippingMethods))
+	orderSvc := orderService.NewOrderService(
+		validator, db,
+		orderRepository.NewOrderRepository(db),
+		orderRepository.NewProductRepository(db),
+		orderRepository.NewUserRepository(db),
+		orderRepository.NewReservationRepository(db),
+		orderService.NewCouponService(validator, orderRepository.NewCouponRepository(db), rates),
+		outboxRepository.NewOutboxRepository(db),
+		inventoryRepository.NewLedgerRepository(db),
+		orderRepository.NewWarehouseRepository(db),
+		stock.AllocationStrategy(config.GetConfig().WarehouseAllocation),
+		rates,
+		shippingSvc,
+		orderService.NewTaxService(validator, orderRepository.NewTaxRateRepository(db), tax.Mode(config.GetConfig().TaxMode)),
+		orderService.NewInvoices(config.GetConfig(), db),
+		paymentService.NewSettler(config.GetConfig(), db),
+	)
+
+	cartSvc := service.NewCartService(
+		validator, db,
+		repository.NewCartRepository(db),
+		repository.NewProductRepository(db),
+		orderSvc,
+	)
+	handler := NewCartHandler(cartSvc)
+
+	authMiddleware := middleware.JWTAuth()
+	optionalAuthMiddleware := middleware.OptionalJWTAuth()
+
+	cartRoute := r.Group("/cart")
+	{
+		cartRoute.GET("", optionalAuthMiddleware, handler.GetCart)
+		cartRoute.POST("/items", optionalAuthMiddleware, handler.AddItem)
+		cartRoute.PUT("/items/:productId", optionalAuthMiddleware, handler.UpdateItem)
+		cartRoute.DELETE("/items/:productId", optionalAuthMiddleware, handler.RemoveItem)
+		cartRoute.POST("/merge", authMiddleware, handler.MergeCart)
+		cartRoute.POST("/checkout", authMiddleware, handler.Checkout)
+	}
+
+	r.PUT("/me/cart-snapshot", authMiddleware, handler.PutCartSnapshot)
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"goshop/internal/cart/model"
+	"goshop/pkg/dbs"
+)
+
+//go:generate mockery --name=CartRepository
+type CartRepository interface {
+	GetByID(ctx context.Context, id string) (*model.Cart, error)
+	GetByUserID(ctx context.Context, userID string) (*model.Cart, error)
+	Create(ctx context.Context, cart *model.Cart) error
+	// Touch bumps the cart's updated_at and version so "last activity" reflects line edits,
+	// which are written to cart_items and would otherwise leave the parent row untouched.
+	Touch(ctx context.Context, cartID string) error
+	// SaveItem inserts the line when it has no ID yet, otherwise updates it in place.
+	SaveItem(ctx context.Context, item *model.CartItem) error
+	DeleteItem(ctx context.Context, cartID, productID string) error
+	ClearItems(ctx context.Context, cartID string) error
+	Delete(ctx context.Context, cartID string) error
+	// FindAbandoned lists non-empty user carts idle since before idleSince whose current
+	// version has not been reminded yet, oldest first.
+	FindAbandoned(ctx context.Context, idleSince time.Time, limit int) ([]*model.Cart, error)
+	// MarkReminded claims the reminder for the given cart version. It reports false when the
+	// version was already reminded or the cart has changed since it was read.
+	MarkReminded(ctx context.Context, cartID string, version int) (bool, error)
+}
+
+type cartRepo struct {
+	db dbs.Database
+}
+
+func NewCartRepository(db dbs.Database) CartRepository {
+	return &cartRepo{db: db}
+}
+
+func (r *cartRepo) GetByID(ctx context.Context, id string) (*model.Cart, error) {
+	var cart model.Cart
+	err := r.db.FindOne(ctx, &cart,
+		dbs.WithQuery(dbs.NewQuery("id = ?", id)),
+		dbs.WithPreload([]string{"Items", "Items.Product"}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+
+func (r *cartRepo) GetByUserID(ctx context.Context, userID string) (*model.Cart, error) {
+	var cart model.Cart
+	err := r.db.FindOne(ctx, &cart,
+		dbs.WithQuery(dbs.NewQuery("user_id = ?", userID)),
+		dbs.WithPreload([]string{"Items", "Items.Product"}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+
+func (r *cartRepo) Create(ctx context.Context, cart *model.Cart) error {
+	return r.db.Create(ctx, cart)
+}
+
+func (r *cartRepo) Touch(ctx context.Context, cartID string) error {
+	return r.db.GetDB().WithContext(ctx).
+		Model(&model.Cart{}).
+		Where("id = ?", cartID).
+		UpdateColumns(map[string]any{
+			"updated_at": time.Now(),
+			"version":    gorm.Expr("version + 1"),
+		}).Error
+}
+
+// SaveItem never writes through the Product association: the cart only holds a read-only
+// projection of the products table.
+func (r *cartRepo) SaveItem(ctx context.Context, item *model.CartItem) error {
+	tx := r.db.GetDB().WithContext(ctx).Omit("Product")
+	if item.ID == "" {
+		return tx.Create(item).Error
+	}
+	return tx.Save(item).Error
+}
+
+func (r *cartRepo) DeleteItem(ctx context.Context, cartID, productID string) error {
+	return r.db.Delete(ctx, &model.CartItem{},
+		dbs.WithQuery(dbs.NewQuery("cart_id = ? AND product_id = ?", cartID, productID)),
+	)
+}
+
+func (r *cartRepo) ClearItems(ctx context.Context, cartID string) error {
+	return r.db.Delete(ctx, &model.CartItem{},
+		dbs.WithQuery(dbs.NewQuery("cart_id = ?", cartID)),
+	)
+}
+
+func (r *cartRepo) Delete(ctx context.Context, cartID string) error {
+	return r.db.Delete(ctx, &model.Cart{},
+		dbs.WithQuery(dbs.NewQuery("id = ?", cartID)),
+	)
+}
+
+func (r *cartRepo) FindAbandoned(ctx context.Context, idleSince time.Time, limit int) ([]*model.Cart, error) {
+	var carts []*model.Cart
+	err := r.db.Find(ctx, &carts,
+		dbs.WithQuery(
+			dbs.NewQuery("user_id IS NOT NULL"),
+			dbs.NewQuery("updated_at < ?", idleSince),
+			dbs.NewQuery("reminded_version < version"),
+			dbs.NewQuery("EXISTS (SELECT 1 FROM cart_items WHERE cart_items.cart_id = carts.id)"),
+		),
+		dbs.WithOrder("updated_at"),
+		dbs.WithLimit(limit),
+		dbs.WithPreload([]string{"User", "Items"}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return carts, nil
+}
+
+func (r *cartRepo) MarkReminded(ctx context.Context, cartID string, version int) (bool, error) {
+	res := r.db.GetDB().WithContext(ctx).
+		Model(&model.Cart{}).
+		Where("id = ? AND version = ? AND reminded_version < ?", cartID, version, version).
+		UpdateColumn("reminded_version", version)
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
@@ -0,0 +1,167 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"goshop/internal/cart/model"
+	dbsMocks "goshop/pkg/dbs/mocks"
+)
+
+func newCartSQLMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, m, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	g, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return g, m
+}
+
+func TestCartRepo_GetByID(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.Cart{}, mock.Anything, mock.Anything).Return(nil).Once()
+	got, err := NewCartRepository(dbm).GetByID(context.Background(), "c1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+}
+
+func TestCartRepo_GetByID_Error(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.Cart{}, mock.Anything, mock.Anything).Return(errors.New("not found")).Once()
+	got, err := NewCartRepository(dbm).GetByID(context.Background(), "c1")
+	require.Error(t, err)
+	require.Nil(t, got)
+}
+
+func TestCartRepo_GetByUserID(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.Cart{}, mock.Anything, mock.Anything).Return(nil).Once()
+	got, err := NewCartRepository(dbm).GetByUserID(context.Background(), "u1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+}
+
+func TestCartRepo_GetByUserID_Error(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.Cart{}, mock.Anything, mock.Anything).Return(errors.New("boom")).Once()
+	_, err := NewCartRepository(dbm).GetByUserID(context.Background(), "u1")
+	require.Error(t, err)
+}
+
+func TestCartRepo_Create(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	require.NoError(t, NewCartRepository(dbm).Create(context.Background(), &model.Cart{}))
+}
+
+func TestCartRepo_Touch(t *testing.T) {
+	g, m := newCartSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	m.ExpectExec(regexp.QuoteMeta(`UPDATE "carts" SET "updated_at"=$1,"version"=version + 1 WHERE id = $2`)).
+		WithArgs(sqlmock.AnyArg(), "c1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	require.NoError(t, NewCartRepository(dbm).Touch(context.Background(), "c1"))
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestCartRepo_SaveItem_Insert(t *testing.T) {
+	g, m := newCartSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	m.ExpectExec(regexp.QuoteMeta(`INSERT INTO "cart_items"`)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	item := &model.CartItem{CartID: "c1", ProductID: "p1", Quantity: 1, Product: &model.Product{ID: "p1"}}
+	require.NoError(t, NewCartRepository(dbm).SaveItem(context.Background(), item))
+	require.NotEmpty(t, item.ID)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestCartRepo_SaveItem_Update(t *testing.T) {
+	g, m := newCartSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	m.ExpectExec(regexp.QuoteMeta(`UPDATE "cart_items" SET`)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	item := &model.CartItem{ID: "i1", CartID: "c1", ProductID: "p1", Quantity: 3, Product: &model.Product{ID: "p1"}}
+	require.NoError(t, NewCartRepository(dbm).SaveItem(context.Background(), item))
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestCartRepo_Deletes(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(3)
+	repo := NewCartRepository(dbm)
+	require.NoError(t, repo.DeleteItem(context.Background(), "c1", "p1"))
+	require.NoError(t, repo.ClearItems(context.Background(), "c1"))
+	require.NoError(t, repo.Delete(context.Background(), "c1"))
+}
+
+func TestCartRepo_FindAbandoned(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Find", mock.Anything, mock.AnythingOfType("*[]*model.Cart"), mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+	_, err := NewCartRepository(dbm).FindAbandoned(context.Background(), time.Now(), 10)
+	require.NoError(t, err)
+}
+
+func TestCartRepo_FindAbandoned_Error(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("boom")).Once()
+	got, err := NewCartRepository(dbm).FindAbandoned(context.Background(), time.Now(), 10)
+	require.Error(t, err)
+	require.Nil(t, got)
+}
+
+func TestCartRepo_MarkReminded(t *testing.T) {
+	g, m := newCartSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	query := regexp.QuoteMeta(`UPDATE "carts" SET "reminded_version"=$1 WHERE id = $2 AND version = $3 AND reminded_version < $4`)
+	m.ExpectExec(query).WithArgs(3, "c1", 3, 3).WillReturnResult(sqlmock.NewResult(0, 1))
+	m.ExpectExec(query).WithArgs(3, "c1", 3, 3).WillReturnResult(sqlmock.NewResult(0, 0))
+	m.ExpectExec(query).WithArgs(3, "c1", 3, 3).WillReturnError(errors.New("boom"))
+
+	repo := NewCartRepository(dbm)
+	claimed, err := repo.MarkReminded(context.Background(), "c1", 3)
+	require.NoError(t, err)
+	require.True(t, claimed)
+
+	claimed, err = repo.MarkReminded(context.Background(), "c1", 3)
+	require.NoError(t, err)
+	require.False(t, claimed)
+
+	_, err = repo.MarkReminded(context.Background(), "c1", 3)
+	require.Error(t, err)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestProductRepo_GetProductByID(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindById", mock.Anything, "p1", &model.Product{}).Return(nil).Once()
+	dbm.On("FindById", mock.Anything, "missing", &model.Product{}).Return(errors.New("not found")).Once()
+	repo := NewProductRepository(dbm)
+
+	got, err := repo.GetProductByID(context.Background(), "p1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+
+	got, err = repo.GetProductByID(context.Background(), "missing")
+	require.Error(t, err)
+	require.Nil(t, got)
+}
@@ -0,0 +1,407 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/quangdangfit/gocommon/validation"
+	"gorm.io/gorm"
+
+	"goshop/internal/cart/domain"
+	"goshop/internal/cart/model"
+	cartRepo "goshop/internal/cart/repository"
+	orderDomain "goshop/internal/order/domain"
+	orderModel "goshop/internal/order/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/dbs"
+)
+
+// OrderPlacer is the slice of the order service the cart needs at checkout. Declared here so
+// the cart domain doesn't depend on the order service package.
+//
+//go:generate mockery --name=OrderPlacer
+type OrderPlacer interface {
+	PlaceOrder(ctx context.Context, req *orderDomain.PlaceOrderReq) (*orderModel.Order, error)
+}
+
+//go:generate mockery --name=CartService
+type CartService interface {
+	// GetCart returns the owner's cart with every line re-validated against the live product.
+	// Owners without a cart yet get an empty, unsaved one.
+	GetCart(ctx context.Context, owner domain.CartOwner) (*model.Cart, error)
+	// AddItem adds qty units of a product, creating the cart on first use. Adding a product
+	// already in the cart increases the existing line.
+	AddItem(ctx context.Context, owner domain.CartOwner, req *domain.AddCartItemReq) (*model.Cart, error)
+	// UpdateItem sets a line's quantity; a quantity of zero removes the line.
+	UpdateItem(ctx context.Context, owner domain.CartOwner, productID string, req *domain.UpdateCartItemReq) (*model.Cart, error)
+	RemoveItem(ctx context.Context, owner domain.CartOwner, productID string) (*model.Cart, error)
+	// ReplaceItems overwrites a user's cart with the given snapshot.
+	ReplaceItems(ctx context.Context, userID string, req *domain.CartSnapshotReq) error
+	// MergeGuestCart folds a guest cart into the user's cart after login and deletes it.
+	// Quantities of products present in both carts are summed.
+	MergeGuestCart(ctx context.Context, userID string, req *domain.MergeCartReq) (*model.Cart, error)
+	// Checkout re-validates the cart and places an order from it. Any line issue rejects the
+	// checkout with a *CartValidationError; on success the cart is emptied in the same
+	// transaction, so an order is never left behind a full cart.
+	Checkout(ctx context.Context, userID string, req *domain.CheckoutCartReq) (*orderModel.Order, error)
+}
+
+type cartService struct {
+	validator   validation.Validation
+	db          dbs.Database
+	repo        cartRepo.CartRepository
+	productRepo cartRepo.ProductRepository
+	orders      OrderPlacer
+}
+
+func NewCartService(
+	validator validation.Validation,
+	db dbs.Database,
+	repo cartRepo.CartRepository,
+	productRepo cartRepo.ProductRepository,
+	orders OrderPlacer,
+) CartService {
+	return &cartService{
+		validator:   validator,
+		db:          db,
+		repo:        repo,
+		productRepo: productRepo,
+		orders:      orders,
+	}
+}
+
+func (s *cartService) GetCart(ctx context.Context, owner domain.CartOwner) (*model.Cart, error) {
+	cart, err := s.resolve(ctx, owner, false)
+	if err != nil {
+		return nil, err
+	}
+	validateItems(cart)
+	return cart, nil
+}
+
+func (s *cartService) AddItem(ctx context.Context, owner domain.CartOwner, req *domain.AddCartItemReq) (*model.Cart, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	product, err := s.productRepo.GetProductByID(ctx, req.ProductID)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+		return nil, err
+	}
+	// Inactive and deleted products are hidden from the catalog; treat them as missing.
+	if !product.Active || product.DeletedAt != nil {
+		return nil, apperror.ErrNotFound
+	}
+
+	cart, err := s.resolve(ctx, owner, true)
+	if err != nil {
+		return nil, err
+	}
+
+	item := findItem(cart, req.ProductID)
+	if item == nil {
+		item = &model.CartItem{CartID: cart.ID, ProductID: req.ProductID}
+		cart.Items = append(cart.Items, item)
+	}
+	item.Quantity += req.Quantity
+	item.UnitPrice = product.Price
+	item.Product = product
+	if err := s.repo.SaveItem(ctx, item); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Touch(ctx, cart.ID); err != nil {
+		return nil, err
+	}
+
+	validateItems(cart)
+	return cart, nil
+}
+
+func (s *cartService) UpdateItem(ctx context.Context, owner domain.CartOwner, productID string, req *domain.UpdateCartItemReq) (*model.Cart, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if req.Quantity == 0 {
+		return s.RemoveItem(ctx, owner, productID)
+	}
+
+	cart, err := s.resolve(ctx, owner, false)
+	if err != nil {
+		return nil, err
+	}
+	item := findItem(cart, productID)
+	if item == nil {
+		return nil, apperror.ErrNotFound
+	}
+
+	item.Quantity = req.Quantity
+	if err := s.repo.SaveItem(ctx, item); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Touch(ctx, cart.ID); err != nil {
+		return nil, err
+	}
+
+	validateItems(cart)
+	return cart, nil
+}
+
+func (s *cartService) RemoveItem(ctx context.Context, owner domain.CartOwner, productID string) (*model.Cart, error) {
+	cart, err := s.resolve(ctx, owner, false)
+	if err != nil {
+		return nil, err
+	}
+	if findItem(cart, productID) == nil {
+		return nil, apperror.ErrNotFound
+	}
+
+	if err := s.repo.DeleteItem(ctx, cart.ID, productID); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Touch(ctx, cart.ID); err != nil {
+		return nil, err
+	}
+
+	items := cart.Items[:0]
+	for _, it := range cart.Items {
+		if it.ProductID != productID {
+			items = append(items, it)
+		}
+	}
+	cart.Items = items
+
+	validateItems(cart)
+	return cart, nil
+}
+
+func (s *cartService) ReplaceItems(ctx context.Context, userID string, req *domain.CartSnapshotReq) error {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return err
+	}
+
+	cart, err := s.resolve(ctx, domain.CartOwner{UserID: userID}, true)
+	if err != nil {
+		return err
+	}
+
+	return s.db.WithTransaction(func() error {
+		if err := s.repo.ClearItems(ctx, cart.ID); err != nil {
+			return err
+		}
+		for _, it := range req.Items {
+			product, err := s.productRepo.GetProductByID(ctx, it.ProductID)
+			if err != nil {
+				if errors.Is(err, gorm.ErrRecordNotFound) {
+					continue // product deleted since the client cached it; drop the line
+				}
+				return err
+			}
+			item := &model.CartItem{
+				CartID:    cart.ID,
+				ProductID: it.ProductID,
+				Quantity:  it.Quantity,
+				UnitPrice: product.Price,
+			}
+			if err := s.repo.SaveItem(ctx, item); err != nil {
+				return err
+			}
+		}
+		return s.repo.Touch(ctx, cart.ID)
+	})
+}
+
+func (s *cartService) MergeGuestCart(ctx context.Context, userID string, req *domain.MergeCartReq) (*model.Cart, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	guest, err := s.repo.GetByID(ctx, req.GuestCartID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if guest != nil && !guest.IsGuest() && *guest.UserID != userID {
+		return nil, apperror.ErrForbidden
+	}
+
+	cart, err := s.resolve(ctx, domain.CartOwner{UserID: userID}, true)
+	if err != nil {
+		return nil, err
+	}
+	// Nothing to merge: the guest cart expired, was already merged, or is the user's own cart.
+	if guest == nil || guest.ID == cart.ID {
+		validateItems(cart)
+		return cart, nil
+	}
+
+	err = s.db.WithTransaction(func() error {
+		for _, gi := range guest.Items {
+			item := findItem(cart, gi.ProductID)
+			if item == nil {
+				item = &model.CartItem{CartID: cart.ID, ProductID: gi.ProductID, UnitPrice: gi.UnitPrice, Product: gi.Product}
+				cart.Items = append(cart.Items, item)
+			}
+			item.Quantity += gi.Quantity
+			if err := s.repo.SaveItem(ctx, item); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.ClearItems(ctx, guest.ID); err != nil {
+			return err
+		}
+		if err := s.repo.Delete(ctx, guest.ID); err != nil {
+			return err
+		}
+		return s.repo.Touch(ctx, cart.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	validateItems(cart)
+	return cart, nil
+}
+
+func (s *cartService) Checkout(ctx context.Context, userID string, req *domain.CheckoutCartReq) (*orderModel.Order, error) {
+	cart, err := s.resolve(ctx, domain.CartOwner{UserID: userID}, false)
+	if err != nil {
+		return nil, err
+	}
+	if len(cart.Items) == 0 {
+		return nil, apperror.ErrCartEmpty
+	}
+
+	if issues := validateItems(cart); len(issues) > 0 {
+		// Accept the new prices so a second checkout attempt goes through once the shopper
+		// has seen them; stock/availability issues still need the shopper to edit the cart.
+		for _, it := range cart.Items {
+			if it.Issue == model.ItemIssuePriceChanged {
+				it.UnitPrice = it.Product.Price
+				if err := s.repo.SaveItem(ctx, it); err != nil {
+					return nil, err
+				}
+			}
+		}
+		return nil, &CartValidationError{Issues: issues}
+	}
+
+	placeReq := &orderDomain.PlaceOrderReq{
+		UserID:            userID,
+		CouponCode:        req.CouponCode,
+		Lines:             make([]orderDomain.PlaceOrderLineReq, len(cart.Items)),
+		PaymentMethod:     req.PaymentMethod,
+		Currency:          req.Currency,
+		ShippingAddressID: req.ShippingAddressID,
+		ShippingAddress:   req.ShippingAddress,
+		BillingAddressID:  req.BillingAddressID,
+		BillingAddress:    req.BillingAddress,
+		ShippingMethod:    req.ShippingMethod,
+	}
+	for i, it := range cart.Items {
+		placeReq.Lines[i] = orderDomain.PlaceOrderLineReq{
+			ProductID: it.ProductID,
+			Quantity:  uint(it.Quantity), //nolint:gosec // validated > 0 on write
+		}
+	}
+
+	var order *orderModel.Order
+	err = s.db.WithTransaction(func() error {
+		var err error
+		if order, err = s.orders.PlaceOrder(ctx, placeReq); err != nil {
+			return err
+		}
+		if err := s.repo.ClearItems(ctx, cart.ID); err != nil {
+			return fmt.Errorf("clear cart after checkout: %w", err)
+		}
+		return s.repo.Touch(ctx, cart.ID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+// resolve finds the cart addressed by owner. When create is set a missing cart is created;
+// otherwise an empty unsaved cart is returned so reads never 404 for a shopper who simply
+// hasn't added anything yet.
+func (s *cartService) resolve(ctx context.Context, owner domain.CartOwner, create bool) (*model.Cart, error) {
+	var (
+		cart *model.Cart
+		err  error
+	)
+	switch {
+	case owner.UserID != "":
+		cart, err = s.repo.GetByUserID(ctx, owner.UserID)
+	case owner.GuestCartID != "":
+		cart, err = s.repo.GetByID(ctx, owner.GuestCartID)
+		// A claimed cart can't be addressed anonymously by its ID.
+		if err == nil && !cart.IsGuest() {
+			cart, err = nil, gorm.ErrRecordNotFound
+		}
+	default:
+		err = gorm.ErrRecordNotFound
+	}
+	if err == nil {
+		return cart, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	cart = &model.Cart{Items: []*model.CartItem{}}
+	if owner.UserID != "" {
+		userID := owner.UserID
+		cart.UserID = &userID
+	}
+	if !create {
+		return cart, nil
+	}
+	if err := s.repo.Create(ctx, cart); err != nil {
+		return nil, err
+	}
+	return cart, nil
+}
+
+// validateItems annotates every line with its current availability and any issue that
+// would block checkout, returning the issues found.
+func validateItems(cart *model.Cart) []domain.CartIssue {
+	var issues []domain.CartIssue
+	for _, it := range cart.Items {
+		it.Issue = ""
+		it.Available = 0
+		p := it.Product
+		switch {
+		case p == nil || !p.Active || p.DeletedAt != nil:
+			it.Issue = model.ItemIssueUnavailable
+		case it.Quantity > p.Available():
+			it.Issue = model.ItemIssueInsufficientStock
+		case it.UnitPrice != p.Price:
+			it.Issue = model.ItemIssuePriceChanged
+		}
+		if p != nil {
+			it.Available = max(p.Available(), 0)
+		}
+		if it.Issue != "" {
+			issues = append(issues, domain.CartIssue{
+				ProductID: it.ProductID,
+				Issue:     string(it.Issue),
+				Requested: it.Quantity,
+				Available: it.Available,
+			})
+		}
+	}
+	return issues
+}
+
+func findItem(cart *model.Cart, productID string) *model.CartItem {
+	for _, it := range cart.Items {
+		if it.ProductID == productID {
+			return it
+		}
+	}
+	return nil
+}
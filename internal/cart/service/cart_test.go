@@ -0,0 +1,417 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/quangdangfit/gocommon/validation"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"goshop/internal/cart/domain"
+	"goshop/internal/cart/model"
+	cartMocks "goshop/internal/cart/repository/mocks"
+	serviceMocks "goshop/internal/cart/service/mocks"
+	orderDomain "goshop/internal/order/domain"
+	orderModel "goshop/internal/order/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	dbsMocks "goshop/pkg/dbs/mocks"
+	"goshop/pkg/money"
+)
+
+type CartServiceTestSuite struct {
+	suite.Suite
+	mockDB          *dbsMocks.Database
+	mockRepo        *cartMocks.CartRepository
+	mockProductRepo *cartMocks.ProductRepository
+	mockOrders      *serviceMocks.OrderPlacer
+	service         CartService
+}
+
+func (suite *CartServiceTestSuite) SetupTest() {
+	logger.Initialize(config.ProductionEnv)
+
+	suite.mockDB = dbsMocks.NewDatabase(suite.T())
+	suite.mockRepo = cartMocks.NewCartRepository(suite.T())
+	suite.mockProductRepo = cartMocks.NewProductRepository(suite.T())
+	suite.mockOrders = serviceMocks.NewOrderPlacer(suite.T())
+	suite.mockDB.On("WithTransaction", mock.Anything).Return(func(fn func() error) error { return fn() }).Maybe()
+	suite.service = NewCartService(
+		validation.New(),
+		suite.mockDB,
+		suite.mockRepo,
+		suite.mockProductRepo,
+		suite.mockOrders,
+	)
+}
+
+func TestCartServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(CartServiceTestSuite))
+}
+
+func strPtr(s string) *string { return &s }
+
+func product(id string, price money.Money, stock, reserved int) *model.Product {
+	return &model.Product{ID: id, Name: "name-" + id, Price: price, Active: true, StockQuantity: stock, ReservedQuantity: reserved}
+}
+
+func userCart(items ...*model.CartItem) *model.Cart {
+	return &model.Cart{ID: "c1", UserID: strPtr("u1"), Items: items}
+}
+
+// GetCart
+// =================================================================
+
+func (suite *CartServiceTestSuite) TestGetCart_AnnotatesIssues() {
+	now := time.Now()
+	cart := userCart(
+		&model.CartItem{ProductID: "ok", Quantity: 1, UnitPrice: money.New(1000, "USD"), Product: product("ok", money.New(1000, "USD"), 5, 0)},
+		&model.CartItem{ProductID: "short", Quantity: 4, UnitPrice: money.New(1000, "USD"), Product: product("short", money.New(1000, "USD"), 5, 2)},
+		&model.CartItem{ProductID: "price", Quantity: 1, UnitPrice: money.New(1000, "USD"), Product: product("price", money.New(1200, "USD"), 5, 0)},
+		&model.CartItem{ProductID: "gone", Quantity: 1, UnitPrice: money.New(1000, "USD"), Product: &model.Product{ID: "gone", Active: true, DeletedAt: &now}},
+		&model.CartItem{ProductID: "missing", Quantity: 1, UnitPrice: money.New(1000, "USD")},
+	)
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(cart, nil).Once()
+
+	got, err := suite.service.GetCart(context.Background(), domain.CartOwner{UserID: "u1"})
+	suite.NoError(err)
+	suite.Equal(model.ItemIssue(""), got.Items[0].Issue)
+	suite.Equal(5, got.Items[0].Available)
+	suite.Equal(model.ItemIssueInsufficientStock, got.Items[1].Issue)
+	suite.Equal(3, got.Items[1].Available)
+	suite.Equal(model.ItemIssuePriceChanged, got.Items[2].Issue)
+	suite.Equal(model.ItemIssueUnavailable, got.Items[3].Issue)
+	suite.Equal(model.ItemIssueUnavailable, got.Items[4].Issue)
+}
+
+func (suite *CartServiceTestSuite) TestGetCart_NoCartYet() {
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	got, err := suite.service.GetCart(context.Background(), domain.CartOwner{UserID: "u1"})
+	suite.NoError(err)
+	suite.Empty(got.ID)
+	suite.Empty(got.Items)
+}
+
+func (suite *CartServiceTestSuite) TestGetCart_AnonymousWithoutCartID() {
+	got, err := suite.service.GetCart(context.Background(), domain.CartOwner{})
+	suite.NoError(err)
+	suite.True(got.IsGuest())
+	suite.Empty(got.Items)
+}
+
+func (suite *CartServiceTestSuite) TestGetCart_GuestCannotReadClaimedCart() {
+	suite.mockRepo.On("GetByID", mock.Anything, "c1").Return(userCart(), nil).Once()
+
+	got, err := suite.service.GetCart(context.Background(), domain.CartOwner{GuestCartID: "c1"})
+	suite.NoError(err)
+	suite.Empty(got.ID)
+}
+
+func (suite *CartServiceTestSuite) TestGetCart_RepoError() {
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(nil, errors.New("db down")).Once()
+
+	got, err := suite.service.GetCart(context.Background(), domain.CartOwner{UserID: "u1"})
+	suite.Error(err)
+	suite.Nil(got)
+}
+
+// AddItem
+// =================================================================
+
+func (suite *CartServiceTestSuite) TestAddItem_CreatesGuestCart() {
+	req := &domain.AddCartItemReq{ProductID: "p1", Quantity: 2}
+	suite.mockProductRepo.On("GetProductByID", mock.Anything, "p1").Return(product("p1", money.New(1000, "USD"), 5, 0), nil).Once()
+	suite.mockRepo.On("Create", mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { args.Get(1).(*model.Cart).ID = "guest" }).
+		Return(nil).Once()
+	suite.mockRepo.On("SaveItem", mock.Anything, mock.MatchedBy(func(it *model.CartItem) bool {
+		return it.CartID == "guest" && it.Quantity == 2 && it.UnitPrice == money.New(1000, "USD")
+	})).Return(nil).Once()
+	suite.mockRepo.On("Touch", mock.Anything, "guest").Return(nil).Once()
+
+	got, err := suite.service.AddItem(context.Background(), domain.CartOwner{}, req)
+	suite.NoError(err)
+	suite.Equal("guest", got.ID)
+	suite.True(got.IsGuest())
+	suite.Len(got.Items, 1)
+}
+
+func (suite *CartServiceTestSuite) TestAddItem_IncrementsExistingLine() {
+	cart := userCart(&model.CartItem{ID: "i1", CartID: "c1", ProductID: "p1", Quantity: 1, UnitPrice: money.New(900, "USD")})
+	suite.mockProductRepo.On("GetProductByID", mock.Anything, "p1").Return(product("p1", money.New(1000, "USD"), 5, 0), nil).Once()
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(cart, nil).Once()
+	suite.mockRepo.On("SaveItem", mock.Anything, mock.MatchedBy(func(it *model.CartItem) bool {
+		return it.ID == "i1" && it.Quantity == 3 && it.UnitPrice == money.New(1000, "USD")
+	})).Return(nil).Once()
+	suite.mockRepo.On("Touch", mock.Anything, "c1").Return(nil).Once()
+
+	got, err := suite.service.AddItem(context.Background(), domain.CartOwner{UserID: "u1"}, &domain.AddCartItemReq{ProductID: "p1", Quantity: 2})
+	suite.NoError(err)
+	suite.Len(got.Items, 1)
+	suite.Equal(model.ItemIssue(""), got.Items[0].Issue)
+}
+
+func (suite *CartServiceTestSuite) TestAddItem_InvalidReq() {
+	_, err := suite.service.AddItem(context.Background(), domain.CartOwner{UserID: "u1"}, &domain.AddCartItemReq{ProductID: "p1"})
+	suite.Error(err)
+}
+
+func (suite *CartServiceTestSuite) TestAddItem_ProductNotFound() {
+	suite.mockProductRepo.On("GetProductByID", mock.Anything, "p1").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := suite.service.AddItem(context.Background(), domain.CartOwner{UserID: "u1"}, &domain.AddCartItemReq{ProductID: "p1", Quantity: 1})
+	suite.ErrorIs(err, apperror.ErrNotFound)
+}
+
+func (suite *CartServiceTestSuite) TestAddItem_InactiveProduct() {
+	p := product("p1", money.New(1000, "USD"), 5, 0)
+	p.Active = false
+	suite.mockProductRepo.On("GetProductByID", mock.Anything, "p1").Return(p, nil).Once()
+
+	_, err := suite.service.AddItem(context.Background(), domain.CartOwner{UserID: "u1"}, &domain.AddCartItemReq{ProductID: "p1", Quantity: 1})
+	suite.ErrorIs(err, apperror.ErrNotFound)
+}
+
+func (suite *CartServiceTestSuite) TestAddItem_SaveFail() {
+	suite.mockProductRepo.On("GetProductByID", mock.Anything, "p1").Return(product("p1", money.New(1000, "USD"), 5, 0), nil).Once()
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(userCart(), nil).Once()
+	suite.mockRepo.On("SaveItem", mock.Anything, mock.Anything).Return(errors.New("boom")).Once()
+
+	_, err := suite.service.AddItem(context.Background(), domain.CartOwner{UserID: "u1"}, &domain.AddCartItemReq{ProductID: "p1", Quantity: 1})
+	suite.Error(err)
+}
+
+// UpdateItem / RemoveItem
+// =================================================================
+
+func (suite *CartServiceTestSuite) TestUpdateItem_Success() {
+	cart := userCart(&model.CartItem{ID: "i1", CartID: "c1", ProductID: "p1", Quantity: 1, UnitPrice: money.New(1000, "USD"), Product: product("p1", money.New(1000, "USD"), 5, 0)})
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(cart, nil).Once()
+	suite.mockRepo.On("SaveItem", mock.Anything, mock.MatchedBy(func(it *model.CartItem) bool { return it.Quantity == 4 })).Return(nil).Once()
+	suite.mockRepo.On("Touch", mock.Anything, "c1").Return(nil).Once()
+
+	got, err := suite.service.UpdateItem(context.Background(), domain.CartOwner{UserID: "u1"}, "p1", &domain.UpdateCartItemReq{Quantity: 4})
+	suite.NoError(err)
+	suite.Equal(4, got.Items[0].Quantity)
+}
+
+func (suite *CartServiceTestSuite) TestUpdateItem_ZeroRemoves() {
+	cart := userCart(&model.CartItem{ID: "i1", CartID: "c1", ProductID: "p1", Quantity: 1})
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(cart, nil).Once()
+	suite.mockRepo.On("DeleteItem", mock.Anything, "c1", "p1").Return(nil).Once()
+	suite.mockRepo.On("Touch", mock.Anything, "c1").Return(nil).Once()
+
+	got, err := suite.service.UpdateItem(context.Background(), domain.CartOwner{UserID: "u1"}, "p1", &domain.UpdateCartItemReq{Quantity: 0})
+	suite.NoError(err)
+	suite.Empty(got.Items)
+}
+
+func (suite *CartServiceTestSuite) TestUpdateItem_LineNotFound() {
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(userCart(), nil).Once()
+
+	_, err := suite.service.UpdateItem(context.Background(), domain.CartOwner{UserID: "u1"}, "p1", &domain.UpdateCartItemReq{Quantity: 2})
+	suite.ErrorIs(err, apperror.ErrNotFound)
+}
+
+func (suite *CartServiceTestSuite) TestUpdateItem_InvalidReq() {
+	_, err := suite.service.UpdateItem(context.Background(), domain.CartOwner{UserID: "u1"}, "p1", &domain.UpdateCartItemReq{Quantity: -1})
+	suite.Error(err)
+}
+
+func (suite *CartServiceTestSuite) TestRemoveItem_LineNotFound() {
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(userCart(), nil).Once()
+
+	_, err := suite.service.RemoveItem(context.Background(), domain.CartOwner{UserID: "u1"}, "p1")
+	suite.ErrorIs(err, apperror.ErrNotFound)
+}
+
+func (suite *CartServiceTestSuite) TestRemoveItem_DeleteFail() {
+	cart := userCart(&model.CartItem{ID: "i1", CartID: "c1", ProductID: "p1", Quantity: 1})
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(cart, nil).Once()
+	suite.mockRepo.On("DeleteItem", mock.Anything, "c1", "p1").Return(errors.New("boom")).Once()
+
+	_, err := suite.service.RemoveItem(context.Background(), domain.CartOwner{UserID: "u1"}, "p1")
+	suite.Error(err)
+}
+
+// ReplaceItems
+// =================================================================
+
+func (suite *CartServiceTestSuite) TestReplaceItems_Success() {
+	req := &domain.CartSnapshotReq{Items: []domain.CartSnapshotItem{
+		{ProductID: "p1", Quantity: 2},
+		{ProductID: "deleted", Quantity: 1},
+	}}
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(userCart(), nil).Once()
+	suite.mockRepo.On("ClearItems", mock.Anything, "c1").Return(nil).Once()
+	suite.mockProductRepo.On("GetProductByID", mock.Anything, "p1").Return(product("p1", money.New(1000, "USD"), 5, 0), nil).Once()
+	suite.mockProductRepo.On("GetProductByID", mock.Anything, "deleted").Return(nil, gorm.ErrRecordNotFound).Once()
+	suite.mockRepo.On("SaveItem", mock.Anything, mock.MatchedBy(func(it *model.CartItem) bool {
+		return it.ProductID == "p1" && it.Quantity == 2 && it.UnitPrice == money.New(1000, "USD")
+	})).Return(nil).Once()
+	suite.mockRepo.On("Touch", mock.Anything, "c1").Return(nil).Once()
+
+	suite.NoError(suite.service.ReplaceItems(context.Background(), "u1", req))
+}
+
+func (suite *CartServiceTestSuite) TestReplaceItems_TooManyItems() {
+	req := &domain.CartSnapshotReq{Items: make([]domain.CartSnapshotItem, 101)}
+	for i := range req.Items {
+		req.Items[i] = domain.CartSnapshotItem{ProductID: "p", Quantity: 1}
+	}
+	suite.Error(suite.service.ReplaceItems(context.Background(), "u1", req))
+}
+
+// MergeGuestCart
+// =================================================================
+
+func (suite *CartServiceTestSuite) TestMergeGuestCart_SumsQuantities() {
+	guest := &model.Cart{ID: "g1", Items: []*model.CartItem{
+		{ID: "gi1", CartID: "g1", ProductID: "p1", Quantity: 2, UnitPrice: money.New(1000, "USD")},
+		{ID: "gi2", CartID: "g1", ProductID: "p2", Quantity: 1, UnitPrice: money.New(500, "USD")},
+	}}
+	cart := userCart(&model.CartItem{ID: "i1", CartID: "c1", ProductID: "p1", Quantity: 1, UnitPrice: money.New(1000, "USD")})
+	suite.mockRepo.On("GetByID", mock.Anything, "g1").Return(guest, nil).Once()
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(cart, nil).Once()
+	suite.mockRepo.On("SaveItem", mock.Anything, mock.MatchedBy(func(it *model.CartItem) bool {
+		return it.ID == "i1" && it.Quantity == 3
+	})).Return(nil).Once()
+	suite.mockRepo.On("SaveItem", mock.Anything, mock.MatchedBy(func(it *model.CartItem) bool {
+		return it.ID == "" && it.CartID == "c1" && it.ProductID == "p2" && it.Quantity == 1
+	})).Return(nil).Once()
+	suite.mockRepo.On("ClearItems", mock.Anything, "g1").Return(nil).Once()
+	suite.mockRepo.On("Delete", mock.Anything, "g1").Return(nil).Once()
+	suite.mockRepo.On("Touch", mock.Anything, "c1").Return(nil).Once()
+
+	got, err := suite.service.MergeGuestCart(context.Background(), "u1", &domain.MergeCartReq{GuestCartID: "g1"})
+	suite.NoError(err)
+	suite.Len(got.Items, 2)
+}
+
+func (suite *CartServiceTestSuite) TestMergeGuestCart_GuestMissing() {
+	suite.mockRepo.On("GetByID", mock.Anything, "g1").Return(nil, gorm.ErrRecordNotFound).Once()
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(userCart(), nil).Once()
+
+	got, err := suite.service.MergeGuestCart(context.Background(), "u1", &domain.MergeCartReq{GuestCartID: "g1"})
+	suite.NoError(err)
+	suite.Equal("c1", got.ID)
+}
+
+func (suite *CartServiceTestSuite) TestMergeGuestCart_OtherUsersCart() {
+	suite.mockRepo.On("GetByID", mock.Anything, "c2").Return(&model.Cart{ID: "c2", UserID: strPtr("u2")}, nil).Once()
+
+	_, err := suite.service.MergeGuestCart(context.Background(), "u1", &domain.MergeCartReq{GuestCartID: "c2"})
+	suite.ErrorIs(err, apperror.ErrForbidden)
+}
+
+func (suite *CartServiceTestSuite) TestMergeGuestCart_SaveFail() {
+	guest := &model.Cart{ID: "g1", Items: []*model.CartItem{{ProductID: "p1", Quantity: 1}}}
+	suite.mockRepo.On("GetByID", mock.Anything, "g1").Return(guest, nil).Once()
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(userCart(), nil).Once()
+	suite.mockRepo.On("SaveItem", mock.Anything, mock.Anything).Return(errors.New("boom")).Once()
+
+	_, err := suite.service.MergeGuestCart(context.Background(), "u1", &domain.MergeCartReq{GuestCartID: "g1"})
+	suite.Error(err)
+}
+
+// Checkout
+// =================================================================
+
+func (suite *CartServiceTestSuite) TestCheckout_Success() {
+	cart := userCart(
+		&model.CartItem{ID: "i1", CartID: "c1", ProductID: "p1", Quantity: 2, UnitPrice: money.New(1000, "USD"), Product: product("p1", money.New(1000, "USD"), 5, 0)},
+		&model.CartItem{ID: "i2", CartID: "c1", ProductID: "p2", Quantity: 1, UnitPrice: money.New(500, "USD"), Product: product("p2", money.New(500, "USD"), 5, 0)},
+	)
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(cart, nil).Once()
+	suite.mockOrders.On("PlaceOrder", mock.Anything, &orderDomain.PlaceOrderReq{
+		UserID:     "u1",
+		CouponCode: "SAVE10",
+		Lines: []orderDomain.PlaceOrderLineReq{
+			{ProductID: "p1", Quantity: 2},
+			{ProductID: "p2", Quantity: 1},
+		},
+		PaymentMethod:     "cod",
+		ShippingAddressID: "a1",
+		ShippingMethod:    "express",
+	}).Return(&orderModel.Order{ID: "o1"}, nil).Once()
+	suite.mockRepo.On("ClearItems", mock.Anything, "c1").Return(nil).Once()
+	suite.mockRepo.On("Touch", mock.Anything, "c1").Return(nil).Once()
+
+	order, err := suite.service.Checkout(context.Background(), "u1", &domain.CheckoutCartReq{
+		CouponCode: "SAVE10", PaymentMethod: "cod", ShippingAddressID: "a1", ShippingMethod: "express",
+	})
+	suite.NoError(err)
+	suite.Equal("o1", order.ID)
+}
+
+func (suite *CartServiceTestSuite) TestCheckout_ManyLines() {
+	// The cart takes up to 100 lines; the order must take them all.
+	var items []*model.CartItem
+	for _, id := range []string{"p1", "p2", "p3", "p4", "p5", "p6"} {
+		items = append(items, &model.CartItem{CartID: "c1", ProductID: id, Quantity: 1, UnitPrice: money.New(1000, "USD"), Product: product(id, money.New(1000, "USD"), 5, 0)})
+	}
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(userCart(items...), nil).Once()
+	suite.mockOrders.On("PlaceOrder", mock.Anything, mock.MatchedBy(func(req *orderDomain.PlaceOrderReq) bool {
+		return len(req.Lines) == 6 && validation.New().ValidateStruct(req) == nil
+	})).Return(&orderModel.Order{ID: "o1"}, nil).Once()
+	suite.mockRepo.On("ClearItems", mock.Anything, "c1").Return(nil).Once()
+	suite.mockRepo.On("Touch", mock.Anything, "c1").Return(nil).Once()
+
+	order, err := suite.service.Checkout(context.Background(), "u1", &domain.CheckoutCartReq{})
+	suite.NoError(err)
+	suite.Equal("o1", order.ID)
+}
+
+func (suite *CartServiceTestSuite) TestCheckout_EmptyCart() {
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := suite.service.Checkout(context.Background(), "u1", &domain.CheckoutCartReq{})
+	suite.ErrorIs(err, apperror.ErrCartEmpty)
+}
+
+func (suite *CartServiceTestSuite) TestCheckout_InvalidLinesRefreshesPrice() {
+	cart := userCart(
+		&model.CartItem{ID: "i1", CartID: "c1", ProductID: "p1", Quantity: 1, UnitPrice: money.New(1000, "USD"), Product: product("p1", money.New(1200, "USD"), 5, 0)},
+		&model.CartItem{ID: "i2", CartID: "c1", ProductID: "p2", Quantity: 9, UnitPrice: money.New(500, "USD"), Product: product("p2", money.New(500, "USD"), 5, 1)},
+	)
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(cart, nil).Once()
+	suite.mockRepo.On("SaveItem", mock.Anything, mock.MatchedBy(func(it *model.CartItem) bool {
+		return it.ID == "i1" && it.UnitPrice == money.New(1200, "USD")
+	})).Return(nil).Once()
+
+	_, err := suite.service.Checkout(context.Background(), "u1", &domain.CheckoutCartReq{})
+	var cartErr *CartValidationError
+	suite.Require().ErrorAs(err, &cartErr)
+	suite.Equal([]domain.CartIssue{
+		{ProductID: "p1", Issue: "price_changed", Requested: 1, Available: 5},
+		{ProductID: "p2", Issue: "insufficient_stock", Requested: 9, Available: 4},
+	}, cartErr.Issues)
+	suite.Contains(cartErr.Error(), "2 invalid")
+}
+
+func (suite *CartServiceTestSuite) TestCheckout_PlaceOrderFail() {
+	cart := userCart(&model.CartItem{ID: "i1", CartID: "c1", ProductID: "p1", Quantity: 1, UnitPrice: money.New(1000, "USD"), Product: product("p1", money.New(1000, "USD"), 5, 0)})
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(cart, nil).Once()
+	suite.mockOrders.On("PlaceOrder", mock.Anything, mock.Anything).Return(nil, errors.New("boom")).Once()
+
+	_, err := suite.service.Checkout(context.Background(), "u1", &domain.CheckoutCartReq{})
+	suite.Error(err)
+}
+
+func (suite *CartServiceTestSuite) TestCheckout_ClearFail() {
+	cart := userCart(&model.CartItem{ID: "i1", CartID: "c1", ProductID: "p1", Quantity: 1, UnitPrice: money.New(1000, "USD"), Product: product("p1", money.New(1000, "USD"), 5, 0)})
+	suite.mockRepo.On("GetByUserID", mock.Anything, "u1").Return(cart, nil).Once()
+	suite.mockOrders.On("PlaceOrder", mock.Anything, mock.Anything).Return(&orderModel.Order{ID: "o1"}, nil).Once()
+	suite.mockRepo.On("ClearItems", mock.Anything, "c1").Return(errors.New("boom")).Once()
+
+	// The order is placed in the same transaction, so it is rolled back with the cart.
+	_, err := suite.service.Checkout(context.Background(), "u1", &domain.CheckoutCartReq{})
+	suite.Error(err)
+	suite.mockDB.AssertCalled(suite.T(), "WithTransaction", mock.Anything)
+}
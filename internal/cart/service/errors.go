@@ -0,0 +1,18 @@
+package service
+
+import (
+	"fmt"
+
+	"goshop/internal/cart/domain"
+)
+
+// CartValidationError is returned by Checkout when at least one line can't be ordered as-is.
+// Issues lists every offending line so the HTTP handler can return them in one 409 and the
+// FE can highlight them without a second round trip.
+type CartValidationError struct {
+	Issues []domain.CartIssue
+}
+
+func (e *CartValidationError) Error() string {
+	return fmt.Sprintf("cart has %d invalid item(s)", len(e.Issues))
+}
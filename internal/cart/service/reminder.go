@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+
+	cartRepo "goshop/internal/cart/repository"
+	"goshop/pkg/notification"
+)
+
+//go:generate mockery --name=ReminderService
+type ReminderService interface {
+	// SendAbandonedCartReminders notifies the owners of up to batchSize carts that have been
+	// idle for longer than idleFor and returns how many reminders were handed to the notifier.
+	// Each cart version is claimed before sending, so a cart is reminded at most once until
+	// it changes again — even with several replicas running the job.
+	SendAbandonedCartReminders(ctx context.Context, idleFor time.Duration, batchSize int) (int, error)
+}
+
+type reminderService struct {
+	repo     cartRepo.CartRepository
+	notifier notification.Notifier
+}
+
+func NewReminderService(repo cartRepo.CartRepository, notifier notification.Notifier) ReminderService {
+	return &reminderService{
+		repo:     repo,
+		notifier: notifier,
+	}
+}
+
+func (s *reminderService) SendAbandonedCartReminders(ctx context.Context, idleFor time.Duration, batchSize int) (int, error) {
+	carts, err := s.repo.FindAbandoned(ctx, time.Now().Add(-idleFor), batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, cart := range carts {
+		claimed, err := s.repo.MarkReminded(ctx, cart.ID, cart.Version)
+		if err != nil {
+			logger.Error("Failed to claim abandoned cart reminder: ", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		// The version is claimed even when there is nobody to mail, so a cart whose owner
+		// was deleted isn't picked up again on every tick.
+		if cart.User == nil || cart.User.DeletedAt != nil || cart.User.Email == "" {
+			continue
+		}
+
+		// Delivery failures are retried and dead-lettered by the notifier stack; the version
+		// stays claimed so a failing address doesn't get retried on every tick.
+		if err := s.notifier.SendAbandonedCart(ctx, cart.ID, cart.User.Email, cart.ItemCount()); err != nil {
+			logger.Error("Failed to send abandoned cart reminder: ", err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"goshop/internal/cart/model"
+	cartMocks "goshop/internal/cart/repository/mocks"
+	"goshop/pkg/config"
+	notificationMocks "goshop/pkg/notification/mocks"
+)
+
+type ReminderServiceTestSuite struct {
+	suite.Suite
+	mockRepo     *cartMocks.CartRepository
+	mockNotifier *notificationMocks.Notifier
+	service      ReminderService
+}
+
+func (suite *ReminderServiceTestSuite) SetupTest() {
+	logger.Initialize(config.ProductionEnv)
+
+	suite.mockRepo = cartMocks.NewCartRepository(suite.T())
+	suite.mockNotifier = notificationMocks.NewNotifier(suite.T())
+	suite.service = NewReminderService(suite.mockRepo, suite.mockNotifier)
+}
+
+func TestReminderServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(ReminderServiceTestSuite))
+}
+
+func abandonedCart(id string, version int, email string) *model.Cart {
+	return &model.Cart{
+		ID:      id,
+		UserID:  strPtr("u-" + id),
+		User:    &model.User{ID: "u-" + id, Email: email},
+		Version: version,
+		Items: []*model.CartItem{
+			{ProductID: "p1", Quantity: 2},
+			{ProductID: "p2", Quantity: 1},
+		},
+	}
+}
+
+func (suite *ReminderServiceTestSuite) TestSendAbandonedCartReminders_Success() {
+	before := time.Now().Add(-time.Hour)
+	suite.mockRepo.On("FindAbandoned", mock.Anything, mock.MatchedBy(func(t time.Time) bool {
+		return !t.After(before.Add(time.Second)) && t.After(before.Add(-time.Minute))
+	}), 50).Return([]*model.Cart{abandonedCart("c1", 3, "a@x.com")}, nil).Once()
+	suite.mockRepo.On("MarkReminded", mock.Anything, "c1", 3).Return(true, nil).Once()
+	suite.mockNotifier.On("SendAbandonedCart", mock.Anything, "c1", "a@x.com", 3).Return(nil).Once()
+
+	sent, err := suite.service.SendAbandonedCartReminders(context.Background(), time.Hour, 50)
+	suite.NoError(err)
+	suite.Equal(1, sent)
+}
+
+func (suite *ReminderServiceTestSuite) TestSendAbandonedCartReminders_SkipsAlreadyClaimed() {
+	suite.mockRepo.On("FindAbandoned", mock.Anything, mock.Anything, 50).
+		Return([]*model.Cart{abandonedCart("c1", 3, "a@x.com"), abandonedCart("c2", 1, "b@x.com")}, nil).Once()
+	suite.mockRepo.On("MarkReminded", mock.Anything, "c1", 3).Return(false, nil).Once()
+	suite.mockRepo.On("MarkReminded", mock.Anything, "c2", 1).Return(true, nil).Once()
+	suite.mockNotifier.On("SendAbandonedCart", mock.Anything, "c2", "b@x.com", 3).Return(nil).Once()
+
+	sent, err := suite.service.SendAbandonedCartReminders(context.Background(), time.Hour, 50)
+	suite.NoError(err)
+	suite.Equal(1, sent)
+}
+
+func (suite *ReminderServiceTestSuite) TestSendAbandonedCartReminders_ClaimErrorContinues() {
+	suite.mockRepo.On("FindAbandoned", mock.Anything, mock.Anything, 50).
+		Return([]*model.Cart{abandonedCart("c1", 3, "a@x.com"), abandonedCart("c2", 1, "b@x.com")}, nil).Once()
+	suite.mockRepo.On("MarkReminded", mock.Anything, "c1", 3).Return(false, errors.New("db down")).Once()
+	suite.mockRepo.On("MarkReminded", mock.Anything, "c2", 1).Return(true, nil).Once()
+	suite.mockNotifier.On("SendAbandonedCart", mock.Anything, "c2", "b@x.com", 3).Return(nil).Once()
+
+	sent, err := suite.service.SendAbandonedCartReminders(context.Background(), time.Hour, 50)
+	suite.NoError(err)
+	suite.Equal(1, sent)
+}
+
+func (suite *ReminderServiceTestSuite) TestSendAbandonedCartReminders_SkipsMissingOrDeletedUser() {
+	deleted := abandonedCart("c2", 1, "b@x.com")
+	now := time.Now()
+	deleted.User.DeletedAt = &now
+	orphan := abandonedCart("c3", 1, "")
+	orphan.User = nil
+
+	suite.mockRepo.On("FindAbandoned", mock.Anything, mock.Anything, 50).
+		Return([]*model.Cart{deleted, orphan}, nil).Once()
+	suite.mockRepo.On("MarkReminded", mock.Anything, "c2", 1).Return(true, nil).Once()
+	suite.mockRepo.On("MarkReminded", mock.Anything, "c3", 1).Return(true, nil).Once()
+
+	sent, err := suite.service.SendAbandonedCartReminders(context.Background(), time.Hour, 50)
+	suite.NoError(err)
+	suite.Equal(0, sent)
+}
+
+func (suite *ReminderServiceTestSuite) TestSendAbandonedCartReminders_NotifierError() {
+	suite.mockRepo.On("FindAbandoned", mock.Anything, mock.Anything, 50).
+		Return([]*model.Cart{abandonedCart("c1", 3, "a@x.com")}, nil).Once()
+	suite.mockRepo.On("MarkReminded", mock.Anything, "c1", 3).Return(true, nil).Once()
+	suite.mockNotifier.On("SendAbandonedCart", mock.Anything, "c1", "a@x.com", 3).Return(errors.New("smtp down")).Once()
+
+	sent, err := suite.service.SendAbandonedCartReminders(context.Background(), time.Hour, 50)
+	suite.NoError(err)
+	suite.Equal(0, sent)
+}
+
+func (suite *ReminderServiceTestSuite) TestSendAbandonedCartReminders_FindError() {
+	suite.mockRepo.On("FindAbandoned", mock.Anything, mock.Anything, 50).Return(nil, errors.New("db down")).Once()
+
+	sent, err := suite.service.SendAbandonedCartReminders(context.Background(), time.Hour, 50)
+	suite.Error(err)
+	suite.Equal(0, sent)
+}
@@ -0,0 +1,111 @@
+package domain
+
+import (
+	"goshop/internal/inventory/model"
+)
+
+func StockLedgerEntryFromModel(m *model.StockLedgerEntry) *StockLedgerEntry {
+	if m == nil {
+		return nil
+	}
+	return &StockLedgerEntry{
+		ID:             m.ID,
+		CreatedAt:      m.CreatedAt,
+		ProductID:      m.ProductID,
+		Kind:           string(m.Kind),
+		StockDelta:     m.StockDelta,
+		ReservedDelta:  m.ReservedDelta,
+		StockBefore:    m.StockBefore,
+		StockAfter:     m.StockAfter,
+		ReservedBefore: m.ReservedBefore,
+		ReservedAfter:  m.ReservedAfter,
+		Actor:          m.Actor,
+		Reason:         deref(m.Reason),
+		OrderID:        deref(m.OrderID),
+		ReservationID:  deref(m.ReservationID),
+		WarehouseID:    deref(m.WarehouseID),
+	}
+}
+
+func StockLedgerEntriesFromModel(rows []*model.StockLedgerEntry) []*StockLedgerEntry {
+	out := make([]*StockLedgerEntry, len(rows))
+	for i, r := range rows {
+		out[i] = StockLedgerEntryFromModel(r)
+	}
+	return out
+}
+
+func ReconcileResFromModel(rows []*model.Discrepancy) *ReconcileRes {
+	out := make([]*Discrepancy, len(rows))
+	for i, r := range rows {
+		out[i] = &Discrepancy{
+			ProductID:        r.ProductID,
+			StockQuantity:    r.StockQuantity,
+			LedgerStock:      r.LedgerStock,
+			ReservedQuantity: r.ReservedQuantity,
+			LedgerReserved:   r.LedgerReserved,
+		}
+	}
+	return &ReconcileRes{Balanced: len(out) == 0, Discrepancies: out}
+}
+
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func ReconcileReservedResFromModel(dryRun bool, rows []*model.ReservedDrift) *ReconcileReservedRes {
+	out := make([]*ReservedDrift, len(rows))
+	for i, r := range rows {
+		out[i] = &ReservedDrift{
+			ProductID:        r.ProductID,
+			ReservedQuantity: r.ReservedQuantity,
+			ActiveReserved:   r.ActiveReserved,
+			Repaired:         r.Repaired,
+		}
+	}
+	return &ReconcileReservedRes{DryRun: dryRun, Drifts: out}
+}
+
+func WarehouseFromModel(m *model.Warehouse) *Warehouse {
+	if m == nil {
+		return nil
+	}
+	return &Warehouse{
+		ID:        m.ID,
+		Code:      m.Code,
+		Name:      m.Name,
+		Country:   m.Country,
+		City:      m.City,
+		Priority:  m.Priority,
+		IsDefault: m.IsDefault,
+		Active:    m.Active,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func WarehousesFromModel(rows []*model.Warehouse) []*Warehouse {
+	out := make([]*Warehouse, len(rows))
+	for i, r := range rows {
+		out[i] = WarehouseFromModel(r)
+	}
+	return out
+}
+
+func WarehouseStocksFromModel(rows []*model.WarehouseStock) []*WarehouseStock {
+	out := make([]*WarehouseStock, len(rows))
+	for i, r := range rows {
+		out[i] = &WarehouseStock{
+			WarehouseID:      r.WarehouseID,
+			Warehouse:        WarehouseFromModel(r.Warehouse),
+			ProductID:        r.ProductID,
+			StockQuantity:    r.StockQuantity,
+			ReservedQuantity: r.ReservedQuantity,
+			Available:        r.StockQuantity - r.ReservedQuantity,
+		}
+	}
+	return out
+}
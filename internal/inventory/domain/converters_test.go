@@ -0,0 +1,86 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"goshop/internal/inventory/model"
+	"goshop/pkg/stock"
+)
+
+func TestStockLedgerEntryFromModel(t *testing.T) {
+	assert.Nil(t, StockLedgerEntryFromModel(nil))
+
+	reason, orderID := "customer order", "o1"
+	e := StockLedgerEntryFromModel(&model.StockLedgerEntry{
+		ID:             "l1",
+		ProductID:      "p1",
+		Kind:           stock.MovementReserve,
+		ReservedDelta:  2,
+		StockBefore:    10,
+		StockAfter:     10,
+		ReservedBefore: 1,
+		ReservedAfter:  3,
+		Actor:          "u1",
+		Reason:         &reason,
+		OrderID:        &orderID,
+	})
+	assert.Equal(t, "l1", e.ID)
+	assert.Equal(t, "reserve", e.Kind)
+	assert.Equal(t, 2, e.ReservedDelta)
+	assert.Equal(t, 1, e.ReservedBefore)
+	assert.Equal(t, 3, e.ReservedAfter)
+	assert.Equal(t, "customer order", e.Reason)
+	assert.Equal(t, "o1", e.OrderID)
+	assert.Empty(t, e.ReservationID)
+}
+
+func TestStockLedgerEntriesFromModel(t *testing.T) {
+	out := StockLedgerEntriesFromModel([]*model.StockLedgerEntry{{ID: "a"}, {ID: "b"}})
+	assert.Len(t, out, 2)
+	assert.Equal(t, "b", out[1].ID)
+	assert.Empty(t, StockLedgerEntriesFromModel(nil))
+}
+
+func TestReconcileResFromModel(t *testing.T) {
+	res := ReconcileResFromModel(nil)
+	assert.True(t, res.Balanced)
+	assert.Empty(t, res.Discrepancies)
+
+	res = ReconcileResFromModel([]*model.Discrepancy{{ProductID: "p1", StockQuantity: 10, LedgerStock: 8}})
+	assert.False(t, res.Balanced)
+	assert.Equal(t, "p1", res.Discrepancies[0].ProductID)
+	assert.Equal(t, 8, res.Discrepancies[0].LedgerStock)
+}
+
+func TestReconcileReservedResFromModel(t *testing.T) {
+	res := ReconcileReservedResFromModel(true, []*model.ReservedDrift{{ProductID: "p1", ReservedQuantity: 5, ActiveReserved: 2}})
+	assert.True(t, res.DryRun)
+	assert.Equal(t, 5, res.Drifts[0].ReservedQuantity)
+	assert.Equal(t, 2, res.Drifts[0].ActiveReserved)
+	assert.False(t, res.Drifts[0].Repaired)
+
+	assert.Empty(t, ReconcileReservedResFromModel(false, nil).Drifts)
+}
+
+func TestWarehousesFromModel(t *testing.T) {
+	res := WarehousesFromModel([]*model.Warehouse{{ID: "w1", Code: "HAN", Country: "VN", City: "Hanoi", Priority: 1, IsDefault: true, Active: true}})
+	assert.Equal(t, "HAN", res[0].Code)
+	assert.Equal(t, "Hanoi", res[0].City)
+	assert.True(t, res[0].IsDefault)
+
+	assert.Nil(t, WarehouseFromModel(nil))
+	assert.Empty(t, WarehousesFromModel(nil))
+}
+
+func TestWarehouseStocksFromModel(t *testing.T) {
+	res := WarehouseStocksFromModel([]*model.WarehouseStock{
+		{WarehouseID: "w1", Warehouse: &model.Warehouse{ID: "w1", Code: "HAN"}, ProductID: "p1", StockQuantity: 10, ReservedQuantity: 3},
+		{WarehouseID: "w2", ProductID: "p1", StockQuantity: 4},
+	})
+	assert.Equal(t, 7, res[0].Available)
+	assert.Equal(t, "HAN", res[0].Warehouse.Code)
+	assert.Nil(t, res[1].Warehouse)
+	assert.Equal(t, 4, res[1].Available)
+}
@@ -0,0 +1,77 @@
+package domain
+
+import (
+	"time"
+
+	"goshop/pkg/paging"
+)
+
+type StockLedgerEntry struct {
+	ID             string    `json:"id"`
+	CreatedAt      time.Time `json:"created_at"`
+	ProductID      string    `json:"product_id"`
+	Kind           string    `json:"kind"`
+	StockDelta     int       `json:"stock_delta"`
+	ReservedDelta  int       `json:"reserved_delta"`
+	StockBefore    int       `json:"stock_before"`
+	StockAfter     int       `json:"stock_after"`
+	ReservedBefore int       `json:"reserved_before"`
+	ReservedAfter  int       `json:"reserved_after"`
+	Actor          string    `json:"actor"`
+	Reason         string    `json:"reason,omitempty"`
+	OrderID        string    `json:"order_id,omitempty"`
+	ReservationID  string    `json:"reservation_id,omitempty"`
+	WarehouseID    string    `json:"warehouse_id,omitempty"`
+}
+
+// ListLedgerReq pages through one product's ledger, newest first, optionally narrowed to
+// one movement kind.
+type ListLedgerReq struct {
+	ProductID string `json:"-"`
+	Kind      string `json:"kind,omitempty" form:"kind"`
+	Page      int64  `json:"-" form:"page"`
+	Limit     int64  `json:"-" form:"limit"`
+}
+
+type ListLedgerRes struct {
+	Entries    []*StockLedgerEntry `json:"entries"`
+	Pagination *paging.Pagination  `json:"pagination,omitempty"`
+}
+
+// ReconcileReq limits the reconciliation check to one product; empty checks every product.
+type ReconcileReq struct {
+	ProductID string `json:"product_id,omitempty" form:"product_id"`
+}
+
+type Discrepancy struct {
+	ProductID        string `json:"product_id"`
+	StockQuantity    int    `json:"stock_quantity"`
+	LedgerStock      int    `json:"ledger_stock"`
+	ReservedQuantity int    `json:"reserved_quantity"`
+	LedgerReserved   int    `json:"ledger_reserved"`
+}
+
+type ReconcileRes struct {
+	Balanced      bool           `json:"balanced"`
+	Discrepancies []*Discrepancy `json:"discrepancies"`
+}
+
+// ReconcileReservedReq limits the reserved_quantity check to one product; empty checks every
+// product. DryRun reports drift without repairing it.
+type ReconcileReservedReq struct {
+	ProductID string `json:"product_id,omitempty" form:"product_id"`
+	DryRun    bool   `json:"dry_run" form:"dry_run"`
+	ActorID   string `json:"-"`
+}
+
+type ReservedDrift struct {
+	ProductID        string `json:"product_id"`
+	ReservedQuantity int    `json:"reserved_quantity"`
+	ActiveReserved   int    `json:"active_reserved"`
+	Repaired         bool   `json:"repaired"`
+}
+
+type ReconcileReservedRes struct {
+	DryRun bool             `json:"dry_run"`
+	Drifts []*ReservedDrift `json:"drifts"`
+}
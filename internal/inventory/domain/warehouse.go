@@ -0,0 +1,65 @@
+package domain
+
+import (
+	"time"
+
+	"goshop/pkg/paging"
+)
+
+type Warehouse struct {
+	ID        string    `json:"id"`
+	Code      string    `json:"code"`
+	Name      string    `json:"name"`
+	Country   string    `json:"country,omitempty"`
+	City      string    `json:"city,omitempty"`
+	Priority  int       `json:"priority"`
+	IsDefault bool      `json:"is_default"`
+	Active    bool      `json:"active"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// CreateWarehouseReq adds a location. Country and City place it for nearest-warehouse
+// allocation; Priority ranks it, lowest first. Active defaults to true.
+type CreateWarehouseReq struct {
+	Code      string `json:"code" validate:"required,max=64"`
+	Name      string `json:"name" validate:"required"`
+	Country   string `json:"country,omitempty"`
+	City      string `json:"city,omitempty"`
+	Priority  int    `json:"priority,omitempty" validate:"gte=0"`
+	IsDefault bool   `json:"is_default,omitempty"`
+	Active    *bool  `json:"active,omitempty"`
+}
+
+// UpdateWarehouseReq changes the fields that are set. A warehouse stops being the default
+// only when another one is made default.
+type UpdateWarehouseReq struct {
+	Name      string `json:"name,omitempty"`
+	Country   string `json:"country,omitempty"`
+	City      string `json:"city,omitempty"`
+	Priority  *int   `json:"priority,omitempty" validate:"omitempty,gte=0"`
+	IsDefault bool   `json:"is_default,omitempty"`
+	Active    *bool  `json:"active,omitempty"`
+}
+
+type WarehouseStock struct {
+	WarehouseID      string     `json:"warehouse_id"`
+	Warehouse        *Warehouse `json:"warehouse,omitempty"`
+	ProductID        string     `json:"product_id"`
+	StockQuantity    int        `json:"stock_quantity"`
+	ReservedQuantity int        `json:"reserved_quantity"`
+	Available        int        `json:"available"`
+}
+
+// ListWarehouseStockReq pages through stock levels at one warehouse or of one product.
+type ListWarehouseStockReq struct {
+	WarehouseID string `json:"-"`
+	ProductID   string `json:"-"`
+	Page        int64  `json:"-" form:"page"`
+	Limit       int64  `json:"-" form:"limit"`
+}
+
+type ListWarehouseStockRes struct {
+	Stocks     []*WarehouseStock  `json:"stocks"`
+	Pagination *paging.Pagination `json:"pagination,omitempty"`
+}
@@ -0,0 +1,52 @@
+package model
+
+import (
+	"time"
+
+	"goshop/pkg/stock"
+)
+
+// StockLedgerEntry is one row of the append-only stock ledger: a single change to a product's
+// stock_quantity and/or reserved_quantity, with the counters on either side of it. Rows are
+// written in the same transaction as the change and never updated.
+type StockLedgerEntry struct {
+	ID             string             `json:"id" gorm:"primary_key"`
+	CreatedAt      time.Time          `json:"created_at"`
+	ProductID      string             `json:"product_id" gorm:"not null;index"`
+	Kind           stock.MovementKind `json:"kind" gorm:"size:32;not null"`
+	StockDelta     int                `json:"stock_delta"`
+	ReservedDelta  int                `json:"reserved_delta"`
+	StockBefore    int                `json:"stock_before"`
+	StockAfter     int                `json:"stock_after"`
+	ReservedBefore int                `json:"reserved_before"`
+	ReservedAfter  int                `json:"reserved_after"`
+	Actor          string             `json:"actor" gorm:"size:255;not null"`
+	Reason         *string            `json:"reason"`
+	OrderID        *string            `json:"order_id"`
+	ReservationID  *string            `json:"reservation_id"`
+	WarehouseID    *string            `json:"warehouse_id"`
+}
+
+// Discrepancy is a product whose counters don't match the sum of its ledger deltas: some
+// write changed the counters without going through the ledger, or vice versa.
+type Discrepancy struct {
+	ProductID        string `json:"product_id"`
+	StockQuantity    int    `json:"stock_quantity"`
+	LedgerStock      int    `json:"ledger_stock"`
+	ReservedQuantity int    `json:"reserved_quantity"`
+	LedgerReserved   int    `json:"ledger_reserved"`
+}
+
+// ReservedDrift is a product whose reserved_quantity doesn't match the units held by its
+// active stock reservations, the only thing reserved_quantity is supposed to count.
+type ReservedDrift struct {
+	ProductID        string `json:"product_id"`
+	ReservedQuantity int    `json:"reserved_quantity"`
+	ActiveReserved   int    `json:"active_reserved"`
+	Repaired         bool   `json:"repaired" gorm:"-"`
+}
+
+// Delta is the change to reserved_quantity that removes the drift.
+func (d *ReservedDrift) Delta() int {
+	return d.ActiveReserved - d.ReservedQuantity
+}
@@ -0,0 +1,43 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Warehouse is a location that holds stock. Orders draw from warehouses by distance to the
+// shipping destination and by Priority (lowest first); the default warehouse receives stock
+// that isn't assigned anywhere else.
+type Warehouse struct {
+	ID        string     `json:"id" gorm:"unique;not null;index;primary_key"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	DeletedAt *time.Time `json:"deleted_at" gorm:"index"`
+	Code      string     `json:"code" gorm:"size:64;not null"`
+	Name      string     `json:"name" gorm:"size:255;not null"`
+	Country   string     `json:"country"`
+	City      string     `json:"city"`
+	Priority  int        `json:"priority"`
+	IsDefault bool       `json:"is_default"`
+	Active    bool       `json:"active"`
+}
+
+func (w *Warehouse) BeforeCreate(tx *gorm.DB) error {
+	w.ID = uuid.New().String()
+	return nil
+}
+
+// WarehouseStock is one product's stock at one warehouse. Its counters move together with the
+// product's totals, which are their sum across warehouses.
+type WarehouseStock struct {
+	ID               string     `json:"id" gorm:"primary_key"`
+	CreatedAt        time.Time  `json:"created_at"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	WarehouseID      string     `json:"warehouse_id" gorm:"not null"`
+	Warehouse        *Warehouse `json:"warehouse,omitempty"`
+	ProductID        string     `json:"product_id" gorm:"not null;index"`
+	StockQuantity    int        `json:"stock_quantity"`
+	ReservedQuantity int        `json:"reserved_quantity"`
+}
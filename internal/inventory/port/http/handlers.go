@@ -0,0 +1,82 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/service"
+	"goshop/pkg/apperror"
+	"goshop/pkg/response"
+)
+
+type LedgerHandler struct {
+	service service.LedgerService
+}
+
+func NewLedgerHandler(service service.LedgerService) *LedgerHandler {
+	return &LedgerHandler{
+		service: service,
+	}
+}
+
+// ListByProduct godoc
+//
+//	@Summary	Admin: list a product's stock ledger entries, newest first
+//	@Tags		inventory
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string					true	"Product ID"
+//	@Param		_	query		domain.ListLedgerReq	true	"Query"
+//	@Success	200	{object}	domain.ListLedgerRes
+//	@Router		/api/v1/admin/inventory/products/{id}/ledger [get]
+func (h *LedgerHandler) ListByProduct(c *gin.Context) {
+	var req domain.ListLedgerReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to parse request req: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+	req.ProductID = c.Param("id")
+
+	entries, pagination, err := h.service.ListByProduct(c, &req)
+	if err != nil {
+		logger.Error("Failed to list stock ledger: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ListLedgerRes{
+		Entries:    domain.StockLedgerEntriesFromModel(entries),
+		Pagination: pagination,
+	})
+}
+
+// Reconcile godoc
+//
+//	@Summary	Admin: replay the stock ledger against current product counters
+//	@Tags		inventory
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	query		domain.ReconcileReq	true	"Query"
+//	@Success	200	{object}	domain.ReconcileRes
+//	@Router		/api/v1/admin/inventory/reconcile [get]
+func (h *LedgerHandler) Reconcile(c *gin.Context) {
+	var req domain.ReconcileReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to parse request req: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	discrepancies, err := h.service.Reconcile(c, &req)
+	if err != nil {
+		logger.Error("Failed to reconcile stock ledger: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ReconcileResFromModel(discrepancies))
+}
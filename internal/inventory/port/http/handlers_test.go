@@ -0,0 +1,124 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/model"
+	srvMocks "goshop/internal/inventory/service/mocks"
+	"goshop/pkg/config"
+	"goshop/pkg/paging"
+	"goshop/pkg/stock"
+)
+
+type LedgerHandlerTestSuite struct {
+	suite.Suite
+	mockService *srvMocks.LedgerService
+	handler     *LedgerHandler
+}
+
+func (suite *LedgerHandlerTestSuite) SetupTest() {
+	logger.Initialize(config.ProductionEnv)
+	gin.SetMode(gin.TestMode)
+	suite.mockService = srvMocks.NewLedgerService(suite.T())
+	suite.handler = NewLedgerHandler(suite.mockService)
+}
+
+func TestLedgerHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(LedgerHandlerTestSuite))
+}
+
+func (suite *LedgerHandlerTestSuite) prepareContext(method, path string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, nil)
+	return c, w
+}
+
+// ListByProduct
+// =================================================================================================
+
+func (suite *LedgerHandlerTestSuite) TestListByProduct_Success() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/products/p1/ledger?kind=reserve&page=2&limit=5")
+	c.Params = gin.Params{{Key: "id", Value: "p1"}}
+	suite.mockService.On("ListByProduct", mock.Anything, &domain.ListLedgerReq{ProductID: "p1", Kind: "reserve", Page: 2, Limit: 5}).
+		Return([]*model.StockLedgerEntry{{ID: "l1", ProductID: "p1", Kind: stock.MovementReserve, ReservedDelta: 1}},
+			&paging.Pagination{Total: 1}, nil).Once()
+
+	suite.handler.ListByProduct(c)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var res struct {
+		Result domain.ListLedgerRes `json:"result"`
+	}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	suite.Len(res.Result.Entries, 1)
+	suite.Equal("reserve", res.Result.Entries[0].Kind)
+}
+
+func (suite *LedgerHandlerTestSuite) TestListByProduct_BadQuery() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/products/p1/ledger?page=abc")
+	c.Params = gin.Params{{Key: "id", Value: "p1"}}
+
+	suite.handler.ListByProduct(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *LedgerHandlerTestSuite) TestListByProduct_Error() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/products/p1/ledger")
+	c.Params = gin.Params{{Key: "id", Value: "p1"}}
+	suite.mockService.On("ListByProduct", mock.Anything, mock.Anything).Return(nil, nil, errors.New("db down")).Once()
+
+	suite.handler.ListByProduct(c)
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
+
+// Reconcile
+// =================================================================================================
+
+func (suite *LedgerHandlerTestSuite) TestReconcile_Balanced() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/reconcile")
+	suite.mockService.On("Reconcile", mock.Anything, &domain.ReconcileReq{}).Return(nil, nil).Once()
+
+	suite.handler.Reconcile(c)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var res struct {
+		Result domain.ReconcileRes `json:"result"`
+	}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	suite.True(res.Result.Balanced)
+}
+
+func (suite *LedgerHandlerTestSuite) TestReconcile_Discrepancies() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/reconcile?product_id=p1")
+	suite.mockService.On("Reconcile", mock.Anything, &domain.ReconcileReq{ProductID: "p1"}).
+		Return([]*model.Discrepancy{{ProductID: "p1", StockQuantity: 10, LedgerStock: 7}}, nil).Once()
+
+	suite.handler.Reconcile(c)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var res struct {
+		Result domain.ReconcileRes `json:"result"`
+	}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	suite.False(res.Result.Balanced)
+	suite.Equal(7, res.Result.Discrepancies[0].LedgerStock)
+}
+
+func (suite *LedgerHandlerTestSuite) TestReconcile_Error() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/reconcile")
+	suite.mockService.On("Reconcile", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	suite.handler.Reconcile(c)
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/service"
+	"goshop/pkg/apperror"
+	"goshop/pkg/response"
+)
+
+type ReservedHandler struct {
+	service service.ReservedService
+}
+
+func NewReservedHandler(service service.ReservedService) *ReservedHandler {
+	return &ReservedHandler{
+		service: service,
+	}
+}
+
+// ReconcileReserved godoc
+//
+//	@Summary	Admin: recompute reserved_quantity from active reservations and repair drift
+//	@Tags		inventory
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	query		domain.ReconcileReservedReq	true	"Query"
+//	@Success	200	{object}	domain.ReconcileReservedRes
+//	@Router		/api/v1/admin/inventory/reserved/reconcile [post]
+func (h *ReservedHandler) ReconcileReserved(c *gin.Context) {
+	var req domain.ReconcileReservedReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to parse request req: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+	req.ActorID = c.GetString("userId")
+
+	drifts, err := h.service.ReconcileReserved(c, &req)
+	if err != nil {
+		logger.Error("Failed to reconcile reserved quantities: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ReconcileReservedResFromModel(req.DryRun, drifts))
+}
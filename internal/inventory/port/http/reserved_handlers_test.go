@@ -0,0 +1,94 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/model"
+	srvMocks "goshop/internal/inventory/service/mocks"
+	"goshop/pkg/config"
+)
+
+type ReservedHandlerTestSuite struct {
+	suite.Suite
+	mockService *srvMocks.ReservedService
+	handler     *ReservedHandler
+}
+
+func (suite *ReservedHandlerTestSuite) SetupTest() {
+	logger.Initialize(config.ProductionEnv)
+	gin.SetMode(gin.TestMode)
+	suite.mockService = srvMocks.NewReservedService(suite.T())
+	suite.handler = NewReservedHandler(suite.mockService)
+}
+
+func TestReservedHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReservedHandlerTestSuite))
+}
+
+func (suite *ReservedHandlerTestSuite) prepareContext(path string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodPost, path, nil)
+	c.Set("userId", "admin")
+	return c, w
+}
+
+func (suite *ReservedHandlerTestSuite) TestReconcileReserved_DryRun() {
+	c, w := suite.prepareContext("/api/v1/admin/inventory/reserved/reconcile?dry_run=true&product_id=p1")
+	suite.mockService.On("ReconcileReserved", mock.Anything,
+		&domain.ReconcileReservedReq{ProductID: "p1", DryRun: true, ActorID: "admin"}).
+		Return([]*model.ReservedDrift{{ProductID: "p1", ReservedQuantity: 5, ActiveReserved: 2}}, nil).Once()
+
+	suite.handler.ReconcileReserved(c)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var res struct {
+		Result domain.ReconcileReservedRes `json:"result"`
+	}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	suite.True(res.Result.DryRun)
+	suite.Len(res.Result.Drifts, 1)
+	suite.Equal(2, res.Result.Drifts[0].ActiveReserved)
+	suite.False(res.Result.Drifts[0].Repaired)
+}
+
+func (suite *ReservedHandlerTestSuite) TestReconcileReserved_Repair() {
+	c, w := suite.prepareContext("/api/v1/admin/inventory/reserved/reconcile")
+	suite.mockService.On("ReconcileReserved", mock.Anything, &domain.ReconcileReservedReq{ActorID: "admin"}).
+		Return([]*model.ReservedDrift{{ProductID: "p1", ReservedQuantity: 5, ActiveReserved: 2, Repaired: true}}, nil).Once()
+
+	suite.handler.ReconcileReserved(c)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var res struct {
+		Result domain.ReconcileReservedRes `json:"result"`
+	}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	suite.False(res.Result.DryRun)
+	suite.True(res.Result.Drifts[0].Repaired)
+}
+
+func (suite *ReservedHandlerTestSuite) TestReconcileReserved_BadQuery() {
+	c, w := suite.prepareContext("/api/v1/admin/inventory/reserved/reconcile?dry_run=maybe")
+
+	suite.handler.ReconcileReserved(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *ReservedHandlerTestSuite) TestReconcileReserved_Error() {
+	c, w := suite.prepareContext("/api/v1/admin/inventory/reserved/reconcile")
+	suite.mockService.On("ReconcileReserved", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	suite.handler.ReconcileReserved(c)
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
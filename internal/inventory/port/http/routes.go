@@ -0,0 +1,38 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/validation"
+
+	"goshop/internal/inventory/repository"
+	"goshop/internal/inventory/service"
+	"goshop/pkg/dbs"
+	"goshop/pkg/middleware"
+)
+
+// Routes exposes the admin view of the stock ledger, the reserved_quantity repair job and
+// warehouse management. Ledger entries and warehouse stock are written by the product and
+// order services as they change stock.
+func Routes(r *gin.RouterGroup, db dbs.Database, validator validation.Validation) {
+	ledgerRepo := repository.NewLedgerRepository(db)
+	handler := NewLedgerHandler(service.NewLedgerService(ledgerRepo))
+	reservedHandler := NewReservedHandler(
+		service.NewReservedService(db, repository.NewReservedRepository(db), ledgerRepo),
+	)
+	warehouseHandler := NewWarehouseHandler(
+		service.NewWarehouseService(validator, db, repository.NewWarehouseRepository(db)),
+	)
+
+	adminRoute := r.Group("/admin/inventory", middleware.JWTAuth(), middleware.AdminOnly())
+	{
+		adminRoute.GET("/products/:id/ledger", handler.ListByProduct)
+		adminRoute.GET("/reconcile", handler.Reconcile)
+		adminRoute.POST("/reserved/reconcile", reservedHandler.ReconcileReserved)
+		adminRoute.GET("/products/:id/stock", warehouseHandler.ListProductStock)
+		adminRoute.GET("/warehouses", warehouseHandler.List)
+		adminRoute.POST("/warehouses", warehouseHandler.Create)
+		adminRoute.GET("/warehouses/:id", warehouseHandler.GetByID)
+		adminRoute.PUT("/warehouses/:id", warehouseHandler.Update)
+		adminRoute.GET("/warehouses/:id/stock", warehouseHandler.ListStock)
+	}
+}
@@ -0,0 +1,173 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/service"
+	"goshop/pkg/apperror"
+	"goshop/pkg/response"
+)
+
+type WarehouseHandler struct {
+	service service.WarehouseService
+}
+
+func NewWarehouseHandler(service service.WarehouseService) *WarehouseHandler {
+	return &WarehouseHandler{
+		service: service,
+	}
+}
+
+// List godoc
+//
+//	@Summary	Admin: list warehouses by priority
+//	@Tags		inventory
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Success	200	{object}	[]domain.Warehouse
+//	@Router		/api/v1/admin/inventory/warehouses [get]
+func (h *WarehouseHandler) List(c *gin.Context) {
+	warehouses, err := h.service.List(c)
+	if err != nil {
+		logger.Error("Failed to list warehouses: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.WarehousesFromModel(warehouses))
+}
+
+// GetByID godoc
+//
+//	@Summary	Admin: get a warehouse
+//	@Tags		inventory
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string	true	"Warehouse ID"
+//	@Success	200	{object}	domain.Warehouse
+//	@Router		/api/v1/admin/inventory/warehouses/{id} [get]
+func (h *WarehouseHandler) GetByID(c *gin.Context) {
+	warehouse, err := h.service.GetByID(c, c.Param("id"))
+	if err != nil {
+		logger.Error("Failed to get warehouse: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.WarehouseFromModel(warehouse))
+}
+
+// Create godoc
+//
+//	@Summary	Admin: add a warehouse
+//	@Tags		inventory
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	body		domain.CreateWarehouseReq	true	"Body"
+//	@Success	200	{object}	domain.Warehouse
+//	@Router		/api/v1/admin/inventory/warehouses [post]
+func (h *WarehouseHandler) Create(c *gin.Context) {
+	var req domain.CreateWarehouseReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to parse request body: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	warehouse, err := h.service.Create(c, &req)
+	if err != nil {
+		logger.Error("Failed to create warehouse: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.WarehouseFromModel(warehouse))
+}
+
+// Update godoc
+//
+//	@Summary	Admin: update a warehouse, make it the default or deactivate it
+//	@Tags		inventory
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string						true	"Warehouse ID"
+//	@Param		_	body		domain.UpdateWarehouseReq	true	"Body"
+//	@Success	200	{object}	domain.Warehouse
+//	@Router		/api/v1/admin/inventory/warehouses/{id} [put]
+func (h *WarehouseHandler) Update(c *gin.Context) {
+	var req domain.UpdateWarehouseReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to parse request body: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	warehouse, err := h.service.Update(c, c.Param("id"), &req)
+	if err != nil {
+		logger.Error("Failed to update warehouse: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.WarehouseFromModel(warehouse))
+}
+
+// ListStock godoc
+//
+//	@Summary	Admin: list stock levels at a warehouse
+//	@Tags		inventory
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string							true	"Warehouse ID"
+//	@Param		_	query		domain.ListWarehouseStockReq	true	"Query"
+//	@Success	200	{object}	domain.ListWarehouseStockRes
+//	@Router		/api/v1/admin/inventory/warehouses/{id}/stock [get]
+func (h *WarehouseHandler) ListStock(c *gin.Context) {
+	var req domain.ListWarehouseStockReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to parse request req: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+	req.WarehouseID = c.Param("id")
+	h.listStock(c, &req)
+}
+
+// ListProductStock godoc
+//
+//	@Summary	Admin: list a product's stock at each warehouse
+//	@Tags		inventory
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string							true	"Product ID"
+//	@Param		_	query		domain.ListWarehouseStockReq	true	"Query"
+//	@Success	200	{object}	domain.ListWarehouseStockRes
+//	@Router		/api/v1/admin/inventory/products/{id}/stock [get]
+func (h *WarehouseHandler) ListProductStock(c *gin.Context) {
+	var req domain.ListWarehouseStockReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to parse request req: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+	req.ProductID = c.Param("id")
+	h.listStock(c, &req)
+}
+
+func (h *WarehouseHandler) listStock(c *gin.Context, req *domain.ListWarehouseStockReq) {
+	stocks, pagination, err := h.service.ListStock(c, req)
+	if err != nil {
+		logger.Error("Failed to list warehouse stock: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ListWarehouseStockRes{
+		Stocks:     domain.WarehouseStocksFromModel(stocks),
+		Pagination: pagination,
+	})
+}
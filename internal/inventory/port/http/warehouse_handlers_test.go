@@ -0,0 +1,211 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/model"
+	srvMocks "goshop/internal/inventory/service/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/paging"
+)
+
+type WarehouseHandlerTestSuite struct {
+	suite.Suite
+	mockService *srvMocks.WarehouseService
+	handler     *WarehouseHandler
+}
+
+func (suite *WarehouseHandlerTestSuite) SetupTest() {
+	logger.Initialize(config.ProductionEnv)
+	gin.SetMode(gin.TestMode)
+	suite.mockService = srvMocks.NewWarehouseService(suite.T())
+	suite.handler = NewWarehouseHandler(suite.mockService)
+}
+
+func TestWarehouseHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(WarehouseHandlerTestSuite))
+}
+
+func (suite *WarehouseHandlerTestSuite) prepareContext(method, path string, body any) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	var buf *bytes.Buffer
+	if body != nil {
+		raw, _ := json.Marshal(body)
+		buf = bytes.NewBuffer(raw)
+	} else {
+		buf = bytes.NewBuffer(nil)
+	}
+	c.Request = httptest.NewRequest(method, path, buf)
+	return c, w
+}
+
+func (suite *WarehouseHandlerTestSuite) TestList() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/warehouses", nil)
+	suite.mockService.On("List", mock.Anything).
+		Return([]*model.Warehouse{{ID: "w1", Code: "HAN", IsDefault: true}}, nil).Once()
+
+	suite.handler.List(c)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var res struct {
+		Result []domain.Warehouse `json:"result"`
+	}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	suite.Len(res.Result, 1)
+	suite.Equal("HAN", res.Result[0].Code)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestList_Error() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/warehouses", nil)
+	suite.mockService.On("List", mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	suite.handler.List(c)
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestGetByID() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/warehouses/w1", nil)
+	c.Params = gin.Params{{Key: "id", Value: "w1"}}
+	suite.mockService.On("GetByID", mock.Anything, "w1").Return(&model.Warehouse{ID: "w1", Code: "HAN"}, nil).Once()
+
+	suite.handler.GetByID(c)
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestGetByID_NotFound() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/warehouses/missing", nil)
+	c.Params = gin.Params{{Key: "id", Value: "missing"}}
+	suite.mockService.On("GetByID", mock.Anything, "missing").
+		Return(nil, apperror.WrapMessage(apperror.ErrNotFound, nil, "warehouse not found")).Once()
+
+	suite.handler.GetByID(c)
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestCreate() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/admin/inventory/warehouses",
+		map[string]any{"code": "HAN", "name": "Hanoi", "country": "VN", "city": "Hanoi", "priority": 1})
+	suite.mockService.On("Create", mock.Anything, &domain.CreateWarehouseReq{
+		Code: "HAN", Name: "Hanoi", Country: "VN", City: "Hanoi", Priority: 1,
+	}).Return(&model.Warehouse{ID: "w1", Code: "HAN", Active: true}, nil).Once()
+
+	suite.handler.Create(c)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var res struct {
+		Result domain.Warehouse `json:"result"`
+	}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	suite.Equal("w1", res.Result.ID)
+	suite.True(res.Result.Active)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestCreate_BadBody() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/admin/inventory/warehouses", map[string]any{"priority": "first"})
+
+	suite.handler.Create(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestCreate_Error() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/admin/inventory/warehouses", map[string]any{"code": "HAN", "name": "Hanoi"})
+	suite.mockService.On("Create", mock.Anything, mock.Anything).Return(nil, errors.New("db down")).Once()
+
+	suite.handler.Create(c)
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestUpdate() {
+	c, w := suite.prepareContext(http.MethodPut, "/api/v1/admin/inventory/warehouses/w2", map[string]any{"is_default": true})
+	c.Params = gin.Params{{Key: "id", Value: "w2"}}
+	suite.mockService.On("Update", mock.Anything, "w2", &domain.UpdateWarehouseReq{IsDefault: true}).
+		Return(&model.Warehouse{ID: "w2", IsDefault: true, Active: true}, nil).Once()
+
+	suite.handler.Update(c)
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestUpdate_BadBody() {
+	c, w := suite.prepareContext(http.MethodPut, "/api/v1/admin/inventory/warehouses/w2", map[string]any{"active": "no"})
+	c.Params = gin.Params{{Key: "id", Value: "w2"}}
+
+	suite.handler.Update(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestUpdate_InactiveDefault() {
+	c, w := suite.prepareContext(http.MethodPut, "/api/v1/admin/inventory/warehouses/w1", map[string]any{"active": false})
+	c.Params = gin.Params{{Key: "id", Value: "w1"}}
+	suite.mockService.On("Update", mock.Anything, "w1", mock.Anything).
+		Return(nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "the default warehouse must stay active")).Once()
+
+	suite.handler.Update(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestListStock() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/warehouses/w1/stock?page=2&limit=5", nil)
+	c.Params = gin.Params{{Key: "id", Value: "w1"}}
+	suite.mockService.On("ListStock", mock.Anything, &domain.ListWarehouseStockReq{WarehouseID: "w1", Page: 2, Limit: 5}).
+		Return([]*model.WarehouseStock{{WarehouseID: "w1", ProductID: "p1", StockQuantity: 5, ReservedQuantity: 2}},
+			&paging.Pagination{Total: 1}, nil).Once()
+
+	suite.handler.ListStock(c)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var res struct {
+		Result domain.ListWarehouseStockRes `json:"result"`
+	}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	suite.Len(res.Result.Stocks, 1)
+	suite.Equal(3, res.Result.Stocks[0].Available)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestListStock_BadQuery() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/warehouses/w1/stock?page=abc", nil)
+	c.Params = gin.Params{{Key: "id", Value: "w1"}}
+
+	suite.handler.ListStock(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestListProductStock() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/products/p1/stock", nil)
+	c.Params = gin.Params{{Key: "id", Value: "p1"}}
+	suite.mockService.On("ListStock", mock.Anything, &domain.ListWarehouseStockReq{ProductID: "p1"}).
+		Return([]*model.WarehouseStock{{WarehouseID: "w1", ProductID: "p1"}, {WarehouseID: "w2", ProductID: "p1"}},
+			&paging.Pagination{Total: 2}, nil).Once()
+
+	suite.handler.ListProductStock(c)
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestListProductStock_BadQuery() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/products/p1/stock?limit=x", nil)
+	c.Params = gin.Params{{Key: "id", Value: "p1"}}
+
+	suite.handler.ListProductStock(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *WarehouseHandlerTestSuite) TestListProductStock_Error() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/inventory/products/p1/stock", nil)
+	c.Params = gin.Params{{Key: "id", Value: "p1"}}
+	suite.mockService.On("ListStock", mock.Anything, mock.Anything).Return(nil, nil, errors.New("db down")).Once()
+
+	suite.handler.ListProductStock(c)
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
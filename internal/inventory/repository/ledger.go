@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/model"
+	"goshop/pkg/dbs"
+	"goshop/pkg/paging"
+	"goshop/pkg/stock"
+)
+
+// ErrProductNotFound is returned by Record when the movement's product doesn't exist.
+var ErrProductNotFound = errors.New("stock ledger: product not found")
+
+//go:generate mockery --name=LedgerRepository
+type LedgerRepository interface {
+	// Record appends m to the ledger, reading the product's counters after the change and
+	// deriving the before values from m's deltas. Call it right after the counter update and
+	// inside the same db.WithTransaction: the update's row lock keeps the counters stable
+	// until commit, so the recorded before/after values are exact.
+	Record(ctx context.Context, m stock.Movement) error
+	ListByProduct(ctx context.Context, req *domain.ListLedgerReq) ([]*model.StockLedgerEntry, *paging.Pagination, error)
+	// Reconcile replays the ledger by summing each product's deltas and returns the products
+	// whose counters disagree with the sum. An empty productID checks every product.
+	Reconcile(ctx context.Context, productID string) ([]*model.Discrepancy, error)
+}
+
+type ledgerRepo struct {
+	db dbs.Database
+}
+
+func NewLedgerRepository(db dbs.Database) LedgerRepository {
+	return &ledgerRepo{db: db}
+}
+
+const recordQuery = `
+INSERT INTO stock_ledger_entries (id, created_at, product_id, kind, stock_delta, reserved_delta,
+	stock_before, stock_after, reserved_before, reserved_after, actor, reason, order_id, reservation_id, warehouse_id)
+SELECT ?, ?, id, ?, ?, ?, stock_quantity - ?, stock_quantity, reserved_quantity - ?, reserved_quantity,
+	?, NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, ''), NULLIF(?, '')
+FROM products WHERE id = ?`
+
+func (r *ledgerRepo) Record(ctx context.Context, m stock.Movement) error {
+	if m.StockDelta == 0 && m.ReservedDelta == 0 {
+		return nil
+	}
+	actor := m.Actor
+	if actor == "" {
+		actor = stock.ActorSystem
+	}
+	result := r.db.GetDB().WithContext(ctx).Exec(recordQuery,
+		uuid.New().String(), time.Now(), m.Kind, m.StockDelta, m.ReservedDelta,
+		m.StockDelta, m.ReservedDelta,
+		actor, m.Reason, m.OrderID, m.ReservationID, m.WarehouseID,
+		m.ProductID,
+	)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrProductNotFound
+	}
+	return nil
+}
+
+func (r *ledgerRepo) ListByProduct(ctx context.Context, req *domain.ListLedgerReq) ([]*model.StockLedgerEntry, *paging.Pagination, error) {
+	query := []dbs.Query{dbs.NewQuery("product_id = ?", req.ProductID)}
+	if req.Kind != "" {
+		query = append(query, dbs.NewQuery("kind = ?", req.Kind))
+	}
+
+	var total int64
+	if err := r.db.Count(ctx, &model.StockLedgerEntry{}, &total, dbs.WithQuery(query...)); err != nil {
+		return nil, nil, err
+	}
+
+	pagination := paging.New(req.Page, req.Limit, total)
+
+	var entries []*model.StockLedgerEntry
+	if err := r.db.Find(
+		ctx,
+		&entries,
+		dbs.WithQuery(query...),
+		dbs.WithLimit(int(pagination.Limit)),
+		dbs.WithOffset(int(pagination.Skip)),
+		dbs.WithOrder("created_at DESC"),
+	); err != nil {
+		return nil, nil, err
+	}
+
+	return entries, pagination, nil
+}
+
+const reconcileQuery = `
+SELECT p.id AS product_id,
+	p.stock_quantity,
+	COALESCE(SUM(l.stock_delta), 0) AS ledger_stock,
+	p.reserved_quantity,
+	COALESCE(SUM(l.reserved_delta), 0) AS ledger_reserved
+FROM products p
+LEFT JOIN stock_ledger_entries l ON l.product_id = p.id
+WHERE p.deleted_at IS NULL AND (? = '' OR p.id = ?)
+GROUP BY p.id, p.stock_quantity, p.reserved_quantity
+HAVING p.stock_quantity <> COALESCE(SUM(l.stock_delta), 0)
+	OR p.reserved_quantity <> COALESCE(SUM(l.reserved_delta), 0)
+ORDER BY p.id`
+
+func (r *ledgerRepo) Reconcile(ctx context.Context, productID string) ([]*model.Discrepancy, error) {
+	var rows []*model.Discrepancy
+	err := r.db.GetDB().WithContext(ctx).
+		Raw(reconcileQuery, productID, productID).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/model"
+	dbsMocks "goshop/pkg/dbs/mocks"
+	"goshop/pkg/stock"
+)
+
+func newLedgerSQLMockRepo(t *testing.T) (LedgerRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, m, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	g, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	return NewLedgerRepository(dbm), m
+}
+
+var recordRe = regexp.QuoteMeta(`INSERT INTO stock_ledger_entries`)
+
+func TestLedgerRepo_Record(t *testing.T) {
+	repo, m := newLedgerSQLMockRepo(t)
+	m.ExpectExec(recordRe).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), stock.MovementCommit, -2, -2, -2, -2,
+			stock.ActorSystem, "payment cleared", "o1", "r1", "w1", "p1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := repo.Record(context.Background(), stock.Movement{
+		ProductID:     "p1",
+		Kind:          stock.MovementCommit,
+		StockDelta:    -2,
+		ReservedDelta: -2,
+		Reason:        "payment cleared",
+		OrderID:       "o1",
+		ReservationID: "r1",
+		WarehouseID:   "w1",
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestLedgerRepo_Record_ProductMissing(t *testing.T) {
+	repo, m := newLedgerSQLMockRepo(t)
+	m.ExpectExec(recordRe).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := repo.Record(context.Background(), stock.Movement{ProductID: "ghost", Kind: stock.MovementRestock, StockDelta: 1})
+	require.ErrorIs(t, err, ErrProductNotFound)
+}
+
+func TestLedgerRepo_Record_Error(t *testing.T) {
+	repo, m := newLedgerSQLMockRepo(t)
+	m.ExpectExec(recordRe).WillReturnError(errors.New("db down"))
+
+	err := repo.Record(context.Background(), stock.Movement{ProductID: "p1", Kind: stock.MovementRestock, StockDelta: 1})
+	require.Error(t, err)
+}
+
+func TestLedgerRepo_Record_ZeroDeltaIsNoop(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	err := NewLedgerRepository(dbm).Record(context.Background(), stock.Movement{ProductID: "p1", Kind: stock.MovementAdjustment})
+	require.NoError(t, err)
+}
+
+func TestLedgerRepo_ListByProduct(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Count", mock.Anything, &model.StockLedgerEntry{}, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(2).(*int64) = 3 }).
+		Return(nil).Once()
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	_, pagination, err := NewLedgerRepository(dbm).ListByProduct(context.Background(), &domain.ListLedgerReq{ProductID: "p1", Kind: "reserve"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), pagination.Total)
+}
+
+func TestLedgerRepo_ListByProduct_CountError(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Count", mock.Anything, &model.StockLedgerEntry{}, mock.Anything, mock.Anything).Return(errors.New("boom")).Once()
+
+	_, _, err := NewLedgerRepository(dbm).ListByProduct(context.Background(), &domain.ListLedgerReq{ProductID: "p1"})
+	require.Error(t, err)
+}
+
+func TestLedgerRepo_ListByProduct_FindError(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Count", mock.Anything, &model.StockLedgerEntry{}, mock.Anything, mock.Anything).Return(nil).Once()
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("boom")).Once()
+
+	_, _, err := NewLedgerRepository(dbm).ListByProduct(context.Background(), &domain.ListLedgerReq{ProductID: "p1"})
+	require.Error(t, err)
+}
+
+var reconcileRe = regexp.QuoteMeta(`SELECT p.id AS product_id`)
+
+func TestLedgerRepo_Reconcile(t *testing.T) {
+	repo, m := newLedgerSQLMockRepo(t)
+	rows := sqlmock.NewRows([]string{"product_id", "stock_quantity", "ledger_stock", "reserved_quantity", "ledger_reserved"}).
+		AddRow("p1", 10, 8, 1, 1)
+	m.ExpectQuery(reconcileRe).WithArgs("p1", "p1").WillReturnRows(rows)
+
+	got, err := repo.Reconcile(context.Background(), "p1")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, "p1", got[0].ProductID)
+	require.Equal(t, 10, got[0].StockQuantity)
+	require.Equal(t, 8, got[0].LedgerStock)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestLedgerRepo_Reconcile_Error(t *testing.T) {
+	repo, m := newLedgerSQLMockRepo(t)
+	m.ExpectQuery(reconcileRe).WillReturnError(errors.New("db down"))
+
+	_, err := repo.Reconcile(context.Background(), "")
+	require.Error(t, err)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+
+	"goshop/internal/inventory/model"
+	"goshop/pkg/dbs"
+)
+
+//go:generate mockery --name=ReservedRepository
+type ReservedRepository interface {
+	// FindDrift returns the products whose reserved_quantity differs from the sum of their
+	// active stock reservations. An empty productID checks every product.
+	FindDrift(ctx context.Context, productID string) ([]*model.ReservedDrift, error)
+	// SetReserved overwrites a product's reserved_quantity with to, provided it still holds
+	// from and to fits within stock_quantity. It reports false, writing nothing, otherwise:
+	// either the counter moved in the meantime or the reservations oversell the product.
+	SetReserved(ctx context.Context, productID string, from, to int) (bool, error)
+}
+
+type reservedRepo struct {
+	db dbs.Database
+}
+
+func NewReservedRepository(db dbs.Database) ReservedRepository {
+	return &reservedRepo{db: db}
+}
+
+const findDriftQuery = `
+SELECT p.id AS product_id,
+	p.reserved_quantity,
+	COALESCE(SUM(r.quantity), 0) AS active_reserved
+FROM products p
+LEFT JOIN stock_reservations r
+	ON r.product_id = p.id AND r.status = 'active' AND r.deleted_at IS NULL
+WHERE p.deleted_at IS NULL AND (? = '' OR p.id = ?)
+GROUP BY p.id, p.reserved_quantity
+HAVING p.reserved_quantity <> COALESCE(SUM(r.quantity), 0)
+ORDER BY p.id`
+
+func (r *reservedRepo) FindDrift(ctx context.Context, productID string) ([]*model.ReservedDrift, error) {
+	var rows []*model.ReservedDrift
+	err := r.db.GetDB().WithContext(ctx).
+		Raw(findDriftQuery, productID, productID).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+func (r *reservedRepo) SetReserved(ctx context.Context, productID string, from, to int) (bool, error) {
+	result := r.db.GetDB().WithContext(ctx).
+		Exec("UPDATE products SET reserved_quantity = ? WHERE id = ? AND reserved_quantity = ? AND stock_quantity >= ?",
+			to, productID, from, to)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	dbsMocks "goshop/pkg/dbs/mocks"
+)
+
+func newReservedSQLMockRepo(t *testing.T) (ReservedRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, m, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	g, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	return NewReservedRepository(dbm), m
+}
+
+var (
+	findDriftRe   = regexp.QuoteMeta(`SELECT p.id AS product_id`)
+	setReservedRe = regexp.QuoteMeta(`UPDATE products SET reserved_quantity`)
+)
+
+func TestReservedRepo_FindDrift(t *testing.T) {
+	repo, m := newReservedSQLMockRepo(t)
+	rows := sqlmock.NewRows([]string{"product_id", "reserved_quantity", "active_reserved"}).
+		AddRow("p1", 5, 2)
+	m.ExpectQuery(findDriftRe).WithArgs("", "").WillReturnRows(rows)
+
+	got, err := repo.FindDrift(context.Background(), "")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, 5, got[0].ReservedQuantity)
+	require.Equal(t, 2, got[0].ActiveReserved)
+	require.Equal(t, -3, got[0].Delta())
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestReservedRepo_FindDrift_Error(t *testing.T) {
+	repo, m := newReservedSQLMockRepo(t)
+	m.ExpectQuery(findDriftRe).WillReturnError(errors.New("db down"))
+
+	_, err := repo.FindDrift(context.Background(), "p1")
+	require.Error(t, err)
+}
+
+func TestReservedRepo_SetReserved(t *testing.T) {
+	repo, m := newReservedSQLMockRepo(t)
+	m.ExpectExec(setReservedRe).WithArgs(2, "p1", 5, 2).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ok, err := repo.SetReserved(context.Background(), "p1", 5, 2)
+	require.NoError(t, err)
+	require.True(t, ok)
+}
+
+func TestReservedRepo_SetReserved_CounterMoved(t *testing.T) {
+	repo, m := newReservedSQLMockRepo(t)
+	m.ExpectExec(setReservedRe).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	ok, err := repo.SetReserved(context.Background(), "p1", 5, 2)
+	require.NoError(t, err)
+	require.False(t, ok)
+}
+
+func TestReservedRepo_SetReserved_Error(t *testing.T) {
+	repo, m := newReservedSQLMockRepo(t)
+	m.ExpectExec(setReservedRe).WillReturnError(errors.New("db down"))
+
+	_, err := repo.SetReserved(context.Background(), "p1", 5, 2)
+	require.Error(t, err)
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/model"
+	"goshop/pkg/dbs"
+	"goshop/pkg/paging"
+)
+
+//go:generate mockery --name=WarehouseRepository
+type WarehouseRepository interface {
+	List(ctx context.Context) ([]*model.Warehouse, error)
+	GetByID(ctx context.Context, id string) (*model.Warehouse, error)
+	Create(ctx context.Context, warehouse *model.Warehouse) error
+	Update(ctx context.Context, warehouse *model.Warehouse) error
+	// ClearDefault unsets is_default on every warehouse other than keepID. Call it in the
+	// same transaction that makes keepID the default.
+	ClearDefault(ctx context.Context, keepID string) error
+	// ListStock pages through stock levels, filtered by warehouse and/or product, with each
+	// row's warehouse preloaded.
+	ListStock(ctx context.Context, req *domain.ListWarehouseStockReq) ([]*model.WarehouseStock, *paging.Pagination, error)
+}
+
+type warehouseRepo struct {
+	db dbs.Database
+}
+
+func NewWarehouseRepository(db dbs.Database) WarehouseRepository {
+	return &warehouseRepo{db: db}
+}
+
+func (r *warehouseRepo) List(ctx context.Context) ([]*model.Warehouse, error) {
+	var warehouses []*model.Warehouse
+	if err := r.db.Find(ctx, &warehouses, dbs.WithOrder("priority, code")); err != nil {
+		return nil, err
+	}
+	return warehouses, nil
+}
+
+func (r *warehouseRepo) GetByID(ctx context.Context, id string) (*model.Warehouse, error) {
+	var warehouse model.Warehouse
+	if err := r.db.FindById(ctx, id, &warehouse); err != nil {
+		return nil, err
+	}
+	return &warehouse, nil
+}
+
+func (r *warehouseRepo) Create(ctx context.Context, warehouse *model.Warehouse) error {
+	return r.db.Create(ctx, warehouse)
+}
+
+func (r *warehouseRepo) Update(ctx context.Context, warehouse *model.Warehouse) error {
+	return r.db.Update(ctx, warehouse)
+}
+
+func (r *warehouseRepo) ClearDefault(ctx context.Context, keepID string) error {
+	return r.db.GetDB().WithContext(ctx).Model(&model.Warehouse{}).
+		Where("is_default AND id <> ?", keepID).
+		Update("is_default", false).Error
+}
+
+func (r *warehouseRepo) ListStock(ctx context.Context, req *domain.ListWarehouseStockReq) ([]*model.WarehouseStock, *paging.Pagination, error) {
+	var query []dbs.Query
+	if req.WarehouseID != "" {
+		query = append(query, dbs.NewQuery("warehouse_id = ?", req.WarehouseID))
+	}
+	if req.ProductID != "" {
+		query = append(query, dbs.NewQuery("product_id = ?", req.ProductID))
+	}
+
+	var total int64
+	if err := r.db.Count(ctx, &model.WarehouseStock{}, &total, dbs.WithQuery(query...)); err != nil {
+		return nil, nil, err
+	}
+
+	pagination := paging.New(req.Page, req.Limit, total)
+
+	var stocks []*model.WarehouseStock
+	if err := r.db.Find(
+		ctx,
+		&stocks,
+		dbs.WithQuery(query...),
+		dbs.WithLimit(int(pagination.Limit)),
+		dbs.WithOffset(int(pagination.Skip)),
+		dbs.WithOrder("product_id, warehouse_id"),
+		dbs.WithPreload([]string{"Warehouse"}),
+	); err != nil {
+		return nil, nil, err
+	}
+
+	return stocks, pagination, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/model"
+	dbsMocks "goshop/pkg/dbs/mocks"
+)
+
+func TestWarehouseRepo_List(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Find", mock.Anything, mock.AnythingOfType("*[]*model.Warehouse"), mock.Anything).
+		Run(func(args mock.Arguments) {
+			out := args.Get(1).(*[]*model.Warehouse)
+			*out = []*model.Warehouse{{ID: "w1"}, {ID: "w2"}}
+		}).Return(nil).Once()
+
+	got, err := NewWarehouseRepository(dbm).List(context.Background())
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+}
+
+func TestWarehouseRepo_List_Error(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db")).Once()
+
+	got, err := NewWarehouseRepository(dbm).List(context.Background())
+	require.Error(t, err)
+	require.Nil(t, got)
+}
+
+func TestWarehouseRepo_GetByID(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindById", mock.Anything, "w1", &model.Warehouse{}).Return(nil).Once()
+	dbm.On("FindById", mock.Anything, "missing", &model.Warehouse{}).Return(gorm.ErrRecordNotFound).Once()
+	repo := NewWarehouseRepository(dbm)
+
+	got, err := repo.GetByID(context.Background(), "w1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+
+	got, err = repo.GetByID(context.Background(), "missing")
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.Nil(t, got)
+}
+
+func TestWarehouseRepo_CreateUpdate(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	w := &model.Warehouse{ID: "w1", Code: "HAN"}
+	dbm.On("Create", mock.Anything, w).Return(nil).Once()
+	dbm.On("Update", mock.Anything, w).Return(nil).Once()
+	repo := NewWarehouseRepository(dbm)
+
+	require.NoError(t, repo.Create(context.Background(), w))
+	require.NoError(t, repo.Update(context.Background(), w))
+}
+
+func TestWarehouseRepo_ClearDefault(t *testing.T) {
+	sqlDB, m, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	g, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	m.ExpectExec(regexp.QuoteMeta(`UPDATE "warehouses" SET "is_default"=$1,"updated_at"=$2 WHERE is_default AND id <> $3`)).
+		WithArgs(false, sqlmock.AnyArg(), "w2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, NewWarehouseRepository(dbm).ClearDefault(context.Background(), "w2"))
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestWarehouseRepo_ListStock(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Count", mock.Anything, &model.WarehouseStock{}, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(2).(*int64) = 3 }).Return(nil).Once()
+	dbm.On("Find", mock.Anything, mock.AnythingOfType("*[]*model.WarehouseStock"),
+		mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			out := args.Get(1).(*[]*model.WarehouseStock)
+			*out = []*model.WarehouseStock{{WarehouseID: "w1", ProductID: "p1"}}
+		}).Return(nil).Once()
+
+	got, pagination, err := NewWarehouseRepository(dbm).ListStock(context.Background(),
+		&domain.ListWarehouseStockReq{WarehouseID: "w1", ProductID: "p1", Page: 1, Limit: 2})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, int64(3), pagination.Total)
+}
+
+func TestWarehouseRepo_ListStock_Errors(t *testing.T) {
+	t.Run("count", func(t *testing.T) {
+		dbm := dbsMocks.NewDatabase(t)
+		dbm.On("Count", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db")).Once()
+		_, _, err := NewWarehouseRepository(dbm).ListStock(context.Background(), &domain.ListWarehouseStockReq{})
+		require.Error(t, err)
+	})
+	t.Run("find", func(t *testing.T) {
+		dbm := dbsMocks.NewDatabase(t)
+		dbm.On("Count", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+		dbm.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(errors.New("db")).Once()
+		_, _, err := NewWarehouseRepository(dbm).ListStock(context.Background(), &domain.ListWarehouseStockReq{})
+		require.Error(t, err)
+	})
+}
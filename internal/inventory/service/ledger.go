@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/model"
+	"goshop/internal/inventory/repository"
+	"goshop/pkg/apperror"
+	"goshop/pkg/paging"
+)
+
+//go:generate mockery --name=LedgerService
+type LedgerService interface {
+	ListByProduct(ctx context.Context, req *domain.ListLedgerReq) ([]*model.StockLedgerEntry, *paging.Pagination, error)
+	// Reconcile replays the ledger against the current stock counters and returns every
+	// product the two disagree on; an empty result means the ledger accounts for all stock.
+	Reconcile(ctx context.Context, req *domain.ReconcileReq) ([]*model.Discrepancy, error)
+}
+
+type ledgerService struct {
+	repo repository.LedgerRepository
+}
+
+func NewLedgerService(repo repository.LedgerRepository) LedgerService {
+	return &ledgerService{repo: repo}
+}
+
+func (s *ledgerService) ListByProduct(ctx context.Context, req *domain.ListLedgerReq) ([]*model.StockLedgerEntry, *paging.Pagination, error) {
+	if req.ProductID == "" {
+		return nil, nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "product id is required")
+	}
+	return s.repo.ListByProduct(ctx, req)
+}
+
+func (s *ledgerService) Reconcile(ctx context.Context, req *domain.ReconcileReq) ([]*model.Discrepancy, error) {
+	discrepancies, err := s.repo.Reconcile(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range discrepancies {
+		logger.Warnf("stock ledger mismatch: product=%s stock=%d ledger_stock=%d reserved=%d ledger_reserved=%d",
+			d.ProductID, d.StockQuantity, d.LedgerStock, d.ReservedQuantity, d.LedgerReserved)
+	}
+	return discrepancies, nil
+}
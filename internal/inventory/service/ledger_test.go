@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/model"
+	repoMocks "goshop/internal/inventory/repository/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/paging"
+)
+
+type LedgerServiceTestSuite struct {
+	suite.Suite
+	mockRepo *repoMocks.LedgerRepository
+	service  LedgerService
+}
+
+func (suite *LedgerServiceTestSuite) SetupTest() {
+	logger.Initialize(config.ProductionEnv)
+
+	suite.mockRepo = repoMocks.NewLedgerRepository(suite.T())
+	suite.service = NewLedgerService(suite.mockRepo)
+}
+
+func TestLedgerServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(LedgerServiceTestSuite))
+}
+
+func (suite *LedgerServiceTestSuite) TestListByProduct() {
+	req := &domain.ListLedgerReq{ProductID: "p1", Page: 2, Limit: 10}
+	suite.mockRepo.On("ListByProduct", mock.Anything, req).
+		Return([]*model.StockLedgerEntry{{ID: "l1"}}, &paging.Pagination{Total: 11}, nil).Once()
+
+	entries, pagination, err := suite.service.ListByProduct(context.Background(), req)
+	suite.NoError(err)
+	suite.Len(entries, 1)
+	suite.Equal(int64(11), pagination.Total)
+}
+
+func (suite *LedgerServiceTestSuite) TestListByProduct_MissingProduct() {
+	_, _, err := suite.service.ListByProduct(context.Background(), &domain.ListLedgerReq{})
+	var appErr *apperror.AppError
+	suite.ErrorAs(err, &appErr)
+	suite.Equal(apperror.ErrBadRequest.Code, appErr.Code)
+}
+
+func (suite *LedgerServiceTestSuite) TestReconcile() {
+	suite.mockRepo.On("Reconcile", mock.Anything, "").
+		Return([]*model.Discrepancy{{ProductID: "p1", StockQuantity: 5, LedgerStock: 4}}, nil).Once()
+
+	got, err := suite.service.Reconcile(context.Background(), &domain.ReconcileReq{})
+	suite.NoError(err)
+	suite.Len(got, 1)
+}
+
+func (suite *LedgerServiceTestSuite) TestReconcile_Error() {
+	suite.mockRepo.On("Reconcile", mock.Anything, "p1").Return(nil, errors.New("db down")).Once()
+
+	_, err := suite.service.Reconcile(context.Background(), &domain.ReconcileReq{ProductID: "p1"})
+	suite.Error(err)
+}
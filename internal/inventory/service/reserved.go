@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/model"
+	"goshop/internal/inventory/repository"
+	"goshop/pkg/dbs"
+	"goshop/pkg/stock"
+)
+
+//go:generate mockery --name=ReservedService
+type ReservedService interface {
+	// ReconcileReserved recomputes each product's reserved_quantity from its active stock
+	// reservations and returns every product that drifted. Unless req.DryRun is set, the
+	// drifted counters are reset in one transaction, each with a repair entry in the stock
+	// ledger; a product whose counter moved since it was read is left for the next run.
+	ReconcileReserved(ctx context.Context, req *domain.ReconcileReservedReq) ([]*model.ReservedDrift, error)
+}
+
+type reservedService struct {
+	db       dbs.Database
+	reserved repository.ReservedRepository
+	ledger   repository.LedgerRepository
+}
+
+func NewReservedService(
+	db dbs.Database,
+	reserved repository.ReservedRepository,
+	ledger repository.LedgerRepository,
+) ReservedService {
+	return &reservedService{db: db, reserved: reserved, ledger: ledger}
+}
+
+func (s *reservedService) ReconcileReserved(ctx context.Context, req *domain.ReconcileReservedReq) ([]*model.ReservedDrift, error) {
+	drifts, err := s.reserved.FindDrift(ctx, req.ProductID)
+	if err != nil {
+		return nil, err
+	}
+	for _, d := range drifts {
+		logger.Warnf("reserved_quantity drift: product=%s reserved=%d active_reservations=%d",
+			d.ProductID, d.ReservedQuantity, d.ActiveReserved)
+	}
+	if req.DryRun || len(drifts) == 0 {
+		return drifts, nil
+	}
+
+	err = s.db.WithTransaction(func() error {
+		for _, d := range drifts {
+			ok, err := s.reserved.SetReserved(ctx, d.ProductID, d.ReservedQuantity, d.ActiveReserved)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				logger.Warnf("reserved_quantity drift: product=%s not repaired, counter moved or reservations exceed stock",
+					d.ProductID)
+				continue
+			}
+			if err := s.ledger.Record(ctx, stock.Movement{
+				ProductID:     d.ProductID,
+				Kind:          stock.MovementRepair,
+				ReservedDelta: d.Delta(),
+				Actor:         req.ActorID,
+				Reason:        "reserved_quantity reconciliation",
+			}); err != nil {
+				return err
+			}
+			d.Repaired = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return drifts, nil
+}
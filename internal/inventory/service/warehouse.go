@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/quangdangfit/gocommon/validation"
+	"gorm.io/gorm"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/model"
+	"goshop/internal/inventory/repository"
+	"goshop/pkg/apperror"
+	"goshop/pkg/dbs"
+	"goshop/pkg/paging"
+)
+
+//go:generate mockery --name=WarehouseService
+type WarehouseService interface {
+	List(ctx context.Context) ([]*model.Warehouse, error)
+	GetByID(ctx context.Context, id string) (*model.Warehouse, error)
+	Create(ctx context.Context, req *domain.CreateWarehouseReq) (*model.Warehouse, error)
+	// Update changes a warehouse. Making it the default clears the flag on the previous
+	// default in the same transaction; the default warehouse can't be deactivated.
+	Update(ctx context.Context, id string, req *domain.UpdateWarehouseReq) (*model.Warehouse, error)
+	ListStock(ctx context.Context, req *domain.ListWarehouseStockReq) ([]*model.WarehouseStock, *paging.Pagination, error)
+}
+
+type warehouseService struct {
+	validator validation.Validation
+	db        dbs.Database
+	repo      repository.WarehouseRepository
+}
+
+func NewWarehouseService(validator validation.Validation, db dbs.Database, repo repository.WarehouseRepository) WarehouseService {
+	return &warehouseService{validator: validator, db: db, repo: repo}
+}
+
+func (s *warehouseService) List(ctx context.Context) ([]*model.Warehouse, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *warehouseService) GetByID(ctx context.Context, id string) (*model.Warehouse, error) {
+	warehouse, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, err, "warehouse not found")
+	}
+	return warehouse, err
+}
+
+func (s *warehouseService) Create(ctx context.Context, req *domain.CreateWarehouseReq) (*model.Warehouse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	warehouse := model.Warehouse{
+		Code:      req.Code,
+		Name:      req.Name,
+		Country:   req.Country,
+		City:      req.City,
+		Priority:  req.Priority,
+		IsDefault: req.IsDefault,
+		Active:    req.Active == nil || *req.Active,
+	}
+	if warehouse.IsDefault && !warehouse.Active {
+		return nil, errInactiveDefault
+	}
+	// Clear the old default first: at most one live warehouse may hold the flag.
+	err := s.db.WithTransaction(func() error {
+		if warehouse.IsDefault {
+			if err := s.repo.ClearDefault(ctx, ""); err != nil {
+				return err
+			}
+		}
+		return s.repo.Create(ctx, &warehouse)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &warehouse, nil
+}
+
+func (s *warehouseService) Update(ctx context.Context, id string, req *domain.UpdateWarehouseReq) (*model.Warehouse, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	warehouse, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != "" {
+		warehouse.Name = req.Name
+	}
+	if req.Country != "" {
+		warehouse.Country = req.Country
+	}
+	if req.City != "" {
+		warehouse.City = req.City
+	}
+	if req.Priority != nil {
+		warehouse.Priority = *req.Priority
+	}
+	if req.Active != nil {
+		warehouse.Active = *req.Active
+	}
+	becomesDefault := req.IsDefault && !warehouse.IsDefault
+	if req.IsDefault {
+		warehouse.IsDefault = true
+	}
+	if warehouse.IsDefault && !warehouse.Active {
+		return nil, errInactiveDefault
+	}
+
+	err = s.db.WithTransaction(func() error {
+		if becomesDefault {
+			if err := s.repo.ClearDefault(ctx, warehouse.ID); err != nil {
+				return err
+			}
+		}
+		return s.repo.Update(ctx, warehouse)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return warehouse, nil
+}
+
+func (s *warehouseService) ListStock(ctx context.Context, req *domain.ListWarehouseStockReq) ([]*model.WarehouseStock, *paging.Pagination, error) {
+	return s.repo.ListStock(ctx, req)
+}
+
+var errInactiveDefault = apperror.WrapMessage(apperror.ErrBadRequest, nil, "the default warehouse must stay active")
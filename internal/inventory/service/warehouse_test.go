@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/quangdangfit/gocommon/validation"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"goshop/internal/inventory/domain"
+	"goshop/internal/inventory/model"
+	repoMocks "goshop/internal/inventory/repository/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	dbsMocks "goshop/pkg/dbs/mocks"
+	"goshop/pkg/paging"
+)
+
+type WarehouseServiceTestSuite struct {
+	suite.Suite
+	mockDB   *dbsMocks.Database
+	mockRepo *repoMocks.WarehouseRepository
+	service  WarehouseService
+}
+
+func (suite *WarehouseServiceTestSuite) SetupTest() {
+	logger.Initialize(config.ProductionEnv)
+
+	suite.mockDB = dbsMocks.NewDatabase(suite.T())
+	suite.mockRepo = repoMocks.NewWarehouseRepository(suite.T())
+	suite.service = NewWarehouseService(validation.New(), suite.mockDB, suite.mockRepo)
+}
+
+func TestWarehouseServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(WarehouseServiceTestSuite))
+}
+
+func (suite *WarehouseServiceTestSuite) runTx() {
+	suite.mockDB.On("WithTransaction", mock.Anything).
+		Return(func(fn func() error) error { return fn() }).Once()
+}
+
+func (suite *WarehouseServiceTestSuite) TestList() {
+	suite.mockRepo.On("List", mock.Anything).Return([]*model.Warehouse{{ID: "w1"}}, nil).Once()
+
+	got, err := suite.service.List(context.Background())
+	suite.NoError(err)
+	suite.Len(got, 1)
+}
+
+func (suite *WarehouseServiceTestSuite) TestGetByID_NotFound() {
+	suite.mockRepo.On("GetByID", mock.Anything, "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := suite.service.GetByID(context.Background(), "missing")
+	var appErr *apperror.AppError
+	suite.ErrorAs(err, &appErr)
+	suite.Equal(apperror.ErrNotFound.Code, appErr.Code)
+}
+
+func (suite *WarehouseServiceTestSuite) TestCreate_ActiveByDefault() {
+	suite.runTx()
+	suite.mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(w *model.Warehouse) bool {
+		return w.Code == "HAN" && w.Active && !w.IsDefault
+	})).Return(nil).Once()
+
+	got, err := suite.service.Create(context.Background(), &domain.CreateWarehouseReq{Code: "HAN", Name: "Hanoi"})
+	suite.NoError(err)
+	suite.True(got.Active)
+}
+
+func (suite *WarehouseServiceTestSuite) TestCreate_DefaultClearsPrevious() {
+	suite.runTx()
+	clearCall := suite.mockRepo.On("ClearDefault", mock.Anything, "").Return(nil).Once()
+	suite.mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(w *model.Warehouse) bool {
+		return w.IsDefault
+	})).Return(nil).Once().NotBefore(clearCall)
+
+	_, err := suite.service.Create(context.Background(), &domain.CreateWarehouseReq{Code: "HAN", Name: "Hanoi", IsDefault: true})
+	suite.NoError(err)
+}
+
+func (suite *WarehouseServiceTestSuite) TestCreate_InactiveDefaultRejected() {
+	inactive := false
+	_, err := suite.service.Create(context.Background(), &domain.CreateWarehouseReq{
+		Code: "HAN", Name: "Hanoi", IsDefault: true, Active: &inactive,
+	})
+	var appErr *apperror.AppError
+	suite.ErrorAs(err, &appErr)
+	suite.Equal(apperror.ErrBadRequest.Code, appErr.Code)
+}
+
+func (suite *WarehouseServiceTestSuite) TestCreate_Invalid() {
+	_, err := suite.service.Create(context.Background(), &domain.CreateWarehouseReq{Name: "no code"})
+	suite.Error(err)
+}
+
+func (suite *WarehouseServiceTestSuite) TestCreate_RepoError() {
+	suite.runTx()
+	suite.mockRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("duplicate code")).Once()
+
+	got, err := suite.service.Create(context.Background(), &domain.CreateWarehouseReq{Code: "HAN", Name: "Hanoi"})
+	suite.Error(err)
+	suite.Nil(got)
+}
+
+func (suite *WarehouseServiceTestSuite) TestUpdate_MakeDefault() {
+	priority := 3
+	suite.mockRepo.On("GetByID", mock.Anything, "w2").
+		Return(&model.Warehouse{ID: "w2", Code: "SGN", Name: "Saigon", Active: true}, nil).Once()
+	suite.runTx()
+	clearCall := suite.mockRepo.On("ClearDefault", mock.Anything, "w2").Return(nil).Once()
+	suite.mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(w *model.Warehouse) bool {
+		return w.IsDefault && w.Priority == 3 && w.City == "Ho Chi Minh City" && w.Name == "Saigon"
+	})).Return(nil).Once().NotBefore(clearCall)
+
+	got, err := suite.service.Update(context.Background(), "w2", &domain.UpdateWarehouseReq{
+		City: "Ho Chi Minh City", Priority: &priority, IsDefault: true,
+	})
+	suite.NoError(err)
+	suite.True(got.IsDefault)
+}
+
+func (suite *WarehouseServiceTestSuite) TestUpdate_AlreadyDefaultDoesNotClear() {
+	suite.mockRepo.On("GetByID", mock.Anything, "w1").
+		Return(&model.Warehouse{ID: "w1", IsDefault: true, Active: true}, nil).Once()
+	suite.runTx()
+	suite.mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := suite.service.Update(context.Background(), "w1", &domain.UpdateWarehouseReq{Name: "Main", IsDefault: true})
+	suite.NoError(err)
+}
+
+func (suite *WarehouseServiceTestSuite) TestUpdate_DeactivateDefaultRejected() {
+	inactive := false
+	suite.mockRepo.On("GetByID", mock.Anything, "w1").
+		Return(&model.Warehouse{ID: "w1", IsDefault: true, Active: true}, nil).Once()
+
+	_, err := suite.service.Update(context.Background(), "w1", &domain.UpdateWarehouseReq{Active: &inactive})
+	var appErr *apperror.AppError
+	suite.ErrorAs(err, &appErr)
+	suite.Equal(apperror.ErrBadRequest.Code, appErr.Code)
+}
+
+func (suite *WarehouseServiceTestSuite) TestUpdate_NotFound() {
+	suite.mockRepo.On("GetByID", mock.Anything, "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	_, err := suite.service.Update(context.Background(), "missing", &domain.UpdateWarehouseReq{Name: "x"})
+	var appErr *apperror.AppError
+	suite.ErrorAs(err, &appErr)
+	suite.Equal(apperror.ErrNotFound.Code, appErr.Code)
+}
+
+func (suite *WarehouseServiceTestSuite) TestListStock() {
+	req := &domain.ListWarehouseStockReq{ProductID: "p1"}
+	suite.mockRepo.On("ListStock", mock.Anything, req).
+		Return([]*model.WarehouseStock{{WarehouseID: "w1", ProductID: "p1"}}, &paging.Pagination{Total: 1}, nil).Once()
+
+	got, pagination, err := suite.service.ListStock(context.Background(), req)
+	suite.NoError(err)
+	suite.Len(got, 1)
+	suite.Equal(int64(1), pagination.Total)
+}
@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// LowStockAlert records the last time admins were emailed about a product running low, so one
+// product doesn't page them again until the cooldown has passed.
+type LowStockAlert struct {
+	ProductID string    `json:"product_id" gorm:"primary_key"`
+	AlertedAt time.Time `json:"alerted_at" gorm:"not null"`
+	Available int       `json:"available" gorm:"not null"`
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"goshop/pkg/dbs"
+)
+
+//go:generate mockery --name=LowStockAlertRepository
+type LowStockAlertRepository interface {
+	// Claim reports whether the caller should alert admins about productID now: true when the
+	// product has never been alerted or its last alert is older than cooldown, in which case the
+	// alert time is moved to now. Safe under concurrent callers; at most one wins per cooldown.
+	Claim(ctx context.Context, productID string, available int, cooldown time.Duration) (bool, error)
+}
+
+type lowStockAlertRepo struct {
+	db dbs.Database
+}
+
+func NewLowStockAlertRepository(db dbs.Database) LowStockAlertRepository {
+	return &lowStockAlertRepo{db: db}
+}
+
+const claimLowStockAlertQuery = `INSERT INTO low_stock_alerts (product_id, alerted_at, available)
+VALUES (?, ?, ?)
+ON CONFLICT (product_id) DO UPDATE SET alerted_at = EXCLUDED.alerted_at, available = EXCLUDED.available
+WHERE low_stock_alerts.alerted_at <= ?`
+
+func (r *lowStockAlertRepo) Claim(ctx context.Context, productID string, available int, cooldown time.Duration) (bool, error) {
+	now := time.Now()
+	res := r.db.GetDB().WithContext(ctx).
+		Exec(claimLowStockAlertQuery, productID, now, available, now.Add(-cooldown))
+	if res.Error != nil {
+		return false, res.Error
+	}
+	return res.RowsAffected == 1, nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	dbsMocks "goshop/pkg/dbs/mocks"
+)
+
+func newLowStockAlertRepo(t *testing.T) (LowStockAlertRepository, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, m, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	g, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	return NewLowStockAlertRepository(dbm), m
+}
+
+var claimLowStockAlertRe = regexp.QuoteMeta(`INSERT INTO low_stock_alerts (product_id, alerted_at, available)`)
+
+func TestLowStockAlertRepo_Claim(t *testing.T) {
+	tests := []struct {
+		name     string
+		affected int64
+		want     bool
+	}{
+		{"first_alert_or_cooldown_passed", 1, true},
+		{"within_cooldown", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, m := newLowStockAlertRepo(t)
+			m.ExpectExec(claimLowStockAlertRe).
+				WithArgs("p1", sqlmock.AnyArg(), 3, sqlmock.AnyArg()).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			got, err := repo.Claim(context.Background(), "p1", 3, time.Hour)
+			require.NoError(t, err)
+			require.Equal(t, tt.want, got)
+			require.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestLowStockAlertRepo_Claim_Error(t *testing.T) {
+	repo, m := newLowStockAlertRepo(t)
+	m.ExpectExec(claimLowStockAlertRe).WillReturnError(errors.New("db down"))
+
+	_, err := repo.Claim(context.Background(), "p1", 3, time.Hour)
+	require.Error(t, err)
+}
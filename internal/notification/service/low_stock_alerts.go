@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/notification/repository"
+	"goshop/pkg/eventbus"
+	"goshop/pkg/notification"
+)
+
+// SubscribeLowStockAlerts emails every admin when a product runs low. A product alerts at most
+// once per cooldown, across replicas, however many orders keep it under its threshold. Admins
+// opt out through the "low_stock" email preference, which the notifier honours per recipient.
+func SubscribeLowStockAlerts(
+	bus eventbus.Bus,
+	admins AdminLookup,
+	alerts repository.LowStockAlertRepository,
+	notifier notification.Notifier,
+	cooldown time.Duration,
+) {
+	bus.Subscribe(eventbus.TopicLowStock, func(ctx context.Context, ev eventbus.Event) {
+		ls, ok := ev.(eventbus.LowStock)
+		if !ok {
+			logger.Errorf("low stock alert: unexpected event %T on %s", ev, eventbus.TopicLowStock)
+			return
+		}
+		// Find who to tell before claiming the cooldown, so a failed lookup doesn't lose the alert.
+		emails, err := admins.ListAdminEmails(ctx)
+		if err != nil {
+			logger.Error("Failed to list admins for low stock alert: ", err)
+			return
+		}
+		if len(emails) == 0 {
+			logger.Warnf("low stock for product %s but no admin to notify", ls.ProductID)
+			return
+		}
+		claimed, err := alerts.Claim(ctx, ls.ProductID, ls.Available, cooldown)
+		if err != nil {
+			logger.Error("Failed to de-duplicate low stock alert: ", err)
+			return
+		}
+		if !claimed {
+			return
+		}
+		for _, email := range emails {
+			if err := notifier.SendLowStock(ctx, ls.ProductID, email, ls.ProductName, ls.Available, ls.Threshold, ls.ReorderQuantity); err != nil {
+				logger.Error("Failed to send low stock notification: ", err)
+			}
+		}
+	})
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"goshop/pkg/config"
+	"goshop/pkg/eventbus"
+	notificationMocks "goshop/pkg/notification/mocks"
+)
+
+type stubAdmins struct {
+	emails []string
+	err    error
+}
+
+func (s *stubAdmins) ListAdminEmails(context.Context) ([]string, error) {
+	return s.emails, s.err
+}
+
+// stubAlerts claims each product once, like the table does within one cooldown window.
+type stubAlerts struct {
+	claimed map[string]bool
+	err     error
+}
+
+func (s *stubAlerts) Claim(_ context.Context, productID string, _ int, _ time.Duration) (bool, error) {
+	if s.err != nil {
+		return false, s.err
+	}
+	if s.claimed == nil {
+		s.claimed = map[string]bool{}
+	}
+	if s.claimed[productID] {
+		return false, nil
+	}
+	s.claimed[productID] = true
+	return true, nil
+}
+
+func TestSubscribeLowStockAlerts_EmailsEachAdminOncePerProduct(t *testing.T) {
+	logger.Initialize(config.ProductionEnv)
+	notifier := notificationMocks.NewNotifier(t)
+	bus := &syncBus{}
+	SubscribeLowStockAlerts(bus, &stubAdmins{emails: []string{"a1@x.com", "a2@x.com"}}, &stubAlerts{}, notifier, time.Hour)
+
+	notifier.On("SendLowStock", mock.Anything, "p1", "a1@x.com", "Mug", 3, 5, 40).Return(nil).Once()
+	notifier.On("SendLowStock", mock.Anything, "p1", "a2@x.com", "Mug", 3, 5, 40).Return(errors.New("smtp down")).Once()
+	notifier.On("SendLowStock", mock.Anything, "p2", "a1@x.com", "", 0, 2, 0).Return(nil).Once()
+	notifier.On("SendLowStock", mock.Anything, "p2", "a2@x.com", "", 0, 2, 0).Return(nil).Once()
+
+	ctx := context.Background()
+	require.NoError(t, bus.Publish(ctx, eventbus.LowStock{ProductID: "p1", ProductName: "Mug", Available: 3, Threshold: 5, ReorderQuantity: 40}))
+	// Still low after the next order: already alerted within the cooldown.
+	require.NoError(t, bus.Publish(ctx, eventbus.LowStock{ProductID: "p1", ProductName: "Mug", Available: 2, Threshold: 5}))
+	require.NoError(t, bus.Publish(ctx, eventbus.LowStock{ProductID: "p2", Available: 0, Threshold: 2}))
+}
+
+func TestSubscribeLowStockAlerts_SkipsOnErrors(t *testing.T) {
+	logger.Initialize(config.ProductionEnv)
+	tests := []struct {
+		name   string
+		admins *stubAdmins
+		alerts *stubAlerts
+	}{
+		{"claim_error", &stubAdmins{emails: []string{"a1@x.com"}}, &stubAlerts{err: errors.New("db down")}},
+		{"admin_lookup_error", &stubAdmins{err: errors.New("db down")}, &stubAlerts{}},
+		{"no_admins", &stubAdmins{}, &stubAlerts{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifier := notificationMocks.NewNotifier(t)
+			bus := &syncBus{}
+			SubscribeLowStockAlerts(bus, tt.admins, tt.alerts, notifier, time.Hour)
+
+			require.NoError(t, bus.Publish(context.Background(), eventbus.LowStock{ProductID: "p1", Available: 1, Threshold: 5}))
+		})
+	}
+}
+
+func TestSubscribeLowStockAlerts_AdminLookupErrorKeepsAlert(t *testing.T) {
+	logger.Initialize(config.ProductionEnv)
+	notifier := notificationMocks.NewNotifier(t)
+	admins := &stubAdmins{err: errors.New("db down")}
+	bus := &syncBus{}
+	SubscribeLowStockAlerts(bus, admins, &stubAlerts{}, notifier, time.Hour)
+
+	ctx := context.Background()
+	require.NoError(t, bus.Publish(ctx, eventbus.LowStock{ProductID: "p1", Available: 1, Threshold: 5}))
+
+	// The failed lookup didn't take the cooldown: the next event still alerts.
+	admins.err, admins.emails = nil, []string{"a1@x.com"}
+	notifier.On("SendLowStock", mock.Anything, "p1", "a1@x.com", "", 1, 5, 0).Return(nil).Once()
+	require.NoError(t, bus.Publish(ctx, eventbus.LowStock{ProductID: "p1", Available: 1, Threshold: 5}))
+}
+
+func TestSubscribeLowStockAlerts_IgnoresUnexpectedEvent(t *testing.T) {
+	logger.Initialize(config.ProductionEnv)
+	bus := &syncBus{}
+	SubscribeLowStockAlerts(bus, &stubAdmins{emails: []string{"a1@x.com"}}, &stubAlerts{}, notificationMocks.NewNotifier(t), time.Hour)
+
+	require.NotPanics(t, func() {
+		bus.handlers[eventbus.TopicLowStock][0](context.Background(), eventbus.OrderPaid{})
+	})
+}
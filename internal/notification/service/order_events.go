@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"gorm.io/gorm"
+
+	"goshop/pkg/eventbus"
+	"goshop/pkg/notification"
+)
+
+// orderStatusTopics are the order events that tell the buyer their order moved to a new status.
+// Paid has an email of its own, which carries the invoice.
+var orderStatusTopics = []string{
+	eventbus.TopicOrderPaymentFailed,
+	eventbus.TopicOrderInProgress,
+	eventbus.TopicOrderDone,
+	eventbus.TopicOrderCancelled,
+	eventbus.TopicOrderStatusChanged,
+}
+
+// shipmentTopics are the shipment events that tell the buyer where a parcel of their order is.
+var shipmentTopics = []string{
+	eventbus.TopicShipmentPacked,
+	eventbus.TopicShipmentShipped,
+	eventbus.TopicShipmentDelivered,
+}
+
+// InvoiceSource renders an order's invoice as a PDF, with the name to attach it under.
+type InvoiceSource interface {
+	InvoicePDF(ctx context.Context, orderID string) (string, []byte, error)
+}
+
+// SubscribeOrderEmails sends the customer-facing order and shipment emails from their events, so
+// the order domain only records what happened and never talks to a mail transport itself. The
+// payment confirmation attaches the invoice from invoices, or it follows on its own when the
+// order is invoiced later.
+func SubscribeOrderEmails(bus eventbus.Bus, notifier notification.Notifier, invoices InvoiceSource) {
+	bus.Subscribe(eventbus.TopicOrderCreated, func(ctx context.Context, ev eventbus.Event) {
+		order := ev.(eventbus.OrderEvent).Payload()
+		if !addressable(ev.Topic(), order) {
+			return
+		}
+		if err := notifier.SendOrderPlaced(ctx, order.OrderID, order.UserEmail); err != nil {
+			logger.Error("Failed to send order placed notification: ", err)
+		}
+	})
+
+	bus.Subscribe(eventbus.TopicOrderPaid, func(ctx context.Context, ev eventbus.Event) {
+		order := ev.(eventbus.OrderEvent).Payload()
+		if !addressable(ev.Topic(), order) {
+			return
+		}
+		var attachment *notification.Attachment
+		filename, pdf, err := invoices.InvoicePDF(ctx, order.OrderID)
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			// An authorized payment: the invoice follows with OrderInvoiced once it is captured.
+		case err != nil:
+			// The confirmation still goes out; the buyer can download the invoice later.
+			logger.Errorf("Failed to render invoice for order %s: %s", order.OrderID, err)
+		default:
+			attachment = &notification.Attachment{Filename: filename, ContentType: "application/pdf", Data: pdf}
+		}
+		if err := notifier.SendOrderPaid(ctx, order.OrderID, order.UserEmail, attachment); err != nil {
+			logger.Error("Failed to send order paid notification: ", err)
+		}
+	})
+
+	bus.Subscribe(eventbus.TopicOrderInvoiced, func(ctx context.Context, ev eventbus.Event) {
+		order := ev.(eventbus.OrderEvent).Payload()
+		if !addressable(ev.Topic(), order) {
+			return
+		}
+		filename, pdf, err := invoices.InvoicePDF(ctx, order.OrderID)
+		if err != nil {
+			logger.Errorf("Failed to render invoice for order %s: %s", order.OrderID, err)
+			return
+		}
+		invoice := notification.Attachment{Filename: filename, ContentType: "application/pdf", Data: pdf}
+		if err := notifier.SendInvoice(ctx, order.OrderID, order.UserEmail, invoice); err != nil {
+			logger.Error("Failed to send invoice notification: ", err)
+		}
+	})
+
+	for _, topic := range orderStatusTopics {
+		bus.Subscribe(topic, func(ctx context.Context, ev eventbus.Event) {
+			order := ev.(eventbus.OrderEvent).Payload()
+			if !addressable(ev.Topic(), order) {
+				return
+			}
+			if err := notifier.SendOrderStatusChanged(ctx, order.OrderID, order.UserEmail, order.Status); err != nil {
+				logger.Error("Failed to send order status changed notification: ", err)
+			}
+		})
+	}
+
+	for _, topic := range shipmentTopics {
+		bus.Subscribe(topic, func(ctx context.Context, ev eventbus.Event) {
+			shipment := ev.(eventbus.ShipmentEvent).Payload()
+			if shipment.UserEmail == "" {
+				logger.Warnf("%s for order %s has no user email, skipping notification", ev.Topic(), shipment.OrderID)
+				return
+			}
+			if err := notifier.SendShipmentUpdate(ctx, shipment.OrderID, shipment.UserEmail, shipment.Status,
+				shipment.Carrier, shipment.TrackingNumber); err != nil {
+				logger.Error("Failed to send shipment notification: ", err)
+			}
+		})
+	}
+}
+
+func addressable(topic string, order eventbus.OrderPayload) bool {
+	if order.UserEmail == "" {
+		logger.Warnf("%s for order %s has no user email, skipping notification", topic, order.OrderID)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/eventbus"
+	"goshop/pkg/notification"
+	notificationMocks "goshop/pkg/notification/mocks"
+)
+
+// syncBus runs handlers inline so tests can assert on the notifier right after Publish.
+type syncBus struct {
+	handlers map[string][]eventbus.Handler
+}
+
+func (b *syncBus) Subscribe(topic string, h eventbus.Handler) {
+	if b.handlers == nil {
+		b.handlers = map[string][]eventbus.Handler{}
+	}
+	b.handlers[topic] = append(b.handlers[topic], h)
+}
+
+func (b *syncBus) Publish(ctx context.Context, ev eventbus.Event) error {
+	for _, h := range b.handlers[ev.Topic()] {
+		h(ctx, ev)
+	}
+	return nil
+}
+
+// stubInvoices renders every order's invoice, or fails with err.
+type stubInvoices struct{ err error }
+
+func (s stubInvoices) InvoicePDF(_ context.Context, orderID string) (string, []byte, error) {
+	if s.err != nil {
+		return "", nil, s.err
+	}
+	return "INV-" + orderID + ".pdf", []byte("%PDF-1.4"), nil
+}
+
+func TestSubscribeOrderEmails(t *testing.T) {
+	logger.Initialize(config.ProductionEnv)
+	notifier := notificationMocks.NewNotifier(t)
+	bus := &syncBus{}
+	SubscribeOrderEmails(bus, notifier, stubInvoices{})
+
+	notifier.On("SendOrderPlaced", mock.Anything, "o1", "a@x.com").Return(nil).Once()
+	notifier.On("SendOrderPaid", mock.Anything, "o1", "a@x.com", &notification.Attachment{
+		Filename: "INV-o1.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4"),
+	}).Return(nil).Once()
+	for _, status := range []string{"payment_failed", "in_progress", "pending_payment", "cancelled"} {
+		notifier.On("SendOrderStatusChanged", mock.Anything, "o1", "a@x.com", status).Return(nil).Once()
+	}
+	notifier.On("SendOrderStatusChanged", mock.Anything, "o1", "a@x.com", "done").Return(errors.New("smtp down")).Once()
+	notifier.On("SendInvoice", mock.Anything, "o1", "a@x.com", notification.Attachment{
+		Filename: "INV-o1.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4"),
+	}).Return(nil).Once()
+
+	ctx := context.Background()
+	payload := func(status string) eventbus.OrderPayload {
+		return eventbus.OrderPayload{OrderID: "o1", UserEmail: "a@x.com", Status: status}
+	}
+	events := []eventbus.Event{
+		eventbus.OrderCreated{OrderPayload: payload("pending_payment")},
+		eventbus.OrderPaid{OrderPayload: payload("paid")},
+		eventbus.OrderPaymentFailed{OrderPayload: payload("payment_failed")},
+		eventbus.OrderInProgress{OrderPayload: payload("in_progress")},
+		eventbus.OrderDone{OrderPayload: payload("done")},
+		eventbus.OrderInvoiced{OrderPayload: payload("done")},
+		eventbus.OrderStatusChanged{OrderPayload: payload("pending_payment")},
+		eventbus.OrderCancelled{OrderPayload: payload("cancelled"), Reason: "reservation_expired"},
+		// No email of its own: the OrderCancelled that follows it tells the buyer.
+		eventbus.OrderReservationExpired{OrderPayload: payload("cancelled")},
+	}
+	for _, ev := range events {
+		require.NoError(t, bus.Publish(ctx, ev))
+	}
+}
+
+func TestSubscribeOrderEmails_PaidWithoutInvoice(t *testing.T) {
+	logger.Initialize(config.ProductionEnv)
+	notifier := notificationMocks.NewNotifier(t)
+	bus := &syncBus{}
+	SubscribeOrderEmails(bus, notifier, stubInvoices{err: errors.New("invoice not found")})
+
+	// The confirmation still goes out, without the attachment.
+	notifier.On("SendOrderPaid", mock.Anything, "o1", "a@x.com", (*notification.Attachment)(nil)).Return(nil).Once()
+	require.NoError(t, bus.Publish(context.Background(), eventbus.OrderPaid{
+		OrderPayload: eventbus.OrderPayload{OrderID: "o1", UserEmail: "a@x.com", Status: "paid"},
+	}))
+}
+
+func TestSubscribeOrderEmails_AuthorizedNotYetInvoiced(t *testing.T) {
+	logger.Initialize(config.ProductionEnv)
+	notifier := notificationMocks.NewNotifier(t)
+	bus := &syncBus{}
+	SubscribeOrderEmails(bus, notifier, stubInvoices{err: apperror.WrapMessage(apperror.ErrNotFound, gorm.ErrRecordNotFound, "invoice not found")})
+
+	notifier.On("SendOrderPaid", mock.Anything, "o1", "a@x.com", (*notification.Attachment)(nil)).Return(nil).Once()
+	require.NoError(t, bus.Publish(context.Background(), eventbus.OrderPaid{
+		OrderPayload: eventbus.OrderPayload{OrderID: "o1", UserEmail: "a@x.com", Status: "paid"},
+	}))
+}
+
+func TestSubscribeOrderEmails_InvoiceRenderFails(t *testing.T) {
+	logger.Initialize(config.ProductionEnv)
+	notifier := notificationMocks.NewNotifier(t)
+	bus := &syncBus{}
+	SubscribeOrderEmails(bus, notifier, stubInvoices{err: errors.New("render failed")})
+
+	// No SendInvoice expectation: there is nothing to attach.
+	require.NoError(t, bus.Publish(context.Background(), eventbus.OrderInvoiced{
+		OrderPayload: eventbus.OrderPayload{OrderID: "o1", UserEmail: "a@x.com", Status: "paid"},
+	}))
+}
+
+func TestSubscribeOrderEmails_SkipsEventsWithoutEmail(t *testing.T) {
+	logger.Initialize(config.ProductionEnv)
+	notifier := notificationMocks.NewNotifier(t)
+	bus := &syncBus{}
+	SubscribeOrderEmails(bus, notifier, stubInvoices{})
+
+	ctx := context.Background()
+	require.NoError(t, bus.Publish(ctx, eventbus.OrderCreated{OrderPayload: eventbus.OrderPayload{OrderID: "o1"}}))
+	require.NoError(t, bus.Publish(ctx, eventbus.OrderPaid{OrderPayload: eventbus.OrderPayload{OrderID: "o1", Status: "paid"}}))
+	require.NoError(t, bus.Publish(ctx, eventbus.OrderStatusChanged{OrderPayload: eventbus.OrderPayload{OrderID: "o1", Status: "done"}}))
+	require.NoError(t, bus.Publish(ctx, eventbus.OrderCancelled{OrderPayload: eventbus.OrderPayload{OrderID: "o1"}}))
+}
+
+func TestSubscribeOrderEmails_Shipments(t *testing.T) {
+	logger.Initialize(config.ProductionEnv)
+	notifier := notificationMocks.NewNotifier(t)
+	bus := &syncBus{}
+	SubscribeOrderEmails(bus, notifier, stubInvoices{})
+
+	notifier.On("SendShipmentUpdate", mock.Anything, "o1", "a@x.com", "packed", "", "").Return(nil).Once()
+	notifier.On("SendShipmentUpdate", mock.Anything, "o1", "a@x.com", "shipped", "DHL", "JD0001").Return(nil).Once()
+	notifier.On("SendShipmentUpdate", mock.Anything, "o1", "a@x.com", "delivered", "DHL", "JD0001").
+		Return(errors.New("smtp down")).Once()
+
+	ctx := context.Background()
+	shipped := eventbus.ShipmentPayload{
+		ShipmentID: "s1", OrderID: "o1", UserEmail: "a@x.com", Status: "shipped", Carrier: "DHL", TrackingNumber: "JD0001",
+	}
+	delivered := shipped
+	delivered.Status = "delivered"
+	events := []eventbus.Event{
+		eventbus.ShipmentPacked{ShipmentPayload: eventbus.ShipmentPayload{ShipmentID: "s1", OrderID: "o1", UserEmail: "a@x.com", Status: "packed"}},
+		eventbus.ShipmentShipped{ShipmentPayload: shipped},
+		eventbus.ShipmentDelivered{ShipmentPayload: delivered},
+		// Skipped: nobody to tell.
+		eventbus.ShipmentShipped{ShipmentPayload: eventbus.ShipmentPayload{ShipmentID: "s2", OrderID: "o1", Status: "shipped"}},
+	}
+	for _, ev := range events {
+		require.NoError(t, bus.Publish(ctx, ev))
+	}
+}
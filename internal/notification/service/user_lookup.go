@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 
+	userModel "goshop/internal/user/model"
 	userRepo "goshop/internal/user/repository"
 )
 
@@ -24,3 +25,26 @@ func (l *userRepoLookup) GetUserIDByEmail(ctx context.Context, email string) (st
 	}
 	return u.ID, nil
 }
+
+// AdminLookup lists the addresses operational alerts go to.
+type AdminLookup interface {
+	ListAdminEmails(ctx context.Context) ([]string, error)
+}
+
+func NewAdminLookup(users userRepo.UserRepository) AdminLookup {
+	return &userRepoLookup{users: users}
+}
+
+func (l *userRepoLookup) ListAdminEmails(ctx context.Context) ([]string, error) {
+	admins, err := l.users.ListByRole(ctx, userModel.UserRoleAdmin)
+	if err != nil {
+		return nil, err
+	}
+	emails := make([]string, 0, len(admins))
+	for _, u := range admins {
+		if u.Email != "" {
+			emails = append(emails, u.Email)
+		}
+	}
+	return emails, nil
+}
@@ -40,3 +40,21 @@ func TestUserRepoLookup_NilUserReturnsEmpty(t *testing.T) {
 	require.NoError(t, err)
 	require.Empty(t, id)
 }
+
+func TestAdminLookup_ListsAdminEmails(t *testing.T) {
+	repo := userMocks.NewUserRepository(t)
+	repo.On("ListByRole", mock.Anything, userModel.UserRoleAdmin).
+		Return([]*userModel.User{{ID: "a1", Email: "a1@example.com"}, {ID: "a2"}}, nil).Once()
+
+	emails, err := NewAdminLookup(repo).ListAdminEmails(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, []string{"a1@example.com"}, emails)
+}
+
+func TestAdminLookup_PropagatesError(t *testing.T) {
+	repo := userMocks.NewUserRepository(t)
+	repo.On("ListByRole", mock.Anything, userModel.UserRoleAdmin).Return(nil, errors.New("db down")).Once()
+
+	_, err := NewAdminLookup(repo).ListAdminEmails(context.Background())
+	require.Error(t, err)
+}
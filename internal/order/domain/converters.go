@@ -1,6 +1,12 @@
 package domain
 
-import "goshop/internal/order/model"
+import (
+	"strings"
+
+	"goshop/internal/order/model"
+	"goshop/pkg/money"
+	"goshop/pkg/shipping"
+)
 
 // CouponFromModel returns the API DTO for a coupon, hiding internal columns
 // (deleted_at) and removing the unreachable utils.Copy error branches that
@@ -10,14 +16,15 @@ func CouponFromModel(m *model.Coupon) *Coupon {
 		return nil
 	}
 	return &Coupon{
-		ID:             m.ID,
-		Code:           m.Code,
-		DiscountType:   string(m.DiscountType),
-		DiscountValue:  m.DiscountValue,
-		MinOrderAmount: m.MinOrderAmount,
-		MaxUsage:       m.MaxUsage,
-		UsedCount:      m.UsedCount,
-		ExpiresAt:      m.ExpiresAt,
+		ID:              m.ID,
+		Code:            m.Code,
+		DiscountType:    string(m.DiscountType),
+		DiscountPercent: m.DiscountPercent,
+		DiscountAmount:  m.DiscountAmount,
+		MinOrderAmount:  m.MinOrderAmount,
+		MaxUsage:        m.MaxUsage,
+		UsedCount:       m.UsedCount,
+		ExpiresAt:       m.ExpiresAt,
 	}
 }
 
@@ -27,17 +34,55 @@ func OrderFromModel(m *model.Order) *Order {
 		return nil
 	}
 	return &Order{
-		ID:             m.ID,
-		Code:           m.Code,
-		TotalPrice:     m.TotalPrice,
-		DiscountAmount: m.DiscountAmount,
-		FinalPrice:     m.FinalPrice,
-		CouponCode:     m.CouponCode,
-		Status:         string(m.Status),
-		Lines:          OrderLinesFromModel(m.Lines),
-		CreatedAt:      m.CreatedAt,
-		UpdatedAt:      m.UpdatedAt,
+		ID:              m.ID,
+		Code:            m.Code,
+		TotalPrice:      m.TotalPrice,
+		DiscountAmount:  m.DiscountAmount,
+		FinalPrice:      m.FinalPrice,
+		CouponCode:      m.CouponCode,
+		Status:          string(m.Status),
+		PaymentMethod:   string(m.PaymentMethod),
+		Currency:        m.Currency,
+		ShippingMethod:  m.ShippingMethod,
+		ShippingFee:     m.ShippingFee,
+		ShippingAddress: AddressFromModel(m.ShippingAddress),
+		BillingAddress:  AddressFromModel(m.BillingAddress),
+		TaxAmount:       m.TaxAmount,
+		TaxMode:         string(m.TaxMode),
+		Lines:           OrderLinesFromModel(m.Lines),
+		PaymentAttempts: PaymentAttemptsFromModel(m.PaymentAttempts),
+		Shipments:       ShipmentsFromModel(m.Shipments),
+		CreatedAt:       m.CreatedAt,
+		UpdatedAt:       m.UpdatedAt,
+	}
+}
+
+// AddressFromModel returns nil for an order placed without the address.
+func AddressFromModel(m model.OrderAddress) *Address {
+	if m.IsZero() {
+		return nil
+	}
+	return &Address{Name: m.Name, Phone: m.Phone, Street: m.Street, City: m.City, Region: m.Region, Country: m.Country}
+}
+
+// PaymentAttemptsFromModel converts payment attempts, whose amounts are stored in minor units
+// with the lower-case currency code payment providers use.
+func PaymentAttemptsFromModel(rows []*model.PaymentAttempt) []*PaymentAttempt {
+	if len(rows) == 0 {
+		return nil
+	}
+	out := make([]*PaymentAttempt, len(rows))
+	for i, r := range rows {
+		out[i] = &PaymentAttempt{
+			Attempt:   r.Attempt,
+			Provider:  r.Provider,
+			Amount:    money.New(r.Amount, strings.ToUpper(r.Currency)),
+			Status:    r.Status,
+			CreatedAt: r.CreatedAt,
+			UpdatedAt: r.UpdatedAt,
+		}
 	}
+	return out
 }
 
 func OrdersFromModel(rows []*model.Order) []*Order {
@@ -53,8 +98,13 @@ func OrderLineFromModel(m *model.OrderLine) *OrderLine {
 		return nil
 	}
 	out := &OrderLine{
-		Quantity: m.Quantity,
-		Price:    m.Price,
+		ID:        m.ID,
+		Quantity:  m.Quantity,
+		Price:     m.Price,
+		Currency:  m.Currency,
+		TaxClass:  m.TaxClass,
+		TaxRate:   m.TaxRate,
+		TaxAmount: m.TaxAmount,
 	}
 	if m.Product != nil {
 		out.Product = Product{
@@ -74,3 +124,92 @@ func OrderLinesFromModel(lines []*model.OrderLine) []*OrderLine {
 	}
 	return out
 }
+
+// ShipmentFromModel returns the API DTO for a shipment.
+func ShipmentFromModel(m *model.Shipment) *Shipment {
+	if m == nil {
+		return nil
+	}
+	out := &Shipment{
+		ID:             m.ID,
+		Status:         string(m.Status),
+		Carrier:        m.Carrier,
+		TrackingNumber: m.TrackingNumber,
+		Lines:          make([]*ShipmentLine, len(m.Lines)),
+		ShippedAt:      m.ShippedAt,
+		DeliveredAt:    m.DeliveredAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+	for i, l := range m.Lines {
+		out.Lines[i] = &ShipmentLine{OrderLineID: l.OrderLineID, ProductID: l.ProductID, Quantity: l.Quantity}
+	}
+	return out
+}
+
+func ShipmentsFromModel(rows []*model.Shipment) []*Shipment {
+	if len(rows) == 0 {
+		return nil
+	}
+	out := make([]*Shipment, len(rows))
+	for i, r := range rows {
+		out[i] = ShipmentFromModel(r)
+	}
+	return out
+}
+
+func ShippingRateFromModel(m *model.ShippingRate) *ShippingRate {
+	if m == nil {
+		return nil
+	}
+	return &ShippingRate{
+		ID:        m.ID,
+		Code:      m.Code,
+		Name:      m.Name,
+		Position:  m.Position,
+		Active:    m.Active,
+		Rule:      m.Rule,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func ShippingRatesFromModel(rows []*model.ShippingRate) []*ShippingRate {
+	out := make([]*ShippingRate, len(rows))
+	for i, r := range rows {
+		out[i] = ShippingRateFromModel(r)
+	}
+	return out
+}
+
+func ShippingMethodsFromList(methods []shipping.Method) []*ShippingMethod {
+	out := make([]*ShippingMethod, len(methods))
+	for i, m := range methods {
+		out[i] = &ShippingMethod{Code: m.Code, Name: m.Name, Rule: m.Rule}
+	}
+	return out
+}
+
+func TaxRateFromModel(m *model.TaxRate) *TaxRate {
+	if m == nil {
+		return nil
+	}
+	return &TaxRate{
+		ID:        m.ID,
+		Country:   m.Country,
+		Region:    m.Region,
+		TaxClass:  m.TaxClass,
+		Name:      m.Name,
+		Rate:      m.Rate,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+	}
+}
+
+func TaxRatesFromModel(rows []*model.TaxRate) []*TaxRate {
+	out := make([]*TaxRate, len(rows))
+	for i, r := range rows {
+		out[i] = TaxRateFromModel(r)
+	}
+	return out
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"goshop/internal/order/model"
+	"goshop/pkg/money"
+	"goshop/pkg/shipping"
 )
 
 func TestCouponFromModel(t *testing.T) {
@@ -16,14 +18,16 @@ func TestCouponFromModel(t *testing.T) {
 		ID:             "c1",
 		Code:           "X",
 		DiscountType:   model.DiscountTypeFixed,
-		DiscountValue:  10,
-		MinOrderAmount: 5,
+		DiscountAmount: money.New(1000, "USD"),
+		MinOrderAmount: money.New(500, "USD"),
 		MaxUsage:       2,
 		UsedCount:      1,
 		ExpiresAt:      &exp,
 	})
 	assert.Equal(t, "c1", c.ID)
 	assert.Equal(t, "fixed", c.DiscountType)
+	assert.Equal(t, money.New(1000, "USD"), c.DiscountAmount)
+	assert.Equal(t, money.New(500, "USD"), c.MinOrderAmount)
 	assert.Equal(t, &exp, c.ExpiresAt)
 }
 
@@ -32,20 +36,48 @@ func TestOrderFromModel(t *testing.T) {
 	o := OrderFromModel(&model.Order{
 		ID:         "o1",
 		Code:       "C",
-		TotalPrice: 100,
+		TotalPrice: money.New(10000, "EUR"),
 		Status:     model.OrderStatusNew,
+		Currency:   "EUR",
 		Lines: []*model.OrderLine{
-			{Quantity: 2, Price: 5, Product: &model.Product{ID: "p1", Code: "PC", Name: "n", Price: 5}},
+			{Quantity: 2, Price: money.New(1000, "EUR"), Currency: "EUR", Product: &model.Product{ID: "p1", Code: "PC", Name: "n", Price: money.New(500, "EUR")}},
 			nil,
 		},
 	})
 	assert.Equal(t, "o1", o.ID)
 	assert.Equal(t, "new", o.Status)
+	assert.Equal(t, "EUR", o.Currency)
+	assert.Equal(t, money.New(10000, "EUR"), o.TotalPrice)
+	assert.Equal(t, "EUR", o.Lines[0].Currency)
 	assert.Len(t, o.Lines, 2)
 	assert.Equal(t, "p1", o.Lines[0].Product.ID)
 	assert.Nil(t, o.Lines[1])
 }
 
+func TestOrderFromModel_Shipping(t *testing.T) {
+	o := OrderFromModel(&model.Order{
+		ID:              "o1",
+		ShippingMethod:  "express",
+		ShippingFee:     money.New(919, "EUR"),
+		ShippingAddress: model.OrderAddress{Name: "Lan", Phone: "090", Street: "1 Trang Tien", City: "Hanoi", Country: "VN"},
+	})
+	assert.Equal(t, "express", o.ShippingMethod)
+	assert.Equal(t, money.New(919, "EUR"), o.ShippingFee)
+	assert.Equal(t, &Address{Name: "Lan", Phone: "090", Street: "1 Trang Tien", City: "Hanoi", Country: "VN"}, o.ShippingAddress)
+	assert.Nil(t, o.BillingAddress)
+}
+
+func TestShippingRatesFromModel(t *testing.T) {
+	assert.Nil(t, ShippingRateFromModel(nil))
+	out := ShippingRatesFromModel([]*model.ShippingRate{{ID: "r1", Code: "express", Name: "Express", Position: 1, Active: true, Rule: shipping.Flat(money.New(999, "USD"))}})
+	assert.Equal(t, &ShippingRate{ID: "r1", Code: "express", Name: "Express", Position: 1, Active: true, Rule: shipping.Flat(money.New(999, "USD"))}, out[0])
+}
+
+func TestShippingMethodsFromList(t *testing.T) {
+	out := ShippingMethodsFromList(shipping.MustParseMethods("USD", "standard:4.99").List())
+	assert.Equal(t, []*ShippingMethod{{Code: "standard", Rule: shipping.Flat(money.New(499, "USD"))}}, out)
+}
+
 func TestOrdersFromModel(t *testing.T) {
 	out := OrdersFromModel([]*model.Order{{ID: "a"}, nil})
 	assert.Len(t, out, 2)
@@ -55,7 +87,48 @@ func TestOrdersFromModel(t *testing.T) {
 
 func TestOrderLineFromModel_NoProduct(t *testing.T) {
 	assert.Nil(t, OrderLineFromModel(nil))
-	out := OrderLineFromModel(&model.OrderLine{Quantity: 3, Price: 9})
+	out := OrderLineFromModel(&model.OrderLine{Quantity: 3, Price: money.New(900, "USD")})
 	assert.Equal(t, uint(3), out.Quantity)
 	assert.Equal(t, "", out.Product.ID)
 }
+
+func TestPaymentAttemptsFromModel(t *testing.T) {
+	assert.Nil(t, PaymentAttemptsFromModel(nil))
+	out := PaymentAttemptsFromModel([]*model.PaymentAttempt{
+		{Attempt: 1, Provider: "stripe", Amount: 1250, Currency: "eur", Status: "failed"},
+		{Attempt: 2, Provider: "paypal", Amount: 1250, Currency: "eur", Status: "succeeded"},
+	})
+	assert.Len(t, out, 2)
+	assert.Equal(t, 2, out[1].Attempt)
+	assert.Equal(t, "paypal", out[1].Provider)
+	assert.Equal(t, money.New(1250, "EUR"), out[0].Amount)
+	assert.Equal(t, "failed", out[0].Status)
+}
+
+func TestShipmentsFromModel(t *testing.T) {
+	assert.Nil(t, ShipmentsFromModel(nil))
+	shipped := time.Now()
+	out := ShipmentsFromModel([]*model.Shipment{{
+		ID:             "s1",
+		Status:         model.ShipmentStatusShipped,
+		Carrier:        "dhl",
+		TrackingNumber: "TRK1",
+		ShippedAt:      &shipped,
+		Lines:          []*model.ShipmentLine{{ID: "sl1", OrderLineID: "l1", ProductID: "p1", Quantity: 2}},
+	}})
+	assert.Len(t, out, 1)
+	assert.Equal(t, "shipped", out[0].Status)
+	assert.Equal(t, "TRK1", out[0].TrackingNumber)
+	assert.Equal(t, &shipped, out[0].ShippedAt)
+	assert.Equal(t, []*ShipmentLine{{OrderLineID: "l1", ProductID: "p1", Quantity: 2}}, out[0].Lines)
+
+	o := OrderFromModel(&model.Order{ID: "o1", Lines: []*model.OrderLine{{ID: "l1"}}, Shipments: []*model.Shipment{{ID: "s1"}}})
+	assert.Equal(t, "l1", o.Lines[0].ID)
+	assert.Len(t, o.Shipments, 1)
+}
+
+func TestTaxRatesFromModel(t *testing.T) {
+	assert.Nil(t, TaxRateFromModel(nil))
+	out := TaxRatesFromModel([]*model.TaxRate{{ID: "r1", Country: "US", Region: "CA", TaxClass: "standard", Name: "CA sales tax", Rate: 725}})
+	assert.Equal(t, &TaxRate{ID: "r1", Country: "US", Region: "CA", TaxClass: "standard", Name: "CA sales tax", Rate: 725}, out[0])
+}
@@ -1,23 +1,31 @@
 package domain
 
-import "time"
+import (
+	"time"
+
+	"goshop/pkg/money"
+)
 
 type Coupon struct {
-	ID             string     `json:"id"`
-	Code           string     `json:"code"`
-	DiscountType   string     `json:"discount_type"`
-	DiscountValue  float64    `json:"discount_value"`
-	MinOrderAmount float64    `json:"min_order_amount"`
-	MaxUsage       int        `json:"max_usage"`
-	UsedCount      int        `json:"used_count"`
-	ExpiresAt      *time.Time `json:"expires_at"`
+	ID              string        `json:"id"`
+	Code            string        `json:"code"`
+	DiscountType    string        `json:"discount_type"`
+	DiscountPercent money.Percent `json:"discount_percent,omitzero"`
+	DiscountAmount  money.Money   `json:"discount_amount,omitzero"`
+	MinOrderAmount  money.Money   `json:"min_order_amount,omitzero"`
+	MaxUsage        int           `json:"max_usage"`
+	UsedCount       int           `json:"used_count"`
+	ExpiresAt       *time.Time    `json:"expires_at"`
 }
 
+// CreateCouponReq creates a coupon. A percentage coupon sets DiscountPercent (up to 100); a
+// fixed coupon sets DiscountAmount. Amounts are in the shop's base currency.
 type CreateCouponReq struct {
-	Code           string     `json:"code" validate:"required"`
-	DiscountType   string     `json:"discount_type" validate:"required,oneof=fixed percentage"`
-	DiscountValue  float64    `json:"discount_value" validate:"required,gt=0"`
-	MinOrderAmount float64    `json:"min_order_amount" validate:"gte=0"`
-	MaxUsage       int        `json:"max_usage" validate:"gte=0"`
-	ExpiresAt      *time.Time `json:"expires_at"`
+	Code            string        `json:"code" validate:"required"`
+	DiscountType    string        `json:"discount_type" validate:"required,oneof=fixed percentage"`
+	DiscountPercent money.Percent `json:"discount_percent,omitzero"`
+	DiscountAmount  money.Money   `json:"discount_amount,omitzero"`
+	MinOrderAmount  money.Money   `json:"min_order_amount,omitzero"`
+	MaxUsage        int           `json:"max_usage" validate:"gte=0"`
+	ExpiresAt       *time.Time    `json:"expires_at"`
 }
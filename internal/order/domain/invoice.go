@@ -0,0 +1,20 @@
+package domain
+
+// CreditNoteReq credits an order's invoice for a refund. Amounts are in minor units of the
+// order's currency.
+type CreditNoteReq struct {
+	OrderID  string
+	RefundID string
+	Amount   int64
+	// Lines are the refunded units of each order line; empty when the refund took whatever
+	// was left on the payment.
+	Lines  []CreditNoteLine
+	Reason string
+}
+
+// CreditNoteLine is the refunded units of one order line and what they were refunded for.
+type CreditNoteLine struct {
+	OrderLineID string
+	Quantity    uint
+	Amount      int64
+}
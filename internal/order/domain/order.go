@@ -3,6 +3,7 @@ package domain
 import (
 	"time"
 
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
 )
 
@@ -10,25 +11,79 @@ type Order struct {
 	ID             string       `json:"id"`
 	Code           string       `json:"code"`
 	Lines          []*OrderLine `json:"lines"`
-	TotalPrice     float64      `json:"total_price"`
-	DiscountAmount float64      `json:"discount_amount"`
-	FinalPrice     float64      `json:"final_price"`
+	TotalPrice     money.Money  `json:"total_price"`
+	DiscountAmount money.Money  `json:"discount_amount"`
+	FinalPrice     money.Money  `json:"final_price"`
 	CouponCode     string       `json:"coupon_code,omitempty"`
 	Status         string       `json:"status"`
-	CreatedAt      time.Time    `json:"created_at"`
-	UpdatedAt      time.Time    `json:"updated_at"`
+	PaymentMethod  string       `json:"payment_method"`
+	Currency       string       `json:"currency"`
+	// ShippingFee is what ShippingMethod costs; FinalPrice includes it.
+	ShippingMethod  string      `json:"shipping_method,omitempty"`
+	ShippingFee     money.Money `json:"shipping_fee"`
+	ShippingAddress *Address    `json:"shipping_address,omitempty"`
+	BillingAddress  *Address    `json:"billing_address,omitempty"`
+	// TaxAmount sums the lines' tax. With TaxMode "inclusive" the prices already contained it;
+	// with "exclusive" FinalPrice adds it.
+	TaxAmount money.Money `json:"tax_amount"`
+	TaxMode   string      `json:"tax_mode"`
+	// PaymentAttempts lists every try at paying the order, oldest first. Only the order
+	// detail carries it.
+	PaymentAttempts []*PaymentAttempt `json:"payment_attempts,omitempty"`
+	// Shipments lists the parcels the order has been split into, oldest first. Only the order
+	// detail carries it.
+	Shipments []*Shipment `json:"shipments,omitempty"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+}
+
+type PaymentAttempt struct {
+	Attempt   int         `json:"attempt"`
+	Provider  string      `json:"provider"`
+	Amount    money.Money `json:"amount"`
+	Status    string      `json:"status"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
 }
 
 type OrderLine struct {
-	Product  Product `json:"product,omitempty"`
-	Quantity uint    `json:"quantity"`
-	Price    float64 `json:"price"`
+	ID        string        `json:"id"`
+	Product   Product       `json:"product,omitempty"`
+	Quantity  uint          `json:"quantity"`
+	Price     money.Money   `json:"price"`
+	Currency  string        `json:"currency"`
+	TaxClass  string        `json:"tax_class,omitempty"`
+	TaxRate   money.Percent `json:"tax_rate"`
+	TaxAmount money.Money   `json:"tax_amount"`
 }
 
 type PlaceOrderReq struct {
 	UserID     string              `json:"user_id" validate:"required"`
 	CouponCode string              `json:"coupon_code,omitempty"`
-	Lines      []PlaceOrderLineReq `json:"lines,omitempty" validate:"required,gt=0,lte=5,dive"`
+	Lines      []PlaceOrderLineReq `json:"lines,omitempty" validate:"required,gt=0,lte=100,dive"`
+	// PaymentMethod is "online" (the default) or "cod" for cash on delivery.
+	PaymentMethod string `json:"payment_method,omitempty" validate:"omitempty,oneof=online cod"`
+	// Currency is the ISO 4217 code to price the order in; the shop's base currency by default.
+	Currency string `json:"currency,omitempty" validate:"omitempty,len=3"`
+	// ShippingAddressID picks one of the buyer's saved addresses; ShippingAddress gives one
+	// inline instead. With neither, the order ships to the buyer's default address.
+	ShippingAddressID string      `json:"shipping_address_id,omitempty"`
+	ShippingAddress   *AddressReq `json:"shipping_address,omitempty"`
+	// BillingAddressID and BillingAddress work the same way. With neither, the order is
+	// billed to its shipping address.
+	BillingAddressID string      `json:"billing_address_id,omitempty"`
+	BillingAddress   *AddressReq `json:"billing_address,omitempty"`
+	// ShippingMethod is one of the shop's shipping methods; the default one when empty.
+	ShippingMethod string `json:"shipping_method,omitempty"`
+}
+
+type AddressReq struct {
+	Name    string `json:"name" validate:"required"`
+	Phone   string `json:"phone" validate:"required"`
+	Street  string `json:"street" validate:"required"`
+	City    string `json:"city" validate:"required"`
+	Region  string `json:"region,omitempty"`
+	Country string `json:"country" validate:"required"`
 }
 
 type PlaceOrderLineReq struct {
@@ -50,3 +105,13 @@ type ListOrderRes struct {
 	Orders     []*Order           `json:"orders,omitempty"`
 	Pagination *paging.Pagination `json:"pagination,omitempty"`
 }
+
+// Address is where an order ships or is billed to, as it was when the order was placed.
+type Address struct {
+	Name    string `json:"name"`
+	Phone   string `json:"phone"`
+	Street  string `json:"street"`
+	City    string `json:"city"`
+	Region  string `json:"region,omitempty"`
+	Country string `json:"country"`
+}
@@ -1,8 +1,10 @@
 package domain
 
+import "goshop/pkg/money"
+
 type Product struct {
-	ID    string  `json:"id"`
-	Code  string  `json:"code"`
-	Name  string  `json:"name"`
-	Price float64 `json:"price"`
+	ID    string      `json:"id"`
+	Code  string      `json:"code"`
+	Name  string      `json:"name"`
+	Price money.Money `json:"price"`
 }
@@ -0,0 +1,42 @@
+package domain
+
+import "time"
+
+type Shipment struct {
+	ID             string          `json:"id"`
+	Status         string          `json:"status"`
+	Carrier        string          `json:"carrier,omitempty"`
+	TrackingNumber string          `json:"tracking_number,omitempty"`
+	Lines          []*ShipmentLine `json:"lines"`
+	ShippedAt      *time.Time      `json:"shipped_at,omitempty"`
+	DeliveredAt    *time.Time      `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time       `json:"created_at"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+}
+
+type ShipmentLine struct {
+	OrderLineID string `json:"order_line_id"`
+	ProductID   string `json:"product_id"`
+	Quantity    uint   `json:"quantity"`
+}
+
+// CreateShipmentReq packs some units of an order's lines into a new shipment. Carrier and
+// tracking number can be filled in now or when the shipment is marked shipped.
+type CreateShipmentReq struct {
+	Carrier        string            `json:"carrier,omitempty" validate:"max=64"`
+	TrackingNumber string            `json:"tracking_number,omitempty" validate:"max=128"`
+	Lines          []ShipmentLineReq `json:"lines" validate:"required,gt=0,dive"`
+}
+
+type ShipmentLineReq struct {
+	OrderLineID string `json:"order_line_id" validate:"required"`
+	Quantity    uint   `json:"quantity" validate:"required"`
+}
+
+// UpdateShipmentReq moves a shipment to its next status. A shipped shipment needs a carrier and
+// tracking number, taken from the request or already on the shipment.
+type UpdateShipmentReq struct {
+	Status         string `json:"status" validate:"required,oneof=packed shipped delivered"`
+	Carrier        string `json:"carrier,omitempty" validate:"max=64"`
+	TrackingNumber string `json:"tracking_number,omitempty" validate:"max=128"`
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"time"
+
+	"goshop/pkg/money"
+	"goshop/pkg/shipping"
+)
+
+// ShippingMethod is a way an order can be delivered. Rule prices it, amounts in the shop's base
+// currency; ask for a quote to see what it costs for a given cart.
+type ShippingMethod struct {
+	Code string        `json:"code"`
+	Name string        `json:"name,omitempty"`
+	Rule shipping.Rule `json:"rule"`
+}
+
+type ShippingRate struct {
+	ID        string        `json:"id"`
+	Code      string        `json:"code"`
+	Name      string        `json:"name,omitempty"`
+	Position  int           `json:"position"`
+	Active    bool          `json:"active"`
+	Rule      shipping.Rule `json:"rule"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// CreateShippingRateReq adds a shipping method. Rule amounts are in the shop's base currency;
+// Position orders the methods at checkout, lowest first. Active defaults to true.
+type CreateShippingRateReq struct {
+	Code     string        `json:"code" validate:"required,max=64"`
+	Name     string        `json:"name,omitempty"`
+	Position int           `json:"position,omitempty" validate:"gte=0"`
+	Active   *bool         `json:"active,omitempty"`
+	Rule     shipping.Rule `json:"rule"`
+}
+
+// UpdateShippingRateReq changes the fields that are set; a rule replaces the whole rule.
+type UpdateShippingRateReq struct {
+	Name     string         `json:"name,omitempty"`
+	Position *int           `json:"position,omitempty" validate:"omitempty,gte=0"`
+	Active   *bool          `json:"active,omitempty"`
+	Rule     *shipping.Rule `json:"rule,omitempty"`
+}
+
+// QuoteShippingReq asks what shipping the lines to Country would cost, in Currency (the base
+// currency when empty).
+type QuoteShippingReq struct {
+	Lines    []PlaceOrderLineReq `json:"lines" validate:"required,gt=0,lte=100,dive"`
+	Country  string              `json:"country,omitempty"`
+	Currency string              `json:"currency,omitempty" validate:"omitempty,len=3"`
+}
+
+// ShippingQuote is what a shipping method costs for the quoted lines.
+type ShippingQuote struct {
+	Code string      `json:"code"`
+	Name string      `json:"name,omitempty"`
+	Rate money.Money `json:"rate"`
+}
@@ -0,0 +1,35 @@
+package domain
+
+import (
+	"time"
+
+	"goshop/pkg/money"
+)
+
+type TaxRate struct {
+	ID        string        `json:"id"`
+	Country   string        `json:"country,omitempty"`
+	Region    string        `json:"region,omitempty"`
+	TaxClass  string        `json:"tax_class"`
+	Name      string        `json:"name,omitempty"`
+	Rate      money.Percent `json:"rate"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+}
+
+// CreateTaxRateReq taxes TaxClass (standard when empty) at Rate percent in a region of Country,
+// the rest of Country without a region, or everywhere else without a country either.
+type CreateTaxRateReq struct {
+	Country  string        `json:"country,omitempty" validate:"omitempty,len=2"`
+	Region   string        `json:"region,omitempty" validate:"omitempty,max=64"`
+	TaxClass string        `json:"tax_class,omitempty" validate:"omitempty,max=64"`
+	Name     string        `json:"name,omitempty"`
+	Rate     money.Percent `json:"rate" validate:"gte=0,lte=10000"`
+}
+
+// UpdateTaxRateReq changes the name and rate; a rate for another destination or class is a new
+// rate.
+type UpdateTaxRateReq struct {
+	Name string         `json:"name,omitempty"`
+	Rate *money.Percent `json:"rate,omitempty" validate:"omitempty,gte=0,lte=10000"`
+}
@@ -0,0 +1,41 @@
+package model
+
+import (
+	"time"
+)
+
+// Address mirrors the user domain's address book; placing an order reads the buyer's saved
+// addresses to snapshot where it ships and to pick the nearest warehouse.
+type Address struct {
+	ID        string     `json:"id" gorm:"primary_key"`
+	DeletedAt *time.Time `json:"deleted_at"`
+	UserID    string     `json:"user_id"`
+	Name      string     `json:"name"`
+	Phone     string     `json:"phone"`
+	Street    string     `json:"street"`
+	City      string     `json:"city"`
+	Region    string     `json:"region"`
+	Country   string     `json:"country"`
+	IsDefault bool       `json:"is_default"`
+}
+
+// OrderAddress is an address as it was when the order was placed. It's copied onto the order,
+// so later edits to the buyer's address book don't move a placed order.
+type OrderAddress struct {
+	Name    string `json:"name"`
+	Phone   string `json:"phone"`
+	Street  string `json:"street"`
+	City    string `json:"city"`
+	Region  string `json:"region"`
+	Country string `json:"country"`
+}
+
+// SnapshotAddress copies a saved address onto an order.
+func SnapshotAddress(a *Address) OrderAddress {
+	return OrderAddress{Name: a.Name, Phone: a.Phone, Street: a.Street, City: a.City, Region: a.Region, Country: a.Country}
+}
+
+// IsZero reports whether no address was recorded.
+func (a OrderAddress) IsZero() bool {
+	return a == OrderAddress{}
+}
@@ -0,0 +1,11 @@
+package model
+
+// Category mirrors the stock-level and tax class columns of the canonical categories table so
+// the order service can resolve a product's effective low-stock threshold and tax class without
+// crossing into the product domain.
+type Category struct {
+	ID                string `json:"id" gorm:"unique;not null;index;primary_key"`
+	LowStockThreshold *int   `json:"low_stock_threshold"`
+	ReorderQuantity   *int   `json:"reorder_quantity"`
+	TaxClass          string `json:"tax_class"`
+}
@@ -5,6 +5,9 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"goshop/pkg/currency"
+	"goshop/pkg/money"
 )
 
 type DiscountType string
@@ -15,20 +18,42 @@ const (
 )
 
 type Coupon struct {
-	ID             string       `json:"id" gorm:"unique;not null;index;primary_key"`
-	CreatedAt      time.Time    `json:"created_at"`
-	UpdatedAt      time.Time    `json:"updated_at"`
-	DeletedAt      *time.Time   `json:"deleted_at" gorm:"index"`
-	Code           string       `json:"code" gorm:"uniqueIndex;not null"`
-	DiscountType   DiscountType `json:"discount_type"`
-	DiscountValue  float64      `json:"discount_value"`
-	MinOrderAmount float64      `json:"min_order_amount" gorm:"default:0"`
-	MaxUsage       int          `json:"max_usage" gorm:"default:0"`
-	UsedCount      int          `json:"used_count" gorm:"default:0"`
-	ExpiresAt      *time.Time   `json:"expires_at"`
+	ID           string       `json:"id" gorm:"unique;not null;index;primary_key"`
+	CreatedAt    time.Time    `json:"created_at"`
+	UpdatedAt    time.Time    `json:"updated_at"`
+	DeletedAt    *time.Time   `json:"deleted_at" gorm:"index"`
+	Code         string       `json:"code" gorm:"uniqueIndex;not null"`
+	DiscountType DiscountType `json:"discount_type"`
+	// DiscountPercent is a percentage coupon's discount and DiscountAmount a fixed coupon's;
+	// the other is zero.
+	DiscountPercent money.Percent `json:"discount_percent"`
+	DiscountAmount  money.Money   `json:"discount_amount" gorm:"column:discount_amount_minor"`
+	MinOrderAmount  money.Money   `json:"min_order_amount" gorm:"column:min_order_amount_minor"`
+	// Currency is DiscountAmount's and MinOrderAmount's, the shop's base currency; orders in
+	// other currencies apply them converted.
+	Currency  string     `json:"currency" gorm:"size:3;not null;default:USD"`
+	MaxUsage  int        `json:"max_usage" gorm:"default:0"`
+	UsedCount int        `json:"used_count" gorm:"default:0"`
+	ExpiresAt *time.Time `json:"expires_at"`
 }
 
 func (c *Coupon) BeforeCreate(tx *gorm.DB) error {
 	c.ID = uuid.New().String()
+	if c.Currency == "" {
+		c.Currency = c.DiscountAmount.Currency()
+	}
+	if c.Currency == "" {
+		c.Currency = c.MinOrderAmount.Currency()
+	}
+	if c.Currency == "" {
+		c.Currency = currency.Default
+	}
+	return nil
+}
+
+// AfterFind applies the coupon's currency to the amounts scanned from minor-unit columns.
+func (c *Coupon) AfterFind(tx *gorm.DB) error {
+	c.DiscountAmount = c.DiscountAmount.WithCurrency(c.Currency)
+	c.MinOrderAmount = c.MinOrderAmount.WithCurrency(c.Currency)
 	return nil
 }
@@ -0,0 +1,42 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"goshop/pkg/invoice"
+	"goshop/pkg/money"
+)
+
+// Invoice is an issued invoice or credit note. Number is the Sequence-th of its Kind in Year;
+// each series runs without gaps. An order has at most one invoice, and each refund at most one
+// credit note. Document is the copy the renderings are made from.
+type Invoice struct {
+	ID        string           `json:"id" gorm:"unique;not null;index;primary_key"`
+	CreatedAt time.Time        `json:"created_at"`
+	Kind      invoice.Kind     `json:"kind" gorm:"not null"`
+	Number    string           `json:"number" gorm:"uniqueIndex;not null"`
+	Year      int              `json:"year" gorm:"not null"`
+	Sequence  int              `json:"sequence" gorm:"not null"`
+	OrderID   string           `json:"order_id" gorm:"index;not null"`
+	UserID    string           `json:"user_id" gorm:"not null"`
+	RefundID  *string          `json:"refund_id,omitempty" gorm:"uniqueIndex"`
+	Currency  string           `json:"currency" gorm:"not null"`
+	Total     money.Money      `json:"total" gorm:"column:total_minor;not null"`
+	Document  invoice.Document `json:"document" gorm:"serializer:json"`
+}
+
+func (i *Invoice) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == "" {
+		i.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// AfterFind gives Total the row's currency.
+func (i *Invoice) AfterFind(tx *gorm.DB) error {
+	i.Total = i.Total.WithCurrency(i.Currency)
+	return nil
+}
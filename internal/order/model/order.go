@@ -6,6 +6,9 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"goshop/pkg/currency"
+	"goshop/pkg/money"
+	"goshop/pkg/tax"
 	"goshop/pkg/utils"
 )
 
@@ -16,32 +19,53 @@ const (
 	OrderStatusPendingPayment OrderStatus = "pending_payment"
 	OrderStatusPaid           OrderStatus = "paid"
 	OrderStatusInProgress     OrderStatus = "in-progress"
-	OrderStatusDone           OrderStatus = "done"
-	OrderStatusCancelled      OrderStatus = "cancelled"
-	OrderStatusPaymentFailed  OrderStatus = "payment_failed"
+	// OrderStatusPartiallyShipped and OrderStatusShipped follow from the order's shipments:
+	// some or all of its units are on their way.
+	OrderStatusPartiallyShipped OrderStatus = "partially_shipped"
+	OrderStatusShipped          OrderStatus = "shipped"
+	OrderStatusDone             OrderStatus = "done"
+	OrderStatusCancelled        OrderStatus = "cancelled"
+	OrderStatusPaymentFailed    OrderStatus = "payment_failed"
 )
 
 func (s OrderStatus) IsValid() bool {
 	switch s {
 	case OrderStatusNew, OrderStatusPendingPayment, OrderStatusPaid,
-		OrderStatusInProgress, OrderStatusDone, OrderStatusCancelled, OrderStatusPaymentFailed:
+		OrderStatusInProgress, OrderStatusPartiallyShipped, OrderStatusShipped,
+		OrderStatusDone, OrderStatusCancelled, OrderStatusPaymentFailed:
 		return true
 	}
 	return false
 }
 
+// PaymentMethod is how the buyer pays. Online orders wait in pending_payment until a payment
+// provider clears them; cash-on-delivery orders skip the payment intent and are paid to the
+// courier, so they start as new and their stock is committed at placement.
+type PaymentMethod string
+
+const (
+	PaymentMethodOnline PaymentMethod = "online"
+	PaymentMethodCOD    PaymentMethod = "cod"
+)
+
 // allowedTransitions maps each status to the set of statuses it can advance to. Terminal
 // statuses (done, cancelled) have no outbound transitions. Designed for admin-driven
 // fulfillment moves; payment-driven transitions (pending_payment -> paid/payment_failed)
-// also live here so the webhook path goes through the same gate.
+// also live here so the webhook path goes through the same gate, as do the moves shipments
+// drive (in-progress -> partially_shipped -> shipped -> done). Once a parcel has left, the
+// order can no longer be cancelled.
 var allowedTransitions = map[OrderStatus]map[OrderStatus]struct{}{
 	OrderStatusNew:            {OrderStatusInProgress: {}, OrderStatusCancelled: {}},
 	OrderStatusPendingPayment: {OrderStatusPaid: {}, OrderStatusPaymentFailed: {}, OrderStatusCancelled: {}},
 	OrderStatusPaid:           {OrderStatusInProgress: {}, OrderStatusCancelled: {}},
-	OrderStatusInProgress:     {OrderStatusDone: {}, OrderStatusCancelled: {}},
-	OrderStatusPaymentFailed:  {OrderStatusCancelled: {}, OrderStatusPendingPayment: {}},
-	OrderStatusDone:           {},
-	OrderStatusCancelled:      {},
+	OrderStatusInProgress: {
+		OrderStatusPartiallyShipped: {}, OrderStatusShipped: {}, OrderStatusDone: {}, OrderStatusCancelled: {},
+	},
+	OrderStatusPartiallyShipped: {OrderStatusShipped: {}},
+	OrderStatusShipped:          {OrderStatusDone: {}},
+	OrderStatusPaymentFailed:    {OrderStatusCancelled: {}, OrderStatusPendingPayment: {}},
+	OrderStatusDone:             {},
+	OrderStatusCancelled:        {},
 }
 
 // CanTransitionTo reports whether `s` is allowed to advance to `next`. Idempotent moves
@@ -66,12 +90,35 @@ type Order struct {
 	Code           string     `json:"code"`
 	UserID         string     `json:"user_id"`
 	User           *User
-	Lines          []*OrderLine `json:"lines"`
-	TotalPrice     float64      `json:"total_price"`
-	DiscountAmount float64      `json:"discount_amount" gorm:"default:0"`
-	FinalPrice     float64      `json:"final_price" gorm:"default:0"`
-	CouponCode     string       `json:"coupon_code"`
-	Status         OrderStatus  `json:"status"`
+	Lines          []*OrderLine  `json:"lines"`
+	TotalPrice     money.Money   `json:"total_price" gorm:"column:total_price_minor"`
+	DiscountAmount money.Money   `json:"discount_amount" gorm:"column:discount_amount_minor"`
+	FinalPrice     money.Money   `json:"final_price" gorm:"column:final_price_minor"`
+	CouponCode     string        `json:"coupon_code"`
+	Status         OrderStatus   `json:"status"`
+	PaymentMethod  PaymentMethod `json:"payment_method" gorm:"not null;default:online"`
+	// Currency is the ISO 4217 code the order's prices, payment and refunds are in.
+	Currency string `json:"currency" gorm:"size:3;not null;default:USD"`
+	// ShippingFee is what ShippingMethod costs, in the order's currency; FinalPrice includes it.
+	ShippingMethod  string       `json:"shipping_method"`
+	ShippingFee     money.Money  `json:"shipping_fee" gorm:"column:shipping_fee_minor"`
+	ShippingAddress OrderAddress `json:"shipping_address" gorm:"embedded;embeddedPrefix:shipping_"`
+	BillingAddress  OrderAddress `json:"billing_address" gorm:"embedded;embeddedPrefix:billing_"`
+	// TaxAmount sums the lines' tax. TaxMode is whether their prices included it; when they
+	// didn't, FinalPrice adds it.
+	TaxAmount money.Money `json:"tax_amount" gorm:"column:tax_amount_minor"`
+	TaxMode   tax.Mode    `json:"tax_mode" gorm:"not null;default:exclusive"`
+	// PaymentAttempts is the order's payment history, oldest first. Loaded on demand and
+	// never saved with the order: the payment domain owns those rows.
+	PaymentAttempts []*PaymentAttempt `json:"payment_attempts,omitempty" gorm:"-"`
+	// Shipments are the parcels the order was split into, oldest first. Loaded on demand and
+	// saved on their own, so saving the order never touches them.
+	Shipments []*Shipment `json:"shipments,omitempty" gorm:"-"`
+}
+
+// CashOnDelivery reports whether the order is paid to the courier on delivery.
+func (order *Order) CashOnDelivery() bool {
+	return order.PaymentMethod == PaymentMethodCOD
 }
 
 func (order *Order) BeforeCreate(tx *gorm.DB) error {
@@ -84,5 +131,53 @@ func (order *Order) BeforeCreate(tx *gorm.DB) error {
 	if order.Status == "" {
 		order.Status = OrderStatusNew
 	}
+	if order.PaymentMethod == "" {
+		order.PaymentMethod = PaymentMethodOnline
+	}
+	if order.Currency == "" {
+		order.Currency = order.TotalPrice.Currency()
+	}
+	if order.Currency == "" {
+		order.Currency = currency.Default
+	}
 	return nil
 }
+
+// AfterFind applies the order's currency to the amounts scanned from minor-unit columns.
+func (order *Order) AfterFind(tx *gorm.DB) error {
+	order.TotalPrice = order.TotalPrice.WithCurrency(order.Currency)
+	order.DiscountAmount = order.DiscountAmount.WithCurrency(order.Currency)
+	order.FinalPrice = order.FinalPrice.WithCurrency(order.Currency)
+	order.ShippingFee = order.ShippingFee.WithCurrency(order.Currency)
+	order.TaxAmount = order.TaxAmount.WithCurrency(order.Currency)
+	return nil
+}
+
+// LineNet is what line costs after its share of the order's discount, the amount its tax is
+// worked out on.
+func (order *Order) LineNet(line *OrderLine) money.Money {
+	return order.lineGoods(line, line.Quantity)
+}
+
+// LineCharge is what qty units of line cost the buyer: their share of the line after the
+// order's discount, plus their tax when prices exclude it. Shipping isn't part of it.
+func (order *Order) LineCharge(line *OrderLine, qty uint) money.Money {
+	charge := order.lineGoods(line, qty)
+	if !order.TaxMode.Inclusive() && line.Quantity > 0 {
+		charge = charge.Add(line.TaxAmount.Prorate(int64(qty), int64(line.Quantity)))
+	}
+	return charge
+}
+
+// lineGoods is qty units of line after their share of the discount, rounded once.
+func (order *Order) lineGoods(line *OrderLine, qty uint) money.Money {
+	if line.Quantity == 0 {
+		return money.Zero(line.Price.Currency())
+	}
+	total := order.TotalPrice.Amount()
+	if total <= 0 || !order.DiscountAmount.IsPositive() {
+		return line.Price.Prorate(int64(qty), int64(line.Quantity))
+	}
+	net := total - order.DiscountAmount.Amount()
+	return line.Price.Prorate(int64(qty)*net, int64(line.Quantity)*total)
+}
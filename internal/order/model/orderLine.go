@@ -5,6 +5,9 @@ import (
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+
+	"goshop/pkg/currency"
+	"goshop/pkg/money"
 )
 
 type OrderLine struct {
@@ -15,8 +18,14 @@ type OrderLine struct {
 	OrderID   string     `json:"order_id"`
 	ProductID string     `json:"product_id"`
 	Product   *Product
-	Quantity  uint    `json:"quantity"`
-	Price     float64 `json:"price"`
+	Quantity  uint        `json:"quantity"`
+	Price     money.Money `json:"price" gorm:"column:price_minor"` // line total: unit price × quantity
+	Currency  string      `json:"currency" gorm:"size:3;not null;default:USD"`
+	// TaxClass and TaxRate are the product's tax class and the rate it was taxed at where the
+	// order ships; TaxAmount is the line's tax, in the order's currency.
+	TaxClass  string        `json:"tax_class"`
+	TaxRate   money.Percent `json:"tax_rate"`
+	TaxAmount money.Money   `json:"tax_amount" gorm:"column:tax_amount_minor"`
 }
 
 // BeforeCreate generates a UUID only when one isn't already set. Unconditional
@@ -28,5 +37,18 @@ func (line *OrderLine) BeforeCreate(tx *gorm.DB) error {
 	if line.ID == "" {
 		line.ID = uuid.New().String()
 	}
+	if line.Currency == "" {
+		line.Currency = line.Price.Currency()
+	}
+	if line.Currency == "" {
+		line.Currency = currency.Default
+	}
+	return nil
+}
+
+// AfterFind applies the line's currency to its price and tax.
+func (line *OrderLine) AfterFind(tx *gorm.DB) error {
+	line.Price = line.Price.WithCurrency(line.Currency)
+	line.TaxAmount = line.TaxAmount.WithCurrency(line.Currency)
 	return nil
 }
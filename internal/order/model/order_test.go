@@ -4,6 +4,9 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"goshop/pkg/money"
+	"goshop/pkg/tax"
 )
 
 func TestOrder_BeforeCreate(t *testing.T) {
@@ -69,8 +72,10 @@ func TestOrderStatus_IsValid(t *testing.T) {
 		{name: "in-progress", status: OrderStatusInProgress, want: true},
 		{name: "done", status: OrderStatusDone, want: true},
 		{name: "cancelled", status: OrderStatusCancelled, want: true},
+		{name: "partially_shipped", status: OrderStatusPartiallyShipped, want: true},
+		{name: "shipped", status: OrderStatusShipped, want: true},
 		{name: "empty string", status: OrderStatus(""), want: false},
-		{name: "unknown", status: OrderStatus("shipped"), want: false},
+		{name: "unknown", status: OrderStatus("dispatched"), want: false},
 		{name: "wrong casing", status: OrderStatus("NEW"), want: false},
 	}
 
@@ -80,3 +85,21 @@ func TestOrderStatus_IsValid(t *testing.T) {
 		})
 	}
 }
+
+func TestOrder_LineCharge(t *testing.T) {
+	line := &OrderLine{Quantity: 3, Price: money.New(3000, "USD"), TaxAmount: money.New(180, "USD")}
+	order := &Order{TotalPrice: money.New(4000, "USD"), DiscountAmount: money.New(1000, "USD"), Lines: []*OrderLine{line}}
+
+	// The line carries 3/4 of the goods, so 7.50 of the 10.00 off.
+	assert.Equal(t, money.New(2250, "USD"), order.LineNet(line))
+	assert.Equal(t, money.New(810, "USD"), order.LineCharge(line, 1))
+
+	order.TaxMode = tax.Inclusive
+	assert.Equal(t, money.New(750, "USD"), order.LineCharge(line, 1))
+}
+
+func TestProduct_EffectiveTaxClass(t *testing.T) {
+	assert.Equal(t, tax.Standard, (&Product{}).EffectiveTaxClass())
+	assert.Equal(t, "books", (&Product{Category: &Category{TaxClass: "books"}}).EffectiveTaxClass())
+	assert.Equal(t, "food", (&Product{TaxClass: "food", Category: &Category{TaxClass: "books"}}).EffectiveTaxClass())
+}
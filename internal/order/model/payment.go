@@ -0,0 +1,23 @@
+package model
+
+import (
+	"time"
+)
+
+// PaymentAttempt mirrors one row of the payment domain's payments table: a single try at
+// paying an order. An order keeps its failed attempts when the customer retries.
+type PaymentAttempt struct {
+	ID        string    `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+	OrderID   string    `json:"order_id"`
+	Attempt   int       `json:"attempt"`
+	Provider  string    `json:"provider"`
+	Amount    int64     `json:"amount"`
+	Currency  string    `json:"currency"`
+	Status    string    `json:"status"`
+}
+
+func (PaymentAttempt) TableName() string {
+	return "payments"
+}
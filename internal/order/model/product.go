@@ -2,20 +2,76 @@ package model
 
 import (
 	"time"
+
+	"gorm.io/gorm"
+
+	"goshop/pkg/money"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
 )
 
 type Product struct {
-	ID          string     `json:"id" gorm:"unique;not null;index;primary_key"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
-	DeletedAt   *time.Time `json:"deleted_at" gorm:"index"`
-	Code        string     `json:"code" gorm:"uniqueIndex:idx_product_code,not null"`
-	Name        string     `json:"name" gorm:"uniqueIndex:idx_product_name,not null"`
-	Description string     `json:"description"`
-	Price       float64    `json:"price"`
-	Active      bool       `json:"active" gorm:"default:true"`
+	ID          string      `json:"id" gorm:"unique;not null;index;primary_key"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	DeletedAt   *time.Time  `json:"deleted_at" gorm:"index"`
+	Code        string      `json:"code" gorm:"uniqueIndex:idx_product_code,not null"`
+	Name        string      `json:"name" gorm:"uniqueIndex:idx_product_name,not null"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price" gorm:"column:price_minor"`
+	Currency    string      `json:"currency"`
+	Active      bool        `json:"active" gorm:"default:true"`
 	// StockQuantity / ReservedQuantity mirror the canonical products columns so the order
 	// service can compute available stock without crossing into the product domain.
 	StockQuantity    int `json:"stock_quantity" gorm:"default:0"`
 	ReservedQuantity int `json:"reserved_quantity" gorm:"default:0"`
+	// WeightGrams is one unit's shipping weight.
+	WeightGrams int64 `json:"weight_grams"`
+	// TaxClass picks the product's tax rates; empty falls back to the category's, then to
+	// tax.Standard.
+	TaxClass string `json:"tax_class"`
+	// LowStockThreshold and ReorderQuantity are the product's own stock settings; nil falls
+	// back to the category's, then to the shop-wide default.
+	LowStockThreshold *int      `json:"low_stock_threshold"`
+	ReorderQuantity   *int      `json:"reorder_quantity"`
+	CategoryID        *string   `json:"category_id"`
+	Category          *Category `json:"category,omitempty" gorm:"foreignKey:CategoryID"`
+}
+
+// AfterFind applies the row's currency to Price.
+func (p *Product) AfterFind(tx *gorm.DB) error {
+	p.Price = p.Price.WithCurrency(p.Currency)
+	return nil
+}
+
+// categoryStockSettings returns the category's threshold and reorder quantity, or nils when
+// the category isn't loaded.
+func (p *Product) categoryStockSettings() (threshold, reorder *int) {
+	if p.Category == nil {
+		return nil, nil
+	}
+	return p.Category.LowStockThreshold, p.Category.ReorderQuantity
+}
+
+// EffectiveLowStockThreshold is the product's threshold, else its category's, else the default.
+func (p *Product) EffectiveLowStockThreshold() int {
+	category, _ := p.categoryStockSettings()
+	return stock.LowStockThreshold(p.LowStockThreshold, category)
+}
+
+// EffectiveTaxClass is the product's tax class, else its category's, else tax.Standard.
+func (p *Product) EffectiveTaxClass() string {
+	switch {
+	case p.TaxClass != "":
+		return p.TaxClass
+	case p.Category != nil && p.Category.TaxClass != "":
+		return p.Category.TaxClass
+	}
+	return tax.Standard
+}
+
+// EffectiveReorderQuantity is the product's reorder quantity, else its category's; 0 means none.
+func (p *Product) EffectiveReorderQuantity() int {
+	_, category := p.categoryStockSettings()
+	return stock.ReorderQuantity(p.ReorderQuantity, category)
 }
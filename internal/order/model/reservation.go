@@ -18,17 +18,20 @@ const (
 // StockReservation holds units of a product committed to an in-flight order. While active, the
 // quantity counts toward Product.ReservedQuantity. Active reservations expire at ExpiresAt; a
 // background sweeper releases expired ones and cancels their parent order if still unpaid.
+// WarehouseID is the warehouse holding the units, whose counters move with the product's; it
+// is nil only for reservations placed before warehouses existed.
 type StockReservation struct {
 	ID        string     `json:"id" gorm:"primary_key"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at" gorm:"index"`
 
-	OrderID   string            `json:"order_id" gorm:"index;not null"`
-	ProductID string            `json:"product_id" gorm:"index;not null"`
-	Quantity  int               `json:"quantity" gorm:"not null"`
-	Status    ReservationStatus `json:"status" gorm:"index;not null"`
-	ExpiresAt time.Time         `json:"expires_at" gorm:"index;not null"`
+	OrderID     string            `json:"order_id" gorm:"index;not null"`
+	ProductID   string            `json:"product_id" gorm:"index;not null"`
+	WarehouseID *string           `json:"warehouse_id" gorm:"index"`
+	Quantity    int               `json:"quantity" gorm:"not null"`
+	Status      ReservationStatus `json:"status" gorm:"index;not null"`
+	ExpiresAt   time.Time         `json:"expires_at" gorm:"index;not null"`
 }
 
 func (r *StockReservation) BeforeCreate(tx *gorm.DB) error {
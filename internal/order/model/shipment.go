@@ -0,0 +1,139 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type ShipmentStatus string
+
+const (
+	ShipmentStatusPacked    ShipmentStatus = "packed"
+	ShipmentStatusShipped   ShipmentStatus = "shipped"
+	ShipmentStatusDelivered ShipmentStatus = "delivered"
+)
+
+func (s ShipmentStatus) IsValid() bool {
+	switch s {
+	case ShipmentStatusPacked, ShipmentStatusShipped, ShipmentStatusDelivered:
+		return true
+	}
+	return false
+}
+
+// CanTransitionTo reports whether a shipment in status s can move to next. Shipments only move
+// forward, one step at a time; a repeated move is accepted like an order's.
+func (s ShipmentStatus) CanTransitionTo(next ShipmentStatus) bool {
+	switch s {
+	case ShipmentStatusPacked:
+		return next == ShipmentStatusPacked || next == ShipmentStatusShipped
+	case ShipmentStatusShipped:
+		return next == ShipmentStatusShipped || next == ShipmentStatusDelivered
+	case ShipmentStatusDelivered:
+		return next == ShipmentStatusDelivered
+	}
+	return false
+}
+
+// left reports whether the shipment's units have left the warehouse.
+func (s ShipmentStatus) left() bool {
+	return s == ShipmentStatusShipped || s == ShipmentStatusDelivered
+}
+
+// Shipment is one parcel of an order: some units of some of its lines, sent with a carrier.
+// An order can be split across several shipments, each moving packed -> shipped -> delivered.
+type Shipment struct {
+	ID        string    `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	OrderID        string          `json:"order_id" gorm:"index;not null"`
+	Status         ShipmentStatus  `json:"status" gorm:"not null"`
+	Carrier        string          `json:"carrier"`
+	TrackingNumber string          `json:"tracking_number"`
+	ShippedAt      *time.Time      `json:"shipped_at"`
+	DeliveredAt    *time.Time      `json:"delivered_at"`
+	Lines          []*ShipmentLine `json:"lines"`
+}
+
+func (s *Shipment) BeforeCreate(tx *gorm.DB) error {
+	if s.ID == "" {
+		s.ID = uuid.New().String()
+	}
+	if s.Status == "" {
+		s.Status = ShipmentStatusPacked
+	}
+	return nil
+}
+
+// ShipmentLine records how many units of an order line a shipment carries.
+type ShipmentLine struct {
+	ID          string    `json:"id" gorm:"primary_key"`
+	CreatedAt   time.Time `json:"created_at"`
+	ShipmentID  string    `json:"shipment_id" gorm:"index;not null"`
+	OrderLineID string    `json:"order_line_id" gorm:"index;not null"`
+	ProductID   string    `json:"product_id" gorm:"not null"`
+	Quantity    uint      `json:"quantity" gorm:"not null"`
+}
+
+// BeforeCreate keeps an existing ID: saving a shipment upserts its lines, like an order's.
+func (l *ShipmentLine) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// ShippedQuantities sums the units of each order line the shipments carry, keyed by order line
+// ID. Packed shipments count: their units are spoken for.
+func ShippedQuantities(shipments []*Shipment) map[string]uint {
+	qty := make(map[string]uint)
+	for _, s := range shipments {
+		for _, l := range s.Lines {
+			qty[l.OrderLineID] += l.Quantity
+		}
+	}
+	return qty
+}
+
+// FulfillmentStatus derives the order status the shipments imply: done once every unit has been
+// delivered, shipped once every unit has left, and partially_shipped once some have. It returns
+// "" while nothing has left yet, so the order keeps its status.
+func FulfillmentStatus(lines []*OrderLine, shipments []*Shipment) OrderStatus {
+	left := make(map[string]uint)
+	delivered := make(map[string]uint)
+	for _, s := range shipments {
+		if !s.Status.left() {
+			continue
+		}
+		for _, l := range s.Lines {
+			left[l.OrderLineID] += l.Quantity
+			if s.Status == ShipmentStatusDelivered {
+				delivered[l.OrderLineID] += l.Quantity
+			}
+		}
+	}
+	if len(left) == 0 {
+		return ""
+	}
+
+	allLeft, allDelivered := true, true
+	for _, line := range lines {
+		if left[line.ID] < line.Quantity {
+			allLeft = false
+		}
+		if delivered[line.ID] < line.Quantity {
+			allDelivered = false
+		}
+	}
+	switch {
+	case allDelivered:
+		return OrderStatusDone
+	case allLeft:
+		return OrderStatusShipped
+	default:
+		return OrderStatusPartiallyShipped
+	}
+}
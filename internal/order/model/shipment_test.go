@@ -0,0 +1,84 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShipmentStatus_CanTransitionTo(t *testing.T) {
+	cases := []struct {
+		from, to ShipmentStatus
+		ok       bool
+	}{
+		{ShipmentStatusPacked, ShipmentStatusPacked, true},
+		{ShipmentStatusPacked, ShipmentStatusShipped, true},
+		{ShipmentStatusPacked, ShipmentStatusDelivered, false},
+		{ShipmentStatusShipped, ShipmentStatusDelivered, true},
+		{ShipmentStatusShipped, ShipmentStatusPacked, false},
+		{ShipmentStatusDelivered, ShipmentStatusDelivered, true},
+		{ShipmentStatusDelivered, ShipmentStatusShipped, false},
+		{ShipmentStatus("lost"), ShipmentStatusShipped, false},
+	}
+	for _, tc := range cases {
+		require.Equalf(t, tc.ok, tc.from.CanTransitionTo(tc.to), "from=%s to=%s", tc.from, tc.to)
+	}
+	require.False(t, ShipmentStatus("lost").IsValid())
+}
+
+func TestShipment_BeforeCreate(t *testing.T) {
+	s := &Shipment{}
+	require.NoError(t, s.BeforeCreate(nil))
+	require.NotEmpty(t, s.ID)
+	require.Equal(t, ShipmentStatusPacked, s.Status)
+
+	l := &ShipmentLine{ID: "kept"}
+	require.NoError(t, l.BeforeCreate(nil))
+	require.Equal(t, "kept", l.ID)
+}
+
+func TestFulfillmentStatus(t *testing.T) {
+	lines := []*OrderLine{{ID: "l1", Quantity: 2}, {ID: "l2", Quantity: 1}}
+	shipment := func(status ShipmentStatus, qty ...uint) *Shipment {
+		s := &Shipment{Status: status}
+		for i, q := range qty {
+			if q > 0 {
+				s.Lines = append(s.Lines, &ShipmentLine{OrderLineID: lines[i].ID, Quantity: q})
+			}
+		}
+		return s
+	}
+
+	tests := []struct {
+		name      string
+		shipments []*Shipment
+		want      OrderStatus
+	}{
+		{name: "none", want: ""},
+		{name: "only_packed", shipments: []*Shipment{shipment(ShipmentStatusPacked, 2, 1)}, want: ""},
+		{name: "some_left", shipments: []*Shipment{shipment(ShipmentStatusShipped, 1, 0)}, want: OrderStatusPartiallyShipped},
+		{
+			name:      "rest_still_packed",
+			shipments: []*Shipment{shipment(ShipmentStatusDelivered, 2, 0), shipment(ShipmentStatusPacked, 0, 1)},
+			want:      OrderStatusPartiallyShipped,
+		},
+		{
+			name:      "all_left",
+			shipments: []*Shipment{shipment(ShipmentStatusDelivered, 2, 0), shipment(ShipmentStatusShipped, 0, 1)},
+			want:      OrderStatusShipped,
+		},
+		{
+			name:      "all_delivered",
+			shipments: []*Shipment{shipment(ShipmentStatusDelivered, 1, 1), shipment(ShipmentStatusDelivered, 1, 0)},
+			want:      OrderStatusDone,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, FulfillmentStatus(lines, tt.shipments))
+		})
+	}
+
+	require.Equal(t, map[string]uint{"l1": 3, "l2": 1},
+		ShippedQuantities([]*Shipment{shipment(ShipmentStatusPacked, 2, 1), shipment(ShipmentStatusShipped, 1, 0)}))
+}
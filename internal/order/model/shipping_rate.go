@@ -0,0 +1,29 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"goshop/pkg/shipping"
+)
+
+// ShippingRate is a shipping method as admins configure it: Rule prices it, amounts in the
+// shop's base currency. Active rates are offered at checkout by Position, lowest first; the
+// first one is the default.
+type ShippingRate struct {
+	ID        string        `json:"id" gorm:"unique;not null;index;primary_key"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	Code      string        `json:"code" gorm:"uniqueIndex;not null"`
+	Name      string        `json:"name"`
+	Position  int           `json:"position"`
+	Active    bool          `json:"active"`
+	Rule      shipping.Rule `json:"rule" gorm:"serializer:json"`
+}
+
+func (r *ShippingRate) BeforeCreate(tx *gorm.DB) error {
+	r.ID = uuid.New().String()
+	return nil
+}
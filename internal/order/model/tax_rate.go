@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"goshop/pkg/money"
+	"goshop/pkg/tax"
+)
+
+// TaxRate is the tax admins configure on one tax class at a destination: a region of a
+// country, the rest of a country when Region is empty, or the rest of the world when Country
+// is empty too.
+type TaxRate struct {
+	ID        string        `json:"id" gorm:"unique;not null;index;primary_key"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	Country   string        `json:"country" gorm:"uniqueIndex:idx_tax_rates_destination;not null;default:''"`
+	Region    string        `json:"region" gorm:"uniqueIndex:idx_tax_rates_destination;not null;default:''"`
+	TaxClass  string        `json:"tax_class" gorm:"uniqueIndex:idx_tax_rates_destination;not null"`
+	Name      string        `json:"name"`
+	Rate      money.Percent `json:"rate" gorm:"not null"`
+}
+
+func (r *TaxRate) BeforeCreate(tx *gorm.DB) error {
+	r.ID = uuid.New().String()
+	return nil
+}
+
+// TaxRule is the rate as the tax engine looks it up.
+func (r *TaxRate) TaxRule() tax.Rate {
+	return tax.Rate{Country: r.Country, Region: r.Region, Class: r.TaxClass, Percent: r.Rate}
+}
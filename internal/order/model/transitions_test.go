@@ -24,6 +24,13 @@ func TestCanTransitionTo(t *testing.T) {
 		{OrderStatusPaid, OrderStatusCancelled, true},
 		{OrderStatusInProgress, OrderStatusDone, true},
 		{OrderStatusInProgress, OrderStatusCancelled, true},
+		{OrderStatusInProgress, OrderStatusPartiallyShipped, true},
+		{OrderStatusInProgress, OrderStatusShipped, true},
+		{OrderStatusPartiallyShipped, OrderStatusShipped, true},
+		{OrderStatusPartiallyShipped, OrderStatusCancelled, false},
+		{OrderStatusShipped, OrderStatusDone, true},
+		{OrderStatusShipped, OrderStatusCancelled, false},
+		{OrderStatusPaid, OrderStatusShipped, false},
 		{OrderStatusPaymentFailed, OrderStatusCancelled, true},
 		{OrderStatusPaymentFailed, OrderStatusPendingPayment, true},
 		{OrderStatusDone, OrderStatusCancelled, false},
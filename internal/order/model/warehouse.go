@@ -0,0 +1,26 @@
+package model
+
+import (
+	"time"
+)
+
+// Warehouse mirrors the inventory domain's warehouses with the fields allocation ranks by.
+type Warehouse struct {
+	ID       string `json:"id" gorm:"primary_key"`
+	Country  string `json:"country"`
+	City     string `json:"city"`
+	Priority int    `json:"priority"`
+	Active   bool   `json:"active"`
+}
+
+// WarehouseStock mirrors one product's stock at one warehouse. Reservations move these
+// counters together with the product's totals.
+type WarehouseStock struct {
+	ID               string     `json:"id" gorm:"primary_key"`
+	UpdatedAt        time.Time  `json:"updated_at"`
+	WarehouseID      string     `json:"warehouse_id"`
+	Warehouse        *Warehouse `json:"warehouse,omitempty"`
+	ProductID        string     `json:"product_id"`
+	StockQuantity    int        `json:"stock_quantity"`
+	ReservedQuantity int        `json:"reserved_quantity"`
+}
@@ -1,7 +1,10 @@
 package grpc
 
 import (
+	"goshop/internal/order/domain"
 	"goshop/internal/order/model"
+	"goshop/pkg/money"
+	moneypb "goshop/proto/gen/go/money"
 	pb "goshop/proto/gen/go/order"
 )
 
@@ -13,12 +16,22 @@ func orderInfoFromModel(m *model.Order) *pb.OrderInfo {
 		return nil
 	}
 	return &pb.OrderInfo{
-		Id:         m.ID,
-		Code:       m.Code,
-		UserId:     m.UserID,
-		Lines:      orderLineInfosFromModel(m.Lines),
-		TotalPrice: float32(m.TotalPrice),
-		Status:     string(m.Status),
+		Id:                  m.ID,
+		Code:                m.Code,
+		UserId:              m.UserID,
+		Lines:               orderLineInfosFromModel(m.Lines),
+		TotalPrice:          float32(m.TotalPrice.Float64()),
+		Status:              string(m.Status),
+		Currency:            m.Currency,
+		TotalPriceMoney:     moneyToPB(m.TotalPrice),
+		DiscountAmountMoney: moneyToPB(m.DiscountAmount),
+		FinalPriceMoney:     moneyToPB(m.FinalPrice),
+		ShippingMethod:      m.ShippingMethod,
+		ShippingFeeMoney:    moneyToPB(m.ShippingFee),
+		ShippingAddress:     addressToPB(m.ShippingAddress),
+		BillingAddress:      addressToPB(m.BillingAddress),
+		TaxAmountMoney:      moneyToPB(m.TaxAmount),
+		TaxMode:             string(m.TaxMode),
 	}
 }
 
@@ -34,9 +47,13 @@ func orderLineInfosFromModel(lines []*model.OrderLine) []*pb.OrderLineInfo {
 	out := make([]*pb.OrderLineInfo, len(lines))
 	for i, l := range lines {
 		info := &pb.OrderLineInfo{
-			ProductId: l.ProductID,
-			Quantity:  uint32(l.Quantity), //nolint:gosec // quantity is a small positive integer
-			Price:     float32(l.Price),
+			ProductId:      l.ProductID,
+			Quantity:       uint32(l.Quantity), //nolint:gosec // quantity is a small positive integer
+			Price:          float32(l.Price.Float64()),
+			PriceMoney:     moneyToPB(l.Price),
+			TaxClass:       l.TaxClass,
+			TaxRate:        l.TaxRate.String(),
+			TaxAmountMoney: moneyToPB(l.TaxAmount),
 		}
 		if l.Product != nil {
 			info.ProductName = l.Product.Name
@@ -45,3 +62,23 @@ func orderLineInfosFromModel(lines []*model.OrderLine) []*pb.OrderLineInfo {
 	}
 	return out
 }
+
+// addressToPB leaves the address unset when the order has none.
+func addressToPB(a model.OrderAddress) *pb.Address {
+	if a.IsZero() {
+		return nil
+	}
+	return &pb.Address{Name: a.Name, Phone: a.Phone, Street: a.Street, City: a.City, Region: a.Region, Country: a.Country}
+}
+
+// addressFromPB maps a request address; an unset one stays nil so the service falls back.
+func addressFromPB(a *pb.Address) *domain.AddressReq {
+	if a == nil {
+		return nil
+	}
+	return &domain.AddressReq{Name: a.Name, Phone: a.Phone, Street: a.Street, City: a.City, Region: a.Region, Country: a.Country}
+}
+
+func moneyToPB(m money.Money) *moneypb.Money {
+	return &moneypb.Money{Amount: m.Amount(), Currency: m.Currency()}
+}
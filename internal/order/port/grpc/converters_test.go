@@ -5,23 +5,67 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"goshop/internal/order/domain"
 	"goshop/internal/order/model"
+	"goshop/pkg/money"
+	"goshop/pkg/tax"
+	moneypb "goshop/proto/gen/go/money"
+	pb "goshop/proto/gen/go/order"
 )
 
 func TestOrderInfoFromModel(t *testing.T) {
 	assert.Nil(t, orderInfoFromModel(nil))
 	got := orderInfoFromModel(&model.Order{
-		ID: "o1", Code: "C", UserID: "u", TotalPrice: 12.5, Status: model.OrderStatusNew,
+		ID: "o1", Code: "C", UserID: "u", TotalPrice: money.New(1250, "EUR"), Status: model.OrderStatusNew, Currency: "EUR",
 		Lines: []*model.OrderLine{
-			{ProductID: "p", Quantity: 2, Price: 4, Product: &model.Product{Name: "n"}},
-			{ProductID: "p2", Quantity: 1, Price: 1},
+			{ProductID: "p", Quantity: 2, Price: money.New(400, "EUR"), Product: &model.Product{Name: "n"}},
+			{ProductID: "p2", Quantity: 1, Price: money.New(100, "EUR")},
 		},
 	})
 	assert.Equal(t, "o1", got.Id)
 	assert.Equal(t, float32(12.5), got.TotalPrice)
+	assert.Equal(t, &moneypb.Money{Amount: 1250, Currency: "EUR"}, got.TotalPriceMoney)
+	assert.Equal(t, int64(400), got.Lines[0].PriceMoney.Amount)
+	assert.Equal(t, "EUR", got.Currency)
 	assert.Len(t, got.Lines, 2)
 	assert.Equal(t, "n", got.Lines[0].ProductName)
 	assert.Equal(t, "", got.Lines[1].ProductName)
+	assert.Nil(t, got.ShippingAddress)
+}
+
+func TestOrderInfoFromModel_Shipping(t *testing.T) {
+	got := orderInfoFromModel(&model.Order{
+		ID: "o1", Currency: "EUR", ShippingMethod: "express", ShippingFee: money.New(919, "EUR"),
+		ShippingAddress: model.OrderAddress{Name: "Lan", City: "Hanoi", Country: "VN"},
+		BillingAddress:  model.OrderAddress{Name: "Acme", City: "Austin", Country: "US"},
+	})
+	assert.Equal(t, "express", got.ShippingMethod)
+	assert.Equal(t, &moneypb.Money{Amount: 919, Currency: "EUR"}, got.ShippingFeeMoney)
+	assert.Equal(t, &pb.Address{Name: "Lan", City: "Hanoi", Country: "VN"}, got.ShippingAddress)
+	assert.Equal(t, "Acme", got.BillingAddress.Name)
+}
+
+func TestOrderInfoFromModel_Tax(t *testing.T) {
+	got := orderInfoFromModel(&model.Order{
+		ID: "o1", Currency: "USD", TaxMode: tax.Exclusive, TaxAmount: money.New(145, "USD"),
+		ShippingAddress: model.OrderAddress{City: "Los Angeles", Region: "CA", Country: "US"},
+		Lines: []*model.OrderLine{
+			{ProductID: "p", Quantity: 1, Price: money.New(2000, "USD"), TaxClass: "standard", TaxRate: 725, TaxAmount: money.New(145, "USD")},
+		},
+	})
+	assert.Equal(t, "exclusive", got.TaxMode)
+	assert.Equal(t, &moneypb.Money{Amount: 145, Currency: "USD"}, got.TaxAmountMoney)
+	assert.Equal(t, "standard", got.Lines[0].TaxClass)
+	assert.Equal(t, "7.25", got.Lines[0].TaxRate)
+	assert.Equal(t, int64(145), got.Lines[0].TaxAmountMoney.Amount)
+	assert.Equal(t, "CA", got.ShippingAddress.Region)
+}
+
+func TestAddressFromPB(t *testing.T) {
+	assert.Nil(t, addressFromPB(nil))
+	assert.Equal(t, &domain.AddressReq{Name: "Lan", Phone: "090", Street: "1 Trang Tien", City: "Hanoi", Country: "VN"},
+		addressFromPB(&pb.Address{Name: "Lan", Phone: "090", Street: "1 Trang Tien", City: "Hanoi", Country: "VN"}))
+	assert.Equal(t, "CA", addressFromPB(&pb.Address{City: "Los Angeles", Region: "CA", Country: "US"}).Region)
 }
 
 func TestOrdersInfoFromModel(t *testing.T) {
@@ -38,8 +38,14 @@ func (h *OrderHandler) PlaceOrder(ctx context.Context, req *pb.PlaceOrderReq) (*
 	}
 
 	order, err := h.service.PlaceOrder(ctx, &domain.PlaceOrderReq{
-		UserID: userID,
-		Lines:  lines,
+		UserID:            userID,
+		Lines:             lines,
+		Currency:          req.Currency,
+		ShippingAddressID: req.ShippingAddressId,
+		ShippingAddress:   addressFromPB(req.ShippingAddress),
+		BillingAddressID:  req.BillingAddressId,
+		BillingAddress:    addressFromPB(req.BillingAddress),
+		ShippingMethod:    req.ShippingMethod,
 	})
 	if err != nil {
 		logger.Error("Failed to place order ", err)
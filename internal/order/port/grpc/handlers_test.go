@@ -12,6 +12,7 @@ import (
 	"goshop/internal/order/model"
 	"goshop/internal/order/service/mocks"
 	"goshop/pkg/config"
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
 	pb "goshop/proto/gen/go/order"
 )
@@ -54,10 +55,10 @@ func (suite *OrderHandlerTestSuite) TestPlaceOrder() {
 						ID:         "orderId1",
 						Code:       "SO-001",
 						UserID:     "userID",
-						TotalPrice: 20.0,
+						TotalPrice: money.New(2000, "USD"),
 						Status:     model.OrderStatusNew,
 						Lines: []*model.OrderLine{
-							{ProductID: "productId1", Quantity: 2, Price: 20.0},
+							{ProductID: "productId1", Quantity: 2, Price: money.New(2000, "USD")},
 						},
 					}, nil).Times(1)
 			},
@@ -149,7 +150,7 @@ func (suite *OrderHandlerTestSuite) TestGetOrderByID() {
 						ID:         "orderId1",
 						Code:       "SO-001",
 						UserID:     "userID",
-						TotalPrice: 20.0,
+						TotalPrice: money.New(2000, "USD"),
 						Status:     model.OrderStatusNew,
 					}, nil).Times(1)
 			},
@@ -238,7 +239,7 @@ func (suite *OrderHandlerTestSuite) TestGetMyOrders() {
 								ID:         "orderId1",
 								Code:       "SO-001",
 								UserID:     "userID",
-								TotalPrice: 20.0,
+								TotalPrice: money.New(2000, "USD"),
 								Status:     model.OrderStatusNew,
 							},
 						},
@@ -332,7 +333,7 @@ func (suite *OrderHandlerTestSuite) TestCancelOrder() {
 						ID:         "orderId1",
 						Code:       "SO-001",
 						UserID:     "userID",
-						TotalPrice: 20.0,
+						TotalPrice: money.New(2000, "USD"),
 						Status:     model.OrderStatusCancelled,
 					}, nil).Times(1)
 			},
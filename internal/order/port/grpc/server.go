@@ -4,14 +4,17 @@ import (
 	"github.com/quangdangfit/gocommon/validation"
 	"google.golang.org/grpc"
 
-	notificationRepo "goshop/internal/notification/repository"
-	notificationSvc "goshop/internal/notification/service"
+	inventoryRepo "goshop/internal/inventory/repository"
 	"goshop/internal/order/repository"
 	"goshop/internal/order/service"
-	userRepo "goshop/internal/user/repository"
+	outboxRepo "goshop/internal/outbox/repository"
+	paymentService "goshop/internal/payment/service"
 	"goshop/pkg/config"
+	"goshop/pkg/currency"
 	"goshop/pkg/dbs"
-	"goshop/pkg/notification"
+	"goshop/pkg/shipping"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
 	pb "goshop/proto/gen/go/order"
 )
 
@@ -21,22 +24,17 @@ func RegisterHandlers(svr *grpc.Server, db dbs.Database, validator validation.Va
 	uRepo := repository.NewUserRepository(db)
 	couponRepo := repository.NewCouponRepository(db)
 	reservationRepo := repository.NewReservationRepository(db)
+	warehouseRepo := repository.NewWarehouseRepository(db)
 	cfg := config.GetConfig()
-	couponSvc := service.NewCouponService(validator, couponRepo)
-	prefChecker := notificationSvc.NewDBPreferenceChecker(
-		notificationSvc.NewUserRepoLookup(userRepo.NewUserRepository(db)),
-		notificationRepo.NewPreferenceRepository(db),
-	)
-	notifier := notification.BuildDefault(notification.Settings{
-		SMTPHost:     cfg.SMTPHost,
-		SMTPPort:     cfg.SMTPPort,
-		SMTPUser:     cfg.SMTPUser,
-		SMTPPassword: cfg.SMTPPassword,
-		EmailFrom:    cfg.EmailFrom,
-		Prefs:        prefChecker,
-		DLQ:          notificationRepo.NewDeadLetterSink(db),
-	})
-	orderSvc := service.NewOrderService(validator, db, oRepo, pRepo, uRepo, reservationRepo, couponSvc, notifier)
+	rates := currency.MustParseRates(cfg.BaseCurrency, cfg.ExchangeRates)
+	couponSvc := service.NewCouponService(validator, couponRepo, rates)
+	shippingSvc := service.NewShippingService(validator, repository.NewShippingRateRepository(db), rates,
+		shipping.MustParseMethods(cfg.BaseCurrency, cfg.ShippingMethods))
+	orderSvc := service.NewOrderService(validator, db, oRepo, pRepo, uRepo, reservationRepo, couponSvc,
+		outboxRepo.NewOutboxRepository(db), inventoryRepo.NewLedgerRepository(db),
+		warehouseRepo, stock.AllocationStrategy(cfg.WarehouseAllocation), rates, shippingSvc,
+		service.NewTaxService(validator, repository.NewTaxRateRepository(db), tax.Mode(cfg.TaxMode)), service.NewInvoices(cfg, db),
+		paymentService.NewSettler(cfg, db))
 	orderHandler := NewOrderHandler(orderSvc)
 
 	pb.RegisterOrderServiceServer(svr, orderHandler)
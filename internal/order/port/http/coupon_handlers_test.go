@@ -18,6 +18,7 @@ import (
 	"goshop/internal/order/model"
 	svcMocks "goshop/internal/order/service/mocks"
 	"goshop/pkg/config"
+	"goshop/pkg/money"
 	"goshop/pkg/response"
 	"goshop/pkg/utils"
 )
@@ -70,20 +71,20 @@ func (suite *CouponHandlerTestSuite) TestCreateCoupon() {
 		{
 			name: "Success",
 			body: &domain.CreateCouponReq{
-				Code:          "SAVE10",
-				DiscountType:  "fixed",
-				DiscountValue: 10,
+				Code:           "SAVE10",
+				DiscountType:   "fixed",
+				DiscountAmount: money.New(1000, "USD"),
 			},
 			setup: func() {
 				suite.mockCouponService.On("Create", mock.Anything, &domain.CreateCouponReq{
-					Code:          "SAVE10",
-					DiscountType:  "fixed",
-					DiscountValue: 10,
+					Code:           "SAVE10",
+					DiscountType:   "fixed",
+					DiscountAmount: money.New(1000, "USD"),
 				}).Return(&model.Coupon{
-					ID:            "c1",
-					Code:          "SAVE10",
-					DiscountType:  model.DiscountTypeFixed,
-					DiscountValue: 10,
+					ID:             "c1",
+					Code:           "SAVE10",
+					DiscountType:   model.DiscountTypeFixed,
+					DiscountAmount: money.New(1000, "USD"),
 				}, nil).Times(1)
 			},
 			expected: http.StatusOK,
@@ -104,15 +105,15 @@ func (suite *CouponHandlerTestSuite) TestCreateCoupon() {
 		{
 			name: "Fail",
 			body: &domain.CreateCouponReq{
-				Code:          "SAVE10",
-				DiscountType:  "fixed",
-				DiscountValue: 10,
+				Code:           "SAVE10",
+				DiscountType:   "fixed",
+				DiscountAmount: money.New(1000, "USD"),
 			},
 			setup: func() {
 				suite.mockCouponService.On("Create", mock.Anything, &domain.CreateCouponReq{
-					Code:          "SAVE10",
-					DiscountType:  "fixed",
-					DiscountValue: 10,
+					Code:           "SAVE10",
+					DiscountType:   "fixed",
+					DiscountAmount: money.New(1000, "USD"),
 				}).Return(nil, errors.New("duplicate code")).Times(1)
 			},
 			expected: http.StatusInternalServerError,
@@ -147,10 +148,10 @@ func (suite *CouponHandlerTestSuite) TestGetCouponByCode() {
 			setup: func() {
 				suite.mockCouponService.On("GetByCode", mock.Anything, "SAVE10").
 					Return(&model.Coupon{
-						ID:            "c1",
-						Code:          "SAVE10",
-						DiscountType:  model.DiscountTypeFixed,
-						DiscountValue: 10,
+						ID:             "c1",
+						Code:           "SAVE10",
+						DiscountType:   model.DiscountTypeFixed,
+						DiscountAmount: money.New(1000, "USD"),
 					}, nil).Times(1)
 			},
 			expected: http.StatusOK,
@@ -17,7 +17,9 @@ import (
 	"goshop/internal/order/model"
 	"goshop/internal/order/service"
 	srvMocks "goshop/internal/order/service/mocks"
+	"goshop/pkg/apperror"
 	"goshop/pkg/config"
+	"goshop/pkg/money"
 )
 
 func TestPlaceOrder_UnauthorizedNoUserID(t *testing.T) {
@@ -170,3 +172,28 @@ func TestCancelOrder_MissingID(t *testing.T) {
 	h.CancelOrder(c)
 	require.Equal(t, http.StatusBadRequest, w.Code)
 }
+
+func TestQuoteShipping(t *testing.T) {
+	logger.Initialize(config.ProductionEnv)
+	gin.SetMode(gin.TestMode)
+	mockSvc := srvMocks.NewOrderService(t)
+	mockSvc.On("QuoteShipping", mock.Anything, &domain.QuoteShippingReq{
+		Lines: []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 2}}, Country: "DE", Currency: "EUR",
+	}).Return([]*domain.ShippingQuote{{Code: "standard", Rate: money.New(460, "EUR")}}, nil).Once()
+	mockSvc.On("QuoteShipping", mock.Anything, mock.Anything).
+		Return(nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "currency GBP is not supported")).Once()
+	h := NewOrderHandler(mockSvc)
+
+	quote := func(body string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(body))
+		h.QuoteShipping(c)
+		return w
+	}
+	w := quote(`{"lines":[{"product_id":"p1","quantity":2}],"country":"DE","currency":"EUR"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"code":"standard"`)
+	require.Equal(t, http.StatusBadRequest, quote(`{"lines":[{"product_id":"p1","quantity":1}],"currency":"GBP"}`).Code)
+	require.Equal(t, http.StatusBadRequest, quote(`{`).Code)
+}
@@ -201,3 +201,29 @@ func (a *OrderHandler) CancelOrder(c *gin.Context) {
 
 	response.JSON(c, http.StatusOK, domain.OrderFromModel(order))
 }
+
+// QuoteShipping godoc
+//
+//	@Summary	quote shipping for a cart before placing the order
+//	@Tags		orders
+//	@Produce	json
+//	@Param		_	body		domain.QuoteShippingReq	true	"Body"
+//	@Success	200	{object}	[]domain.ShippingQuote
+//	@Router		/api/v1/shipping-methods/quote [post]
+func (a *OrderHandler) QuoteShipping(c *gin.Context) {
+	var req domain.QuoteShippingReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to get body", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	quotes, err := a.service.QuoteShipping(c, &req)
+	if err != nil {
+		logger.Error("Failed to quote shipping: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, quotes)
+}
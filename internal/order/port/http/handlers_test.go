@@ -19,6 +19,7 @@ import (
 	"goshop/internal/order/service/mocks"
 	productMocks "goshop/internal/product/service/mocks"
 	"goshop/pkg/config"
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
 	"goshop/pkg/response"
 	"goshop/pkg/utils"
@@ -81,7 +82,7 @@ func (suite *OrderHandlerTestSuite) TestPlaceOrder() {
 					&model.Order{
 						ID:         "orderId1",
 						Code:       "orderCode1",
-						TotalPrice: 8,
+						TotalPrice: money.New(800, "USD"),
 						Status:     model.OrderStatusNew,
 						Lines: []*model.OrderLine{
 							{ProductID: "productId1", Quantity: 2},
@@ -97,7 +98,7 @@ func (suite *OrderHandlerTestSuite) TestPlaceOrder() {
 				var orderRes domain.Order
 				_ = json.Unmarshal(writer.Body.Bytes(), &res)
 				_ = utils.Copy(&orderRes, &res.Result)
-				suite.Equal(float64(8), orderRes.TotalPrice)
+				suite.Equal(money.New(800, "USD"), orderRes.TotalPrice)
 				suite.Equal(string(model.OrderStatusNew), orderRes.Status)
 				suite.Equal(2, len(orderRes.Lines))
 			},
@@ -206,7 +207,7 @@ func (suite *OrderHandlerTestSuite) TestGetOrderByID() {
 						&model.Order{
 							ID:         "orderId1",
 							UserID:     "123456",
-							TotalPrice: 5,
+							TotalPrice: money.New(500, "USD"),
 							Status:     model.OrderStatusNew,
 						},
 						nil,
@@ -218,7 +219,7 @@ func (suite *OrderHandlerTestSuite) TestGetOrderByID() {
 				var orderRes domain.Order
 				_ = json.Unmarshal(writer.Body.Bytes(), &res)
 				_ = utils.Copy(&orderRes, &res.Result)
-				suite.Equal(float64(5), orderRes.TotalPrice)
+				suite.Equal(money.New(500, "USD"), orderRes.TotalPrice)
 				suite.Equal(string(model.OrderStatusNew), orderRes.Status)
 				suite.Equal(0, len(orderRes.Lines))
 			},
@@ -295,7 +296,7 @@ func (suite *OrderHandlerTestSuite) TestGetOrders() {
 							{
 								ID:         "orderId1",
 								UserID:     "123456",
-								TotalPrice: 5,
+								TotalPrice: money.New(500, "USD"),
 								Status:     model.OrderStatusNew,
 							},
 						},
@@ -314,7 +315,7 @@ func (suite *OrderHandlerTestSuite) TestGetOrders() {
 				_ = utils.Copy(&orderRes, &res.Result)
 				suite.Equal(1, len(orderRes.Orders))
 				suite.Equal("orderId1", orderRes.Orders[0].ID)
-				suite.Equal(float64(5), orderRes.Orders[0].TotalPrice)
+				suite.Equal(money.New(500, "USD"), orderRes.Orders[0].TotalPrice)
 				suite.Equal(string(model.OrderStatusNew), orderRes.Orders[0].Status)
 			},
 		},
@@ -387,7 +388,7 @@ func (suite *OrderHandlerTestSuite) TestCancelOrder() {
 						&model.Order{
 							ID:         "orderId1",
 							UserID:     "123456",
-							TotalPrice: 5,
+							TotalPrice: money.New(500, "USD"),
 							Status:     model.OrderStatusNew,
 						},
 						nil,
@@ -400,7 +401,7 @@ func (suite *OrderHandlerTestSuite) TestCancelOrder() {
 				_ = json.Unmarshal(writer.Body.Bytes(), &res)
 				_ = utils.Copy(&orderRes, &res.Result)
 				suite.Equal("orderId1", orderRes.ID)
-				suite.Equal(float64(5), orderRes.TotalPrice)
+				suite.Equal(money.New(500, "USD"), orderRes.TotalPrice)
 				suite.Equal(string(model.OrderStatusNew), orderRes.Status)
 			},
 		},
@@ -0,0 +1,106 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/order/model"
+	"goshop/internal/order/service"
+	"goshop/pkg/apperror"
+	"goshop/pkg/invoice"
+	"goshop/pkg/middleware"
+)
+
+type InvoiceHandler struct {
+	service service.InvoiceService
+}
+
+func NewInvoiceHandler(svc service.InvoiceService) *InvoiceHandler {
+	return &InvoiceHandler{service: svc}
+}
+
+// GetInvoice godoc
+//
+//	@Summary	download an order's invoice
+//	@Tags		orders
+//	@Produce	application/pdf
+//	@Produce	html
+//	@Security	ApiKeyAuth
+//	@Param		id		path	string	true	"Order ID"
+//	@Param		format	query	string	false	"pdf (default) or html"
+//	@Success	200		{file}	file
+//	@Router		/api/v1/orders/{id}/invoice [get]
+func (h *InvoiceHandler) GetInvoice(c *gin.Context) {
+	orderID := c.Param("id")
+	inv, err := h.service.GetInvoice(c, orderID, ownerFilter(c))
+	if err != nil {
+		logger.Errorf("Failed to get invoice, order id: %s, error: %s", orderID, err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+	renderDocument(c, inv)
+}
+
+// GetCreditNote godoc
+//
+//	@Summary	download one of an order's credit notes
+//	@Tags		orders
+//	@Produce	application/pdf
+//	@Produce	html
+//	@Security	ApiKeyAuth
+//	@Param		id		path	string	true	"Order ID"
+//	@Param		number	path	string	true	"Credit note number"
+//	@Param		format	query	string	false	"pdf (default) or html"
+//	@Success	200		{file}	file
+//	@Router		/api/v1/orders/{id}/credit-notes/{number} [get]
+func (h *InvoiceHandler) GetCreditNote(c *gin.Context) {
+	orderID := c.Param("id")
+	number := c.Param("number")
+	inv, err := h.service.GetCreditNote(c, orderID, number, ownerFilter(c))
+	if err != nil {
+		logger.Errorf("Failed to get credit note %s, order id: %s, error: %s", number, orderID, err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+	renderDocument(c, inv)
+}
+
+// ownerFilter is the user whose documents the caller may see; admins see everyone's.
+func ownerFilter(c *gin.Context) string {
+	if middleware.IsAdmin(c) {
+		return ""
+	}
+	return c.GetString("userId")
+}
+
+// renderDocument writes inv in the requested format as a download.
+func renderDocument(c *gin.Context, inv *model.Invoice) {
+	var (
+		body        []byte
+		err         error
+		ext         string
+		contentType string
+	)
+	switch format := c.DefaultQuery("format", "pdf"); format {
+	case "pdf":
+		body, err = invoice.PDF(&inv.Document)
+		ext, contentType = "pdf", "application/pdf"
+	case "html":
+		body, err = invoice.HTML(&inv.Document)
+		ext, contentType = "html", "text/html; charset=utf-8"
+	default:
+		apperror.WrapMessage(apperror.ErrBadRequest, nil, fmt.Sprintf("unknown format %q, want pdf or html", format)).HTTPError(c)
+		return
+	}
+	if err != nil {
+		logger.Errorf("Failed to render %s, error: %s", inv.Number, err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, inv.Document.Filename(ext)))
+	c.Data(http.StatusOK, contentType, body)
+}
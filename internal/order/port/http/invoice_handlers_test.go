@@ -0,0 +1,91 @@
+package http
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"goshop/internal/order/model"
+	svcMocks "goshop/internal/order/service/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/invoice"
+	"goshop/pkg/money"
+)
+
+// setupInvoiceRouter signs requests in as u1, or as an admin when the role query is admin.
+func setupInvoiceRouter(t *testing.T) (*gin.Engine, *svcMocks.InvoiceService) {
+	logger.Initialize(config.ProductionEnv)
+	gin.SetMode(gin.TestMode)
+	svc := svcMocks.NewInvoiceService(t)
+	h := NewInvoiceHandler(svc)
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set("userId", "u1")
+		c.Set("role", c.Query("role"))
+	})
+	r.GET("/orders/:id/invoice", h.GetInvoice)
+	r.GET("/orders/:id/credit-notes/:number", h.GetCreditNote)
+	return r, svc
+}
+
+func testInvoice(kind invoice.Kind, number string) *model.Invoice {
+	return &model.Invoice{Kind: kind, Number: number, UserID: "u1", Document: invoice.Document{
+		Kind: kind, Number: number, OrderCode: "SO1", Currency: "USD",
+		Seller: invoice.Party{Name: "GoShop"}, Buyer: invoice.Party{Name: "Ann Buyer"},
+		Subtotal: money.New(1000, "USD"), Total: money.New(1000, "USD"),
+	}}
+}
+
+func TestGetInvoiceHandler(t *testing.T) {
+	r, svc := setupInvoiceRouter(t)
+	svc.On("GetInvoice", mock.Anything, "o1", "u1").Return(testInvoice(invoice.KindInvoice, "INV-2026-000001"), nil).Twice()
+
+	w := serveShipment(r, http.MethodGet, "/orders/o1/invoice", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "application/pdf", w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="INV-2026-000001.pdf"`, w.Header().Get("Content-Disposition"))
+	require.True(t, strings.HasPrefix(w.Body.String(), "%PDF-"))
+
+	w = serveShipment(r, http.MethodGet, "/orders/o1/invoice?format=html", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "text/html; charset=utf-8", w.Header().Get("Content-Type"))
+	require.Equal(t, `attachment; filename="INV-2026-000001.html"`, w.Header().Get("Content-Disposition"))
+	require.Contains(t, w.Body.String(), "INV-2026-000001")
+}
+
+func TestGetInvoiceHandler_AdminSeesAnyOrder(t *testing.T) {
+	r, svc := setupInvoiceRouter(t)
+	svc.On("GetInvoice", mock.Anything, "o1", "").Return(testInvoice(invoice.KindInvoice, "INV-2026-000001"), nil).Once()
+
+	require.Equal(t, http.StatusOK, serveShipment(r, http.MethodGet, "/orders/o1/invoice?role=admin", "").Code)
+}
+
+func TestGetInvoiceHandler_Errors(t *testing.T) {
+	r, svc := setupInvoiceRouter(t)
+	svc.On("GetInvoice", mock.Anything, "o2", "u1").
+		Return(nil, apperror.WrapMessage(apperror.ErrNotFound, nil, "invoice not found")).Once()
+	require.Equal(t, http.StatusNotFound, serveShipment(r, http.MethodGet, "/orders/o2/invoice", "").Code)
+
+	svc.On("GetInvoice", mock.Anything, "o1", "u1").Return(testInvoice(invoice.KindInvoice, "INV-2026-000001"), nil).Once()
+	require.Equal(t, http.StatusBadRequest, serveShipment(r, http.MethodGet, "/orders/o1/invoice?format=docx", "").Code)
+}
+
+func TestGetCreditNoteHandler(t *testing.T) {
+	r, svc := setupInvoiceRouter(t)
+	svc.On("GetCreditNote", mock.Anything, "o1", "CN-2026-000002", "u1").
+		Return(testInvoice(invoice.KindCreditNote, "CN-2026-000002"), nil).Once()
+
+	w := serveShipment(r, http.MethodGet, "/orders/o1/credit-notes/CN-2026-000002", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, `attachment; filename="CN-2026-000002.pdf"`, w.Header().Get("Content-Disposition"))
+
+	svc.On("GetCreditNote", mock.Anything, "o1", "CN-2026-000009", "u1").
+		Return(nil, apperror.WrapMessage(apperror.ErrNotFound, nil, "credit note not found")).Once()
+	require.Equal(t, http.StatusNotFound, serveShipment(r, http.MethodGet, "/orders/o1/credit-notes/CN-2026-000009", "").Code)
+}
@@ -4,15 +4,18 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/quangdangfit/gocommon/validation"
 
-	notificationRepo "goshop/internal/notification/repository"
-	notificationSvc "goshop/internal/notification/service"
+	inventoryRepository "goshop/internal/inventory/repository"
 	"goshop/internal/order/repository"
 	"goshop/internal/order/service"
-	userRepository "goshop/internal/user/repository"
+	outboxRepository "goshop/internal/outbox/repository"
+	paymentService "goshop/internal/payment/service"
 	"goshop/pkg/config"
+	"goshop/pkg/currency"
 	"goshop/pkg/dbs"
 	"goshop/pkg/middleware"
-	"goshop/pkg/notification"
+	"goshop/pkg/shipping"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
 )
 
 func Routes(r *gin.RouterGroup, db dbs.Database, validator validation.Validation) {
@@ -21,26 +24,28 @@ func Routes(r *gin.RouterGroup, db dbs.Database, validator validation.Validation
 	couponRepo := repository.NewCouponRepository(db)
 	userRepo := repository.NewUserRepository(db)
 	reservationRepo := repository.NewReservationRepository(db)
+	warehouseRepo := repository.NewWarehouseRepository(db)
+	shipmentRepo := repository.NewShipmentRepository(db)
+	shippingRateRepo := repository.NewShippingRateRepository(db)
+
+	outboxRepo := outboxRepository.NewOutboxRepository(db)
+	ledgerRepo := inventoryRepository.NewLedgerRepository(db)
 
 	cfg := config.GetConfig()
-	couponSvc := service.NewCouponService(validator, couponRepo)
-	prefChecker := notificationSvc.NewDBPreferenceChecker(
-		notificationSvc.NewUserRepoLookup(userRepository.NewUserRepository(db)),
-		notificationRepo.NewPreferenceRepository(db),
-	)
-	notifier := notification.BuildDefault(notification.Settings{
-		SMTPHost:     cfg.SMTPHost,
-		SMTPPort:     cfg.SMTPPort,
-		SMTPUser:     cfg.SMTPUser,
-		SMTPPassword: cfg.SMTPPassword,
-		EmailFrom:    cfg.EmailFrom,
-		Prefs:        prefChecker,
-		DLQ:          notificationRepo.NewDeadLetterSink(db),
-	})
-
-	orderSvc := service.NewOrderService(validator, db, orderRepo, productRepo, userRepo, reservationRepo, couponSvc, notifier)
+	rates := currency.MustParseRates(cfg.BaseCurrency, cfg.ExchangeRates)
+	couponSvc := service.NewCouponService(validator, couponRepo, rates)
+	shippingSvc := service.NewShippingService(validator, shippingRateRepo, rates, shipping.MustParseMethods(cfg.BaseCurrency, cfg.ShippingMethods))
+	taxSvc := service.NewTaxService(validator, repository.NewTaxRateRepository(db), tax.Mode(cfg.TaxMode))
+	invoiceSvc := service.NewInvoices(cfg, db)
+	orderSvc := service.NewOrderService(validator, db, orderRepo, productRepo, userRepo, reservationRepo, couponSvc, outboxRepo, ledgerRepo,
+		warehouseRepo, stock.AllocationStrategy(cfg.WarehouseAllocation), rates, shippingSvc, taxSvc, invoiceSvc,
+		paymentService.NewSettler(cfg, db))
 	orderHandler := NewOrderHandler(orderSvc)
+	shippingHandler := NewShippingHandler(shippingSvc)
+	taxHandler := NewTaxHandler(taxSvc)
+	invoiceHandler := NewInvoiceHandler(invoiceSvc)
 	couponHandler := NewCouponHandler(couponSvc)
+	shipmentHandler := NewShipmentHandler(service.NewShipmentService(validator, db, orderRepo, shipmentRepo, userRepo, outboxRepo))
 
 	authMiddleware := middleware.JWTAuth()
 	adminMiddleware := middleware.AdminOnly()
@@ -52,6 +57,29 @@ func Routes(r *gin.RouterGroup, db dbs.Database, validator validation.Validation
 		orderRoute.GET("", orderHandler.GetOrders)
 		orderRoute.PUT("/:id/cancel", orderHandler.CancelOrder)
 		orderRoute.PUT("/:id/status", adminMiddleware, orderHandler.UpdateOrderStatus)
+		orderRoute.POST("/:id/shipments", adminMiddleware, shipmentHandler.CreateShipment)
+		orderRoute.PUT("/:id/shipments/:shipment_id", adminMiddleware, shipmentHandler.UpdateShipment)
+		orderRoute.GET("/:id/invoice", invoiceHandler.GetInvoice)
+		orderRoute.GET("/:id/credit-notes/:number", invoiceHandler.GetCreditNote)
+	}
+
+	r.GET("/shipping-methods", shippingHandler.ListMethods)
+	r.POST("/shipping-methods/quote", orderHandler.QuoteShipping)
+
+	shippingRateRoute := r.Group("/shipping-rates", authMiddleware, adminMiddleware)
+	{
+		shippingRateRoute.GET("", shippingHandler.ListRates)
+		shippingRateRoute.POST("", shippingHandler.CreateRate)
+		shippingRateRoute.PUT("/:code", shippingHandler.UpdateRate)
+		shippingRateRoute.DELETE("/:code", shippingHandler.DeleteRate)
+	}
+
+	taxRateRoute := r.Group("/tax-rates", authMiddleware, adminMiddleware)
+	{
+		taxRateRoute.GET("", taxHandler.ListRates)
+		taxRateRoute.POST("", taxHandler.CreateRate)
+		taxRateRoute.PUT("/:id", taxHandler.UpdateRate)
+		taxRateRoute.DELETE("/:id", taxHandler.DeleteRate)
 	}
 
 	couponRoute := r.Group("/coupons", authMiddleware)
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/service"
+	"goshop/pkg/apperror"
+	"goshop/pkg/response"
+)
+
+type ShipmentHandler struct {
+	service service.ShipmentService
+}
+
+func NewShipmentHandler(svc service.ShipmentService) *ShipmentHandler {
+	return &ShipmentHandler{service: svc}
+}
+
+// CreateShipment godoc
+//
+//	@Summary	pack order lines into a shipment (admin)
+//	@Tags		orders
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string						true	"Order ID"
+//	@Param		_	body		domain.CreateShipmentReq	true	"Body"
+//	@Success	200	{object}	domain.Shipment
+//	@Router		/api/v1/orders/{id}/shipments [post]
+func (h *ShipmentHandler) CreateShipment(c *gin.Context) {
+	orderID := c.Param("id")
+	var req domain.CreateShipmentReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to get body: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	shipment, err := h.service.CreateShipment(c, orderID, &req)
+	if err != nil {
+		logger.Errorf("Failed to create shipment, order id: %s, error: %s", orderID, err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ShipmentFromModel(shipment))
+}
+
+// UpdateShipment godoc
+//
+//	@Summary	update shipment status (admin)
+//	@Tags		orders
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id			path		string						true	"Order ID"
+//	@Param		shipment_id	path		string						true	"Shipment ID"
+//	@Param		_			body		domain.UpdateShipmentReq	true	"Body"
+//	@Success	200			{object}	domain.Shipment
+//	@Router		/api/v1/orders/{id}/shipments/{shipment_id} [put]
+func (h *ShipmentHandler) UpdateShipment(c *gin.Context) {
+	orderID := c.Param("id")
+	shipmentID := c.Param("shipment_id")
+	var req domain.UpdateShipmentReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to get body: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	shipment, err := h.service.UpdateShipment(c, orderID, shipmentID, &req)
+	if err != nil {
+		logger.Errorf("Failed to update shipment %s, order id: %s, error: %s", shipmentID, orderID, err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ShipmentFromModel(shipment))
+}
@@ -0,0 +1,71 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	svcMocks "goshop/internal/order/service/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+)
+
+func setupShipmentRouter(t *testing.T) (*gin.Engine, *svcMocks.ShipmentService) {
+	logger.Initialize(config.ProductionEnv)
+	gin.SetMode(gin.TestMode)
+	svc := svcMocks.NewShipmentService(t)
+	h := NewShipmentHandler(svc)
+	r := gin.New()
+	r.POST("/orders/:id/shipments", h.CreateShipment)
+	r.PUT("/orders/:id/shipments/:shipment_id", h.UpdateShipment)
+	return r, svc
+}
+
+func serveShipment(r *gin.Engine, method, path, body string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(method, path, strings.NewReader(body)))
+	return w
+}
+
+func TestCreateShipmentHandler(t *testing.T) {
+	r, svc := setupShipmentRouter(t)
+	svc.On("CreateShipment", mock.Anything, "o1", &domain.CreateShipmentReq{
+		Carrier: "dhl",
+		Lines:   []domain.ShipmentLineReq{{OrderLineID: "l1", Quantity: 1}},
+	}).Return(&model.Shipment{ID: "s1", Status: model.ShipmentStatusPacked, Carrier: "dhl"}, nil).Once()
+
+	w := serveShipment(r, http.MethodPost, "/orders/o1/shipments", `{"carrier":"dhl","lines":[{"order_line_id":"l1","quantity":1}]}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"status":"packed"`)
+
+	require.Equal(t, http.StatusBadRequest, serveShipment(r, http.MethodPost, "/orders/o1/shipments", `{`).Code)
+
+	svc.On("CreateShipment", mock.Anything, "o2", mock.Anything).
+		Return(nil, apperror.WrapMessage(apperror.ErrInvalidStatus, nil, "order is paid, only an order in progress can be shipped")).Once()
+	w = serveShipment(r, http.MethodPost, "/orders/o2/shipments", `{"lines":[{"order_line_id":"l1","quantity":1}]}`)
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestUpdateShipmentHandler(t *testing.T) {
+	r, svc := setupShipmentRouter(t)
+	svc.On("UpdateShipment", mock.Anything, "o1", "s1", &domain.UpdateShipmentReq{Status: "shipped", Carrier: "ups", TrackingNumber: "1Z"}).
+		Return(&model.Shipment{ID: "s1", Status: model.ShipmentStatusShipped, Carrier: "ups", TrackingNumber: "1Z"}, nil).Once()
+
+	w := serveShipment(r, http.MethodPut, "/orders/o1/shipments/s1", `{"status":"shipped","carrier":"ups","tracking_number":"1Z"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"tracking_number":"1Z"`)
+
+	require.Equal(t, http.StatusBadRequest, serveShipment(r, http.MethodPut, "/orders/o1/shipments/s1", `{`).Code)
+
+	svc.On("UpdateShipment", mock.Anything, "o1", "sx", mock.Anything).
+		Return(nil, apperror.WrapMessage(apperror.ErrNotFound, nil, "shipment not found")).Once()
+	require.Equal(t, http.StatusNotFound, serveShipment(r, http.MethodPut, "/orders/o1/shipments/sx", `{"status":"shipped"}`).Code)
+}
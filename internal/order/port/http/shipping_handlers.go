@@ -0,0 +1,133 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/service"
+	"goshop/pkg/apperror"
+	"goshop/pkg/response"
+)
+
+type ShippingHandler struct {
+	service service.ShippingService
+}
+
+func NewShippingHandler(svc service.ShippingService) *ShippingHandler {
+	return &ShippingHandler{service: svc}
+}
+
+// ListMethods godoc
+//
+//	@Summary	list shipping methods offered at checkout
+//	@Tags		shipping
+//	@Produce	json
+//	@Success	200	{object}	[]domain.ShippingMethod
+//	@Router		/api/v1/shipping-methods [get]
+func (h *ShippingHandler) ListMethods(c *gin.Context) {
+	methods, err := h.service.Methods(c)
+	if err != nil {
+		logger.Error("Failed to list shipping methods: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ShippingMethodsFromList(methods))
+}
+
+// ListRates godoc
+//
+//	@Summary	list configured shipping rates (admin)
+//	@Tags		shipping
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Success	200	{object}	[]domain.ShippingRate
+//	@Router		/api/v1/shipping-rates [get]
+func (h *ShippingHandler) ListRates(c *gin.Context) {
+	rates, err := h.service.List(c)
+	if err != nil {
+		logger.Error("Failed to list shipping rates: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ShippingRatesFromModel(rates))
+}
+
+// CreateRate godoc
+//
+//	@Summary	add a shipping rate (admin)
+//	@Tags		shipping
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	body		domain.CreateShippingRateReq	true	"Body"
+//	@Success	200	{object}	domain.ShippingRate
+//	@Router		/api/v1/shipping-rates [post]
+func (h *ShippingHandler) CreateRate(c *gin.Context) {
+	var req domain.CreateShippingRateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to get body: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	rate, err := h.service.Create(c, &req)
+	if err != nil {
+		logger.Error("Failed to create shipping rate: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ShippingRateFromModel(rate))
+}
+
+// UpdateRate godoc
+//
+//	@Summary	update a shipping rate (admin)
+//	@Tags		shipping
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		code	path		string							true	"Shipping method code"
+//	@Param		_		body		domain.UpdateShippingRateReq	true	"Body"
+//	@Success	200		{object}	domain.ShippingRate
+//	@Router		/api/v1/shipping-rates/{code} [put]
+func (h *ShippingHandler) UpdateRate(c *gin.Context) {
+	code := c.Param("code")
+	var req domain.UpdateShippingRateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to get body: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	rate, err := h.service.Update(c, code, &req)
+	if err != nil {
+		logger.Errorf("Failed to update shipping rate %s, error: %s", code, err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ShippingRateFromModel(rate))
+}
+
+// DeleteRate godoc
+//
+//	@Summary	delete a shipping rate (admin)
+//	@Tags		shipping
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		code	path	string	true	"Shipping method code"
+//	@Router		/api/v1/shipping-rates/{code} [delete]
+func (h *ShippingHandler) DeleteRate(c *gin.Context) {
+	code := c.Param("code")
+	if err := h.service.Delete(c, code); err != nil {
+		logger.Errorf("Failed to delete shipping rate %s, error: %s", code, err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, nil)
+}
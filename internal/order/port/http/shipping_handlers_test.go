@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	svcMocks "goshop/internal/order/service/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/money"
+	"goshop/pkg/shipping"
+)
+
+func setupShippingRouter(t *testing.T) (*gin.Engine, *svcMocks.ShippingService) {
+	logger.Initialize(config.ProductionEnv)
+	gin.SetMode(gin.TestMode)
+	svc := svcMocks.NewShippingService(t)
+	h := NewShippingHandler(svc)
+	r := gin.New()
+	r.GET("/shipping-methods", h.ListMethods)
+	r.GET("/shipping-rates", h.ListRates)
+	r.POST("/shipping-rates", h.CreateRate)
+	r.PUT("/shipping-rates/:code", h.UpdateRate)
+	r.DELETE("/shipping-rates/:code", h.DeleteRate)
+	return r, svc
+}
+
+func TestListShippingMethodsHandler(t *testing.T) {
+	r, svc := setupShippingRouter(t)
+	svc.On("Methods", mock.Anything).Return(shipping.MustParseMethods("USD", "standard:4.99").List(), nil).Once()
+	w := serveShipment(r, http.MethodGet, "/shipping-methods", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"rule":{"type":"flat","amount":{"amount":"4.99","currency":"USD"}`)
+
+	svc.On("Methods", mock.Anything).Return(nil, errors.New("db")).Once()
+	require.Equal(t, http.StatusInternalServerError, serveShipment(r, http.MethodGet, "/shipping-methods", "").Code)
+}
+
+func TestListShippingRatesHandler(t *testing.T) {
+	r, svc := setupShippingRouter(t)
+	svc.On("List", mock.Anything).Return([]*model.ShippingRate{{Code: "express", Active: true, Rule: shipping.Flat(money.New(999, "USD"))}}, nil).Once()
+	w := serveShipment(r, http.MethodGet, "/shipping-rates", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"code":"express"`)
+}
+
+func TestCreateShippingRateHandler(t *testing.T) {
+	r, svc := setupShippingRouter(t)
+	svc.On("Create", mock.Anything, &domain.CreateShippingRateReq{Code: "express", Rule: shipping.Flat(money.New(999, "USD"))}).
+		Return(&model.ShippingRate{Code: "express", Active: true, Rule: shipping.Flat(money.New(999, "USD"))}, nil).Once()
+	w := serveShipment(r, http.MethodPost, "/shipping-rates", `{"code":"express","rule":{"type":"flat","amount":{"amount":"9.99","currency":"USD"}}}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"active":true`)
+
+	require.Equal(t, http.StatusBadRequest, serveShipment(r, http.MethodPost, "/shipping-rates", `{`).Code)
+
+	svc.On("Create", mock.Anything, mock.Anything).Return(nil, apperror.WrapMessage(apperror.ErrConflict, nil, "shipping rate express already exists")).Once()
+	require.Equal(t, http.StatusConflict, serveShipment(r, http.MethodPost, "/shipping-rates", `{"code":"express","rule":{"type":"flat"}}`).Code)
+}
+
+func TestUpdateShippingRateHandler(t *testing.T) {
+	r, svc := setupShippingRouter(t)
+	active := false
+	svc.On("Update", mock.Anything, "express", &domain.UpdateShippingRateReq{Active: &active}).
+		Return(&model.ShippingRate{Code: "express"}, nil).Once()
+	require.Equal(t, http.StatusOK, serveShipment(r, http.MethodPut, "/shipping-rates/express", `{"active":false}`).Code)
+
+	require.Equal(t, http.StatusBadRequest, serveShipment(r, http.MethodPut, "/shipping-rates/express", `{`).Code)
+
+	svc.On("Update", mock.Anything, "drone", mock.Anything).Return(nil, apperror.WrapMessage(apperror.ErrNotFound, nil, "shipping rate not found")).Once()
+	require.Equal(t, http.StatusNotFound, serveShipment(r, http.MethodPut, "/shipping-rates/drone", `{}`).Code)
+}
+
+func TestDeleteShippingRateHandler(t *testing.T) {
+	r, svc := setupShippingRouter(t)
+	svc.On("Delete", mock.Anything, "express").Return(nil).Once()
+	require.Equal(t, http.StatusOK, serveShipment(r, http.MethodDelete, "/shipping-rates/express", "").Code)
+
+	svc.On("Delete", mock.Anything, "drone").Return(apperror.WrapMessage(apperror.ErrNotFound, nil, "shipping rate not found")).Once()
+	require.Equal(t, http.StatusNotFound, serveShipment(r, http.MethodDelete, "/shipping-rates/drone", "").Code)
+}
@@ -0,0 +1,115 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/service"
+	"goshop/pkg/apperror"
+	"goshop/pkg/response"
+)
+
+type TaxHandler struct {
+	service service.TaxService
+}
+
+func NewTaxHandler(svc service.TaxService) *TaxHandler {
+	return &TaxHandler{service: svc}
+}
+
+// ListRates godoc
+//
+//	@Summary	list configured tax rates (admin)
+//	@Tags		tax
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Success	200	{object}	[]domain.TaxRate
+//	@Router		/api/v1/tax-rates [get]
+func (h *TaxHandler) ListRates(c *gin.Context) {
+	rates, err := h.service.List(c)
+	if err != nil {
+		logger.Error("Failed to list tax rates: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.TaxRatesFromModel(rates))
+}
+
+// CreateRate godoc
+//
+//	@Summary	add a tax rate (admin)
+//	@Tags		tax
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	body		domain.CreateTaxRateReq	true	"Body"
+//	@Success	200	{object}	domain.TaxRate
+//	@Router		/api/v1/tax-rates [post]
+func (h *TaxHandler) CreateRate(c *gin.Context) {
+	var req domain.CreateTaxRateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to get body: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	rate, err := h.service.Create(c, &req)
+	if err != nil {
+		logger.Error("Failed to create tax rate: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.TaxRateFromModel(rate))
+}
+
+// UpdateRate godoc
+//
+//	@Summary	update a tax rate (admin)
+//	@Tags		tax
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string					true	"Tax rate ID"
+//	@Param		_	body		domain.UpdateTaxRateReq	true	"Body"
+//	@Success	200	{object}	domain.TaxRate
+//	@Router		/api/v1/tax-rates/{id} [put]
+func (h *TaxHandler) UpdateRate(c *gin.Context) {
+	id := c.Param("id")
+	var req domain.UpdateTaxRateReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to get body: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	rate, err := h.service.Update(c, id, &req)
+	if err != nil {
+		logger.Errorf("Failed to update tax rate %s, error: %s", id, err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.TaxRateFromModel(rate))
+}
+
+// DeleteRate godoc
+//
+//	@Summary	delete a tax rate (admin)
+//	@Tags		tax
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path	string	true	"Tax rate ID"
+//	@Router		/api/v1/tax-rates/{id} [delete]
+func (h *TaxHandler) DeleteRate(c *gin.Context) {
+	id := c.Param("id")
+	if err := h.service.Delete(c, id); err != nil {
+		logger.Errorf("Failed to delete tax rate %s, error: %s", id, err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, nil)
+}
@@ -0,0 +1,79 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	svcMocks "goshop/internal/order/service/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/money"
+)
+
+func setupTaxRouter(t *testing.T) (*gin.Engine, *svcMocks.TaxService) {
+	logger.Initialize(config.ProductionEnv)
+	gin.SetMode(gin.TestMode)
+	svc := svcMocks.NewTaxService(t)
+	h := NewTaxHandler(svc)
+	r := gin.New()
+	r.GET("/tax-rates", h.ListRates)
+	r.POST("/tax-rates", h.CreateRate)
+	r.PUT("/tax-rates/:id", h.UpdateRate)
+	r.DELETE("/tax-rates/:id", h.DeleteRate)
+	return r, svc
+}
+
+func TestListTaxRatesHandler(t *testing.T) {
+	r, svc := setupTaxRouter(t)
+	svc.On("List", mock.Anything).Return([]*model.TaxRate{{ID: "r1", Country: "US", Region: "CA", TaxClass: "standard", Rate: 725}}, nil).Once()
+	w := serveShipment(r, http.MethodGet, "/tax-rates", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"region":"CA","tax_class":"standard","rate":7.25`)
+
+	svc.On("List", mock.Anything).Return(nil, errors.New("db")).Once()
+	require.Equal(t, http.StatusInternalServerError, serveShipment(r, http.MethodGet, "/tax-rates", "").Code)
+}
+
+func TestCreateTaxRateHandler(t *testing.T) {
+	r, svc := setupTaxRouter(t)
+	svc.On("Create", mock.Anything, &domain.CreateTaxRateReq{Country: "DE", TaxClass: "reduced", Rate: 700}).
+		Return(&model.TaxRate{ID: "r1", Country: "DE", TaxClass: "reduced", Rate: 700}, nil).Once()
+	w := serveShipment(r, http.MethodPost, "/tax-rates", `{"country":"DE","tax_class":"reduced","rate":"7"}`)
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"rate":7`)
+
+	require.Equal(t, http.StatusBadRequest, serveShipment(r, http.MethodPost, "/tax-rates", `{`).Code)
+
+	svc.On("Create", mock.Anything, mock.Anything).Return(nil, apperror.WrapMessage(apperror.ErrConflict, nil, "DE already has a rate for tax class reduced")).Once()
+	require.Equal(t, http.StatusConflict, serveShipment(r, http.MethodPost, "/tax-rates", `{"country":"DE","tax_class":"reduced","rate":7}`).Code)
+}
+
+func TestUpdateTaxRateHandler(t *testing.T) {
+	r, svc := setupTaxRouter(t)
+	rate := money.Percent(1900)
+	svc.On("Update", mock.Anything, "r1", &domain.UpdateTaxRateReq{Rate: &rate}).
+		Return(&model.TaxRate{ID: "r1", Country: "DE", TaxClass: "standard", Rate: 1900}, nil).Once()
+	require.Equal(t, http.StatusOK, serveShipment(r, http.MethodPut, "/tax-rates/r1", `{"rate":19}`).Code)
+
+	require.Equal(t, http.StatusBadRequest, serveShipment(r, http.MethodPut, "/tax-rates/r1", `{`).Code)
+
+	svc.On("Update", mock.Anything, "missing", mock.Anything).Return(nil, apperror.WrapMessage(apperror.ErrNotFound, nil, "tax rate not found")).Once()
+	require.Equal(t, http.StatusNotFound, serveShipment(r, http.MethodPut, "/tax-rates/missing", `{}`).Code)
+}
+
+func TestDeleteTaxRateHandler(t *testing.T) {
+	r, svc := setupTaxRouter(t)
+	svc.On("Delete", mock.Anything, "r1").Return(nil).Once()
+	require.Equal(t, http.StatusOK, serveShipment(r, http.MethodDelete, "/tax-rates/r1", "").Code)
+
+	svc.On("Delete", mock.Anything, "missing").Return(apperror.WrapMessage(apperror.ErrNotFound, nil, "tax rate not found")).Once()
+	require.Equal(t, http.StatusNotFound, serveShipment(r, http.MethodDelete, "/tax-rates/missing", "").Code)
+}
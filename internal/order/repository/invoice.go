@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"context"
+
+	"goshop/internal/order/model"
+	"goshop/pkg/dbs"
+	"goshop/pkg/invoice"
+)
+
+// InvoiceRepository stores issued invoices and credit notes and numbers them.
+//
+//go:generate mockery --name=InvoiceRepository
+type InvoiceRepository interface {
+	// NextSequence takes the next number in kind's series for year. Call it in the transaction
+	// that stores the document: the series row stays locked until it commits, and a rollback
+	// hands the number back, so the series has no gaps.
+	NextSequence(ctx context.Context, kind invoice.Kind, year int) (int, error)
+	Create(ctx context.Context, inv *model.Invoice) error
+	// GetInvoice returns the order's invoice.
+	GetInvoice(ctx context.Context, orderID string) (*model.Invoice, error)
+	// GetCreditNote returns the order's credit note with the given number.
+	GetCreditNote(ctx context.Context, orderID, number string) (*model.Invoice, error)
+	// GetByRefundID returns the credit note issued for a refund.
+	GetByRefundID(ctx context.Context, refundID string) (*model.Invoice, error)
+}
+
+type invoiceRepo struct {
+	db dbs.Database
+}
+
+func NewInvoiceRepository(db dbs.Database) InvoiceRepository {
+	return &invoiceRepo{db: db}
+}
+
+func (r *invoiceRepo) NextSequence(ctx context.Context, kind invoice.Kind, year int) (int, error) {
+	var seq int
+	err := r.db.GetDB().WithContext(ctx).Raw(`
+		INSERT INTO invoice_sequences (kind, year, last_number) VALUES (?, ?, 1)
+		ON CONFLICT (kind, year) DO UPDATE SET last_number = invoice_sequences.last_number + 1
+		RETURNING last_number`, kind, year).Scan(&seq).Error
+	return seq, err
+}
+
+func (r *invoiceRepo) Create(ctx context.Context, inv *model.Invoice) error {
+	return r.db.Create(ctx, inv)
+}
+
+func (r *invoiceRepo) GetInvoice(ctx context.Context, orderID string) (*model.Invoice, error) {
+	return r.findOne(ctx, dbs.NewQuery("order_id = ? AND kind = ?", orderID, invoice.KindInvoice))
+}
+
+func (r *invoiceRepo) GetCreditNote(ctx context.Context, orderID, number string) (*model.Invoice, error) {
+	return r.findOne(ctx, dbs.NewQuery("order_id = ? AND number = ? AND kind = ?", orderID, number, invoice.KindCreditNote))
+}
+
+func (r *invoiceRepo) GetByRefundID(ctx context.Context, refundID string) (*model.Invoice, error) {
+	return r.findOne(ctx, dbs.NewQuery("refund_id = ?", refundID))
+}
+
+func (r *invoiceRepo) findOne(ctx context.Context, query dbs.Query) (*model.Invoice, error) {
+	var inv model.Invoice
+	if err := r.db.FindOne(ctx, &inv, dbs.WithQuery(query)); err != nil {
+		return nil, err
+	}
+	return &inv, nil
+}
@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/model"
+	dbsMocks "goshop/pkg/dbs/mocks"
+	"goshop/pkg/invoice"
+)
+
+func TestInvoiceRepo_NextSequence(t *testing.T) {
+	g, m := newCouponSQLMockGormDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	m.ExpectQuery(`INSERT INTO invoice_sequences .* ON CONFLICT \(kind, year\) DO UPDATE .* RETURNING last_number`).
+		WithArgs(invoice.KindCreditNote, 2026).
+		WillReturnRows(sqlmock.NewRows([]string{"last_number"}).AddRow(7))
+
+	seq, err := NewInvoiceRepository(dbm).NextSequence(context.Background(), invoice.KindCreditNote, 2026)
+	require.NoError(t, err)
+	require.Equal(t, 7, seq)
+
+	m.ExpectQuery(`INSERT INTO invoice_sequences`).WillReturnError(errors.New("db"))
+	_, err = NewInvoiceRepository(dbm).NextSequence(context.Background(), invoice.KindInvoice, 2026)
+	require.EqualError(t, err, "db")
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestInvoiceRepo_Get(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.Invoice{}, mock.Anything).Return(nil).Twice()
+	repo := NewInvoiceRepository(dbm)
+
+	inv, err := repo.GetInvoice(context.Background(), "o1")
+	require.NoError(t, err)
+	require.NotNil(t, inv)
+	_, err = repo.GetCreditNote(context.Background(), "o1", "CN-2026-000001")
+	require.NoError(t, err)
+
+	dbm.On("FindOne", mock.Anything, &model.Invoice{}, mock.Anything).Return(gorm.ErrRecordNotFound).Once()
+	inv, err = repo.GetByRefundID(context.Background(), "missing")
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.Nil(t, inv)
+}
+
+func TestInvoiceRepo_Create(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	inv := &model.Invoice{Kind: invoice.KindInvoice, Number: "INV-2026-000001"}
+	dbm.On("Create", mock.Anything, inv).Return(nil).Once()
+	require.NoError(t, NewInvoiceRepository(dbm).Create(context.Background(), inv))
+}
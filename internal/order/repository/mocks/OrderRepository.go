@@ -8,6 +8,7 @@ import (
 	"context"
 	"goshop/internal/order/domain"
 	"goshop/internal/order/model"
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
 
 	mock "github.com/stretchr/testify/mock"
@@ -41,8 +42,8 @@ func (_m *OrderRepository) EXPECT() *OrderRepository_Expecter {
 }
 
 // CreateOrder provides a mock function for the type OrderRepository
-func (_mock *OrderRepository) CreateOrder(ctx context.Context, userID string, lines []*model.OrderLine, couponCode string, discountAmount float64) (*model.Order, error) {
-	ret := _mock.Called(ctx, userID, lines, couponCode, discountAmount)
+func (_mock *OrderRepository) CreateOrder(ctx context.Context, userID string, lines []*model.OrderLine, couponCode string, discount money.Money) (*model.Order, error) {
+	ret := _mock.Called(ctx, userID, lines, couponCode, discount)
 
 	if len(ret) == 0 {
 		panic("no return value specified for CreateOrder")
@@ -50,18 +51,18 @@ func (_mock *OrderRepository) CreateOrder(ctx context.Context, userID string, li
 
 	var r0 *model.Order
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []*model.OrderLine, string, float64) (*model.Order, error)); ok {
-		return returnFunc(ctx, userID, lines, couponCode, discountAmount)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []*model.OrderLine, string, money.Money) (*model.Order, error)); ok {
+		return returnFunc(ctx, userID, lines, couponCode, discount)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []*model.OrderLine, string, float64) *model.Order); ok {
-		r0 = returnFunc(ctx, userID, lines, couponCode, discountAmount)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, []*model.OrderLine, string, money.Money) *model.Order); ok {
+		r0 = returnFunc(ctx, userID, lines, couponCode, discount)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*model.Order)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []*model.OrderLine, string, float64) error); ok {
-		r1 = returnFunc(ctx, userID, lines, couponCode, discountAmount)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, []*model.OrderLine, string, money.Money) error); ok {
+		r1 = returnFunc(ctx, userID, lines, couponCode, discount)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -78,12 +79,12 @@ type OrderRepository_CreateOrder_Call struct {
 //   - userID string
 //   - lines []*model.OrderLine
 //   - couponCode string
-//   - discountAmount float64
-func (_e *OrderRepository_Expecter) CreateOrder(ctx interface{}, userID interface{}, lines interface{}, couponCode interface{}, discountAmount interface{}) *OrderRepository_CreateOrder_Call {
-	return &OrderRepository_CreateOrder_Call{Call: _e.mock.On("CreateOrder", ctx, userID, lines, couponCode, discountAmount)}
+//   - discount money.Money
+func (_e *OrderRepository_Expecter) CreateOrder(ctx interface{}, userID interface{}, lines interface{}, couponCode interface{}, discount interface{}) *OrderRepository_CreateOrder_Call {
+	return &OrderRepository_CreateOrder_Call{Call: _e.mock.On("CreateOrder", ctx, userID, lines, couponCode, discount)}
 }
 
-func (_c *OrderRepository_CreateOrder_Call) Run(run func(ctx context.Context, userID string, lines []*model.OrderLine, couponCode string, discountAmount float64)) *OrderRepository_CreateOrder_Call {
+func (_c *OrderRepository_CreateOrder_Call) Run(run func(ctx context.Context, userID string, lines []*model.OrderLine, couponCode string, discount money.Money)) *OrderRepository_CreateOrder_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -101,9 +102,9 @@ func (_c *OrderRepository_CreateOrder_Call) Run(run func(ctx context.Context, us
 		if args[3] != nil {
 			arg3 = args[3].(string)
 		}
-		var arg4 float64
+		var arg4 money.Money
 		if args[4] != nil {
-			arg4 = args[4].(float64)
+			arg4 = args[4].(money.Money)
 		}
 		run(
 			arg0,
@@ -121,7 +122,7 @@ func (_c *OrderRepository_CreateOrder_Call) Return(order *model.Order, err error
 	return _c
 }
 
-func (_c *OrderRepository_CreateOrder_Call) RunAndReturn(run func(ctx context.Context, userID string, lines []*model.OrderLine, couponCode string, discountAmount float64) (*model.Order, error)) *OrderRepository_CreateOrder_Call {
+func (_c *OrderRepository_CreateOrder_Call) RunAndReturn(run func(ctx context.Context, userID string, lines []*model.OrderLine, couponCode string, discount money.Money) (*model.Order, error)) *OrderRepository_CreateOrder_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -276,6 +277,199 @@ func (_c *OrderRepository_GetOrderByID_Call) RunAndReturn(run func(ctx context.C
 	return _c
 }
 
+// ListPaymentAttempts provides a mock function for the type OrderRepository
+func (_mock *OrderRepository) ListPaymentAttempts(ctx context.Context, orderID string) ([]*model.PaymentAttempt, error) {
+	ret := _mock.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListPaymentAttempts")
+	}
+
+	var r0 []*model.PaymentAttempt
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.PaymentAttempt, error)); ok {
+		return returnFunc(ctx, orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.PaymentAttempt); ok {
+		r0 = returnFunc(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PaymentAttempt)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderRepository_ListPaymentAttempts_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListPaymentAttempts'
+type OrderRepository_ListPaymentAttempts_Call struct {
+	*mock.Call
+}
+
+// ListPaymentAttempts is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID string
+func (_e *OrderRepository_Expecter) ListPaymentAttempts(ctx interface{}, orderID interface{}) *OrderRepository_ListPaymentAttempts_Call {
+	return &OrderRepository_ListPaymentAttempts_Call{Call: _e.mock.On("ListPaymentAttempts", ctx, orderID)}
+}
+
+func (_c *OrderRepository_ListPaymentAttempts_Call) Run(run func(ctx context.Context, orderID string)) *OrderRepository_ListPaymentAttempts_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepository_ListPaymentAttempts_Call) Return(paymentAttempts []*model.PaymentAttempt, err error) *OrderRepository_ListPaymentAttempts_Call {
+	_c.Call.Return(paymentAttempts, err)
+	return _c
+}
+
+func (_c *OrderRepository_ListPaymentAttempts_Call) RunAndReturn(run func(ctx context.Context, orderID string) ([]*model.PaymentAttempt, error)) *OrderRepository_ListPaymentAttempts_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ListShipments provides a mock function for the type OrderRepository
+func (_mock *OrderRepository) ListShipments(ctx context.Context, orderID string) ([]*model.Shipment, error) {
+	ret := _mock.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListShipments")
+	}
+
+	var r0 []*model.Shipment
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) ([]*model.Shipment, error)); ok {
+		return returnFunc(ctx, orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) []*model.Shipment); ok {
+		r0 = returnFunc(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Shipment)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderRepository_ListShipments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListShipments'
+type OrderRepository_ListShipments_Call struct {
+	*mock.Call
+}
+
+// ListShipments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID string
+func (_e *OrderRepository_Expecter) ListShipments(ctx interface{}, orderID interface{}) *OrderRepository_ListShipments_Call {
+	return &OrderRepository_ListShipments_Call{Call: _e.mock.On("ListShipments", ctx, orderID)}
+}
+
+func (_c *OrderRepository_ListShipments_Call) Run(run func(ctx context.Context, orderID string)) *OrderRepository_ListShipments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepository_ListShipments_Call) Return(shipments []*model.Shipment, err error) *OrderRepository_ListShipments_Call {
+	_c.Call.Return(shipments, err)
+	return _c
+}
+
+func (_c *OrderRepository_ListShipments_Call) RunAndReturn(run func(ctx context.Context, orderID string) ([]*model.Shipment, error)) *OrderRepository_ListShipments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// LockOrder provides a mock function for the type OrderRepository
+func (_mock *OrderRepository) LockOrder(ctx context.Context, id string) error {
+	ret := _mock.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for LockOrder")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = returnFunc(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// OrderRepository_LockOrder_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'LockOrder'
+type OrderRepository_LockOrder_Call struct {
+	*mock.Call
+}
+
+// LockOrder is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+func (_e *OrderRepository_Expecter) LockOrder(ctx interface{}, id interface{}) *OrderRepository_LockOrder_Call {
+	return &OrderRepository_LockOrder_Call{Call: _e.mock.On("LockOrder", ctx, id)}
+}
+
+func (_c *OrderRepository_LockOrder_Call) Run(run func(ctx context.Context, id string)) *OrderRepository_LockOrder_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderRepository_LockOrder_Call) Return(err error) *OrderRepository_LockOrder_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OrderRepository_LockOrder_Call) RunAndReturn(run func(ctx context.Context, id string) error) *OrderRepository_LockOrder_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // UpdateOrder provides a mock function for the type OrderRepository
 func (_mock *OrderRepository) UpdateOrder(ctx context.Context, order *model.Order) error {
 	ret := _mock.Called(ctx, order)
@@ -38,6 +38,69 @@ func (_m *ProductRepository) EXPECT() *ProductRepository_Expecter {
 	return &ProductRepository_Expecter{mock: &_m.Mock}
 }
 
+// CommitReservation provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) CommitReservation(ctx context.Context, id string, qty int) error {
+	ret := _mock.Called(ctx, id, qty)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CommitReservation")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = returnFunc(ctx, id, qty)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProductRepository_CommitReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CommitReservation'
+type ProductRepository_CommitReservation_Call struct {
+	*mock.Call
+}
+
+// CommitReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - qty int
+func (_e *ProductRepository_Expecter) CommitReservation(ctx interface{}, id interface{}, qty interface{}) *ProductRepository_CommitReservation_Call {
+	return &ProductRepository_CommitReservation_Call{Call: _e.mock.On("CommitReservation", ctx, id, qty)}
+}
+
+func (_c *ProductRepository_CommitReservation_Call) Run(run func(ctx context.Context, id string, qty int)) *ProductRepository_CommitReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_CommitReservation_Call) Return(err error) *ProductRepository_CommitReservation_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProductRepository_CommitReservation_Call) RunAndReturn(run func(ctx context.Context, id string, qty int) error) *ProductRepository_CommitReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // DecrementStock provides a mock function for the type ProductRepository
 func (_mock *ProductRepository) DecrementStock(ctx context.Context, id string, qty int) error {
 	ret := _mock.Called(ctx, id, qty)
@@ -169,26 +232,191 @@ func (_c *ProductRepository_GetProductByID_Call) RunAndReturn(run func(ctx conte
 	return _c
 }
 
-func (_m *ProductRepository) ReserveStock(ctx context.Context, id string, qty int) error {
-	ret := _m.Called(ctx, id, qty)
-	if fn, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
-		return fn(ctx, id, qty)
+// ReleaseReservation provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) ReleaseReservation(ctx context.Context, id string, qty int) error {
+	ret := _mock.Called(ctx, id, qty)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseReservation")
 	}
-	return ret.Error(0)
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = returnFunc(ctx, id, qty)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProductRepository_ReleaseReservation_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReleaseReservation'
+type ProductRepository_ReleaseReservation_Call struct {
+	*mock.Call
+}
+
+// ReleaseReservation is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - qty int
+func (_e *ProductRepository_Expecter) ReleaseReservation(ctx interface{}, id interface{}, qty interface{}) *ProductRepository_ReleaseReservation_Call {
+	return &ProductRepository_ReleaseReservation_Call{Call: _e.mock.On("ReleaseReservation", ctx, id, qty)}
+}
+
+func (_c *ProductRepository_ReleaseReservation_Call) Run(run func(ctx context.Context, id string, qty int)) *ProductRepository_ReleaseReservation_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_ReleaseReservation_Call) Return(err error) *ProductRepository_ReleaseReservation_Call {
+	_c.Call.Return(err)
+	return _c
 }
 
-func (_m *ProductRepository) CommitReservation(ctx context.Context, id string, qty int) error {
-	ret := _m.Called(ctx, id, qty)
-	if fn, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
-		return fn(ctx, id, qty)
+func (_c *ProductRepository_ReleaseReservation_Call) RunAndReturn(run func(ctx context.Context, id string, qty int) error) *ProductRepository_ReleaseReservation_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ReserveStock provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) ReserveStock(ctx context.Context, id string, qty int) error {
+	ret := _mock.Called(ctx, id, qty)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReserveStock")
 	}
-	return ret.Error(0)
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = returnFunc(ctx, id, qty)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProductRepository_ReserveStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReserveStock'
+type ProductRepository_ReserveStock_Call struct {
+	*mock.Call
+}
+
+// ReserveStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - qty int
+func (_e *ProductRepository_Expecter) ReserveStock(ctx interface{}, id interface{}, qty interface{}) *ProductRepository_ReserveStock_Call {
+	return &ProductRepository_ReserveStock_Call{Call: _e.mock.On("ReserveStock", ctx, id, qty)}
+}
+
+func (_c *ProductRepository_ReserveStock_Call) Run(run func(ctx context.Context, id string, qty int)) *ProductRepository_ReserveStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_ReserveStock_Call) Return(err error) *ProductRepository_ReserveStock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProductRepository_ReserveStock_Call) RunAndReturn(run func(ctx context.Context, id string, qty int) error) *ProductRepository_ReserveStock_Call {
+	_c.Call.Return(run)
+	return _c
 }
 
-func (_m *ProductRepository) ReleaseReservation(ctx context.Context, id string, qty int) error {
-	ret := _m.Called(ctx, id, qty)
-	if fn, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
-		return fn(ctx, id, qty)
+// ReturnCommitted provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) ReturnCommitted(ctx context.Context, id string, qty int) error {
+	ret := _mock.Called(ctx, id, qty)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReturnCommitted")
 	}
-	return ret.Error(0)
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = returnFunc(ctx, id, qty)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProductRepository_ReturnCommitted_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReturnCommitted'
+type ProductRepository_ReturnCommitted_Call struct {
+	*mock.Call
+}
+
+// ReturnCommitted is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - qty int
+func (_e *ProductRepository_Expecter) ReturnCommitted(ctx interface{}, id interface{}, qty interface{}) *ProductRepository_ReturnCommitted_Call {
+	return &ProductRepository_ReturnCommitted_Call{Call: _e.mock.On("ReturnCommitted", ctx, id, qty)}
+}
+
+func (_c *ProductRepository_ReturnCommitted_Call) Run(run func(ctx context.Context, id string, qty int)) *ProductRepository_ReturnCommitted_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_ReturnCommitted_Call) Return(err error) *ProductRepository_ReturnCommitted_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProductRepository_ReturnCommitted_Call) RunAndReturn(run func(ctx context.Context, id string, qty int) error) *ProductRepository_ReturnCommitted_Call {
+	_c.Call.Return(run)
+	return _c
 }
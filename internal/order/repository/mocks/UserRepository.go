@@ -38,6 +38,148 @@ func (_m *UserRepository) EXPECT() *UserRepository_Expecter {
 	return &UserRepository_Expecter{mock: &_m.Mock}
 }
 
+// GetAddress provides a mock function for the type UserRepository
+func (_mock *UserRepository) GetAddress(ctx context.Context, userID string, id string) (*model.Address, error) {
+	ret := _mock.Called(ctx, userID, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAddress")
+	}
+
+	var r0 *model.Address
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) (*model.Address, error)); ok {
+		return returnFunc(ctx, userID, id)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string) *model.Address); ok {
+		r0 = returnFunc(ctx, userID, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Address)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = returnFunc(ctx, userID, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_GetAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAddress'
+type UserRepository_GetAddress_Call struct {
+	*mock.Call
+}
+
+// GetAddress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+//   - id string
+func (_e *UserRepository_Expecter) GetAddress(ctx interface{}, userID interface{}, id interface{}) *UserRepository_GetAddress_Call {
+	return &UserRepository_GetAddress_Call{Call: _e.mock.On("GetAddress", ctx, userID, id)}
+}
+
+func (_c *UserRepository_GetAddress_Call) Run(run func(ctx context.Context, userID string, id string)) *UserRepository_GetAddress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetAddress_Call) Return(address *model.Address, err error) *UserRepository_GetAddress_Call {
+	_c.Call.Return(address, err)
+	return _c
+}
+
+func (_c *UserRepository_GetAddress_Call) RunAndReturn(run func(ctx context.Context, userID string, id string) (*model.Address, error)) *UserRepository_GetAddress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDefaultAddress provides a mock function for the type UserRepository
+func (_mock *UserRepository) GetDefaultAddress(ctx context.Context, userID string) (*model.Address, error) {
+	ret := _mock.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDefaultAddress")
+	}
+
+	var r0 *model.Address
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Address, error)); ok {
+		return returnFunc(ctx, userID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Address); ok {
+		r0 = returnFunc(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Address)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_GetDefaultAddress_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDefaultAddress'
+type UserRepository_GetDefaultAddress_Call struct {
+	*mock.Call
+}
+
+// GetDefaultAddress is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID string
+func (_e *UserRepository_Expecter) GetDefaultAddress(ctx interface{}, userID interface{}) *UserRepository_GetDefaultAddress_Call {
+	return &UserRepository_GetDefaultAddress_Call{Call: _e.mock.On("GetDefaultAddress", ctx, userID)}
+}
+
+func (_c *UserRepository_GetDefaultAddress_Call) Run(run func(ctx context.Context, userID string)) *UserRepository_GetDefaultAddress_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_GetDefaultAddress_Call) Return(address *model.Address, err error) *UserRepository_GetDefaultAddress_Call {
+	_c.Call.Return(address, err)
+	return _c
+}
+
+func (_c *UserRepository_GetDefaultAddress_Call) RunAndReturn(run func(ctx context.Context, userID string) (*model.Address, error)) *UserRepository_GetDefaultAddress_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetUserByID provides a mock function for the type UserRepository
 func (_mock *UserRepository) GetUserByID(ctx context.Context, id string) (*model.User, error) {
 	ret := _mock.Called(ctx, id)
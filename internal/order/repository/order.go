@@ -3,19 +3,30 @@ package repository
 import (
 	"context"
 
+	"gorm.io/gorm"
+
 	"goshop/internal/order/domain"
 	"goshop/internal/order/model"
+	"goshop/pkg/currency"
 	"goshop/pkg/dbs"
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
 	"goshop/pkg/utils"
 )
 
 //go:generate mockery --name=OrderRepository
 type OrderRepository interface {
-	CreateOrder(ctx context.Context, userID string, lines []*model.OrderLine, couponCode string, discountAmount float64) (*model.Order, error)
+	CreateOrder(ctx context.Context, userID string, lines []*model.OrderLine, couponCode string, discount money.Money) (*model.Order, error)
 	GetOrderByID(ctx context.Context, id string, preload bool) (*model.Order, error)
 	GetMyOrders(ctx context.Context, req *domain.ListOrderReq) ([]*model.Order, *paging.Pagination, error)
 	UpdateOrder(ctx context.Context, order *model.Order) error
+	// LockOrder row-locks the order until the transaction it runs in ends, so changes checked
+	// against the order's current state (e.g. what is left to ship) are made one at a time.
+	LockOrder(ctx context.Context, id string) error
+	// ListPaymentAttempts returns the order's payment attempts, oldest first.
+	ListPaymentAttempts(ctx context.Context, orderID string) ([]*model.PaymentAttempt, error)
+	// ListShipments returns the order's shipments with their lines, oldest first.
+	ListShipments(ctx context.Context, orderID string) ([]*model.Shipment, error)
 }
 
 type orderRepo struct {
@@ -26,16 +37,22 @@ func NewOrderRepository(db dbs.Database) OrderRepository {
 	return &orderRepo{db: db}
 }
 
-func (r *orderRepo) CreateOrder(ctx context.Context, userID string, lines []*model.OrderLine, couponCode string, discountAmount float64) (*model.Order, error) {
+func (r *orderRepo) CreateOrder(ctx context.Context, userID string, lines []*model.OrderLine, couponCode string, discount money.Money) (*model.Order, error) {
 	order := new(model.Order)
+	if len(lines) > 0 {
+		order.Currency = lines[0].Currency
+	}
+	if order.Currency == "" {
+		order.Currency = currency.Default
+	}
 
-	var totalPrice float64
+	total := money.Zero(order.Currency)
 	for _, line := range lines {
-		totalPrice += line.Price
+		total = total.Add(line.Price)
 	}
-	order.TotalPrice = totalPrice
-	order.DiscountAmount = discountAmount
-	order.FinalPrice = totalPrice - discountAmount
+	order.TotalPrice = total
+	order.DiscountAmount = money.Zero(order.Currency).Add(discount)
+	order.FinalPrice = total.Sub(order.DiscountAmount)
 	order.CouponCode = couponCode
 	order.UserID = userID
 
@@ -133,3 +150,42 @@ func (r *orderRepo) GetMyOrders(ctx context.Context, req *domain.ListOrderReq) (
 func (r *orderRepo) UpdateOrder(ctx context.Context, order *model.Order) error {
 	return r.db.Update(ctx, order)
 }
+
+func (r *orderRepo) LockOrder(ctx context.Context, id string) error {
+	var locked []string
+	err := r.db.GetDB().WithContext(ctx).
+		Raw("SELECT id FROM orders WHERE id = ? AND deleted_at IS NULL FOR UPDATE", id).
+		Scan(&locked).Error
+	if err != nil {
+		return err
+	}
+	if len(locked) == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *orderRepo) ListPaymentAttempts(ctx context.Context, orderID string) ([]*model.PaymentAttempt, error) {
+	var attempts []*model.PaymentAttempt
+	err := r.db.Find(ctx, &attempts,
+		dbs.WithQuery(dbs.NewQuery("order_id = ?", orderID)),
+		dbs.WithOrder("attempt"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return attempts, nil
+}
+
+func (r *orderRepo) ListShipments(ctx context.Context, orderID string) ([]*model.Shipment, error) {
+	var shipments []*model.Shipment
+	err := r.db.Find(ctx, &shipments,
+		dbs.WithQuery(dbs.NewQuery("order_id = ?", orderID)),
+		dbs.WithPreload([]string{"Lines"}),
+		dbs.WithOrder("created_at"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return shipments, nil
+}
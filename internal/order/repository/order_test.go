@@ -5,14 +5,18 @@ import (
 	"errors"
 	"testing"
 
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/quangdangfit/gocommon/logger"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 
 	"goshop/internal/order/domain"
 	"goshop/internal/order/model"
 	"goshop/pkg/config"
 	"goshop/pkg/dbs/mocks"
+	"goshop/pkg/money"
 )
 
 type OrderRepositoryTestSuite struct {
@@ -37,7 +41,7 @@ func (suite *OrderRepositoryTestSuite) TestCreateOrder() {
 		name           string
 		setup          func()
 		wantErr        bool
-		wantTotalPrice float64
+		wantTotalPrice money.Money
 	}{
 		{
 			name: "Success",
@@ -57,7 +61,7 @@ func (suite *OrderRepositoryTestSuite) TestCreateOrder() {
 					}).
 					Return(nil).Times(1)
 			},
-			wantTotalPrice: 30.0,
+			wantTotalPrice: money.New(3000, "USD"),
 		},
 		{
 			name: "Transaction create fails",
@@ -98,18 +102,18 @@ func (suite *OrderRepositoryTestSuite) TestCreateOrder() {
 		suite.Run(tc.name, func() {
 			suite.SetupTest()
 			tc.setup()
-			orderLines := []*model.OrderLine{{ProductID: "p1", Quantity: 1, Price: 10.0}, {ProductID: "p2", Quantity: 2, Price: 20.0}}
+			orderLines := []*model.OrderLine{{ProductID: "p1", Quantity: 1, Price: money.New(1000, "USD")}, {ProductID: "p2", Quantity: 2, Price: money.New(2000, "USD")}}
 			if tc.name == "Success" || tc.name == "WithTransaction fails" {
 				orderLines = []*model.OrderLine{{ProductID: "productID", Quantity: 2}}
 			}
-			order, err := suite.repo.CreateOrder(context.Background(), "userID", orderLines, "", 0)
+			order, err := suite.repo.CreateOrder(context.Background(), "userID", orderLines, "", money.Zero("USD"))
 			if tc.wantErr {
 				suite.Nil(order)
 				suite.NotNil(err)
 			} else {
 				suite.NotNil(order)
 				suite.Nil(err)
-				if tc.wantTotalPrice > 0 {
+				if !tc.wantTotalPrice.IsZero() {
 					suite.Equal(tc.wantTotalPrice, order.TotalPrice)
 				}
 			}
@@ -117,6 +121,51 @@ func (suite *OrderRepositoryTestSuite) TestCreateOrder() {
 	}
 }
 
+func (suite *OrderRepositoryTestSuite) TestListPaymentAttempts() {
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				suite.mockDB.On("Find", mock.Anything, mock.AnythingOfType("*[]*model.PaymentAttempt"), mock.Anything, mock.Anything).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "DB error",
+			setup: func() {
+				suite.mockDB.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			tc.setup()
+			_, err := suite.repo.ListPaymentAttempts(context.Background(), "orderId1")
+			if tc.wantErr {
+				suite.NotNil(err)
+			} else {
+				suite.Nil(err)
+			}
+		})
+	}
+}
+
+func (suite *OrderRepositoryTestSuite) TestListShipments() {
+	suite.mockDB.On("Find", mock.Anything, mock.AnythingOfType("*[]*model.Shipment"), mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	_, err := suite.repo.ListShipments(context.Background(), "orderId1")
+	suite.Nil(err)
+
+	suite.SetupTest()
+	suite.mockDB.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error")).Once()
+	_, err = suite.repo.ListShipments(context.Background(), "orderId1")
+	suite.NotNil(err)
+}
+
 func (suite *OrderRepositoryTestSuite) TestUpdateOrder() {
 	tests := []struct {
 		name    string
@@ -251,3 +300,21 @@ func (suite *OrderRepositoryTestSuite) TestGetMyOrders() {
 		})
 	}
 }
+
+func TestOrderRepo_LockOrder(t *testing.T) {
+	g, m := newCouponSQLMockGormDB(t)
+	dbm := mocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	repo := NewOrderRepository(dbm)
+
+	m.ExpectQuery(`SELECT id FROM orders WHERE id = \$1 AND deleted_at IS NULL FOR UPDATE`).
+		WithArgs("o1").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("o1"))
+	require.NoError(t, repo.LockOrder(context.Background(), "o1"))
+
+	m.ExpectQuery(`FOR UPDATE`).WithArgs("o2").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	require.ErrorIs(t, repo.LockOrder(context.Background(), "o2"), gorm.ErrRecordNotFound)
+
+	m.ExpectQuery(`FOR UPDATE`).WillReturnError(errors.New("db"))
+	require.EqualError(t, repo.LockOrder(context.Background(), "o3"), "db")
+	require.NoError(t, m.ExpectationsWereMet())
+}
@@ -24,6 +24,9 @@ type ProductRepository interface {
 	// ReleaseReservation atomically decrements reserved_quantity by qty, returning units to
 	// available stock without touching the on-hand total.
 	ReleaseReservation(ctx context.Context, id string, qty int) error
+	// ReturnCommitted atomically increments stock_quantity by qty, putting back units a
+	// committed reservation had taken.
+	ReturnCommitted(ctx context.Context, id string, qty int) error
 }
 
 type productRepo struct {
@@ -36,7 +39,11 @@ func NewProductRepository(db dbs.Database) ProductRepository {
 
 func (r *productRepo) GetProductByID(ctx context.Context, id string) (*model.Product, error) {
 	var product model.Product
-	if err := r.db.FindById(ctx, id, &product); err != nil {
+	err := r.db.FindOne(ctx, &product,
+		dbs.WithQuery(dbs.NewQuery("id = ?", id)),
+		dbs.WithPreload([]string{"Category"}),
+	)
+	if err != nil {
 		return nil, err
 	}
 
@@ -110,3 +117,16 @@ func (r *productRepo) ReleaseReservation(ctx context.Context, id string, qty int
 	}
 	return nil
 }
+
+func (r *productRepo) ReturnCommitted(ctx context.Context, id string, qty int) error {
+	result := r.db.GetDB().WithContext(ctx).Model(&model.Product{}).
+		Where("id = ?", id).
+		UpdateColumn("stock_quantity", gorm.Expr("stock_quantity + ?", qty))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("return committed stock: product not found")
+	}
+	return nil
+}
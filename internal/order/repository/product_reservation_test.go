@@ -48,6 +48,9 @@ func TestProductReservation(t *testing.T) {
 	release := func(repo ProductRepository) error {
 		return repo.ReleaseReservation(context.Background(), "p1", 1)
 	}
+	returnCommitted := func(repo ProductRepository) error {
+		return repo.ReturnCommitted(context.Background(), "p1", 1)
+	}
 
 	tests := []struct {
 		name    string
@@ -65,6 +68,10 @@ func TestProductReservation(t *testing.T) {
 		{"release_success", release, outcome{rowsAffected: 1}},
 		{"release_no_rows_sentinel", release, outcome{rowsAffected: 0, wantErrIs: ErrReservationAlreadyReleased}},
 		{"release_db_error", release, outcome{dbErr: errors.New("boom"), wantAnyErr: true}},
+
+		{"return_success", returnCommitted, outcome{rowsAffected: 1}},
+		{"return_no_rows", returnCommitted, outcome{rowsAffected: 0, wantAnyErr: true}},
+		{"return_db_error", returnCommitted, outcome{dbErr: errors.New("boom"), wantAnyErr: true}},
 	}
 
 	for _, tt := range tests {
@@ -59,13 +59,13 @@ func (suite *ProductRepositoryTestSuite) TestGetProductByID() {
 		{
 			name: "Success",
 			setup: func() {
-				suite.mockDB.On("FindById", mock.Anything, "productId1", &model.Product{}).Return(nil).Times(1)
+				suite.mockDB.On("FindOne", mock.Anything, &model.Product{}, mock.Anything, mock.Anything).Return(nil).Times(1)
 			},
 		},
 		{
 			name: "Not found",
 			setup: func() {
-				suite.mockDB.On("FindById", mock.Anything, "productId1", &model.Product{}).Return(errors.New("error")).Times(1)
+				suite.mockDB.On("FindOne", mock.Anything, &model.Product{}, mock.Anything, mock.Anything).Return(errors.New("error")).Times(1)
 			},
 			wantErr: true,
 		},
@@ -12,8 +12,13 @@ import (
 type ReservationRepository interface {
 	CreateMany(ctx context.Context, items []*model.StockReservation) error
 	FindActiveByOrderID(ctx context.Context, orderID string) ([]*model.StockReservation, error)
+	// FindCommittedByOrderID returns the reservations whose units the order has taken.
+	FindCommittedByOrderID(ctx context.Context, orderID string) ([]*model.StockReservation, error)
 	FindExpired(ctx context.Context, now time.Time, limit int) ([]*model.StockReservation, error)
 	UpdateStatus(ctx context.Context, ids []string, status model.ReservationStatus) error
+	// ExtendActive pushes the expiry of an order's active reservations out to until. It never
+	// shortens a reservation.
+	ExtendActive(ctx context.Context, orderID string, until time.Time) error
 }
 
 type reservationRepo struct {
@@ -42,6 +47,17 @@ func (r *reservationRepo) FindActiveByOrderID(ctx context.Context, orderID strin
 	return rows, nil
 }
 
+func (r *reservationRepo) FindCommittedByOrderID(ctx context.Context, orderID string) ([]*model.StockReservation, error) {
+	var rows []*model.StockReservation
+	err := r.db.GetDB().WithContext(ctx).
+		Where("order_id = ? AND status = ?", orderID, model.ReservationStatusCommitted).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
 func (r *reservationRepo) FindExpired(ctx context.Context, now time.Time, limit int) ([]*model.StockReservation, error) {
 	var rows []*model.StockReservation
 	err := r.db.GetDB().WithContext(ctx).
@@ -63,3 +79,10 @@ func (r *reservationRepo) UpdateStatus(ctx context.Context, ids []string, status
 		Where("id IN ?", ids).
 		Update("status", status).Error
 }
+
+func (r *reservationRepo) ExtendActive(ctx context.Context, orderID string, until time.Time) error {
+	return r.db.GetDB().WithContext(ctx).
+		Model(&model.StockReservation{}).
+		Where("order_id = ? AND status = ? AND expires_at < ?", orderID, model.ReservationStatusActive, until).
+		Update("expires_at", until).Error
+}
@@ -64,6 +64,20 @@ func TestReservationRepo_FindActiveByOrderID_Error(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestReservationRepo_FindCommittedByOrderID(t *testing.T) {
+	g, m := newReservationSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	rows := sqlmock.NewRows([]string{"id"}).AddRow("r1")
+	m.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "stock_reservations" WHERE order_id = $1 AND status = $2`)).
+		WithArgs("o1", string(model.ReservationStatusCommitted)).WillReturnRows(rows)
+
+	got, err := NewReservationRepository(dbm).FindCommittedByOrderID(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+}
+
 func TestReservationRepo_FindExpired(t *testing.T) {
 	g, m := newReservationSQLMockDB(t)
 	dbm := dbsMocks.NewDatabase(t)
@@ -104,3 +118,19 @@ func TestReservationRepo_UpdateStatus(t *testing.T) {
 	err := NewReservationRepository(dbm).UpdateStatus(context.Background(), []string{"r1", "r2"}, model.ReservationStatusCommitted)
 	require.NoError(t, err)
 }
+
+func TestReservationRepo_ExtendActive(t *testing.T) {
+	g, m := newReservationSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	until := time.Now().Add(72 * time.Hour)
+	m.ExpectExec(regexp.QuoteMeta(`UPDATE "stock_reservations" SET "expires_at"=$1,"updated_at"=$2 `+
+		`WHERE order_id = $3 AND status = $4 AND expires_at < $5`)).
+		WithArgs(until, sqlmock.AnyArg(), "o1", model.ReservationStatusActive, until).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err := NewReservationRepository(dbm).ExtendActive(context.Background(), "o1", until)
+	require.NoError(t, err)
+	require.NoError(t, m.ExpectationsWereMet())
+}
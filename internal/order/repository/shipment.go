@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"goshop/internal/order/model"
+	"goshop/pkg/dbs"
+)
+
+// ShipmentRepository stores the parcels an order is split into. OrderRepository.ListShipments
+// lists an order's shipments.
+//
+//go:generate mockery --name=ShipmentRepository
+type ShipmentRepository interface {
+	// Create inserts the shipment together with its lines.
+	Create(ctx context.Context, shipment *model.Shipment) error
+	// Update saves the shipment's own columns; its lines are fixed once created.
+	Update(ctx context.Context, shipment *model.Shipment) error
+	// GetByID returns one of the order's shipments with its lines.
+	GetByID(ctx context.Context, orderID, id string) (*model.Shipment, error)
+}
+
+type shipmentRepo struct {
+	db dbs.Database
+}
+
+func NewShipmentRepository(db dbs.Database) ShipmentRepository {
+	return &shipmentRepo{db: db}
+}
+
+func (r *shipmentRepo) Create(ctx context.Context, shipment *model.Shipment) error {
+	return r.db.Create(ctx, shipment)
+}
+
+func (r *shipmentRepo) Update(ctx context.Context, shipment *model.Shipment) error {
+	return r.db.GetDB().WithContext(ctx).Omit(clause.Associations).Save(shipment).Error
+}
+
+func (r *shipmentRepo) GetByID(ctx context.Context, orderID, id string) (*model.Shipment, error) {
+	var shipment model.Shipment
+	err := r.db.FindOne(ctx, &shipment,
+		dbs.WithQuery(dbs.NewQuery("id = ?", id), dbs.NewQuery("order_id = ?", orderID)),
+		dbs.WithPreload([]string{"Lines"}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
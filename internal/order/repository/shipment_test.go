@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/model"
+	dbsMocks "goshop/pkg/dbs/mocks"
+)
+
+func TestShipmentRepo_Create(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	shipment := &model.Shipment{OrderID: "o1", Lines: []*model.ShipmentLine{{OrderLineID: "l1", Quantity: 1}}}
+	dbm.On("Create", mock.Anything, shipment).Return(nil).Once()
+	require.NoError(t, NewShipmentRepository(dbm).Create(context.Background(), shipment))
+}
+
+func TestShipmentRepo_UpdateLeavesLinesAlone(t *testing.T) {
+	g, m := newProductSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	m.ExpectExec(regexp.QuoteMeta(`UPDATE "shipments" SET`)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := NewShipmentRepository(dbm).Update(context.Background(), &model.Shipment{
+		ID:     "s1",
+		Status: model.ShipmentStatusShipped,
+		Lines:  []*model.ShipmentLine{{ID: "sl1", OrderLineID: "l1", Quantity: 1}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestShipmentRepo_GetByID(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.Shipment{}, mock.Anything, mock.Anything).Return(nil).Once()
+	shipment, err := NewShipmentRepository(dbm).GetByID(context.Background(), "o1", "s1")
+	require.NoError(t, err)
+	require.NotNil(t, shipment)
+
+	dbm = dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.Shipment{}, mock.Anything, mock.Anything).Return(gorm.ErrRecordNotFound).Once()
+	shipment, err = NewShipmentRepository(dbm).GetByID(context.Background(), "o1", "s1")
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.Nil(t, shipment)
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"goshop/internal/order/model"
+	"goshop/pkg/dbs"
+)
+
+// ShippingRateRepository stores the shipping methods admins configure.
+//
+//go:generate mockery --name=ShippingRateRepository
+type ShippingRateRepository interface {
+	// List returns every rate, active or not, by position.
+	List(ctx context.Context) ([]*model.ShippingRate, error)
+	GetByCode(ctx context.Context, code string) (*model.ShippingRate, error)
+	Create(ctx context.Context, rate *model.ShippingRate) error
+	Update(ctx context.Context, rate *model.ShippingRate) error
+	Delete(ctx context.Context, rate *model.ShippingRate) error
+}
+
+type shippingRateRepo struct {
+	db dbs.Database
+}
+
+func NewShippingRateRepository(db dbs.Database) ShippingRateRepository {
+	return &shippingRateRepo{db: db}
+}
+
+func (r *shippingRateRepo) List(ctx context.Context) ([]*model.ShippingRate, error) {
+	var rates []*model.ShippingRate
+	if err := r.db.Find(ctx, &rates, dbs.WithOrder("position, code")); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+func (r *shippingRateRepo) GetByCode(ctx context.Context, code string) (*model.ShippingRate, error) {
+	var rate model.ShippingRate
+	if err := r.db.FindOne(ctx, &rate, dbs.WithQuery(dbs.NewQuery("code = ?", code))); err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *shippingRateRepo) Create(ctx context.Context, rate *model.ShippingRate) error {
+	return r.db.Create(ctx, rate)
+}
+
+func (r *shippingRateRepo) Update(ctx context.Context, rate *model.ShippingRate) error {
+	return r.db.Update(ctx, rate)
+}
+
+func (r *shippingRateRepo) Delete(ctx context.Context, rate *model.ShippingRate) error {
+	return r.db.Delete(ctx, rate)
+}
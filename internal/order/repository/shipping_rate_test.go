@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/model"
+	dbsMocks "goshop/pkg/dbs/mocks"
+)
+
+func TestShippingRateRepo_List(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Find", mock.Anything, mock.AnythingOfType("*[]*model.ShippingRate"), mock.Anything).Return(nil).Once()
+	rates, err := NewShippingRateRepository(dbm).List(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, rates)
+
+	dbm = dbsMocks.NewDatabase(t)
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db")).Once()
+	_, err = NewShippingRateRepository(dbm).List(context.Background())
+	require.EqualError(t, err, "db")
+}
+
+func TestShippingRateRepo_GetByCode(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.ShippingRate{}, mock.Anything).Return(nil).Once()
+	rate, err := NewShippingRateRepository(dbm).GetByCode(context.Background(), "express")
+	require.NoError(t, err)
+	require.NotNil(t, rate)
+
+	dbm = dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.ShippingRate{}, mock.Anything).Return(gorm.ErrRecordNotFound).Once()
+	rate, err = NewShippingRateRepository(dbm).GetByCode(context.Background(), "drone")
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.Nil(t, rate)
+}
+
+func TestShippingRateRepo_Write(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	rate := &model.ShippingRate{Code: "express"}
+	dbm.On("Create", mock.Anything, rate).Return(nil).Once()
+	dbm.On("Update", mock.Anything, rate).Return(nil).Once()
+	dbm.On("Delete", mock.Anything, rate).Return(nil).Once()
+
+	repo := NewShippingRateRepository(dbm)
+	require.NoError(t, repo.Create(context.Background(), rate))
+	require.NoError(t, repo.Update(context.Background(), rate))
+	require.NoError(t, repo.Delete(context.Background(), rate))
+}
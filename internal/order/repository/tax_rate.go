@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+
+	"goshop/internal/order/model"
+	"goshop/pkg/dbs"
+)
+
+// TaxRateRepository stores the tax rates admins configure.
+//
+//go:generate mockery --name=TaxRateRepository
+type TaxRateRepository interface {
+	// List returns every rate by destination and class.
+	List(ctx context.Context) ([]*model.TaxRate, error)
+	GetByID(ctx context.Context, id string) (*model.TaxRate, error)
+	Create(ctx context.Context, rate *model.TaxRate) error
+	Update(ctx context.Context, rate *model.TaxRate) error
+	Delete(ctx context.Context, rate *model.TaxRate) error
+}
+
+type taxRateRepo struct {
+	db dbs.Database
+}
+
+func NewTaxRateRepository(db dbs.Database) TaxRateRepository {
+	return &taxRateRepo{db: db}
+}
+
+func (r *taxRateRepo) List(ctx context.Context) ([]*model.TaxRate, error) {
+	var rates []*model.TaxRate
+	if err := r.db.Find(ctx, &rates, dbs.WithOrder("country, region, tax_class")); err != nil {
+		return nil, err
+	}
+	return rates, nil
+}
+
+func (r *taxRateRepo) GetByID(ctx context.Context, id string) (*model.TaxRate, error) {
+	var rate model.TaxRate
+	if err := r.db.FindOne(ctx, &rate, dbs.WithQuery(dbs.NewQuery("id = ?", id))); err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (r *taxRateRepo) Create(ctx context.Context, rate *model.TaxRate) error {
+	return r.db.Create(ctx, rate)
+}
+
+func (r *taxRateRepo) Update(ctx context.Context, rate *model.TaxRate) error {
+	return r.db.Update(ctx, rate)
+}
+
+func (r *taxRateRepo) Delete(ctx context.Context, rate *model.TaxRate) error {
+	return r.db.Delete(ctx, rate)
+}
@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/model"
+	dbsMocks "goshop/pkg/dbs/mocks"
+)
+
+func TestTaxRateRepo_List(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Find", mock.Anything, mock.AnythingOfType("*[]*model.TaxRate"), mock.Anything).Return(nil).Once()
+	rates, err := NewTaxRateRepository(dbm).List(context.Background())
+	require.NoError(t, err)
+	require.Empty(t, rates)
+
+	dbm = dbsMocks.NewDatabase(t)
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db")).Once()
+	_, err = NewTaxRateRepository(dbm).List(context.Background())
+	require.EqualError(t, err, "db")
+}
+
+func TestTaxRateRepo_GetByID(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.TaxRate{}, mock.Anything).Return(nil).Once()
+	rate, err := NewTaxRateRepository(dbm).GetByID(context.Background(), "r1")
+	require.NoError(t, err)
+	require.NotNil(t, rate)
+
+	dbm = dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.TaxRate{}, mock.Anything).Return(gorm.ErrRecordNotFound).Once()
+	rate, err = NewTaxRateRepository(dbm).GetByID(context.Background(), "missing")
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.Nil(t, rate)
+}
+
+func TestTaxRateRepo_Write(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	rate := &model.TaxRate{Country: "DE", TaxClass: "standard", Rate: 1900}
+	dbm.On("Create", mock.Anything, rate).Return(nil).Once()
+	dbm.On("Update", mock.Anything, rate).Return(nil).Once()
+	dbm.On("Delete", mock.Anything, rate).Return(nil).Once()
+
+	repo := NewTaxRateRepository(dbm)
+	require.NoError(t, repo.Create(context.Background(), rate))
+	require.NoError(t, repo.Update(context.Background(), rate))
+	require.NoError(t, repo.Delete(context.Background(), rate))
+}
@@ -2,6 +2,9 @@ package repository
 
 import (
 	"context"
+	"errors"
+
+	"gorm.io/gorm"
 
 	"goshop/internal/order/model"
 	"goshop/pkg/dbs"
@@ -9,6 +12,10 @@ import (
 
 type UserRepository interface {
 	GetUserByID(ctx context.Context, id string) (*model.User, error)
+	// GetDefaultAddress returns the user's default address, or nil when they have none.
+	GetDefaultAddress(ctx context.Context, userID string) (*model.Address, error)
+	// GetAddress returns one of the user's saved addresses.
+	GetAddress(ctx context.Context, userID, id string) (*model.Address, error)
 }
 
 type userRepo struct {
@@ -26,3 +33,28 @@ func (r *userRepo) GetUserByID(ctx context.Context, id string) (*model.User, err
 	}
 	return &user, nil
 }
+
+func (r *userRepo) GetDefaultAddress(ctx context.Context, userID string) (*model.Address, error) {
+	var address model.Address
+	err := r.db.FindOne(ctx, &address,
+		dbs.WithQuery(dbs.NewQuery("user_id = ? AND is_default AND deleted_at IS NULL", userID)),
+	)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
+
+func (r *userRepo) GetAddress(ctx context.Context, userID, id string) (*model.Address, error) {
+	var address model.Address
+	err := r.db.FindOne(ctx, &address,
+		dbs.WithQuery(dbs.NewQuery("id = ? AND user_id = ? AND deleted_at IS NULL", id, userID)),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &address, nil
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/quangdangfit/gocommon/logger"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
 
 	"goshop/internal/order/model"
 	"goshop/pkg/config"
@@ -68,3 +69,38 @@ func (suite *UserRepositoryOrderTestSuite) TestGetUserByID() {
 		})
 	}
 }
+
+func (suite *UserRepositoryOrderTestSuite) TestGetDefaultAddress() {
+	tests := []struct {
+		name    string
+		err     error
+		wantNil bool
+		wantErr bool
+	}{
+		{name: "Success"},
+		{name: "No default address", err: gorm.ErrRecordNotFound, wantNil: true},
+		{name: "DB error", err: errors.New("db"), wantNil: true, wantErr: true},
+	}
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			suite.mockDB.On("FindOne", mock.Anything, &model.Address{}, mock.Anything).Return(tc.err).Times(1)
+			address, err := suite.repo.GetDefaultAddress(context.Background(), "u1")
+			suite.Equal(tc.wantErr, err != nil)
+			suite.Equal(tc.wantNil, address == nil)
+		})
+	}
+}
+
+func (suite *UserRepositoryOrderTestSuite) TestGetAddress() {
+	suite.mockDB.On("FindOne", mock.Anything, &model.Address{}, mock.Anything).Return(nil).Times(1)
+	address, err := suite.repo.GetAddress(context.Background(), "u1", "a1")
+	suite.NoError(err)
+	suite.NotNil(address)
+
+	suite.SetupTest()
+	suite.mockDB.On("FindOne", mock.Anything, &model.Address{}, mock.Anything).Return(gorm.ErrRecordNotFound).Times(1)
+	address, err = suite.repo.GetAddress(context.Background(), "u1", "a1")
+	suite.ErrorIs(err, gorm.ErrRecordNotFound)
+	suite.Nil(address)
+}
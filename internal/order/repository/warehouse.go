@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	"goshop/internal/order/model"
+	"goshop/pkg/dbs"
+)
+
+// WarehouseRepository moves per-warehouse stock counters. Call each method right after the
+// matching ProductRepository update, in the same transaction: the product row's lock
+// serializes every stock change for the product, warehouse rows included.
+//
+//go:generate mockery --name=WarehouseRepository
+type WarehouseRepository interface {
+	// ListStock returns the product's stock at every active warehouse, each with its
+	// warehouse preloaded.
+	ListStock(ctx context.Context, productID string) ([]*model.WarehouseStock, error)
+	// Reserve atomically increments reserved_quantity at one warehouse if it has qty units
+	// available. Returns ErrInsufficientStock when it doesn't.
+	Reserve(ctx context.Context, warehouseID, productID string, qty int) error
+	// Commit atomically decrements both counters at one warehouse by qty.
+	Commit(ctx context.Context, warehouseID, productID string, qty int) error
+	// Release atomically decrements reserved_quantity at one warehouse by qty. Returns
+	// ErrReservationAlreadyReleased when the counter is already below qty.
+	Release(ctx context.Context, warehouseID, productID string, qty int) error
+	// ReturnCommitted atomically increments stock_quantity at one warehouse by qty.
+	ReturnCommitted(ctx context.Context, warehouseID, productID string, qty int) error
+}
+
+type warehouseRepo struct {
+	db dbs.Database
+}
+
+func NewWarehouseRepository(db dbs.Database) WarehouseRepository {
+	return &warehouseRepo{db: db}
+}
+
+func (r *warehouseRepo) ListStock(ctx context.Context, productID string) ([]*model.WarehouseStock, error) {
+	var stocks []*model.WarehouseStock
+	err := r.db.Find(ctx, &stocks,
+		dbs.WithQuery(
+			dbs.NewQuery("product_id = ?", productID),
+			dbs.NewQuery("warehouse_id IN (SELECT id FROM warehouses WHERE active AND deleted_at IS NULL)"),
+		),
+		dbs.WithPreload([]string{"Warehouse"}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return stocks, nil
+}
+
+func (r *warehouseRepo) Reserve(ctx context.Context, warehouseID, productID string, qty int) error {
+	result := r.db.GetDB().WithContext(ctx).Model(&model.WarehouseStock{}).
+		Where("warehouse_id = ? AND product_id = ? AND stock_quantity - reserved_quantity >= ?", warehouseID, productID, qty).
+		UpdateColumn("reserved_quantity", gorm.Expr("reserved_quantity + ?", qty))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrInsufficientStock
+	}
+	return nil
+}
+
+func (r *warehouseRepo) Commit(ctx context.Context, warehouseID, productID string, qty int) error {
+	result := r.db.GetDB().WithContext(ctx).Model(&model.WarehouseStock{}).
+		Where("warehouse_id = ? AND product_id = ? AND reserved_quantity >= ? AND stock_quantity >= ?", warehouseID, productID, qty, qty).
+		Updates(map[string]any{
+			"stock_quantity":    gorm.Expr("stock_quantity - ?", qty),
+			"reserved_quantity": gorm.Expr("reserved_quantity - ?", qty),
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("commit warehouse reservation: row not in expected state")
+	}
+	return nil
+}
+
+func (r *warehouseRepo) Release(ctx context.Context, warehouseID, productID string, qty int) error {
+	result := r.db.GetDB().WithContext(ctx).Model(&model.WarehouseStock{}).
+		Where("warehouse_id = ? AND product_id = ? AND reserved_quantity >= ?", warehouseID, productID, qty).
+		UpdateColumn("reserved_quantity", gorm.Expr("reserved_quantity - ?", qty))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrReservationAlreadyReleased
+	}
+	return nil
+}
+
+func (r *warehouseRepo) ReturnCommitted(ctx context.Context, warehouseID, productID string, qty int) error {
+	result := r.db.GetDB().WithContext(ctx).Model(&model.WarehouseStock{}).
+		Where("warehouse_id = ? AND product_id = ?", warehouseID, productID).
+		UpdateColumn("stock_quantity", gorm.Expr("stock_quantity + ?", qty))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return errors.New("return committed warehouse stock: row not found")
+	}
+	return nil
+}
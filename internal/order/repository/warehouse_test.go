@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"goshop/internal/order/model"
+	dbsMocks "goshop/pkg/dbs/mocks"
+)
+
+// TestWarehouseStockCounters mirrors TestProductReservation for the per-warehouse counters.
+func TestWarehouseStockCounters(t *testing.T) {
+	const sqlPattern = `UPDATE "warehouse_stocks"`
+
+	reserve := func(repo WarehouseRepository) error {
+		return repo.Reserve(context.Background(), "w1", "p1", 2)
+	}
+	commit := func(repo WarehouseRepository) error {
+		return repo.Commit(context.Background(), "w1", "p1", 1)
+	}
+	release := func(repo WarehouseRepository) error {
+		return repo.Release(context.Background(), "w1", "p1", 1)
+	}
+	returnCommitted := func(repo WarehouseRepository) error {
+		return repo.ReturnCommitted(context.Background(), "w1", "p1", 1)
+	}
+
+	tests := []struct {
+		name         string
+		fn           func(repo WarehouseRepository) error
+		rowsAffected int64
+		dbErr        error
+		wantErrIs    error
+		wantAnyErr   bool
+	}{
+		{name: "reserve_success", fn: reserve, rowsAffected: 1},
+		{name: "reserve_insufficient_stock", fn: reserve, wantErrIs: ErrInsufficientStock},
+		{name: "reserve_db_error", fn: reserve, dbErr: errors.New("boom"), wantAnyErr: true},
+
+		{name: "commit_success", fn: commit, rowsAffected: 1},
+		{name: "commit_no_rows", fn: commit, wantAnyErr: true},
+		{name: "commit_db_error", fn: commit, dbErr: errors.New("boom"), wantAnyErr: true},
+
+		{name: "release_success", fn: release, rowsAffected: 1},
+		{name: "release_no_rows_sentinel", fn: release, wantErrIs: ErrReservationAlreadyReleased},
+		{name: "release_db_error", fn: release, dbErr: errors.New("boom"), wantAnyErr: true},
+
+		{name: "return_success", fn: returnCommitted, rowsAffected: 1},
+		{name: "return_no_rows", fn: returnCommitted, wantAnyErr: true},
+		{name: "return_db_error", fn: returnCommitted, dbErr: errors.New("boom"), wantAnyErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, m := newProductSQLMockDB(t)
+			dbm := dbsMocks.NewDatabase(t)
+			dbm.On("GetDB").Return(g)
+			if tt.dbErr != nil {
+				m.ExpectExec(sqlPattern).WillReturnError(tt.dbErr)
+			} else {
+				m.ExpectExec(sqlPattern).WillReturnResult(sqlmock.NewResult(0, tt.rowsAffected))
+			}
+
+			err := tt.fn(NewWarehouseRepository(dbm))
+			switch {
+			case tt.wantErrIs != nil:
+				require.ErrorIs(t, err, tt.wantErrIs)
+			case tt.wantAnyErr:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+			}
+			require.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestWarehouseListStock(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		dbm := dbsMocks.NewDatabase(t)
+		dbm.On("Find", mock.Anything, mock.AnythingOfType("*[]*model.WarehouseStock"), mock.Anything, mock.Anything).
+			Run(func(args mock.Arguments) {
+				out := args.Get(1).(*[]*model.WarehouseStock)
+				*out = []*model.WarehouseStock{{WarehouseID: "w1", ProductID: "p1", StockQuantity: 3}}
+			}).Return(nil).Once()
+
+		stocks, err := NewWarehouseRepository(dbm).ListStock(context.Background(), "p1")
+		require.NoError(t, err)
+		require.Len(t, stocks, 1)
+		require.Equal(t, "w1", stocks[0].WarehouseID)
+	})
+	t.Run("db_error", func(t *testing.T) {
+		dbm := dbsMocks.NewDatabase(t)
+		dbm.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db")).Once()
+
+		stocks, err := NewWarehouseRepository(dbm).ListStock(context.Background(), "p1")
+		require.Error(t, err)
+		require.Nil(t, stocks)
+	})
+}
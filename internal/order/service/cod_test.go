@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/eventbus"
+	"goshop/pkg/invoice"
+	"goshop/pkg/stock"
+)
+
+func TestPlaceOrder_CashOnDeliveryCommitsStock(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	placeOrderOf(f, 2)
+	f.userRepo.On("GetDefaultAddress", mock.Anything, "u1").Return(nil, nil).Once()
+	f.warehouses.On("ListStock", mock.Anything, "p1").
+		Return([]*model.WarehouseStock{warehouseStock("w1", "VN", "Hanoi", 0, 5)}, nil).Once()
+	f.warehouses.On("Reserve", mock.Anything, "w1", "p1", 2).Return(nil).Once()
+	f.reservRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).
+		Run(func(args mock.Arguments) { args.Get(1).([]*model.StockReservation)[0].ID = "r1" }).Once()
+	f.ledger.On("Record", mock.Anything, mock.MatchedBy(func(m stock.Movement) bool {
+		return m.Kind == stock.MovementReserve && m.ReservedDelta == 2
+	})).Return(nil).Once()
+	f.productRepo.On("CommitReservation", mock.Anything, "p1", 2).Return(nil).Once()
+	f.warehouses.On("Commit", mock.Anything, "w1", "p1", 2).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.MatchedBy(func(m stock.Movement) bool {
+		return m.Kind == stock.MovementCommit && m.StockDelta == -2 && m.ReservedDelta == -2 &&
+			m.Actor == "u1" && m.WarehouseID == "w1"
+	})).Return(nil).Once()
+	f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusCommitted).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCreated")).Return(nil).Once()
+	f.productRepo.On("GetProductByID", mock.Anything, "p1").
+		Return(&model.Product{ID: "p1", StockQuantity: 3, LowStockThreshold: intPtr(5)}, nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.LowStock")).Return(nil).Once()
+
+	order, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID:        "u1",
+		Lines:         []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 2}},
+		PaymentMethod: "cod",
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.OrderStatusNew, order.Status)
+	require.Equal(t, model.PaymentMethodCOD, order.PaymentMethod)
+}
+
+func TestPlaceOrder_OnlineIsDefault(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	placeOrderOf(f, 1)
+	f.userRepo.On("GetDefaultAddress", mock.Anything, "u1").Return(nil, nil).Once()
+	f.warehouses.On("ListStock", mock.Anything, "p1").
+		Return([]*model.WarehouseStock{warehouseStock("w1", "VN", "Hanoi", 0, 5)}, nil).Once()
+	f.warehouses.On("Reserve", mock.Anything, "w1", "p1", 1).Return(nil).Once()
+	f.reservRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCreated")).Return(nil).Once()
+
+	order, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID: "u1",
+		Lines:  []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, model.OrderStatusPendingPayment, order.Status)
+	require.Equal(t, model.PaymentMethodOnline, order.PaymentMethod)
+}
+
+func TestPlaceOrder_RejectsUnknownPaymentMethod(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	_, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID:        "u1",
+		Lines:         []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}},
+		PaymentMethod: "cheque",
+	})
+	require.Error(t, err)
+}
+
+func TestCancelOrder_CashOnDeliveryIsAdminOnly(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(&model.Order{
+		ID: "o1", UserID: "u1", Status: model.OrderStatusNew, PaymentMethod: model.PaymentMethodCOD,
+	}, nil).Once()
+
+	_, err := f.svc.CancelOrder(context.Background(), "o1", "u1")
+	require.ErrorIs(t, err, apperror.ErrInvalidStatus)
+}
+
+func TestUpdateOrderStatus_CashOnDeliveryFulfillment(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusNew, PaymentMethod: model.PaymentMethodCOD}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Twice()
+	f.repo.On("UpdateOrder", mock.Anything, order).Return(nil).Twice()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderInProgress")).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.MatchedBy(func(ev eventbus.Event) bool {
+		_, ok := ev.(eventbus.OrderDone)
+		return ok
+	})).Return(nil).Once()
+	// The cash is collected when the order is done, so that is when it is invoiced.
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderInvoiced")).Return(nil).Once()
+
+	_, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusInProgress)
+	require.NoError(t, err)
+	f.invoices.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	got, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusDone)
+	require.NoError(t, err)
+	require.Equal(t, model.OrderStatusDone, got.Status)
+	f.invoices.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(inv *model.Invoice) bool {
+		return inv.OrderID == "o1" && inv.Kind == invoice.KindInvoice
+	}))
+}
+
+func TestUpdateOrderStatus_CashOnDeliveryInvoiceErrorKeepsStatus(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	f.invoices.ExpectedCalls = nil
+	f.invoices.On("GetInvoice", mock.Anything, "o1").Return(nil, gorm.ErrRecordNotFound)
+	f.invoices.On("NextSequence", mock.Anything, invoice.KindInvoice, mock.Anything).Return(0, errors.New("db")).Once()
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusInProgress, PaymentMethod: model.PaymentMethodCOD}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, order).Return(nil).Once()
+
+	_, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusDone)
+	require.ErrorContains(t, err, "issue invoice")
+}
+
+func TestUpdateOrderStatus_CancelledCashOnDeliveryReturnsStock(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	w1 := "w1"
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusInProgress, PaymentMethod: model.PaymentMethodCOD}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.reservRepo.On("FindCommittedByOrderID", mock.Anything, "o1").Return([]*model.StockReservation{
+		{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 2, WarehouseID: &w1, Status: model.ReservationStatusCommitted},
+	}, nil).Once()
+	f.productRepo.On("ReturnCommitted", mock.Anything, "p1", 2).Return(nil).Once()
+	f.warehouses.On("ReturnCommitted", mock.Anything, "w1", "p1", 2).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, stock.Movement{
+		ProductID: "p1", Kind: stock.MovementReturn, StockDelta: 2, Actor: stock.ActorSystem,
+		Reason: "order cancelled", OrderID: "o1", ReservationID: "r1", WarehouseID: "w1",
+	}).Return(nil).Once()
+	f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusReleased).Return(nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, order).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCancelled")).Return(nil).Once()
+
+	got, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusCancelled)
+	require.NoError(t, err)
+	require.Equal(t, model.OrderStatusCancelled, got.Status)
+}
+
+func TestUpdateOrderStatus_CancelledCashOnDeliveryReturnErrorKeepsOrder(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusNew, PaymentMethod: model.PaymentMethodCOD}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.reservRepo.On("FindCommittedByOrderID", mock.Anything, "o1").Return([]*model.StockReservation{
+		{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 2},
+	}, nil).Once()
+	f.productRepo.On("ReturnCommitted", mock.Anything, "p1", 2).Return(errors.New("db down")).Once()
+
+	_, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusCancelled)
+	require.ErrorContains(t, err, "return reservation r1")
+}
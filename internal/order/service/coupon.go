@@ -10,23 +10,28 @@ import (
 	"goshop/internal/order/model"
 	"goshop/internal/order/repository"
 	"goshop/pkg/apperror"
+	"goshop/pkg/currency"
+	"goshop/pkg/money"
 )
 
 //go:generate mockery --name=CouponService
 type CouponService interface {
 	GetByCode(ctx context.Context, code string) (*model.Coupon, error)
 	Create(ctx context.Context, req *domain.CreateCouponReq) (*model.Coupon, error)
-	Apply(ctx context.Context, code string, totalPrice float64) (discountAmount float64, coupon *model.Coupon, err error)
+	// Apply checks the coupon against an order total and returns its discount in the total's
+	// currency, never more than the total.
+	Apply(ctx context.Context, code string, total money.Money) (discount money.Money, coupon *model.Coupon, err error)
 	IncrUsedCount(ctx context.Context, id string) error
 }
 
 type couponSvc struct {
 	validator validation.Validation
 	repo      repository.CouponRepository
+	rates     *currency.Rates
 }
 
-func NewCouponService(validator validation.Validation, repo repository.CouponRepository) CouponService {
-	return &couponSvc{validator: validator, repo: repo}
+func NewCouponService(validator validation.Validation, repo repository.CouponRepository, rates *currency.Rates) CouponService {
+	return &couponSvc{validator: validator, repo: repo, rates: rates}
 }
 
 func (s *couponSvc) GetByCode(ctx context.Context, code string) (*model.Coupon, error) {
@@ -37,13 +42,18 @@ func (s *couponSvc) Create(ctx context.Context, req *domain.CreateCouponReq) (*m
 	if err := s.validator.ValidateStruct(req); err != nil {
 		return nil, err
 	}
+	if err := s.checkDiscount(req); err != nil {
+		return nil, err
+	}
 	coupon := model.Coupon{
-		Code:           req.Code,
-		DiscountType:   model.DiscountType(req.DiscountType),
-		DiscountValue:  req.DiscountValue,
-		MinOrderAmount: req.MinOrderAmount,
-		MaxUsage:       req.MaxUsage,
-		ExpiresAt:      req.ExpiresAt,
+		Code:            req.Code,
+		DiscountType:    model.DiscountType(req.DiscountType),
+		DiscountPercent: req.DiscountPercent,
+		DiscountAmount:  req.DiscountAmount,
+		MinOrderAmount:  req.MinOrderAmount,
+		Currency:        s.rates.Base(),
+		MaxUsage:        req.MaxUsage,
+		ExpiresAt:       req.ExpiresAt,
 	}
 	if err := s.repo.Create(ctx, &coupon); err != nil {
 		return nil, err
@@ -51,37 +61,78 @@ func (s *couponSvc) Create(ctx context.Context, req *domain.CreateCouponReq) (*m
 	return &coupon, nil
 }
 
-func (s *couponSvc) Apply(ctx context.Context, code string, totalPrice float64) (float64, *model.Coupon, error) {
+// checkDiscount requires the discount field matching the coupon's type, and amounts in the
+// shop's base currency.
+func (s *couponSvc) checkDiscount(req *domain.CreateCouponReq) error {
+	base := s.rates.Base()
+	switch model.DiscountType(req.DiscountType) {
+	case model.DiscountTypePercentage:
+		if req.DiscountPercent <= 0 || req.DiscountPercent > money.Whole || !req.DiscountAmount.IsZero() {
+			return apperror.WrapMessage(apperror.ErrBadRequest, nil, "a percentage coupon needs a discount_percent between 0 and 100")
+		}
+	case model.DiscountTypeFixed:
+		if !req.DiscountAmount.IsPositive() || req.DiscountPercent != 0 {
+			return apperror.WrapMessage(apperror.ErrBadRequest, nil, "a fixed coupon needs a positive discount_amount")
+		}
+		if req.DiscountAmount.Currency() != base {
+			return apperror.WrapMessage(apperror.ErrBadRequest, nil, "discount_amount must be in "+base)
+		}
+	}
+	if req.MinOrderAmount.IsNegative() || (!req.MinOrderAmount.IsZero() && req.MinOrderAmount.Currency() != base) {
+		return apperror.WrapMessage(apperror.ErrBadRequest, nil, "min_order_amount must be a non-negative amount in "+base)
+	}
+	return nil
+}
+
+func (s *couponSvc) Apply(ctx context.Context, code string, total money.Money) (money.Money, *model.Coupon, error) {
+	none := money.Zero(total.Currency())
 	coupon, err := s.repo.GetByCode(ctx, code)
 	if err != nil {
-		return 0, nil, apperror.Wrap(apperror.ErrNotFound, err)
+		return none, nil, apperror.Wrap(apperror.ErrNotFound, err)
 	}
 
 	now := time.Now()
 	if coupon.ExpiresAt != nil && coupon.ExpiresAt.Before(now) {
-		return 0, nil, apperror.ErrCouponExpired
+		return none, nil, apperror.ErrCouponExpired
 	}
 	if coupon.MaxUsage > 0 && coupon.UsedCount >= coupon.MaxUsage {
-		return 0, nil, apperror.ErrCouponMaxUsage
+		return none, nil, apperror.ErrCouponMaxUsage
 	}
-	if totalPrice < coupon.MinOrderAmount {
-		return 0, nil, apperror.ErrCouponMinOrder
+	minOrder, err := s.inCurrencyOf(coupon.MinOrderAmount, total)
+	if err != nil {
+		return none, nil, err
+	}
+	if total.Cmp(minOrder) < 0 {
+		return none, nil, apperror.ErrCouponMinOrder
 	}
 
-	var discount float64
+	discount := none
 	switch coupon.DiscountType {
 	case model.DiscountTypeFixed:
-		discount = coupon.DiscountValue
-		if discount > totalPrice {
-			discount = totalPrice
+		amount, err := s.inCurrencyOf(coupon.DiscountAmount, total)
+		if err != nil {
+			return none, nil, err
 		}
+		discount = amount.Min(total)
 	case model.DiscountTypePercentage:
-		discount = totalPrice * coupon.DiscountValue / 100
+		discount = total.Percent(coupon.DiscountPercent).Min(total)
 	}
 
 	return discount, coupon, nil
 }
 
+// inCurrencyOf converts a coupon amount into the currency of an order total.
+func (s *couponSvc) inCurrencyOf(amount, total money.Money) (money.Money, error) {
+	if amount.IsZero() || amount.Currency() == total.Currency() {
+		return amount.WithCurrency(total.Currency()), nil
+	}
+	converted, err := amount.Convert(s.rates, total.Currency())
+	if err != nil {
+		return money.Money{}, apperror.Wrap(apperror.ErrBadRequest, err)
+	}
+	return converted, nil
+}
+
 func (s *couponSvc) IncrUsedCount(ctx context.Context, id string) error {
 	return s.repo.IncrUsedCount(ctx, id)
 }
@@ -15,6 +15,7 @@ import (
 	"goshop/internal/order/model"
 	orderMocks "goshop/internal/order/repository/mocks"
 	"goshop/pkg/config"
+	"goshop/pkg/money"
 )
 
 type CouponServiceTestSuite struct {
@@ -27,7 +28,7 @@ func (suite *CouponServiceTestSuite) SetupTest() {
 	logger.Initialize(config.ProductionEnv)
 	validator := validation.New()
 	suite.mockRepo = orderMocks.NewCouponRepository(suite.T())
-	suite.service = NewCouponService(validator, suite.mockRepo)
+	suite.service = NewCouponService(validator, suite.mockRepo, testRates)
 }
 
 func TestCouponServiceTestSuite(t *testing.T) {
@@ -84,9 +85,20 @@ func (suite *CouponServiceTestSuite) TestCreate() {
 	}{
 		{
 			name: "Success",
-			req:  &domain.CreateCouponReq{Code: "SAVE10", DiscountType: "fixed", DiscountValue: 10},
+			req:  &domain.CreateCouponReq{Code: "SAVE10", DiscountType: "fixed", DiscountAmount: money.New(1000, "USD")},
 			setup: func() {
-				suite.mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Times(1)
+				suite.mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *model.Coupon) bool {
+					return c.DiscountAmount == money.New(1000, "USD") && c.Currency == "USD"
+				})).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "Percentage",
+			req:  &domain.CreateCouponReq{Code: "SAVE10", DiscountType: "percentage", DiscountPercent: 1250},
+			setup: func() {
+				suite.mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(c *model.Coupon) bool {
+					return c.DiscountPercent == 1250 && c.DiscountAmount.IsZero()
+				})).Return(nil).Times(1)
 			},
 		},
 		{
@@ -95,9 +107,34 @@ func (suite *CouponServiceTestSuite) TestCreate() {
 			setup:   func() {},
 			wantErr: true,
 		},
+		{
+			name:    "Percentage over 100",
+			req:     &domain.CreateCouponReq{Code: "SAVE10", DiscountType: "percentage", DiscountPercent: money.Whole + 1},
+			setup:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "Fixed without an amount",
+			req:     &domain.CreateCouponReq{Code: "SAVE10", DiscountType: "fixed", DiscountPercent: 1000},
+			setup:   func() {},
+			wantErr: true,
+		},
+		{
+			name:    "Fixed amount not in the base currency",
+			req:     &domain.CreateCouponReq{Code: "SAVE10", DiscountType: "fixed", DiscountAmount: money.New(1000, "EUR")},
+			setup:   func() {},
+			wantErr: true,
+		},
+		{
+			name: "Minimum order not in the base currency",
+			req: &domain.CreateCouponReq{Code: "SAVE10", DiscountType: "fixed", DiscountAmount: money.New(1000, "USD"),
+				MinOrderAmount: money.New(5000, "JPY")},
+			setup:   func() {},
+			wantErr: true,
+		},
 		{
 			name: "DB fail",
-			req:  &domain.CreateCouponReq{Code: "SAVE10", DiscountType: "fixed", DiscountValue: 10},
+			req:  &domain.CreateCouponReq{Code: "SAVE10", DiscountType: "fixed", DiscountAmount: money.New(1000, "USD")},
 			setup: func() {
 				suite.mockRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("duplicate")).Times(1)
 			},
@@ -126,45 +163,97 @@ func (suite *CouponServiceTestSuite) TestApply() {
 	tests := []struct {
 		name         string
 		code         string
-		totalPrice   float64
+		total        money.Money
 		setup        func()
 		wantErr      bool
-		wantDiscount float64
+		wantDiscount money.Money
 	}{
 		{
-			name:       "Fixed discount",
-			code:       "SAVE10",
-			totalPrice: 100,
+			name:  "Fixed discount",
+			code:  "SAVE10",
+			total: money.New(10000, "USD"),
 			setup: func() {
 				suite.mockRepo.On("GetByCode", mock.Anything, "SAVE10").
-					Return(&model.Coupon{ID: "c1", Code: "SAVE10", DiscountType: model.DiscountTypeFixed, DiscountValue: 10}, nil).Times(1)
+					Return(&model.Coupon{ID: "c1", Code: "SAVE10", DiscountType: model.DiscountTypeFixed, DiscountAmount: money.New(1000, "USD")}, nil).Times(1)
 			},
-			wantDiscount: 10,
+			wantDiscount: money.New(1000, "USD"),
 		},
 		{
-			name:       "Percentage discount",
-			code:       "SAVE10PCT",
-			totalPrice: 200,
+			name:  "Percentage discount",
+			code:  "SAVE10PCT",
+			total: money.New(20000, "USD"),
 			setup: func() {
 				suite.mockRepo.On("GetByCode", mock.Anything, "SAVE10PCT").
-					Return(&model.Coupon{ID: "c1", Code: "SAVE10PCT", DiscountType: model.DiscountTypePercentage, DiscountValue: 10}, nil).Times(1)
+					Return(&model.Coupon{ID: "c1", Code: "SAVE10PCT", DiscountType: model.DiscountTypePercentage, DiscountPercent: 1000}, nil).Times(1)
 			},
-			wantDiscount: 20,
+			wantDiscount: money.New(2000, "USD"),
 		},
 		{
-			name:       "Fixed discount exceeds total",
-			code:       "SAVE100",
-			totalPrice: 50,
+			name:  "Fixed discount exceeds total",
+			code:  "SAVE100",
+			total: money.New(5000, "USD"),
 			setup: func() {
 				suite.mockRepo.On("GetByCode", mock.Anything, "SAVE100").
-					Return(&model.Coupon{ID: "c1", Code: "SAVE100", DiscountType: model.DiscountTypeFixed, DiscountValue: 200}, nil).Times(1)
+					Return(&model.Coupon{ID: "c1", Code: "SAVE100", DiscountType: model.DiscountTypeFixed, DiscountAmount: money.New(20000, "USD")}, nil).Times(1)
 			},
-			wantDiscount: 50,
+			wantDiscount: money.New(5000, "USD"),
+		},
+		{
+			name:  "Percentage rounds to the cent",
+			code:  "SAVE12_5",
+			total: money.New(1099, "USD"),
+			setup: func() {
+				suite.mockRepo.On("GetByCode", mock.Anything, "SAVE12_5").
+					Return(&model.Coupon{ID: "c1", Code: "SAVE12_5", DiscountType: model.DiscountTypePercentage, DiscountPercent: 1250}, nil).Times(1)
+			},
+			wantDiscount: money.New(137, "USD"), // 12.5% of 10.99 = 1.37375
+		},
+		{
+			name:  "Fixed discount converted to the order currency",
+			code:  "SAVE10",
+			total: money.New(10000, "EUR"),
+			setup: func() {
+				suite.mockRepo.On("GetByCode", mock.Anything, "SAVE10").
+					Return(&model.Coupon{ID: "c1", Code: "SAVE10", DiscountType: model.DiscountTypeFixed, DiscountAmount: money.New(1000, "USD")}, nil).Times(1)
+			},
+			wantDiscount: money.New(920, "EUR"),
+		},
+		{
+			// 5.00 USD is 757.5 yen, rounded to 758.
+			name:  "Fixed discount rounded in a currency without minor units",
+			code:  "SAVE5",
+			total: money.New(3028, "JPY"),
+			setup: func() {
+				suite.mockRepo.On("GetByCode", mock.Anything, "SAVE5").
+					Return(&model.Coupon{ID: "c1", Code: "SAVE5", DiscountType: model.DiscountTypeFixed, DiscountAmount: money.New(500, "USD")}, nil).Times(1)
+			},
+			wantDiscount: money.New(758, "JPY"),
+		},
+		{
+			// 15% of 3028 yen is 454.2.
+			name:  "Percentage of a yen total",
+			code:  "SAVE15PCT",
+			total: money.New(3028, "JPY"),
+			setup: func() {
+				suite.mockRepo.On("GetByCode", mock.Anything, "SAVE15PCT").
+					Return(&model.Coupon{ID: "c1", Code: "SAVE15PCT", DiscountType: model.DiscountTypePercentage, DiscountPercent: 1500}, nil).Times(1)
+			},
+			wantDiscount: money.New(454, "JPY"),
+		},
+		{
+			name:  "Min order amount converted to the order currency",
+			code:  "MIN50",
+			total: money.New(7000, "JPY"), // about 46.20 USD
+			setup: func() {
+				suite.mockRepo.On("GetByCode", mock.Anything, "MIN50").
+					Return(&model.Coupon{ID: "c1", Code: "MIN50", DiscountType: model.DiscountTypeFixed, DiscountAmount: money.New(1000, "USD"), MinOrderAmount: money.New(5000, "USD")}, nil).Times(1)
+			},
+			wantErr: true,
 		},
 		{
-			name:       "Not found",
-			code:       "INVALID",
-			totalPrice: 100,
+			name:  "Not found",
+			code:  "INVALID",
+			total: money.New(10000, "USD"),
 			setup: func() {
 				suite.mockRepo.On("GetByCode", mock.Anything, "INVALID").
 					Return(nil, errors.New("not found")).Times(1)
@@ -172,9 +261,9 @@ func (suite *CouponServiceTestSuite) TestApply() {
 			wantErr: true,
 		},
 		{
-			name:       "Expired",
-			code:       "EXPIRED",
-			totalPrice: 100,
+			name:  "Expired",
+			code:  "EXPIRED",
+			total: money.New(10000, "USD"),
 			setup: func() {
 				suite.mockRepo.On("GetByCode", mock.Anything, "EXPIRED").
 					Return(&model.Coupon{ID: "c1", Code: "EXPIRED", ExpiresAt: &past}, nil).Times(1)
@@ -182,9 +271,9 @@ func (suite *CouponServiceTestSuite) TestApply() {
 			wantErr: true,
 		},
 		{
-			name:       "Max usage reached",
-			code:       "MAXED",
-			totalPrice: 100,
+			name:  "Max usage reached",
+			code:  "MAXED",
+			total: money.New(10000, "USD"),
 			setup: func() {
 				suite.mockRepo.On("GetByCode", mock.Anything, "MAXED").
 					Return(&model.Coupon{ID: "c1", Code: "MAXED", MaxUsage: 5, UsedCount: 5}, nil).Times(1)
@@ -192,12 +281,12 @@ func (suite *CouponServiceTestSuite) TestApply() {
 			wantErr: true,
 		},
 		{
-			name:       "Below min order amount",
-			code:       "MIN50",
-			totalPrice: 30,
+			name:  "Below min order amount",
+			code:  "MIN50",
+			total: money.New(3000, "USD"),
 			setup: func() {
 				suite.mockRepo.On("GetByCode", mock.Anything, "MIN50").
-					Return(&model.Coupon{ID: "c1", Code: "MIN50", DiscountType: model.DiscountTypeFixed, DiscountValue: 10, MinOrderAmount: 50}, nil).Times(1)
+					Return(&model.Coupon{ID: "c1", Code: "MIN50", DiscountType: model.DiscountTypeFixed, DiscountAmount: money.New(1000, "USD"), MinOrderAmount: money.New(5000, "USD")}, nil).Times(1)
 			},
 			wantErr: true,
 		},
@@ -206,11 +295,11 @@ func (suite *CouponServiceTestSuite) TestApply() {
 		suite.Run(tc.name, func() {
 			suite.SetupTest()
 			tc.setup()
-			discount, coupon, err := suite.service.Apply(context.Background(), tc.code, tc.totalPrice)
+			discount, coupon, err := suite.service.Apply(context.Background(), tc.code, tc.total)
 			if tc.wantErr {
 				suite.NotNil(err)
 				suite.Nil(coupon)
-				suite.Equal(float64(0), discount)
+				suite.Equal(money.Zero(tc.total.Currency()), discount)
 			} else {
 				suite.Nil(err)
 				suite.NotNil(coupon)
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/money"
+)
+
+// placeOrderIn wires a successful PlaceOrder of qty units of p1 priced at price in the base
+// currency, and captures the lines and discount handed to CreateOrder.
+func placeOrderIn(f *markPaidFixture, price money.Money, qty int, couponCode string) (*[]*model.OrderLine, *money.Money) {
+	var lines []*model.OrderLine
+	var discount money.Money
+	f.productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{ID: "p1", Price: price}, nil).Once()
+	f.repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, couponCode, mock.Anything).
+		Run(func(args mock.Arguments) {
+			lines = args.Get(2).([]*model.OrderLine)
+			discount = args.Get(4).(money.Money)
+		}).
+		Return(&model.Order{ID: "o1", UserID: "u1", Lines: []*model.OrderLine{{ProductID: "p1", Quantity: uint(qty)}}}, nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	f.productRepo.On("ReserveStock", mock.Anything, "p1", qty).Return(nil).Once()
+	f.userRepo.On("GetDefaultAddress", mock.Anything, "u1").Return(nil, nil).Once()
+	f.warehouses.On("ListStock", mock.Anything, "p1").
+		Return([]*model.WarehouseStock{warehouseStock("w1", "VN", "Hanoi", 0, 100)}, nil).Once()
+	f.warehouses.On("Reserve", mock.Anything, "w1", "p1", qty).Return(nil).Once()
+	f.reservRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCreated")).Return(nil).Once()
+	return &lines, &discount
+}
+
+func TestPlaceOrder_PricesInOrderCurrency(t *testing.T) {
+	tests := []struct {
+		name         string
+		currency     string
+		price        money.Money
+		qty          int
+		wantCurrency string
+		wantPrice    money.Money
+	}{
+		{name: "base", currency: "", price: money.New(29, "USD"), qty: 3, wantCurrency: "USD", wantPrice: money.New(87, "USD")},
+		{name: "euro", currency: "eur", price: money.New(1999, "USD"), qty: 3, wantCurrency: "EUR", wantPrice: money.New(5517, "EUR")},                    // 18.39 each
+		{name: "yen_has_no_minor_unit", currency: "JPY", price: money.New(1999, "USD"), qty: 2, wantCurrency: "JPY", wantPrice: money.New(6056, "JPY")},   // 3028 each
+		{name: "dinar_has_three_places", currency: "KWD", price: money.New(1999, "USD"), qty: 2, wantCurrency: "KWD", wantPrice: money.New(12294, "KWD")}, // 6.147 each
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newMarkPaidFixture(t)
+			lines, _ := placeOrderIn(f, tt.price, tt.qty, "")
+
+			order, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+				UserID:   "u1",
+				Lines:    []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: uint(tt.qty)}},
+				Currency: tt.currency,
+			})
+			require.NoError(t, err)
+			require.NotNil(t, order)
+			require.Len(t, *lines, 1)
+			require.Equal(t, tt.wantCurrency, (*lines)[0].Currency)
+			require.Equal(t, tt.wantPrice, (*lines)[0].Price)
+		})
+	}
+}
+
+func TestPlaceOrder_AppliesCouponToConvertedTotal(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	coupon := &model.Coupon{ID: "c1", Code: "C", DiscountType: model.DiscountTypeFixed, DiscountAmount: money.New(500, "USD")}
+	f.coupons.On("Apply", mock.Anything, "C", money.New(3028, "JPY")).Return(money.New(758, "JPY"), coupon, nil).Once()
+	f.coupons.On("IncrUsedCount", mock.Anything, "c1").Return(nil).Once()
+	_, discount := placeOrderIn(f, money.New(1999, "USD"), 1, "C")
+
+	_, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID:     "u1",
+		Lines:      []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}},
+		CouponCode: "C",
+		Currency:   "JPY",
+	})
+	require.NoError(t, err)
+	require.Equal(t, money.New(758, "JPY"), *discount)
+}
+
+func TestPlaceOrder_RejectsUnsupportedCurrency(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	_, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID:   "u1",
+		Lines:    []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}},
+		Currency: "GBP",
+	})
+	var appErr *apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperror.ErrBadRequest.Code, appErr.Code)
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"testing"
-	"time"
 
 	"github.com/quangdangfit/gocommon/logger"
 	"github.com/quangdangfit/gocommon/validation"
@@ -17,10 +16,16 @@ import (
 	serviceMocks "goshop/internal/order/service/mocks"
 	"goshop/pkg/config"
 	dbsMocks "goshop/pkg/dbs/mocks"
-	notifMocks "goshop/pkg/notification/mocks"
+	"goshop/pkg/eventbus"
+	"goshop/pkg/money"
+	"goshop/pkg/stock"
 )
 
-func newEdgeFixture(t *testing.T) (OrderService, *orderMocks.OrderRepository, *orderMocks.ProductRepository, *orderMocks.UserRepository, *orderMocks.ReservationRepository, *notifMocks.Notifier) {
+func newEdgeFixture(t *testing.T) (OrderService, *orderMocks.OrderRepository, *orderMocks.ProductRepository, *orderMocks.UserRepository, *orderMocks.ReservationRepository, *serviceMocks.EventOutbox) {
+	// Payments settle cleanly unless a test checks settlement itself.
+	payments := serviceMocks.NewPaymentSettler(t)
+	payments.On("CaptureOrderPayment", mock.Anything, mock.Anything).Return(nil).Maybe()
+	payments.On("VoidOrderPayment", mock.Anything, mock.Anything).Return(nil).Maybe()
 	logger.Initialize(config.ProductionEnv)
 	db := dbsMocks.NewDatabase(t)
 	repo := orderMocks.NewOrderRepository(t)
@@ -28,16 +33,25 @@ func newEdgeFixture(t *testing.T) (OrderService, *orderMocks.OrderRepository, *o
 	userRepo := orderMocks.NewUserRepository(t)
 	reservRepo := orderMocks.NewReservationRepository(t)
 	couponSvc := serviceMocks.NewCouponService(t)
-	notifier := notifMocks.NewNotifier(t)
+	outbox := serviceMocks.NewEventOutbox(t)
+	ledger := serviceMocks.NewStockLedger(t)
+	warehouseRepo := orderMocks.NewWarehouseRepository(t)
 	db.On("WithTransaction", mock.Anything).Return(func(fn func() error) error { return fn() }).Maybe()
-	svc := NewOrderService(validation.New(), db, repo, productRepo, userRepo, reservRepo, couponSvc, notifier)
-	return svc, repo, productRepo, userRepo, reservRepo, notifier
+	userRepo.On("GetUserByID", mock.Anything, mock.Anything).Return(&model.User{ID: "u1", Email: "x@example.com"}, nil).Maybe()
+	userRepo.On("GetDefaultAddress", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
+	ledger.On("Record", mock.Anything, mock.Anything).Return(nil).Maybe()
+	warehouseRepo.On("ListStock", mock.Anything, mock.Anything).
+		Return([]*model.WarehouseStock{{WarehouseID: "w1", StockQuantity: 10}}, nil).Maybe()
+	warehouseRepo.On("Reserve", mock.Anything, "w1", mock.Anything, mock.Anything).Return(nil).Maybe()
+	svc := NewOrderService(validation.New(), db, repo, productRepo, userRepo, reservRepo, couponSvc, outbox, ledger,
+		warehouseRepo, stock.AllocateNearest, testRates, newTestShipping(t, nil), newTestTax(t, nil), testInvoices(t), payments)
+	return svc, repo, productRepo, userRepo, reservRepo, outbox
 }
 
 func TestPlaceOrder_ReserveStockReturnsInsufficient(t *testing.T) {
 	svc, repo, productRepo, _, _, _ := newEdgeFixture(t)
-	productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{Name: "p", Price: 1}, nil).Once()
-	repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, "", float64(0)).
+	productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{Name: "p", Price: money.New(100, "USD")}, nil).Once()
+	repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, "", money.Zero("USD")).
 		Return(&model.Order{ID: "o1", UserID: "u1", Lines: []*model.OrderLine{{ProductID: "p1", Quantity: 2}}}, nil).Once()
 	repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
 	productRepo.On("ReserveStock", mock.Anything, "p1", 2).
@@ -52,8 +66,8 @@ func TestPlaceOrder_ReserveStockReturnsInsufficient(t *testing.T) {
 
 func TestPlaceOrder_CreateOrderError(t *testing.T) {
 	svc, repo, productRepo, _, _, _ := newEdgeFixture(t)
-	productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{Name: "p", Price: 1}, nil).Once()
-	repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, "", float64(0)).Return(nil, errors.New("db")).Once()
+	productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{Name: "p", Price: money.New(100, "USD")}, nil).Once()
+	repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, "", money.Zero("USD")).Return(nil, errors.New("db")).Once()
 
 	_, err := svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
 		UserID: "u1",
@@ -64,8 +78,8 @@ func TestPlaceOrder_CreateOrderError(t *testing.T) {
 
 func TestPlaceOrder_CreateManyError(t *testing.T) {
 	svc, repo, productRepo, _, reservRepo, _ := newEdgeFixture(t)
-	productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{Name: "p", Price: 1}, nil).Once()
-	repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, "", float64(0)).
+	productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{Name: "p", Price: money.New(100, "USD")}, nil).Once()
+	repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, "", money.Zero("USD")).
 		Return(&model.Order{ID: "o1", UserID: "u1", Lines: []*model.OrderLine{{ProductID: "p1", Quantity: 1}}}, nil).Once()
 	repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
 	productRepo.On("ReserveStock", mock.Anything, "p1", 1).Return(nil).Once()
@@ -78,35 +92,58 @@ func TestPlaceOrder_CreateManyError(t *testing.T) {
 	require.Error(t, err)
 }
 
-func TestPlaceOrder_NotifyHappy(t *testing.T) {
-	svc, repo, productRepo, userRepo, reservRepo, notifier := newEdgeFixture(t)
-	productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{Name: "p", Price: 1}, nil).Once()
-	repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, "", float64(0)).
+func TestPlaceOrder_RecordsOrderCreated(t *testing.T) {
+	svc, repo, productRepo, _, reservRepo, outbox := newEdgeFixture(t)
+	productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{Name: "p", Price: money.New(100, "USD")}, nil).Once()
+	repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, "", money.Zero("USD")).
 		Return(&model.Order{ID: "o1", UserID: "u1", Lines: []*model.OrderLine{{ProductID: "p1", Quantity: 1}}}, nil).Once()
 	repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
 	productRepo.On("ReserveStock", mock.Anything, "p1", 1).Return(nil).Once()
 	reservRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Once()
-	userRepo.On("GetUserByID", mock.Anything, "u1").Return(&model.User{ID: "u1", Email: "x@example.com"}, nil).Maybe()
-	notifier.On("SendOrderPlaced", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("smtp")).Maybe()
+	outbox.On("Add", mock.Anything, eventbus.OrderCreated{OrderPayload: eventbus.OrderPayload{
+		OrderID:     "o1",
+		UserID:      "u1",
+		UserEmail:   "x@example.com",
+		Status:      string(model.OrderStatusPendingPayment),
+		ShippingFee: money.Zero("USD"),
+		TaxAmount:   money.Zero("USD"),
+		Lines:       []eventbus.OrderLine{{ProductID: "p1", Quantity: 1}},
+	}}).Return(nil).Once()
 
 	_, err := svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
 		UserID: "u1",
 		Lines:  []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}},
 	})
 	require.NoError(t, err)
-	time.Sleep(20 * time.Millisecond)
+}
+
+func TestPlaceOrder_OutboxErrorRollsBack(t *testing.T) {
+	svc, repo, productRepo, _, reservRepo, outbox := newEdgeFixture(t)
+	productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{Name: "p", Price: money.New(100, "USD")}, nil).Once()
+	repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, "", money.Zero("USD")).
+		Return(&model.Order{ID: "o1", UserID: "u1", Lines: []*model.OrderLine{{ProductID: "p1", Quantity: 1}}}, nil).Once()
+	repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	productRepo.On("ReserveStock", mock.Anything, "p1", 1).Return(nil).Once()
+	reservRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Once()
+	outbox.On("Add", mock.Anything, mock.Anything).Return(errors.New("db")).Once()
+
+	_, err := svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID: "u1",
+		Lines:  []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}},
+	})
+	require.Error(t, err)
 }
 
 func TestUpdateOrderStatus_GetOrderError(t *testing.T) {
 	svc, repo, _, _, _, _ := newEdgeFixture(t)
-	repo.On("GetOrderByID", mock.Anything, "o1", false).Return(nil, errors.New("not found")).Once()
+	repo.On("GetOrderByID", mock.Anything, "o1", true).Return(nil, errors.New("not found")).Once()
 	_, err := svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusPaid)
 	require.Error(t, err)
 }
 
 func TestUpdateOrderStatus_InvalidStatus(t *testing.T) {
 	svc, repo, _, _, _, _ := newEdgeFixture(t)
-	repo.On("GetOrderByID", mock.Anything, "o1", false).
+	repo.On("GetOrderByID", mock.Anything, "o1", true).
 		Return(&model.Order{ID: "o1", Status: model.OrderStatusNew}, nil).Once()
 	_, err := svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatus("bogus"))
 	require.Error(t, err)
@@ -114,7 +151,7 @@ func TestUpdateOrderStatus_InvalidStatus(t *testing.T) {
 
 func TestUpdateOrderStatus_DisallowedTransition(t *testing.T) {
 	svc, repo, _, _, _, _ := newEdgeFixture(t)
-	repo.On("GetOrderByID", mock.Anything, "o1", false).
+	repo.On("GetOrderByID", mock.Anything, "o1", true).
 		Return(&model.Order{ID: "o1", Status: model.OrderStatusDone}, nil).Once()
 	_, err := svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusCancelled)
 	require.Error(t, err)
@@ -122,23 +159,33 @@ func TestUpdateOrderStatus_DisallowedTransition(t *testing.T) {
 
 func TestUpdateOrderStatus_UpdateError(t *testing.T) {
 	svc, repo, _, _, _, _ := newEdgeFixture(t)
-	repo.On("GetOrderByID", mock.Anything, "o1", false).
+	repo.On("GetOrderByID", mock.Anything, "o1", true).
 		Return(&model.Order{ID: "o1", Status: model.OrderStatusPaid}, nil).Once()
 	repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(errors.New("db")).Once()
 	_, err := svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusInProgress)
 	require.Error(t, err)
 }
 
+func TestUpdateOrderStatus_RepeatedStatusRecordsNoEvent(t *testing.T) {
+	svc, repo, _, _, _, _ := newEdgeFixture(t)
+	repo.On("GetOrderByID", mock.Anything, "o1", true).
+		Return(&model.Order{ID: "o1", Status: model.OrderStatusInProgress}, nil).Once()
+	repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	// No outbox expectation: the mock fails the test if an event is recorded.
+	_, err := svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusInProgress)
+	require.NoError(t, err)
+}
+
 func TestCancelOrder_GetError(t *testing.T) {
 	svc, repo, _, _, _, _ := newEdgeFixture(t)
-	repo.On("GetOrderByID", mock.Anything, "o1", false).Return(nil, errors.New("not found")).Once()
+	repo.On("GetOrderByID", mock.Anything, "o1", true).Return(nil, errors.New("not found")).Once()
 	_, err := svc.CancelOrder(context.Background(), "o1", "u1")
 	require.Error(t, err)
 }
 
 func TestCancelOrder_Forbidden(t *testing.T) {
 	svc, repo, _, _, _, _ := newEdgeFixture(t)
-	repo.On("GetOrderByID", mock.Anything, "o1", false).
+	repo.On("GetOrderByID", mock.Anything, "o1", true).
 		Return(&model.Order{ID: "o1", UserID: "owner"}, nil).Once()
 	_, err := svc.CancelOrder(context.Background(), "o1", "intruder")
 	require.Error(t, err)
@@ -147,7 +194,7 @@ func TestCancelOrder_Forbidden(t *testing.T) {
 func TestCancelOrder_TerminalStatus(t *testing.T) {
 	for _, st := range []model.OrderStatus{model.OrderStatusDone, model.OrderStatusCancelled, model.OrderStatusPaid} {
 		svc, repo, _, _, _, _ := newEdgeFixture(t)
-		repo.On("GetOrderByID", mock.Anything, "o1", false).
+		repo.On("GetOrderByID", mock.Anything, "o1", true).
 			Return(&model.Order{ID: "o1", UserID: "u1", Status: st}, nil).Once()
 		_, err := svc.CancelOrder(context.Background(), "o1", "u1")
 		require.Error(t, err)
@@ -155,22 +202,46 @@ func TestCancelOrder_TerminalStatus(t *testing.T) {
 }
 
 func TestCancelOrder_HappyPath(t *testing.T) {
-	svc, repo, productRepo, _, reservRepo, _ := newEdgeFixture(t)
-	repo.On("GetOrderByID", mock.Anything, "o1", false).
-		Return(&model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}, nil).Once()
+	svc, repo, productRepo, _, reservRepo, outbox := newEdgeFixture(t)
+	repo.On("GetOrderByID", mock.Anything, "o1", true).
+		Return(&model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment, FinalPrice: money.New(900, "USD")}, nil).Once()
 	reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").
 		Return([]*model.StockReservation{{ID: "r1", ProductID: "p1", Quantity: 1}}, nil).Once()
 	productRepo.On("ReleaseReservation", mock.Anything, "p1", 1).Return(nil).Once()
 	reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusReleased).Return(nil).Once()
 	repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	outbox.On("Add", mock.Anything, eventbus.OrderCancelled{
+		OrderPayload: eventbus.OrderPayload{
+			OrderID:    "o1",
+			UserID:     "u1",
+			UserEmail:  "x@example.com",
+			Status:     string(model.OrderStatusCancelled),
+			FinalPrice: money.New(900, "USD"),
+			Lines:      []eventbus.OrderLine{},
+		},
+		Reason: CancelReasonCustomer,
+	}).Return(nil).Once()
 
 	_, err := svc.CancelOrder(context.Background(), "o1", "u1")
 	require.NoError(t, err)
 }
 
+func TestCancelOrder_OutboxErrorRollsBack(t *testing.T) {
+	svc, repo, _, _, reservRepo, outbox := newEdgeFixture(t)
+	repo.On("GetOrderByID", mock.Anything, "o1", true).
+		Return(&model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusNew}, nil).Once()
+	reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(nil, nil).Once()
+	reservRepo.On("UpdateStatus", mock.Anything, []string{}, model.ReservationStatusReleased).Return(nil).Once()
+	repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCancelled")).Return(errors.New("db")).Once()
+
+	_, err := svc.CancelOrder(context.Background(), "o1", "u1")
+	require.Error(t, err)
+}
+
 func TestCancelOrder_ReleaseError(t *testing.T) {
 	svc, repo, productRepo, _, reservRepo, _ := newEdgeFixture(t)
-	repo.On("GetOrderByID", mock.Anything, "o1", false).
+	repo.On("GetOrderByID", mock.Anything, "o1", true).
 		Return(&model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}, nil).Once()
 	reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").
 		Return([]*model.StockReservation{{ID: "r1", ProductID: "p1", Quantity: 1}}, nil).Once()
@@ -182,7 +253,7 @@ func TestCancelOrder_ReleaseError(t *testing.T) {
 
 func TestCancelOrder_FindReservationsError(t *testing.T) {
 	svc, repo, _, _, reservRepo, _ := newEdgeFixture(t)
-	repo.On("GetOrderByID", mock.Anything, "o1", false).
+	repo.On("GetOrderByID", mock.Anything, "o1", true).
 		Return(&model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}, nil).Once()
 	reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(nil, errors.New("db")).Once()
 
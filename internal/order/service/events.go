@@ -0,0 +1,65 @@
+package service
+
+import (
+	"goshop/internal/order/model"
+	"goshop/pkg/eventbus"
+)
+
+// Reasons carried on OrderCancelled.
+const (
+	// CancelReasonCustomer: the buyer cancelled through CancelOrder.
+	CancelReasonCustomer = "customer_request"
+	// CancelReasonStatusUpdate: moved to cancelled through UpdateOrderStatus, by an admin or a
+	// payment provider reporting the payment as cancelled.
+	CancelReasonStatusUpdate = "status_update"
+	// CancelReasonReservationExpired: the sweeper released the stock of an order left unpaid
+	// past ReservationTTL.
+	CancelReasonReservationExpired = "reservation_expired"
+)
+
+// orderPayload snapshots order for an event. Lines are included when they're loaded.
+func orderPayload(order *model.Order, userEmail string) eventbus.OrderPayload {
+	lines := make([]eventbus.OrderLine, 0, len(order.Lines))
+	for _, l := range order.Lines {
+		lines = append(lines, eventbus.OrderLine{
+			ProductID: l.ProductID,
+			Quantity:  l.Quantity,
+			Price:     l.Price,
+		})
+	}
+	return eventbus.OrderPayload{
+		OrderID:        order.ID,
+		Code:           order.Code,
+		UserID:         order.UserID,
+		UserEmail:      userEmail,
+		Status:         string(order.Status),
+		TotalPrice:     order.TotalPrice,
+		DiscountAmount: order.DiscountAmount,
+		ShippingFee:    order.ShippingFee,
+		TaxAmount:      order.TaxAmount,
+		FinalPrice:     order.FinalPrice,
+		Currency:       order.Currency,
+		CouponCode:     order.CouponCode,
+		Lines:          lines,
+	}
+}
+
+// statusEvent builds the event for order having just moved to its current status. cancelReason
+// is only used when that status is cancelled.
+func statusEvent(order *model.Order, userEmail, cancelReason string) eventbus.Event {
+	payload := orderPayload(order, userEmail)
+	switch order.Status {
+	case model.OrderStatusPaid:
+		return eventbus.OrderPaid{OrderPayload: payload}
+	case model.OrderStatusPaymentFailed:
+		return eventbus.OrderPaymentFailed{OrderPayload: payload}
+	case model.OrderStatusInProgress:
+		return eventbus.OrderInProgress{OrderPayload: payload}
+	case model.OrderStatusDone:
+		return eventbus.OrderDone{OrderPayload: payload}
+	case model.OrderStatusCancelled:
+		return eventbus.OrderCancelled{OrderPayload: payload, Reason: cancelReason}
+	default:
+		return eventbus.OrderStatusChanged{OrderPayload: payload}
+	}
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"goshop/internal/order/model"
+	"goshop/pkg/eventbus"
+	"goshop/pkg/money"
+)
+
+func TestOrderPayload(t *testing.T) {
+	order := &model.Order{
+		ID: "o1", Code: "C1", UserID: "u1", Status: model.OrderStatusPaid,
+		Currency: "USD", TotalPrice: money.New(3000, "USD"), DiscountAmount: money.New(500, "USD"),
+		ShippingFee: money.New(499, "USD"), TaxAmount: money.New(200, "USD"), FinalPrice: money.New(3199, "USD"), CouponCode: "SAVE5",
+		Lines: []*model.OrderLine{
+			{ProductID: "p1", Quantity: 1, Price: money.New(1000, "USD")},
+			{ProductID: "p2", Quantity: 2, Price: money.New(2000, "USD")},
+		},
+	}
+	require.Equal(t, eventbus.OrderPayload{
+		OrderID: "o1", Code: "C1", UserID: "u1", UserEmail: "u1@example.com", Status: "paid",
+		TotalPrice: money.New(3000, "USD"), DiscountAmount: money.New(500, "USD"), ShippingFee: money.New(499, "USD"),
+		TaxAmount: money.New(200, "USD"), FinalPrice: money.New(3199, "USD"), Currency: "USD", CouponCode: "SAVE5",
+		Lines: []eventbus.OrderLine{
+			{ProductID: "p1", Quantity: 1, Price: money.New(1000, "USD")},
+			{ProductID: "p2", Quantity: 2, Price: money.New(2000, "USD")},
+		},
+	}, orderPayload(order, "u1@example.com"))
+}
+
+func TestStatusEvent(t *testing.T) {
+	tests := []struct {
+		status model.OrderStatus
+		topic  string
+	}{
+		{model.OrderStatusPaid, eventbus.TopicOrderPaid},
+		{model.OrderStatusPaymentFailed, eventbus.TopicOrderPaymentFailed},
+		{model.OrderStatusInProgress, eventbus.TopicOrderInProgress},
+		{model.OrderStatusDone, eventbus.TopicOrderDone},
+		{model.OrderStatusCancelled, eventbus.TopicOrderCancelled},
+		{model.OrderStatusPendingPayment, eventbus.TopicOrderStatusChanged},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			ev := statusEvent(&model.Order{ID: "o1", Status: tt.status}, "", CancelReasonStatusUpdate)
+			require.Equal(t, tt.topic, ev.Topic())
+			require.Equal(t, string(tt.status), ev.(eventbus.OrderEvent).Payload().Status)
+		})
+	}
+
+	ev := statusEvent(&model.Order{ID: "o1", Status: model.OrderStatusCancelled}, "", CancelReasonStatusUpdate)
+	require.Equal(t, CancelReasonStatusUpdate, ev.(eventbus.OrderCancelled).Reason)
+}
@@ -0,0 +1,272 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	orderRepo "goshop/internal/order/repository"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/dbs"
+	"goshop/pkg/invoice"
+	"goshop/pkg/money"
+	"goshop/pkg/tax"
+)
+
+// InvoiceService issues an invoice for each paid order and a credit note for each refund of
+// one, numbering each kind in its own gap-free yearly series.
+//
+//go:generate mockery --name=InvoiceService
+type InvoiceService interface {
+	// IssueInvoice numbers and stores the invoice of a paid order, billed to its billing
+	// address and buyerEmail. Call it in the transaction that marks the order paid, so the
+	// number is only taken if the order is. Idempotent per order. The order's lines and their
+	// products must be loaded.
+	IssueInvoice(ctx context.Context, order *model.Order, buyerEmail string) (*model.Invoice, error)
+	// IssueCreditNote numbers and stores a credit note against the order's invoice for a refund
+	// that went through. Idempotent per refund. Returns nil for an order that was never
+	// invoiced.
+	IssueCreditNote(ctx context.Context, req domain.CreditNoteReq) (*model.Invoice, error)
+	// GetInvoice returns the order's invoice. A non-empty userID must be the buyer's.
+	GetInvoice(ctx context.Context, orderID, userID string) (*model.Invoice, error)
+	// GetCreditNote returns one of the order's credit notes by number. A non-empty userID must
+	// be the buyer's.
+	GetCreditNote(ctx context.Context, orderID, number, userID string) (*model.Invoice, error)
+	// InvoicePDF renders the order's invoice as a PDF, with the name to attach it under.
+	InvoicePDF(ctx context.Context, orderID string) (string, []byte, error)
+}
+
+type invoiceService struct {
+	repo   orderRepo.InvoiceRepository
+	seller invoice.Party
+	now    func() time.Time
+}
+
+// NewInvoiceService issues documents from seller, the shop as invoices name it.
+func NewInvoiceService(repo orderRepo.InvoiceRepository, seller invoice.Party) InvoiceService {
+	return &invoiceService{repo: repo, seller: seller, now: time.Now}
+}
+
+// NewInvoices builds the InvoiceService that issues invoices and credit notes in the name of
+// the configured seller.
+func NewInvoices(cfg *config.Schema, db dbs.Database) InvoiceService {
+	return NewInvoiceService(orderRepo.NewInvoiceRepository(db), invoice.Party{
+		Name:    cfg.InvoiceSellerName,
+		Address: invoice.AddressLines(cfg.InvoiceSellerAddress),
+		TaxID:   cfg.InvoiceSellerTaxID,
+	})
+}
+
+func (s *invoiceService) IssueInvoice(ctx context.Context, order *model.Order, buyerEmail string) (*model.Invoice, error) {
+	existing, err := s.repo.GetInvoice(ctx, order.ID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	billTo := order.BillingAddress
+	if billTo.IsZero() {
+		billTo = order.ShippingAddress
+	}
+	doc := invoice.Document{
+		Kind:      invoice.KindInvoice,
+		OrderCode: order.Code,
+		Seller:    s.seller,
+		Buyer:     invoice.Party{Name: billTo.Name, Address: addressLines(billTo), Email: buyerEmail},
+		Currency:  order.Currency,
+		TaxMode:   order.TaxMode,
+		Lines:     make([]invoice.Line, len(order.Lines)),
+		Subtotal:  order.TotalPrice,
+		Discount:  order.DiscountAmount,
+		Shipping:  order.ShippingFee,
+		Tax:       order.TaxAmount,
+		Total:     order.FinalPrice,
+	}
+	if doc.Buyer.Name == "" {
+		doc.Buyer.Name = buyerEmail
+	}
+	for i, line := range order.Lines {
+		description := line.ProductID
+		if line.Product != nil && line.Product.Name != "" {
+			description = line.Product.Name
+		}
+		doc.Lines[i] = invoice.Line{
+			ItemID:      line.ID,
+			Description: description,
+			Quantity:    line.Quantity,
+			UnitPrice:   line.Price.Prorate(1, int64(line.Quantity)),
+			TaxRate:     line.TaxRate,
+			TaxAmount:   line.TaxAmount,
+			Amount:      line.Price,
+		}
+	}
+	return s.issue(ctx, &model.Invoice{OrderID: order.ID, UserID: order.UserID}, doc)
+}
+
+func (s *invoiceService) IssueCreditNote(ctx context.Context, req domain.CreditNoteReq) (*model.Invoice, error) {
+	existing, err := s.repo.GetByRefundID(ctx, req.RefundID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	inv, err := s.repo.GetInvoice(ctx, req.OrderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	billed := inv.Document
+	doc := invoice.Document{
+		Kind:      invoice.KindCreditNote,
+		OrderCode: billed.OrderCode,
+		Reference: inv.Number,
+		Seller:    s.seller,
+		Buyer:     billed.Buyer,
+		Currency:  inv.Currency,
+		TaxMode:   billed.TaxMode,
+		Subtotal:  money.Zero(inv.Currency),
+		Tax:       money.Zero(inv.Currency),
+		Total:     money.New(req.Amount, inv.Currency),
+		Note:      req.Reason,
+	}
+	if len(req.Lines) == 0 {
+		// The rest of the payment: its tax is the invoice's, in proportion.
+		lineTax := money.Zero(inv.Currency)
+		if billed.Total.IsPositive() {
+			lineTax = billed.Tax.Prorate(req.Amount, billed.Total.Amount())
+		}
+		doc.Lines = append(doc.Lines, creditLine(invoice.Line{Description: "Refund", Quantity: 1}, 1, doc.Total, lineTax, doc.TaxMode))
+	}
+	for _, l := range req.Lines {
+		billedLine, ok := findLine(billed.Lines, l.OrderLineID)
+		if !ok {
+			return nil, fmt.Errorf("order line %s is not on invoice %s", l.OrderLineID, inv.Number)
+		}
+		lineTax := billedLine.TaxAmount.Prorate(int64(l.Quantity), int64(billedLine.Quantity))
+		doc.Lines = append(doc.Lines, creditLine(billedLine, l.Quantity, money.New(l.Amount, inv.Currency), lineTax, doc.TaxMode))
+	}
+	for _, l := range doc.Lines {
+		doc.Subtotal = doc.Subtotal.Add(l.Amount)
+		doc.Tax = doc.Tax.Add(l.TaxAmount)
+	}
+	refundID := req.RefundID
+	return s.issue(ctx, &model.Invoice{OrderID: inv.OrderID, UserID: inv.UserID, RefundID: &refundID}, doc)
+}
+
+// creditLine credits qty units of billed that were refunded for refunded, tax included. Like
+// an invoice line, its amount leaves the tax out when prices exclude it.
+func creditLine(billed invoice.Line, qty uint, refunded, lineTax money.Money, mode tax.Mode) invoice.Line {
+	amount := refunded
+	if !mode.Inclusive() {
+		amount = refunded.Sub(lineTax)
+	}
+	unit := billed.UnitPrice
+	if billed.ItemID == "" {
+		unit = amount
+	}
+	return invoice.Line{
+		ItemID:      billed.ItemID,
+		Description: billed.Description,
+		Quantity:    qty,
+		UnitPrice:   unit,
+		TaxRate:     billed.TaxRate,
+		TaxAmount:   lineTax,
+		Amount:      amount,
+	}
+}
+
+func findLine(lines []invoice.Line, itemID string) (invoice.Line, bool) {
+	for _, l := range lines {
+		if l.ItemID == itemID {
+			return l, true
+		}
+	}
+	return invoice.Line{}, false
+}
+
+// issue numbers doc as the next of its kind this year and stores it on row.
+func (s *invoiceService) issue(ctx context.Context, row *model.Invoice, doc invoice.Document) (*model.Invoice, error) {
+	issuedAt := s.now().UTC()
+	seq, err := s.repo.NextSequence(ctx, doc.Kind, issuedAt.Year())
+	if err != nil {
+		return nil, fmt.Errorf("number %s: %w", doc.Kind, err)
+	}
+	doc.Number = invoice.Number(doc.Kind, issuedAt.Year(), seq)
+	doc.IssuedAt = issuedAt
+
+	row.Kind = doc.Kind
+	row.Number = doc.Number
+	row.Year = issuedAt.Year()
+	row.Sequence = seq
+	row.Currency = doc.Currency
+	row.Total = doc.Total
+	row.Document = doc
+	if err := s.repo.Create(ctx, row); err != nil {
+		return nil, err
+	}
+	return row, nil
+}
+
+func (s *invoiceService) GetInvoice(ctx context.Context, orderID, userID string) (*model.Invoice, error) {
+	inv, err := s.repo.GetInvoice(ctx, orderID)
+	return ownDocument(inv, err, userID, "invoice not found")
+}
+
+func (s *invoiceService) GetCreditNote(ctx context.Context, orderID, number, userID string) (*model.Invoice, error) {
+	inv, err := s.repo.GetCreditNote(ctx, orderID, number)
+	return ownDocument(inv, err, userID, "credit note not found")
+}
+
+// ownDocument hides a document from anyone but its buyer when userID is set.
+func ownDocument(inv *model.Invoice, err error, userID, notFound string) (*model.Invoice, error) {
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && userID != "" && inv.UserID != userID) {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, err, notFound)
+	}
+	return inv, err
+}
+
+func (s *invoiceService) InvoicePDF(ctx context.Context, orderID string) (string, []byte, error) {
+	inv, err := s.GetInvoice(ctx, orderID, "")
+	if err != nil {
+		return "", nil, err
+	}
+	pdf, err := invoice.PDF(&inv.Document)
+	if err != nil {
+		return "", nil, err
+	}
+	return inv.Document.Filename("pdf"), pdf, nil
+}
+
+// addressLines lays an address out for a document: street, city and region, then country.
+func addressLines(a model.OrderAddress) []string {
+	var lines []string
+	for _, l := range []string{a.Street, strings.Join(nonEmpty(a.City, a.Region), ", "), a.Country} {
+		if l != "" {
+			lines = append(lines, l)
+		}
+	}
+	return lines
+}
+
+func nonEmpty(values ...string) []string {
+	out := values[:0:0]
+	for _, v := range values {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
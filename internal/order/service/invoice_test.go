@@ -0,0 +1,212 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	orderMocks "goshop/internal/order/repository/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/invoice"
+	"goshop/pkg/money"
+	"goshop/pkg/tax"
+)
+
+func newInvoiceSvc(t *testing.T) (*invoiceService, *orderMocks.InvoiceRepository) {
+	repo := orderMocks.NewInvoiceRepository(t)
+	svc := NewInvoiceService(repo, invoice.Party{Name: "GoShop", Address: []string{"1 Market Street"}}).(*invoiceService)
+	svc.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+	return svc, repo
+}
+
+// invoicedOrder is a paid order of two taxed mugs, tax excluded from prices.
+func invoicedOrder() *model.Order {
+	return &model.Order{
+		ID: "o1", Code: "SO1", UserID: "u1", Currency: "USD", TaxMode: tax.Exclusive,
+		TotalPrice: money.New(2000, "USD"), FinalPrice: money.New(2160, "USD"), TaxAmount: money.New(160, "USD"),
+		ShippingAddress: model.OrderAddress{Name: "Ann Buyer", Street: "1 Main St", City: "Springfield", Region: "IL", Country: "US"},
+		Lines: []*model.OrderLine{{
+			ID: "l1", ProductID: "p1", Product: &model.Product{Name: "Mug"}, Quantity: 2,
+			Price: money.New(2000, "USD"), TaxRate: 800, TaxAmount: money.New(160, "USD"),
+		}},
+	}
+}
+
+func TestInvoiceService_IssueInvoice(t *testing.T) {
+	svc, repo := newInvoiceSvc(t)
+	repo.On("GetInvoice", mock.Anything, "o1").Return(nil, gorm.ErrRecordNotFound).Once()
+	repo.On("NextSequence", mock.Anything, invoice.KindInvoice, 2026).Return(42, nil).Once()
+	repo.On("Create", mock.Anything, mock.AnythingOfType("*model.Invoice")).Return(nil).Once()
+
+	inv, err := svc.IssueInvoice(context.Background(), invoicedOrder(), "ann@example.com")
+	require.NoError(t, err)
+	require.Equal(t, "INV-2026-000042", inv.Number)
+	require.Equal(t, 2026, inv.Year)
+	require.Equal(t, 42, inv.Sequence)
+	require.Equal(t, "u1", inv.UserID)
+	require.Equal(t, money.New(2160, "USD"), inv.Total)
+
+	doc := inv.Document
+	require.Equal(t, inv.Number, doc.Number)
+	require.Equal(t, "GoShop", doc.Seller.Name)
+	// No billing address: the invoice is billed to where the order shipped.
+	require.Equal(t, invoice.Party{
+		Name: "Ann Buyer", Address: []string{"1 Main St", "Springfield, IL", "US"}, Email: "ann@example.com",
+	}, doc.Buyer)
+	require.Equal(t, []invoice.Line{{
+		ItemID: "l1", Description: "Mug", Quantity: 2, UnitPrice: money.New(1000, "USD"),
+		TaxRate: 800, TaxAmount: money.New(160, "USD"), Amount: money.New(2000, "USD"),
+	}}, doc.Lines)
+	require.Equal(t, money.New(160, "USD"), doc.Tax)
+}
+
+func TestInvoiceService_IssueInvoice_Idempotent(t *testing.T) {
+	svc, repo := newInvoiceSvc(t)
+	existing := &model.Invoice{ID: "i1", Number: "INV-2026-000001"}
+	repo.On("GetInvoice", mock.Anything, "o1").Return(existing, nil).Once()
+
+	inv, err := svc.IssueInvoice(context.Background(), invoicedOrder(), "ann@example.com")
+	require.NoError(t, err)
+	require.Same(t, existing, inv)
+}
+
+// issuedInvoice is invoicedOrder's invoice as stored.
+func issuedInvoice(t *testing.T) *model.Invoice {
+	svc, repo := newInvoiceSvc(t)
+	repo.On("GetInvoice", mock.Anything, "o1").Return(nil, gorm.ErrRecordNotFound).Once()
+	repo.On("NextSequence", mock.Anything, invoice.KindInvoice, 2026).Return(7, nil).Once()
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	inv, err := svc.IssueInvoice(context.Background(), invoicedOrder(), "ann@example.com")
+	require.NoError(t, err)
+	return inv
+}
+
+func TestInvoiceService_IssueCreditNote_Lines(t *testing.T) {
+	billed := issuedInvoice(t)
+	svc, repo := newInvoiceSvc(t)
+	repo.On("GetByRefundID", mock.Anything, "rf1").Return(nil, gorm.ErrRecordNotFound).Once()
+	repo.On("GetInvoice", mock.Anything, "o1").Return(billed, nil).Once()
+	repo.On("NextSequence", mock.Anything, invoice.KindCreditNote, 2026).Return(3, nil).Once()
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+	// One of the two mugs, refunded with its share of the tax.
+	note, err := svc.IssueCreditNote(context.Background(), domain.CreditNoteReq{
+		OrderID: "o1", RefundID: "rf1", Amount: 1080, Reason: "damaged",
+		Lines: []domain.CreditNoteLine{{OrderLineID: "l1", Quantity: 1, Amount: 1080}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "CN-2026-000003", note.Number)
+	require.Equal(t, "rf1", *note.RefundID)
+	doc := note.Document
+	require.Equal(t, invoice.KindCreditNote, doc.Kind)
+	require.Equal(t, "INV-2026-000007", doc.Reference)
+	require.Equal(t, billed.Document.Buyer, doc.Buyer)
+	require.Equal(t, "damaged", doc.Note)
+	require.Equal(t, []invoice.Line{{
+		ItemID: "l1", Description: "Mug", Quantity: 1, UnitPrice: money.New(1000, "USD"),
+		TaxRate: 800, TaxAmount: money.New(80, "USD"), Amount: money.New(1000, "USD"),
+	}}, doc.Lines)
+	require.Equal(t, money.New(1000, "USD"), doc.Subtotal)
+	require.Equal(t, money.New(80, "USD"), doc.Tax)
+	require.Equal(t, money.New(1080, "USD"), doc.Total)
+}
+
+func TestInvoiceService_IssueCreditNote_WholePayment(t *testing.T) {
+	billed := issuedInvoice(t)
+	billed.Document.TaxMode = tax.Inclusive
+	svc, repo := newInvoiceSvc(t)
+	repo.On("GetByRefundID", mock.Anything, "rf1").Return(nil, gorm.ErrRecordNotFound).Once()
+	repo.On("GetInvoice", mock.Anything, "o1").Return(billed, nil).Once()
+	repo.On("NextSequence", mock.Anything, invoice.KindCreditNote, 2026).Return(1, nil).Once()
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+
+	// Half of the payment back: half of the invoice's tax with it, already in the amount.
+	note, err := svc.IssueCreditNote(context.Background(), domain.CreditNoteReq{OrderID: "o1", RefundID: "rf1", Amount: 1080})
+	require.NoError(t, err)
+	require.Len(t, note.Document.Lines, 1)
+	line := note.Document.Lines[0]
+	require.Equal(t, "Refund", line.Description)
+	require.Equal(t, money.New(80, "USD"), line.TaxAmount)
+	require.Equal(t, money.New(1080, "USD"), line.Amount)
+	require.Equal(t, money.New(1080, "USD"), note.Total)
+}
+
+func TestInvoiceService_IssueCreditNote_Idempotent(t *testing.T) {
+	svc, repo := newInvoiceSvc(t)
+	existing := &model.Invoice{ID: "cn1"}
+	repo.On("GetByRefundID", mock.Anything, "rf1").Return(existing, nil).Once()
+
+	note, err := svc.IssueCreditNote(context.Background(), domain.CreditNoteReq{OrderID: "o1", RefundID: "rf1", Amount: 100})
+	require.NoError(t, err)
+	require.Same(t, existing, note)
+}
+
+func TestInvoiceService_IssueCreditNote_NotInvoiced(t *testing.T) {
+	svc, repo := newInvoiceSvc(t)
+	repo.On("GetByRefundID", mock.Anything, "rf1").Return(nil, gorm.ErrRecordNotFound).Once()
+	repo.On("GetInvoice", mock.Anything, "o1").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	note, err := svc.IssueCreditNote(context.Background(), domain.CreditNoteReq{OrderID: "o1", RefundID: "rf1", Amount: 100})
+	require.NoError(t, err)
+	require.Nil(t, note)
+}
+
+func TestInvoiceService_IssueCreditNote_UnknownLine(t *testing.T) {
+	billed := issuedInvoice(t)
+	svc, repo := newInvoiceSvc(t)
+	repo.On("GetByRefundID", mock.Anything, "rf1").Return(nil, gorm.ErrRecordNotFound).Once()
+	repo.On("GetInvoice", mock.Anything, "o1").Return(billed, nil).Once()
+
+	_, err := svc.IssueCreditNote(context.Background(), domain.CreditNoteReq{
+		OrderID: "o1", RefundID: "rf1", Amount: 100,
+		Lines: []domain.CreditNoteLine{{OrderLineID: "nope", Quantity: 1, Amount: 100}},
+	})
+	require.ErrorContains(t, err, "order line nope is not on invoice INV-2026-000007")
+}
+
+func TestInvoiceService_GetInvoice(t *testing.T) {
+	svc, repo := newInvoiceSvc(t)
+	inv := &model.Invoice{ID: "i1", UserID: "u1"}
+	repo.On("GetInvoice", mock.Anything, "o1").Return(inv, nil)
+	repo.On("GetInvoice", mock.Anything, "o2").Return(nil, gorm.ErrRecordNotFound)
+
+	got, err := svc.GetInvoice(context.Background(), "o1", "u1")
+	require.NoError(t, err)
+	require.Same(t, inv, got)
+	got, err = svc.GetInvoice(context.Background(), "o1", "")
+	require.NoError(t, err)
+	require.Same(t, inv, got)
+
+	// Another buyer's invoice is as missing as one never issued.
+	_, err = svc.GetInvoice(context.Background(), "o1", "u2")
+	requireAppError(t, err, apperror.ErrNotFound)
+	_, err = svc.GetInvoice(context.Background(), "o2", "u1")
+	requireAppError(t, err, apperror.ErrNotFound)
+}
+
+func TestInvoiceService_GetCreditNote(t *testing.T) {
+	svc, repo := newInvoiceSvc(t)
+	repo.On("GetCreditNote", mock.Anything, "o1", "CN-2026-000001").Return(&model.Invoice{UserID: "u1"}, nil)
+
+	_, err := svc.GetCreditNote(context.Background(), "o1", "CN-2026-000001", "u1")
+	require.NoError(t, err)
+	_, err = svc.GetCreditNote(context.Background(), "o1", "CN-2026-000001", "u2")
+	requireAppError(t, err, apperror.ErrNotFound)
+}
+
+func TestInvoiceService_InvoicePDF(t *testing.T) {
+	svc, repo := newInvoiceSvc(t)
+	repo.On("GetInvoice", mock.Anything, "o1").Return(issuedInvoice(t), nil).Once()
+
+	name, pdf, err := svc.InvoicePDF(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Equal(t, "INV-2026-000007.pdf", name)
+	require.True(t, bytes.HasPrefix(pdf, []byte("%PDF-")))
+}
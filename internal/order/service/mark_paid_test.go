@@ -3,33 +3,99 @@ package service
 import (
 	"context"
 	"errors"
-	"sync"
 	"testing"
-	"time"
 
 	"github.com/quangdangfit/gocommon/logger"
 	"github.com/quangdangfit/gocommon/validation"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
 
 	"goshop/internal/order/model"
 	orderMocks "goshop/internal/order/repository/mocks"
 	serviceMocks "goshop/internal/order/service/mocks"
 	"goshop/pkg/config"
+	"goshop/pkg/currency"
 	dbsMocks "goshop/pkg/dbs/mocks"
 	"goshop/pkg/eventbus"
-	notifMocks "goshop/pkg/notification/mocks"
+	"goshop/pkg/invoice"
+	"goshop/pkg/money"
+	"goshop/pkg/shipping"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
 )
 
+// testRates is the exchange-rate table the order service tests price orders with.
+var testRates = currency.MustParseRates("USD", "EUR:0.92,JPY:151.5,KWD:0.3075")
+
+// testMethods are the shipping methods the order service tests offer while no shipping rates
+// are configured; the default one is free so totals stay those of the lines.
+var testMethods = shipping.MustParseMethods("USD", "standard:0,express:9.99")
+
+// newTestShipping prices shipping with the rates configured returns, falling back to
+// testMethods when there are none. configured may be nil.
+func newTestShipping(t *testing.T, configured func() []*model.ShippingRate) ShippingService {
+	t.Helper()
+	repo := orderMocks.NewShippingRateRepository(t)
+	repo.On("List", mock.Anything).Return(func(context.Context) []*model.ShippingRate {
+		if configured == nil {
+			return nil
+		}
+		return configured()
+	}, nil).Maybe()
+	return NewShippingService(validation.New(), repo, testRates, testMethods)
+}
+
+// newTestTax taxes orders with the rates configured returns, exclusive of prices. configured
+// may be nil.
+func newTestTax(t *testing.T, configured func() []*model.TaxRate) TaxService {
+	t.Helper()
+	repo := orderMocks.NewTaxRateRepository(t)
+	repo.On("List", mock.Anything).Return(func(context.Context) []*model.TaxRate {
+		if configured == nil {
+			return nil
+		}
+		return configured()
+	}, nil).Maybe()
+	return NewTaxService(validation.New(), repo, tax.Exclusive)
+}
+
+// newTestInvoices issues invoices through a repository that has none yet and numbers each
+// series from 1.
+func newTestInvoices(t *testing.T) (InvoiceService, *orderMocks.InvoiceRepository) {
+	t.Helper()
+	repo := orderMocks.NewInvoiceRepository(t)
+	repo.On("GetInvoice", mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound).Maybe()
+	repo.On("GetByRefundID", mock.Anything, mock.Anything).Return(nil, gorm.ErrRecordNotFound).Maybe()
+	repo.On("NextSequence", mock.Anything, mock.Anything, mock.Anything).Return(1, nil).Maybe()
+	repo.On("Create", mock.Anything, mock.Anything).Return(nil).Maybe()
+	return NewInvoiceService(repo, invoice.Party{Name: "GoShop"}), repo
+}
+
+// testInvoices is newTestInvoices for tests that don't look at the invoices issued.
+func testInvoices(t *testing.T) InvoiceService {
+	t.Helper()
+	svc, _ := newTestInvoices(t)
+	return svc
+}
+
 type markPaidFixture struct {
-	svc          *orderService
-	db           *dbsMocks.Database
-	repo         *orderMocks.OrderRepository
-	productRepo  *orderMocks.ProductRepository
-	reservRepo   *orderMocks.ReservationRepository
-	bus          eventbus.Bus
-	captured     []eventbus.LowStock
-	capturedLock sync.Mutex
+	svc         OrderService
+	db          *dbsMocks.Database
+	repo        *orderMocks.OrderRepository
+	productRepo *orderMocks.ProductRepository
+	userRepo    *orderMocks.UserRepository
+	reservRepo  *orderMocks.ReservationRepository
+	outbox      *serviceMocks.EventOutbox
+	ledger      *serviceMocks.StockLedger
+	warehouses  *orderMocks.WarehouseRepository
+	coupons     *serviceMocks.CouponService
+	payments    *serviceMocks.PaymentSettler
+	invoices    *orderMocks.InvoiceRepository
+	// shippingRates are the configured shipping rates; testMethods are offered while it is empty.
+	shippingRates []*model.ShippingRate
+	// taxRates are the configured tax rates; orders are untaxed while it is empty.
+	taxRates []*model.TaxRate
 }
 
 func newMarkPaidFixture(t *testing.T) *markPaidFixture {
@@ -41,41 +107,28 @@ func newMarkPaidFixture(t *testing.T) *markPaidFixture {
 	reservRepo := orderMocks.NewReservationRepository(t)
 	userRepo := orderMocks.NewUserRepository(t)
 	couponSvc := serviceMocks.NewCouponService(t)
-	notifier := notifMocks.NewNotifier(t)
+	outbox := serviceMocks.NewEventOutbox(t)
+	ledger := serviceMocks.NewStockLedger(t)
+	warehouseRepo := orderMocks.NewWarehouseRepository(t)
+	payments := serviceMocks.NewPaymentSettler(t)
 	db.On("WithTransaction", mock.Anything).Return(func(fn func() error) error { return fn() }).Maybe()
 
-	svc := NewOrderService(validation.New(), db, repo, productRepo, userRepo, reservRepo, couponSvc, notifier).(*orderService)
-	bus := eventbus.New()
-	svc.SetEventBus(bus)
-
 	f := &markPaidFixture{
-		svc: svc, db: db, repo: repo, productRepo: productRepo, reservRepo: reservRepo, bus: bus,
+		db: db, repo: repo, productRepo: productRepo, userRepo: userRepo, reservRepo: reservRepo, outbox: outbox,
+		ledger: ledger, warehouses: warehouseRepo, coupons: couponSvc, payments: payments,
 	}
-	bus.Subscribe(eventbus.TopicLowStock, func(_ context.Context, ev eventbus.Event) {
-		f.capturedLock.Lock()
-		defer f.capturedLock.Unlock()
-		f.captured = append(f.captured, ev.(eventbus.LowStock))
-	})
+	shippingSvc := newTestShipping(t, func() []*model.ShippingRate { return f.shippingRates })
+	taxSvc := newTestTax(t, func() []*model.TaxRate { return f.taxRates })
+	var invoiceSvc InvoiceService
+	invoiceSvc, f.invoices = newTestInvoices(t)
+	f.svc = NewOrderService(validation.New(), db, repo, productRepo, userRepo, reservRepo, couponSvc, outbox, ledger,
+		warehouseRepo, stock.AllocateNearest, testRates, shippingSvc, taxSvc, invoiceSvc, payments)
+	userRepo.On("GetUserByID", mock.Anything, "u1").Return(&model.User{ID: "u1", Email: "u1@example.com"}, nil).Maybe()
 	return f
 }
 
-func (f *markPaidFixture) waitForEvents(t *testing.T, n int) {
-	t.Helper()
-	deadline := time.Now().Add(time.Second)
-	for time.Now().Before(deadline) {
-		f.capturedLock.Lock()
-		got := len(f.captured)
-		f.capturedLock.Unlock()
-		if got >= n {
-			return
-		}
-		time.Sleep(5 * time.Millisecond)
-	}
-	t.Fatalf("expected %d LowStock events, got %d", n, len(f.captured))
-}
-
-// TestMarkOrderPaid_LowStockEvent table-drives the post-commit LowStock-event
-// behavior: the happy commit path is identical across cases; only the final
+// TestMarkOrderPaid_LowStockEvent table-drives the LowStock-event behavior recorded in
+// the commit transaction: the happy commit path is identical across cases; only the final
 // GetProductByID outcome (stock value or error) varies.
 func TestMarkOrderPaid_LowStockEvent(t *testing.T) {
 	tests := []struct {
@@ -84,10 +137,12 @@ func TestMarkOrderPaid_LowStockEvent(t *testing.T) {
 		productErr       error
 		wantEvents       int
 		wantAvailable    int // only checked when wantEvents > 0
+		wantThreshold    int // defaults to LowStockThreshold
+		wantReorder      int
 		wantOrderSuccess bool
 	}{
 		{
-			name:             "publishes_event_when_below_threshold",
+			name:             "records_event_when_below_threshold",
 			productResult:    &model.Product{ID: "p1", StockQuantity: 4, ReservedQuantity: 1},
 			wantEvents:       1,
 			wantAvailable:    3,
@@ -99,6 +154,46 @@ func TestMarkOrderPaid_LowStockEvent(t *testing.T) {
 			wantEvents:       0,
 			wantOrderSuccess: true,
 		},
+		{
+			name: "product_threshold_overrides_default",
+			productResult: &model.Product{
+				ID: "p1", Name: "Mug", StockQuantity: 9, ReservedQuantity: 1, LowStockThreshold: intPtr(10),
+			},
+			wantEvents:       1,
+			wantAvailable:    8,
+			wantThreshold:    10,
+			wantOrderSuccess: true,
+		},
+		{
+			name:             "product_threshold_below_default",
+			productResult:    &model.Product{ID: "p1", StockQuantity: 4, ReservedQuantity: 1, LowStockThreshold: intPtr(2)},
+			wantEvents:       0,
+			wantOrderSuccess: true,
+		},
+		{
+			name: "category_threshold_and_reorder_apply",
+			productResult: &model.Product{
+				ID: "p1", StockQuantity: 8, ReservedQuantity: 0,
+				Category: &model.Category{ID: "c1", LowStockThreshold: intPtr(8), ReorderQuantity: intPtr(50)},
+			},
+			wantEvents:       1,
+			wantAvailable:    8,
+			wantThreshold:    8,
+			wantReorder:      50,
+			wantOrderSuccess: true,
+		},
+		{
+			name: "product_settings_override_category",
+			productResult: &model.Product{
+				ID: "p1", StockQuantity: 8, ReservedQuantity: 0, ReorderQuantity: intPtr(20),
+				Category: &model.Category{ID: "c1", LowStockThreshold: intPtr(8), ReorderQuantity: intPtr(50)},
+			},
+			wantEvents:       1,
+			wantAvailable:    8,
+			wantThreshold:    8,
+			wantReorder:      20,
+			wantOrderSuccess: true,
+		},
 		{
 			name:             "lookup_error_is_non_fatal",
 			productErr:       errors.New("db down"),
@@ -110,15 +205,39 @@ func TestMarkOrderPaid_LowStockEvent(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			f := newMarkPaidFixture(t)
-			order := &model.Order{ID: "o1", Status: model.OrderStatusPendingPayment}
+			order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}
 			reservations := []*model.StockReservation{{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1}}
 
 			f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
 			f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(reservations, nil).Once()
 			f.productRepo.On("CommitReservation", mock.Anything, "p1", 1).Return(nil).Once()
+			f.ledger.On("Record", mock.Anything, stock.Movement{
+				ProductID:     "p1",
+				Kind:          stock.MovementCommit,
+				StockDelta:    -1,
+				ReservedDelta: -1,
+				Actor:         stock.ActorSystem,
+				Reason:        "payment cleared",
+				OrderID:       "o1",
+				ReservationID: "r1",
+			}).Return(nil).Once()
 			f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusCommitted).Return(nil).Once()
 			f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+			f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderPaid")).Return(nil).Once()
 			f.productRepo.On("GetProductByID", mock.Anything, "p1").Return(tt.productResult, tt.productErr).Once()
+			if tt.wantEvents > 0 {
+				threshold := tt.wantThreshold
+				if threshold == 0 {
+					threshold = LowStockThreshold
+				}
+				f.outbox.On("Add", mock.Anything, eventbus.LowStock{
+					ProductID:       "p1",
+					ProductName:     tt.productResult.Name,
+					Available:       tt.wantAvailable,
+					Threshold:       threshold,
+					ReorderQuantity: tt.wantReorder,
+				}).Return(nil).Times(tt.wantEvents)
+			}
 
 			got, err := f.svc.MarkOrderPaid(context.Background(), "o1")
 			if tt.wantOrderSuccess {
@@ -127,30 +246,81 @@ func TestMarkOrderPaid_LowStockEvent(t *testing.T) {
 			} else {
 				require.Error(t, err)
 			}
-
-			if tt.wantEvents > 0 {
-				f.waitForEvents(t, tt.wantEvents)
-				require.Equal(t, "p1", f.captured[0].ProductID)
-				require.Equal(t, tt.wantAvailable, f.captured[0].Available)
-				require.Equal(t, LowStockThreshold, f.captured[0].Threshold)
-			} else {
-				time.Sleep(50 * time.Millisecond)
-				f.capturedLock.Lock()
-				defer f.capturedLock.Unlock()
-				require.Empty(t, f.captured)
-			}
 		})
 	}
 }
 
 func TestMarkOrderPaid_IdempotentOnAlreadyPaid(t *testing.T) {
+	// A captured payment's webhook arrives after fulfillment has started; the order was
+	// authorized without an invoice, so it is invoiced now.
+	for _, status := range []model.OrderStatus{model.OrderStatusPaid, model.OrderStatusInProgress, model.OrderStatusDone} {
+		t.Run(string(status), func(t *testing.T) {
+			f := newMarkPaidFixture(t)
+			order := &model.Order{ID: "o1", UserID: "u1", Status: status}
+			f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+			// OrderPaid went out without the invoice, so the buyer is sent it now.
+			f.outbox.On("Add", mock.Anything, mock.MatchedBy(func(ev eventbus.OrderInvoiced) bool {
+				return ev.OrderID == "o1" && ev.UserEmail == "u1@example.com"
+			})).Return(nil).Once()
+
+			got, err := f.svc.MarkOrderPaid(context.Background(), "o1")
+			require.NoError(t, err)
+			require.Equal(t, status, got.Status)
+			f.invoices.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(inv *model.Invoice) bool {
+				return inv.OrderID == "o1" && inv.Kind == invoice.KindInvoice
+			}))
+		})
+	}
+}
+
+func TestMarkOrderPaid_AlreadyInvoiced(t *testing.T) {
 	f := newMarkPaidFixture(t)
-	order := &model.Order{ID: "o1", Status: model.OrderStatusPaid}
+	f.invoices.ExpectedCalls = nil
+	f.invoices.On("GetInvoice", mock.Anything, "o1").Return(&model.Invoice{ID: "i1", OrderID: "o1"}, nil).Once()
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusInProgress}
 	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
 
-	got, err := f.svc.MarkOrderPaid(context.Background(), "o1")
+	_, err := f.svc.MarkOrderPaid(context.Background(), "o1")
+	require.NoError(t, err)
+	f.invoices.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestMarkOrderPaid_LateInvoiceError(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	f.invoices.ExpectedCalls = nil
+	f.invoices.On("GetInvoice", mock.Anything, "o1").Return(nil, gorm.ErrRecordNotFound)
+	f.invoices.On("NextSequence", mock.Anything, invoice.KindInvoice, mock.Anything).Return(0, errors.New("db")).Once()
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPaid}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+
+	_, err := f.svc.MarkOrderPaid(context.Background(), "o1")
+	require.ErrorContains(t, err, "issue invoice")
+}
+
+func TestMarkOrderAuthorized_CommitsWithoutInvoice(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(nil, nil).Once()
+	f.reservRepo.On("UpdateStatus", mock.Anything, []string{}, model.ReservationStatusCommitted).Return(nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderPaid")).Return(nil).Once()
+
+	got, err := f.svc.MarkOrderAuthorized(context.Background(), "o1")
 	require.NoError(t, err)
 	require.Equal(t, model.OrderStatusPaid, got.Status)
+	f.invoices.AssertNotCalled(t, "NextSequence", mock.Anything, mock.Anything, mock.Anything)
+	f.invoices.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestMarkOrderAuthorized_IdempotentOnAlreadyPaid(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPaid}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+
+	_, err := f.svc.MarkOrderAuthorized(context.Background(), "o1")
+	require.NoError(t, err)
+	f.invoices.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
 }
 
 func TestMarkOrderPaid_RejectsCancelledOrFailed(t *testing.T) {
@@ -183,7 +353,7 @@ func TestMarkOrderPaid_GetOrderError(t *testing.T) {
 
 func TestMarkOrderPaid_CommitReservationFails(t *testing.T) {
 	f := newMarkPaidFixture(t)
-	order := &model.Order{ID: "o1", Status: model.OrderStatusPendingPayment}
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}
 	reservations := []*model.StockReservation{{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1}}
 
 	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
@@ -194,52 +364,140 @@ func TestMarkOrderPaid_CommitReservationFails(t *testing.T) {
 	require.Error(t, err)
 }
 
-func TestMarkOrderPaid_FallsBackToDefaultBus(t *testing.T) {
-	logger.Initialize(config.ProductionEnv)
-	defaultBus := eventbus.New()
-	eventbus.SetDefault(defaultBus)
-	t.Cleanup(func() { eventbus.SetDefault(eventbus.New()) })
-
-	var captured []eventbus.LowStock
-	var mu sync.Mutex
-	defaultBus.Subscribe(eventbus.TopicLowStock, func(_ context.Context, ev eventbus.Event) {
-		mu.Lock()
-		defer mu.Unlock()
-		captured = append(captured, ev.(eventbus.LowStock))
-	})
+func TestMarkOrderPaid_RecordsOrderPaid(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{
+		ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment, TotalPrice: money.New(2000, "USD"), FinalPrice: money.New(2000, "USD"),
+		Lines: []*model.OrderLine{{ProductID: "p1", Quantity: 2, Price: money.New(2000, "USD")}},
+	}
 
-	db := dbsMocks.NewDatabase(t)
-	repo := orderMocks.NewOrderRepository(t)
-	productRepo := orderMocks.NewProductRepository(t)
-	reservRepo := orderMocks.NewReservationRepository(t)
-	userRepo := orderMocks.NewUserRepository(t)
-	couponSvc := serviceMocks.NewCouponService(t)
-	notifier := notifMocks.NewNotifier(t)
-	db.On("WithTransaction", mock.Anything).Return(func(fn func() error) error { return fn() }).Maybe()
-	svc := NewOrderService(validation.New(), db, repo, productRepo, userRepo, reservRepo, couponSvc, notifier)
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(nil, nil).Once()
+	f.reservRepo.On("UpdateStatus", mock.Anything, []string{}, model.ReservationStatusCommitted).Return(nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, eventbus.OrderPaid{OrderPayload: eventbus.OrderPayload{
+		OrderID:    "o1",
+		UserID:     "u1",
+		UserEmail:  "u1@example.com",
+		Status:     string(model.OrderStatusPaid),
+		TotalPrice: money.New(2000, "USD"),
+		FinalPrice: money.New(2000, "USD"),
+		Lines:      []eventbus.OrderLine{{ProductID: "p1", Quantity: 2, Price: money.New(2000, "USD")}},
+	}}).Return(nil).Once()
+
+	_, err := f.svc.MarkOrderPaid(context.Background(), "o1")
+	require.NoError(t, err)
+}
+
+func TestMarkOrderPaid_IssuesInvoice(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{
+		ID: "o1", Code: "SO1", UserID: "u1", Status: model.OrderStatusPendingPayment, Currency: "USD",
+		TotalPrice: money.New(2000, "USD"), FinalPrice: money.New(2499, "USD"), ShippingFee: money.New(499, "USD"),
+		BillingAddress: model.OrderAddress{Name: "Ann Buyer", Street: "1 Main St", City: "Springfield", Country: "US"},
+		Lines:          []*model.OrderLine{{ID: "l1", ProductID: "p1", Product: &model.Product{Name: "Mug"}, Quantity: 2, Price: money.New(2000, "USD")}},
+	}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(nil, nil).Once()
+	f.reservRepo.On("UpdateStatus", mock.Anything, []string{}, model.ReservationStatusCommitted).Return(nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderPaid")).Return(nil).Once()
+
+	_, err := f.svc.MarkOrderPaid(context.Background(), "o1")
+	require.NoError(t, err)
+	f.invoices.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(inv *model.Invoice) bool {
+		doc := inv.Document
+		return inv.OrderID == "o1" && inv.UserID == "u1" && inv.Number == doc.Number && doc.OrderCode == "SO1" &&
+			doc.Buyer.Name == "Ann Buyer" && doc.Buyer.Email == "u1@example.com" &&
+			len(doc.Lines) == 1 && doc.Lines[0].Description == "Mug" && doc.Lines[0].UnitPrice == money.New(1000, "USD") &&
+			doc.Shipping == money.New(499, "USD") && inv.Total == money.New(2499, "USD")
+	}))
+}
 
-	order := &model.Order{ID: "o1", Status: model.OrderStatusPendingPayment}
+func TestUpdateOrderStatus_PaidCommitsStockAndInvoices(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{
+		ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment,
+		Lines: []*model.OrderLine{{ProductID: "p1", Quantity: 1, Price: money.New(1000, "USD")}},
+	}
 	reservations := []*model.StockReservation{{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1}}
-	repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
-	reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(reservations, nil).Once()
-	productRepo.On("CommitReservation", mock.Anything, "p1", 1).Return(nil).Once()
-	reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusCommitted).Return(nil).Once()
-	repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
-	productRepo.On("GetProductByID", mock.Anything, "p1").
-		Return(&model.Product{ID: "p1", StockQuantity: 2, ReservedQuantity: 1}, nil).Once()
-
-	_, err := svc.MarkOrderPaid(context.Background(), "o1")
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Twice()
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(reservations, nil).Once()
+	f.productRepo.On("CommitReservation", mock.Anything, "p1", 1).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.AnythingOfType("stock.Movement")).Return(nil).Once()
+	f.productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{ID: "p1", StockQuantity: 100}, nil).Once()
+	f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusCommitted).Return(nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderPaid")).Return(nil).Once()
+
+	got, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusPaid)
 	require.NoError(t, err)
+	require.Equal(t, model.OrderStatusPaid, got.Status)
+	f.invoices.AssertCalled(t, "Create", mock.Anything, mock.MatchedBy(func(inv *model.Invoice) bool {
+		return inv.OrderID == "o1"
+	}))
+}
 
-	deadline := time.Now().Add(time.Second)
-	for time.Now().Before(deadline) {
-		mu.Lock()
-		if len(captured) > 0 {
-			mu.Unlock()
-			return
-		}
-		mu.Unlock()
-		time.Sleep(5 * time.Millisecond)
+func TestMarkOrderPaid_InvoiceErrorFailsCommit(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	f.invoices.ExpectedCalls = nil
+	f.invoices.On("GetInvoice", mock.Anything, "o1").Return(nil, gorm.ErrRecordNotFound).Once()
+	f.invoices.On("NextSequence", mock.Anything, invoice.KindInvoice, mock.Anything).Return(0, errors.New("db")).Once()
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(nil, nil).Once()
+	f.reservRepo.On("UpdateStatus", mock.Anything, []string{}, model.ReservationStatusCommitted).Return(nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := f.svc.MarkOrderPaid(context.Background(), "o1")
+	require.ErrorContains(t, err, "issue invoice")
+}
+
+func TestMarkOrderPaid_OutboxErrorFailsCommit(t *testing.T) {
+	tests := []struct {
+		name      string
+		failEvent string
+	}{
+		{"order_paid", "eventbus.OrderPaid"},
+		{"low_stock", "eventbus.LowStock"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newMarkPaidFixture(t)
+			order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}
+			reservations := []*model.StockReservation{{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1}}
+
+			f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+			f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(reservations, nil).Once()
+			f.productRepo.On("CommitReservation", mock.Anything, "p1", 1).Return(nil).Once()
+			f.ledger.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+			f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusCommitted).Return(nil).Once()
+			f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+			f.outbox.On("Add", mock.Anything, mock.AnythingOfType(tt.failEvent)).Return(errors.New("db")).Once()
+			if tt.failEvent == "eventbus.LowStock" {
+				f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderPaid")).Return(nil).Once()
+				f.productRepo.On("GetProductByID", mock.Anything, "p1").
+					Return(&model.Product{ID: "p1", StockQuantity: 2, ReservedQuantity: 1}, nil).Once()
+			}
+
+			_, err := f.svc.MarkOrderPaid(context.Background(), "o1")
+			require.Error(t, err)
+		})
 	}
-	t.Fatal("expected event on default bus")
 }
+
+func TestMarkOrderPaid_LedgerErrorFailsCommit(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}
+	reservations := []*model.StockReservation{{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1}}
+
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(reservations, nil).Once()
+	f.productRepo.On("CommitReservation", mock.Anything, "p1", 1).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.Anything).Return(errors.New("db")).Once()
+
+	_, err := f.svc.MarkOrderPaid(context.Background(), "o1")
+	require.Error(t, err)
+}
+
+func intPtr(v int) *int { return &v }
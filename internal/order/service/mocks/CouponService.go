@@ -8,6 +8,7 @@ import (
 	"context"
 	"goshop/internal/order/domain"
 	"goshop/internal/order/model"
+	"goshop/pkg/money"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -40,33 +41,33 @@ func (_m *CouponService) EXPECT() *CouponService_Expecter {
 }
 
 // Apply provides a mock function for the type CouponService
-func (_mock *CouponService) Apply(ctx context.Context, code string, totalPrice float64) (float64, *model.Coupon, error) {
-	ret := _mock.Called(ctx, code, totalPrice)
+func (_mock *CouponService) Apply(ctx context.Context, code string, total money.Money) (money.Money, *model.Coupon, error) {
+	ret := _mock.Called(ctx, code, total)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Apply")
 	}
 
-	var r0 float64
+	var r0 money.Money
 	var r1 *model.Coupon
 	var r2 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, float64) (float64, *model.Coupon, error)); ok {
-		return returnFunc(ctx, code, totalPrice)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, money.Money) (money.Money, *model.Coupon, error)); ok {
+		return returnFunc(ctx, code, total)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, float64) float64); ok {
-		r0 = returnFunc(ctx, code, totalPrice)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, money.Money) money.Money); ok {
+		r0 = returnFunc(ctx, code, total)
 	} else {
-		r0 = ret.Get(0).(float64)
+		r0 = ret.Get(0).(money.Money)
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, float64) *model.Coupon); ok {
-		r1 = returnFunc(ctx, code, totalPrice)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, money.Money) *model.Coupon); ok {
+		r1 = returnFunc(ctx, code, total)
 	} else {
 		if ret.Get(1) != nil {
 			r1 = ret.Get(1).(*model.Coupon)
 		}
 	}
-	if returnFunc, ok := ret.Get(2).(func(context.Context, string, float64) error); ok {
-		r2 = returnFunc(ctx, code, totalPrice)
+	if returnFunc, ok := ret.Get(2).(func(context.Context, string, money.Money) error); ok {
+		r2 = returnFunc(ctx, code, total)
 	} else {
 		r2 = ret.Error(2)
 	}
@@ -81,12 +82,12 @@ type CouponService_Apply_Call struct {
 // Apply is a helper method to define mock.On call
 //   - ctx context.Context
 //   - code string
-//   - totalPrice float64
-func (_e *CouponService_Expecter) Apply(ctx interface{}, code interface{}, totalPrice interface{}) *CouponService_Apply_Call {
-	return &CouponService_Apply_Call{Call: _e.mock.On("Apply", ctx, code, totalPrice)}
+//   - total money.Money
+func (_e *CouponService_Expecter) Apply(ctx interface{}, code interface{}, total interface{}) *CouponService_Apply_Call {
+	return &CouponService_Apply_Call{Call: _e.mock.On("Apply", ctx, code, total)}
 }
 
-func (_c *CouponService_Apply_Call) Run(run func(ctx context.Context, code string, totalPrice float64)) *CouponService_Apply_Call {
+func (_c *CouponService_Apply_Call) Run(run func(ctx context.Context, code string, total money.Money)) *CouponService_Apply_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
@@ -96,9 +97,9 @@ func (_c *CouponService_Apply_Call) Run(run func(ctx context.Context, code strin
 		if args[1] != nil {
 			arg1 = args[1].(string)
 		}
-		var arg2 float64
+		var arg2 money.Money
 		if args[2] != nil {
-			arg2 = args[2].(float64)
+			arg2 = args[2].(money.Money)
 		}
 		run(
 			arg0,
@@ -109,12 +110,12 @@ func (_c *CouponService_Apply_Call) Run(run func(ctx context.Context, code strin
 	return _c
 }
 
-func (_c *CouponService_Apply_Call) Return(discountAmount float64, coupon *model.Coupon, err error) *CouponService_Apply_Call {
-	_c.Call.Return(discountAmount, coupon, err)
+func (_c *CouponService_Apply_Call) Return(discount money.Money, coupon *model.Coupon, err error) *CouponService_Apply_Call {
+	_c.Call.Return(discount, coupon, err)
 	return _c
 }
 
-func (_c *CouponService_Apply_Call) RunAndReturn(run func(ctx context.Context, code string, totalPrice float64) (float64, *model.Coupon, error)) *CouponService_Apply_Call {
+func (_c *CouponService_Apply_Call) RunAndReturn(run func(ctx context.Context, code string, total money.Money) (money.Money, *model.Coupon, error)) *CouponService_Apply_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -9,6 +9,7 @@ import (
 	"goshop/internal/order/domain"
 	"goshop/internal/order/model"
 	"goshop/pkg/paging"
+	"time"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -114,6 +115,69 @@ func (_c *OrderService_CancelOrder_Call) RunAndReturn(run func(ctx context.Conte
 	return _c
 }
 
+// ExtendReservations provides a mock function for the type OrderService
+func (_mock *OrderService) ExtendReservations(ctx context.Context, orderID string, until time.Time) error {
+	ret := _mock.Called(ctx, orderID, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExtendReservations")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, time.Time) error); ok {
+		r0 = returnFunc(ctx, orderID, until)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// OrderService_ExtendReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ExtendReservations'
+type OrderService_ExtendReservations_Call struct {
+	*mock.Call
+}
+
+// ExtendReservations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID string
+//   - until time.Time
+func (_e *OrderService_Expecter) ExtendReservations(ctx interface{}, orderID interface{}, until interface{}) *OrderService_ExtendReservations_Call {
+	return &OrderService_ExtendReservations_Call{Call: _e.mock.On("ExtendReservations", ctx, orderID, until)}
+}
+
+func (_c *OrderService_ExtendReservations_Call) Run(run func(ctx context.Context, orderID string, until time.Time)) *OrderService_ExtendReservations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 time.Time
+		if args[2] != nil {
+			arg2 = args[2].(time.Time)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderService_ExtendReservations_Call) Return(err error) *OrderService_ExtendReservations_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *OrderService_ExtendReservations_Call) RunAndReturn(run func(ctx context.Context, orderID string, until time.Time) error) *OrderService_ExtendReservations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // GetMyOrders provides a mock function for the type OrderService
 func (_mock *OrderService) GetMyOrders(ctx context.Context, req *domain.ListOrderReq) ([]*model.Order, *paging.Pagination, error) {
 	ret := _mock.Called(ctx, req)
@@ -258,6 +322,142 @@ func (_c *OrderService_GetOrderByID_Call) RunAndReturn(run func(ctx context.Cont
 	return _c
 }
 
+// MarkOrderAuthorized provides a mock function for the type OrderService
+func (_mock *OrderService) MarkOrderAuthorized(ctx context.Context, orderID string) (*model.Order, error) {
+	ret := _mock.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOrderAuthorized")
+	}
+
+	var r0 *model.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Order, error)); ok {
+		return returnFunc(ctx, orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Order); ok {
+		r0 = returnFunc(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Order)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderService_MarkOrderAuthorized_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkOrderAuthorized'
+type OrderService_MarkOrderAuthorized_Call struct {
+	*mock.Call
+}
+
+// MarkOrderAuthorized is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID string
+func (_e *OrderService_Expecter) MarkOrderAuthorized(ctx interface{}, orderID interface{}) *OrderService_MarkOrderAuthorized_Call {
+	return &OrderService_MarkOrderAuthorized_Call{Call: _e.mock.On("MarkOrderAuthorized", ctx, orderID)}
+}
+
+func (_c *OrderService_MarkOrderAuthorized_Call) Run(run func(ctx context.Context, orderID string)) *OrderService_MarkOrderAuthorized_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderService_MarkOrderAuthorized_Call) Return(order *model.Order, err error) *OrderService_MarkOrderAuthorized_Call {
+	_c.Call.Return(order, err)
+	return _c
+}
+
+func (_c *OrderService_MarkOrderAuthorized_Call) RunAndReturn(run func(ctx context.Context, orderID string) (*model.Order, error)) *OrderService_MarkOrderAuthorized_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MarkOrderPaid provides a mock function for the type OrderService
+func (_mock *OrderService) MarkOrderPaid(ctx context.Context, orderID string) (*model.Order, error) {
+	ret := _mock.Called(ctx, orderID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MarkOrderPaid")
+	}
+
+	var r0 *model.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (*model.Order, error)); ok {
+		return returnFunc(ctx, orderID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) *model.Order); ok {
+		r0 = returnFunc(ctx, orderID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Order)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, orderID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderService_MarkOrderPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MarkOrderPaid'
+type OrderService_MarkOrderPaid_Call struct {
+	*mock.Call
+}
+
+// MarkOrderPaid is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID string
+func (_e *OrderService_Expecter) MarkOrderPaid(ctx interface{}, orderID interface{}) *OrderService_MarkOrderPaid_Call {
+	return &OrderService_MarkOrderPaid_Call{Call: _e.mock.On("MarkOrderPaid", ctx, orderID)}
+}
+
+func (_c *OrderService_MarkOrderPaid_Call) Run(run func(ctx context.Context, orderID string)) *OrderService_MarkOrderPaid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderService_MarkOrderPaid_Call) Return(order *model.Order, err error) *OrderService_MarkOrderPaid_Call {
+	_c.Call.Return(order, err)
+	return _c
+}
+
+func (_c *OrderService_MarkOrderPaid_Call) RunAndReturn(run func(ctx context.Context, orderID string) (*model.Order, error)) *OrderService_MarkOrderPaid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // PlaceOrder provides a mock function for the type OrderService
 func (_mock *OrderService) PlaceOrder(ctx context.Context, req *domain.PlaceOrderReq) (*model.Order, error) {
 	ret := _mock.Called(ctx, req)
@@ -326,86 +526,80 @@ func (_c *OrderService_PlaceOrder_Call) RunAndReturn(run func(ctx context.Contex
 	return _c
 }
 
-// UpdateOrderStatus provides a mock function for the type OrderService
-func (_mock *OrderService) UpdateOrderStatus(ctx context.Context, orderID string, status model.OrderStatus) (*model.Order, error) {
-	ret := _mock.Called(ctx, orderID, status)
+// QuoteShipping provides a mock function for the type OrderService
+func (_mock *OrderService) QuoteShipping(ctx context.Context, req *domain.QuoteShippingReq) ([]*domain.ShippingQuote, error) {
+	ret := _mock.Called(ctx, req)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateOrderStatus")
+		panic("no return value specified for QuoteShipping")
 	}
 
-	var r0 *model.Order
+	var r0 []*domain.ShippingQuote
 	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.OrderStatus) (*model.Order, error)); ok {
-		return returnFunc(ctx, orderID, status)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.QuoteShippingReq) ([]*domain.ShippingQuote, error)); ok {
+		return returnFunc(ctx, req)
 	}
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.OrderStatus) *model.Order); ok {
-		r0 = returnFunc(ctx, orderID, status)
+	if returnFunc, ok := ret.Get(0).(func(context.Context, *domain.QuoteShippingReq) []*domain.ShippingQuote); ok {
+		r0 = returnFunc(ctx, req)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*model.Order)
+			r0 = ret.Get(0).([]*domain.ShippingQuote)
 		}
 	}
-	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.OrderStatus) error); ok {
-		r1 = returnFunc(ctx, orderID, status)
+	if returnFunc, ok := ret.Get(1).(func(context.Context, *domain.QuoteShippingReq) error); ok {
+		r1 = returnFunc(ctx, req)
 	} else {
 		r1 = ret.Error(1)
 	}
 	return r0, r1
 }
 
-// OrderService_UpdateOrderStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrderStatus'
-type OrderService_UpdateOrderStatus_Call struct {
+// OrderService_QuoteShipping_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'QuoteShipping'
+type OrderService_QuoteShipping_Call struct {
 	*mock.Call
 }
 
-// UpdateOrderStatus is a helper method to define mock.On call
+// QuoteShipping is a helper method to define mock.On call
 //   - ctx context.Context
-//   - orderID string
-//   - status model.OrderStatus
-func (_e *OrderService_Expecter) UpdateOrderStatus(ctx interface{}, orderID interface{}, status interface{}) *OrderService_UpdateOrderStatus_Call {
-	return &OrderService_UpdateOrderStatus_Call{Call: _e.mock.On("UpdateOrderStatus", ctx, orderID, status)}
+//   - req *domain.QuoteShippingReq
+func (_e *OrderService_Expecter) QuoteShipping(ctx interface{}, req interface{}) *OrderService_QuoteShipping_Call {
+	return &OrderService_QuoteShipping_Call{Call: _e.mock.On("QuoteShipping", ctx, req)}
 }
 
-func (_c *OrderService_UpdateOrderStatus_Call) Run(run func(ctx context.Context, orderID string, status model.OrderStatus)) *OrderService_UpdateOrderStatus_Call {
+func (_c *OrderService_QuoteShipping_Call) Run(run func(ctx context.Context, req *domain.QuoteShippingReq)) *OrderService_QuoteShipping_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		var arg0 context.Context
 		if args[0] != nil {
 			arg0 = args[0].(context.Context)
 		}
-		var arg1 string
+		var arg1 *domain.QuoteShippingReq
 		if args[1] != nil {
-			arg1 = args[1].(string)
-		}
-		var arg2 model.OrderStatus
-		if args[2] != nil {
-			arg2 = args[2].(model.OrderStatus)
+			arg1 = args[1].(*domain.QuoteShippingReq)
 		}
 		run(
 			arg0,
 			arg1,
-			arg2,
 		)
 	})
 	return _c
 }
 
-func (_c *OrderService_UpdateOrderStatus_Call) Return(order *model.Order, err error) *OrderService_UpdateOrderStatus_Call {
-	_c.Call.Return(order, err)
+func (_c *OrderService_QuoteShipping_Call) Return(shippingQuotes []*domain.ShippingQuote, err error) *OrderService_QuoteShipping_Call {
+	_c.Call.Return(shippingQuotes, err)
 	return _c
 }
 
-func (_c *OrderService_UpdateOrderStatus_Call) RunAndReturn(run func(ctx context.Context, orderID string, status model.OrderStatus) (*model.Order, error)) *OrderService_UpdateOrderStatus_Call {
+func (_c *OrderService_QuoteShipping_Call) RunAndReturn(run func(ctx context.Context, req *domain.QuoteShippingReq) ([]*domain.ShippingQuote, error)) *OrderService_QuoteShipping_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// MarkOrderPaid provides a mock function for the type OrderService
-func (_mock *OrderService) MarkOrderPaid(ctx context.Context, orderID string) (*model.Order, error) {
+// ReopenForPayment provides a mock function for the type OrderService
+func (_mock *OrderService) ReopenForPayment(ctx context.Context, orderID string) (*model.Order, error) {
 	ret := _mock.Called(ctx, orderID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for MarkOrderPaid")
+		panic("no return value specified for ReopenForPayment")
 	}
 
 	var r0 *model.Order
@@ -428,6 +622,46 @@ func (_mock *OrderService) MarkOrderPaid(ctx context.Context, orderID string) (*
 	return r0, r1
 }
 
+// OrderService_ReopenForPayment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ReopenForPayment'
+type OrderService_ReopenForPayment_Call struct {
+	*mock.Call
+}
+
+// ReopenForPayment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID string
+func (_e *OrderService_Expecter) ReopenForPayment(ctx interface{}, orderID interface{}) *OrderService_ReopenForPayment_Call {
+	return &OrderService_ReopenForPayment_Call{Call: _e.mock.On("ReopenForPayment", ctx, orderID)}
+}
+
+func (_c *OrderService_ReopenForPayment_Call) Run(run func(ctx context.Context, orderID string)) *OrderService_ReopenForPayment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderService_ReopenForPayment_Call) Return(order *model.Order, err error) *OrderService_ReopenForPayment_Call {
+	_c.Call.Return(order, err)
+	return _c
+}
+
+func (_c *OrderService_ReopenForPayment_Call) RunAndReturn(run func(ctx context.Context, orderID string) (*model.Order, error)) *OrderService_ReopenForPayment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SweepExpiredReservations provides a mock function for the type OrderService
 func (_mock *OrderService) SweepExpiredReservations(ctx context.Context, batchSize int) (int, error) {
 	ret := _mock.Called(ctx, batchSize)
@@ -453,3 +687,117 @@ func (_mock *OrderService) SweepExpiredReservations(ctx context.Context, batchSi
 	}
 	return r0, r1
 }
+
+// OrderService_SweepExpiredReservations_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SweepExpiredReservations'
+type OrderService_SweepExpiredReservations_Call struct {
+	*mock.Call
+}
+
+// SweepExpiredReservations is a helper method to define mock.On call
+//   - ctx context.Context
+//   - batchSize int
+func (_e *OrderService_Expecter) SweepExpiredReservations(ctx interface{}, batchSize interface{}) *OrderService_SweepExpiredReservations_Call {
+	return &OrderService_SweepExpiredReservations_Call{Call: _e.mock.On("SweepExpiredReservations", ctx, batchSize)}
+}
+
+func (_c *OrderService_SweepExpiredReservations_Call) Run(run func(ctx context.Context, batchSize int)) *OrderService_SweepExpiredReservations_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 int
+		if args[1] != nil {
+			arg1 = args[1].(int)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderService_SweepExpiredReservations_Call) Return(n int, err error) *OrderService_SweepExpiredReservations_Call {
+	_c.Call.Return(n, err)
+	return _c
+}
+
+func (_c *OrderService_SweepExpiredReservations_Call) RunAndReturn(run func(ctx context.Context, batchSize int) (int, error)) *OrderService_SweepExpiredReservations_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// UpdateOrderStatus provides a mock function for the type OrderService
+func (_mock *OrderService) UpdateOrderStatus(ctx context.Context, orderID string, status model.OrderStatus) (*model.Order, error) {
+	ret := _mock.Called(ctx, orderID, status)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateOrderStatus")
+	}
+
+	var r0 *model.Order
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.OrderStatus) (*model.Order, error)); ok {
+		return returnFunc(ctx, orderID, status)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, model.OrderStatus) *model.Order); ok {
+		r0 = returnFunc(ctx, orderID, status)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Order)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, model.OrderStatus) error); ok {
+		r1 = returnFunc(ctx, orderID, status)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// OrderService_UpdateOrderStatus_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateOrderStatus'
+type OrderService_UpdateOrderStatus_Call struct {
+	*mock.Call
+}
+
+// UpdateOrderStatus is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID string
+//   - status model.OrderStatus
+func (_e *OrderService_Expecter) UpdateOrderStatus(ctx interface{}, orderID interface{}, status interface{}) *OrderService_UpdateOrderStatus_Call {
+	return &OrderService_UpdateOrderStatus_Call{Call: _e.mock.On("UpdateOrderStatus", ctx, orderID, status)}
+}
+
+func (_c *OrderService_UpdateOrderStatus_Call) Run(run func(ctx context.Context, orderID string, status model.OrderStatus)) *OrderService_UpdateOrderStatus_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 model.OrderStatus
+		if args[2] != nil {
+			arg2 = args[2].(model.OrderStatus)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *OrderService_UpdateOrderStatus_Call) Return(order *model.Order, err error) *OrderService_UpdateOrderStatus_Call {
+	_c.Call.Return(order, err)
+	return _c
+}
+
+func (_c *OrderService_UpdateOrderStatus_Call) RunAndReturn(run func(ctx context.Context, orderID string, status model.OrderStatus) (*model.Order, error)) *OrderService_UpdateOrderStatus_Call {
+	_c.Call.Return(run)
+	return _c
+}
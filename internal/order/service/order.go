@@ -14,10 +14,12 @@ import (
 	"goshop/internal/order/model"
 	orderRepo "goshop/internal/order/repository"
 	"goshop/pkg/apperror"
+	"goshop/pkg/currency"
 	"goshop/pkg/dbs"
 	"goshop/pkg/eventbus"
-	"goshop/pkg/notification"
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
+	"goshop/pkg/stock"
 )
 
 // ReservationTTL is how long a placed order holds reserved stock before the sweeper releases
@@ -25,24 +27,73 @@ import (
 // short enough that abandoned carts don't starve other shoppers.
 const ReservationTTL = 15 * time.Minute
 
-// LowStockThreshold is the available-stock floor at or below which a LowStock event is
-// published after a commit. Matches the FE badge threshold so admin alerts and customer
+// LowStockThreshold is the default available-stock floor at or below which a LowStock event is
+// recorded when a payment commits stock; a product's or its category's low_stock_threshold
+// overrides it. Shared with the product badge via pkg/stock so admin alerts and customer
 // "Low stock" badges fire on the same boundary.
-const LowStockThreshold = 5
+const LowStockThreshold = stock.DefaultLowStockThreshold
+
+// EventOutbox records domain events in the same transaction as the state change that produced
+// them; the outbox relay publishes them to the event bus after commit. Declared here so the
+// order domain doesn't depend on the outbox package.
+//
+//go:generate mockery --name=EventOutbox
+type EventOutbox interface {
+	Add(ctx context.Context, ev eventbus.Event) error
+}
+
+// StockLedger appends a movement to the inventory ledger. Call it right after the counter
+// change it describes, inside the same transaction. Declared here so the order domain doesn't
+// depend on the inventory package.
+//
+//go:generate mockery --name=StockLedger
+type StockLedger interface {
+	Record(ctx context.Context, m stock.Movement) error
+}
+
+// PaymentSettler settles an order's authorized payment: it captures the payment when
+// fulfillment starts and voids it when the order is cancelled instead. Payments charged at
+// checkout, and orders without a payment, are left alone. Declared here so the order domain
+// doesn't depend on the payment package.
+//
+//go:generate mockery --name=PaymentSettler
+type PaymentSettler interface {
+	CaptureOrderPayment(ctx context.Context, orderID string) error
+	VoidOrderPayment(ctx context.Context, orderID string) error
+}
 
 //go:generate mockery --name=OrderService
 type OrderService interface {
 	PlaceOrder(ctx context.Context, req *domain.PlaceOrderReq) (*model.Order, error)
+	// QuoteShipping prices the shipping methods that ship the lines to the given country, as
+	// PlaceOrder would charge them.
+	QuoteShipping(ctx context.Context, req *domain.QuoteShippingReq) ([]*domain.ShippingQuote, error)
 	GetOrderByID(ctx context.Context, id string) (*model.Order, error)
 	GetMyOrders(ctx context.Context, req *domain.ListOrderReq) ([]*model.Order, *paging.Pagination, error)
 	CancelOrder(ctx context.Context, orderID, userID string) (*model.Order, error)
+	// UpdateOrderStatus moves the order along allowedTransitions. Moving to in-progress captures
+	// an authorized payment first, and cancelling voids it; if that fails the status stays.
+	// Moving to paid is MarkOrderPaid, and a cash-on-delivery order is invoiced when it is done.
 	UpdateOrderStatus(ctx context.Context, orderID string, status model.OrderStatus) (*model.Order, error)
-	// MarkOrderPaid commits the order's stock reservations and flips its status to paid. Idempotent
-	// per order: a second call on an already-paid order is a no-op.
+	// MarkOrderPaid commits the order's stock reservations, flips its status to paid and issues
+	// its invoice. Idempotent per order: on an order that is already paid, or further along, it
+	// only issues the invoice an authorization left out, recording OrderInvoiced for it.
 	MarkOrderPaid(ctx context.Context, orderID string) (*model.Order, error)
+	// MarkOrderAuthorized is MarkOrderPaid for a payment whose funds are only held: the order is
+	// paid and its stock committed, but it isn't invoiced until MarkOrderPaid once the funds
+	// are captured, so a voided authorization leaves no invoice behind.
+	MarkOrderAuthorized(ctx context.Context, orderID string) (*model.Order, error)
 	// SweepExpiredReservations releases reservations past their TTL whose parent order is still
-	// pending payment, and cancels those orders. Returns the number of reservations released.
+	// unpaid, and cancels those orders, except ones whose payment failed: they stay open for a
+	// retry. Returns the number of reservations released.
 	SweepExpiredReservations(ctx context.Context, batchSize int) (int, error)
+	// ExtendReservations keeps a pending order's stock held until the given time, for payment
+	// methods that take longer than ReservationTTL to settle (e.g. bank transfer).
+	ExtendReservations(ctx context.Context, orderID string, until time.Time) error
+	// ReopenForPayment moves a payment_failed order back to pending_payment so the customer
+	// can pay again, holding its stock for another ReservationTTL. Stock the sweeper released
+	// in the meantime is reserved again. Idempotent on a pending_payment order.
+	ReopenForPayment(ctx context.Context, orderID string) (*model.Order, error)
 }
 
 type orderService struct {
@@ -53,15 +104,17 @@ type orderService struct {
 	userRepo        orderRepo.UserRepository
 	reservationRepo orderRepo.ReservationRepository
 	couponSvc       CouponService
-	notifier        notification.Notifier
-	bus             eventbus.Bus
+	outbox          EventOutbox
+	ledger          StockLedger
+	warehouseRepo   orderRepo.WarehouseRepository
+	allocation      stock.AllocationStrategy
+	rates           *currency.Rates
+	shipping        ShippingService
+	taxes           TaxService
+	invoices        InvoiceService
+	payments        PaymentSettler
 }
 
-// SetEventBus wires an optional event bus. When set, the service publishes domain
-// events (currently LowStock after commit) without coupling notification transports
-// into the order service itself. Safe to leave unset for tests.
-func (s *orderService) SetEventBus(bus eventbus.Bus) { s.bus = bus }
-
 func NewOrderService(
 	validator validation.Validation,
 	db dbs.Database,
@@ -70,7 +123,15 @@ func NewOrderService(
 	userRepo orderRepo.UserRepository,
 	reservationRepo orderRepo.ReservationRepository,
 	couponSvc CouponService,
-	notifier notification.Notifier,
+	outbox EventOutbox,
+	ledger StockLedger,
+	warehouseRepo orderRepo.WarehouseRepository,
+	allocation stock.AllocationStrategy,
+	rates *currency.Rates,
+	shipping ShippingService,
+	taxes TaxService,
+	invoices InvoiceService,
+	payments PaymentSettler,
 ) OrderService {
 	return &orderService{
 		validator:       validator,
@@ -80,7 +141,15 @@ func NewOrderService(
 		userRepo:        userRepo,
 		reservationRepo: reservationRepo,
 		couponSvc:       couponSvc,
-		notifier:        notifier,
+		outbox:          outbox,
+		ledger:          ledger,
+		warehouseRepo:   warehouseRepo,
+		allocation:      allocation,
+		rates:           rates,
+		shipping:        shipping,
+		taxes:           taxes,
+		invoices:        invoices,
+		payments:        payments,
 	}
 }
 
@@ -89,81 +158,153 @@ func (s *orderService) PlaceOrder(ctx context.Context, req *domain.PlaceOrderReq
 		return nil, err
 	}
 
+	base := s.rates.Base()
+	orderCurrency := base
+	if req.Currency != "" {
+		orderCurrency = currency.Normalize(req.Currency)
+	}
+	if !s.rates.Supported(orderCurrency) {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil,
+			fmt.Sprintf("currency %s is not supported", orderCurrency))
+	}
+
 	lines := make([]*model.OrderLine, len(req.Lines))
 	for i, l := range req.Lines {
-		lines[i] = &model.OrderLine{ProductID: l.ProductID, Quantity: l.Quantity}
+		lines[i] = &model.OrderLine{ProductID: l.ProductID, Quantity: l.Quantity, Currency: orderCurrency}
 	}
 
+	// The unit price is converted once and multiplied, so the order total is exactly the sum
+	// of its lines.
 	productMap := make(map[string]*model.Product)
+	total := money.Zero(orderCurrency)
 	for _, line := range lines {
 		product, err := s.productRepo.GetProductByID(ctx, line.ProductID)
 		if err != nil {
 			return nil, err
 		}
-		line.Price = product.Price * float64(line.Quantity)
+		unit, err := product.Price.Convert(s.rates, orderCurrency)
+		if err != nil {
+			return nil, err
+		}
+		line.Price = unit.Mul(int64(line.Quantity))
+		line.TaxClass = product.EffectiveTaxClass()
+		total = total.Add(line.Price)
 		productMap[line.ProductID] = product
 	}
 
-	var totalPrice float64
-	for _, line := range lines {
-		totalPrice += line.Price
-	}
-
-	var discountAmount float64
+	discount := money.Zero(orderCurrency)
 	var couponCode string
 	var couponID string
 	if req.CouponCode != "" {
-		discount, coupon, err := s.couponSvc.Apply(ctx, req.CouponCode, totalPrice)
+		var coupon *model.Coupon
+		var err error
+		discount, coupon, err = s.couponSvc.Apply(ctx, req.CouponCode, total)
 		if err != nil {
 			return nil, err
 		}
-		discountAmount = discount
 		couponCode = coupon.Code
 		couponID = coupon.ID
 	}
 
-	// Reserve stock + create order + persist reservations + bump coupon usage atomically.
-	// Reservations hold inventory until payment clears or the sweeper releases them.
+	paymentMethod := model.PaymentMethod(req.PaymentMethod)
+	if paymentMethod == "" {
+		paymentMethod = model.PaymentMethodOnline
+	}
+
+	shipTo, billTo, err := s.orderAddresses(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	method, shippingFee, err := s.shipping.Fee(ctx, req.ShippingMethod, s.parcel(shipTo.Country, lines, productMap), orderCurrency)
+	if err != nil {
+		return nil, err
+	}
+	// Tax is worked out on the lines after their share of the discount, where the order ships;
+	// the lines are saved with it.
+	taxed := &model.Order{Lines: lines, TotalPrice: total, DiscountAmount: discount, ShippingAddress: shipTo}
+	if err := s.taxes.Apply(ctx, taxed); err != nil {
+		return nil, err
+	}
+
+	userEmail := s.userEmail(ctx, req.UserID)
+	dest := stock.Destination{Country: shipTo.Country, City: shipTo.City}
+
+	// Reserve stock + create order + persist reservations + bump coupon usage + record the
+	// OrderCreated event atomically. Reservations hold inventory until payment clears or the
+	// sweeper releases them; cash-on-delivery orders commit them straight away, as there is
+	// no payment to wait for.
 	var order *model.Order
 	expiresAt := time.Now().Add(ReservationTTL)
 	txErr := s.db.WithTransaction(func() error {
-		o, err := s.repo.CreateOrder(ctx, req.UserID, lines, couponCode, discountAmount)
+		o, err := s.repo.CreateOrder(ctx, req.UserID, lines, couponCode, discount)
 		if err != nil {
 			return err
 		}
+		o.PaymentMethod = paymentMethod
+		o.ShippingMethod = method.Code
+		o.ShippingFee = shippingFee
+		if shippingFee.IsPositive() {
+			o.FinalPrice = o.FinalPrice.Add(shippingFee)
+		}
+		o.TaxMode = taxed.TaxMode
+		o.TaxAmount = taxed.TaxAmount
+		if !o.TaxMode.Inclusive() && o.TaxAmount.IsPositive() {
+			o.FinalPrice = o.FinalPrice.Add(o.TaxAmount)
+		}
+		o.ShippingAddress = shipTo
+		o.BillingAddress = billTo
 		o.Status = model.OrderStatusPendingPayment
+		if o.CashOnDelivery() {
+			o.Status = model.OrderStatusNew
+		}
 		if err := s.repo.UpdateOrder(ctx, o); err != nil {
 			return err
 		}
 
 		reservations := make([]*model.StockReservation, 0, len(lines))
 		for _, line := range lines {
-			qty := int(line.Quantity) //nolint:gosec // bounded by validation (lte=5 lines, uint qty)
-			if err := s.productRepo.ReserveStock(ctx, line.ProductID, qty); err != nil {
-				if errors.Is(err, orderRepo.ErrInsufficientStock) {
-					return &InsufficientStockError{ProductID: line.ProductID, Requested: qty}
-				}
-				return fmt.Errorf("reserve stock for product %s: %w", line.ProductID, err)
+			qty := int(line.Quantity) //nolint:gosec // bounded by validation (lte=100 lines, uint qty)
+			held, err := s.reserveLine(ctx, o.ID, line.ProductID, qty, dest, expiresAt)
+			if err != nil {
+				return err
 			}
-			reservations = append(reservations, &model.StockReservation{
-				OrderID:   o.ID,
-				ProductID: line.ProductID,
-				Quantity:  qty,
-				Status:    model.ReservationStatusActive,
-				ExpiresAt: expiresAt,
-			})
+			reservations = append(reservations, held...)
 		}
 		if err := s.reservationRepo.CreateMany(ctx, reservations); err != nil {
 			return fmt.Errorf("persist reservations: %w", err)
 		}
+		for _, res := range reservations {
+			if err := s.ledger.Record(ctx, stock.Movement{
+				ProductID:     res.ProductID,
+				Kind:          stock.MovementReserve,
+				ReservedDelta: res.Quantity,
+				Actor:         req.UserID,
+				Reason:        "order placed",
+				OrderID:       o.ID,
+				ReservationID: res.ID,
+				WarehouseID:   warehouseOf(res),
+			}); err != nil {
+				return fmt.Errorf("record stock reservation: %w", err)
+			}
+		}
+		var committedProductIDs []string
+		if o.CashOnDelivery() {
+			committedProductIDs, err = s.commitReservations(ctx, o.ID, reservations, req.UserID, "cash on delivery order placed")
+			if err != nil {
+				return err
+			}
+		}
 
 		if couponID != "" {
 			if err := s.couponSvc.IncrUsedCount(ctx, couponID); err != nil {
 				return fmt.Errorf("increment coupon usage: %w", err)
 			}
 		}
+		if err := s.outbox.Add(ctx, eventbus.OrderCreated{OrderPayload: orderPayload(o, userEmail)}); err != nil {
+			return fmt.Errorf("record order created event: %w", err)
+		}
 		order = o
-		return nil
+		return s.recordLowStock(ctx, committedProductIDs)
 	})
 	if txErr != nil {
 		return nil, txErr
@@ -173,17 +314,6 @@ func (s *orderService) PlaceOrder(ctx context.Context, req *domain.PlaceOrderReq
 		line.Product = productMap[line.ProductID]
 	}
 
-	go func() {
-		user, err := s.userRepo.GetUserByID(ctx, req.UserID)
-		if err != nil {
-			logger.Error("Failed to get user for notification: ", err)
-			return
-		}
-		if err := s.notifier.SendOrderPlaced(ctx, order.ID, user.Email); err != nil {
-			logger.Error("Failed to send order placed notification: ", err)
-		}
-	}()
-
 	return order, nil
 }
 
@@ -192,6 +322,14 @@ func (s *orderService) GetOrderByID(ctx context.Context, id string) (*model.Orde
 	if err != nil {
 		return nil, err
 	}
+	order.PaymentAttempts, err = s.repo.ListPaymentAttempts(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	order.Shipments, err = s.repo.ListShipments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
 
 	return order, nil
 }
@@ -206,7 +344,7 @@ func (s *orderService) GetMyOrders(ctx context.Context, req *domain.ListOrderReq
 }
 
 func (s *orderService) UpdateOrderStatus(ctx context.Context, orderID string, status model.OrderStatus) (*model.Order, error) {
-	order, err := s.repo.GetOrderByID(ctx, orderID, false)
+	order, err := s.repo.GetOrderByID(ctx, orderID, true)
 	if err != nil {
 		return nil, err
 	}
@@ -217,75 +355,200 @@ func (s *orderService) UpdateOrderStatus(ctx context.Context, orderID string, st
 	if !order.Status.CanTransitionTo(status) {
 		return nil, apperror.ErrInvalidStatus
 	}
-
+	// Paying an order commits its stock and invoices it, which only MarkOrderPaid does.
+	if status == model.OrderStatusPaid {
+		return s.MarkOrderPaid(ctx, orderID)
+	}
+
+	// A repeated move (e.g. a retried webhook) is accepted but isn't a new transition.
+	changed := order.Status != status
+	userEmail := ""
+	if changed {
+		// Settle the payment before the move, so an order is never shipped uncharged and a
+		// failed void can be retried by cancelling again. Cash on delivery has nothing to settle.
+		switch {
+		case order.CashOnDelivery():
+		case status == model.OrderStatusInProgress:
+			if err := s.payments.CaptureOrderPayment(ctx, order.ID); err != nil {
+				return nil, fmt.Errorf("capture payment: %w", err)
+			}
+		case status == model.OrderStatusCancelled:
+			if err := s.payments.VoidOrderPayment(ctx, order.ID); err != nil {
+				return nil, fmt.Errorf("void payment: %w", err)
+			}
+		}
+		userEmail = s.userEmail(ctx, order.UserID)
+	}
+	// Stock is committed once the payment clears (or is authorized), and at placement for cash
+	// on delivery. A cancelled order puts it back, in the same transaction as the move.
+	restock := changed && status == model.OrderStatusCancelled && (order.CashOnDelivery() ||
+		order.Status == model.OrderStatusPaid || order.Status == model.OrderStatusInProgress)
+	// An unpaid order only holds reservations; cancelling it frees them with the move rather
+	// than leaving them for the expiry sweep.
+	release := changed && status == model.OrderStatusCancelled && !order.CashOnDelivery() &&
+		(order.Status == model.OrderStatusPendingPayment || order.Status == model.OrderStatusPaymentFailed)
+	// A cash-on-delivery order is paid when it is done: the courier collected the cash.
+	invoice := changed && status == model.OrderStatusDone && order.CashOnDelivery()
 	order.Status = status
-	if err := s.repo.UpdateOrder(ctx, order); err != nil {
-		return nil, err
-	}
-
-	go func() {
-		user, err := s.userRepo.GetUserByID(ctx, order.UserID)
-		if err != nil {
-			logger.Error("Failed to get user for notification: ", err)
-			return
+	txErr := s.db.WithTransaction(func() error {
+		if restock {
+			if err := s.returnCommittedStock(ctx, order.ID, stock.ActorSystem); err != nil {
+				return err
+			}
 		}
-		if err := s.notifier.SendOrderStatusChanged(ctx, order.ID, user.Email, string(status)); err != nil {
-			logger.Error("Failed to send order status changed notification: ", err)
+		if release {
+			if err := s.releaseActiveReservations(ctx, order.ID, stock.ActorSystem); err != nil {
+				return err
+			}
+		}
+		if err := s.repo.UpdateOrder(ctx, order); err != nil {
+			return err
 		}
-	}()
+		if !changed {
+			return nil
+		}
+		if invoice {
+			if err := s.invoiceLate(ctx, order, userEmail); err != nil {
+				return err
+			}
+		}
+		return s.outbox.Add(ctx, statusEvent(order, userEmail, CancelReasonStatusUpdate))
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
 
 	return order, nil
 }
 
 func (s *orderService) MarkOrderPaid(ctx context.Context, orderID string) (*model.Order, error) {
+	return s.markPaid(ctx, orderID, true)
+}
+
+func (s *orderService) MarkOrderAuthorized(ctx context.Context, orderID string) (*model.Order, error) {
+	return s.markPaid(ctx, orderID, false)
+}
+
+// markPaid commits the order's stock and moves it to paid, invoicing it once captured says the
+// money has been taken.
+func (s *orderService) markPaid(ctx context.Context, orderID string, captured bool) (*model.Order, error) {
 	order, err := s.repo.GetOrderByID(ctx, orderID, true)
 	if err != nil {
 		return nil, err
 	}
-	if order.Status == model.OrderStatusPaid {
-		return order, nil // idempotent: already committed
+	switch order.Status {
+	case model.OrderStatusPaid, model.OrderStatusInProgress, model.OrderStatusPartiallyShipped,
+		model.OrderStatusShipped, model.OrderStatusDone:
+		// Idempotent: already committed. A captured payment's webhook arrives after
+		// fulfillment has started, and only then is the authorized order invoiced.
+		if !captured {
+			return order, nil
+		}
+		userEmail := s.userEmail(ctx, order.UserID)
+		if err := s.db.WithTransaction(func() error { return s.invoiceLate(ctx, order, userEmail) }); err != nil {
+			return nil, err
+		}
+		return order, nil
 	}
 	if order.Status == model.OrderStatusCancelled || order.Status == model.OrderStatusPaymentFailed {
 		return nil, apperror.ErrInvalidStatus
 	}
 
-	var committedProductIDs []string
+	userEmail := s.userEmail(ctx, order.UserID)
 	txErr := s.db.WithTransaction(func() error {
 		reservations, err := s.reservationRepo.FindActiveByOrderID(ctx, order.ID)
 		if err != nil {
 			return err
 		}
-		for _, res := range reservations {
-			if err := s.productRepo.CommitReservation(ctx, res.ProductID, res.Quantity); err != nil {
-				return fmt.Errorf("commit reservation %s: %w", res.ID, err)
-			}
-			committedProductIDs = append(committedProductIDs, res.ProductID)
-		}
-		ids := reservationIDs(reservations)
-		if err := s.reservationRepo.UpdateStatus(ctx, ids, model.ReservationStatusCommitted); err != nil {
+		committedProductIDs, err := s.commitReservations(ctx, order.ID, reservations, stock.ActorSystem, "payment cleared")
+		if err != nil {
 			return err
 		}
 		order.Status = model.OrderStatusPaid
-		return s.repo.UpdateOrder(ctx, order)
+		if err := s.repo.UpdateOrder(ctx, order); err != nil {
+			return err
+		}
+		if captured {
+			if _, err := s.invoices.IssueInvoice(ctx, order, userEmail); err != nil {
+				return fmt.Errorf("issue invoice: %w", err)
+			}
+		}
+		if err := s.outbox.Add(ctx, eventbus.OrderPaid{OrderPayload: orderPayload(order, userEmail)}); err != nil {
+			return fmt.Errorf("record order paid event: %w", err)
+		}
+		return s.recordLowStock(ctx, committedProductIDs)
 	})
 	if txErr != nil {
 		return nil, txErr
 	}
-	s.publishLowStock(ctx, committedProductIDs)
 	return order, nil
 }
 
-// publishLowStock fires a LowStock event for each product whose available stock is at
-// or below the threshold. Best-effort: a fetch failure is logged but does not fail the
-// commit, since payment has already cleared.
-func (s *orderService) publishLowStock(ctx context.Context, productIDs []string) {
-	if len(productIDs) == 0 {
-		return
+// invoiceLate invoices an order whose OrderPaid event went out without an invoice: an
+// authorization that has now been captured, or cash collected on delivery. It records
+// OrderInvoiced so the buyer is sent the invoice. Call it in a transaction, so the number is
+// only taken if the invoice is stored; a no-op when the order has its invoice.
+func (s *orderService) invoiceLate(ctx context.Context, order *model.Order, userEmail string) error {
+	_, err := s.invoices.GetInvoice(ctx, order.ID, "")
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if _, err := s.invoices.IssueInvoice(ctx, order, userEmail); err != nil {
+		return fmt.Errorf("issue invoice: %w", err)
+	}
+	if err := s.outbox.Add(ctx, eventbus.OrderInvoiced{OrderPayload: orderPayload(order, userEmail)}); err != nil {
+		return fmt.Errorf("record order invoiced event: %w", err)
+	}
+	return nil
+}
+
+// commitReservations turns held units into sold ones: the product and warehouse counters drop
+// by each reservation's quantity, a commit movement is recorded and the reservations are
+// marked committed. Returns the committed product IDs for the low-stock check.
+func (s *orderService) commitReservations(
+	ctx context.Context,
+	orderID string,
+	reservations []*model.StockReservation,
+	actor, reason string,
+) ([]string, error) {
+	productIDs := make([]string, 0, len(reservations))
+	for _, res := range reservations {
+		if err := s.productRepo.CommitReservation(ctx, res.ProductID, res.Quantity); err != nil {
+			return nil, fmt.Errorf("commit reservation %s: %w", res.ID, err)
+		}
+		if res.WarehouseID != nil {
+			if err := s.warehouseRepo.Commit(ctx, *res.WarehouseID, res.ProductID, res.Quantity); err != nil {
+				return nil, fmt.Errorf("commit reservation %s: %w", res.ID, err)
+			}
+		}
+		if err := s.ledger.Record(ctx, stock.Movement{
+			ProductID:     res.ProductID,
+			Kind:          stock.MovementCommit,
+			StockDelta:    -res.Quantity,
+			ReservedDelta: -res.Quantity,
+			Actor:         actor,
+			Reason:        reason,
+			OrderID:       orderID,
+			ReservationID: res.ID,
+			WarehouseID:   warehouseOf(res),
+		}); err != nil {
+			return nil, fmt.Errorf("record stock commit: %w", err)
+		}
+		productIDs = append(productIDs, res.ProductID)
 	}
-	bus := s.bus
-	if bus == nil {
-		bus = eventbus.Default()
+	if err := s.reservationRepo.UpdateStatus(ctx, reservationIDs(reservations), model.ReservationStatusCommitted); err != nil {
+		return nil, err
 	}
+	return productIDs, nil
+}
+
+// recordLowStock records a LowStock event for each product whose available stock is at or
+// below its threshold. Runs inside the commit transaction, so it sees the decremented stock.
+// A failed lookup only skips that product's alert: the payment has already cleared.
+func (s *orderService) recordLowStock(ctx context.Context, productIDs []string) error {
 	for _, pid := range productIDs {
 		p, err := s.productRepo.GetProductByID(ctx, pid)
 		if err != nil {
@@ -293,18 +556,25 @@ func (s *orderService) publishLowStock(ctx context.Context, productIDs []string)
 			continue
 		}
 		available := p.StockQuantity - p.ReservedQuantity
-		if available <= LowStockThreshold {
-			bus.Publish(ctx, eventbus.LowStock{
-				ProductID: pid,
-				Available: available,
-				Threshold: LowStockThreshold,
-			})
+		threshold := p.EffectiveLowStockThreshold()
+		if !stock.IsLow(available, threshold) {
+			continue
+		}
+		if err := s.outbox.Add(ctx, eventbus.LowStock{
+			ProductID:       pid,
+			ProductName:     p.Name,
+			Available:       available,
+			Threshold:       threshold,
+			ReorderQuantity: p.EffectiveReorderQuantity(),
+		}); err != nil {
+			return fmt.Errorf("record low stock event: %w", err)
 		}
 	}
+	return nil
 }
 
 func (s *orderService) CancelOrder(ctx context.Context, orderID, userID string) (*model.Order, error) {
-	order, err := s.repo.GetOrderByID(ctx, orderID, false)
+	order, err := s.repo.GetOrderByID(ctx, orderID, true)
 	if err != nil {
 		return nil, err
 	}
@@ -313,18 +583,30 @@ func (s *orderService) CancelOrder(ctx context.Context, orderID, userID string)
 		return nil, apperror.ErrForbidden
 	}
 
+	// Cash-on-delivery stock is committed at placement, like a paid order's, so only an
+	// admin can cancel it.
 	if order.Status == model.OrderStatusDone ||
 		order.Status == model.OrderStatusCancelled ||
-		order.Status == model.OrderStatusPaid {
+		order.Status == model.OrderStatusPaid ||
+		order.Status == model.OrderStatusPartiallyShipped ||
+		order.Status == model.OrderStatusShipped ||
+		order.CashOnDelivery() {
 		return nil, apperror.ErrInvalidStatus
 	}
 
+	userEmail := s.userEmail(ctx, order.UserID)
 	txErr := s.db.WithTransaction(func() error {
-		if err := s.releaseActiveReservations(ctx, order.ID); err != nil {
+		if err := s.releaseActiveReservations(ctx, order.ID, userID); err != nil {
 			return err
 		}
 		order.Status = model.OrderStatusCancelled
-		return s.repo.UpdateOrder(ctx, order)
+		if err := s.repo.UpdateOrder(ctx, order); err != nil {
+			return err
+		}
+		return s.outbox.Add(ctx, eventbus.OrderCancelled{
+			OrderPayload: orderPayload(order, userEmail),
+			Reason:       CancelReasonCustomer,
+		})
 	})
 	if txErr != nil {
 		return nil, txErr
@@ -354,7 +636,7 @@ func (s *orderService) SweepExpiredReservations(ctx context.Context, batchSize i
 	for orderID, group := range byOrder {
 		orderMissing := false
 		txErr := s.db.WithTransaction(func() error {
-			order, err := s.repo.GetOrderByID(ctx, orderID, false)
+			order, err := s.repo.GetOrderByID(ctx, orderID, true)
 			if err != nil {
 				// Orphaned reservation: the order row was deleted (or soft-deleted) but the
 				// reservation lived on. Still release the held stock and mark the rows so
@@ -364,31 +646,59 @@ func (s *orderService) SweepExpiredReservations(ctx context.Context, batchSize i
 				} else {
 					return err
 				}
-			} else if order.Status != model.OrderStatusPendingPayment && order.Status != model.OrderStatusNew {
+			} else if order.Status != model.OrderStatusPendingPayment &&
+				order.Status != model.OrderStatusNew &&
+				order.Status != model.OrderStatusPaymentFailed {
 				// Paid / fulfilled order's reservations should have been committed already,
 				// not released. Skip without touching stock.
 				return nil
 			}
 			for _, r := range group {
-				if err := s.productRepo.ReleaseReservation(ctx, r.ProductID, r.Quantity); err != nil {
+				if err := s.releaseReservation(ctx, r); err != nil {
 					// Drift recovery: if the counter is already drained (re-seed, prior
 					// half-completed sweep, etc.) treat the release as done so we can still
-					// mark the reservation row 'released' and stop the error loop.
+					// mark the reservation row 'released' and stop the error loop. `api
+					// reconcile-reserved` resets the counter from the active reservations.
 					if !errors.Is(err, orderRepo.ErrReservationAlreadyReleased) {
 						return fmt.Errorf("release reservation %s: %w", r.ID, err)
 					}
 					logger.Warnf("sweep order %s: product %s reserved_quantity already drained, skipping release", orderID, r.ProductID)
+					continue
+				}
+				if err := s.ledger.Record(ctx, stock.Movement{
+					ProductID:     r.ProductID,
+					Kind:          stock.MovementExpire,
+					ReservedDelta: -r.Quantity,
+					Actor:         stock.ActorSystem,
+					Reason:        "reservation expired",
+					OrderID:       r.OrderID,
+					ReservationID: r.ID,
+					WarehouseID:   warehouseOf(r),
+				}); err != nil {
+					return fmt.Errorf("record stock release: %w", err)
 				}
 			}
 			ids := reservationIDs(group)
 			if err := s.reservationRepo.UpdateStatus(ctx, ids, model.ReservationStatusReleased); err != nil {
 				return err
 			}
-			if orderMissing {
+			// A failed payment stays open for a retry, which reserves the stock again.
+			if orderMissing || order.Status == model.OrderStatusPaymentFailed {
 				return nil
 			}
 			order.Status = model.OrderStatusCancelled
-			return s.repo.UpdateOrder(ctx, order)
+			if err := s.repo.UpdateOrder(ctx, order); err != nil {
+				return err
+			}
+			payload := orderPayload(order, s.userEmail(ctx, order.UserID))
+			if err := s.outbox.Add(ctx, eventbus.OrderReservationExpired{OrderPayload: payload}); err != nil {
+				return err
+			}
+			// Tell the buyer their reservation was released.
+			return s.outbox.Add(ctx, eventbus.OrderCancelled{
+				OrderPayload: payload,
+				Reason:       CancelReasonReservationExpired,
+			})
 		})
 		if txErr != nil {
 			logger.Errorf("sweep order %s: %s", orderID, txErr)
@@ -400,38 +710,264 @@ func (s *orderService) SweepExpiredReservations(ctx context.Context, batchSize i
 			continue
 		}
 		released += len(group)
+	}
+	return released, nil
+}
 
-		// Notify the buyer their reservation was released. Best-effort: failures only log.
-		go func(orderID string) {
-			order, err := s.repo.GetOrderByID(ctx, orderID, false)
+// ExtendReservations moves the expiry of the order's active reservations; only a pending
+// order still holds stock that can lapse.
+func (s *orderService) ExtendReservations(ctx context.Context, orderID string, until time.Time) error {
+	order, err := s.repo.GetOrderByID(ctx, orderID, false)
+	if err != nil {
+		return err
+	}
+	if order.Status != model.OrderStatusPendingPayment {
+		return apperror.ErrInvalidStatus
+	}
+	return s.reservationRepo.ExtendActive(ctx, orderID, until)
+}
+
+func (s *orderService) ReopenForPayment(ctx context.Context, orderID string) (*model.Order, error) {
+	order, err := s.repo.GetOrderByID(ctx, orderID, true)
+	if err != nil {
+		return nil, err
+	}
+	if order.Status != model.OrderStatusPaymentFailed && order.Status != model.OrderStatusPendingPayment {
+		return nil, apperror.ErrInvalidStatus
+	}
+
+	changed := order.Status != model.OrderStatusPendingPayment
+	userEmail := ""
+	if changed {
+		userEmail = s.userEmail(ctx, order.UserID)
+	}
+	// Stock is taken again for where the order ships, as at placement.
+	dest := stock.Destination{Country: order.ShippingAddress.Country, City: order.ShippingAddress.City}
+	expiresAt := time.Now().Add(ReservationTTL)
+	txErr := s.db.WithTransaction(func() error {
+		active, err := s.reservationRepo.FindActiveByOrderID(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		if err := s.reservationRepo.ExtendActive(ctx, order.ID, expiresAt); err != nil {
+			return err
+		}
+		// Reserve whatever the active reservations no longer cover, line by line.
+		held := make(map[string]int, len(active))
+		for _, r := range active {
+			held[r.ProductID] += r.Quantity
+		}
+		var reservations []*model.StockReservation
+		for _, line := range order.Lines {
+			qty := int(line.Quantity) //nolint:gosec // bounded by validation (uint qty)
+			covered := min(held[line.ProductID], qty)
+			held[line.ProductID] -= covered
+			if qty == covered {
+				continue
+			}
+			more, err := s.reserveLine(ctx, order.ID, line.ProductID, qty-covered, dest, expiresAt)
 			if err != nil {
-				return
+				return err
 			}
-			user, err := s.userRepo.GetUserByID(ctx, order.UserID)
-			if err != nil || user == nil {
-				return
+			reservations = append(reservations, more...)
+		}
+		if len(reservations) > 0 {
+			if err := s.reservationRepo.CreateMany(ctx, reservations); err != nil {
+				return fmt.Errorf("persist reservations: %w", err)
 			}
-			if err := s.notifier.SendOrderStatusChanged(ctx, order.ID, user.Email, string(model.OrderStatusCancelled)); err != nil {
-				logger.Errorf("sweep notify %s: %s", order.ID, err)
+		}
+		for _, res := range reservations {
+			if err := s.ledger.Record(ctx, stock.Movement{
+				ProductID:     res.ProductID,
+				Kind:          stock.MovementReserve,
+				ReservedDelta: res.Quantity,
+				Actor:         order.UserID,
+				Reason:        "payment retry",
+				OrderID:       order.ID,
+				ReservationID: res.ID,
+				WarehouseID:   warehouseOf(res),
+			}); err != nil {
+				return fmt.Errorf("record stock reservation: %w", err)
 			}
-		}(orderID)
+		}
+
+		if !changed {
+			return nil
+		}
+		order.Status = model.OrderStatusPendingPayment
+		if err := s.repo.UpdateOrder(ctx, order); err != nil {
+			return err
+		}
+		return s.outbox.Add(ctx, statusEvent(order, userEmail, ""))
+	})
+	if txErr != nil {
+		return nil, txErr
 	}
-	return released, nil
+	return order, nil
+}
+
+// userEmail resolves the address carried on order events. A failed lookup leaves it empty
+// rather than failing the state change; email subscribers skip events they can't address.
+func (s *orderService) userEmail(ctx context.Context, userID string) string {
+	return lookupUserEmail(ctx, s.userRepo, userID)
+}
+
+// lookupUserEmail returns where to reach the user for an event, or "" when the lookup fails:
+// the event is still recorded, only its emails are skipped.
+func lookupUserEmail(ctx context.Context, users orderRepo.UserRepository, userID string) string {
+	user, err := users.GetUserByID(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get user for order event: ", err)
+		return ""
+	}
+	if user == nil {
+		return ""
+	}
+	return user.Email
 }
 
-func (s *orderService) releaseActiveReservations(ctx context.Context, orderID string) error {
+func (s *orderService) releaseActiveReservations(ctx context.Context, orderID, actor string) error {
 	reservations, err := s.reservationRepo.FindActiveByOrderID(ctx, orderID)
 	if err != nil {
 		return err
 	}
 	for _, res := range reservations {
-		if err := s.productRepo.ReleaseReservation(ctx, res.ProductID, res.Quantity); err != nil {
+		if err := s.releaseReservation(ctx, res); err != nil {
 			return fmt.Errorf("release reservation %s: %w", res.ID, err)
 		}
+		if err := s.ledger.Record(ctx, stock.Movement{
+			ProductID:     res.ProductID,
+			Kind:          stock.MovementRelease,
+			ReservedDelta: -res.Quantity,
+			Actor:         actor,
+			Reason:        "order cancelled",
+			OrderID:       orderID,
+			ReservationID: res.ID,
+			WarehouseID:   warehouseOf(res),
+		}); err != nil {
+			return fmt.Errorf("record stock release: %w", err)
+		}
+	}
+	return s.reservationRepo.UpdateStatus(ctx, reservationIDs(reservations), model.ReservationStatusReleased)
+}
+
+// returnCommittedStock puts the units the order's committed reservations took back on hand,
+// at the product and at each warehouse, and marks those reservations released.
+func (s *orderService) returnCommittedStock(ctx context.Context, orderID, actor string) error {
+	reservations, err := s.reservationRepo.FindCommittedByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	for _, res := range reservations {
+		if err := s.productRepo.ReturnCommitted(ctx, res.ProductID, res.Quantity); err != nil {
+			return fmt.Errorf("return reservation %s: %w", res.ID, err)
+		}
+		if res.WarehouseID != nil {
+			if err := s.warehouseRepo.ReturnCommitted(ctx, *res.WarehouseID, res.ProductID, res.Quantity); err != nil {
+				return fmt.Errorf("return reservation %s: %w", res.ID, err)
+			}
+		}
+		if err := s.ledger.Record(ctx, stock.Movement{
+			ProductID:     res.ProductID,
+			Kind:          stock.MovementReturn,
+			StockDelta:    res.Quantity,
+			Actor:         actor,
+			Reason:        "order cancelled",
+			OrderID:       orderID,
+			ReservationID: res.ID,
+			WarehouseID:   warehouseOf(res),
+		}); err != nil {
+			return fmt.Errorf("record stock return: %w", err)
+		}
 	}
 	return s.reservationRepo.UpdateStatus(ctx, reservationIDs(reservations), model.ReservationStatusReleased)
 }
 
+// reserveLine holds qty units of a product for an order. The product total is reserved
+// first, which locks the product row, then the units are split across warehouses by
+// s.allocation, one reservation per warehouse drawn from.
+func (s *orderService) reserveLine(
+	ctx context.Context,
+	orderID, productID string,
+	qty int,
+	dest stock.Destination,
+	expiresAt time.Time,
+) ([]*model.StockReservation, error) {
+	insufficient := &InsufficientStockError{ProductID: productID, Requested: qty}
+	if err := s.productRepo.ReserveStock(ctx, productID, qty); err != nil {
+		if errors.Is(err, orderRepo.ErrInsufficientStock) {
+			return nil, insufficient
+		}
+		return nil, fmt.Errorf("reserve stock for product %s: %w", productID, err)
+	}
+
+	stocks, err := s.warehouseRepo.ListStock(ctx, productID)
+	if err != nil {
+		return nil, fmt.Errorf("list warehouse stock for product %s: %w", productID, err)
+	}
+	locations := make([]stock.Location, 0, len(stocks))
+	for _, ws := range stocks {
+		loc := stock.Location{WarehouseID: ws.WarehouseID, Available: ws.StockQuantity - ws.ReservedQuantity}
+		if ws.Warehouse != nil {
+			loc.Country = ws.Warehouse.Country
+			loc.City = ws.Warehouse.City
+			loc.Priority = ws.Warehouse.Priority
+		}
+		locations = append(locations, loc)
+	}
+	// The product total can cover qty while the active warehouses can't, e.g. when stock
+	// sits in a deactivated warehouse.
+	plan, ok := stock.Allocate(locations, qty, dest, s.allocation)
+	if !ok {
+		return nil, insufficient
+	}
+
+	reservations := make([]*model.StockReservation, 0, len(plan))
+	for _, a := range plan {
+		if err := s.warehouseRepo.Reserve(ctx, a.WarehouseID, productID, a.Quantity); err != nil {
+			if errors.Is(err, orderRepo.ErrInsufficientStock) {
+				return nil, insufficient
+			}
+			return nil, fmt.Errorf("reserve stock for product %s at warehouse %s: %w", productID, a.WarehouseID, err)
+		}
+		warehouseID := a.WarehouseID
+		reservations = append(reservations, &model.StockReservation{
+			OrderID:     orderID,
+			ProductID:   productID,
+			WarehouseID: &warehouseID,
+			Quantity:    a.Quantity,
+			Status:      model.ReservationStatusActive,
+			ExpiresAt:   expiresAt,
+		})
+	}
+	return reservations, nil
+}
+
+// releaseReservation returns a reservation's units to available stock, in the product total
+// and at its warehouse. A drained warehouse counter is only logged: the product total is what
+// gates availability, and it has been released.
+func (s *orderService) releaseReservation(ctx context.Context, r *model.StockReservation) error {
+	if err := s.productRepo.ReleaseReservation(ctx, r.ProductID, r.Quantity); err != nil {
+		return err
+	}
+	if r.WarehouseID == nil {
+		return nil
+	}
+	err := s.warehouseRepo.Release(ctx, *r.WarehouseID, r.ProductID, r.Quantity)
+	if errors.Is(err, orderRepo.ErrReservationAlreadyReleased) {
+		logger.Warnf("release reservation %s: warehouse %s reserved_quantity already drained", r.ID, *r.WarehouseID)
+		return nil
+	}
+	return err
+}
+
+func warehouseOf(r *model.StockReservation) string {
+	if r.WarehouseID == nil {
+		return ""
+	}
+	return *r.WarehouseID
+}
+
 func reservationIDs(rs []*model.StockReservation) []string {
 	ids := make([]string, 0, len(rs))
 	for _, r := range rs {
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"testing"
-	"time"
 
 	"github.com/quangdangfit/gocommon/logger"
 	"github.com/quangdangfit/gocommon/validation"
@@ -17,8 +16,10 @@ import (
 	serviceMocks "goshop/internal/order/service/mocks"
 	"goshop/pkg/config"
 	dbsMocks "goshop/pkg/dbs/mocks"
-	notifMocks "goshop/pkg/notification/mocks"
+	"goshop/pkg/eventbus"
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
+	"goshop/pkg/stock"
 )
 
 type OrderServiceTestSuite struct {
@@ -29,7 +30,10 @@ type OrderServiceTestSuite struct {
 	mockUserRepo        *orderMocks.UserRepository
 	mockReservationRepo *orderMocks.ReservationRepository
 	mockCouponSvc       *serviceMocks.CouponService
-	mockNotifier        *notifMocks.Notifier
+	mockOutbox          *serviceMocks.EventOutbox
+	mockLedger          *serviceMocks.StockLedger
+	mockWarehouseRepo   *orderMocks.WarehouseRepository
+	mockPayments        *serviceMocks.PaymentSettler
 	service             OrderService
 }
 
@@ -43,9 +47,14 @@ func (suite *OrderServiceTestSuite) SetupTest() {
 	suite.mockUserRepo = orderMocks.NewUserRepository(suite.T())
 	suite.mockReservationRepo = orderMocks.NewReservationRepository(suite.T())
 	suite.mockCouponSvc = serviceMocks.NewCouponService(suite.T())
-	suite.mockNotifier = notifMocks.NewNotifier(suite.T())
+	suite.mockOutbox = serviceMocks.NewEventOutbox(suite.T())
+	suite.mockLedger = serviceMocks.NewStockLedger(suite.T())
+	suite.mockWarehouseRepo = orderMocks.NewWarehouseRepository(suite.T())
+	suite.mockPayments = serviceMocks.NewPaymentSettler(suite.T())
 	// WithTransaction is a thin pass-through in tests — invoke the function and surface its error.
 	suite.mockDB.On("WithTransaction", mock.Anything).Return(func(fn func() error) error { return fn() }).Maybe()
+	// Buyers have no default address unless a test says otherwise; allocation then ranks by priority.
+	suite.mockUserRepo.On("GetDefaultAddress", mock.Anything, mock.Anything).Return(nil, nil).Maybe()
 	suite.service = NewOrderService(
 		validator,
 		suite.mockDB,
@@ -54,10 +63,25 @@ func (suite *OrderServiceTestSuite) SetupTest() {
 		suite.mockUserRepo,
 		suite.mockReservationRepo,
 		suite.mockCouponSvc,
-		suite.mockNotifier,
+		suite.mockOutbox,
+		suite.mockLedger,
+		suite.mockWarehouseRepo,
+		stock.AllocateNearest,
+		testRates,
+		newTestShipping(suite.T(), nil),
+		newTestTax(suite.T(), nil),
+		testInvoices(suite.T()),
+		suite.mockPayments,
 	)
 }
 
+// expectWarehouseReserve wires a single-warehouse allocation of qty units of productID at w1.
+func (suite *OrderServiceTestSuite) expectWarehouseReserve(productID string, qty int) {
+	suite.mockWarehouseRepo.On("ListStock", mock.Anything, productID).
+		Return([]*model.WarehouseStock{{WarehouseID: "w1", Warehouse: &model.Warehouse{ID: "w1"}, ProductID: productID, StockQuantity: 10}}, nil).Times(1)
+	suite.mockWarehouseRepo.On("Reserve", mock.Anything, "w1", productID, qty).Return(nil).Times(1)
+}
+
 func TestOrderServiceTestSuite(t *testing.T) {
 	suite.Run(t, new(OrderServiceTestSuite))
 }
@@ -72,8 +96,34 @@ func (suite *OrderServiceTestSuite) TestGetOrderByID() {
 			name: "Success",
 			setup: func() {
 				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
-					Return(&model.Order{UserID: "userID", TotalPrice: 111.1, Status: model.OrderStatusInProgress}, nil).Times(1)
+					Return(&model.Order{UserID: "userID", TotalPrice: money.New(11110, "USD"), Status: model.OrderStatusInProgress}, nil).Times(1)
+				suite.mockRepo.On("ListPaymentAttempts", mock.Anything, "orderID").
+					Return([]*model.PaymentAttempt{{Attempt: 1, Status: "failed"}, {Attempt: 2, Status: "succeeded"}}, nil).Times(1)
+				suite.mockRepo.On("ListShipments", mock.Anything, "orderID").
+					Return([]*model.Shipment{{ID: "s1", Status: model.ShipmentStatusPacked}}, nil).Times(1)
+			},
+		},
+		{
+			name: "Payment attempts error",
+			setup: func() {
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
+					Return(&model.Order{UserID: "userID"}, nil).Times(1)
+				suite.mockRepo.On("ListPaymentAttempts", mock.Anything, "orderID").
+					Return(nil, errors.New("error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Shipments error",
+			setup: func() {
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
+					Return(&model.Order{UserID: "userID"}, nil).Times(1)
+				suite.mockRepo.On("ListPaymentAttempts", mock.Anything, "orderID").
+					Return(nil, nil).Times(1)
+				suite.mockRepo.On("ListShipments", mock.Anything, "orderID").
+					Return(nil, errors.New("error")).Times(1)
 			},
+			wantErr: true,
 		},
 		{
 			name: "Not found",
@@ -95,7 +145,9 @@ func (suite *OrderServiceTestSuite) TestGetOrderByID() {
 			} else {
 				suite.NotNil(order)
 				suite.Equal("userID", order.UserID)
-				suite.Equal(111.1, order.TotalPrice)
+				suite.Equal(money.New(11110, "USD"), order.TotalPrice)
+				suite.Len(order.PaymentAttempts, 2)
+				suite.Len(order.Shipments, 1)
 				suite.Nil(err)
 			}
 		})
@@ -112,7 +164,7 @@ func (suite *OrderServiceTestSuite) TestGetMyOrders() {
 			name: "Success",
 			setup: func() {
 				suite.mockRepo.On("GetMyOrders", mock.Anything, mock.Anything).
-					Return([]*model.Order{{UserID: "userID", TotalPrice: 111.2, Status: model.OrderStatusNew}},
+					Return([]*model.Order{{UserID: "userID", TotalPrice: money.New(11120, "USD"), Status: model.OrderStatusNew}},
 						&paging.Pagination{Total: 1, CurrentPage: 1, Limit: 10}, nil).Times(1)
 			},
 		},
@@ -146,18 +198,48 @@ func (suite *OrderServiceTestSuite) TestGetMyOrders() {
 }
 
 func (suite *OrderServiceTestSuite) TestPlaceOrder() {
+	// userLookup wires the email lookup for the OrderCreated event, done once the order is priced.
+	userLookup := func() {
+		suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
+			Return(&model.User{ID: "userID", Email: "user@test.com"}, nil).Times(1)
+	}
+	// orderCreated is the event for the order CreateOrder returns below.
+	orderCreated := func(email string) eventbus.OrderCreated {
+		return eventbus.OrderCreated{OrderPayload: eventbus.OrderPayload{
+			OrderID:     "orderID",
+			UserID:      "userID",
+			UserEmail:   email,
+			Status:      string(model.OrderStatusPendingPayment),
+			ShippingFee: money.Zero("USD"),
+			TaxAmount:   money.Zero("USD"),
+			Lines:       []eventbus.OrderLine{{ProductID: "productID", Quantity: 2}},
+		}}
+	}
+	// reserveRecorded expects the ledger row for the productID×qty=2 reservation.
+	reserveRecorded := func() {
+		suite.mockLedger.On("Record", mock.Anything, stock.Movement{
+			ProductID:     "productID",
+			Kind:          stock.MovementReserve,
+			ReservedDelta: 2,
+			Actor:         "userID",
+			Reason:        "order placed",
+			OrderID:       "orderID",
+			WarehouseID:   "w1",
+		}).Return(nil).Times(1)
+	}
 	// happyPath wires the common mocks for a successful PlaceOrder for one productID×qty=2 line.
-	happyPath := func(couponCode string, discount float64) {
+	happyPath := func(couponCode string, discount money.Money) {
 		suite.mockProductRepo.On("GetProductByID", mock.Anything, "productID").
-			Return(&model.Product{Name: "product", Price: 10.0}, nil).Times(1)
+			Return(&model.Product{Name: "product", Price: money.New(1000, "USD")}, nil).Times(1)
 		suite.mockRepo.On("CreateOrder", mock.Anything, "userID", mock.Anything, couponCode, discount).
 			Return(&model.Order{ID: "orderID", UserID: "userID", Lines: []*model.OrderLine{{ProductID: "productID", Quantity: 2}}}, nil).Times(1)
 		suite.mockRepo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Times(1)
 		suite.mockProductRepo.On("ReserveStock", mock.Anything, "productID", 2).Return(nil).Times(1)
+		suite.expectWarehouseReserve("productID", 2)
 		suite.mockReservationRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Times(1)
-		suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
-			Return(&model.User{ID: "userID", Email: "user@test.com"}, nil).Maybe()
-		suite.mockNotifier.On("SendOrderPlaced", mock.Anything, mock.Anything, "user@test.com").Return(nil).Maybe()
+		reserveRecorded()
+		userLookup()
+		suite.mockOutbox.On("Add", mock.Anything, orderCreated("user@test.com")).Return(nil).Times(1)
 	}
 
 	tests := []struct {
@@ -165,7 +247,6 @@ func (suite *OrderServiceTestSuite) TestPlaceOrder() {
 		req     *domain.PlaceOrderReq
 		setup   func()
 		wantErr bool
-		sleep   bool
 	}{
 		{
 			name: "Success",
@@ -173,7 +254,7 @@ func (suite *OrderServiceTestSuite) TestPlaceOrder() {
 				UserID: "userID",
 				Lines:  []domain.PlaceOrderLineReq{{ProductID: "productID", Quantity: 2}},
 			},
-			setup: func() { happyPath("", 0) },
+			setup: func() { happyPath("", money.New(0, "USD")) },
 		},
 		{
 			name: "GetProductByID fail",
@@ -203,8 +284,9 @@ func (suite *OrderServiceTestSuite) TestPlaceOrder() {
 			},
 			setup: func() {
 				suite.mockProductRepo.On("GetProductByID", mock.Anything, "productID").
-					Return(&model.Product{Name: "product", Price: 1.1}, nil).Times(1)
-				suite.mockRepo.On("CreateOrder", mock.Anything, "userID", mock.Anything, "", float64(0)).
+					Return(&model.Product{Name: "product", Price: money.New(110, "USD")}, nil).Times(1)
+				userLookup()
+				suite.mockRepo.On("CreateOrder", mock.Anything, "userID", mock.Anything, "", money.Zero("USD")).
 					Return(nil, errors.New("error")).Times(1)
 			},
 			wantErr: true,
@@ -216,10 +298,10 @@ func (suite *OrderServiceTestSuite) TestPlaceOrder() {
 				Lines: []domain.PlaceOrderLineReq{{ProductID: "productID", Quantity: 2}},
 			},
 			setup: func() {
-				suite.mockCouponSvc.On("Apply", mock.Anything, "SAVE10", float64(20)).
-					Return(float64(2), &model.Coupon{ID: "c1", Code: "SAVE10"}, nil).Times(1)
+				suite.mockCouponSvc.On("Apply", mock.Anything, "SAVE10", money.New(2000, "USD")).
+					Return(money.New(200, "USD"), &model.Coupon{ID: "c1", Code: "SAVE10", DiscountType: model.DiscountTypePercentage, DiscountPercent: 1000}, nil).Times(1)
 				suite.mockCouponSvc.On("IncrUsedCount", mock.Anything, "c1").Return(nil).Times(1)
-				happyPath("SAVE10", float64(2))
+				happyPath("SAVE10", money.New(200, "USD"))
 			},
 		},
 		{
@@ -230,9 +312,9 @@ func (suite *OrderServiceTestSuite) TestPlaceOrder() {
 			},
 			setup: func() {
 				suite.mockProductRepo.On("GetProductByID", mock.Anything, "productID").
-					Return(&model.Product{Name: "product", Price: 10.0}, nil).Times(1)
-				suite.mockCouponSvc.On("Apply", mock.Anything, "INVALID", float64(20)).
-					Return(float64(0), (*model.Coupon)(nil), errors.New("coupon not found")).Times(1)
+					Return(&model.Product{Name: "product", Price: money.New(1000, "USD")}, nil).Times(1)
+				suite.mockCouponSvc.On("Apply", mock.Anything, "INVALID", money.New(2000, "USD")).
+					Return(money.Zero("USD"), (*model.Coupon)(nil), errors.New("coupon not found")).Times(1)
 			},
 			wantErr: true,
 		},
@@ -245,8 +327,9 @@ func (suite *OrderServiceTestSuite) TestPlaceOrder() {
 			},
 			setup: func() {
 				suite.mockProductRepo.On("GetProductByID", mock.Anything, "productID").
-					Return(&model.Product{Name: "product", Price: 1.1}, nil).Times(1)
-				suite.mockRepo.On("CreateOrder", mock.Anything, "userID", mock.Anything, "", float64(0)).
+					Return(&model.Product{Name: "product", Price: money.New(110, "USD")}, nil).Times(1)
+				userLookup()
+				suite.mockRepo.On("CreateOrder", mock.Anything, "userID", mock.Anything, "", money.Zero("USD")).
 					Return(&model.Order{ID: "orderID", UserID: "userID", Lines: []*model.OrderLine{{ProductID: "productID", Quantity: 2}}}, nil).Times(1)
 				suite.mockRepo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Times(1)
 				suite.mockProductRepo.On("ReserveStock", mock.Anything, "productID", 2).Return(errors.New("insufficient")).Times(1)
@@ -260,36 +343,61 @@ func (suite *OrderServiceTestSuite) TestPlaceOrder() {
 			},
 			setup: func() {
 				suite.mockProductRepo.On("GetProductByID", mock.Anything, "productID").
-					Return(&model.Product{Name: "product", Price: 10.0}, nil).Times(1)
-				suite.mockCouponSvc.On("Apply", mock.Anything, "SAVE10", float64(20)).
-					Return(float64(2), &model.Coupon{ID: "c1", Code: "SAVE10"}, nil).Times(1)
-				suite.mockRepo.On("CreateOrder", mock.Anything, "userID", mock.Anything, "SAVE10", float64(2)).
+					Return(&model.Product{Name: "product", Price: money.New(1000, "USD")}, nil).Times(1)
+				suite.mockCouponSvc.On("Apply", mock.Anything, "SAVE10", money.New(2000, "USD")).
+					Return(money.New(200, "USD"), &model.Coupon{ID: "c1", Code: "SAVE10", DiscountType: model.DiscountTypePercentage, DiscountPercent: 1000}, nil).Times(1)
+				userLookup()
+				suite.mockRepo.On("CreateOrder", mock.Anything, "userID", mock.Anything, "SAVE10", money.New(200, "USD")).
 					Return(&model.Order{ID: "orderID", UserID: "userID", Lines: []*model.OrderLine{{ProductID: "productID", Quantity: 2}}}, nil).Times(1)
 				suite.mockRepo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Times(1)
 				suite.mockProductRepo.On("ReserveStock", mock.Anything, "productID", 2).Return(nil).Times(1)
+				suite.expectWarehouseReserve("productID", 2)
 				suite.mockReservationRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Times(1)
+				reserveRecorded()
 				suite.mockCouponSvc.On("IncrUsedCount", mock.Anything, "c1").Return(errors.New("incr error")).Times(1)
 			},
 			wantErr: true,
 		},
 		{
-			name: "GetUser for notification fail",
+			name:    "Ledger fail rolls back",
+			wantErr: true,
 			req: &domain.PlaceOrderReq{
 				UserID: "userID",
 				Lines:  []domain.PlaceOrderLineReq{{ProductID: "productID", Quantity: 2}},
 			},
 			setup: func() {
 				suite.mockProductRepo.On("GetProductByID", mock.Anything, "productID").
-					Return(&model.Product{Name: "product", Price: 1.1}, nil).Times(1)
-				suite.mockRepo.On("CreateOrder", mock.Anything, "userID", mock.Anything, "", float64(0)).
+					Return(&model.Product{Name: "product", Price: money.New(110, "USD")}, nil).Times(1)
+				userLookup()
+				suite.mockRepo.On("CreateOrder", mock.Anything, "userID", mock.Anything, "", money.Zero("USD")).
 					Return(&model.Order{ID: "orderID", UserID: "userID", Lines: []*model.OrderLine{{ProductID: "productID", Quantity: 2}}}, nil).Times(1)
 				suite.mockRepo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Times(1)
 				suite.mockProductRepo.On("ReserveStock", mock.Anything, "productID", 2).Return(nil).Times(1)
+				suite.expectWarehouseReserve("productID", 2)
 				suite.mockReservationRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Times(1)
+				suite.mockLedger.On("Record", mock.Anything, mock.Anything).Return(errors.New("db")).Times(1)
+			},
+		},
+		{
+			name: "GetUser for event fail still places order",
+			req: &domain.PlaceOrderReq{
+				UserID: "userID",
+				Lines:  []domain.PlaceOrderLineReq{{ProductID: "productID", Quantity: 2}},
+			},
+			setup: func() {
+				suite.mockProductRepo.On("GetProductByID", mock.Anything, "productID").
+					Return(&model.Product{Name: "product", Price: money.New(110, "USD")}, nil).Times(1)
 				suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
 					Return(nil, errors.New("user not found")).Times(1)
+				suite.mockRepo.On("CreateOrder", mock.Anything, "userID", mock.Anything, "", money.Zero("USD")).
+					Return(&model.Order{ID: "orderID", UserID: "userID", Lines: []*model.OrderLine{{ProductID: "productID", Quantity: 2}}}, nil).Times(1)
+				suite.mockRepo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Times(1)
+				suite.mockProductRepo.On("ReserveStock", mock.Anything, "productID", 2).Return(nil).Times(1)
+				suite.expectWarehouseReserve("productID", 2)
+				suite.mockReservationRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Times(1)
+				reserveRecorded()
+				suite.mockOutbox.On("Add", mock.Anything, orderCreated("")).Return(nil).Times(1)
 			},
-			sleep: true,
 		},
 	}
 	for _, tc := range tests {
@@ -304,9 +412,6 @@ func (suite *OrderServiceTestSuite) TestPlaceOrder() {
 				suite.NotNil(order)
 				suite.Nil(err)
 			}
-			if tc.sleep {
-				time.Sleep(20 * time.Millisecond)
-			}
 		})
 	}
 }
@@ -320,28 +425,58 @@ func (suite *OrderServiceTestSuite) TestCancelOrder() {
 		{
 			name: "Success",
 			setup: func() {
-				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", false).
-					Return(&model.Order{UserID: "userID", TotalPrice: 111.1, Status: model.OrderStatusInProgress}, nil).Times(1)
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
+					Return(&model.Order{UserID: "userID", TotalPrice: money.New(11110, "USD"), Status: model.OrderStatusInProgress}, nil).Times(1)
 				suite.mockReservationRepo.On("FindActiveByOrderID", mock.Anything, mock.Anything).
 					Return([]*model.StockReservation{}, nil).Times(1)
 				suite.mockReservationRepo.On("UpdateStatus", mock.Anything, mock.Anything, model.ReservationStatusReleased).
 					Return(nil).Times(1)
+				suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
+					Return(&model.User{ID: "userID", Email: "user@test.com"}, nil).Times(1)
 				suite.mockRepo.On("UpdateOrder", mock.Anything, &model.Order{
-					UserID: "userID", TotalPrice: 111.1, Status: model.OrderStatusCancelled,
+					UserID: "userID", TotalPrice: money.New(11110, "USD"), Status: model.OrderStatusCancelled,
 				}).Return(nil).Times(1)
+				suite.mockOutbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCancelled")).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "Releases reservations into the ledger",
+			setup: func() {
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
+					Return(&model.Order{ID: "orderID", UserID: "userID", Status: model.OrderStatusPendingPayment}, nil).Times(1)
+				suite.mockReservationRepo.On("FindActiveByOrderID", mock.Anything, "orderID").
+					Return([]*model.StockReservation{{ID: "r1", ProductID: "p1", Quantity: 3}}, nil).Times(1)
+				suite.mockProductRepo.On("ReleaseReservation", mock.Anything, "p1", 3).Return(nil).Times(1)
+				suite.mockLedger.On("Record", mock.Anything, stock.Movement{
+					ProductID:     "p1",
+					Kind:          stock.MovementRelease,
+					ReservedDelta: -3,
+					Actor:         "userID",
+					Reason:        "order cancelled",
+					OrderID:       "orderID",
+					ReservationID: "r1",
+				}).Return(nil).Times(1)
+				suite.mockReservationRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusReleased).
+					Return(nil).Times(1)
+				suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
+					Return(&model.User{ID: "userID", Email: "user@test.com"}, nil).Times(1)
+				suite.mockRepo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Times(1)
+				suite.mockOutbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCancelled")).Return(nil).Times(1)
 			},
 		},
 		{
 			name: "Update fail",
 			setup: func() {
-				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", false).
-					Return(&model.Order{UserID: "userID", TotalPrice: 111.1, Status: model.OrderStatusInProgress}, nil).Times(1)
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
+					Return(&model.Order{UserID: "userID", TotalPrice: money.New(11110, "USD"), Status: model.OrderStatusInProgress}, nil).Times(1)
 				suite.mockReservationRepo.On("FindActiveByOrderID", mock.Anything, mock.Anything).
 					Return([]*model.StockReservation{}, nil).Times(1)
 				suite.mockReservationRepo.On("UpdateStatus", mock.Anything, mock.Anything, model.ReservationStatusReleased).
 					Return(nil).Times(1)
+				suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
+					Return(&model.User{ID: "userID", Email: "user@test.com"}, nil).Times(1)
 				suite.mockRepo.On("UpdateOrder", mock.Anything, &model.Order{
-					UserID: "userID", TotalPrice: 111.1, Status: model.OrderStatusCancelled,
+					UserID: "userID", TotalPrice: money.New(11110, "USD"), Status: model.OrderStatusCancelled,
 				}).Return(errors.New("error")).Times(1)
 			},
 			wantErr: true,
@@ -349,23 +484,23 @@ func (suite *OrderServiceTestSuite) TestCancelOrder() {
 		{
 			name: "Different user",
 			setup: func() {
-				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", false).
-					Return(&model.Order{UserID: "userID1", TotalPrice: 111.1, Status: model.OrderStatusInProgress}, nil).Times(1)
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
+					Return(&model.Order{UserID: "userID1", TotalPrice: money.New(11110, "USD"), Status: model.OrderStatusInProgress}, nil).Times(1)
 			},
 			wantErr: true,
 		},
 		{
 			name: "Invalid status",
 			setup: func() {
-				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", false).
-					Return(&model.Order{UserID: "userID", TotalPrice: 111.1, Status: model.OrderStatusCancelled}, nil).Times(1)
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
+					Return(&model.Order{UserID: "userID", TotalPrice: money.New(11110, "USD"), Status: model.OrderStatusCancelled}, nil).Times(1)
 			},
 			wantErr: true,
 		},
 		{
 			name: "GetOrderByID fail",
 			setup: func() {
-				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", false).
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
 					Return(nil, errors.New("error")).Times(1)
 			},
 			wantErr: true,
@@ -389,30 +524,38 @@ func (suite *OrderServiceTestSuite) TestCancelOrder() {
 }
 
 func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
+	statusChanged := func(email string) eventbus.OrderDone {
+		return eventbus.OrderDone{OrderPayload: eventbus.OrderPayload{
+			OrderID:   "orderID",
+			UserID:    "userID",
+			UserEmail: email,
+			Status:    string(model.OrderStatusDone),
+			Lines:     []eventbus.OrderLine{},
+		}}
+	}
+
 	tests := []struct {
 		name    string
 		setup   func()
 		wantErr bool
-		sleep   bool
 	}{
 		{
 			name: "Success",
 			setup: func() {
-				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", false).
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
 					Return(&model.Order{ID: "orderID", UserID: "userID", Status: model.OrderStatusInProgress}, nil).Times(1)
+				suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
+					Return(&model.User{ID: "userID", Email: "user@test.com"}, nil).Times(1)
 				suite.mockRepo.On("UpdateOrder", mock.Anything, &model.Order{
 					ID: "orderID", UserID: "userID", Status: model.OrderStatusDone,
 				}).Return(nil).Times(1)
-				suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
-					Return(&model.User{ID: "userID", Email: "user@test.com"}, nil).Maybe()
-				suite.mockNotifier.On("SendOrderStatusChanged", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
-					Return(nil).Maybe()
+				suite.mockOutbox.On("Add", mock.Anything, statusChanged("user@test.com")).Return(nil).Times(1)
 			},
 		},
 		{
 			name: "GetOrder fail",
 			setup: func() {
-				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", false).
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
 					Return(nil, errors.New("not found")).Times(1)
 			},
 			wantErr: true,
@@ -420,8 +563,10 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 		{
 			name: "Update fail",
 			setup: func() {
-				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", false).
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
 					Return(&model.Order{ID: "orderID", UserID: "userID", Status: model.OrderStatusInProgress}, nil).Times(1)
+				suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
+					Return(&model.User{ID: "userID", Email: "user@test.com"}, nil).Times(1)
 				suite.mockRepo.On("UpdateOrder", mock.Anything, &model.Order{
 					ID: "orderID", UserID: "userID", Status: model.OrderStatusDone,
 				}).Return(errors.New("db error")).Times(1)
@@ -429,32 +574,32 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 			wantErr: true,
 		},
 		{
-			name: "GetUser fail (goroutine)",
+			name: "GetUser fail still updates",
 			setup: func() {
-				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", false).
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
 					Return(&model.Order{ID: "orderID", UserID: "userID", Status: model.OrderStatusInProgress}, nil).Times(1)
+				suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
+					Return(nil, errors.New("user not found")).Times(1)
 				suite.mockRepo.On("UpdateOrder", mock.Anything, &model.Order{
 					ID: "orderID", UserID: "userID", Status: model.OrderStatusDone,
 				}).Return(nil).Times(1)
-				suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
-					Return(nil, errors.New("user not found")).Times(1)
+				suite.mockOutbox.On("Add", mock.Anything, statusChanged("")).Return(nil).Times(1)
 			},
-			sleep: true,
 		},
 		{
-			name: "Send notification fail (goroutine)",
+			name: "Outbox fail rolls back",
 			setup: func() {
-				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", false).
+				suite.mockRepo.On("GetOrderByID", mock.Anything, "orderID", true).
 					Return(&model.Order{ID: "orderID", UserID: "userID", Status: model.OrderStatusInProgress}, nil).Times(1)
+				suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
+					Return(&model.User{ID: "userID", Email: "user@test.com"}, nil).Times(1)
 				suite.mockRepo.On("UpdateOrder", mock.Anything, &model.Order{
 					ID: "orderID", UserID: "userID", Status: model.OrderStatusDone,
 				}).Return(nil).Times(1)
-				suite.mockUserRepo.On("GetUserByID", mock.Anything, "userID").
-					Return(&model.User{ID: "userID", Email: "user@test.com"}, nil).Times(1)
-				suite.mockNotifier.On("SendOrderStatusChanged", mock.Anything, mock.Anything, "user@test.com", string(model.OrderStatusDone)).
-					Return(errors.New("notif error")).Times(1)
+				suite.mockOutbox.On("Add", mock.Anything, statusChanged("user@test.com")).
+					Return(errors.New("db error")).Times(1)
 			},
-			sleep: true,
+			wantErr: true,
 		},
 	}
 	for _, tc := range tests {
@@ -470,9 +615,6 @@ func (suite *OrderServiceTestSuite) TestUpdateOrderStatus() {
 				suite.Equal(model.OrderStatusDone, order.Status)
 				suite.Nil(err)
 			}
-			if tc.sleep {
-				time.Sleep(20 * time.Millisecond)
-			}
 		})
 	}
 }
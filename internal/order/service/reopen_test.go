@@ -0,0 +1,90 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"goshop/internal/order/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/stock"
+)
+
+func failedOrder() *model.Order {
+	return &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPaymentFailed, Lines: []*model.OrderLine{
+		{ProductID: "p1", Quantity: 2},
+		{ProductID: "p2", Quantity: 1},
+	}}
+}
+
+func TestReopenForPayment_ReReservesReleasedStock(t *testing.T) {
+	f := newSweepFixture(t)
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(failedOrder(), nil).Once()
+	f.userRepo.On("GetUserByID", mock.Anything, "u1").Return(&model.User{ID: "u1", Email: "u1@example.com"}, nil).Once()
+	// p2 is still held; p1 was released by the sweeper.
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").
+		Return([]*model.StockReservation{{ID: "r2", OrderID: "o1", ProductID: "p2", Quantity: 1}}, nil).Once()
+	f.reservRepo.On("ExtendActive", mock.Anything, "o1", mock.Anything).Return(nil).Once()
+	f.productRepo.On("ReserveStock", mock.Anything, "p1", 2).Return(nil).Once()
+	f.warehouses.On("ListStock", mock.Anything, "p1").
+		Return([]*model.WarehouseStock{{WarehouseID: "w1", Warehouse: &model.Warehouse{ID: "w1"}, ProductID: "p1", StockQuantity: 10}}, nil).Once()
+	f.warehouses.On("Reserve", mock.Anything, "w1", "p1", 2).Return(nil).Once()
+	f.reservRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(rs []*model.StockReservation) bool {
+		return len(rs) == 1 && rs[0].ProductID == "p1" && rs[0].Quantity == 2
+	})).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.MatchedBy(func(m stock.Movement) bool {
+		return m.Kind == stock.MovementReserve && m.ProductID == "p1" && m.ReservedDelta == 2 && m.Reason == "payment retry"
+	})).Return(nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.MatchedBy(func(o *model.Order) bool {
+		return o.Status == model.OrderStatusPendingPayment
+	})).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderStatusChanged")).Return(nil).Once()
+
+	order, err := f.svc.ReopenForPayment(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Equal(t, model.OrderStatusPendingPayment, order.Status)
+}
+
+func TestReopenForPayment_InsufficientStock(t *testing.T) {
+	f := newSweepFixture(t)
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(failedOrder(), nil).Once()
+	f.userRepo.On("GetUserByID", mock.Anything, "u1").Return(&model.User{ID: "u1"}, nil).Once()
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(nil, nil).Once()
+	f.reservRepo.On("ExtendActive", mock.Anything, "o1", mock.Anything).Return(nil).Once()
+	f.productRepo.On("ReserveStock", mock.Anything, "p1", 2).Return(nil).Once()
+	f.warehouses.On("ListStock", mock.Anything, "p1").Return(nil, nil).Once()
+
+	_, err := f.svc.ReopenForPayment(context.Background(), "o1")
+	var stockErr *InsufficientStockError
+	require.ErrorAs(t, err, &stockErr)
+	require.Equal(t, "p1", stockErr.ProductID)
+}
+
+func TestReopenForPayment_PendingOrderOnlyExtends(t *testing.T) {
+	f := newSweepFixture(t)
+	order := failedOrder()
+	order.Status = model.OrderStatusPendingPayment
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return([]*model.StockReservation{
+		{ProductID: "p1", Quantity: 2}, {ProductID: "p2", Quantity: 1},
+	}, nil).Once()
+	f.reservRepo.On("ExtendActive", mock.Anything, "o1", mock.Anything).Return(nil).Once()
+
+	_, err := f.svc.ReopenForPayment(context.Background(), "o1")
+	require.NoError(t, err)
+	f.outbox.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
+}
+
+func TestReopenForPayment_RejectsOtherStatuses(t *testing.T) {
+	for _, status := range []model.OrderStatus{model.OrderStatusPaid, model.OrderStatusCancelled, model.OrderStatusNew} {
+		t.Run(string(status), func(t *testing.T) {
+			f := newSweepFixture(t)
+			f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(&model.Order{ID: "o1", Status: status}, nil).Once()
+
+			_, err := f.svc.ReopenForPayment(context.Background(), "o1")
+			require.ErrorIs(t, err, apperror.ErrInvalidStatus)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"goshop/internal/order/model"
+	"goshop/pkg/stock"
+)
+
+func TestUpdateOrderStatus_InProgressCapturesPayment(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPaid}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.payments.On("CaptureOrderPayment", mock.Anything, "o1").Return(nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, order).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderInProgress")).Return(nil).Once()
+
+	got, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusInProgress)
+	require.NoError(t, err)
+	require.Equal(t, model.OrderStatusInProgress, got.Status)
+}
+
+func TestUpdateOrderStatus_FailedCaptureKeepsStatus(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPaid}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.payments.On("CaptureOrderPayment", mock.Anything, "o1").Return(errors.New("card declined")).Once()
+	// No UpdateOrder expectation: the mock fails the test if the order moves.
+
+	_, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusInProgress)
+	require.ErrorContains(t, err, "capture payment")
+	require.Equal(t, model.OrderStatusPaid, order.Status)
+}
+
+func TestUpdateOrderStatus_CancelVoidsPayment(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPaid}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	// The authorization is voided, then the stock it committed goes back with the move.
+	mock.InOrder(
+		f.payments.On("VoidOrderPayment", mock.Anything, "o1").Return(nil).Once(),
+		f.reservRepo.On("FindCommittedByOrderID", mock.Anything, "o1").Return([]*model.StockReservation{
+			{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 3},
+		}, nil).Once(),
+		f.productRepo.On("ReturnCommitted", mock.Anything, "p1", 3).Return(nil).Once(),
+		f.ledger.On("Record", mock.Anything, mock.MatchedBy(func(m stock.Movement) bool {
+			return m.Kind == stock.MovementReturn && m.StockDelta == 3 && m.ReservedDelta == 0 && m.OrderID == "o1"
+		})).Return(nil).Once(),
+		f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusReleased).Return(nil).Once(),
+		f.repo.On("UpdateOrder", mock.Anything, order).Return(nil).Once(),
+	)
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCancelled")).Return(nil).Once()
+
+	got, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusCancelled)
+	require.NoError(t, err)
+	require.Equal(t, model.OrderStatusCancelled, got.Status)
+}
+
+func TestUpdateOrderStatus_AuthorizedThenCancelledIssuesNoInvoice(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Twice()
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return([]*model.StockReservation{
+		{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1},
+	}, nil).Once()
+	f.productRepo.On("CommitReservation", mock.Anything, "p1", 1).Return(nil).Once()
+	f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusCommitted).Return(nil).Once()
+	f.productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{ID: "p1", StockQuantity: 100}, nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, order).Return(nil).Twice()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderPaid")).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.Anything).Return(nil)
+
+	_, err := f.svc.MarkOrderAuthorized(context.Background(), "o1")
+	require.NoError(t, err)
+
+	// Cancelling voids the hold and returns the stock; there is no invoice to credit.
+	f.payments.On("VoidOrderPayment", mock.Anything, "o1").Return(nil).Once()
+	f.reservRepo.On("FindCommittedByOrderID", mock.Anything, "o1").Return([]*model.StockReservation{
+		{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1, Status: model.ReservationStatusCommitted},
+	}, nil).Once()
+	f.productRepo.On("ReturnCommitted", mock.Anything, "p1", 1).Return(nil).Once()
+	f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusReleased).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCancelled")).Return(nil).Once()
+
+	got, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusCancelled)
+	require.NoError(t, err)
+	require.Equal(t, model.OrderStatusCancelled, got.Status)
+	f.invoices.AssertNotCalled(t, "NextSequence", mock.Anything, mock.Anything, mock.Anything)
+	f.invoices.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestUpdateOrderStatus_FailedVoidKeepsStatus(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPaid}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.payments.On("VoidOrderPayment", mock.Anything, "o1").Return(errors.New("provider down")).Once()
+
+	_, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusCancelled)
+	require.ErrorContains(t, err, "void payment")
+	require.Equal(t, model.OrderStatusPaid, order.Status)
+}
+
+func TestUpdateOrderStatus_CancelUnpaidReleasesReservations(t *testing.T) {
+	for _, from := range []model.OrderStatus{model.OrderStatusPendingPayment, model.OrderStatusPaymentFailed} {
+		t.Run(string(from), func(t *testing.T) {
+			f := newMarkPaidFixture(t)
+			order := &model.Order{ID: "o1", UserID: "u1", Status: from}
+			wh := "w1"
+			reservations := []*model.StockReservation{{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 2, WarehouseID: &wh}}
+			f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+			f.payments.On("VoidOrderPayment", mock.Anything, "o1").Return(nil).Once()
+			f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(reservations, nil).Once()
+			f.productRepo.On("ReleaseReservation", mock.Anything, "p1", 2).Return(nil).Once()
+			f.warehouses.On("Release", mock.Anything, "w1", "p1", 2).Return(nil).Once()
+			f.ledger.On("Record", mock.Anything, stock.Movement{
+				ProductID:     "p1",
+				Kind:          stock.MovementRelease,
+				ReservedDelta: -2,
+				Actor:         stock.ActorSystem,
+				Reason:        "order cancelled",
+				OrderID:       "o1",
+				ReservationID: "r1",
+				WarehouseID:   "w1",
+			}).Return(nil).Once()
+			f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusReleased).Return(nil).Once()
+			f.repo.On("UpdateOrder", mock.Anything, order).Return(nil).Once()
+			f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCancelled")).Return(nil).Once()
+			// No FindCommittedByOrderID expectation: an unpaid order only holds reservations.
+
+			_, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusCancelled)
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestUpdateOrderStatus_CancelUnpaidReleaseError(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.payments.On("VoidOrderPayment", mock.Anything, "o1").Return(nil).Once()
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(nil, errors.New("db")).Once()
+	// No UpdateOrder expectation: the order isn't cancelled while it still holds stock.
+
+	_, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusCancelled)
+	require.EqualError(t, err, "db")
+}
+
+func TestUpdateOrderStatus_RepeatedMoveDoesNotSettleAgain(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusInProgress}
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, order).Return(nil).Once()
+	// No settler expectation: the payment was captured on the first move.
+
+	_, err := f.svc.UpdateOrderStatus(context.Background(), "o1", model.OrderStatusInProgress)
+	require.NoError(t, err)
+}
@@ -0,0 +1,259 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/quangdangfit/gocommon/validation"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	orderRepo "goshop/internal/order/repository"
+	"goshop/pkg/apperror"
+	"goshop/pkg/dbs"
+	"goshop/pkg/eventbus"
+)
+
+// ShipmentService splits an order's fulfillment into shipments and derives the order's status
+// from them: partially_shipped once some units have left, shipped once all have, done once all
+// have been delivered.
+//
+//go:generate mockery --name=ShipmentService
+type ShipmentService interface {
+	// CreateShipment packs some units of the order's lines into a new shipment. The order must
+	// be in progress or partially shipped, and no line can be packed beyond its quantity.
+	CreateShipment(ctx context.Context, orderID string, req *domain.CreateShipmentReq) (*model.Shipment, error)
+	// UpdateShipment moves a shipment to its next status and the order along with it. A
+	// repeated move only updates the carrier details. A cancelled or done order's shipments
+	// can't change.
+	UpdateShipment(ctx context.Context, orderID, shipmentID string, req *domain.UpdateShipmentReq) (*model.Shipment, error)
+}
+
+type shipmentService struct {
+	validator validation.Validation
+	db        dbs.Database
+	repo      orderRepo.OrderRepository
+	shipments orderRepo.ShipmentRepository
+	userRepo  orderRepo.UserRepository
+	outbox    EventOutbox
+}
+
+func NewShipmentService(
+	validator validation.Validation,
+	db dbs.Database,
+	repo orderRepo.OrderRepository,
+	shipments orderRepo.ShipmentRepository,
+	userRepo orderRepo.UserRepository,
+	outbox EventOutbox,
+) ShipmentService {
+	return &shipmentService{
+		validator: validator,
+		db:        db,
+		repo:      repo,
+		shipments: shipments,
+		userRepo:  userRepo,
+		outbox:    outbox,
+	}
+}
+
+func (s *shipmentService) CreateShipment(ctx context.Context, orderID string, req *domain.CreateShipmentReq) (*model.Shipment, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	var shipment *model.Shipment
+	txErr := s.db.WithTransaction(func() error {
+		// Lock the order before reading what's already packed: concurrent packs would otherwise
+		// both see the same units left and ship more than were ordered.
+		if err := s.repo.LockOrder(ctx, orderID); err != nil {
+			return err
+		}
+		order, err := s.repo.GetOrderByID(ctx, orderID, true)
+		if err != nil {
+			return err
+		}
+		if order.Status != model.OrderStatusInProgress && order.Status != model.OrderStatusPartiallyShipped {
+			return apperror.WrapMessage(apperror.ErrInvalidStatus, nil,
+				fmt.Sprintf("order is %s, only an order in progress can be shipped", order.Status))
+		}
+		existing, err := s.repo.ListShipments(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+		if shipment, err = packShipment(order, existing, req); err != nil {
+			return err
+		}
+
+		if err := s.shipments.Create(ctx, shipment); err != nil {
+			return err
+		}
+		userEmail := lookupUserEmail(ctx, s.userRepo, order.UserID)
+		return s.outbox.Add(ctx, eventbus.ShipmentPacked{ShipmentPayload: shipmentPayload(order, shipment, userEmail)})
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return shipment, nil
+}
+
+// packShipment builds the shipment req asks for, checking no line is packed beyond its
+// quantity on top of the existing shipments.
+func packShipment(order *model.Order, existing []*model.Shipment, req *domain.CreateShipmentReq) (*model.Shipment, error) {
+	lines := make(map[string]*model.OrderLine, len(order.Lines))
+	for _, l := range order.Lines {
+		lines[l.ID] = l
+	}
+	packed := model.ShippedQuantities(existing)
+	shipment := &model.Shipment{
+		OrderID:        order.ID,
+		Status:         model.ShipmentStatusPacked,
+		Carrier:        req.Carrier,
+		TrackingNumber: req.TrackingNumber,
+	}
+	for _, l := range req.Lines {
+		line, ok := lines[l.OrderLineID]
+		if !ok {
+			return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "order line "+l.OrderLineID+" is not on this order")
+		}
+		packed[line.ID] += l.Quantity
+		if packed[line.ID] > line.Quantity {
+			return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil,
+				fmt.Sprintf("order line %s has %d units, %d would be shipped", line.ID, line.Quantity, packed[line.ID]))
+		}
+		shipment.Lines = append(shipment.Lines, &model.ShipmentLine{
+			OrderLineID: line.ID,
+			ProductID:   line.ProductID,
+			Quantity:    l.Quantity,
+		})
+	}
+	return shipment, nil
+}
+
+func (s *shipmentService) UpdateShipment(ctx context.Context, orderID, shipmentID string, req *domain.UpdateShipmentReq) (*model.Shipment, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+
+	var shipment *model.Shipment
+	txErr := s.db.WithTransaction(func() error {
+		// Lock the order before reading its shipments: concurrent updates would otherwise each
+		// derive the order's status from a stale set and the last one to write would win.
+		if err := s.repo.LockOrder(ctx, orderID); err != nil {
+			return err
+		}
+		order, err := s.repo.GetOrderByID(ctx, orderID, true)
+		if err != nil {
+			return err
+		}
+		if order.Status == model.OrderStatusCancelled || order.Status == model.OrderStatusDone {
+			return apperror.WrapMessage(apperror.ErrInvalidStatus, nil,
+				fmt.Sprintf("order is %s, its shipments can't change", order.Status))
+		}
+		shipment, err = s.shipments.GetByID(ctx, orderID, shipmentID)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return apperror.WrapMessage(apperror.ErrNotFound, err, "shipment not found")
+			}
+			return err
+		}
+		status := model.ShipmentStatus(req.Status)
+		if !shipment.Status.CanTransitionTo(status) {
+			return apperror.WrapMessage(apperror.ErrInvalidStatus, nil,
+				fmt.Sprintf("shipment is %s, can't move to %s", shipment.Status, status))
+		}
+		if req.Carrier != "" {
+			shipment.Carrier = req.Carrier
+		}
+		if req.TrackingNumber != "" {
+			shipment.TrackingNumber = req.TrackingNumber
+		}
+		if status == model.ShipmentStatusShipped && (shipment.Carrier == "" || shipment.TrackingNumber == "") {
+			return apperror.WrapMessage(apperror.ErrBadRequest, nil, "a shipped shipment needs a carrier and tracking number")
+		}
+		shipments, err := s.repo.ListShipments(ctx, order.ID)
+		if err != nil {
+			return err
+		}
+
+		changed := shipment.Status != status
+		now := time.Now()
+		switch {
+		case !changed:
+		case status == model.ShipmentStatusShipped:
+			shipment.ShippedAt = &now
+		case status == model.ShipmentStatusDelivered:
+			shipment.DeliveredAt = &now
+		}
+		shipment.Status = status
+		for i, sh := range shipments {
+			if sh.ID == shipment.ID {
+				shipments[i] = shipment
+			}
+		}
+
+		// The order follows its shipments, but only forward: a move its status doesn't allow
+		// leaves it alone.
+		orderStatus := model.FulfillmentStatus(order.Lines, shipments)
+		if orderStatus == model.OrderStatusDone && order.CashOnDelivery() {
+			// A cash-on-delivery order is done once the cash is collected, not when it's delivered.
+			orderStatus = model.OrderStatusShipped
+		}
+		orderChanged := orderStatus != "" && orderStatus != order.Status && order.Status.CanTransitionTo(orderStatus)
+
+		if err := s.shipments.Update(ctx, shipment); err != nil {
+			return err
+		}
+		userEmail := ""
+		if changed || orderChanged {
+			userEmail = lookupUserEmail(ctx, s.userRepo, order.UserID)
+		}
+		if changed {
+			payload := shipmentPayload(order, shipment, userEmail)
+			var ev eventbus.Event = eventbus.ShipmentShipped{ShipmentPayload: payload}
+			if status == model.ShipmentStatusDelivered {
+				ev = eventbus.ShipmentDelivered{ShipmentPayload: payload}
+			}
+			if err := s.outbox.Add(ctx, ev); err != nil {
+				return fmt.Errorf("record shipment event: %w", err)
+			}
+		}
+		if !orderChanged {
+			return nil
+		}
+		order.Status = orderStatus
+		if err := s.repo.UpdateOrder(ctx, order); err != nil {
+			return err
+		}
+		return s.outbox.Add(ctx, statusEvent(order, userEmail, ""))
+	})
+	if txErr != nil {
+		return nil, txErr
+	}
+	return shipment, nil
+}
+
+// shipmentPayload snapshots shipment, and the order it belongs to, for an event.
+func shipmentPayload(order *model.Order, shipment *model.Shipment, userEmail string) eventbus.ShipmentPayload {
+	lines := make([]eventbus.ShipmentLine, 0, len(shipment.Lines))
+	for _, l := range shipment.Lines {
+		lines = append(lines, eventbus.ShipmentLine{
+			OrderLineID: l.OrderLineID,
+			ProductID:   l.ProductID,
+			Quantity:    l.Quantity,
+		})
+	}
+	return eventbus.ShipmentPayload{
+		ShipmentID:     shipment.ID,
+		OrderID:        order.ID,
+		OrderCode:      order.Code,
+		UserID:         order.UserID,
+		UserEmail:      userEmail,
+		Status:         string(shipment.Status),
+		Carrier:        shipment.Carrier,
+		TrackingNumber: shipment.TrackingNumber,
+		Lines:          lines,
+	}
+}
@@ -0,0 +1,264 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/quangdangfit/gocommon/validation"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	orderMocks "goshop/internal/order/repository/mocks"
+	serviceMocks "goshop/internal/order/service/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	dbsMocks "goshop/pkg/dbs/mocks"
+	"goshop/pkg/eventbus"
+)
+
+type shipmentFixture struct {
+	svc       ShipmentService
+	repo      *orderMocks.OrderRepository
+	shipments *orderMocks.ShipmentRepository
+	outbox    *serviceMocks.EventOutbox
+}
+
+func newShipmentFixture(t *testing.T) *shipmentFixture {
+	t.Helper()
+	logger.Initialize(config.ProductionEnv)
+	db := dbsMocks.NewDatabase(t)
+	repo := orderMocks.NewOrderRepository(t)
+	shipments := orderMocks.NewShipmentRepository(t)
+	userRepo := orderMocks.NewUserRepository(t)
+	outbox := serviceMocks.NewEventOutbox(t)
+	db.On("WithTransaction", mock.Anything).Return(func(fn func() error) error { return fn() }).Maybe()
+	userRepo.On("GetUserByID", mock.Anything, "u1").Return(&model.User{ID: "u1", Email: "u1@example.com"}, nil).Maybe()
+	return &shipmentFixture{
+		svc:       NewShipmentService(validation.New(), db, repo, shipments, userRepo, outbox),
+		repo:      repo,
+		shipments: shipments,
+		outbox:    outbox,
+	}
+}
+
+func requireAppError(t *testing.T, err error, want *apperror.AppError) {
+	t.Helper()
+	var appErr *apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, want.Code, appErr.Code)
+}
+
+// shippableOrder has two lines: two units of p1 and one of p2.
+func shippableOrder(status model.OrderStatus) *model.Order {
+	return &model.Order{
+		ID:     "o1",
+		Code:   "C1",
+		UserID: "u1",
+		Status: status,
+		Lines: []*model.OrderLine{
+			{ID: "l1", ProductID: "p1", Quantity: 2},
+			{ID: "l2", ProductID: "p2", Quantity: 1},
+		},
+	}
+}
+
+func shipmentOf(id string, status model.ShipmentStatus, lines ...*model.ShipmentLine) *model.Shipment {
+	return &model.Shipment{ID: id, OrderID: "o1", Status: status, Carrier: "dhl", TrackingNumber: "TRK-" + id, Lines: lines}
+}
+
+func TestCreateShipment(t *testing.T) {
+	f := newShipmentFixture(t)
+	// What's already packed is only read under the order's lock.
+	mock.InOrder(
+		f.repo.On("LockOrder", mock.Anything, "o1").Return(nil).Once(),
+		f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(shippableOrder(model.OrderStatusInProgress), nil).Once(),
+		f.repo.On("ListShipments", mock.Anything, "o1").
+			Return([]*model.Shipment{shipmentOf("s1", model.ShipmentStatusShipped, &model.ShipmentLine{OrderLineID: "l1", Quantity: 1})}, nil).Once(),
+	)
+	f.shipments.On("Create", mock.Anything, mock.MatchedBy(func(s *model.Shipment) bool {
+		return s.OrderID == "o1" && s.Status == model.ShipmentStatusPacked && len(s.Lines) == 2 &&
+			s.Lines[0].ProductID == "p1" && s.Lines[0].Quantity == 1
+	})).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.MatchedBy(func(ev eventbus.ShipmentPacked) bool {
+		return ev.OrderCode == "C1" && ev.UserEmail == "u1@example.com" && ev.Status == "packed" && len(ev.Lines) == 2
+	})).Return(nil).Once()
+
+	shipment, err := f.svc.CreateShipment(context.Background(), "o1", &domain.CreateShipmentReq{
+		Carrier: "dhl",
+		Lines:   []domain.ShipmentLineReq{{OrderLineID: "l1", Quantity: 1}, {OrderLineID: "l2", Quantity: 1}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "dhl", shipment.Carrier)
+}
+
+func TestCreateShipment_Rejects(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  model.OrderStatus
+		lines   []domain.ShipmentLineReq
+		wantErr *apperror.AppError
+	}{
+		{name: "not_in_progress", status: model.OrderStatusPaid, lines: []domain.ShipmentLineReq{{OrderLineID: "l1", Quantity: 1}}, wantErr: apperror.ErrInvalidStatus},
+		{name: "unknown_line", status: model.OrderStatusInProgress, lines: []domain.ShipmentLineReq{{OrderLineID: "lx", Quantity: 1}}, wantErr: apperror.ErrBadRequest},
+		{name: "over_shipped", status: model.OrderStatusPartiallyShipped, lines: []domain.ShipmentLineReq{{OrderLineID: "l1", Quantity: 2}}, wantErr: apperror.ErrBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newShipmentFixture(t)
+			f.repo.On("LockOrder", mock.Anything, "o1").Return(nil).Once()
+			f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(shippableOrder(tt.status), nil).Once()
+			f.repo.On("ListShipments", mock.Anything, "o1").
+				Return([]*model.Shipment{shipmentOf("s1", model.ShipmentStatusPacked, &model.ShipmentLine{OrderLineID: "l1", Quantity: 1})}, nil).Maybe()
+
+			_, err := f.svc.CreateShipment(context.Background(), "o1", &domain.CreateShipmentReq{Lines: tt.lines})
+			requireAppError(t, err, tt.wantErr)
+		})
+	}
+
+	t.Run("order_not_found", func(t *testing.T) {
+		f := newShipmentFixture(t)
+		f.repo.On("LockOrder", mock.Anything, "o1").Return(gorm.ErrRecordNotFound).Once()
+
+		_, err := f.svc.CreateShipment(context.Background(), "o1", &domain.CreateShipmentReq{
+			Lines: []domain.ShipmentLineReq{{OrderLineID: "l1", Quantity: 1}},
+		})
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+
+	t.Run("no_lines", func(t *testing.T) {
+		_, err := newShipmentFixture(t).svc.CreateShipment(context.Background(), "o1", &domain.CreateShipmentReq{})
+		require.Error(t, err)
+	})
+}
+
+func TestUpdateShipment_PartiallyShipped(t *testing.T) {
+	f := newShipmentFixture(t)
+	s1 := shipmentOf("s1", model.ShipmentStatusPacked, &model.ShipmentLine{OrderLineID: "l1", Quantity: 2})
+	s1.Carrier, s1.TrackingNumber = "", ""
+	s2 := shipmentOf("s2", model.ShipmentStatusPacked, &model.ShipmentLine{OrderLineID: "l2", Quantity: 1})
+	// The order's shipments are only read under its lock.
+	mock.InOrder(
+		f.repo.On("LockOrder", mock.Anything, "o1").Return(nil).Once(),
+		f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(shippableOrder(model.OrderStatusInProgress), nil).Once(),
+		f.shipments.On("GetByID", mock.Anything, "o1", "s1").Return(s1, nil).Once(),
+		f.repo.On("ListShipments", mock.Anything, "o1").Return([]*model.Shipment{shipmentOf("s1", model.ShipmentStatusPacked), s2}, nil).Once(),
+	)
+	f.shipments.On("Update", mock.Anything, mock.MatchedBy(func(s *model.Shipment) bool {
+		return s.Status == model.ShipmentStatusShipped && s.ShippedAt != nil && s.TrackingNumber == "1Z"
+	})).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.MatchedBy(func(ev eventbus.ShipmentShipped) bool {
+		return ev.ShipmentID == "s1" && ev.Carrier == "ups" && ev.TrackingNumber == "1Z"
+	})).Return(nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.MatchedBy(func(o *model.Order) bool {
+		return o.Status == model.OrderStatusPartiallyShipped
+	})).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderStatusChanged")).Return(nil).Once()
+
+	shipment, err := f.svc.UpdateShipment(context.Background(), "o1", "s1",
+		&domain.UpdateShipmentReq{Status: "shipped", Carrier: "ups", TrackingNumber: "1Z"})
+	require.NoError(t, err)
+	require.Equal(t, model.ShipmentStatusShipped, shipment.Status)
+}
+
+func TestUpdateShipment_LastDeliveryCompletesOrder(t *testing.T) {
+	f := newShipmentFixture(t)
+	s1 := shipmentOf("s1", model.ShipmentStatusDelivered, &model.ShipmentLine{OrderLineID: "l1", Quantity: 2})
+	s2 := shipmentOf("s2", model.ShipmentStatusShipped, &model.ShipmentLine{OrderLineID: "l2", Quantity: 1})
+	f.repo.On("LockOrder", mock.Anything, "o1").Return(nil).Once()
+	f.shipments.On("GetByID", mock.Anything, "o1", "s2").Return(s2, nil).Once()
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(shippableOrder(model.OrderStatusShipped), nil).Once()
+	f.repo.On("ListShipments", mock.Anything, "o1").
+		Return([]*model.Shipment{s1, shipmentOf("s2", model.ShipmentStatusShipped, s2.Lines...)}, nil).Once()
+	f.shipments.On("Update", mock.Anything, s2).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.ShipmentDelivered")).Return(nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.MatchedBy(func(o *model.Order) bool {
+		return o.Status == model.OrderStatusDone
+	})).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderDone")).Return(nil).Once()
+
+	shipment, err := f.svc.UpdateShipment(context.Background(), "o1", "s2", &domain.UpdateShipmentReq{Status: "delivered"})
+	require.NoError(t, err)
+	require.NotNil(t, shipment.DeliveredAt)
+}
+
+func TestUpdateShipment_CashOnDeliveryStaysShipped(t *testing.T) {
+	f := newShipmentFixture(t)
+	order := shippableOrder(model.OrderStatusShipped)
+	order.PaymentMethod = model.PaymentMethodCOD
+	s1 := shipmentOf("s1", model.ShipmentStatusShipped,
+		&model.ShipmentLine{OrderLineID: "l1", Quantity: 2}, &model.ShipmentLine{OrderLineID: "l2", Quantity: 1})
+	f.repo.On("LockOrder", mock.Anything, "o1").Return(nil).Once()
+	f.shipments.On("GetByID", mock.Anything, "o1", "s1").Return(s1, nil).Once()
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.repo.On("ListShipments", mock.Anything, "o1").Return([]*model.Shipment{shipmentOf("s1", model.ShipmentStatusShipped)}, nil).Once()
+	f.shipments.On("Update", mock.Anything, s1).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.ShipmentDelivered")).Return(nil).Once()
+
+	_, err := f.svc.UpdateShipment(context.Background(), "o1", "s1", &domain.UpdateShipmentReq{Status: "delivered"})
+	require.NoError(t, err)
+	require.Equal(t, model.OrderStatusShipped, order.Status)
+}
+
+func TestUpdateShipment_Rejects(t *testing.T) {
+	// newFixture has o1 in progress, locked and loaded.
+	newFixture := func(t *testing.T) *shipmentFixture {
+		f := newShipmentFixture(t)
+		f.repo.On("LockOrder", mock.Anything, "o1").Return(nil).Once()
+		f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(shippableOrder(model.OrderStatusInProgress), nil).Once()
+		return f
+	}
+	t.Run("not_found", func(t *testing.T) {
+		f := newFixture(t)
+		f.shipments.On("GetByID", mock.Anything, "o1", "sx").Return(nil, gorm.ErrRecordNotFound).Once()
+		_, err := f.svc.UpdateShipment(context.Background(), "o1", "sx", &domain.UpdateShipmentReq{Status: "shipped"})
+		requireAppError(t, err, apperror.ErrNotFound)
+	})
+	t.Run("order_not_found", func(t *testing.T) {
+		f := newShipmentFixture(t)
+		f.repo.On("LockOrder", mock.Anything, "o1").Return(gorm.ErrRecordNotFound).Once()
+		_, err := f.svc.UpdateShipment(context.Background(), "o1", "s1", &domain.UpdateShipmentReq{Status: "shipped"})
+		require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	})
+	for _, status := range []model.OrderStatus{model.OrderStatusCancelled, model.OrderStatusDone} {
+		t.Run("order_"+string(status), func(t *testing.T) {
+			f := newShipmentFixture(t)
+			f.repo.On("LockOrder", mock.Anything, "o1").Return(nil).Once()
+			f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(shippableOrder(status), nil).Once()
+			// No shipment expectations: the mock fails the test if one is read or written.
+			_, err := f.svc.UpdateShipment(context.Background(), "o1", "s1", &domain.UpdateShipmentReq{Status: "shipped"})
+			requireAppError(t, err, apperror.ErrInvalidStatus)
+		})
+	}
+	t.Run("skips_a_step", func(t *testing.T) {
+		f := newFixture(t)
+		f.shipments.On("GetByID", mock.Anything, "o1", "s1").Return(shipmentOf("s1", model.ShipmentStatusPacked), nil).Once()
+		_, err := f.svc.UpdateShipment(context.Background(), "o1", "s1", &domain.UpdateShipmentReq{Status: "delivered"})
+		requireAppError(t, err, apperror.ErrInvalidStatus)
+	})
+	t.Run("shipped_without_tracking", func(t *testing.T) {
+		f := newFixture(t)
+		s1 := shipmentOf("s1", model.ShipmentStatusPacked)
+		s1.TrackingNumber = ""
+		f.shipments.On("GetByID", mock.Anything, "o1", "s1").Return(s1, nil).Once()
+		_, err := f.svc.UpdateShipment(context.Background(), "o1", "s1", &domain.UpdateShipmentReq{Status: "shipped"})
+		requireAppError(t, err, apperror.ErrBadRequest)
+	})
+	t.Run("unknown_status", func(t *testing.T) {
+		_, err := newShipmentFixture(t).svc.UpdateShipment(context.Background(), "o1", "s1", &domain.UpdateShipmentReq{Status: "lost"})
+		require.Error(t, err)
+	})
+	t.Run("update_error", func(t *testing.T) {
+		f := newFixture(t)
+		s1 := shipmentOf("s1", model.ShipmentStatusPacked, &model.ShipmentLine{OrderLineID: "l1", Quantity: 1})
+		f.shipments.On("GetByID", mock.Anything, "o1", "s1").Return(s1, nil).Once()
+		f.repo.On("ListShipments", mock.Anything, "o1").Return([]*model.Shipment{s1}, nil).Once()
+		f.shipments.On("Update", mock.Anything, s1).Return(errors.New("db")).Once()
+		_, err := f.svc.UpdateShipment(context.Background(), "o1", "s1", &domain.UpdateShipmentReq{Status: "shipped"})
+		require.EqualError(t, err, "db")
+	})
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/money"
+	"goshop/pkg/shipping"
+)
+
+func (s *orderService) QuoteShipping(ctx context.Context, req *domain.QuoteShippingReq) ([]*domain.ShippingQuote, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	lines := make([]*model.OrderLine, len(req.Lines))
+	products := make(map[string]*model.Product)
+	for i, l := range req.Lines {
+		product, err := s.productRepo.GetProductByID(ctx, l.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		lines[i] = &model.OrderLine{ProductID: l.ProductID, Quantity: l.Quantity}
+		products[l.ProductID] = product
+	}
+	return s.shipping.Quote(ctx, s.parcel(req.Country, lines, products), req.Currency)
+}
+
+// parcel is what shipping rates price the lines by: their subtotal in the base currency, before
+// coupons, and their weight.
+func (s *orderService) parcel(country string, lines []*model.OrderLine, products map[string]*model.Product) shipping.Parcel {
+	p := shipping.Parcel{Country: country, Subtotal: money.Zero(s.rates.Base())}
+	for _, line := range lines {
+		product := products[line.ProductID]
+		qty := int64(line.Quantity)
+		p.Subtotal = p.Subtotal.Add(product.Price.Mul(qty))
+		p.Grams += product.WeightGrams * qty
+	}
+	return p
+}
+
+// orderAddresses resolves where the order ships and is billed to. The shipping address is
+// given inline, picked from the buyer's saved addresses, or else their default address; the
+// billing address the same way, or else the shipping address. An order can be placed without
+// any address when the buyer has none saved.
+func (s *orderService) orderAddresses(ctx context.Context, req *domain.PlaceOrderReq) (shipTo, billTo model.OrderAddress, err error) {
+	shipTo, err = s.orderAddress(ctx, req.UserID, "shipping", req.ShippingAddressID, req.ShippingAddress)
+	if err != nil {
+		return shipTo, billTo, err
+	}
+	if shipTo.IsZero() && req.ShippingAddressID == "" && req.ShippingAddress == nil {
+		shipTo = s.defaultAddress(ctx, req.UserID)
+	}
+	billTo, err = s.orderAddress(ctx, req.UserID, "billing", req.BillingAddressID, req.BillingAddress)
+	if err != nil {
+		return shipTo, billTo, err
+	}
+	if billTo.IsZero() {
+		billTo = shipTo
+	}
+	return shipTo, billTo, nil
+}
+
+// orderAddress snapshots the address given by id or inline; neither gives a zero address.
+func (s *orderService) orderAddress(ctx context.Context, userID, kind, id string, inline *domain.AddressReq) (model.OrderAddress, error) {
+	switch {
+	case id != "" && inline != nil:
+		return model.OrderAddress{}, apperror.WrapMessage(apperror.ErrBadRequest, nil,
+			fmt.Sprintf("pass %s_address_id or %s_address, not both", kind, kind))
+	case inline != nil:
+		return model.OrderAddress{
+			Name:    inline.Name,
+			Phone:   inline.Phone,
+			Street:  inline.Street,
+			City:    inline.City,
+			Region:  inline.Region,
+			Country: inline.Country,
+		}, nil
+	case id != "":
+		address, err := s.userRepo.GetAddress(ctx, userID, id)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return model.OrderAddress{}, apperror.WrapMessage(apperror.ErrBadRequest, err, kind+" address not found")
+		}
+		if err != nil {
+			return model.OrderAddress{}, err
+		}
+		return model.SnapshotAddress(address), nil
+	}
+	return model.OrderAddress{}, nil
+}
+
+// defaultAddress snapshots the buyer's default address. Without one, or when the lookup fails,
+// the order ships without an address and warehouse allocation falls back to priority.
+func (s *orderService) defaultAddress(ctx context.Context, userID string) model.OrderAddress {
+	address, err := s.userRepo.GetDefaultAddress(ctx, userID)
+	if err != nil {
+		logger.Error("Failed to get default address: ", err)
+		return model.OrderAddress{}
+	}
+	if address == nil {
+		return model.OrderAddress{}
+	}
+	return model.SnapshotAddress(address)
+}
@@ -0,0 +1,227 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/quangdangfit/gocommon/validation"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	"goshop/internal/order/repository"
+	"goshop/pkg/apperror"
+	"goshop/pkg/currency"
+	"goshop/pkg/money"
+	"goshop/pkg/shipping"
+)
+
+//go:generate mockery --name=ShippingService
+type ShippingService interface {
+	// Methods lists the shipping methods on offer, default first: the active rates admins
+	// configured, or the shipping_methods from configuration while there are none.
+	Methods(ctx context.Context) ([]shipping.Method, error)
+	// List returns every configured rate, inactive ones included.
+	List(ctx context.Context) ([]*model.ShippingRate, error)
+	Create(ctx context.Context, req *domain.CreateShippingRateReq) (*model.ShippingRate, error)
+	Update(ctx context.Context, code string, req *domain.UpdateShippingRateReq) (*model.ShippingRate, error)
+	Delete(ctx context.Context, code string) error
+	// Quote prices every method that ships the parcel, in the given currency (the base currency
+	// when empty).
+	Quote(ctx context.Context, parcel shipping.Parcel, currency string) ([]*domain.ShippingQuote, error)
+	// Fee prices the method with code, the default one when empty, for the parcel in the given
+	// currency. A method that isn't offered, or doesn't ship the parcel, is a bad request.
+	Fee(ctx context.Context, code string, parcel shipping.Parcel, currency string) (shipping.Method, money.Money, error)
+}
+
+type shippingSvc struct {
+	validator validation.Validation
+	repo      repository.ShippingRateRepository
+	rates     *currency.Rates
+	fallback  *shipping.Methods
+}
+
+// NewShippingService prices shipping with the configured rates; fallback is offered while
+// admins haven't configured any.
+func NewShippingService(validator validation.Validation, repo repository.ShippingRateRepository, rates *currency.Rates,
+	fallback *shipping.Methods) ShippingService {
+	return &shippingSvc{validator: validator, repo: repo, rates: rates, fallback: fallback}
+}
+
+func (s *shippingSvc) Methods(ctx context.Context) ([]shipping.Method, error) {
+	methods, err := s.methods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return methods.List(), nil
+}
+
+func (s *shippingSvc) methods(ctx context.Context) (*shipping.Methods, error) {
+	rows, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var list []shipping.Method
+	for _, row := range rows {
+		if !row.Active {
+			continue
+		}
+		method, err := shipping.NewMethod(row.Code, row.Name, row.Rule, s.rates.Base())
+		if err != nil {
+			return nil, fmt.Errorf("shipping rate %s: %w", row.Code, err)
+		}
+		list = append(list, method)
+	}
+	if len(list) == 0 {
+		return s.fallback, nil
+	}
+	return shipping.NewMethods(list), nil
+}
+
+func (s *shippingSvc) List(ctx context.Context) ([]*model.ShippingRate, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *shippingSvc) Create(ctx context.Context, req *domain.CreateShippingRateReq) (*model.ShippingRate, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	if err := s.checkRule(req.Rule); err != nil {
+		return nil, err
+	}
+	rate := model.ShippingRate{
+		Code:     shippingCode(req.Code),
+		Name:     req.Name,
+		Position: req.Position,
+		Active:   req.Active == nil || *req.Active,
+		Rule:     req.Rule,
+	}
+	_, err := s.repo.GetByCode(ctx, rate.Code)
+	if err == nil {
+		return nil, apperror.WrapMessage(apperror.ErrConflict, nil, fmt.Sprintf("shipping rate %s already exists", rate.Code))
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, &rate); err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (s *shippingSvc) Update(ctx context.Context, code string, req *domain.UpdateShippingRateReq) (*model.ShippingRate, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	rate, err := s.get(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		rate.Name = req.Name
+	}
+	if req.Position != nil {
+		rate.Position = *req.Position
+	}
+	if req.Active != nil {
+		rate.Active = *req.Active
+	}
+	if req.Rule != nil {
+		if err := s.checkRule(*req.Rule); err != nil {
+			return nil, err
+		}
+		rate.Rule = *req.Rule
+	}
+	if err := s.repo.Update(ctx, rate); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+func (s *shippingSvc) Delete(ctx context.Context, code string) error {
+	rate, err := s.get(ctx, code)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, rate)
+}
+
+func (s *shippingSvc) get(ctx context.Context, code string) (*model.ShippingRate, error) {
+	rate, err := s.repo.GetByCode(ctx, shippingCode(code))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, err, "shipping rate not found")
+	}
+	return rate, err
+}
+
+// checkRule requires a rule that builds a rate provider, its amounts in the base currency.
+func (s *shippingSvc) checkRule(rule shipping.Rule) error {
+	if _, err := rule.Provider(s.rates.Base()); err != nil {
+		return apperror.WrapMessage(apperror.ErrBadRequest, err, err.Error())
+	}
+	return nil
+}
+
+func (s *shippingSvc) Quote(ctx context.Context, parcel shipping.Parcel, in string) ([]*domain.ShippingQuote, error) {
+	in, err := s.currency(in)
+	if err != nil {
+		return nil, err
+	}
+	methods, err := s.methods(ctx)
+	if err != nil {
+		return nil, err
+	}
+	quotes := make([]*domain.ShippingQuote, 0)
+	for _, method := range methods.List() {
+		rate, ok := method.Rate(parcel)
+		if !ok {
+			continue
+		}
+		rate, err := rate.Convert(s.rates, in)
+		if err != nil {
+			return nil, err
+		}
+		quotes = append(quotes, &domain.ShippingQuote{Code: method.Code, Name: method.Name, Rate: rate})
+	}
+	return quotes, nil
+}
+
+func (s *shippingSvc) Fee(ctx context.Context, code string, parcel shipping.Parcel, orderCurrency string) (shipping.Method, money.Money, error) {
+	methods, err := s.methods(ctx)
+	if err != nil {
+		return shipping.Method{}, money.Money{}, err
+	}
+	method, ok := methods.Get(code)
+	if !ok {
+		return shipping.Method{}, money.Money{}, apperror.WrapMessage(apperror.ErrBadRequest, nil,
+			fmt.Sprintf("shipping method %s is not offered", code))
+	}
+	rate, ok := method.Rate(parcel)
+	if !ok {
+		return shipping.Method{}, money.Money{}, apperror.WrapMessage(apperror.ErrBadRequest, nil,
+			fmt.Sprintf("shipping method %s doesn't ship this order", method.Code))
+	}
+	fee, err := rate.Convert(s.rates, orderCurrency)
+	if err != nil {
+		return shipping.Method{}, money.Money{}, err
+	}
+	return method, fee, nil
+}
+
+// currency normalizes a requested currency, the base currency when empty.
+func (s *shippingSvc) currency(code string) (string, error) {
+	code = currency.Normalize(code)
+	if code == "" {
+		return s.rates.Base(), nil
+	}
+	if !s.rates.Supported(code) {
+		return "", apperror.WrapMessage(apperror.ErrBadRequest, nil, fmt.Sprintf("currency %s is not supported", code))
+	}
+	return code, nil
+}
+
+func shippingCode(code string) string {
+	return strings.ToLower(strings.TrimSpace(code))
+}
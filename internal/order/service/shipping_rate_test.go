@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/quangdangfit/gocommon/validation"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	orderMocks "goshop/internal/order/repository/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/money"
+	"goshop/pkg/shipping"
+)
+
+func newShippingSvc(t *testing.T) (ShippingService, *orderMocks.ShippingRateRepository) {
+	repo := orderMocks.NewShippingRateRepository(t)
+	return NewShippingService(validation.New(), repo, testRates, testMethods), repo
+}
+
+func TestShippingService_Methods(t *testing.T) {
+	svc, repo := newShippingSvc(t)
+	repo.On("List", mock.Anything).Return(nil, nil).Once()
+	methods, err := svc.Methods(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, testMethods.List(), methods)
+
+	// Configured rates replace the fallback; inactive ones aren't offered.
+	repo.On("List", mock.Anything).Return(configuredRates(), nil).Once()
+	methods, err = svc.Methods(context.Background())
+	require.NoError(t, err)
+	require.Len(t, methods, 2)
+	require.Equal(t, "standard", methods[0].Code)
+	require.Equal(t, "express", methods[1].Code)
+
+	// A stored rule that no longer builds, say after the base currency changed, is an error.
+	repo.On("List", mock.Anything).Return([]*model.ShippingRate{{Code: "eu", Active: true, Rule: shipping.Flat(money.New(100, "EUR"))}}, nil).Once()
+	_, err = svc.Methods(context.Background())
+	require.ErrorContains(t, err, "shipping rate eu")
+
+	repo.On("List", mock.Anything).Return(nil, errors.New("db")).Once()
+	_, err = svc.Methods(context.Background())
+	require.EqualError(t, err, "db")
+}
+
+func TestShippingService_Fee(t *testing.T) {
+	svc, repo := newShippingSvc(t)
+	repo.On("List", mock.Anything).Return(configuredRates(), nil)
+	parcel := shipping.Parcel{Country: "US", Subtotal: money.New(1000, "USD"), Grams: 200}
+
+	method, fee, err := svc.Fee(context.Background(), "", parcel, "JPY")
+	require.NoError(t, err)
+	require.Equal(t, "standard", method.Code)
+	require.Equal(t, money.New(758, "JPY"), fee)
+
+	method, fee, err = svc.Fee(context.Background(), "EXPRESS", parcel, "USD")
+	require.NoError(t, err)
+	require.Equal(t, "express", method.Code)
+	require.Equal(t, money.New(2000, "USD"), fee)
+
+	_, _, err = svc.Fee(context.Background(), "pickup", parcel, "USD")
+	requireAppError(t, err, apperror.ErrBadRequest)
+
+	parcel.Country = "VN"
+	_, _, err = svc.Fee(context.Background(), "express", parcel, "USD")
+	requireAppError(t, err, apperror.ErrBadRequest)
+}
+
+func TestShippingService_Create(t *testing.T) {
+	svc, repo := newShippingSvc(t)
+	rule := shipping.Rule{Type: shipping.RuleFreeOver, Threshold: money.New(5000, "USD"), Below: &shipping.Rule{Type: shipping.RuleFlat, Amount: money.New(499, "USD")}}
+	repo.On("GetByCode", mock.Anything, "standard").Return(nil, gorm.ErrRecordNotFound).Once()
+	repo.On("Create", mock.Anything, &model.ShippingRate{Code: "standard", Name: "Standard", Active: true, Rule: rule}).Return(nil).Once()
+
+	rate, err := svc.Create(context.Background(), &domain.CreateShippingRateReq{Code: " Standard", Name: "Standard", Rule: rule})
+	require.NoError(t, err)
+	require.Equal(t, "standard", rate.Code)
+
+	inactive := false
+	repo.On("GetByCode", mock.Anything, "pickup").Return(nil, gorm.ErrRecordNotFound).Once()
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(r *model.ShippingRate) bool { return !r.Active })).Return(nil).Once()
+	_, err = svc.Create(context.Background(), &domain.CreateShippingRateReq{Code: "pickup", Active: &inactive, Rule: shipping.Flat(money.Money{})})
+	require.NoError(t, err)
+}
+
+func TestShippingService_CreateRejects(t *testing.T) {
+	tests := []struct {
+		name  string
+		req   domain.CreateShippingRateReq
+		setup func(repo *orderMocks.ShippingRateRepository)
+		want  *apperror.AppError
+	}{
+		{name: "no_code", req: domain.CreateShippingRateReq{Rule: shipping.Flat(money.Money{})}},
+		{name: "bad_rule", req: domain.CreateShippingRateReq{Code: "x", Rule: shipping.Flat(money.New(100, "EUR"))}, want: apperror.ErrBadRequest},
+		{
+			name: "duplicate",
+			req:  domain.CreateShippingRateReq{Code: "standard", Rule: shipping.Flat(money.Money{})},
+			setup: func(repo *orderMocks.ShippingRateRepository) {
+				repo.On("GetByCode", mock.Anything, "standard").Return(&model.ShippingRate{Code: "standard"}, nil).Once()
+			},
+			want: apperror.ErrConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc, repo := newShippingSvc(t)
+			if tt.setup != nil {
+				tt.setup(repo)
+			}
+			_, err := svc.Create(context.Background(), &tt.req)
+			require.Error(t, err)
+			if tt.want != nil {
+				requireAppError(t, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestShippingService_Update(t *testing.T) {
+	svc, repo := newShippingSvc(t)
+	repo.On("GetByCode", mock.Anything, "standard").
+		Return(&model.ShippingRate{Code: "standard", Name: "Standard", Active: true, Rule: shipping.Flat(money.New(499, "USD"))}, nil).Once()
+	position, active := 2, false
+	rule := shipping.Flat(money.New(599, "USD"))
+	repo.On("Update", mock.Anything, &model.ShippingRate{Code: "standard", Name: "Standard", Position: 2, Rule: rule}).Return(nil).Once()
+
+	rate, err := svc.Update(context.Background(), "Standard", &domain.UpdateShippingRateReq{Position: &position, Active: &active, Rule: &rule})
+	require.NoError(t, err)
+	require.False(t, rate.Active)
+
+	repo.On("GetByCode", mock.Anything, "standard").Return(&model.ShippingRate{Code: "standard"}, nil).Once()
+	bad := shipping.Rule{Type: shipping.RuleZones}
+	_, err = svc.Update(context.Background(), "standard", &domain.UpdateShippingRateReq{Rule: &bad})
+	requireAppError(t, err, apperror.ErrBadRequest)
+
+	repo.On("GetByCode", mock.Anything, "drone").Return(nil, gorm.ErrRecordNotFound).Once()
+	_, err = svc.Update(context.Background(), "drone", &domain.UpdateShippingRateReq{Name: "Drone"})
+	requireAppError(t, err, apperror.ErrNotFound)
+}
+
+func TestShippingService_Delete(t *testing.T) {
+	svc, repo := newShippingSvc(t)
+	rate := &model.ShippingRate{ID: "r1", Code: "express"}
+	repo.On("GetByCode", mock.Anything, "express").Return(rate, nil).Once()
+	repo.On("Delete", mock.Anything, rate).Return(nil).Once()
+	require.NoError(t, svc.Delete(context.Background(), "express"))
+
+	repo.On("GetByCode", mock.Anything, "drone").Return(nil, gorm.ErrRecordNotFound).Once()
+	requireAppError(t, svc.Delete(context.Background(), "drone"), apperror.ErrNotFound)
+}
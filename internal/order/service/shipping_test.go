@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/money"
+	"goshop/pkg/shipping"
+)
+
+// placeShippedOrder wires PlaceOrder for a single p1×1 line in EUR, capturing the order as
+// saved.
+func placeShippedOrder(f *markPaidFixture, saved **model.Order) {
+	f.productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{ID: "p1", Price: money.New(1000, "USD")}, nil).Once()
+	f.repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, "", money.Zero("EUR")).
+		Return(&model.Order{ID: "o1", UserID: "u1", Currency: "EUR", FinalPrice: money.New(920, "EUR"),
+			Lines: []*model.OrderLine{{ProductID: "p1", Quantity: 1}}}, nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).
+		Run(func(args mock.Arguments) { *saved = args.Get(1).(*model.Order) }).Once()
+	f.productRepo.On("ReserveStock", mock.Anything, "p1", 1).Return(nil).Once()
+	f.warehouses.On("ListStock", mock.Anything, "p1").Return([]*model.WarehouseStock{
+		warehouseStock("w-us", "US", "Austin", 0, 5),
+		warehouseStock("w-han", "VN", "Hanoi", 1, 5),
+	}, nil).Once()
+	f.reservRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCreated")).Return(nil).Once()
+}
+
+func TestPlaceOrder_SnapshotsAddressesAndChargesShipping(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	var saved *model.Order
+	placeShippedOrder(f, &saved)
+	f.userRepo.On("GetAddress", mock.Anything, "u1", "a1").
+		Return(&model.Address{ID: "a1", UserID: "u1", Name: "Lan", Phone: "090", Street: "1 Trang Tien", City: "Hanoi", Country: "VN"}, nil).Once()
+	// The saved address, not the default one, picks the warehouse.
+	f.warehouses.On("Reserve", mock.Anything, "w-han", "p1", 1).Return(nil).Once()
+
+	_, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID:            "u1",
+		Lines:             []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}},
+		Currency:          "EUR",
+		ShippingMethod:    "express",
+		ShippingAddressID: "a1",
+		BillingAddress:    &domain.AddressReq{Name: "Acme", Phone: "1", Street: "2 Main St", City: "Austin", Country: "US"},
+	})
+	require.NoError(t, err)
+	require.Equal(t, "express", saved.ShippingMethod)
+	require.Equal(t, money.New(919, "EUR"), saved.ShippingFee) // 9.99 USD
+	require.Equal(t, money.New(1839, "EUR"), saved.FinalPrice)
+	require.Equal(t, model.OrderAddress{Name: "Lan", Phone: "090", Street: "1 Trang Tien", City: "Hanoi", Country: "VN"}, saved.ShippingAddress)
+	require.Equal(t, "Acme", saved.BillingAddress.Name)
+}
+
+func TestPlaceOrder_DefaultAddressAndMethod(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	var saved *model.Order
+	placeShippedOrder(f, &saved)
+	f.userRepo.On("GetDefaultAddress", mock.Anything, "u1").
+		Return(&model.Address{ID: "a2", UserID: "u1", Name: "Lan", City: "Austin", Country: "US", IsDefault: true}, nil).Once()
+	f.warehouses.On("Reserve", mock.Anything, "w-us", "p1", 1).Return(nil).Once()
+
+	_, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID:   "u1",
+		Lines:    []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}},
+		Currency: "EUR",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "standard", saved.ShippingMethod)
+	require.Equal(t, money.New(920, "EUR"), saved.FinalPrice)
+	require.Equal(t, "Austin", saved.ShippingAddress.City)
+	// Billed to where it ships.
+	require.Equal(t, saved.ShippingAddress, saved.BillingAddress)
+}
+
+func TestPlaceOrder_RejectsBadShipping(t *testing.T) {
+	line := []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}}
+	inline := &domain.AddressReq{Name: "Lan", Phone: "090", Street: "1 Trang Tien", City: "Hanoi", Country: "VN"}
+	tests := []struct {
+		name  string
+		req   domain.PlaceOrderReq
+		setup func(f *markPaidFixture)
+	}{
+		{name: "unknown_method", req: domain.PlaceOrderReq{ShippingMethod: "drone"}},
+		{name: "id_and_inline", req: domain.PlaceOrderReq{ShippingAddressID: "a1", ShippingAddress: inline}},
+		{name: "incomplete_inline", req: domain.PlaceOrderReq{ShippingAddress: &domain.AddressReq{City: "Hanoi"}}},
+		{
+			name: "someone_elses_address",
+			req:  domain.PlaceOrderReq{BillingAddressID: "a9"},
+			setup: func(f *markPaidFixture) {
+				f.userRepo.On("GetDefaultAddress", mock.Anything, "u1").Return(nil, nil).Once()
+				f.userRepo.On("GetAddress", mock.Anything, "u1", "a9").Return(nil, gorm.ErrRecordNotFound).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newMarkPaidFixture(t)
+			f.productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{ID: "p1", Price: money.New(100, "USD")}, nil).Maybe()
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+			f.userRepo.On("GetDefaultAddress", mock.Anything, "u1").Return(nil, nil).Maybe()
+			req := tt.req
+			req.UserID, req.Lines = "u1", line
+			_, err := f.svc.PlaceOrder(context.Background(), &req)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestPlaceOrder_AddressLookupError(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	f.productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{ID: "p1", Price: money.New(100, "USD")}, nil).Once()
+	f.userRepo.On("GetAddress", mock.Anything, "u1", "a1").Return(nil, errors.New("db")).Once()
+
+	_, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID:            "u1",
+		Lines:             []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}},
+		ShippingAddressID: "a1",
+	})
+	require.EqualError(t, err, "db")
+}
+
+// configuredRates are shipping rates as admins would set them up: standard ships everywhere,
+// by zone and weight; express ships to the US only; pickup is switched off.
+func configuredRates() []*model.ShippingRate {
+	usd := func(amount int64) money.Money { return money.New(amount, "USD") }
+	return []*model.ShippingRate{
+		{Code: "standard", Name: "Standard", Active: true, Rule: shipping.Rule{Type: shipping.RuleZones, Zones: []shipping.ZoneRule{
+			{Countries: []string{"VN"}, Rule: shipping.Flat(usd(200))},
+			{Rule: shipping.Rule{Type: shipping.RuleWeightTiers, Tiers: []shipping.Tier{
+				{UpToGrams: 1000, Rate: usd(500)},
+				{Rate: usd(1200)},
+			}}},
+		}}},
+		{Code: "express", Name: "Express", Active: true, Rule: shipping.Rule{Type: shipping.RuleZones, Zones: []shipping.ZoneRule{
+			{Countries: []string{"US"}, Rule: shipping.Flat(usd(2000))},
+		}}},
+		{Code: "pickup", Rule: shipping.Flat(money.Money{})},
+	}
+}
+
+func TestPlaceOrder_ChargesConfiguredRate(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	f.shippingRates = []*model.ShippingRate{{Code: "tiered", Active: true, Rule: shipping.Rule{Type: shipping.RulePriceTiers, Tiers: []shipping.Tier{
+		{UpTo: money.New(500, "USD"), Rate: money.New(300, "USD")},
+		{Rate: money.New(700, "USD")},
+	}}}}
+	var saved *model.Order
+	placeShippedOrder(f, &saved)
+	f.userRepo.On("GetDefaultAddress", mock.Anything, "u1").Return(nil, nil).Once()
+	f.warehouses.On("Reserve", mock.Anything, "w-us", "p1", 1).Return(nil).Once()
+
+	_, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID:   "u1",
+		Lines:    []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}},
+		Currency: "EUR",
+	})
+	require.NoError(t, err)
+	// The 10.00 USD line falls in the open tier: 7.00 USD.
+	require.Equal(t, "tiered", saved.ShippingMethod)
+	require.Equal(t, money.New(644, "EUR"), saved.ShippingFee)
+	require.Equal(t, money.New(1564, "EUR"), saved.FinalPrice)
+}
+
+func TestQuoteShipping(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	f.shippingRates = configuredRates()
+	f.productRepo.On("GetProductByID", mock.Anything, "p1").
+		Return(&model.Product{ID: "p1", Price: money.New(1000, "USD"), WeightGrams: 400}, nil)
+
+	tests := []struct {
+		name    string
+		country string
+		qty     uint
+		want    []*domain.ShippingQuote
+	}{
+		{name: "zone", country: "VN", qty: 1, want: []*domain.ShippingQuote{{Code: "standard", Name: "Standard", Rate: money.New(184, "EUR")}}},
+		{name: "light", country: "DE", qty: 2, want: []*domain.ShippingQuote{{Code: "standard", Name: "Standard", Rate: money.New(460, "EUR")}}},
+		{name: "heavy", country: "DE", qty: 3, want: []*domain.ShippingQuote{{Code: "standard", Name: "Standard", Rate: money.New(1104, "EUR")}}},
+		{name: "express", country: "US", qty: 1, want: []*domain.ShippingQuote{
+			{Code: "standard", Name: "Standard", Rate: money.New(460, "EUR")},
+			{Code: "express", Name: "Express", Rate: money.New(1840, "EUR")},
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			quotes, err := f.svc.QuoteShipping(context.Background(), &domain.QuoteShippingReq{
+				Lines:    []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: tt.qty}},
+				Country:  tt.country,
+				Currency: "EUR",
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, quotes)
+		})
+	}
+
+	_, err := f.svc.QuoteShipping(context.Background(), &domain.QuoteShippingReq{})
+	require.Error(t, err)
+	_, err = f.svc.QuoteShipping(context.Background(), &domain.QuoteShippingReq{
+		Lines: []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}}, Currency: "GBP",
+	})
+	requireAppError(t, err, apperror.ErrBadRequest)
+}
@@ -16,9 +16,11 @@ import (
 	orderRepo "goshop/internal/order/repository"
 	orderMocks "goshop/internal/order/repository/mocks"
 	serviceMocks "goshop/internal/order/service/mocks"
+	"goshop/pkg/apperror"
 	"goshop/pkg/config"
 	dbsMocks "goshop/pkg/dbs/mocks"
-	notifMocks "goshop/pkg/notification/mocks"
+	"goshop/pkg/eventbus"
+	"goshop/pkg/stock"
 )
 
 type sweepFixture struct {
@@ -28,7 +30,9 @@ type sweepFixture struct {
 	productRepo *orderMocks.ProductRepository
 	userRepo    *orderMocks.UserRepository
 	reservRepo  *orderMocks.ReservationRepository
-	notifier    *notifMocks.Notifier
+	outbox      *serviceMocks.EventOutbox
+	ledger      *serviceMocks.StockLedger
+	warehouses  *orderMocks.WarehouseRepository
 }
 
 func newSweepFixture(t *testing.T) *sweepFixture {
@@ -39,10 +43,13 @@ func newSweepFixture(t *testing.T) *sweepFixture {
 	userRepo := orderMocks.NewUserRepository(t)
 	reservRepo := orderMocks.NewReservationRepository(t)
 	couponSvc := serviceMocks.NewCouponService(t)
-	notifier := notifMocks.NewNotifier(t)
+	outbox := serviceMocks.NewEventOutbox(t)
+	ledger := serviceMocks.NewStockLedger(t)
+	warehouseRepo := orderMocks.NewWarehouseRepository(t)
 	db.On("WithTransaction", mock.Anything).Return(func(fn func() error) error { return fn() }).Maybe()
-	svc := NewOrderService(validation.New(), db, repo, productRepo, userRepo, reservRepo, couponSvc, notifier)
-	return &sweepFixture{svc, db, repo, productRepo, userRepo, reservRepo, notifier}
+	svc := NewOrderService(validation.New(), db, repo, productRepo, userRepo, reservRepo, couponSvc, outbox, ledger,
+		warehouseRepo, stock.AllocateNearest, testRates, newTestShipping(t, nil), newTestTax(t, nil), testInvoices(t), serviceMocks.NewPaymentSettler(t))
+	return &sweepFixture{svc, db, repo, productRepo, userRepo, reservRepo, outbox, ledger, warehouseRepo}
 }
 
 func TestSweep_EmptyBatchReturnsZero(t *testing.T) {
@@ -66,18 +73,57 @@ func TestSweep_HappyPathReleasesAndCancels(t *testing.T) {
 		{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1},
 	}
 	f.reservRepo.On("FindExpired", mock.Anything, mock.Anything, 100).Return(expired, nil).Once()
-	f.repo.On("GetOrderByID", mock.Anything, "o1", false).
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).
 		Return(&model.Order{ID: "o1", Status: model.OrderStatusPendingPayment, UserID: "u1"}, nil)
 	f.productRepo.On("ReleaseReservation", mock.Anything, "p1", 1).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, stock.Movement{
+		ProductID:     "p1",
+		Kind:          stock.MovementExpire,
+		ReservedDelta: -1,
+		Actor:         stock.ActorSystem,
+		Reason:        "reservation expired",
+		OrderID:       "o1",
+		ReservationID: "r1",
+	}).Return(nil).Once()
 	f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusReleased).Return(nil).Once()
 	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
-	// Notify goroutine
-	f.userRepo.On("GetUserByID", mock.Anything, "u1").Return(nil, nil).Maybe()
+	f.userRepo.On("GetUserByID", mock.Anything, "u1").Return(&model.User{ID: "u1", Email: "u1@example.com"}, nil).Once()
+	payload := eventbus.OrderPayload{
+		OrderID:   "o1",
+		UserID:    "u1",
+		UserEmail: "u1@example.com",
+		Status:    string(model.OrderStatusCancelled),
+		Lines:     []eventbus.OrderLine{},
+	}
+	expiredCall := f.outbox.On("Add", mock.Anything, eventbus.OrderReservationExpired{OrderPayload: payload}).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, eventbus.OrderCancelled{
+		OrderPayload: payload,
+		Reason:       CancelReasonReservationExpired,
+	}).Return(nil).Once().NotBefore(expiredCall)
 
 	n, err := f.svc.SweepExpiredReservations(context.Background(), 100)
 	require.NoError(t, err)
 	require.Equal(t, 1, n)
-	time.Sleep(20 * time.Millisecond)
+}
+
+func TestSweep_OutboxErrorLeavesOrderForNextRun(t *testing.T) {
+	f := newSweepFixture(t)
+	expired := []*model.StockReservation{
+		{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1},
+	}
+	f.reservRepo.On("FindExpired", mock.Anything, mock.Anything, 100).Return(expired, nil).Once()
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).
+		Return(&model.Order{ID: "o1", Status: model.OrderStatusPendingPayment, UserID: "u1"}, nil)
+	f.productRepo.On("ReleaseReservation", mock.Anything, "p1", 1).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+	f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusReleased).Return(nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	f.userRepo.On("GetUserByID", mock.Anything, "u1").Return(nil, errors.New("db")).Once()
+	f.outbox.On("Add", mock.Anything, mock.Anything).Return(errors.New("db")).Once()
+
+	n, err := f.svc.SweepExpiredReservations(context.Background(), 100)
+	require.NoError(t, err)
+	require.Zero(t, n)
 }
 
 func TestSweep_SkipsNonPendingOrder(t *testing.T) {
@@ -86,14 +132,33 @@ func TestSweep_SkipsNonPendingOrder(t *testing.T) {
 		{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1},
 	}
 	f.reservRepo.On("FindExpired", mock.Anything, mock.Anything, 100).Return(expired, nil).Once()
-	f.repo.On("GetOrderByID", mock.Anything, "o1", false).
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).
 		Return(&model.Order{ID: "o1", Status: model.OrderStatusPaid, UserID: "u1"}, nil)
-	f.userRepo.On("GetUserByID", mock.Anything, "u1").Return(nil, nil).Maybe()
 
 	n, err := f.svc.SweepExpiredReservations(context.Background(), 100)
 	require.NoError(t, err)
 	require.Equal(t, 1, n)
-	time.Sleep(20 * time.Millisecond)
+}
+
+func TestSweep_PaymentFailedOrderReleasesButStaysOpen(t *testing.T) {
+	f := newSweepFixture(t)
+	expired := []*model.StockReservation{
+		{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 2},
+	}
+	f.reservRepo.On("FindExpired", mock.Anything, mock.Anything, 100).Return(expired, nil).Once()
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).
+		Return(&model.Order{ID: "o1", Status: model.OrderStatusPaymentFailed, UserID: "u1"}, nil)
+	f.productRepo.On("ReleaseReservation", mock.Anything, "p1", 2).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.MatchedBy(func(m stock.Movement) bool {
+		return m.Kind == stock.MovementExpire && m.ReservedDelta == -2
+	})).Return(nil).Once()
+	f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusReleased).Return(nil).Once()
+
+	n, err := f.svc.SweepExpiredReservations(context.Background(), 100)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	f.repo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything)
+	f.outbox.AssertNotCalled(t, "Add", mock.Anything, mock.Anything)
 }
 
 func TestSweep_TxErrorIsLoggedNotReturned(t *testing.T) {
@@ -102,7 +167,7 @@ func TestSweep_TxErrorIsLoggedNotReturned(t *testing.T) {
 		{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1},
 	}
 	f.reservRepo.On("FindExpired", mock.Anything, mock.Anything, 100).Return(expired, nil).Once()
-	f.repo.On("GetOrderByID", mock.Anything, "o1", false).
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).
 		Return(&model.Order{ID: "o1", Status: model.OrderStatusPendingPayment}, nil)
 	f.productRepo.On("ReleaseReservation", mock.Anything, "p1", 1).Return(errors.New("locked")).Once()
 
@@ -118,16 +183,18 @@ func TestSweep_OrphanedReservation_StillReleasesAndDoesNotError(t *testing.T) {
 	}
 	f.reservRepo.On("FindExpired", mock.Anything, mock.Anything, 100).Return(expired, nil).Once()
 	// Order row is missing — GetOrderByID returns ErrRecordNotFound.
-	f.repo.On("GetOrderByID", mock.Anything, "ghost", false).Return(nil, gorm.ErrRecordNotFound).Once()
+	f.repo.On("GetOrderByID", mock.Anything, "ghost", true).Return(nil, gorm.ErrRecordNotFound).Once()
 	// Sweeper should still release the held stock and mark the reservation released.
 	f.productRepo.On("ReleaseReservation", mock.Anything, "p1", 2).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.MatchedBy(func(m stock.Movement) bool {
+		return m.Kind == stock.MovementExpire && m.ReservedDelta == -2 && m.OrderID == "ghost"
+	})).Return(nil).Once()
 	f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusReleased).Return(nil).Once()
-	// And NOT call UpdateOrder, since there is no order row to update.
+	// And NOT call UpdateOrder or record an event, since there is no order row to update.
 
 	n, err := f.svc.SweepExpiredReservations(context.Background(), 100)
 	require.NoError(t, err)
 	require.Equal(t, 1, n)
-	time.Sleep(20 * time.Millisecond)
 }
 
 func TestSweep_ReservationAlreadyReleased_IsTolerated(t *testing.T) {
@@ -136,20 +203,38 @@ func TestSweep_ReservationAlreadyReleased_IsTolerated(t *testing.T) {
 		{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1},
 	}
 	f.reservRepo.On("FindExpired", mock.Anything, mock.Anything, 100).Return(expired, nil).Once()
-	f.repo.On("GetOrderByID", mock.Anything, "o1", false).
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).
 		Return(&model.Order{ID: "o1", Status: model.OrderStatusPendingPayment, UserID: "u1"}, nil)
 	// The product's counter is already drained (drift). Sweeper should still
-	// mark the reservation released and cancel the order.
+	// mark the reservation released and cancel the order, with no ledger row since
+	// no counter moved.
 	f.productRepo.On("ReleaseReservation", mock.Anything, "p1", 1).
 		Return(orderRepo.ErrReservationAlreadyReleased).Once()
 	f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusReleased).Return(nil).Once()
 	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
-	f.userRepo.On("GetUserByID", mock.Anything, "u1").Return(nil, nil).Maybe()
+	f.userRepo.On("GetUserByID", mock.Anything, "u1").Return(nil, nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderReservationExpired")).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCancelled")).Return(nil).Once()
 
 	n, err := f.svc.SweepExpiredReservations(context.Background(), 100)
 	require.NoError(t, err)
 	require.Equal(t, 1, n)
-	time.Sleep(20 * time.Millisecond)
+}
+
+func TestSweep_LedgerErrorLeavesOrderForNextRun(t *testing.T) {
+	f := newSweepFixture(t)
+	expired := []*model.StockReservation{
+		{ID: "r1", OrderID: "o1", ProductID: "p1", Quantity: 1},
+	}
+	f.reservRepo.On("FindExpired", mock.Anything, mock.Anything, 100).Return(expired, nil).Once()
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).
+		Return(&model.Order{ID: "o1", Status: model.OrderStatusPendingPayment, UserID: "u1"}, nil)
+	f.productRepo.On("ReleaseReservation", mock.Anything, "p1", 1).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.Anything).Return(errors.New("db")).Once()
+
+	n, err := f.svc.SweepExpiredReservations(context.Background(), 100)
+	require.NoError(t, err)
+	require.Zero(t, n)
 }
 
 func TestInsufficientStockErrorMessage(t *testing.T) {
@@ -157,3 +242,28 @@ func TestInsufficientStockErrorMessage(t *testing.T) {
 	require.Contains(t, e.Error(), "p1")
 	require.Contains(t, e.Error(), "5")
 }
+
+func TestExtendReservations(t *testing.T) {
+	until := time.Now().Add(72 * time.Hour)
+	tests := []struct {
+		name    string
+		status  model.OrderStatus
+		wantErr error
+	}{
+		{name: "pending_payment_extends", status: model.OrderStatusPendingPayment},
+		{name: "paid_is_rejected", status: model.OrderStatusPaid, wantErr: apperror.ErrInvalidStatus},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSweepFixture(t)
+			f.repo.On("GetOrderByID", mock.Anything, "o1", false).
+				Return(&model.Order{ID: "o1", Status: tt.status}, nil).Once()
+			if tt.wantErr == nil {
+				f.reservRepo.On("ExtendActive", mock.Anything, "o1", until).Return(nil).Once()
+			}
+
+			err := f.svc.ExtendReservations(context.Background(), "o1", until)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,163 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/quangdangfit/gocommon/validation"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	"goshop/internal/order/repository"
+	"goshop/pkg/apperror"
+	"goshop/pkg/money"
+	"goshop/pkg/tax"
+)
+
+//go:generate mockery --name=TaxService
+type TaxService interface {
+	// Mode is whether the shop's prices include tax.
+	Mode() tax.Mode
+	List(ctx context.Context) ([]*model.TaxRate, error)
+	Create(ctx context.Context, req *domain.CreateTaxRateReq) (*model.TaxRate, error)
+	Update(ctx context.Context, id string, req *domain.UpdateTaxRateReq) (*model.TaxRate, error)
+	Delete(ctx context.Context, id string) error
+	// Apply taxes the order's lines where it ships, by each line's TaxClass: it sets every
+	// line's TaxRate and TaxAmount and the order's TaxAmount and TaxMode. A line no rate covers
+	// is untaxed. FinalPrice is left to the caller.
+	Apply(ctx context.Context, order *model.Order) error
+}
+
+type taxSvc struct {
+	validator validation.Validation
+	repo      repository.TaxRateRepository
+	mode      tax.Mode
+}
+
+// NewTaxService taxes orders with the configured rates; mode is whether prices include tax.
+func NewTaxService(validator validation.Validation, repo repository.TaxRateRepository, mode tax.Mode) TaxService {
+	if !mode.Inclusive() {
+		mode = tax.Exclusive
+	}
+	return &taxSvc{validator: validator, repo: repo, mode: mode}
+}
+
+func (s *taxSvc) Mode() tax.Mode {
+	return s.mode
+}
+
+func (s *taxSvc) List(ctx context.Context) ([]*model.TaxRate, error) {
+	return s.repo.List(ctx)
+}
+
+func (s *taxSvc) Create(ctx context.Context, req *domain.CreateTaxRateReq) (*model.TaxRate, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	country := strings.ToUpper(strings.TrimSpace(req.Country))
+	region := strings.ToUpper(strings.TrimSpace(req.Region))
+	if country == "" && region != "" {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "a region needs a country")
+	}
+	rate := model.TaxRate{
+		Country:  country,
+		Region:   region,
+		TaxClass: taxClass(req.TaxClass),
+		Name:     req.Name,
+		Rate:     req.Rate,
+	}
+	rates, err := s.repo.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rates {
+		if r.Country == rate.Country && r.Region == rate.Region && r.TaxClass == rate.TaxClass {
+			return nil, apperror.WrapMessage(apperror.ErrConflict, nil,
+				fmt.Sprintf("%s already has a rate for tax class %s", destination(r), r.TaxClass))
+		}
+	}
+	if err := s.repo.Create(ctx, &rate); err != nil {
+		return nil, err
+	}
+	return &rate, nil
+}
+
+func (s *taxSvc) Update(ctx context.Context, id string, req *domain.UpdateTaxRateReq) (*model.TaxRate, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
+	rate, err := s.get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if req.Name != "" {
+		rate.Name = req.Name
+	}
+	if req.Rate != nil {
+		rate.Rate = *req.Rate
+	}
+	if err := s.repo.Update(ctx, rate); err != nil {
+		return nil, err
+	}
+	return rate, nil
+}
+
+func (s *taxSvc) Delete(ctx context.Context, id string) error {
+	rate, err := s.get(ctx, id)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, rate)
+}
+
+func (s *taxSvc) get(ctx context.Context, id string) (*model.TaxRate, error) {
+	rate, err := s.repo.GetByID(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, err, "tax rate not found")
+	}
+	return rate, err
+}
+
+func (s *taxSvc) Apply(ctx context.Context, order *model.Order) error {
+	rows, err := s.repo.List(ctx)
+	if err != nil {
+		return err
+	}
+	rates := make([]tax.Rate, len(rows))
+	for i, row := range rows {
+		rates[i] = row.TaxRule()
+	}
+
+	to := order.ShippingAddress
+	order.TaxMode = s.mode
+	order.TaxAmount = money.Zero(order.TotalPrice.Currency())
+	for _, line := range order.Lines {
+		line.TaxClass = taxClass(line.TaxClass)
+		line.TaxRate, _ = tax.Lookup(rates, to.Country, to.Region, line.TaxClass)
+		line.TaxAmount = tax.Of(order.LineNet(line), line.TaxRate, s.mode)
+		order.TaxAmount = order.TaxAmount.Add(line.TaxAmount)
+	}
+	return nil
+}
+
+func taxClass(class string) string {
+	class = strings.ToLower(strings.TrimSpace(class))
+	if class == "" {
+		return tax.Standard
+	}
+	return class
+}
+
+// destination names where a rate applies, for messages.
+func destination(r *model.TaxRate) string {
+	switch {
+	case r.Country == "":
+		return "the rest of the world"
+	case r.Region == "":
+		return r.Country
+	}
+	return r.Region + ", " + r.Country
+}
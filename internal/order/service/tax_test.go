@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/quangdangfit/gocommon/validation"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	orderMocks "goshop/internal/order/repository/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/money"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
+)
+
+func configuredTaxRates() []*model.TaxRate {
+	return []*model.TaxRate{
+		{Country: "US", TaxClass: tax.Standard, Rate: 500},
+		{Country: "US", Region: "CA", TaxClass: tax.Standard, Rate: 725},
+		{Country: "US", Region: "CA", TaxClass: "books", Rate: 0},
+		{Country: "DE", TaxClass: tax.Standard, Rate: 1900},
+	}
+}
+
+func newTaxSvc(t *testing.T, mode tax.Mode) (TaxService, *orderMocks.TaxRateRepository) {
+	repo := orderMocks.NewTaxRateRepository(t)
+	return NewTaxService(validation.New(), repo, mode), repo
+}
+
+func TestTaxService_Apply(t *testing.T) {
+	svc, repo := newTaxSvc(t, "")
+	repo.On("List", mock.Anything).Return(configuredTaxRates(), nil)
+	require.Equal(t, tax.Exclusive, svc.Mode())
+
+	// 30.00 of goods less 6.00 off: each line is taxed on its 80%.
+	order := &model.Order{
+		TotalPrice: money.New(3000, "USD"), DiscountAmount: money.New(600, "USD"),
+		ShippingAddress: model.OrderAddress{Region: "ca", Country: "us"},
+		Lines: []*model.OrderLine{
+			{ProductID: "p1", Quantity: 2, Price: money.New(2000, "USD")},
+			{ProductID: "p2", Quantity: 1, Price: money.New(1000, "USD"), TaxClass: "Books"},
+		},
+	}
+	require.NoError(t, svc.Apply(context.Background(), order))
+	require.Equal(t, tax.Exclusive, order.TaxMode)
+	require.Equal(t, "standard", order.Lines[0].TaxClass)
+	require.Equal(t, money.Percent(725), order.Lines[0].TaxRate)
+	require.Equal(t, money.New(116, "USD"), order.Lines[0].TaxAmount)
+	require.Equal(t, "books", order.Lines[1].TaxClass)
+	require.Equal(t, money.Zero("USD"), order.Lines[1].TaxAmount)
+	require.Equal(t, money.New(116, "USD"), order.TaxAmount)
+
+	// No rate covers Vietnam: untaxed.
+	order.ShippingAddress = model.OrderAddress{Country: "VN"}
+	require.NoError(t, svc.Apply(context.Background(), order))
+	require.Equal(t, money.Zero("USD"), order.TaxAmount)
+	require.Equal(t, money.Percent(0), order.Lines[0].TaxRate)
+}
+
+func TestTaxService_ApplyInclusive(t *testing.T) {
+	svc, repo := newTaxSvc(t, tax.Inclusive)
+	repo.On("List", mock.Anything).Return(configuredTaxRates(), nil)
+
+	order := &model.Order{
+		TotalPrice:      money.New(1190, "EUR"),
+		ShippingAddress: model.OrderAddress{Country: "DE"},
+		Lines:           []*model.OrderLine{{ProductID: "p1", Quantity: 1, Price: money.New(1190, "EUR")}},
+	}
+	require.NoError(t, svc.Apply(context.Background(), order))
+	require.Equal(t, tax.Inclusive, order.TaxMode)
+	require.Equal(t, money.New(190, "EUR"), order.TaxAmount)
+}
+
+func TestTaxService_Create(t *testing.T) {
+	svc, repo := newTaxSvc(t, tax.Exclusive)
+	repo.On("List", mock.Anything).Return(configuredTaxRates(), nil)
+	repo.On("Create", mock.Anything, &model.TaxRate{Country: "US", Region: "NY", TaxClass: tax.Standard, Name: "NY sales tax", Rate: 888}).
+		Return(nil).Once()
+
+	rate, err := svc.Create(context.Background(), &domain.CreateTaxRateReq{Country: "us", Region: " ny", Name: "NY sales tax", Rate: 888})
+	require.NoError(t, err)
+	require.Equal(t, "NY", rate.Region)
+
+	_, err = svc.Create(context.Background(), &domain.CreateTaxRateReq{Country: "US", Region: "CA", TaxClass: "Books"})
+	requireAppError(t, err, apperror.ErrConflict)
+	_, err = svc.Create(context.Background(), &domain.CreateTaxRateReq{Region: "CA", Rate: 100})
+	requireAppError(t, err, apperror.ErrBadRequest)
+	_, err = svc.Create(context.Background(), &domain.CreateTaxRateReq{Country: "USA", Rate: 100})
+	require.Error(t, err)
+	_, err = svc.Create(context.Background(), &domain.CreateTaxRateReq{Country: "US", Rate: 10001})
+	require.Error(t, err)
+}
+
+func TestTaxService_Update(t *testing.T) {
+	svc, repo := newTaxSvc(t, tax.Exclusive)
+	repo.On("GetByID", mock.Anything, "r1").Return(&model.TaxRate{ID: "r1", Country: "DE", TaxClass: tax.Standard, Rate: 1600}, nil).Once()
+	repo.On("Update", mock.Anything, &model.TaxRate{ID: "r1", Country: "DE", TaxClass: tax.Standard, Name: "MwSt", Rate: 1900}).Return(nil).Once()
+
+	rate := money.Percent(1900)
+	updated, err := svc.Update(context.Background(), "r1", &domain.UpdateTaxRateReq{Name: "MwSt", Rate: &rate})
+	require.NoError(t, err)
+	require.Equal(t, money.Percent(1900), updated.Rate)
+
+	repo.On("GetByID", mock.Anything, "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+	_, err = svc.Update(context.Background(), "missing", &domain.UpdateTaxRateReq{Name: "x"})
+	requireAppError(t, err, apperror.ErrNotFound)
+}
+
+func TestTaxService_Delete(t *testing.T) {
+	svc, repo := newTaxSvc(t, tax.Exclusive)
+	rate := &model.TaxRate{ID: "r1"}
+	repo.On("GetByID", mock.Anything, "r1").Return(rate, nil).Once()
+	repo.On("Delete", mock.Anything, rate).Return(nil).Once()
+	require.NoError(t, svc.Delete(context.Background(), "r1"))
+
+	repo.On("GetByID", mock.Anything, "missing").Return(nil, gorm.ErrRecordNotFound).Once()
+	requireAppError(t, svc.Delete(context.Background(), "missing"), apperror.ErrNotFound)
+}
+
+// placeTaxedOrder expects a 2 × 10.00 USD order of p1, a book by its category, to California.
+func placeTaxedOrder(f *markPaidFixture, discount money.Money, created *[]*model.OrderLine, saved **model.Order) {
+	category := &model.Category{ID: "c1", TaxClass: "books"}
+	f.productRepo.On("GetProductByID", mock.Anything, "p1").
+		Return(&model.Product{ID: "p1", Price: money.New(1000, "USD"), Category: category}, nil).Once()
+	f.repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, mock.Anything, discount).
+		Run(func(args mock.Arguments) { *created = args.Get(2).([]*model.OrderLine) }).
+		Return(&model.Order{ID: "o1", UserID: "u1", Currency: "USD", TotalPrice: money.New(2000, "USD"), DiscountAmount: discount,
+			FinalPrice: money.New(2000, "USD").Sub(discount), Lines: []*model.OrderLine{{ProductID: "p1", Quantity: 2}}}, nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).
+		Run(func(args mock.Arguments) { *saved = args.Get(1).(*model.Order) }).Once()
+	f.productRepo.On("ReserveStock", mock.Anything, "p1", 2).Return(nil).Once()
+	f.warehouses.On("ListStock", mock.Anything, "p1").Return([]*model.WarehouseStock{warehouseStock("w-us", "US", "Austin", 0, 5)}, nil).Once()
+	f.warehouses.On("Reserve", mock.Anything, "w-us", "p1", 2).Return(nil).Once()
+	f.reservRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderCreated")).Return(nil).Once()
+}
+
+func TestPlaceOrder_AddsTax(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	f.taxRates = []*model.TaxRate{{Country: "US", Region: "CA", TaxClass: "books", Rate: 725}}
+	f.coupons.On("Apply", mock.Anything, "SAVE5", money.New(2000, "USD")).
+		Return(money.New(500, "USD"), &model.Coupon{ID: "c1", Code: "SAVE5"}, nil).Once()
+	f.coupons.On("IncrUsedCount", mock.Anything, "c1").Return(nil).Once()
+	var created []*model.OrderLine
+	var saved *model.Order
+	placeTaxedOrder(f, money.New(500, "USD"), &created, &saved)
+
+	_, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID:          "u1",
+		Lines:           []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 2}},
+		CouponCode:      "SAVE5",
+		ShippingAddress: &domain.AddressReq{Name: "Ann", Phone: "1", Street: "1 Main St", City: "Los Angeles", Region: "CA", Country: "US"},
+	})
+	require.NoError(t, err)
+	// 7.25% of the 15.00 left after the coupon, added on top.
+	require.Equal(t, "books", created[0].TaxClass)
+	require.Equal(t, money.Percent(725), created[0].TaxRate)
+	require.Equal(t, money.New(109, "USD"), created[0].TaxAmount)
+	require.Equal(t, tax.Exclusive, saved.TaxMode)
+	require.Equal(t, money.New(109, "USD"), saved.TaxAmount)
+	require.Equal(t, money.New(1609, "USD"), saved.FinalPrice)
+	require.Equal(t, "CA", saved.ShippingAddress.Region)
+}
+
+func TestPlaceOrder_TaxIncludedInPrices(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	inclusive, repo := newTaxSvc(t, tax.Inclusive)
+	repo.On("List", mock.Anything).Return([]*model.TaxRate{{Country: "US", TaxClass: "books", Rate: 725}}, nil)
+	svc := NewOrderService(validation.New(), f.db, f.repo, f.productRepo, f.userRepo, f.reservRepo, f.coupons, f.outbox, f.ledger,
+		f.warehouses, stock.AllocateNearest, testRates, newTestShipping(t, nil), inclusive, testInvoices(t), f.payments)
+	var created []*model.OrderLine
+	var saved *model.Order
+	placeTaxedOrder(f, money.Zero("USD"), &created, &saved)
+
+	_, err := svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID:          "u1",
+		Lines:           []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 2}},
+		ShippingAddress: &domain.AddressReq{Name: "Ann", Phone: "1", Street: "1 Main St", City: "Austin", Region: "TX", Country: "US"},
+	})
+	require.NoError(t, err)
+	// The 20.00 already contains 7.25%: 1.35 of it is tax, and the buyer pays 20.00.
+	require.Equal(t, money.New(135, "USD"), created[0].TaxAmount)
+	require.Equal(t, tax.Inclusive, saved.TaxMode)
+	require.Equal(t, money.New(135, "USD"), saved.TaxAmount)
+	require.Equal(t, money.New(2000, "USD"), saved.FinalPrice)
+}
@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"goshop/internal/order/domain"
+	"goshop/internal/order/model"
+	orderRepo "goshop/internal/order/repository"
+	"goshop/pkg/money"
+	"goshop/pkg/stock"
+)
+
+func warehouseStock(id, country, city string, priority, available int) *model.WarehouseStock {
+	return &model.WarehouseStock{
+		WarehouseID:   id,
+		Warehouse:     &model.Warehouse{ID: id, Country: country, City: city, Priority: priority, Active: true},
+		ProductID:     "p1",
+		StockQuantity: available,
+	}
+}
+
+// placeOrderOf wires everything PlaceOrder needs up to reserving a single p1×qty line.
+func placeOrderOf(f *markPaidFixture, qty int) {
+	f.productRepo.On("GetProductByID", mock.Anything, "p1").Return(&model.Product{ID: "p1", Price: money.New(100, "USD")}, nil).Once()
+	f.repo.On("CreateOrder", mock.Anything, "u1", mock.Anything, "", money.Zero("USD")).
+		Return(&model.Order{ID: "o1", UserID: "u1", Lines: []*model.OrderLine{{ProductID: "p1", Quantity: uint(qty)}}}, nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	f.productRepo.On("ReserveStock", mock.Anything, "p1", qty).Return(nil).Once()
+}
+
+func TestPlaceOrder_SplitsAcrossWarehousesNearestFirst(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	placeOrderOf(f, 3)
+	f.userRepo.On("GetDefaultAddress", mock.Anything, "u1").
+		Return(&model.Address{ID: "a1", UserID: "u1", Country: "VN", City: "Hanoi"}, nil).Once()
+	f.warehouses.On("ListStock", mock.Anything, "p1").Return([]*model.WarehouseStock{
+		warehouseStock("w-us", "US", "Austin", 0, 5),
+		warehouseStock("w-sgn", "VN", "Saigon", 1, 2),
+		warehouseStock("w-han", "vn", "HANOI", 2, 1),
+	}, nil).Once()
+	f.warehouses.On("Reserve", mock.Anything, "w-han", "p1", 1).Return(nil).Once()
+	f.warehouses.On("Reserve", mock.Anything, "w-sgn", "p1", 2).Return(nil).Once()
+	f.reservRepo.On("CreateMany", mock.Anything, mock.MatchedBy(func(rs []*model.StockReservation) bool {
+		return len(rs) == 2 &&
+			*rs[0].WarehouseID == "w-han" && rs[0].Quantity == 1 &&
+			*rs[1].WarehouseID == "w-sgn" && rs[1].Quantity == 2
+	})).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.MatchedBy(func(m stock.Movement) bool {
+		return m.Kind == stock.MovementReserve && m.WarehouseID == "w-han" && m.ReservedDelta == 1
+	})).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.MatchedBy(func(m stock.Movement) bool {
+		return m.Kind == stock.MovementReserve && m.WarehouseID == "w-sgn" && m.ReservedDelta == 2
+	})).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID: "u1",
+		Lines:  []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 3}},
+	})
+	require.NoError(t, err)
+}
+
+func TestPlaceOrder_WarehousesShortIsInsufficientStock(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(f *markPaidFixture)
+	}{
+		{
+			// The product total covers the line but the active warehouses don't.
+			name: "allocation_short",
+			setup: func(f *markPaidFixture) {
+				f.warehouses.On("ListStock", mock.Anything, "p1").
+					Return([]*model.WarehouseStock{warehouseStock("w1", "VN", "Hanoi", 0, 1)}, nil).Once()
+			},
+		},
+		{
+			name: "warehouse_reserve_short",
+			setup: func(f *markPaidFixture) {
+				f.warehouses.On("ListStock", mock.Anything, "p1").
+					Return([]*model.WarehouseStock{warehouseStock("w1", "VN", "Hanoi", 0, 5)}, nil).Once()
+				f.warehouses.On("Reserve", mock.Anything, "w1", "p1", 2).Return(orderRepo.ErrInsufficientStock).Once()
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newMarkPaidFixture(t)
+			placeOrderOf(f, 2)
+			f.userRepo.On("GetDefaultAddress", mock.Anything, "u1").Return(nil, nil).Once()
+			tt.setup(f)
+
+			_, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+				UserID: "u1",
+				Lines:  []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 2}},
+			})
+			var insufficient *InsufficientStockError
+			require.ErrorAs(t, err, &insufficient)
+			require.Equal(t, "p1", insufficient.ProductID)
+		})
+	}
+}
+
+func TestPlaceOrder_DefaultAddressErrorFallsBackToPriority(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	placeOrderOf(f, 1)
+	f.userRepo.On("GetDefaultAddress", mock.Anything, "u1").Return(nil, errors.New("db")).Once()
+	f.warehouses.On("ListStock", mock.Anything, "p1").Return([]*model.WarehouseStock{
+		warehouseStock("w-far", "US", "Austin", 5, 5),
+		warehouseStock("w-first", "DE", "Berlin", 1, 5),
+	}, nil).Once()
+	f.warehouses.On("Reserve", mock.Anything, "w-first", "p1", 1).Return(nil).Once()
+	f.reservRepo.On("CreateMany", mock.Anything, mock.Anything).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.Anything).Return(nil).Once()
+
+	_, err := f.svc.PlaceOrder(context.Background(), &domain.PlaceOrderReq{
+		UserID: "u1",
+		Lines:  []domain.PlaceOrderLineReq{{ProductID: "p1", Quantity: 1}},
+	})
+	require.NoError(t, err)
+}
+
+func TestMarkOrderPaid_CommitsWarehouseStock(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	warehouseID := "w1"
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}
+	reservations := []*model.StockReservation{{ID: "r1", OrderID: "o1", ProductID: "p1", WarehouseID: &warehouseID, Quantity: 2}}
+
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(reservations, nil).Once()
+	f.productRepo.On("CommitReservation", mock.Anything, "p1", 2).Return(nil).Once()
+	f.warehouses.On("Commit", mock.Anything, "w1", "p1", 2).Return(nil).Once()
+	f.ledger.On("Record", mock.Anything, mock.MatchedBy(func(m stock.Movement) bool {
+		return m.Kind == stock.MovementCommit && m.WarehouseID == "w1"
+	})).Return(nil).Once()
+	f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusCommitted).Return(nil).Once()
+	f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+	f.outbox.On("Add", mock.Anything, mock.AnythingOfType("eventbus.OrderPaid")).Return(nil).Once()
+	f.productRepo.On("GetProductByID", mock.Anything, "p1").
+		Return(&model.Product{ID: "p1", StockQuantity: 100}, nil).Once()
+
+	_, err := f.svc.MarkOrderPaid(context.Background(), "o1")
+	require.NoError(t, err)
+}
+
+func TestMarkOrderPaid_WarehouseCommitFails(t *testing.T) {
+	f := newMarkPaidFixture(t)
+	warehouseID := "w1"
+	order := &model.Order{ID: "o1", UserID: "u1", Status: model.OrderStatusPendingPayment}
+	reservations := []*model.StockReservation{{ID: "r1", OrderID: "o1", ProductID: "p1", WarehouseID: &warehouseID, Quantity: 2}}
+
+	f.repo.On("GetOrderByID", mock.Anything, "o1", true).Return(order, nil).Once()
+	f.reservRepo.On("FindActiveByOrderID", mock.Anything, "o1").Return(reservations, nil).Once()
+	f.productRepo.On("CommitReservation", mock.Anything, "p1", 2).Return(nil).Once()
+	f.warehouses.On("Commit", mock.Anything, "w1", "p1", 2).Return(errors.New("row not in expected state")).Once()
+
+	_, err := f.svc.MarkOrderPaid(context.Background(), "o1")
+	require.Error(t, err)
+}
+
+func TestSweep_ReleasesWarehouseStock(t *testing.T) {
+	tests := []struct {
+		name       string
+		releaseErr error
+	}{
+		{"released", nil},
+		{"warehouse_already_drained_is_tolerated", orderRepo.ErrReservationAlreadyReleased},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newSweepFixture(t)
+			warehouseID := "w1"
+			expired := []*model.StockReservation{
+				{ID: "r1", OrderID: "o1", ProductID: "p1", WarehouseID: &warehouseID, Quantity: 1},
+			}
+			f.reservRepo.On("FindExpired", mock.Anything, mock.Anything, 100).Return(expired, nil).Once()
+			f.repo.On("GetOrderByID", mock.Anything, "o1", true).
+				Return(&model.Order{ID: "o1", Status: model.OrderStatusPendingPayment, UserID: "u1"}, nil)
+			f.productRepo.On("ReleaseReservation", mock.Anything, "p1", 1).Return(nil).Once()
+			f.warehouses.On("Release", mock.Anything, "w1", "p1", 1).Return(tt.releaseErr).Once()
+			f.ledger.On("Record", mock.Anything, mock.MatchedBy(func(m stock.Movement) bool {
+				return m.Kind == stock.MovementExpire && m.WarehouseID == "w1"
+			})).Return(nil).Once()
+			f.reservRepo.On("UpdateStatus", mock.Anything, []string{"r1"}, model.ReservationStatusReleased).Return(nil).Once()
+			f.repo.On("UpdateOrder", mock.Anything, mock.Anything).Return(nil).Once()
+			f.userRepo.On("GetUserByID", mock.Anything, "u1").Return(nil, nil).Once()
+			f.outbox.On("Add", mock.Anything, mock.Anything).Return(nil).Twice()
+
+			n, err := f.svc.SweepExpiredReservations(context.Background(), 100)
+			require.NoError(t, err)
+			require.Equal(t, 1, n)
+		})
+	}
+}
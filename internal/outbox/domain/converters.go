@@ -0,0 +1,29 @@
+package domain
+
+import (
+	"goshop/internal/outbox/model"
+)
+
+func OutboxEventFromModel(m *model.OutboxEvent) *OutboxEvent {
+	if m == nil {
+		return nil
+	}
+	return &OutboxEvent{
+		ID:            m.ID,
+		CreatedAt:     m.CreatedAt,
+		Topic:         m.Topic,
+		Payload:       m.Payload,
+		Status:        string(m.Status),
+		Attempts:      m.Attempts,
+		NextAttemptAt: m.NextAttemptAt,
+		LastError:     m.LastError,
+	}
+}
+
+func OutboxEventsFromModel(rows []*model.OutboxEvent) []*OutboxEvent {
+	out := make([]*OutboxEvent, len(rows))
+	for i, r := range rows {
+		out[i] = OutboxEventFromModel(r)
+	}
+	return out
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"goshop/internal/outbox/model"
+)
+
+func TestOutboxEventFromModel(t *testing.T) {
+	assert.Nil(t, OutboxEventFromModel(nil))
+
+	at := time.Now()
+	e := OutboxEventFromModel(&model.OutboxEvent{
+		ID:            "e1",
+		Topic:         "order.created",
+		Payload:       `{"order_id":"o1"}`,
+		Status:        model.OutboxStatusFailed,
+		Attempts:      3,
+		NextAttemptAt: at,
+		LastError:     "boom",
+	})
+	assert.Equal(t, "e1", e.ID)
+	assert.Equal(t, "order.created", e.Topic)
+	assert.Equal(t, `{"order_id":"o1"}`, e.Payload)
+	assert.Equal(t, "failed", e.Status)
+	assert.Equal(t, 3, e.Attempts)
+	assert.Equal(t, at, e.NextAttemptAt)
+	assert.Equal(t, "boom", e.LastError)
+}
+
+func TestOutboxEventsFromModel(t *testing.T) {
+	out := OutboxEventsFromModel([]*model.OutboxEvent{{ID: "a"}, {ID: "b"}})
+	assert.Len(t, out, 2)
+	assert.Equal(t, "b", out[1].ID)
+	assert.Empty(t, OutboxEventsFromModel(nil))
+}
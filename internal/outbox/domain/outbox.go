@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"goshop/pkg/paging"
+)
+
+type OutboxEvent struct {
+	ID            string    `json:"id"`
+	CreatedAt     time.Time `json:"created_at"`
+	Topic         string    `json:"topic"`
+	Payload       string    `json:"payload"`
+	Status        string    `json:"status"`
+	Attempts      int       `json:"attempts"`
+	NextAttemptAt time.Time `json:"next_attempt_at"`
+	LastError     string    `json:"last_error,omitempty"`
+}
+
+// ListStuckReq filters the admin view of undelivered outbox rows. A row is stuck when it has
+// failed for good, or is still pending but has either failed at least once or been waiting
+// since before PendingBefore.
+type ListStuckReq struct {
+	Topic         string    `json:"topic,omitempty" form:"topic"`
+	PendingBefore time.Time `json:"-"`
+	Page          int64     `json:"-" form:"page"`
+	Limit         int64     `json:"-" form:"limit"`
+}
+
+type ListStuckRes struct {
+	Events     []*OutboxEvent     `json:"events"`
+	Pagination *paging.Pagination `json:"pagination,omitempty"`
+}
@@ -0,0 +1,49 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type OutboxStatus string
+
+const (
+	// OutboxStatusPending rows are waiting for (another) delivery attempt.
+	OutboxStatusPending OutboxStatus = "pending"
+	// OutboxStatusDelivered rows were accepted by the event bus.
+	OutboxStatusDelivered OutboxStatus = "delivered"
+	// OutboxStatusFailed rows exhausted their retry budget or can't be decoded; they stay put
+	// until an operator requeues them.
+	OutboxStatusFailed OutboxStatus = "failed"
+)
+
+// OutboxEvent is a domain event written in the same transaction as the state change that
+// produced it. The relay publishes pending rows to the event bus after commit, so an event
+// is never lost to a crash between commit and publish (it may be delivered twice instead).
+type OutboxEvent struct {
+	ID            string       `json:"id" gorm:"primary_key"`
+	CreatedAt     time.Time    `json:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at"`
+	Topic         string       `json:"topic" gorm:"size:128;not null"`
+	Payload       string       `json:"payload" gorm:"type:jsonb;not null"`
+	Status        OutboxStatus `json:"status" gorm:"size:16;not null;default:pending"`
+	Attempts      int          `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time    `json:"next_attempt_at" gorm:"not null"`
+	LastError     string       `json:"last_error" gorm:"type:text"`
+	DeliveredAt   *time.Time   `json:"delivered_at"`
+}
+
+func (e *OutboxEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	if e.Status == "" {
+		e.Status = OutboxStatusPending
+	}
+	if e.NextAttemptAt.IsZero() {
+		e.NextAttemptAt = time.Now()
+	}
+	return nil
+}
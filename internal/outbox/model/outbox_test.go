@@ -0,0 +1,23 @@
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutboxEvent_BeforeCreate(t *testing.T) {
+	e := &OutboxEvent{}
+	assert.NoError(t, e.BeforeCreate(nil))
+	assert.NotEmpty(t, e.ID)
+	assert.Equal(t, OutboxStatusPending, e.Status)
+	assert.False(t, e.NextAttemptAt.IsZero())
+
+	at := time.Now().Add(time.Hour)
+	e = &OutboxEvent{ID: "fixed", Status: OutboxStatusFailed, NextAttemptAt: at}
+	assert.NoError(t, e.BeforeCreate(nil))
+	assert.Equal(t, "fixed", e.ID)
+	assert.Equal(t, OutboxStatusFailed, e.Status)
+	assert.Equal(t, at, e.NextAttemptAt)
+}
@@ -0,0 +1,73 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/outbox/domain"
+	"goshop/internal/outbox/service"
+	"goshop/pkg/apperror"
+	"goshop/pkg/response"
+)
+
+type OutboxHandler struct {
+	service service.OutboxService
+}
+
+func NewOutboxHandler(service service.OutboxService) *OutboxHandler {
+	return &OutboxHandler{
+		service: service,
+	}
+}
+
+// ListStuck godoc
+//
+//	@Summary	Admin: list outbox events that failed or are overdue for delivery
+//	@Tags		outbox
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	query		domain.ListStuckReq	true	"Query"
+//	@Success	200	{object}	domain.ListStuckRes
+//	@Router		/api/v1/admin/outbox [get]
+func (h *OutboxHandler) ListStuck(c *gin.Context) {
+	var req domain.ListStuckReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to parse request req: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	events, pagination, err := h.service.ListStuck(c, &req)
+	if err != nil {
+		logger.Error("Failed to list stuck outbox events: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ListStuckRes{
+		Events:     domain.OutboxEventsFromModel(events),
+		Pagination: pagination,
+	})
+}
+
+// Requeue godoc
+//
+//	@Summary	Admin: reset an undelivered outbox event so the relay retries it
+//	@Tags		outbox
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string	true	"Outbox event ID"
+//	@Success	200	{object}	domain.OutboxEvent
+//	@Router		/api/v1/admin/outbox/{id}/requeue [post]
+func (h *OutboxHandler) Requeue(c *gin.Context) {
+	event, err := h.service.Requeue(c, c.Param("id"))
+	if err != nil {
+		logger.Error("Failed to requeue outbox event: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.OutboxEventFromModel(event))
+}
@@ -0,0 +1,110 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+
+	"goshop/internal/outbox/domain"
+	"goshop/internal/outbox/model"
+	srvMocks "goshop/internal/outbox/service/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/paging"
+)
+
+type OutboxHandlerTestSuite struct {
+	suite.Suite
+	mockService *srvMocks.OutboxService
+	handler     *OutboxHandler
+}
+
+func (suite *OutboxHandlerTestSuite) SetupTest() {
+	logger.Initialize(config.ProductionEnv)
+	gin.SetMode(gin.TestMode)
+	suite.mockService = srvMocks.NewOutboxService(suite.T())
+	suite.handler = NewOutboxHandler(suite.mockService)
+}
+
+func TestOutboxHandlerTestSuite(t *testing.T) {
+	suite.Run(t, new(OutboxHandlerTestSuite))
+}
+
+func (suite *OutboxHandlerTestSuite) prepareContext(method, path string) (*gin.Context, *httptest.ResponseRecorder) {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(method, path, nil)
+	return c, w
+}
+
+// ListStuck
+// =================================================================================================
+
+func (suite *OutboxHandlerTestSuite) TestListStuck_Success() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/outbox?topic=order.created&page=2&limit=5")
+	suite.mockService.On("ListStuck", mock.Anything, &domain.ListStuckReq{Topic: "order.created", Page: 2, Limit: 5}).
+		Return([]*model.OutboxEvent{{ID: "e1", Status: model.OutboxStatusFailed}}, &paging.Pagination{Total: 1}, nil).Once()
+
+	suite.handler.ListStuck(c)
+	suite.Equal(http.StatusOK, w.Code)
+
+	var res struct {
+		Result domain.ListStuckRes `json:"result"`
+	}
+	suite.NoError(json.Unmarshal(w.Body.Bytes(), &res))
+	suite.Len(res.Result.Events, 1)
+	suite.Equal("failed", res.Result.Events[0].Status)
+}
+
+func (suite *OutboxHandlerTestSuite) TestListStuck_BadQuery() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/outbox?page=abc")
+
+	suite.handler.ListStuck(c)
+	suite.Equal(http.StatusBadRequest, w.Code)
+}
+
+func (suite *OutboxHandlerTestSuite) TestListStuck_Error() {
+	c, w := suite.prepareContext(http.MethodGet, "/api/v1/admin/outbox")
+	suite.mockService.On("ListStuck", mock.Anything, mock.Anything).Return(nil, nil, errors.New("db down")).Once()
+
+	suite.handler.ListStuck(c)
+	suite.Equal(http.StatusInternalServerError, w.Code)
+}
+
+// Requeue
+// =================================================================================================
+
+func (suite *OutboxHandlerTestSuite) TestRequeue_Success() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/admin/outbox/e1/requeue")
+	c.Params = gin.Params{{Key: "id", Value: "e1"}}
+	suite.mockService.On("Requeue", mock.Anything, "e1").
+		Return(&model.OutboxEvent{ID: "e1", Status: model.OutboxStatusPending}, nil).Once()
+
+	suite.handler.Requeue(c)
+	suite.Equal(http.StatusOK, w.Code)
+}
+
+func (suite *OutboxHandlerTestSuite) TestRequeue_NotFound() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/admin/outbox/e1/requeue")
+	c.Params = gin.Params{{Key: "id", Value: "e1"}}
+	suite.mockService.On("Requeue", mock.Anything, "e1").Return(nil, apperror.ErrNotFound).Once()
+
+	suite.handler.Requeue(c)
+	suite.Equal(http.StatusNotFound, w.Code)
+}
+
+func (suite *OutboxHandlerTestSuite) TestRequeue_AlreadyDelivered() {
+	c, w := suite.prepareContext(http.MethodPost, "/api/v1/admin/outbox/e1/requeue")
+	c.Params = gin.Params{{Key: "id", Value: "e1"}}
+	suite.mockService.On("Requeue", mock.Anything, "e1").Return(nil, apperror.ErrInvalidStatus).Once()
+
+	suite.handler.Requeue(c)
+	suite.Equal(http.StatusUnprocessableEntity, w.Code)
+}
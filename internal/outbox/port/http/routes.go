@@ -0,0 +1,23 @@
+package http
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"goshop/internal/outbox/repository"
+	"goshop/internal/outbox/service"
+	"goshop/pkg/dbs"
+	"goshop/pkg/eventbus"
+	"goshop/pkg/middleware"
+)
+
+// Routes exposes the admin view of the event outbox. The relay itself runs from main.go.
+func Routes(r *gin.RouterGroup, db dbs.Database) {
+	outboxSvc := service.NewOutboxService(repository.NewOutboxRepository(db), eventbus.Default())
+	handler := NewOutboxHandler(outboxSvc)
+
+	adminRoute := r.Group("/admin/outbox", middleware.JWTAuth(), middleware.AdminOnly())
+	{
+		adminRoute.GET("", handler.ListStuck)
+		adminRoute.POST("/:id/requeue", handler.Requeue)
+	}
+}
@@ -0,0 +1,14 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"goshop/pkg/dbs/mocks"
+)
+
+func TestRoutes(t *testing.T) {
+	mockDB := mocks.NewDatabase(t)
+	Routes(gin.New().Group("/"), mockDB)
+}
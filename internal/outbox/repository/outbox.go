@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"goshop/internal/outbox/domain"
+	"goshop/internal/outbox/model"
+	"goshop/pkg/dbs"
+	"goshop/pkg/eventbus"
+	"goshop/pkg/paging"
+)
+
+//go:generate mockery --name=OutboxRepository
+type OutboxRepository interface {
+	// Add serialises ev into a pending row. Call it inside the db.WithTransaction of the state
+	// change so the event commits or rolls back together with it.
+	Add(ctx context.Context, ev eventbus.Event) error
+	// ClaimDue leases up to limit due pending rows, oldest first, by pushing their
+	// next_attempt_at out by lease. Concurrent relays skip leased rows; if a relay dies
+	// mid-batch its rows become due again once the lease runs out.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*model.OutboxEvent, error)
+	GetByID(ctx context.Context, id string) (*model.OutboxEvent, error)
+	Update(ctx context.Context, event *model.OutboxEvent) error
+	ListStuck(ctx context.Context, req *domain.ListStuckReq) ([]*model.OutboxEvent, *paging.Pagination, error)
+}
+
+type outboxRepo struct {
+	db dbs.Database
+}
+
+func NewOutboxRepository(db dbs.Database) OutboxRepository {
+	return &outboxRepo{db: db}
+}
+
+func (r *outboxRepo) Add(ctx context.Context, ev eventbus.Event) error {
+	payload, err := eventbus.Marshal(ev)
+	if err != nil {
+		return err
+	}
+	return r.db.Create(ctx, &model.OutboxEvent{
+		Topic:   ev.Topic(),
+		Payload: string(payload),
+	})
+}
+
+const claimDueQuery = `
+UPDATE outbox_events SET next_attempt_at = ?, updated_at = ?
+WHERE id IN (
+	SELECT id FROM outbox_events
+	WHERE status = ? AND next_attempt_at <= ?
+	ORDER BY created_at
+	LIMIT ?
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+func (r *outboxRepo) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*model.OutboxEvent, error) {
+	now := time.Now()
+	var events []*model.OutboxEvent
+	err := r.db.GetDB().WithContext(ctx).
+		Raw(claimDueQuery, now.Add(lease), now, model.OutboxStatusPending, now, limit).
+		Scan(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	// RETURNING doesn't preserve the subquery's order.
+	sort.Slice(events, func(i, j int) bool { return events[i].CreatedAt.Before(events[j].CreatedAt) })
+	return events, nil
+}
+
+func (r *outboxRepo) GetByID(ctx context.Context, id string) (*model.OutboxEvent, error) {
+	var event model.OutboxEvent
+	if err := r.db.FindById(ctx, id, &event); err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *outboxRepo) Update(ctx context.Context, event *model.OutboxEvent) error {
+	return r.db.Update(ctx, event)
+}
+
+func (r *outboxRepo) ListStuck(ctx context.Context, req *domain.ListStuckReq) ([]*model.OutboxEvent, *paging.Pagination, error) {
+	query := []dbs.Query{
+		dbs.NewQuery(
+			"(status = ? OR (status = ? AND (attempts > 0 OR created_at < ?)))",
+			model.OutboxStatusFailed, model.OutboxStatusPending, req.PendingBefore,
+		),
+	}
+	if req.Topic != "" {
+		query = append(query, dbs.NewQuery("topic = ?", req.Topic))
+	}
+
+	var total int64
+	if err := r.db.Count(ctx, &model.OutboxEvent{}, &total, dbs.WithQuery(query...)); err != nil {
+		return nil, nil, err
+	}
+
+	pagination := paging.New(req.Page, req.Limit, total)
+
+	var events []*model.OutboxEvent
+	if err := r.db.Find(
+		ctx,
+		&events,
+		dbs.WithQuery(query...),
+		dbs.WithLimit(int(pagination.Limit)),
+		dbs.WithOffset(int(pagination.Skip)),
+		dbs.WithOrder("created_at"),
+	); err != nil {
+		return nil, nil, err
+	}
+
+	return events, pagination, nil
+}
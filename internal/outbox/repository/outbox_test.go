@@ -0,0 +1,133 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"goshop/internal/outbox/domain"
+	"goshop/internal/outbox/model"
+	dbsMocks "goshop/pkg/dbs/mocks"
+	"goshop/pkg/eventbus"
+)
+
+func newOutboxSQLMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, m, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	g, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+	return g, m
+}
+
+func TestOutboxRepo_Add(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Create", mock.Anything, mock.MatchedBy(func(e *model.OutboxEvent) bool {
+		return e.Topic == eventbus.TopicOrderCreated && strings.Contains(e.Payload, `"order_id":"o1"`)
+	})).Return(nil).Once()
+	require.NoError(t, NewOutboxRepository(dbm).Add(context.Background(), eventbus.OrderCreated{OrderPayload: eventbus.OrderPayload{OrderID: "o1"}}))
+}
+
+func TestOutboxRepo_Add_Error(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Create", mock.Anything, mock.Anything).Return(errors.New("boom")).Once()
+	require.Error(t, NewOutboxRepository(dbm).Add(context.Background(), eventbus.LowStock{ProductID: "p1"}))
+}
+
+func TestOutboxRepo_ClaimDue(t *testing.T) {
+	g, m := newOutboxSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	older := time.Now().Add(-time.Minute)
+	newer := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "created_at", "topic", "payload", "status", "attempts"}).
+		AddRow("e2", newer, eventbus.TopicLowStock, "{}", "pending", 0).
+		AddRow("e1", older, eventbus.TopicOrderCreated, "{}", "pending", 1)
+	m.ExpectQuery(regexp.QuoteMeta(`UPDATE outbox_events SET next_attempt_at = $1, updated_at = $2`)).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), model.OutboxStatusPending, sqlmock.AnyArg(), 10).
+		WillReturnRows(rows)
+
+	got, err := NewOutboxRepository(dbm).ClaimDue(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, "e1", got[0].ID)
+	require.Equal(t, "e2", got[1].ID)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestOutboxRepo_ClaimDue_Error(t *testing.T) {
+	g, m := newOutboxSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	m.ExpectQuery(regexp.QuoteMeta(`UPDATE outbox_events`)).WillReturnError(errors.New("boom"))
+
+	got, err := NewOutboxRepository(dbm).ClaimDue(context.Background(), 10, time.Minute)
+	require.Error(t, err)
+	require.Nil(t, got)
+}
+
+func TestOutboxRepo_GetByID(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindById", mock.Anything, "e1", &model.OutboxEvent{}).Return(nil).Once()
+	got, err := NewOutboxRepository(dbm).GetByID(context.Background(), "e1")
+	require.NoError(t, err)
+	require.NotNil(t, got)
+}
+
+func TestOutboxRepo_GetByID_Error(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindById", mock.Anything, "e1", &model.OutboxEvent{}).Return(gorm.ErrRecordNotFound).Once()
+	got, err := NewOutboxRepository(dbm).GetByID(context.Background(), "e1")
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.Nil(t, got)
+}
+
+func TestOutboxRepo_Update(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Update", mock.Anything, &model.OutboxEvent{ID: "e1"}).Return(nil).Once()
+	require.NoError(t, NewOutboxRepository(dbm).Update(context.Background(), &model.OutboxEvent{ID: "e1"}))
+}
+
+func TestOutboxRepo_ListStuck(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Count", mock.Anything, &model.OutboxEvent{}, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(2).(*int64) = 1 }).
+		Return(nil).Once()
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	_, pagination, err := NewOutboxRepository(dbm).ListStuck(context.Background(), &domain.ListStuckReq{Topic: "order.created"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), pagination.Total)
+}
+
+func TestOutboxRepo_ListStuck_CountError(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Count", mock.Anything, &model.OutboxEvent{}, mock.Anything, mock.Anything).Return(errors.New("boom")).Once()
+
+	_, _, err := NewOutboxRepository(dbm).ListStuck(context.Background(), &domain.ListStuckReq{})
+	require.Error(t, err)
+}
+
+func TestOutboxRepo_ListStuck_FindError(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Count", mock.Anything, &model.OutboxEvent{}, mock.Anything, mock.Anything).Return(nil).Once()
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("boom")).Once()
+
+	_, _, err := NewOutboxRepository(dbm).ListStuck(context.Background(), &domain.ListStuckReq{})
+	require.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"gorm.io/gorm"
+
+	"goshop/internal/outbox/domain"
+	"goshop/internal/outbox/model"
+	outboxRepo "goshop/internal/outbox/repository"
+	"goshop/pkg/apperror"
+	"goshop/pkg/eventbus"
+	"goshop/pkg/paging"
+)
+
+const (
+	// MaxAttempts is how many times the relay tries to publish a row before marking it failed.
+	MaxAttempts = 10
+	// ClaimLease is how long a claimed row is hidden from other relays while it is published.
+	ClaimLease = 30 * time.Second
+	// StuckAfter is how long a never-attempted pending row may wait before the admin view
+	// reports it; normally the relay picks rows up within a tick.
+	StuckAfter = 5 * time.Minute
+
+	retryBaseDelay = time.Second
+	retryMaxDelay  = 10 * time.Minute
+)
+
+//go:generate mockery --name=OutboxService
+type OutboxService interface {
+	// RelayPending publishes up to batchSize due rows to the bus and returns how many were
+	// delivered. Delivery is at-least-once: a row is marked delivered only after the bus
+	// accepted it, so a crash in between publishes it again on the next run.
+	RelayPending(ctx context.Context, batchSize int) (int, error)
+	ListStuck(ctx context.Context, req *domain.ListStuckReq) ([]*model.OutboxEvent, *paging.Pagination, error)
+	// Requeue resets an undelivered row so the relay retries it from scratch.
+	Requeue(ctx context.Context, id string) (*model.OutboxEvent, error)
+}
+
+type outboxService struct {
+	repo outboxRepo.OutboxRepository
+	bus  eventbus.Bus
+}
+
+func NewOutboxService(repo outboxRepo.OutboxRepository, bus eventbus.Bus) OutboxService {
+	return &outboxService{
+		repo: repo,
+		bus:  bus,
+	}
+}
+
+func (s *outboxService) RelayPending(ctx context.Context, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	events, err := s.repo.ClaimDue(ctx, batchSize, ClaimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	delivered := 0
+	for _, event := range events {
+		s.deliver(ctx, event)
+		if err := s.repo.Update(ctx, event); err != nil {
+			// The lease expires and the row is retried, so at worst it's published twice.
+			logger.Errorf("outbox %s: save delivery state: %s", event.ID, err)
+			continue
+		}
+		if event.Status == model.OutboxStatusDelivered {
+			delivered++
+		}
+	}
+	return delivered, nil
+}
+
+// deliver publishes one row and records the outcome on it; the caller persists it.
+func (s *outboxService) deliver(ctx context.Context, event *model.OutboxEvent) {
+	event.Attempts++
+
+	ev, err := eventbus.Unmarshal(event.Topic, []byte(event.Payload))
+	if err != nil {
+		// Retrying won't make an unknown topic or a bad payload decodable.
+		logger.Errorf("outbox %s: %s", event.ID, err)
+		event.Status = model.OutboxStatusFailed
+		event.LastError = err.Error()
+		return
+	}
+
+	if err := s.bus.Publish(ctx, ev); err != nil {
+		logger.Warnf("outbox %s: publish %s (attempt %d): %s", event.ID, event.Topic, event.Attempts, err)
+		event.LastError = err.Error()
+		if event.Attempts >= MaxAttempts {
+			event.Status = model.OutboxStatusFailed
+			return
+		}
+		event.NextAttemptAt = time.Now().Add(retryDelay(event.Attempts))
+		return
+	}
+
+	now := time.Now()
+	event.Status = model.OutboxStatusDelivered
+	event.DeliveredAt = &now
+	event.LastError = ""
+}
+
+// retryDelay backs off exponentially from retryBaseDelay, capped at retryMaxDelay.
+func retryDelay(attempts int) time.Duration {
+	delay := retryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= retryMaxDelay {
+			return retryMaxDelay
+		}
+	}
+	return delay
+}
+
+func (s *outboxService) ListStuck(ctx context.Context, req *domain.ListStuckReq) ([]*model.OutboxEvent, *paging.Pagination, error) {
+	req.PendingBefore = time.Now().Add(-StuckAfter)
+	return s.repo.ListStuck(ctx, req)
+}
+
+func (s *outboxService) Requeue(ctx context.Context, id string) (*model.OutboxEvent, error) {
+	event, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, apperror.ErrNotFound
+		}
+		return nil, err
+	}
+	if event.Status == model.OutboxStatusDelivered {
+		return nil, apperror.ErrInvalidStatus
+	}
+
+	event.Status = model.OutboxStatusPending
+	event.Attempts = 0
+	event.NextAttemptAt = time.Now()
+	if err := s.repo.Update(ctx, event); err != nil {
+		return nil, err
+	}
+	return event, nil
+}
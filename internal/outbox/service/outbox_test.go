@@ -0,0 +1,216 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+	"gorm.io/gorm"
+
+	"goshop/internal/outbox/domain"
+	"goshop/internal/outbox/model"
+	repoMocks "goshop/internal/outbox/repository/mocks"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/eventbus"
+	"goshop/pkg/paging"
+)
+
+// fakeBus records published events and fails with err when set.
+type fakeBus struct {
+	published []eventbus.Event
+	err       error
+}
+
+func (b *fakeBus) Publish(_ context.Context, ev eventbus.Event) error {
+	if b.err != nil {
+		return b.err
+	}
+	b.published = append(b.published, ev)
+	return nil
+}
+
+func (b *fakeBus) Subscribe(string, eventbus.Handler) {}
+
+type OutboxServiceTestSuite struct {
+	suite.Suite
+	mockRepo *repoMocks.OutboxRepository
+	bus      *fakeBus
+	service  OutboxService
+}
+
+func (suite *OutboxServiceTestSuite) SetupTest() {
+	logger.Initialize(config.ProductionEnv)
+
+	suite.mockRepo = repoMocks.NewOutboxRepository(suite.T())
+	suite.bus = &fakeBus{}
+	suite.service = NewOutboxService(suite.mockRepo, suite.bus)
+}
+
+func TestOutboxServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(OutboxServiceTestSuite))
+}
+
+func pendingRow(id string, attempts int) *model.OutboxEvent {
+	return &model.OutboxEvent{
+		ID:       id,
+		Topic:    eventbus.TopicOrderCreated,
+		Payload:  `{"order_id":"` + id + `"}`,
+		Status:   model.OutboxStatusPending,
+		Attempts: attempts,
+	}
+}
+
+// RelayPending
+// =================================================================================================
+
+func (suite *OutboxServiceTestSuite) TestRelayPending_Delivers() {
+	suite.mockRepo.On("ClaimDue", mock.Anything, 100, ClaimLease).
+		Return([]*model.OutboxEvent{pendingRow("o1", 0), pendingRow("o2", 2)}, nil).Once()
+	suite.mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(e *model.OutboxEvent) bool {
+		return e.Status == model.OutboxStatusDelivered && e.DeliveredAt != nil && e.LastError == ""
+	})).Return(nil).Twice()
+
+	n, err := suite.service.RelayPending(context.Background(), 0)
+	suite.NoError(err)
+	suite.Equal(2, n)
+	suite.Equal([]eventbus.Event{eventbus.OrderCreated{OrderPayload: eventbus.OrderPayload{OrderID: "o1"}}, eventbus.OrderCreated{OrderPayload: eventbus.OrderPayload{OrderID: "o2"}}}, suite.bus.published)
+}
+
+func (suite *OutboxServiceTestSuite) TestRelayPending_ClaimError() {
+	suite.mockRepo.On("ClaimDue", mock.Anything, 10, ClaimLease).Return(nil, errors.New("db down")).Once()
+
+	n, err := suite.service.RelayPending(context.Background(), 10)
+	suite.Error(err)
+	suite.Equal(0, n)
+}
+
+func (suite *OutboxServiceTestSuite) TestRelayPending_PublishErrorBacksOff() {
+	suite.bus.err = errors.New("bus down")
+	row := pendingRow("o1", 2)
+	suite.mockRepo.On("ClaimDue", mock.Anything, 10, ClaimLease).Return([]*model.OutboxEvent{row}, nil).Once()
+	suite.mockRepo.On("Update", mock.Anything, row).Return(nil).Once()
+
+	start := time.Now()
+	n, err := suite.service.RelayPending(context.Background(), 10)
+	suite.NoError(err)
+	suite.Equal(0, n)
+	suite.Equal(model.OutboxStatusPending, row.Status)
+	suite.Equal(3, row.Attempts)
+	suite.Equal("bus down", row.LastError)
+	suite.WithinDuration(start.Add(4*time.Second), row.NextAttemptAt, time.Second)
+}
+
+func (suite *OutboxServiceTestSuite) TestRelayPending_PublishErrorExhaustsRetries() {
+	suite.bus.err = errors.New("bus down")
+	row := pendingRow("o1", MaxAttempts-1)
+	suite.mockRepo.On("ClaimDue", mock.Anything, 10, ClaimLease).Return([]*model.OutboxEvent{row}, nil).Once()
+	suite.mockRepo.On("Update", mock.Anything, row).Return(nil).Once()
+
+	n, err := suite.service.RelayPending(context.Background(), 10)
+	suite.NoError(err)
+	suite.Equal(0, n)
+	suite.Equal(model.OutboxStatusFailed, row.Status)
+	suite.Equal(MaxAttempts, row.Attempts)
+}
+
+func (suite *OutboxServiceTestSuite) TestRelayPending_UndecodableRowFails() {
+	row := &model.OutboxEvent{ID: "e1", Topic: "unknown.topic", Payload: "{}", Status: model.OutboxStatusPending}
+	suite.mockRepo.On("ClaimDue", mock.Anything, 10, ClaimLease).Return([]*model.OutboxEvent{row}, nil).Once()
+	suite.mockRepo.On("Update", mock.Anything, row).Return(nil).Once()
+
+	n, err := suite.service.RelayPending(context.Background(), 10)
+	suite.NoError(err)
+	suite.Equal(0, n)
+	suite.Equal(model.OutboxStatusFailed, row.Status)
+	suite.NotEmpty(row.LastError)
+	suite.Empty(suite.bus.published)
+}
+
+func (suite *OutboxServiceTestSuite) TestRelayPending_UpdateErrorNotCounted() {
+	suite.mockRepo.On("ClaimDue", mock.Anything, 10, ClaimLease).
+		Return([]*model.OutboxEvent{pendingRow("o1", 0), pendingRow("o2", 0)}, nil).Once()
+	suite.mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(e *model.OutboxEvent) bool { return e.ID == "o1" })).
+		Return(errors.New("db down")).Once()
+	suite.mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(e *model.OutboxEvent) bool { return e.ID == "o2" })).
+		Return(nil).Once()
+
+	n, err := suite.service.RelayPending(context.Background(), 10)
+	suite.NoError(err)
+	suite.Equal(1, n)
+}
+
+func (suite *OutboxServiceTestSuite) TestRetryDelay() {
+	suite.Equal(time.Second, retryDelay(1))
+	suite.Equal(8*time.Second, retryDelay(4))
+	suite.Equal(retryMaxDelay, retryDelay(30))
+}
+
+// ListStuck
+// =================================================================================================
+
+func (suite *OutboxServiceTestSuite) TestListStuck() {
+	req := &domain.ListStuckReq{Topic: eventbus.TopicLowStock}
+	suite.mockRepo.On("ListStuck", mock.Anything, req).
+		Return([]*model.OutboxEvent{{ID: "e1"}}, &paging.Pagination{Total: 1}, nil).Once()
+
+	events, pagination, err := suite.service.ListStuck(context.Background(), req)
+	suite.NoError(err)
+	suite.Len(events, 1)
+	suite.Equal(int64(1), pagination.Total)
+	suite.WithinDuration(time.Now().Add(-StuckAfter), req.PendingBefore, time.Second)
+}
+
+// Requeue
+// =================================================================================================
+
+func (suite *OutboxServiceTestSuite) TestRequeue_Success() {
+	row := &model.OutboxEvent{ID: "e1", Status: model.OutboxStatusFailed, Attempts: MaxAttempts, LastError: "bus down"}
+	suite.mockRepo.On("GetByID", mock.Anything, "e1").Return(row, nil).Once()
+	suite.mockRepo.On("Update", mock.Anything, row).Return(nil).Once()
+
+	got, err := suite.service.Requeue(context.Background(), "e1")
+	suite.NoError(err)
+	suite.Equal(model.OutboxStatusPending, got.Status)
+	suite.Equal(0, got.Attempts)
+	suite.WithinDuration(time.Now(), got.NextAttemptAt, time.Second)
+}
+
+func (suite *OutboxServiceTestSuite) TestRequeue_NotFound() {
+	suite.mockRepo.On("GetByID", mock.Anything, "e1").Return(nil, gorm.ErrRecordNotFound).Once()
+
+	got, err := suite.service.Requeue(context.Background(), "e1")
+	suite.ErrorIs(err, apperror.ErrNotFound)
+	suite.Nil(got)
+}
+
+func (suite *OutboxServiceTestSuite) TestRequeue_GetError() {
+	suite.mockRepo.On("GetByID", mock.Anything, "e1").Return(nil, errors.New("db down")).Once()
+
+	got, err := suite.service.Requeue(context.Background(), "e1")
+	suite.Error(err)
+	suite.Nil(got)
+}
+
+func (suite *OutboxServiceTestSuite) TestRequeue_AlreadyDelivered() {
+	suite.mockRepo.On("GetByID", mock.Anything, "e1").
+		Return(&model.OutboxEvent{ID: "e1", Status: model.OutboxStatusDelivered}, nil).Once()
+
+	got, err := suite.service.Requeue(context.Background(), "e1")
+	suite.ErrorIs(err, apperror.ErrInvalidStatus)
+	suite.Nil(got)
+}
+
+func (suite *OutboxServiceTestSuite) TestRequeue_UpdateError() {
+	suite.mockRepo.On("GetByID", mock.Anything, "e1").
+		Return(&model.OutboxEvent{ID: "e1", Status: model.OutboxStatusFailed}, nil).Once()
+	suite.mockRepo.On("Update", mock.Anything, mock.Anything).Return(errors.New("db down")).Once()
+
+	got, err := suite.service.Requeue(context.Background(), "e1")
+	suite.Error(err)
+	suite.Nil(got)
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"goshop/internal/payment/model"
+	"goshop/pkg/paging"
+)
+
+// ListFailedEventsReq filters the admin view of webhook events whose processing failed:
+// those that gave up, and those still pending after a failed attempt.
+type ListFailedEventsReq struct {
+	Provider string `json:"provider,omitempty" form:"provider"`
+	Page     int64  `json:"-" form:"page"`
+	Limit    int64  `json:"-" form:"limit"`
+}
+
+type ListFailedEventsRes struct {
+	Events     []*model.ProviderEvent `json:"events"`
+	Pagination *paging.Pagination     `json:"pagination,omitempty"`
+}
@@ -0,0 +1,21 @@
+package domain
+
+// StartSetupReq picks the provider to save a payment method with; an empty provider means the
+// default one.
+type StartSetupReq struct {
+	Provider string `json:"provider" binding:"max=32"`
+}
+
+// SetupRes is what the FE needs to collect the payment method: complete the setup intent with
+// its client secret, then save it with SavePaymentMethodReq.
+type SetupRes struct {
+	SetupIntentID string `json:"setup_intent_id"`
+	Provider      string `json:"provider"`
+	ClientSecret  string `json:"client_secret"`
+}
+
+// SavePaymentMethodReq saves the payment method entered for a setup intent that succeeded.
+type SavePaymentMethodReq struct {
+	Provider      string `json:"provider" binding:"max=32"`
+	SetupIntentID string `json:"setup_intent_id" binding:"required,max=255"`
+}
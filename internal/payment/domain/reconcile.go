@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"goshop/internal/payment/model"
+	"goshop/pkg/paging"
+)
+
+// ListReconcileRunsReq pages through payment reconciliation runs, newest first.
+type ListReconcileRunsReq struct {
+	Page  int64 `json:"-" form:"page"`
+	Limit int64 `json:"-" form:"limit"`
+}
+
+type ListReconcileRunsRes struct {
+	Runs       []*model.ReconcileRun `json:"runs"`
+	Pagination *paging.Pagination    `json:"pagination,omitempty"`
+}
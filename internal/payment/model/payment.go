@@ -16,38 +16,112 @@ const (
 	PaymentStatusSucceeded      PaymentStatus = "succeeded"
 	PaymentStatusFailed         PaymentStatus = "failed"
 	PaymentStatusCanceled       PaymentStatus = "canceled"
+	// A succeeded payment moves to partially_refunded / refunded as refunds are issued against it.
+	PaymentStatusPartiallyRefunded PaymentStatus = "partially_refunded"
+	PaymentStatusRefunded          PaymentStatus = "refunded"
+	// An authorized payment's funds are held but not charged: it is captured when the order
+	// ships, or voided if the order is cancelled first.
+	PaymentStatusAuthorized PaymentStatus = "authorized"
 )
 
-// Payment is the local record of a charge attempt against an external provider. Each Order
-// has at most one active Payment; a previously-failed payment can be superseded by a new one.
+// ProviderCashOnDelivery is the provider of cash the courier collected for a cash-on-delivery
+// order. It has no payment.Provider behind it: there is no intent, webhook or refund API.
+const ProviderCashOnDelivery = "cod"
+
+// Payment is the local record of a charge attempt against an external provider. An Order can
+// have several attempts, numbered from 1, but at most one Active one: a failed or canceled
+// attempt is superseded by the next.
 type Payment struct {
 	ID        string     `json:"id" gorm:"primary_key"`
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 	DeletedAt *time.Time `json:"deleted_at" gorm:"index"`
 
-	OrderID          string        `json:"order_id" gorm:"uniqueIndex;not null"`
+	OrderID          string        `json:"order_id" gorm:"index;not null"`
+	Attempt          int           `json:"attempt" gorm:"not null;default:1"`
 	Provider         string        `json:"provider" gorm:"not null"`
 	ProviderIntentID string        `json:"provider_intent_id" gorm:"index;not null"`
 	Amount           int64         `json:"amount" gorm:"not null"`
 	Currency         string        `json:"currency" gorm:"not null"`
 	Status           PaymentStatus `json:"status" gorm:"index;not null"`
+	// AmountRefunded counts refunds in flight as well as succeeded ones, so concurrent refunds
+	// can't promise the customer more than Amount.
+	AmountRefunded int64 `json:"amount_refunded" gorm:"not null;default:0"`
+}
+
+// Refundable is the amount still available to refund.
+func (p *Payment) Refundable() int64 {
+	return p.Amount - p.AmountRefunded
+}
+
+// Committed reports whether money has moved, or may be moving, for this payment. A committed
+// payment can no longer be switched to another provider or intent.
+func (p *Payment) Committed() bool {
+	switch p.Status {
+	case PaymentStatusAuthorized, PaymentStatusProcessing, PaymentStatusSucceeded,
+		PaymentStatusPartiallyRefunded, PaymentStatusRefunded:
+		return true
+	}
+	return false
+}
+
+// Active reports whether the attempt still counts for its order. Only a failed or canceled
+// attempt can be followed by a new one.
+func (p *Payment) Active() bool {
+	return p.Status != PaymentStatusFailed && p.Status != PaymentStatusCanceled
 }
 
 func (p *Payment) BeforeCreate(tx *gorm.DB) error {
 	if p.ID == "" {
 		p.ID = uuid.New().String()
 	}
+	if p.Attempt == 0 {
+		p.Attempt = 1
+	}
 	if p.Status == "" {
 		p.Status = PaymentStatusPending
 	}
 	return nil
 }
 
-// ProviderEvent is the dedup table for provider webhook deliveries. Inserting a row with the
-// same (provider, event_id) is rejected by the unique index, giving exactly-once processing.
+type ProviderEventStatus string
+
+const (
+	// ProviderEventStatusPending events are waiting for (another) processing attempt.
+	ProviderEventStatusPending ProviderEventStatus = "pending"
+	// ProviderEventStatusProcessing events are being applied. Their next_attempt_at is the end
+	// of the claim's lease; a worker that died mid-apply leaves them to be claimed again after.
+	ProviderEventStatusProcessing ProviderEventStatus = "processing"
+	// ProviderEventStatusProcessed events were applied to their payment and order.
+	ProviderEventStatusProcessed ProviderEventStatus = "processed"
+	// ProviderEventStatusFailed events exhausted their retry budget or can't be decoded; they
+	// stay put until an admin replays them.
+	ProviderEventStatusFailed ProviderEventStatus = "failed"
+)
+
+// ProviderEvent is the inbox of verified provider webhook deliveries. Inserting a row with
+// the same (provider, event_id) is rejected by the primary key, so each event is applied
+// once; the stored payload lets a failed event be processed again.
 type ProviderEvent struct {
-	CreatedAt time.Time `json:"created_at"`
-	Provider  string    `json:"provider" gorm:"primaryKey;size:32"`
-	EventID   string    `json:"event_id" gorm:"primaryKey;size:128"`
+	CreatedAt     time.Time           `json:"created_at"`
+	UpdatedAt     time.Time           `json:"updated_at"`
+	Provider      string              `json:"provider" gorm:"primaryKey;size:32"`
+	EventID       string              `json:"event_id" gorm:"primaryKey;size:128"`
+	EventType     string              `json:"event_type" gorm:"size:64"`
+	Payload       string              `json:"payload" gorm:"type:text"`
+	Status        ProviderEventStatus `json:"status" gorm:"size:16;not null;default:pending"`
+	Attempts      int                 `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt time.Time           `json:"next_attempt_at"`
+	LastError     string              `json:"last_error,omitempty" gorm:"type:text"`
+	ProcessedAt   *time.Time          `json:"processed_at,omitempty"`
+}
+
+func (e *ProviderEvent) BeforeCreate(tx *gorm.DB) error {
+	if e.Status == "" {
+		e.Status = ProviderEventStatusPending
+	}
+	if e.NextAttemptAt.IsZero() {
+		e.NextAttemptAt = time.Now()
+	}
+	return nil
 }
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PaymentCustomer is the customer a provider keeps for one of our users, created the first
+// time they save a payment method with that provider.
+type PaymentCustomer struct {
+	UserID             string    `json:"user_id" gorm:"primaryKey"`
+	Provider           string    `json:"provider" gorm:"primaryKey"`
+	CreatedAt          time.Time `json:"created_at"`
+	ProviderCustomerID string    `json:"provider_customer_id" gorm:"not null"`
+}
+
+// PaymentMethod is a payment method a user saved with a provider for later checkouts. Only the
+// provider's tokens are stored, with the card's brand and last 4 digits to show the user.
+type PaymentMethod struct {
+	ID        string    `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	UserID             string `json:"user_id" gorm:"index;not null"`
+	Provider           string `json:"provider" gorm:"uniqueIndex:idx_payment_methods_provider_method_id;not null"`
+	ProviderCustomerID string `json:"-" gorm:"not null"`
+	ProviderMethodID   string `json:"-" gorm:"uniqueIndex:idx_payment_methods_provider_method_id;not null"`
+	Brand              string `json:"brand"`
+	Last4              string `json:"last4" gorm:"type:varchar(4)"`
+}
+
+func (m *PaymentMethod) BeforeCreate(tx *gorm.DB) error {
+	if m.ID == "" {
+		m.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -2,6 +2,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -19,3 +20,48 @@ func TestPaymentBeforeCreatePreservesExistingFields(t *testing.T) {
 	require.Equal(t, "given", p.ID)
 	require.Equal(t, PaymentStatusSucceeded, p.Status)
 }
+
+func TestPaymentRefundable(t *testing.T) {
+	p := &Payment{Amount: 1000, AmountRefunded: 250}
+	require.Equal(t, int64(750), p.Refundable())
+}
+
+func TestPaymentCommitted(t *testing.T) {
+	for _, status := range []PaymentStatus{PaymentStatusPending, PaymentStatusRequiresAction, PaymentStatusFailed, PaymentStatusCanceled} {
+		require.False(t, (&Payment{Status: status}).Committed(), status)
+	}
+	for _, status := range []PaymentStatus{PaymentStatusAuthorized, PaymentStatusProcessing, PaymentStatusSucceeded, PaymentStatusPartiallyRefunded, PaymentStatusRefunded} {
+		require.True(t, (&Payment{Status: status}).Committed(), status)
+	}
+}
+
+func TestProviderEventBeforeCreateDefaults(t *testing.T) {
+	e := &ProviderEvent{}
+	require.NoError(t, e.BeforeCreate(nil))
+	require.Equal(t, ProviderEventStatusPending, e.Status)
+	require.False(t, e.NextAttemptAt.IsZero())
+
+	at := time.Now().Add(time.Minute)
+	e = &ProviderEvent{Status: ProviderEventStatusProcessed, NextAttemptAt: at}
+	require.NoError(t, e.BeforeCreate(nil))
+	require.Equal(t, ProviderEventStatusProcessed, e.Status)
+	require.Equal(t, at, e.NextAttemptAt)
+}
+
+func TestRefundBeforeCreateDefaults(t *testing.T) {
+	r := &Refund{}
+	require.NoError(t, r.BeforeCreate(nil))
+	require.NotEmpty(t, r.ID)
+	require.Equal(t, RefundStatusPending, r.Status)
+
+	l := &RefundLine{}
+	require.NoError(t, l.BeforeCreate(nil))
+	require.NotEmpty(t, l.ID)
+}
+
+func TestRefundStatusActive(t *testing.T) {
+	require.True(t, RefundStatusPending.Active())
+	require.True(t, RefundStatusSucceeded.Active())
+	require.False(t, RefundStatusFailed.Active())
+	require.False(t, RefundStatusCanceled.Active())
+}
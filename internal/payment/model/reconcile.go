@@ -0,0 +1,59 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ReconcileRun records one reconciliation pass over stale unsettled payments, so what it
+// found and changed can be looked at after the fact.
+type ReconcileRun struct {
+	ID        string    `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at"`
+
+	Checked int                  `json:"checked" gorm:"not null"`
+	Updated int                  `json:"updated" gorm:"not null"`
+	Failed  int                  `json:"failed" gorm:"not null"`
+	Entries []*ReconcileRunEntry `json:"entries,omitempty" gorm:"foreignKey:RunID"`
+}
+
+func (ReconcileRun) TableName() string {
+	return "payment_reconcile_runs"
+}
+
+func (r *ReconcileRun) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	return nil
+}
+
+// ReconcileRunEntry is one payment a run checked: its status locally and at the provider,
+// and whether the run updated, skipped or failed to update it.
+type ReconcileRunEntry struct {
+	ID        string    `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at"`
+	RunID     string    `json:"run_id" gorm:"index;not null"`
+
+	PaymentID      string        `json:"payment_id" gorm:"index;not null"`
+	OrderID        string        `json:"order_id" gorm:"not null"`
+	Provider       string        `json:"provider" gorm:"not null"`
+	IntentID       string        `json:"intent_id"`
+	LocalStatus    PaymentStatus `json:"local_status" gorm:"not null"`
+	ProviderStatus string        `json:"provider_status"`
+	Action         string        `json:"action" gorm:"not null"`
+	Detail         string        `json:"detail,omitempty"`
+}
+
+func (ReconcileRunEntry) TableName() string {
+	return "payment_reconcile_run_entries"
+}
+
+func (e *ReconcileRunEntry) BeforeCreate(tx *gorm.DB) error {
+	if e.ID == "" {
+		e.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RefundStatus string
+
+const (
+	RefundStatusPending   RefundStatus = "pending"
+	RefundStatusSucceeded RefundStatus = "succeeded"
+	RefundStatusFailed    RefundStatus = "failed"
+	RefundStatusCanceled  RefundStatus = "canceled"
+)
+
+// Active reports whether the refund still counts against the payment: pending refunds may yet
+// succeed, failed and canceled ones returned nothing.
+func (s RefundStatus) Active() bool {
+	return s == RefundStatusPending || s == RefundStatusSucceeded
+}
+
+// Refund is money returned against a Payment, either the whole remaining amount or the value
+// of specific order lines. IdempotencyKey makes an admin retry return the original refund.
+type Refund struct {
+	ID        string    `json:"id" gorm:"primary_key"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	PaymentID        string        `json:"payment_id" gorm:"index;not null"`
+	OrderID          string        `json:"order_id" gorm:"index;not null"`
+	ProviderRefundID *string       `json:"provider_refund_id" gorm:"uniqueIndex"`
+	IdempotencyKey   string        `json:"idempotency_key" gorm:"uniqueIndex;not null"`
+	Amount           int64         `json:"amount" gorm:"not null"`
+	Currency         string        `json:"currency" gorm:"not null"`
+	Status           RefundStatus  `json:"status" gorm:"index;not null"`
+	Reason           string        `json:"reason"`
+	ActorID          string        `json:"actor_id"`
+	Lines            []*RefundLine `json:"lines"`
+}
+
+func (r *Refund) BeforeCreate(tx *gorm.DB) error {
+	if r.ID == "" {
+		r.ID = uuid.New().String()
+	}
+	if r.Status == "" {
+		r.Status = RefundStatusPending
+	}
+	return nil
+}
+
+// RefundLine records how many units of an order line a refund covers and what they were worth.
+type RefundLine struct {
+	ID          string    `json:"id" gorm:"primary_key"`
+	CreatedAt   time.Time `json:"created_at"`
+	RefundID    string    `json:"refund_id" gorm:"index;not null"`
+	OrderLineID string    `json:"order_line_id" gorm:"index;not null"`
+	ProductID   string    `json:"product_id" gorm:"not null"`
+	Quantity    uint      `json:"quantity" gorm:"not null"`
+	Amount      int64     `json:"amount" gorm:"not null"`
+}
+
+func (l *RefundLine) BeforeCreate(tx *gorm.DB) error {
+	if l.ID == "" {
+		l.ID = uuid.New().String()
+	}
+	return nil
+}
@@ -1,79 +1,395 @@
 package http
 
 import (
+	"errors"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/quangdangfit/gocommon/logger"
 
+	orderService "goshop/internal/order/service"
+	"goshop/internal/payment/domain"
 	"goshop/internal/payment/service"
+	"goshop/pkg/apperror"
 	"goshop/pkg/payment"
 	"goshop/pkg/response"
 )
 
-// stripeSignatureHeader is the header Stripe uses for webhook HMAC delivery.
-const stripeSignatureHeader = "Stripe-Signature"
-
 type Handler struct {
-	svc service.PaymentService
+	svc       service.PaymentService
+	providers *payment.Registry
+}
+
+func NewHandler(svc service.PaymentService, providers *payment.Registry) *Handler {
+	return &Handler{svc: svc, providers: providers}
 }
 
-func NewHandler(svc service.PaymentService) *Handler {
-	return &Handler{svc: svc}
+// createIntentRequest picks the payment provider; the body is optional and an empty provider
+// means the default one. PaymentMethodID charges one of the buyer's saved payment methods,
+// with the provider it was saved with.
+type createIntentRequest struct {
+	Provider        string `json:"provider" binding:"max=32"`
+	PaymentMethodID string `json:"payment_method_id" binding:"max=64"`
 }
 
+// intentResponse carries what the FE needs to take the customer through the chosen
+// provider: a client secret for card elements, a redirect URL, or offline instructions.
 type intentResponse struct {
-	IntentID     string `json:"intent_id"`
-	ClientSecret string `json:"client_secret"`
-	Amount       int64  `json:"amount"`
-	Currency     string `json:"currency"`
-	Status       string `json:"status"`
+	IntentID     string     `json:"intent_id"`
+	Provider     string     `json:"provider"`
+	ClientSecret string     `json:"client_secret"`
+	RedirectURL  string     `json:"redirect_url,omitempty"`
+	Instructions string     `json:"instructions,omitempty"`
+	ExpiresAt    *time.Time `json:"expires_at,omitempty"`
+	Amount       int64      `json:"amount"`
+	Currency     string     `json:"currency"`
+	Status       string     `json:"status"`
 }
 
 // CreatePaymentIntent godoc
 //
-//	@Summary	Create a Stripe PaymentIntent for an order
+//	@Summary	Create a payment intent for an order with the chosen (or default) provider
 //	@Tags		payments
 //	@Produce	json
-//	@Param		id	path		string	true	"Order ID"
+//	@Param		id	path		string				true	"Order ID"
+//	@Param		_	body		createIntentRequest	false	"Body"
 //	@Success	200	{object}	intentResponse
 //	@Failure	400	{object}	response.Response
+//	@Failure	404	{object}	response.Response
+//	@Failure	409	{object}	response.Response
 //	@Router		/orders/{id}/payment-intent [post]
 //	@Security	ApiKeyAuth
 func (h *Handler) CreatePaymentIntent(c *gin.Context) {
+	var req createIntentRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	userID := c.GetString("userId")
+	if userID == "" {
+		apperror.ErrUnauthorized.HTTPError(c)
+		return
+	}
+
 	orderID := c.Param("id")
-	intent, err := h.svc.CreateIntentForOrder(c.Request.Context(), orderID)
+	intent, err := h.svc.CreateIntentForOrder(c.Request.Context(), orderID, userID, req.Provider, req.PaymentMethodID)
 	if err != nil {
-		response.Error(c, http.StatusBadRequest, err, "create payment intent")
+		apperror.ToHTTPError(c, err, http.StatusBadRequest, "create payment intent")
 		return
 	}
-	response.JSON(c, http.StatusOK, intentResponse{
+	response.JSON(c, http.StatusOK, h.intentResponse(intent, req.Provider))
+}
+
+// RetryPayment godoc
+//
+//	@Summary	Retry a failed payment with a new intent, reserving the order's stock again if needed
+//	@Tags		payments
+//	@Produce	json
+//	@Param		id	path		string				true	"Order ID"
+//	@Param		_	body		createIntentRequest	false	"Body"
+//	@Success	200	{object}	intentResponse
+//	@Failure	400	{object}	response.Response
+//	@Failure	404	{object}	response.Response
+//	@Failure	409	{object}	response.Response
+//	@Router		/orders/{id}/payment/retry [post]
+//	@Security	ApiKeyAuth
+func (h *Handler) RetryPayment(c *gin.Context) {
+	var req createIntentRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		response.Error(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	userID := c.GetString("userId")
+	if userID == "" {
+		apperror.ErrUnauthorized.HTTPError(c)
+		return
+	}
+
+	intent, err := h.svc.RetryPayment(c.Request.Context(), c.Param("id"), userID, req.Provider, req.PaymentMethodID)
+	if err != nil {
+		var stockErr *orderService.InsufficientStockError
+		if errors.As(err, &stockErr) {
+			response.JSON(c, http.StatusConflict, gin.H{
+				"error": gin.H{
+					"code":    "INSUFFICIENT_STOCK",
+					"message": stockErr.Error(),
+					"details": gin.H{
+						"product_id": stockErr.ProductID,
+						"requested":  stockErr.Requested,
+					},
+				},
+			})
+			return
+		}
+		apperror.ToHTTPError(c, err, http.StatusBadRequest, "retry payment")
+		return
+	}
+	response.JSON(c, http.StatusOK, h.intentResponse(intent, req.Provider))
+}
+
+func (h *Handler) intentResponse(intent *payment.Intent, provider string) intentResponse {
+	if intent.Provider != "" {
+		provider = intent.Provider
+	}
+	if provider == "" {
+		provider = h.providers.DefaultName()
+	}
+	res := intentResponse{
 		IntentID:     intent.ID,
+		Provider:     provider,
 		ClientSecret: intent.ClientSecret,
+		RedirectURL:  intent.RedirectURL,
+		Instructions: intent.Instructions,
 		Amount:       intent.Amount,
 		Currency:     intent.Currency,
 		Status:       intent.Status,
-	})
+	}
+	if !intent.ExpiresAt.IsZero() {
+		res.ExpiresAt = &intent.ExpiresAt
+	}
+	return res
 }
 
-// StripeWebhook receives provider callbacks. Reads the raw body (signature is over the bytes
-// as transmitted) and verifies before any side effect.
-func (h *Handler) StripeWebhook(c *gin.Context) {
+// Webhook receives provider callbacks at /webhooks/:provider. Reads the raw body (signatures
+// are over the bytes as transmitted) and verifies before any side effect.
+func (h *Handler) Webhook(c *gin.Context) {
+	provider := c.Param("provider")
 	body, err := io.ReadAll(c.Request.Body)
 	if err != nil {
 		response.Error(c, http.StatusBadRequest, err, "read webhook body")
 		return
 	}
-	sig := c.GetHeader(stripeSignatureHeader)
-	if err := h.svc.HandleWebhook(c.Request.Context(), body, sig); err != nil {
-		if err == payment.ErrInvalidSignature {
+	if err := h.svc.HandleWebhook(c.Request.Context(), provider, body, c.Request.Header); err != nil {
+		switch {
+		case errors.Is(err, payment.ErrUnknownProvider), errors.Is(err, payment.ErrWebhooksNotSupported):
+			response.Error(c, http.StatusNotFound, err, "no webhooks for this provider")
+		case errors.Is(err, payment.ErrInvalidSignature):
 			response.Error(c, http.StatusBadRequest, err, "invalid signature")
-			return
+		default:
+			logger.Errorf("%s webhook: %s", provider, err)
+			response.Error(c, http.StatusInternalServerError, err, "webhook processing failed")
 		}
-		logger.Errorf("stripe webhook: %s", err)
-		response.Error(c, http.StatusInternalServerError, err, "webhook processing failed")
 		return
 	}
 	response.JSON(c, http.StatusOK, gin.H{"received": true})
 }
+
+// ConfirmPayment godoc
+//
+//	@Summary	Admin: confirm an offline (bank transfer) payment was received and mark the order paid
+//	@Tags		payments
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string	true	"Order ID"
+//	@Success	200	{object}	model.Payment
+//	@Router		/api/v1/admin/orders/{id}/payment/confirm [post]
+func (h *Handler) ConfirmPayment(c *gin.Context) {
+	rec, err := h.svc.ConfirmPayment(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		logger.Error("Failed to confirm payment: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+	logger.Infof("admin confirm payment: admin=%s order=%s", c.GetString("userId"), rec.OrderID)
+
+	response.JSON(c, http.StatusOK, rec)
+}
+
+// CollectCashOnDelivery godoc
+//
+//	@Summary	Admin: record the cash a courier collected for a cash-on-delivery order and mark it done
+//	@Tags		payments
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string	true	"Order ID"
+//	@Success	200	{object}	model.Payment
+//	@Router		/api/v1/admin/orders/{id}/payment/collect [post]
+func (h *Handler) CollectCashOnDelivery(c *gin.Context) {
+	rec, err := h.svc.CollectCashOnDelivery(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		logger.Error("Failed to collect cash on delivery: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+	logger.Infof("admin collect cash on delivery: admin=%s order=%s amount=%d", c.GetString("userId"), rec.OrderID, rec.Amount)
+
+	response.JSON(c, http.StatusOK, rec)
+}
+
+type refundLineRequest struct {
+	LineID   string `json:"line_id" binding:"required"`
+	Quantity uint   `json:"quantity" binding:"required,gt=0"`
+}
+
+// refundRequest refunds the order's remaining balance when Lines is empty, otherwise the value
+// of the listed units.
+type refundRequest struct {
+	Lines          []refundLineRequest `json:"lines" binding:"omitempty,dive"`
+	Reason         string              `json:"reason" binding:"max=500"`
+	IdempotencyKey string              `json:"idempotency_key" binding:"max=255"`
+}
+
+// RefundOrder godoc
+//
+//	@Summary	Admin: refund an order in full or for specific order lines
+//	@Tags		payments
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string			true	"Order ID"
+//	@Param		_	body		refundRequest	true	"Body"
+//	@Success	200	{object}	model.Refund
+//	@Router		/api/v1/admin/orders/{id}/refunds [post]
+func (h *Handler) RefundOrder(c *gin.Context) {
+	var req refundRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		logger.Error("Failed to parse request body: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	lines := make([]service.RefundLine, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		lines = append(lines, service.RefundLine{OrderLineID: line.LineID, Quantity: line.Quantity})
+	}
+	refund, err := h.svc.RefundOrder(c.Request.Context(), c.Param("id"), service.RefundRequest{
+		Lines:          lines,
+		Reason:         req.Reason,
+		IdempotencyKey: req.IdempotencyKey,
+		ActorID:        c.GetString("userId"),
+	})
+	if err != nil {
+		logger.Error("Failed to refund order: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, refund)
+}
+
+// ListRefunds godoc
+//
+//	@Summary	Admin: list an order's refunds, oldest first
+//	@Tags		payments
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string	true	"Order ID"
+//	@Success	200	{object}	[]model.Refund
+//	@Router		/api/v1/admin/orders/{id}/refunds [get]
+func (h *Handler) ListRefunds(c *gin.Context) {
+	refunds, err := h.svc.ListRefunds(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		logger.Error("Failed to list refunds: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, refunds)
+}
+
+// ListFailedEvents godoc
+//
+//	@Summary	Admin: list webhook events that failed to apply, oldest first
+//	@Tags		payments
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	query		domain.ListFailedEventsReq	true	"Query"
+//	@Success	200	{object}	domain.ListFailedEventsRes
+//	@Router		/api/v1/admin/webhook-events [get]
+func (h *Handler) ListFailedEvents(c *gin.Context) {
+	var req domain.ListFailedEventsReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to parse request req: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	events, pagination, err := h.svc.ListFailedEvents(c.Request.Context(), &req)
+	if err != nil {
+		logger.Error("Failed to list failed webhook events: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ListFailedEventsRes{
+		Events:     events,
+		Pagination: pagination,
+	})
+}
+
+// ReplayEvent godoc
+//
+//	@Summary	Admin: apply a stored webhook event again now
+//	@Tags		payments
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		provider	path		string	true	"Provider"
+//	@Param		event_id	path		string	true	"Provider event ID"
+//	@Success	200			{object}	model.ProviderEvent
+//	@Router		/api/v1/admin/webhook-events/{provider}/{event_id}/replay [post]
+func (h *Handler) ReplayEvent(c *gin.Context) {
+	event, err := h.svc.ReplayEvent(c.Request.Context(), c.Param("provider"), c.Param("event_id"))
+	if err != nil {
+		logger.Error("Failed to replay webhook event: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+	logger.Infof("admin replay webhook event: admin=%s provider=%s event=%s status=%s",
+		c.GetString("userId"), event.Provider, event.EventID, event.Status)
+
+	response.JSON(c, http.StatusOK, event)
+}
+
+// ListReconcileRuns godoc
+//
+//	@Summary	Admin: list payment reconciliation runs, newest first
+//	@Tags		payments
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	query		domain.ListReconcileRunsReq	true	"Query"
+//	@Success	200	{object}	domain.ListReconcileRunsRes
+//	@Router		/api/v1/admin/payments/reconcile-runs [get]
+func (h *Handler) ListReconcileRuns(c *gin.Context) {
+	var req domain.ListReconcileRunsReq
+	if err := c.ShouldBindQuery(&req); err != nil {
+		logger.Error("Failed to parse request req: ", err)
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	runs, pagination, err := h.svc.ListReconcileRuns(c.Request.Context(), &req)
+	if err != nil {
+		logger.Error("Failed to list payment reconcile runs: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, domain.ListReconcileRunsRes{
+		Runs:       runs,
+		Pagination: pagination,
+	})
+}
+
+// GetReconcileRun godoc
+//
+//	@Summary	Admin: get a payment reconciliation run with the payments it checked
+//	@Tags		payments
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path		string	true	"Run ID"
+//	@Success	200	{object}	model.ReconcileRun
+//	@Failure	404	{object}	response.Response
+//	@Router		/api/v1/admin/payments/reconcile-runs/{id} [get]
+func (h *Handler) GetReconcileRun(c *gin.Context) {
+	run, err := h.svc.GetReconcileRun(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		logger.Error("Failed to get payment reconcile run: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+
+	response.JSON(c, http.StatusOK, run)
+}
@@ -3,68 +3,260 @@ package http
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/gin-gonic/gin"
 	"github.com/quangdangfit/gocommon/logger"
 	"github.com/stretchr/testify/require"
 
+	orderService "goshop/internal/order/service"
+	"goshop/internal/payment/domain"
+	"goshop/internal/payment/model"
+	"goshop/internal/payment/service"
+	"goshop/pkg/apperror"
 	"goshop/pkg/config"
+	"goshop/pkg/paging"
 	"goshop/pkg/payment"
 )
 
 type stubPayments struct {
-	createFn func(ctx context.Context, orderID string) (*payment.Intent, error)
-	hookFn   func(ctx context.Context, payload []byte, sig string) error
+	createFn  func(ctx context.Context, orderID, provider string) (*payment.Intent, error)
+	retryFn   func(ctx context.Context, orderID, provider string) (*payment.Intent, error)
+	hookFn    func(ctx context.Context, provider string, payload []byte, headers http.Header) error
+	confirmFn func(ctx context.Context, orderID string) (*model.Payment, error)
+	collectFn func(ctx context.Context, orderID string) (*model.Payment, error)
+	refundFn  func(ctx context.Context, orderID string, req service.RefundRequest) (*model.Refund, error)
+	listFn    func(ctx context.Context, orderID string) ([]*model.Refund, error)
+	eventsFn  func(ctx context.Context, req *domain.ListFailedEventsReq) ([]*model.ProviderEvent, *paging.Pagination, error)
+	replayFn  func(ctx context.Context, provider, eventID string) (*model.ProviderEvent, error)
+	runFn     func(ctx context.Context, id string) (*model.ReconcileRun, error)
+
+	methodID string // payment_method_id of the last create or retry call
+	userID   string // caller of the last create or retry call
 }
 
-func (s *stubPayments) CreateIntentForOrder(ctx context.Context, o string) (*payment.Intent, error) {
-	return s.createFn(ctx, o)
+func (s *stubPayments) CreateIntentForOrder(ctx context.Context, o, userID, provider, methodID string) (*payment.Intent, error) {
+	s.methodID, s.userID = methodID, userID
+	return s.createFn(ctx, o, provider)
+}
+func (s *stubPayments) RetryPayment(ctx context.Context, o, userID, provider, methodID string) (*payment.Intent, error) {
+	s.methodID, s.userID = methodID, userID
+	return s.retryFn(ctx, o, provider)
+}
+func (s *stubPayments) HandleWebhook(ctx context.Context, provider string, payload []byte, headers http.Header) error {
+	return s.hookFn(ctx, provider, payload, headers)
 }
-func (s *stubPayments) HandleWebhook(ctx context.Context, payload []byte, sig string) error {
-	return s.hookFn(ctx, payload, sig)
+func (s *stubPayments) ConfirmPayment(ctx context.Context, o string) (*model.Payment, error) {
+	return s.confirmFn(ctx, o)
+}
+func (s *stubPayments) CollectCashOnDelivery(ctx context.Context, o string) (*model.Payment, error) {
+	return s.collectFn(ctx, o)
+}
+
+func (s *stubPayments) RefundOrder(ctx context.Context, o string, req service.RefundRequest) (*model.Refund, error) {
+	return s.refundFn(ctx, o, req)
+}
+func (s *stubPayments) ListRefunds(ctx context.Context, o string) ([]*model.Refund, error) {
+	return s.listFn(ctx, o)
+}
+func (s *stubPayments) ReconcilePayments(context.Context, service.ReconcileRequest) (*service.ReconcileReport, error) {
+	return nil, nil
+}
+func (s *stubPayments) ProcessPendingEvents(context.Context, int) (int, error) {
+	return 0, nil
+}
+func (s *stubPayments) ListFailedEvents(ctx context.Context, req *domain.ListFailedEventsReq) ([]*model.ProviderEvent, *paging.Pagination, error) {
+	return s.eventsFn(ctx, req)
+}
+func (s *stubPayments) ReplayEvent(ctx context.Context, provider, eventID string) (*model.ProviderEvent, error) {
+	return s.replayFn(ctx, provider, eventID)
+}
+func (s *stubPayments) ListReconcileRuns(context.Context, *domain.ListReconcileRunsReq) ([]*model.ReconcileRun, *paging.Pagination, error) {
+	return nil, nil, nil
+}
+func (s *stubPayments) GetReconcileRun(ctx context.Context, id string) (*model.ReconcileRun, error) {
+	return s.runFn(ctx, id)
 }
 
 func setupRouter(svc *stubPayments) *gin.Engine {
 	logger.Initialize(config.ProductionEnv)
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	h := NewHandler(svc)
-	r.POST("/orders/:id/payment-intent", h.CreatePaymentIntent)
-	r.POST("/webhooks/stripe", h.StripeWebhook)
+	h := NewHandler(svc, payment.NewRegistry("stripe"))
+	buyer := func(c *gin.Context) { c.Set("userId", "u1") }
+	r.POST("/orders/:id/payment-intent", buyer, h.CreatePaymentIntent)
+	r.POST("/orders/:id/payment/retry", buyer, h.RetryPayment)
+	r.POST("/anon/orders/:id/payment-intent", h.CreatePaymentIntent)
+	r.POST("/anon/orders/:id/payment/retry", h.RetryPayment)
+	r.POST("/webhooks/:provider", h.Webhook)
+	r.POST("/admin/orders/:id/payment/confirm", func(c *gin.Context) { c.Set("userId", "admin1") }, h.ConfirmPayment)
+	r.POST("/admin/orders/:id/payment/collect", func(c *gin.Context) { c.Set("userId", "admin1") }, h.CollectCashOnDelivery)
+	r.POST("/admin/orders/:id/refunds", func(c *gin.Context) { c.Set("userId", "admin1") }, h.RefundOrder)
+	r.GET("/admin/orders/:id/refunds", h.ListRefunds)
+	r.GET("/admin/webhook-events", h.ListFailedEvents)
+	r.POST("/admin/webhook-events/:provider/:event_id/replay", h.ReplayEvent)
+	r.GET("/admin/payments/reconcile-runs/:id", h.GetReconcileRun)
 	return r
 }
 
 func TestCreatePaymentIntent_OK(t *testing.T) {
-	svc := &stubPayments{createFn: func(_ context.Context, id string) (*payment.Intent, error) {
+	svc := &stubPayments{createFn: func(_ context.Context, id, provider string) (*payment.Intent, error) {
 		require.Equal(t, "o1", id)
+		require.Empty(t, provider)
 		return &payment.Intent{ID: "pi_1", ClientSecret: "cs", Amount: 1000, Currency: "usd", Status: "requires_payment_method"}, nil
 	}}
 	r := setupRouter(svc)
 	w := httptest.NewRecorder()
 	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders/o1/payment-intent", nil))
 	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "u1", svc.userID)
+
+	var res struct {
+		Result map[string]any `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, "stripe", res.Result["provider"])
+	require.NotContains(t, res.Result, "redirect_url")
 }
 
-func TestCreatePaymentIntent_Error(t *testing.T) {
-	svc := &stubPayments{createFn: func(_ context.Context, _ string) (*payment.Intent, error) {
-		return nil, errors.New("nope")
+func TestCreatePaymentIntent_ChosenProvider(t *testing.T) {
+	svc := &stubPayments{createFn: func(_ context.Context, _, provider string) (*payment.Intent, error) {
+		require.Equal(t, "paypal", provider)
+		return &payment.Intent{ID: "PP-1", RedirectURL: "https://paypal.example/approve", Amount: 1000, Currency: "usd"}, nil
 	}}
 	r := setupRouter(svc)
 	w := httptest.NewRecorder()
-	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders/o1/payment-intent", nil))
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders/o1/payment-intent", strings.NewReader(`{"provider":"paypal"}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Result intentResponse `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, "paypal", res.Result.Provider)
+	require.Equal(t, "https://paypal.example/approve", res.Result.RedirectURL)
+}
+
+func TestCreatePaymentIntent_SavedMethod(t *testing.T) {
+	// The service resolves the provider from the saved method, so the response names it even
+	// though the request didn't.
+	svc := &stubPayments{createFn: func(_ context.Context, _, provider string) (*payment.Intent, error) {
+		require.Empty(t, provider)
+		return &payment.Intent{ID: "pi_1", Provider: "paypal", Amount: 1000, Currency: "usd", Status: "processing"}, nil
+	}}
+	r := setupRouter(svc)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders/o1/payment-intent", strings.NewReader(`{"payment_method_id":"pm1"}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Equal(t, "pm1", svc.methodID)
+
+	var res struct {
+		Result intentResponse `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, "paypal", res.Result.Provider)
+}
+
+func TestCreatePaymentIntent_RequiresUser(t *testing.T) {
+	for _, path := range []string{"/anon/orders/o1/payment-intent", "/anon/orders/o1/payment/retry"} {
+		t.Run(path, func(t *testing.T) {
+			r := setupRouter(&stubPayments{})
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+			require.Equal(t, http.StatusUnauthorized, w.Code)
+		})
+	}
+}
+
+func TestCreatePaymentIntent_InvalidBody(t *testing.T) {
+	r := setupRouter(&stubPayments{})
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders/o1/payment-intent", strings.NewReader(`{`)))
 	require.Equal(t, http.StatusBadRequest, w.Code)
 }
 
-func TestStripeWebhook_OK(t *testing.T) {
+func TestCreatePaymentIntent_Error(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"plain_error", errors.New("nope"), http.StatusBadRequest},
+		{"already_paying_elsewhere", apperror.WrapMessage(apperror.ErrConflict, nil, "order is already being paid with paypal"), http.StatusConflict},
+		{"someone_elses_order", apperror.WrapMessage(apperror.ErrNotFound, nil, "order not found"), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &stubPayments{createFn: func(_ context.Context, _, _ string) (*payment.Intent, error) {
+				return nil, tt.err
+			}}
+			r := setupRouter(svc)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders/o1/payment-intent", nil))
+			require.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestRetryPayment_OK(t *testing.T) {
+	svc := &stubPayments{retryFn: func(_ context.Context, id, provider string) (*payment.Intent, error) {
+		require.Equal(t, "o1", id)
+		require.Equal(t, "paypal", provider)
+		return &payment.Intent{ID: "PP-2", RedirectURL: "https://paypal.example/approve", Amount: 1000, Currency: "usd"}, nil
+	}}
+	r := setupRouter(svc)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders/o1/payment/retry", strings.NewReader(`{"provider":"paypal"}`)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Result intentResponse `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, "PP-2", res.Result.IntentID)
+	require.Equal(t, "paypal", res.Result.Provider)
+}
+
+func TestRetryPayment_Error(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		want     int
+		wantCode string
+	}{
+		{"insufficient_stock", &orderService.InsufficientStockError{ProductID: "p1", Requested: 2}, http.StatusConflict, "INSUFFICIENT_STOCK"},
+		{"payment_still_active", apperror.WrapMessage(apperror.ErrInvalidStatus, nil, "payment is pending"), http.StatusUnprocessableEntity, ""},
+		{"plain_error", errors.New("nope"), http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &stubPayments{retryFn: func(_ context.Context, _, _ string) (*payment.Intent, error) {
+				return nil, tt.err
+			}}
+			r := setupRouter(svc)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/orders/o1/payment/retry", nil))
+			require.Equal(t, tt.want, w.Code)
+			if tt.wantCode != "" {
+				require.Contains(t, w.Body.String(), tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestWebhook_OK(t *testing.T) {
 	called := false
-	svc := &stubPayments{hookFn: func(_ context.Context, payload []byte, sig string) error {
+	svc := &stubPayments{hookFn: func(_ context.Context, provider string, payload []byte, headers http.Header) error {
 		called = true
-		require.Equal(t, "sig", sig)
+		require.Equal(t, "stripe", provider)
+		require.Equal(t, "sig", headers.Get("Stripe-Signature"))
 		require.Equal(t, "{}", string(payload))
 		return nil
 	}}
@@ -77,34 +269,269 @@ func TestStripeWebhook_OK(t *testing.T) {
 	require.True(t, called)
 }
 
-func TestStripeWebhook_InvalidSignature(t *testing.T) {
-	svc := &stubPayments{hookFn: func(_ context.Context, _ []byte, _ string) error { return payment.ErrInvalidSignature }}
+func TestWebhook_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"invalid_signature", payment.ErrInvalidSignature, http.StatusBadRequest},
+		{"unknown_provider", fmt.Errorf("%w: %q", payment.ErrUnknownProvider, "bitcoin"), http.StatusNotFound},
+		{"provider_without_webhooks", payment.ErrWebhooksNotSupported, http.StatusNotFound},
+		{"processing_failed", errors.New("processing failed"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &stubPayments{hookFn: func(_ context.Context, _ string, _ []byte, _ http.Header) error { return tt.err }}
+			r := setupRouter(svc)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/stripe", bytes.NewReader([]byte("{}")))
+			r.ServeHTTP(w, req)
+			require.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+type errReader struct{}
+
+func (errReader) Read(_ []byte) (int, error) { return 0, errors.New("read failed") }
+func (errReader) Close() error               { return nil }
+
+func TestWebhook_ReadBodyError(t *testing.T) {
+	svc := &stubPayments{}
 	r := setupRouter(svc)
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest(http.MethodPost, "/webhooks/stripe", bytes.NewReader([]byte("{}")))
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/stripe", io.NopCloser(errReader{}))
 	r.ServeHTTP(w, req)
 	require.Equal(t, http.StatusBadRequest, w.Code)
 }
 
-func TestStripeWebhook_OtherError(t *testing.T) {
-	svc := &stubPayments{hookFn: func(_ context.Context, _ []byte, _ string) error { return errors.New("processing failed") }}
+func TestRefundOrder_OK(t *testing.T) {
+	svc := &stubPayments{refundFn: func(_ context.Context, id string, req service.RefundRequest) (*model.Refund, error) {
+		require.Equal(t, "o1", id)
+		require.Equal(t, service.RefundRequest{
+			Lines:          []service.RefundLine{{OrderLineID: "l1", Quantity: 2}},
+			Reason:         "damaged",
+			IdempotencyKey: "k1",
+			ActorID:        "admin1",
+		}, req)
+		return &model.Refund{ID: "rf_1", Amount: 500, Status: model.RefundStatusSucceeded}, nil
+	}}
 	r := setupRouter(svc)
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest(http.MethodPost, "/webhooks/stripe", bytes.NewReader([]byte("{}")))
-	r.ServeHTTP(w, req)
+	body := `{"lines":[{"line_id":"l1","quantity":2}],"reason":"damaged","idempotency_key":"k1"}`
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/orders/o1/refunds", strings.NewReader(body)))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Result model.Refund `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, "rf_1", res.Result.ID)
+	require.Equal(t, int64(500), res.Result.Amount)
+}
+
+func TestRefundOrder_InvalidBody(t *testing.T) {
+	for _, body := range []string{`{`, `{"lines":[{"line_id":"l1","quantity":0}]}`, `{"lines":[{"quantity":1}]}`} {
+		r := setupRouter(&stubPayments{})
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/orders/o1/refunds", strings.NewReader(body)))
+		require.Equal(t, http.StatusBadRequest, w.Code, body)
+	}
+}
+
+func TestRefundOrder_ServiceErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"rejected", apperror.WrapMessage(apperror.ErrBadRequest, nil, "nothing left to refund"), http.StatusBadRequest},
+		{"order_not_found", apperror.ErrNotFound, http.StatusNotFound},
+		{"provider_error", errors.New("stripe down"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &stubPayments{refundFn: func(_ context.Context, _ string, _ service.RefundRequest) (*model.Refund, error) {
+				return nil, tt.err
+			}}
+			r := setupRouter(svc)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/orders/o1/refunds", strings.NewReader(`{}`)))
+			require.Equal(t, tt.want, w.Code)
+		})
+	}
+}
+
+func TestListRefunds(t *testing.T) {
+	svc := &stubPayments{listFn: func(_ context.Context, id string) ([]*model.Refund, error) {
+		require.Equal(t, "o1", id)
+		return []*model.Refund{{ID: "rf_1"}, {ID: "rf_2"}}, nil
+	}}
+	r := setupRouter(svc)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/orders/o1/refunds", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Result []*model.Refund `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Len(t, res.Result, 2)
+}
+
+func TestListRefunds_Error(t *testing.T) {
+	svc := &stubPayments{listFn: func(_ context.Context, _ string) ([]*model.Refund, error) { return nil, errors.New("db") }}
+	r := setupRouter(svc)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/orders/o1/refunds", nil))
 	require.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
-type errReader struct{}
+func TestConfirmPayment(t *testing.T) {
+	svc := &stubPayments{confirmFn: func(_ context.Context, id string) (*model.Payment, error) {
+		require.Equal(t, "o1", id)
+		return &model.Payment{ID: "p1", OrderID: "o1", Provider: "bank_transfer", Status: model.PaymentStatusSucceeded}, nil
+	}}
+	r := setupRouter(svc)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/orders/o1/payment/confirm", nil))
+	require.Equal(t, http.StatusOK, w.Code)
 
-func (errReader) Read(_ []byte) (int, error) { return 0, errors.New("read failed") }
-func (errReader) Close() error               { return nil }
+	var res struct {
+		Result model.Payment `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, model.PaymentStatusSucceeded, res.Result.Status)
+}
 
-func TestStripeWebhook_ReadBodyError(t *testing.T) {
-	svc := &stubPayments{}
+func TestConfirmPayment_Error(t *testing.T) {
+	svc := &stubPayments{confirmFn: func(_ context.Context, _ string) (*model.Payment, error) {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "stripe payments are confirmed by the provider")
+	}}
 	r := setupRouter(svc)
 	w := httptest.NewRecorder()
-	req := httptest.NewRequest(http.MethodPost, "/webhooks/stripe", io.NopCloser(errReader{}))
-	r.ServeHTTP(w, req)
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/orders/o1/payment/confirm", nil))
 	require.Equal(t, http.StatusBadRequest, w.Code)
 }
+
+func TestCollectCashOnDelivery(t *testing.T) {
+	svc := &stubPayments{collectFn: func(_ context.Context, id string) (*model.Payment, error) {
+		require.Equal(t, "o1", id)
+		return &model.Payment{ID: "p1", OrderID: "o1", Provider: model.ProviderCashOnDelivery, Amount: 1500, Status: model.PaymentStatusSucceeded}, nil
+	}}
+	r := setupRouter(svc)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/orders/o1/payment/collect", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Result model.Payment `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, model.ProviderCashOnDelivery, res.Result.Provider)
+}
+
+func TestCollectCashOnDelivery_Error(t *testing.T) {
+	svc := &stubPayments{collectFn: func(_ context.Context, _ string) (*model.Payment, error) {
+		return nil, apperror.WrapMessage(apperror.ErrInvalidStatus, nil, "order is new, not out for delivery")
+	}}
+	r := setupRouter(svc)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/orders/o1/payment/collect", nil))
+	require.Equal(t, http.StatusUnprocessableEntity, w.Code)
+}
+
+func TestListFailedEvents(t *testing.T) {
+	svc := &stubPayments{eventsFn: func(_ context.Context, req *domain.ListFailedEventsReq) ([]*model.ProviderEvent, *paging.Pagination, error) {
+		require.Equal(t, "stripe", req.Provider)
+		require.Equal(t, int64(2), req.Page)
+		return []*model.ProviderEvent{{Provider: "stripe", EventID: "evt_1", Status: model.ProviderEventStatusFailed, LastError: "db down"}},
+			paging.New(2, 10, 11), nil
+	}}
+	r := setupRouter(svc)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/webhook-events?provider=stripe&page=2&limit=10", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Result domain.ListFailedEventsRes `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Len(t, res.Result.Events, 1)
+	require.Equal(t, "db down", res.Result.Events[0].LastError)
+	require.Equal(t, int64(11), res.Result.Pagination.Total)
+}
+
+func TestListFailedEvents_Error(t *testing.T) {
+	svc := &stubPayments{eventsFn: func(context.Context, *domain.ListFailedEventsReq) ([]*model.ProviderEvent, *paging.Pagination, error) {
+		return nil, nil, errors.New("db")
+	}}
+	r := setupRouter(svc)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/webhook-events", nil))
+	require.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestReplayEvent(t *testing.T) {
+	svc := &stubPayments{replayFn: func(_ context.Context, provider, eventID string) (*model.ProviderEvent, error) {
+		require.Equal(t, "paypal", provider)
+		require.Equal(t, "WH-1", eventID)
+		return &model.ProviderEvent{Provider: provider, EventID: eventID, Status: model.ProviderEventStatusProcessed}, nil
+	}}
+	r := setupRouter(svc)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/webhook-events/paypal/WH-1/replay", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Result model.ProviderEvent `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, model.ProviderEventStatusProcessed, res.Result.Status)
+}
+
+func TestReplayEvent_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{"not_found", apperror.ErrNotFound, http.StatusNotFound},
+		{"already_processed", apperror.WrapMessage(apperror.ErrInvalidStatus, nil, "webhook event was already processed"), http.StatusUnprocessableEntity},
+		{"being_processed", apperror.WrapMessage(apperror.ErrConflict, nil, "webhook event is already being processed"), http.StatusConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &stubPayments{replayFn: func(context.Context, string, string) (*model.ProviderEvent, error) { return nil, tt.err }}
+			r := setupRouter(svc)
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/admin/webhook-events/stripe/evt_1/replay", nil))
+			require.Equal(t, tt.code, w.Code)
+		})
+	}
+}
+
+func TestGetReconcileRun(t *testing.T) {
+	svc := &stubPayments{runFn: func(_ context.Context, id string) (*model.ReconcileRun, error) {
+		if id != "run1" {
+			return nil, apperror.ErrNotFound
+		}
+		return &model.ReconcileRun{ID: id, Checked: 1, Entries: []*model.ReconcileRunEntry{{PaymentID: "p1", Action: "updated"}}}, nil
+	}}
+	r := setupRouter(svc)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/payments/reconcile-runs/run1", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Result model.ReconcileRun `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Len(t, res.Result.Entries, 1)
+	require.Equal(t, "p1", res.Result.Entries[0].PaymentID)
+
+	w = httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/admin/payments/reconcile-runs/run2", nil))
+	require.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+
+	"goshop/internal/payment/domain"
+	"goshop/internal/payment/service"
+	"goshop/pkg/apperror"
+	"goshop/pkg/payment"
+	"goshop/pkg/response"
+)
+
+type MethodHandler struct {
+	svc       service.PaymentMethodService
+	providers *payment.Registry
+}
+
+func NewMethodHandler(svc service.PaymentMethodService, providers *payment.Registry) *MethodHandler {
+	return &MethodHandler{svc: svc, providers: providers}
+}
+
+// ListPaymentMethods godoc
+//
+//	@Summary	List my saved payment methods, newest first
+//	@Tags		payments
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Success	200	{object}	[]model.PaymentMethod
+//	@Router		/api/v1/me/payment-methods [get]
+func (h *MethodHandler) ListPaymentMethods(c *gin.Context) {
+	userID := c.GetString("userId")
+	if userID == "" {
+		apperror.ErrUnauthorized.HTTPError(c)
+		return
+	}
+	methods, err := h.svc.ListPaymentMethods(c.Request.Context(), userID)
+	if err != nil {
+		logger.Error("Failed to list payment methods: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+	response.JSON(c, http.StatusOK, methods)
+}
+
+// StartSetup godoc
+//
+//	@Summary	Start saving a payment method with the chosen (or default) provider
+//	@Tags		payments
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	body		domain.StartSetupReq	false	"Body"
+//	@Success	200	{object}	domain.SetupRes
+//	@Failure	400	{object}	response.Response
+//	@Router		/api/v1/me/payment-methods/setup [post]
+func (h *MethodHandler) StartSetup(c *gin.Context) {
+	userID := c.GetString("userId")
+	if userID == "" {
+		apperror.ErrUnauthorized.HTTPError(c)
+		return
+	}
+	var req domain.StartSetupReq
+	if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	setup, err := h.svc.StartSetup(c.Request.Context(), userID, req.Provider)
+	if err != nil {
+		logger.Error("Failed to start payment method setup: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+	provider := req.Provider
+	if provider == "" {
+		provider = h.providers.DefaultName()
+	}
+	response.JSON(c, http.StatusOK, domain.SetupRes{
+		SetupIntentID: setup.ID,
+		Provider:      provider,
+		ClientSecret:  setup.ClientSecret,
+	})
+}
+
+// SavePaymentMethod godoc
+//
+//	@Summary	Save the payment method entered for a completed setup intent
+//	@Tags		payments
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		_	body		domain.SavePaymentMethodReq	true	"Body"
+//	@Success	200	{object}	model.PaymentMethod
+//	@Failure	404	{object}	response.Response
+//	@Failure	422	{object}	response.Response
+//	@Router		/api/v1/me/payment-methods [post]
+func (h *MethodHandler) SavePaymentMethod(c *gin.Context) {
+	userID := c.GetString("userId")
+	if userID == "" {
+		apperror.ErrUnauthorized.HTTPError(c)
+		return
+	}
+	var req domain.SavePaymentMethodReq
+	if err := c.ShouldBindJSON(&req); err != nil {
+		apperror.Wrap(apperror.ErrBadRequest, err).HTTPError(c)
+		return
+	}
+
+	method, err := h.svc.SavePaymentMethod(c.Request.Context(), userID, &req)
+	if err != nil {
+		logger.Error("Failed to save payment method: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+	response.JSON(c, http.StatusOK, method)
+}
+
+// DeletePaymentMethod godoc
+//
+//	@Summary	Remove one of my saved payment methods
+//	@Tags		payments
+//	@Produce	json
+//	@Security	ApiKeyAuth
+//	@Param		id	path	string	true	"Payment method ID"
+//	@Success	200
+//	@Failure	404	{object}	response.Response
+//	@Router		/api/v1/me/payment-methods/{id} [delete]
+func (h *MethodHandler) DeletePaymentMethod(c *gin.Context) {
+	userID := c.GetString("userId")
+	if userID == "" {
+		apperror.ErrUnauthorized.HTTPError(c)
+		return
+	}
+	if err := h.svc.DeletePaymentMethod(c.Request.Context(), c.Param("id"), userID); err != nil {
+		logger.Error("Failed to delete payment method: ", err)
+		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
+		return
+	}
+	response.JSON(c, http.StatusOK, nil)
+}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/require"
+
+	"goshop/internal/payment/domain"
+	"goshop/internal/payment/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/payment"
+)
+
+type stubMethods struct {
+	listFn   func(ctx context.Context, userID string) ([]*model.PaymentMethod, error)
+	setupFn  func(ctx context.Context, userID, provider string) (*payment.SetupIntent, error)
+	saveFn   func(ctx context.Context, userID string, req *domain.SavePaymentMethodReq) (*model.PaymentMethod, error)
+	deleteFn func(ctx context.Context, id, userID string) error
+}
+
+func (s *stubMethods) ListPaymentMethods(ctx context.Context, userID string) ([]*model.PaymentMethod, error) {
+	return s.listFn(ctx, userID)
+}
+func (s *stubMethods) StartSetup(ctx context.Context, userID, provider string) (*payment.SetupIntent, error) {
+	return s.setupFn(ctx, userID, provider)
+}
+func (s *stubMethods) SavePaymentMethod(ctx context.Context, userID string, req *domain.SavePaymentMethodReq) (*model.PaymentMethod, error) {
+	return s.saveFn(ctx, userID, req)
+}
+func (s *stubMethods) DeletePaymentMethod(ctx context.Context, id, userID string) error {
+	return s.deleteFn(ctx, id, userID)
+}
+
+func setupMethodRouter(svc *stubMethods) *gin.Engine {
+	logger.Initialize(config.ProductionEnv)
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	h := NewMethodHandler(svc, payment.NewRegistry("stripe"))
+	g := r.Group("/me/payment-methods", func(c *gin.Context) {
+		if id := c.GetHeader("X-User"); id != "" {
+			c.Set("userId", id)
+		}
+	})
+	g.GET("", h.ListPaymentMethods)
+	g.POST("", h.SavePaymentMethod)
+	g.POST("/setup", h.StartSetup)
+	g.DELETE("/:id", h.DeletePaymentMethod)
+	return r
+}
+
+func serveAs(r *gin.Engine, userID, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	if userID != "" {
+		req.Header.Set("X-User", userID)
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestMethodHandlers_Unauthorized(t *testing.T) {
+	r := setupMethodRouter(&stubMethods{})
+	require.Equal(t, http.StatusUnauthorized, serveAs(r, "", http.MethodGet, "/me/payment-methods", "").Code)
+	require.Equal(t, http.StatusUnauthorized, serveAs(r, "", http.MethodPost, "/me/payment-methods/setup", "").Code)
+	require.Equal(t, http.StatusUnauthorized, serveAs(r, "", http.MethodPost, "/me/payment-methods", "{}").Code)
+	require.Equal(t, http.StatusUnauthorized, serveAs(r, "", http.MethodDelete, "/me/payment-methods/pm1", "").Code)
+}
+
+func TestListPaymentMethods(t *testing.T) {
+	svc := &stubMethods{listFn: func(_ context.Context, userID string) ([]*model.PaymentMethod, error) {
+		require.Equal(t, "u1", userID)
+		return []*model.PaymentMethod{{ID: "pm1", Provider: "stripe", ProviderMethodID: "pm_secret", Brand: "visa", Last4: "4242"}}, nil
+	}}
+	w := serveAs(setupMethodRouter(svc), "u1", http.MethodGet, "/me/payment-methods", "")
+	require.Equal(t, http.StatusOK, w.Code)
+	require.Contains(t, w.Body.String(), `"last4":"4242"`)
+	require.NotContains(t, w.Body.String(), "pm_secret")
+}
+
+func TestStartSetup(t *testing.T) {
+	svc := &stubMethods{setupFn: func(_ context.Context, userID, provider string) (*payment.SetupIntent, error) {
+		require.Equal(t, "u1", userID)
+		require.Empty(t, provider)
+		return &payment.SetupIntent{ID: "seti_1", ClientSecret: "seti_1_secret"}, nil
+	}}
+	// No body at all picks the default provider.
+	w := serveAs(setupMethodRouter(svc), "u1", http.MethodPost, "/me/payment-methods/setup", "")
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Result domain.SetupRes `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+	require.Equal(t, domain.SetupRes{SetupIntentID: "seti_1", Provider: "stripe", ClientSecret: "seti_1_secret"}, res.Result)
+}
+
+func TestStartSetup_Errors(t *testing.T) {
+	r := setupMethodRouter(&stubMethods{setupFn: func(_ context.Context, _, _ string) (*payment.SetupIntent, error) {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "paypal can't save payment methods")
+	}})
+	require.Equal(t, http.StatusBadRequest, serveAs(r, "u1", http.MethodPost, "/me/payment-methods/setup", `{`).Code)
+	require.Equal(t, http.StatusBadRequest, serveAs(r, "u1", http.MethodPost, "/me/payment-methods/setup", `{"provider":"paypal"}`).Code)
+}
+
+func TestSavePaymentMethod(t *testing.T) {
+	svc := &stubMethods{saveFn: func(_ context.Context, userID string, req *domain.SavePaymentMethodReq) (*model.PaymentMethod, error) {
+		require.Equal(t, "u1", userID)
+		require.Equal(t, "seti_1", req.SetupIntentID)
+		return &model.PaymentMethod{ID: "pm1", Brand: "visa", Last4: "4242"}, nil
+	}}
+	r := setupMethodRouter(svc)
+	require.Equal(t, http.StatusOK, serveAs(r, "u1", http.MethodPost, "/me/payment-methods", `{"setup_intent_id":"seti_1"}`).Code)
+	require.Equal(t, http.StatusBadRequest, serveAs(r, "u1", http.MethodPost, "/me/payment-methods", `{}`).Code)
+
+	svc.saveFn = func(_ context.Context, _ string, _ *domain.SavePaymentMethodReq) (*model.PaymentMethod, error) {
+		return nil, apperror.WrapMessage(apperror.ErrInvalidStatus, nil, "setup intent is requires_payment_method")
+	}
+	require.Equal(t, http.StatusUnprocessableEntity, serveAs(r, "u1", http.MethodPost, "/me/payment-methods", `{"setup_intent_id":"seti_1"}`).Code)
+}
+
+func TestDeletePaymentMethod(t *testing.T) {
+	svc := &stubMethods{deleteFn: func(_ context.Context, id, userID string) error {
+		require.Equal(t, "pm1", id)
+		require.Equal(t, "u1", userID)
+		return nil
+	}}
+	r := setupMethodRouter(svc)
+	require.Equal(t, http.StatusOK, serveAs(r, "u1", http.MethodDelete, "/me/payment-methods/pm1", "").Code)
+
+	svc.deleteFn = func(_ context.Context, _, _ string) error {
+		return apperror.WrapMessage(apperror.ErrNotFound, errors.New("record not found"), "payment method not found")
+	}
+	require.Equal(t, http.StatusNotFound, serveAs(r, "u1", http.MethodDelete, "/me/payment-methods/pm1", "").Code)
+}
@@ -6,34 +6,37 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/quangdangfit/gocommon/validation"
 
-	notificationRepo "goshop/internal/notification/repository"
-	notificationSvc "goshop/internal/notification/service"
+	inventoryRepository "goshop/internal/inventory/repository"
 	orderRepository "goshop/internal/order/repository"
 	orderService "goshop/internal/order/service"
+	outboxRepository "goshop/internal/outbox/repository"
 	"goshop/internal/payment/repository"
 	"goshop/internal/payment/service"
-	userRepo "goshop/internal/user/repository"
 	"goshop/pkg/config"
+	"goshop/pkg/currency"
 	"goshop/pkg/dbs"
 	"goshop/pkg/middleware"
-	"goshop/pkg/notification"
 	"goshop/pkg/payment"
-	stripeProvider "goshop/pkg/payment/stripe"
 	"goshop/pkg/response"
+	"goshop/pkg/shipping"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
 )
 
-// Routes wires the payment domain. Uses the live config to construct a Stripe provider; the
-// webhook route deliberately sits outside the JWT middleware (Stripe authenticates via the
-// signature header instead).
+// Routes wires the payment domain. Uses the live config to register the payment providers;
+// the webhook routes deliberately sit outside the JWT middleware (providers authenticate
+// their deliveries instead). Saved payment methods belong to the signed-in user. Refunds,
+// offline payment confirmation, cash-on-delivery collection and the webhook inbox are
+// admin-only.
 func Routes(r *gin.RouterGroup, db dbs.Database, validator validation.Validation) {
 	cfg := config.GetConfig()
-	provider := stripeProvider.NewProvider(stripeProvider.Config{
-		SecretKey:     cfg.StripeSecretKey,
-		WebhookSecret: cfg.StripeWebhookSecret,
-		APIBase:       cfg.StripeAPIBase,
-	})
+	providers := service.NewProviders(cfg)
 
+	rates := currency.MustParseRates(cfg.BaseCurrency, cfg.ExchangeRates)
+	shippingSvc := orderService.NewShippingService(validator, orderRepository.NewShippingRateRepository(db), rates,
+		shipping.MustParseMethods(cfg.BaseCurrency, cfg.ShippingMethods))
 	paymentRepo := repository.NewPaymentRepository(db)
+	invoices := orderService.NewInvoices(cfg, db)
 
 	// Build a minimal OrderService for MarkOrderPaid / UpdateOrderStatus on webhook events.
 	orderSvc := orderService.NewOrderService(
@@ -42,40 +45,83 @@ func Routes(r *gin.RouterGroup, db dbs.Database, validator validation.Validation
 		orderRepository.NewProductRepository(db),
 		orderRepository.NewUserRepository(db),
 		orderRepository.NewReservationRepository(db),
-		orderService.NewCouponService(validator, orderRepository.NewCouponRepository(db)),
-		notification.BuildDefault(notification.Settings{
-			SMTPHost:     cfg.SMTPHost,
-			SMTPPort:     cfg.SMTPPort,
-			SMTPUser:     cfg.SMTPUser,
-			SMTPPassword: cfg.SMTPPassword,
-			EmailFrom:    cfg.EmailFrom,
-			Prefs: notificationSvc.NewDBPreferenceChecker(
-				notificationSvc.NewUserRepoLookup(userRepo.NewUserRepository(db)),
-				notificationRepo.NewPreferenceRepository(db),
-			),
-			DLQ: notificationRepo.NewDeadLetterSink(db),
-		}),
+		orderService.NewCouponService(validator, orderRepository.NewCouponRepository(db), rates),
+		outboxRepository.NewOutboxRepository(db),
+		inventoryRepository.NewLedgerRepository(db),
+		orderRepository.NewWarehouseRepository(db),
+		stock.AllocationStrategy(cfg.WarehouseAllocation),
+		rates,
+		shippingSvc,
+		orderService.NewTaxService(validator, orderRepository.NewTaxRateRepository(db), tax.Mode(cfg.TaxMode)),
+		invoices,
+		service.NewPaymentSettler(providers, paymentRepo),
 	)
 
-	paymentSvc := service.NewPaymentService(provider, paymentRepo, orderSvc, orderSvc)
-	handler := NewHandler(paymentSvc)
+	methodRepo := repository.NewPaymentMethodRepository(db)
+	paymentSvc := service.NewPaymentService(db, providers, paymentRepo, repository.NewRefundRepository(db), methodRepo,
+		orderSvc, orderSvc, invoices, payment.CaptureMode(cfg.PaymentCapture))
+	handler := NewHandler(paymentSvc, providers)
+	methodHandler := NewMethodHandler(
+		service.NewPaymentMethodService(providers, methodRepo, orderRepository.NewUserRepository(db)),
+		providers,
+	)
 
 	authMiddleware := middleware.JWTAuth()
 
 	// /orders/:id/payment-intent — authenticated, used by the customer to start checkout.
 	r.POST("/orders/:id/payment-intent", authMiddleware, handler.CreatePaymentIntent)
+	// /orders/:id/payment/retry — authenticated; a new attempt after the payment failed.
+	r.POST("/orders/:id/payment/retry", authMiddleware, handler.RetryPayment)
+
+	// /me/payment-methods — authenticated; cards the buyer saved for later checkouts.
+	methodsRoute := r.Group("/me/payment-methods", authMiddleware)
+	{
+		methodsRoute.GET("", methodHandler.ListPaymentMethods)
+		methodsRoute.POST("", methodHandler.SavePaymentMethod)
+		methodsRoute.POST("/setup", methodHandler.StartSetup)
+		methodsRoute.DELETE("/:id", methodHandler.DeletePaymentMethod)
+	}
+
+	// /admin/orders/:id/refunds — admin only; refunds go back through the provider.
+	// /admin/orders/:id/payment/confirm — admin only; marks a bank transfer as received.
+	// /admin/orders/:id/payment/collect — admin only; the courier delivered a cash-on-delivery order.
+	adminRoute := r.Group("/admin/orders", authMiddleware, middleware.AdminOnly())
+	{
+		adminRoute.POST("/:id/refunds", handler.RefundOrder)
+		adminRoute.GET("/:id/refunds", handler.ListRefunds)
+		adminRoute.POST("/:id/payment/confirm", handler.ConfirmPayment)
+		adminRoute.POST("/:id/payment/collect", handler.CollectCashOnDelivery)
+	}
 
-	// /webhooks/stripe — public, signature-verified.
-	r.POST("/webhooks/stripe", handler.StripeWebhook)
+	// /admin/webhook-events — admin only; inspect and replay webhook events that failed to
+	// apply. The inbox worker retrying them runs from main.go.
+	eventsRoute := r.Group("/admin/webhook-events", authMiddleware, middleware.AdminOnly())
+	{
+		eventsRoute.GET("", handler.ListFailedEvents)
+		eventsRoute.POST("/:provider/:event_id/replay", handler.ReplayEvent)
+	}
 
-	// /config/public — exposes the Stripe publishable key to the FE.
+	// /admin/payments/reconcile-runs — admin only; what each reconciliation pass found and
+	// changed. The reconciler runs from main.go.
+	reconcileRoute := r.Group("/admin/payments/reconcile-runs", authMiddleware, middleware.AdminOnly())
+	{
+		reconcileRoute.GET("", handler.ListReconcileRuns)
+		reconcileRoute.GET("/:id", handler.GetReconcileRun)
+	}
+
+	// /webhooks/:provider — public, verified by the named provider.
+	r.POST("/webhooks/:provider", handler.Webhook)
+
+	// /config/public — exposes the enabled providers, their public keys and the currencies
+	// orders can be placed in to the FE.
 	r.GET("/config/public", func(c *gin.Context) {
 		response.JSON(c, http.StatusOK, gin.H{
-			"stripe_publishable_key": cfg.StripePublishableKey,
+			"base_currency":            rates.Base(),
+			"currencies":               rates.Currencies(),
+			"payment_providers":        providers.Names(),
+			"default_payment_provider": providers.DefaultName(),
+			"stripe_publishable_key":   cfg.StripePublishableKey,
+			"paypal_client_id":         cfg.PayPalClientID,
 		})
 	})
-
-	// Touch payment to silence the unused import lint when the package is imported but no
-	// type from it is referenced directly. (Used transitively via the provider.)
-	_ = payment.ErrInvalidSignature
 }
@@ -24,6 +24,15 @@ func TestRoutesRegistersEndpoints(t *testing.T) {
 		paths[ri.Method+" "+ri.Path] = true
 	}
 	require.True(t, paths["POST /api/v1/orders/:id/payment-intent"])
-	require.True(t, paths["POST /api/v1/webhooks/stripe"])
+	require.True(t, paths["POST /api/v1/webhooks/:provider"])
+	require.True(t, paths["POST /api/v1/admin/orders/:id/payment/confirm"])
 	require.True(t, paths["GET /api/v1/config/public"])
+	require.True(t, paths["POST /api/v1/admin/orders/:id/refunds"])
+	require.True(t, paths["GET /api/v1/admin/orders/:id/refunds"])
+	require.True(t, paths["GET /api/v1/admin/webhook-events"])
+	require.True(t, paths["POST /api/v1/admin/webhook-events/:provider/:event_id/replay"])
+	require.True(t, paths["GET /api/v1/me/payment-methods"])
+	require.True(t, paths["POST /api/v1/me/payment-methods"])
+	require.True(t, paths["POST /api/v1/me/payment-methods/setup"])
+	require.True(t, paths["DELETE /api/v1/me/payment-methods/:id"])
 }
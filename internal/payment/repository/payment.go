@@ -3,23 +3,68 @@ package repository
 import (
 	"context"
 	"errors"
+	"sort"
+	"time"
 
 	"gorm.io/gorm"
 
+	"goshop/internal/payment/domain"
 	"goshop/internal/payment/model"
 	"goshop/pkg/dbs"
+	"goshop/pkg/paging"
 )
 
 var ErrEventAlreadyProcessed = errors.New("provider event already processed")
 
+// ErrRefundExceedsPayment is returned by AddRefunded when the change would take the payment's
+// amount_refunded below zero or above its amount.
+var ErrRefundExceedsPayment = errors.New("refund exceeds the amount left on the payment")
+
+// refundedStatus recomputes payments.status from the new amount_refunded; the two arguments
+// are the new amount_refunded.
+const refundedStatus = `CASE WHEN ? >= amount THEN 'refunded' WHEN ? > 0 THEN 'partially_refunded' ELSE 'succeeded' END`
+
 //go:generate mockery --name=PaymentRepository
 type PaymentRepository interface {
+	// GetByOrderID returns the order's latest payment attempt.
 	GetByOrderID(ctx context.Context, orderID string) (*model.Payment, error)
+	GetByProviderIntentID(ctx context.Context, intentID string) (*model.Payment, error)
 	Create(ctx context.Context, p *model.Payment) error
 	Update(ctx context.Context, p *model.Payment) error
-	// RecordProviderEvent inserts a (provider, event_id) row and returns ErrEventAlreadyProcessed
-	// if the event has been seen before. Used to make webhook handling idempotent.
-	RecordProviderEvent(ctx context.Context, provider, eventID string) error
+	// RecordProviderEvent inserts the event into the webhook inbox and returns
+	// ErrEventAlreadyProcessed if it has been seen before. Used to make webhook handling
+	// idempotent.
+	RecordProviderEvent(ctx context.Context, event *model.ProviderEvent) error
+	GetProviderEvent(ctx context.Context, provider, eventID string) (*model.ProviderEvent, error)
+	UpdateProviderEvent(ctx context.Context, event *model.ProviderEvent) error
+	// ClaimDueEvents leases up to limit due pending inbox events, oldest first, by marking
+	// them processing with their next_attempt_at pushed out by lease, like the outbox relay's
+	// ClaimDue. Processing events whose lease ran out are due again.
+	ClaimDueEvents(ctx context.Context, limit int, lease time.Duration) ([]*model.ProviderEvent, error)
+	// ClaimEvent leases one unprocessed inbox event the same way, whether it is pending or
+	// failed, and returns gorm.ErrRecordNotFound if it is processed or already leased.
+	ClaimEvent(ctx context.Context, provider, eventID string, lease time.Duration) (*model.ProviderEvent, error)
+	// ListFailedEvents returns inbox events that failed for good or are pending after a failed
+	// attempt, oldest first.
+	ListFailedEvents(ctx context.Context, req *domain.ListFailedEventsReq) ([]*model.ProviderEvent, *paging.Pagination, error)
+	// AddRefunded atomically moves amount_refunded by delta (negative to give back a failed
+	// refund) and sets the refunded / partially_refunded status to match. Returns
+	// ErrRefundExceedsPayment, without writing, if the result would leave [0, amount].
+	AddRefunded(ctx context.Context, paymentID string, delta int64) error
+	// SyncRefunded raises amount_refunded to the provider's total, covering refunds issued
+	// outside the shop, and returns how much it raised it by. It never lowers it, since the
+	// local figure includes refunds in flight. It locks the payment row, so call it in a
+	// transaction.
+	SyncRefunded(ctx context.Context, paymentID string, total int64) (int64, error)
+	// ListUnsettled returns up to limit payments still waiting on the provider (pending,
+	// processing or requires_action) that haven't changed since before, oldest first.
+	ListUnsettled(ctx context.Context, before time.Time, limit int) ([]*model.Payment, error)
+	// CreateReconcileRun inserts a reconciliation run together with its entries.
+	CreateReconcileRun(ctx context.Context, run *model.ReconcileRun) error
+	// ListReconcileRuns returns reconciliation runs, newest first, without their entries.
+	ListReconcileRuns(ctx context.Context, req *domain.ListReconcileRunsReq) ([]*model.ReconcileRun, *paging.Pagination, error)
+	// GetReconcileRun returns a reconciliation run with its entries.
+	GetReconcileRun(ctx context.Context, id string) (*model.ReconcileRun, error)
 }
 
 type paymentRepo struct {
@@ -32,7 +77,16 @@ func NewPaymentRepository(db dbs.Database) PaymentRepository {
 
 func (r *paymentRepo) GetByOrderID(ctx context.Context, orderID string) (*model.Payment, error) {
 	var p model.Payment
-	err := r.db.GetDB().WithContext(ctx).Where("order_id = ?", orderID).First(&p).Error
+	err := r.db.GetDB().WithContext(ctx).Where("order_id = ?", orderID).Order("attempt DESC").First(&p).Error
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+func (r *paymentRepo) GetByProviderIntentID(ctx context.Context, intentID string) (*model.Payment, error) {
+	var p model.Payment
+	err := r.db.GetDB().WithContext(ctx).Where("provider_intent_id = ?", intentID).First(&p).Error
 	if err != nil {
 		return nil, err
 	}
@@ -47,8 +101,8 @@ func (r *paymentRepo) Update(ctx context.Context, p *model.Payment) error {
 	return r.db.Update(ctx, p)
 }
 
-func (r *paymentRepo) RecordProviderEvent(ctx context.Context, provider, eventID string) error {
-	err := r.db.GetDB().WithContext(ctx).Create(&model.ProviderEvent{Provider: provider, EventID: eventID}).Error
+func (r *paymentRepo) RecordProviderEvent(ctx context.Context, event *model.ProviderEvent) error {
+	err := r.db.GetDB().WithContext(ctx).Create(event).Error
 	if err == nil {
 		return nil
 	}
@@ -59,6 +113,156 @@ func (r *paymentRepo) RecordProviderEvent(ctx context.Context, provider, eventID
 	return err
 }
 
+func (r *paymentRepo) GetProviderEvent(ctx context.Context, provider, eventID string) (*model.ProviderEvent, error) {
+	var event model.ProviderEvent
+	err := r.db.GetDB().WithContext(ctx).Where("provider = ? AND event_id = ?", provider, eventID).First(&event).Error
+	if err != nil {
+		return nil, err
+	}
+	return &event, nil
+}
+
+func (r *paymentRepo) UpdateProviderEvent(ctx context.Context, event *model.ProviderEvent) error {
+	return r.db.Update(ctx, event)
+}
+
+const claimDueEventsQuery = `
+UPDATE provider_events SET status = ?, next_attempt_at = ?, updated_at = ?
+WHERE (provider, event_id) IN (
+	SELECT provider, event_id FROM provider_events
+	WHERE status IN ? AND next_attempt_at <= ?
+	ORDER BY created_at
+	LIMIT ?
+	FOR UPDATE SKIP LOCKED
+)
+RETURNING *`
+
+func (r *paymentRepo) ClaimDueEvents(ctx context.Context, limit int, lease time.Duration) ([]*model.ProviderEvent, error) {
+	now := time.Now()
+	var events []*model.ProviderEvent
+	err := r.db.GetDB().WithContext(ctx).
+		Raw(claimDueEventsQuery, model.ProviderEventStatusProcessing, now.Add(lease), now,
+			[]model.ProviderEventStatus{model.ProviderEventStatusPending, model.ProviderEventStatusProcessing}, now, limit).
+		Scan(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	// RETURNING doesn't preserve the subquery's order.
+	sort.Slice(events, func(i, j int) bool { return events[i].CreatedAt.Before(events[j].CreatedAt) })
+	return events, nil
+}
+
+// claimEventQuery leases the event unless it's processed or another lease on it is still
+// running. The row lock the UPDATE takes makes concurrent claims of one event see each other.
+const claimEventQuery = `
+UPDATE provider_events SET status = ?, next_attempt_at = ?, updated_at = ?
+WHERE provider = ? AND event_id = ?
+	AND (status IN ? OR (status = ? AND next_attempt_at <= ?))
+RETURNING *`
+
+func (r *paymentRepo) ClaimEvent(ctx context.Context, provider, eventID string, lease time.Duration) (*model.ProviderEvent, error) {
+	now := time.Now()
+	var events []*model.ProviderEvent
+	err := r.db.GetDB().WithContext(ctx).
+		Raw(claimEventQuery, model.ProviderEventStatusProcessing, now.Add(lease), now, provider, eventID,
+			[]model.ProviderEventStatus{model.ProviderEventStatusPending, model.ProviderEventStatusFailed},
+			model.ProviderEventStatusProcessing, now).
+		Scan(&events).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(events) == 0 {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return events[0], nil
+}
+
+func (r *paymentRepo) ListFailedEvents(ctx context.Context, req *domain.ListFailedEventsReq) ([]*model.ProviderEvent, *paging.Pagination, error) {
+	query := []dbs.Query{
+		dbs.NewQuery(
+			"(status = ? OR (status = ? AND attempts > 0))",
+			model.ProviderEventStatusFailed, model.ProviderEventStatusPending,
+		),
+	}
+	if req.Provider != "" {
+		query = append(query, dbs.NewQuery("provider = ?", req.Provider))
+	}
+
+	var total int64
+	if err := r.db.Count(ctx, &model.ProviderEvent{}, &total, dbs.WithQuery(query...)); err != nil {
+		return nil, nil, err
+	}
+
+	pagination := paging.New(req.Page, req.Limit, total)
+
+	var events []*model.ProviderEvent
+	if err := r.db.Find(
+		ctx,
+		&events,
+		dbs.WithQuery(query...),
+		dbs.WithLimit(int(pagination.Limit)),
+		dbs.WithOffset(int(pagination.Skip)),
+		dbs.WithOrder("created_at"),
+	); err != nil {
+		return nil, nil, err
+	}
+
+	return events, pagination, nil
+}
+
+func (r *paymentRepo) AddRefunded(ctx context.Context, paymentID string, delta int64) error {
+	newTotal := gorm.Expr("amount_refunded + ?", delta)
+	res := r.db.GetDB().WithContext(ctx).Model(&model.Payment{}).
+		Where("id = ? AND amount_refunded + ? BETWEEN 0 AND amount", paymentID, delta).
+		Updates(map[string]any{
+			"amount_refunded": newTotal,
+			"status":          gorm.Expr(refundedStatus, newTotal, newTotal),
+		})
+	if res.Error != nil {
+		return res.Error
+	}
+	if res.RowsAffected == 0 {
+		return ErrRefundExceedsPayment
+	}
+	return nil
+}
+
+func (r *paymentRepo) SyncRefunded(ctx context.Context, paymentID string, total int64) (int64, error) {
+	db := r.db.GetDB().WithContext(ctx)
+	var p model.Payment
+	err := db.Raw("SELECT amount, amount_refunded FROM payments WHERE id = ? FOR UPDATE", paymentID).Scan(&p).Error
+	if err != nil {
+		return 0, err
+	}
+	if total <= p.AmountRefunded || total > p.Amount {
+		return 0, nil
+	}
+	err = db.Model(&model.Payment{}).Where("id = ?", paymentID).
+		Updates(map[string]any{
+			"amount_refunded": total,
+			"status":          gorm.Expr(refundedStatus, total, total),
+		}).Error
+	if err != nil {
+		return 0, err
+	}
+	return total - p.AmountRefunded, nil
+}
+
+func (r *paymentRepo) ListUnsettled(ctx context.Context, before time.Time, limit int) ([]*model.Payment, error) {
+	var rows []*model.Payment
+	err := r.db.GetDB().WithContext(ctx).
+		Where("status IN ? AND updated_at < ?", []model.PaymentStatus{
+			model.PaymentStatusPending, model.PaymentStatusProcessing, model.PaymentStatusRequiresAction,
+		}, before).
+		Order("updated_at").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
 func isDuplicateKey(err error) bool {
 	if err == nil {
 		return false
@@ -86,3 +290,38 @@ func indexOf(s, sub string) int {
 	}
 	return -1
 }
+
+func (r *paymentRepo) CreateReconcileRun(ctx context.Context, run *model.ReconcileRun) error {
+	return r.db.Create(ctx, run)
+}
+
+func (r *paymentRepo) ListReconcileRuns(ctx context.Context, req *domain.ListReconcileRunsReq) ([]*model.ReconcileRun, *paging.Pagination, error) {
+	var total int64
+	if err := r.db.Count(ctx, &model.ReconcileRun{}, &total); err != nil {
+		return nil, nil, err
+	}
+
+	pagination := paging.New(req.Page, req.Limit, total)
+
+	var runs []*model.ReconcileRun
+	if err := r.db.Find(
+		ctx,
+		&runs,
+		dbs.WithLimit(int(pagination.Limit)),
+		dbs.WithOffset(int(pagination.Skip)),
+		dbs.WithOrder("created_at DESC"),
+	); err != nil {
+		return nil, nil, err
+	}
+
+	return runs, pagination, nil
+}
+
+func (r *paymentRepo) GetReconcileRun(ctx context.Context, id string) (*model.ReconcileRun, error) {
+	var run model.ReconcileRun
+	err := r.db.FindOne(ctx, &run, dbs.WithQuery(dbs.NewQuery("id = ?", id)), dbs.WithPreload([]string{"Entries"}))
+	if err != nil {
+		return nil, err
+	}
+	return &run, nil
+}
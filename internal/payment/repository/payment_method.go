@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"goshop/internal/payment/model"
+	"goshop/pkg/dbs"
+)
+
+//go:generate mockery --name=PaymentMethodRepository
+type PaymentMethodRepository interface {
+	GetCustomer(ctx context.Context, userID, provider string) (*model.PaymentCustomer, error)
+	// CreateCustomer stores the user's customer at the provider, keeping the existing row if
+	// another request stored one first.
+	CreateCustomer(ctx context.Context, customer *model.PaymentCustomer) error
+	ListByUser(ctx context.Context, userID string) ([]*model.PaymentMethod, error)
+	GetByID(ctx context.Context, id, userID string) (*model.PaymentMethod, error)
+	GetByProviderMethodID(ctx context.Context, provider, providerMethodID string) (*model.PaymentMethod, error)
+	Create(ctx context.Context, method *model.PaymentMethod) error
+	Delete(ctx context.Context, id, userID string) error
+}
+
+type paymentMethodRepo struct {
+	db dbs.Database
+}
+
+func NewPaymentMethodRepository(db dbs.Database) PaymentMethodRepository {
+	return &paymentMethodRepo{db: db}
+}
+
+func (r *paymentMethodRepo) GetCustomer(ctx context.Context, userID, provider string) (*model.PaymentCustomer, error) {
+	var customer model.PaymentCustomer
+	if err := r.db.FindOne(ctx, &customer,
+		dbs.WithQuery(dbs.NewQuery("user_id = ? AND provider = ?", userID, provider)),
+	); err != nil {
+		return nil, err
+	}
+	return &customer, nil
+}
+
+func (r *paymentMethodRepo) CreateCustomer(ctx context.Context, customer *model.PaymentCustomer) error {
+	return r.db.GetDB().WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(customer).Error
+}
+
+func (r *paymentMethodRepo) ListByUser(ctx context.Context, userID string) ([]*model.PaymentMethod, error) {
+	var methods []*model.PaymentMethod
+	if err := r.db.Find(ctx, &methods,
+		dbs.WithQuery(dbs.NewQuery("user_id = ?", userID)),
+		dbs.WithOrder("created_at DESC"),
+	); err != nil {
+		return nil, err
+	}
+	return methods, nil
+}
+
+func (r *paymentMethodRepo) GetByID(ctx context.Context, id, userID string) (*model.PaymentMethod, error) {
+	return r.findOne(ctx, dbs.NewQuery("id = ? AND user_id = ?", id, userID))
+}
+
+func (r *paymentMethodRepo) GetByProviderMethodID(ctx context.Context, provider, providerMethodID string) (*model.PaymentMethod, error) {
+	return r.findOne(ctx, dbs.NewQuery("provider = ? AND provider_method_id = ?", provider, providerMethodID))
+}
+
+func (r *paymentMethodRepo) findOne(ctx context.Context, query dbs.Query) (*model.PaymentMethod, error) {
+	var method model.PaymentMethod
+	if err := r.db.FindOne(ctx, &method, dbs.WithQuery(query)); err != nil {
+		return nil, err
+	}
+	return &method, nil
+}
+
+func (r *paymentMethodRepo) Create(ctx context.Context, method *model.PaymentMethod) error {
+	return r.db.Create(ctx, method)
+}
+
+func (r *paymentMethodRepo) Delete(ctx context.Context, id, userID string) error {
+	return r.db.Delete(ctx, &model.PaymentMethod{}, dbs.WithQuery(dbs.NewQuery("id = ? AND user_id = ?", id, userID)))
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/payment/model"
+	dbsMocks "goshop/pkg/dbs/mocks"
+)
+
+func TestPaymentMethodRepo_GetCustomer(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.PaymentCustomer{}, mock.Anything).Return(nil).Once()
+	customer, err := NewPaymentMethodRepository(dbm).GetCustomer(context.Background(), "u1", "stripe")
+	require.NoError(t, err)
+	require.NotNil(t, customer)
+
+	dbm = dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, &model.PaymentCustomer{}, mock.Anything).Return(gorm.ErrRecordNotFound).Once()
+	_, err = NewPaymentMethodRepository(dbm).GetCustomer(context.Background(), "u1", "stripe")
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestPaymentMethodRepo_CreateCustomerKeepsExistingRow(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	m.ExpectExec(regexp.QuoteMeta(`INSERT INTO "payment_customers"`) + `.*ON CONFLICT DO NOTHING`).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := NewPaymentMethodRepository(dbm).CreateCustomer(context.Background(), &model.PaymentCustomer{
+		UserID: "u1", Provider: "stripe", ProviderCustomerID: "cus_1",
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPaymentMethodRepo_ListByUser(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Find", mock.Anything, mock.AnythingOfType("*[]*model.PaymentMethod"), mock.Anything, mock.Anything).
+		Return(nil).Once()
+	_, err := NewPaymentMethodRepository(dbm).ListByUser(context.Background(), "u1")
+	require.NoError(t, err)
+
+	dbm = dbsMocks.NewDatabase(t)
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db")).Once()
+	_, err = NewPaymentMethodRepository(dbm).ListByUser(context.Background(), "u1")
+	require.Error(t, err)
+}
+
+func TestPaymentMethodRepo_GetBy(t *testing.T) {
+	tests := []struct {
+		name string
+		get  func(r PaymentMethodRepository) (*model.PaymentMethod, error)
+	}{
+		{"id", func(r PaymentMethodRepository) (*model.PaymentMethod, error) {
+			return r.GetByID(context.Background(), "pm_row", "u1")
+		}},
+		{"provider_method_id", func(r PaymentMethodRepository) (*model.PaymentMethod, error) {
+			return r.GetByProviderMethodID(context.Background(), "stripe", "pm_1")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbm := dbsMocks.NewDatabase(t)
+			dbm.On("FindOne", mock.Anything, &model.PaymentMethod{}, mock.Anything).Return(nil).Once()
+			method, err := tt.get(NewPaymentMethodRepository(dbm))
+			require.NoError(t, err)
+			require.NotNil(t, method)
+
+			dbm = dbsMocks.NewDatabase(t)
+			dbm.On("FindOne", mock.Anything, &model.PaymentMethod{}, mock.Anything).Return(gorm.ErrRecordNotFound).Once()
+			method, err = tt.get(NewPaymentMethodRepository(dbm))
+			require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+			require.Nil(t, method)
+		})
+	}
+}
+
+func TestPaymentMethodRepo_CreateAndDelete(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	method := &model.PaymentMethod{UserID: "u1"}
+	dbm.On("Create", mock.Anything, method).Return(nil).Once()
+	dbm.On("Delete", mock.Anything, &model.PaymentMethod{}, mock.Anything).Return(nil).Once()
+
+	repo := NewPaymentMethodRepository(dbm)
+	require.NoError(t, repo.Create(context.Background(), method))
+	require.NoError(t, repo.Delete(context.Background(), "pm_row", "u1"))
+}
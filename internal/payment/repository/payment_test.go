@@ -5,6 +5,7 @@ import (
 	"errors"
 	"regexp"
 	"testing"
+	"time"
 
 	sqlmock "github.com/DATA-DOG/go-sqlmock"
 	"github.com/stretchr/testify/mock"
@@ -12,6 +13,7 @@ import (
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 
+	"goshop/internal/payment/domain"
 	"goshop/internal/payment/model"
 	dbsMocks "goshop/pkg/dbs/mocks"
 )
@@ -34,7 +36,7 @@ func TestPaymentRepo_GetByOrderID_Found(t *testing.T) {
 	dbm.On("GetDB").Return(g)
 
 	rows := sqlmock.NewRows([]string{"id", "order_id"}).AddRow("p1", "o1")
-	m.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "payments" WHERE order_id = $1`)).
+	m.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "payments" WHERE order_id = $1 ORDER BY attempt DESC`)).
 		WithArgs("o1", 1).WillReturnRows(rows)
 
 	p, err := NewPaymentRepository(dbm).GetByOrderID(context.Background(), "o1")
@@ -71,7 +73,7 @@ func TestPaymentRepo_RecordProviderEvent_Success(t *testing.T) {
 
 	m.ExpectExec(`INSERT INTO "provider_events"`).WillReturnResult(sqlmock.NewResult(1, 1))
 
-	err := NewPaymentRepository(dbm).RecordProviderEvent(context.Background(), "stripe", "evt_1")
+	err := NewPaymentRepository(dbm).RecordProviderEvent(context.Background(), &model.ProviderEvent{Provider: "stripe", EventID: "evt_1"})
 	require.NoError(t, err)
 }
 
@@ -83,7 +85,7 @@ func TestPaymentRepo_RecordProviderEvent_DuplicateBecomesSentinel(t *testing.T)
 	m.ExpectExec(`INSERT INTO "provider_events"`).
 		WillReturnError(errors.New(`pq: duplicate key value violates unique constraint`))
 
-	err := NewPaymentRepository(dbm).RecordProviderEvent(context.Background(), "stripe", "evt_1")
+	err := NewPaymentRepository(dbm).RecordProviderEvent(context.Background(), &model.ProviderEvent{Provider: "stripe", EventID: "evt_1"})
 	require.ErrorIs(t, err, ErrEventAlreadyProcessed)
 }
 
@@ -93,7 +95,7 @@ func TestPaymentRepo_RecordProviderEvent_GormDuplicatedKeyBecomesSentinel(t *tes
 	dbm.On("GetDB").Return(g)
 	m.ExpectExec(`INSERT INTO "provider_events"`).WillReturnError(gorm.ErrDuplicatedKey)
 
-	err := NewPaymentRepository(dbm).RecordProviderEvent(context.Background(), "stripe", "evt_1")
+	err := NewPaymentRepository(dbm).RecordProviderEvent(context.Background(), &model.ProviderEvent{Provider: "stripe", EventID: "evt_1"})
 	require.ErrorIs(t, err, ErrEventAlreadyProcessed)
 }
 
@@ -103,11 +105,182 @@ func TestPaymentRepo_RecordProviderEvent_OtherErrorPropagates(t *testing.T) {
 	dbm.On("GetDB").Return(g)
 	m.ExpectExec(`INSERT INTO "provider_events"`).WillReturnError(errors.New("connection lost"))
 
-	err := NewPaymentRepository(dbm).RecordProviderEvent(context.Background(), "stripe", "evt_1")
+	err := NewPaymentRepository(dbm).RecordProviderEvent(context.Background(), &model.ProviderEvent{Provider: "stripe", EventID: "evt_1"})
 	require.Error(t, err)
 	require.NotErrorIs(t, err, ErrEventAlreadyProcessed)
 }
 
+func TestPaymentRepo_GetProviderEvent(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	rows := sqlmock.NewRows([]string{"provider", "event_id", "status"}).AddRow("stripe", "evt_1", "failed")
+	m.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "provider_events" WHERE provider = $1 AND event_id = $2`)).
+		WithArgs("stripe", "evt_1", 1).WillReturnRows(rows)
+
+	event, err := NewPaymentRepository(dbm).GetProviderEvent(context.Background(), "stripe", "evt_1")
+	require.NoError(t, err)
+	require.Equal(t, model.ProviderEventStatusFailed, event.Status)
+}
+
+func TestPaymentRepo_GetProviderEvent_NotFound(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	m.ExpectQuery(`SELECT \* FROM "provider_events"`).WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := NewPaymentRepository(dbm).GetProviderEvent(context.Background(), "stripe", "evt_1")
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestPaymentRepo_UpdateProviderEvent(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+	require.NoError(t, NewPaymentRepository(dbm).UpdateProviderEvent(context.Background(), &model.ProviderEvent{}))
+}
+
+func TestPaymentRepo_ClaimDueEvents(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	older := time.Now().Add(-time.Minute)
+	newer := time.Now()
+	rows := sqlmock.NewRows([]string{"provider", "event_id", "created_at", "payload", "status", "attempts"}).
+		AddRow("stripe", "evt_2", newer, "{}", "pending", 0).
+		AddRow("paypal", "evt_1", older, "{}", "pending", 2)
+	m.ExpectQuery(regexp.QuoteMeta(`UPDATE provider_events SET status = $1, next_attempt_at = $2, updated_at = $3`)).
+		WithArgs(model.ProviderEventStatusProcessing, sqlmock.AnyArg(), sqlmock.AnyArg(),
+			model.ProviderEventStatusPending, model.ProviderEventStatusProcessing, sqlmock.AnyArg(), 10).
+		WillReturnRows(rows)
+
+	got, err := NewPaymentRepository(dbm).ClaimDueEvents(context.Background(), 10, time.Minute)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	require.Equal(t, "evt_1", got[0].EventID)
+	require.Equal(t, "evt_2", got[1].EventID)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPaymentRepo_ClaimDueEvents_Error(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	m.ExpectQuery(regexp.QuoteMeta(`UPDATE provider_events`)).WillReturnError(errors.New("boom"))
+
+	got, err := NewPaymentRepository(dbm).ClaimDueEvents(context.Background(), 10, time.Minute)
+	require.Error(t, err)
+	require.Nil(t, got)
+}
+
+func TestPaymentRepo_ClaimEvent(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	rows := sqlmock.NewRows([]string{"provider", "event_id", "status", "attempts"}).AddRow("stripe", "evt_1", "processing", 10)
+	m.ExpectQuery(regexp.QuoteMeta(`UPDATE provider_events SET status = $1, next_attempt_at = $2, updated_at = $3`)).
+		WithArgs(model.ProviderEventStatusProcessing, sqlmock.AnyArg(), sqlmock.AnyArg(), "stripe", "evt_1",
+			model.ProviderEventStatusPending, model.ProviderEventStatusFailed, model.ProviderEventStatusProcessing, sqlmock.AnyArg()).
+		WillReturnRows(rows)
+
+	got, err := NewPaymentRepository(dbm).ClaimEvent(context.Background(), "stripe", "evt_1", time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, model.ProviderEventStatusProcessing, got.Status)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPaymentRepo_ClaimEvent_NotClaimable(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	m.ExpectQuery(regexp.QuoteMeta(`UPDATE provider_events`)).WillReturnRows(sqlmock.NewRows([]string{"provider", "event_id"}))
+
+	got, err := NewPaymentRepository(dbm).ClaimEvent(context.Background(), "stripe", "evt_1", time.Minute)
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+	require.Nil(t, got)
+}
+
+func TestPaymentRepo_ListFailedEvents(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Count", mock.Anything, &model.ProviderEvent{}, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(2).(*int64) = 3 }).
+		Return(nil).Once()
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	_, pagination, err := NewPaymentRepository(dbm).ListFailedEvents(context.Background(), &domain.ListFailedEventsReq{Provider: "stripe"})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), pagination.Total)
+}
+
+func TestPaymentRepo_ListFailedEvents_CountError(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Count", mock.Anything, &model.ProviderEvent{}, mock.Anything, mock.Anything).Return(errors.New("boom")).Once()
+
+	_, _, err := NewPaymentRepository(dbm).ListFailedEvents(context.Background(), &domain.ListFailedEventsReq{})
+	require.Error(t, err)
+}
+
+func TestPaymentRepo_ListFailedEvents_FindError(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Count", mock.Anything, &model.ProviderEvent{}, mock.Anything, mock.Anything).Return(nil).Once()
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(errors.New("boom")).Once()
+
+	_, _, err := NewPaymentRepository(dbm).ListFailedEvents(context.Background(), &domain.ListFailedEventsReq{})
+	require.Error(t, err)
+}
+
+func TestPaymentRepo_CreateReconcileRun(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	run := &model.ReconcileRun{Checked: 1, Entries: []*model.ReconcileRunEntry{{PaymentID: "p1"}}}
+	dbm.On("Create", mock.Anything, run).Return(nil).Once()
+	require.NoError(t, NewPaymentRepository(dbm).CreateReconcileRun(context.Background(), run))
+}
+
+func TestPaymentRepo_ListReconcileRuns(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Count", mock.Anything, &model.ReconcileRun{}, mock.Anything).
+		Run(func(args mock.Arguments) { *args.Get(2).(*int64) = 3 }).
+		Return(nil).Once()
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(nil).Once()
+
+	_, pagination, err := NewPaymentRepository(dbm).ListReconcileRuns(context.Background(), &domain.ListReconcileRunsReq{})
+	require.NoError(t, err)
+	require.Equal(t, int64(3), pagination.Total)
+}
+
+func TestPaymentRepo_ListReconcileRuns_CountError(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Count", mock.Anything, &model.ReconcileRun{}, mock.Anything).Return(errors.New("boom")).Once()
+
+	_, _, err := NewPaymentRepository(dbm).ListReconcileRuns(context.Background(), &domain.ListReconcileRunsReq{})
+	require.Error(t, err)
+}
+
+func TestPaymentRepo_GetReconcileRun(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			*args.Get(1).(*model.ReconcileRun) = model.ReconcileRun{ID: "run1", Checked: 2}
+		}).Return(nil).Once()
+
+	run, err := NewPaymentRepository(dbm).GetReconcileRun(context.Background(), "run1")
+	require.NoError(t, err)
+	require.Equal(t, 2, run.Checked)
+}
+
+func TestPaymentRepo_GetReconcileRun_NotFound(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("FindOne", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(gorm.ErrRecordNotFound).Once()
+
+	_, err := NewPaymentRepository(dbm).GetReconcileRun(context.Background(), "run1")
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
 func TestIsDuplicateKey_NilFalse(t *testing.T) {
 	require.False(t, isDuplicateKey(nil))
 }
@@ -123,3 +296,123 @@ func TestIndexOf_NotFound(t *testing.T) {
 func TestIndexOf_Found(t *testing.T) {
 	require.Equal(t, 1, indexOf("abc", "bc"))
 }
+
+func TestPaymentRepo_GetByProviderIntentID(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	rows := sqlmock.NewRows([]string{"id", "provider_intent_id"}).AddRow("p1", "pi_1")
+	m.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "payments" WHERE provider_intent_id = $1`)).
+		WithArgs("pi_1", 1).WillReturnRows(rows)
+
+	p, err := NewPaymentRepository(dbm).GetByProviderIntentID(context.Background(), "pi_1")
+	require.NoError(t, err)
+	require.Equal(t, "p1", p.ID)
+}
+
+func TestPaymentRepo_GetByProviderIntentID_Error(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	m.ExpectQuery(`SELECT \* FROM "payments"`).WillReturnError(gorm.ErrRecordNotFound)
+
+	_, err := NewPaymentRepository(dbm).GetByProviderIntentID(context.Background(), "pi_1")
+	require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+}
+
+func TestPaymentRepo_AddRefunded(t *testing.T) {
+	tests := []struct {
+		name    string
+		rows    int64
+		execErr error
+		wantErr error
+	}{
+		{name: "updated", rows: 1},
+		{name: "exceeds_payment", rows: 0, wantErr: ErrRefundExceedsPayment},
+		{name: "db_error", execErr: errors.New("boom")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, m := newSQLMockDB(t)
+			dbm := dbsMocks.NewDatabase(t)
+			dbm.On("GetDB").Return(g)
+
+			exec := m.ExpectExec(regexp.QuoteMeta(`UPDATE "payments" SET "amount_refunded"=amount_refunded + $1,`+
+				`"status"=CASE WHEN amount_refunded + $2 >= amount THEN 'refunded' WHEN amount_refunded + $3 > 0 `+
+				`THEN 'partially_refunded' ELSE 'succeeded' END,"updated_at"=$4 `+
+				`WHERE id = $5 AND amount_refunded + $6 BETWEEN 0 AND amount`)).
+				WithArgs(int64(500), int64(500), int64(500), sqlmock.AnyArg(), "p1", int64(500))
+			if tt.execErr != nil {
+				exec.WillReturnError(tt.execErr)
+			} else {
+				exec.WillReturnResult(sqlmock.NewResult(0, tt.rows))
+			}
+
+			err := NewPaymentRepository(dbm).AddRefunded(context.Background(), "p1", 500)
+			switch {
+			case tt.wantErr != nil:
+				require.ErrorIs(t, err, tt.wantErr)
+			case tt.execErr != nil:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+			}
+			require.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPaymentRepo_SyncRefunded(t *testing.T) {
+	tests := []struct {
+		name       string
+		refunded   int64
+		total      int64
+		wantRaised int64
+	}{
+		{"raises", 250, 400, 150},
+		{"already_counted", 400, 400, 0},
+		{"local_ahead", 500, 400, 0},
+		{"above_amount", 0, 1600, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g, m := newSQLMockDB(t)
+			dbm := dbsMocks.NewDatabase(t)
+			dbm.On("GetDB").Return(g)
+
+			m.ExpectQuery(regexp.QuoteMeta(`SELECT amount, amount_refunded FROM payments WHERE id = $1 FOR UPDATE`)).
+				WithArgs("p1").
+				WillReturnRows(sqlmock.NewRows([]string{"amount", "amount_refunded"}).AddRow(1500, tt.refunded))
+			if tt.wantRaised > 0 {
+				m.ExpectExec(regexp.QuoteMeta(`UPDATE "payments" SET "amount_refunded"=$1,"status"=CASE WHEN $2 >= amount`)).
+					WithArgs(tt.total, tt.total, tt.total, sqlmock.AnyArg(), "p1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			}
+
+			raised, err := NewPaymentRepository(dbm).SyncRefunded(context.Background(), "p1", tt.total)
+			require.NoError(t, err)
+			require.Equal(t, tt.wantRaised, raised)
+			require.NoError(t, m.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPaymentRepo_ListUnsettled(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	before := time.Unix(1700000000, 0)
+
+	rows := sqlmock.NewRows([]string{"id", "order_id", "status"}).AddRow("p1", "o1", "processing")
+	m.ExpectQuery(regexp.QuoteMeta(
+		`SELECT * FROM "payments" WHERE status IN ($1,$2,$3) AND updated_at < $4 ORDER BY updated_at LIMIT $5`)).
+		WithArgs("pending", "processing", "requires_action", before, 50).
+		WillReturnRows(rows)
+
+	got, err := NewPaymentRepository(dbm).ListUnsettled(context.Background(), before, 50)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	require.Equal(t, model.PaymentStatusProcessing, got[0].Status)
+	require.NoError(t, m.ExpectationsWereMet())
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"goshop/internal/payment/model"
+	"goshop/pkg/dbs"
+)
+
+//go:generate mockery --name=RefundRepository
+type RefundRepository interface {
+	// Create inserts the refund together with its lines.
+	Create(ctx context.Context, refund *model.Refund) error
+	// Update saves the refund's own columns; its lines are immutable once created.
+	Update(ctx context.Context, refund *model.Refund) error
+	GetByID(ctx context.Context, id string) (*model.Refund, error)
+	GetByIdempotencyKey(ctx context.Context, key string) (*model.Refund, error)
+	GetByProviderRefundID(ctx context.Context, providerRefundID string) (*model.Refund, error)
+	ListByOrderID(ctx context.Context, orderID string) ([]*model.Refund, error)
+	// RefundedQuantities returns the units already refunded per order line of an order,
+	// counting pending and succeeded refunds.
+	RefundedQuantities(ctx context.Context, orderID string) (map[string]uint, error)
+}
+
+type refundRepo struct {
+	db dbs.Database
+}
+
+func NewRefundRepository(db dbs.Database) RefundRepository {
+	return &refundRepo{db: db}
+}
+
+func (r *refundRepo) Create(ctx context.Context, refund *model.Refund) error {
+	return r.db.Create(ctx, refund)
+}
+
+func (r *refundRepo) Update(ctx context.Context, refund *model.Refund) error {
+	return r.db.GetDB().WithContext(ctx).Omit(clause.Associations).Save(refund).Error
+}
+
+func (r *refundRepo) GetByID(ctx context.Context, id string) (*model.Refund, error) {
+	return r.findOne(ctx, dbs.NewQuery("id = ?", id))
+}
+
+func (r *refundRepo) GetByIdempotencyKey(ctx context.Context, key string) (*model.Refund, error) {
+	return r.findOne(ctx, dbs.NewQuery("idempotency_key = ?", key))
+}
+
+func (r *refundRepo) GetByProviderRefundID(ctx context.Context, providerRefundID string) (*model.Refund, error) {
+	return r.findOne(ctx, dbs.NewQuery("provider_refund_id = ?", providerRefundID))
+}
+
+func (r *refundRepo) findOne(ctx context.Context, query dbs.Query) (*model.Refund, error) {
+	var refund model.Refund
+	if err := r.db.FindOne(ctx, &refund, dbs.WithQuery(query), dbs.WithPreload([]string{"Lines"})); err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+func (r *refundRepo) ListByOrderID(ctx context.Context, orderID string) ([]*model.Refund, error) {
+	var refunds []*model.Refund
+	err := r.db.Find(ctx, &refunds,
+		dbs.WithQuery(dbs.NewQuery("order_id = ?", orderID)),
+		dbs.WithPreload([]string{"Lines"}),
+		dbs.WithOrder("created_at"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return refunds, nil
+}
+
+func (r *refundRepo) RefundedQuantities(ctx context.Context, orderID string) (map[string]uint, error) {
+	var rows []struct {
+		OrderLineID string
+		Quantity    uint
+	}
+	err := r.db.GetDB().WithContext(ctx).
+		Table("refund_lines").
+		Select("refund_lines.order_line_id, SUM(refund_lines.quantity) AS quantity").
+		Joins("JOIN refunds ON refunds.id = refund_lines.refund_id").
+		Where("refunds.order_id = ? AND refunds.status IN ?", orderID,
+			[]model.RefundStatus{model.RefundStatusPending, model.RefundStatusSucceeded}).
+		Group("refund_lines.order_line_id").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, err
+	}
+	quantities := make(map[string]uint, len(rows))
+	for _, row := range rows {
+		quantities[row.OrderLineID] = row.Quantity
+	}
+	return quantities, nil
+}
@@ -0,0 +1,107 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/payment/model"
+	dbsMocks "goshop/pkg/dbs/mocks"
+)
+
+func TestRefundRepo_Create(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	refund := &model.Refund{Lines: []*model.RefundLine{{OrderLineID: "l1"}}}
+	dbm.On("Create", mock.Anything, refund).Return(nil).Once()
+	require.NoError(t, NewRefundRepository(dbm).Create(context.Background(), refund))
+}
+
+func TestRefundRepo_UpdateLeavesLinesAlone(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	m.ExpectExec(regexp.QuoteMeta(`UPDATE "refunds" SET`)).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := NewRefundRepository(dbm).Update(context.Background(), &model.Refund{
+		ID:     "rf_1",
+		Status: model.RefundStatusSucceeded,
+		Lines:  []*model.RefundLine{{ID: "rl_1", OrderLineID: "l1"}},
+	})
+	require.NoError(t, err)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestRefundRepo_GetBy(t *testing.T) {
+	tests := []struct {
+		name string
+		get  func(r RefundRepository) (*model.Refund, error)
+	}{
+		{"id", func(r RefundRepository) (*model.Refund, error) { return r.GetByID(context.Background(), "rf_1") }},
+		{"idempotency_key", func(r RefundRepository) (*model.Refund, error) {
+			return r.GetByIdempotencyKey(context.Background(), "k1")
+		}},
+		{"provider_refund_id", func(r RefundRepository) (*model.Refund, error) {
+			return r.GetByProviderRefundID(context.Background(), "re_1")
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dbm := dbsMocks.NewDatabase(t)
+			dbm.On("FindOne", mock.Anything, &model.Refund{}, mock.Anything).Return(nil).Once()
+			refund, err := tt.get(NewRefundRepository(dbm))
+			require.NoError(t, err)
+			require.NotNil(t, refund)
+
+			dbm = dbsMocks.NewDatabase(t)
+			dbm.On("FindOne", mock.Anything, &model.Refund{}, mock.Anything).Return(gorm.ErrRecordNotFound).Once()
+			refund, err = tt.get(NewRefundRepository(dbm))
+			require.ErrorIs(t, err, gorm.ErrRecordNotFound)
+			require.Nil(t, refund)
+		})
+	}
+}
+
+func TestRefundRepo_ListByOrderID(t *testing.T) {
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("Find", mock.Anything, mock.AnythingOfType("*[]*model.Refund"), mock.Anything).Return(nil).Once()
+	_, err := NewRefundRepository(dbm).ListByOrderID(context.Background(), "o1")
+	require.NoError(t, err)
+
+	dbm = dbsMocks.NewDatabase(t)
+	dbm.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("db")).Once()
+	_, err = NewRefundRepository(dbm).ListByOrderID(context.Background(), "o1")
+	require.Error(t, err)
+}
+
+func TestRefundRepo_RefundedQuantities(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+
+	m.ExpectQuery(regexp.QuoteMeta(`SELECT refund_lines.order_line_id, SUM(refund_lines.quantity) AS quantity `+
+		`FROM "refund_lines" JOIN refunds ON refunds.id = refund_lines.refund_id `+
+		`WHERE refunds.order_id = $1 AND refunds.status IN ($2,$3) GROUP BY "refund_lines"."order_line_id"`)).
+		WithArgs("o1", model.RefundStatusPending, model.RefundStatusSucceeded).
+		WillReturnRows(sqlmock.NewRows([]string{"order_line_id", "quantity"}).AddRow("l1", 2).AddRow("l2", 1))
+
+	quantities, err := NewRefundRepository(dbm).RefundedQuantities(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]uint{"l1": 2, "l2": 1}, quantities)
+}
+
+func TestRefundRepo_RefundedQuantities_Error(t *testing.T) {
+	g, m := newSQLMockDB(t)
+	dbm := dbsMocks.NewDatabase(t)
+	dbm.On("GetDB").Return(g)
+	m.ExpectQuery(`SELECT`).WillReturnError(errors.New("boom"))
+
+	_, err := NewRefundRepository(dbm).RefundedQuantities(context.Background(), "o1")
+	require.Error(t, err)
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	orderModel "goshop/internal/order/model"
+	"goshop/internal/payment/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/money"
+	"goshop/pkg/payment"
+)
+
+// newCODFixture is a providerFixture whose order o1 is paid cash on delivery.
+func newCODFixture(t *testing.T, status orderModel.OrderStatus) *providerFixture {
+	f := newProviderFixture(t)
+	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
+		return &orderModel.Order{ID: "o1", UserID: "u1", Status: status, PaymentMethod: orderModel.PaymentMethodCOD, FinalPrice: money.New(1500, "USD")}, nil
+	}}
+	f.svc = NewPaymentService(nil, registryOf(f.stripe), f.repo, &stubRefundRepo{}, &stubMethodRepo{}, q, f.osvc, &stubCreditNotes{}, payment.CaptureAutomatic)
+	return f
+}
+
+func TestCollectCashOnDelivery(t *testing.T) {
+	f := newCODFixture(t, orderModel.OrderStatusInProgress)
+	f.osvc.On("UpdateOrderStatus", mock.Anything, "o1", orderModel.OrderStatusDone).Return(&orderModel.Order{}, nil).Once()
+
+	rec, err := f.svc.CollectCashOnDelivery(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Equal(t, &model.Payment{
+		OrderID: "o1", Provider: model.ProviderCashOnDelivery, ProviderIntentID: "cod_o1",
+		Amount: 1500, Currency: "usd", Status: model.PaymentStatusSucceeded,
+	}, rec)
+
+	// Collecting again returns the recorded payment.
+	again, err := f.svc.CollectCashOnDelivery(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Same(t, rec, again)
+	require.Equal(t, 1, f.repo.createCall)
+}
+
+func TestCollectCashOnDelivery_Shipped(t *testing.T) {
+	f := newCODFixture(t, orderModel.OrderStatusShipped)
+	f.osvc.On("UpdateOrderStatus", mock.Anything, "o1", orderModel.OrderStatusDone).Return(&orderModel.Order{}, nil).Once()
+
+	rec, err := f.svc.CollectCashOnDelivery(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Equal(t, model.PaymentStatusSucceeded, rec.Status)
+}
+
+func TestCollectCashOnDelivery_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(t *testing.T) *providerFixture
+		wantErr *apperror.AppError
+	}{
+		{
+			name:    "online_order",
+			setup:   func(t *testing.T) *providerFixture { return newProviderFixture(t) },
+			wantErr: apperror.ErrBadRequest,
+		},
+		{
+			name:    "not_out_for_delivery",
+			setup:   func(t *testing.T) *providerFixture { return newCODFixture(t, orderModel.OrderStatusNew) },
+			wantErr: apperror.ErrInvalidStatus,
+		},
+		{
+			name:    "partially_shipped",
+			setup:   func(t *testing.T) *providerFixture { return newCODFixture(t, orderModel.OrderStatusPartiallyShipped) },
+			wantErr: apperror.ErrInvalidStatus,
+		},
+		{
+			name:    "cancelled",
+			setup:   func(t *testing.T) *providerFixture { return newCODFixture(t, orderModel.OrderStatusCancelled) },
+			wantErr: apperror.ErrInvalidStatus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.setup(t)
+			_, err := f.svc.CollectCashOnDelivery(context.Background(), "o1")
+			requireAppError(t, err, tt.wantErr)
+			require.Zero(t, f.repo.createCall)
+		})
+	}
+}
+
+func TestCollectCashOnDelivery_OrderNotFound(t *testing.T) {
+	f := newProviderFixture(t)
+	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
+		return nil, gorm.ErrRecordNotFound
+	}}
+	f.svc = NewPaymentService(nil, registryOf(f.stripe), f.repo, &stubRefundRepo{}, &stubMethodRepo{}, q, f.osvc, &stubCreditNotes{}, payment.CaptureAutomatic)
+
+	_, err := f.svc.CollectCashOnDelivery(context.Background(), "o1")
+	requireAppError(t, err, apperror.ErrNotFound)
+}
+
+func TestCreateIntent_RejectsCashOnDelivery(t *testing.T) {
+	f := newCODFixture(t, orderModel.OrderStatusNew)
+	_, err := f.svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "")
+	requireAppError(t, err, apperror.ErrBadRequest)
+	require.Zero(t, f.repo.createCall)
+}
+
+func TestRefundOrder_RejectsCashOnDelivery(t *testing.T) {
+	f := newCODFixture(t, orderModel.OrderStatusDone)
+	f.payment = &model.Payment{ID: "p1", OrderID: "o1", Provider: model.ProviderCashOnDelivery, Amount: 1500, Status: model.PaymentStatusSucceeded}
+
+	_, err := f.svc.RefundOrder(context.Background(), "o1", RefundRequest{})
+	requireAppError(t, err, apperror.ErrBadRequest)
+}
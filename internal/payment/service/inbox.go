@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"gorm.io/gorm"
+
+	"goshop/internal/payment/domain"
+	"goshop/internal/payment/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/paging"
+	"goshop/pkg/payment"
+)
+
+const (
+	// MaxEventAttempts is how many times a webhook event is applied before it's marked failed.
+	MaxEventAttempts = 10
+	// EventClaimLease is how long an event being applied is hidden from other workers.
+	EventClaimLease = time.Minute
+
+	eventRetryBaseDelay = 5 * time.Second
+	eventRetryMaxDelay  = 30 * time.Minute
+)
+
+// processEvent applies an inbox event and records the outcome on it: processed, pending
+// with a backed-off next attempt, or failed once MaxEventAttempts is reached. Only failing
+// to save the outcome is returned; the apply error is kept in LastError.
+func (s *paymentService) processEvent(ctx context.Context, inbox *model.ProviderEvent, provider payment.Provider, event *payment.Event) error {
+	inbox.Attempts++
+	err := s.applyWebhookEvent(ctx, inbox.Provider, provider, event)
+	now := s.now()
+	switch {
+	case err == nil:
+		inbox.Status = model.ProviderEventStatusProcessed
+		inbox.ProcessedAt = &now
+		inbox.LastError = ""
+	case inbox.Attempts >= MaxEventAttempts:
+		logger.Errorf("%s webhook event %s: giving up after %d attempts: %s", inbox.Provider, inbox.EventID, inbox.Attempts, err)
+		inbox.Status = model.ProviderEventStatusFailed
+		inbox.LastError = err.Error()
+	default:
+		logger.Warnf("%s webhook event %s (attempt %d): %s", inbox.Provider, inbox.EventID, inbox.Attempts, err)
+		inbox.Status = model.ProviderEventStatusPending
+		inbox.LastError = err.Error()
+		inbox.NextAttemptAt = now.Add(eventRetryDelay(inbox.Attempts))
+	}
+	if err := s.repo.UpdateProviderEvent(ctx, inbox); err != nil {
+		// The claim lease runs out and the event is applied again, so at worst twice.
+		return fmt.Errorf("save webhook event %s: %w", inbox.EventID, err)
+	}
+	return nil
+}
+
+// retryEvent decodes a stored event and processes it.
+func (s *paymentService) retryEvent(ctx context.Context, inbox *model.ProviderEvent) error {
+	provider, err := s.providers.Get(inbox.Provider)
+	if err != nil {
+		return s.failEvent(ctx, inbox, err)
+	}
+	event, err := provider.ParseWebhook([]byte(inbox.Payload))
+	if err != nil {
+		return s.failEvent(ctx, inbox, err)
+	}
+	return s.processEvent(ctx, inbox, provider, event)
+}
+
+// failEvent marks an event failed without retries: they won't make an unconfigured provider
+// or an undecodable payload work.
+func (s *paymentService) failEvent(ctx context.Context, inbox *model.ProviderEvent, cause error) error {
+	logger.Errorf("%s webhook event %s: %s", inbox.Provider, inbox.EventID, cause)
+	inbox.Status = model.ProviderEventStatusFailed
+	inbox.LastError = cause.Error()
+	if err := s.repo.UpdateProviderEvent(ctx, inbox); err != nil {
+		return fmt.Errorf("save webhook event %s: %w", inbox.EventID, err)
+	}
+	return nil
+}
+
+// eventRetryDelay backs off exponentially from eventRetryBaseDelay, capped at
+// eventRetryMaxDelay.
+func eventRetryDelay(attempts int) time.Duration {
+	delay := eventRetryBaseDelay
+	for i := 1; i < attempts; i++ {
+		delay *= 2
+		if delay >= eventRetryMaxDelay {
+			return eventRetryMaxDelay
+		}
+	}
+	return delay
+}
+
+func (s *paymentService) ProcessPendingEvents(ctx context.Context, batchSize int) (int, error) {
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+	events, err := s.repo.ClaimDueEvents(ctx, batchSize, EventClaimLease)
+	if err != nil {
+		return 0, err
+	}
+
+	processed := 0
+	for _, inbox := range events {
+		if err := s.retryEvent(ctx, inbox); err != nil {
+			logger.Error("webhook inbox: ", err)
+			continue
+		}
+		if inbox.Status == model.ProviderEventStatusProcessed {
+			processed++
+		}
+	}
+	return processed, nil
+}
+
+func (s *paymentService) ListFailedEvents(ctx context.Context, req *domain.ListFailedEventsReq) ([]*model.ProviderEvent, *paging.Pagination, error) {
+	return s.repo.ListFailedEvents(ctx, req)
+}
+
+func (s *paymentService) ReplayEvent(ctx context.Context, providerName, eventID string) (*model.ProviderEvent, error) {
+	inbox, err := s.repo.GetProviderEvent(ctx, providerName, eventID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if inbox.Status == model.ProviderEventStatusProcessed {
+		return nil, apperror.WrapMessage(apperror.ErrInvalidStatus, nil, "webhook event was already processed")
+	}
+	// Lease the event like the worker does, so it isn't applied twice at once.
+	inbox, err = s.repo.ClaimEvent(ctx, providerName, eventID, EventClaimLease)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.WrapMessage(apperror.ErrConflict, nil, "webhook event is already being processed")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	inbox.Attempts = 0
+	if err := s.retryEvent(ctx, inbox); err != nil {
+		return nil, err
+	}
+	return inbox, nil
+}
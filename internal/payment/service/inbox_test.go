@@ -0,0 +1,306 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/payment/domain"
+	"goshop/internal/payment/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/paging"
+	"goshop/pkg/payment"
+)
+
+// inboxFixture wires a service whose stripe provider parses every stored payload into a
+// processing event for order o1, so applying it only updates the payment.
+type inboxFixture struct {
+	svc     *paymentService
+	prov    *stubProvider
+	repo    *stubRepo
+	parsed  []string
+	updates int
+}
+
+func newInboxFixture(t *testing.T) *inboxFixture {
+	f := &inboxFixture{}
+	f.prov = &stubProvider{parseFn: func(payload []byte) (*payment.Event, error) {
+		f.parsed = append(f.parsed, string(payload))
+		return &payment.Event{ID: "evt_1", Type: payment.EventPaymentProcessing, OrderID: "o1"}, nil
+	}}
+	f.repo = &stubRepo{
+		getFn: func(_ context.Context, _ string) (*model.Payment, error) {
+			return &model.Payment{ID: "p1", OrderID: "o1", Provider: "stripe"}, nil
+		},
+		updateFn: func(_ context.Context, _ *model.Payment) error {
+			f.updates++
+			return nil
+		},
+	}
+	f.svc = NewPaymentService(nil, registryOf(f.prov), f.repo, &stubRefundRepo{}, &stubMethodRepo{}, &stubOrderQuery{},
+		newOrderSvcMock(t), &stubCreditNotes{}, payment.CaptureAutomatic).(*paymentService)
+	f.svc.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return f
+}
+
+func pendingEvent(attempts int) *model.ProviderEvent {
+	return &model.ProviderEvent{
+		Provider: "stripe", EventID: "evt_1", Payload: `{"id":"evt_1"}`,
+		Status: model.ProviderEventStatusPending, Attempts: attempts,
+	}
+}
+
+func TestProcessPendingEvents_AppliesStoredPayload(t *testing.T) {
+	f := newInboxFixture(t)
+	f.repo.claimFn = func(_ context.Context, limit int, lease time.Duration) ([]*model.ProviderEvent, error) {
+		require.Equal(t, 100, limit)
+		require.Equal(t, EventClaimLease, lease)
+		return []*model.ProviderEvent{pendingEvent(2)}, nil
+	}
+
+	n, err := f.svc.ProcessPendingEvents(context.Background(), 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Equal(t, []string{`{"id":"evt_1"}`}, f.parsed)
+	require.Equal(t, 1, f.updates)
+
+	require.Len(t, f.repo.saved, 1)
+	saved := f.repo.saved[0]
+	require.Equal(t, model.ProviderEventStatusProcessed, saved.Status)
+	require.Equal(t, 3, saved.Attempts)
+	require.Empty(t, saved.LastError)
+	require.Equal(t, f.svc.now(), *saved.ProcessedAt)
+}
+
+func TestProcessPendingEvents_BacksOffThenFails(t *testing.T) {
+	tests := []struct {
+		name       string
+		attempts   int
+		wantStatus model.ProviderEventStatus
+	}{
+		{name: "retries", attempts: 2, wantStatus: model.ProviderEventStatusPending},
+		{name: "gives_up", attempts: MaxEventAttempts - 1, wantStatus: model.ProviderEventStatusFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newInboxFixture(t)
+			f.repo.updateFn = func(_ context.Context, _ *model.Payment) error { return errors.New("db down") }
+			f.repo.claimFn = func(_ context.Context, _ int, _ time.Duration) ([]*model.ProviderEvent, error) {
+				return []*model.ProviderEvent{pendingEvent(tt.attempts)}, nil
+			}
+
+			n, err := f.svc.ProcessPendingEvents(context.Background(), 10)
+			require.NoError(t, err)
+			require.Zero(t, n)
+
+			saved := f.repo.saved[0]
+			require.Equal(t, tt.wantStatus, saved.Status)
+			require.Equal(t, tt.attempts+1, saved.Attempts)
+			require.Contains(t, saved.LastError, "db down")
+			if tt.wantStatus == model.ProviderEventStatusPending {
+				require.Equal(t, f.svc.now().Add(eventRetryDelay(tt.attempts+1)), saved.NextAttemptAt)
+			}
+		})
+	}
+}
+
+func TestProcessPendingEvents_UnreadableEventFailsAtOnce(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		parseErr error
+	}{
+		{name: "unknown_provider", provider: "adyen"},
+		{name: "bad_payload", provider: "stripe", parseErr: errors.New("stripe webhook: decode event")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newInboxFixture(t)
+			if tt.parseErr != nil {
+				f.prov.parseFn = func(_ []byte) (*payment.Event, error) { return nil, tt.parseErr }
+			}
+			f.repo.claimFn = func(_ context.Context, _ int, _ time.Duration) ([]*model.ProviderEvent, error) {
+				event := pendingEvent(0)
+				event.Provider = tt.provider
+				return []*model.ProviderEvent{event}, nil
+			}
+
+			_, err := f.svc.ProcessPendingEvents(context.Background(), 10)
+			require.NoError(t, err)
+			require.Equal(t, model.ProviderEventStatusFailed, f.repo.saved[0].Status)
+			require.NotEmpty(t, f.repo.saved[0].LastError)
+			require.Zero(t, f.updates)
+		})
+	}
+}
+
+func TestProcessPendingEvents_Errors(t *testing.T) {
+	f := newInboxFixture(t)
+	f.repo.claimFn = func(_ context.Context, _ int, _ time.Duration) ([]*model.ProviderEvent, error) {
+		return nil, errors.New("db down")
+	}
+	_, err := f.svc.ProcessPendingEvents(context.Background(), 10)
+	require.Error(t, err)
+
+	// A save failure is logged and the rest of the batch still runs.
+	f.repo.claimFn = func(_ context.Context, _ int, _ time.Duration) ([]*model.ProviderEvent, error) {
+		return []*model.ProviderEvent{pendingEvent(0), pendingEvent(0)}, nil
+	}
+	f.repo.saveFn = func(_ context.Context, _ *model.ProviderEvent) error { return errors.New("db down") }
+	n, err := f.svc.ProcessPendingEvents(context.Background(), 10)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Equal(t, 2, f.updates)
+}
+
+func TestListFailedEvents(t *testing.T) {
+	f := newInboxFixture(t)
+	f.repo.failedFn = func(_ context.Context, req *domain.ListFailedEventsReq) ([]*model.ProviderEvent, *paging.Pagination, error) {
+		require.Equal(t, "stripe", req.Provider)
+		return []*model.ProviderEvent{pendingEvent(3)}, &paging.Pagination{Total: 1}, nil
+	}
+	events, pagination, err := f.svc.ListFailedEvents(context.Background(), &domain.ListFailedEventsReq{Provider: "stripe"})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	require.Equal(t, int64(1), pagination.Total)
+}
+
+func TestReplayEvent(t *testing.T) {
+	f := newInboxFixture(t)
+	failed := pendingEvent(MaxEventAttempts)
+	failed.Status = model.ProviderEventStatusFailed
+	failed.LastError = "db down"
+	f.repo.getEventFn = func(_ context.Context, provider, eventID string) (*model.ProviderEvent, error) {
+		require.Equal(t, "stripe", provider)
+		require.Equal(t, "evt_1", eventID)
+		return failed, nil
+	}
+
+	event, err := f.svc.ReplayEvent(context.Background(), "stripe", "evt_1")
+	require.NoError(t, err)
+	require.Equal(t, model.ProviderEventStatusProcessed, event.Status)
+	require.Equal(t, 1, event.Attempts)
+	require.Empty(t, event.LastError)
+	require.Equal(t, 1, f.updates)
+}
+
+func TestReplayEvent_ClaimsBeforeApplying(t *testing.T) {
+	f := newInboxFixture(t)
+	f.repo.getEventFn = func(_ context.Context, _, _ string) (*model.ProviderEvent, error) {
+		return pendingEvent(3), nil
+	}
+	f.repo.claimOneFn = func(_ context.Context, provider, eventID string, lease time.Duration) (*model.ProviderEvent, error) {
+		require.Equal(t, "stripe", provider)
+		require.Equal(t, "evt_1", eventID)
+		require.Equal(t, EventClaimLease, lease)
+		require.Zero(t, f.updates, "applied before it was claimed")
+		claimed := pendingEvent(3)
+		claimed.Status = model.ProviderEventStatusProcessing
+		return claimed, nil
+	}
+
+	event, err := f.svc.ReplayEvent(context.Background(), "stripe", "evt_1")
+	require.NoError(t, err)
+	require.Equal(t, model.ProviderEventStatusProcessed, event.Status)
+	require.Equal(t, 1, f.updates)
+}
+
+func TestReplayEvent_FailureStartsRetriesOver(t *testing.T) {
+	f := newInboxFixture(t)
+	f.repo.updateFn = func(_ context.Context, _ *model.Payment) error { return errors.New("still down") }
+	f.repo.getEventFn = func(_ context.Context, _, _ string) (*model.ProviderEvent, error) {
+		failed := pendingEvent(MaxEventAttempts)
+		failed.Status = model.ProviderEventStatusFailed
+		return failed, nil
+	}
+
+	event, err := f.svc.ReplayEvent(context.Background(), "stripe", "evt_1")
+	require.NoError(t, err)
+	require.Equal(t, model.ProviderEventStatusPending, event.Status)
+	require.Equal(t, 1, event.Attempts)
+	require.Contains(t, event.LastError, "still down")
+}
+
+func TestReplayEvent_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		get   func(context.Context, string, string) (*model.ProviderEvent, error)
+		claim func(context.Context, string, string, time.Duration) (*model.ProviderEvent, error)
+		save  error
+		check func(t *testing.T, err error)
+	}{
+		{
+			name: "not_found",
+			get: func(_ context.Context, _, _ string) (*model.ProviderEvent, error) {
+				return nil, gorm.ErrRecordNotFound
+			},
+			check: func(t *testing.T, err error) { require.ErrorIs(t, err, apperror.ErrNotFound) },
+		},
+		{
+			name: "already_processed",
+			get: func(_ context.Context, _, _ string) (*model.ProviderEvent, error) {
+				return &model.ProviderEvent{Provider: "stripe", EventID: "evt_1", Status: model.ProviderEventStatusProcessed}, nil
+			},
+			check: func(t *testing.T, err error) { requireAppError(t, err, apperror.ErrInvalidStatus) },
+		},
+		{
+			name: "being_processed",
+			get: func(_ context.Context, _, _ string) (*model.ProviderEvent, error) {
+				return pendingEvent(3), nil
+			},
+			claim: func(_ context.Context, _, _ string, _ time.Duration) (*model.ProviderEvent, error) {
+				return nil, gorm.ErrRecordNotFound
+			},
+			check: func(t *testing.T, err error) { requireAppError(t, err, apperror.ErrConflict) },
+		},
+		{
+			name: "claim_error",
+			get: func(_ context.Context, _, _ string) (*model.ProviderEvent, error) {
+				return pendingEvent(3), nil
+			},
+			claim: func(_ context.Context, _, _ string, _ time.Duration) (*model.ProviderEvent, error) {
+				return nil, errors.New("db down")
+			},
+			check: func(t *testing.T, err error) { require.EqualError(t, err, "db down") },
+		},
+		{
+			name: "lookup_error",
+			get: func(_ context.Context, _, _ string) (*model.ProviderEvent, error) {
+				return nil, errors.New("db down")
+			},
+			check: func(t *testing.T, err error) { require.EqualError(t, err, "db down") },
+		},
+		{
+			name: "save_error",
+			get: func(_ context.Context, _, _ string) (*model.ProviderEvent, error) {
+				return pendingEvent(3), nil
+			},
+			save:  errors.New("db down"),
+			check: func(t *testing.T, err error) { require.ErrorContains(t, err, "save webhook event evt_1") },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newInboxFixture(t)
+			f.repo.getEventFn = tt.get
+			f.repo.claimOneFn = tt.claim
+			if tt.save != nil {
+				f.repo.saveFn = func(_ context.Context, _ *model.ProviderEvent) error { return tt.save }
+			}
+			event, err := f.svc.ReplayEvent(context.Background(), "stripe", "evt_1")
+			require.Nil(t, event)
+			tt.check(t, err)
+		})
+	}
+}
+
+func TestEventRetryDelay(t *testing.T) {
+	require.Equal(t, eventRetryBaseDelay, eventRetryDelay(1))
+	require.Equal(t, 4*eventRetryBaseDelay, eventRetryDelay(3))
+	require.Equal(t, eventRetryMaxDelay, eventRetryDelay(MaxEventAttempts))
+	require.Equal(t, eventRetryMaxDelay, eventRetryDelay(100))
+}
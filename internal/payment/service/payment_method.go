@@ -0,0 +1,180 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	orderModel "goshop/internal/order/model"
+	"goshop/internal/payment/domain"
+	"goshop/internal/payment/model"
+	"goshop/internal/payment/repository"
+	"goshop/pkg/apperror"
+	"goshop/pkg/payment"
+)
+
+// PaymentMethodService keeps the payment methods returning buyers save for later checkouts.
+// Saving one takes two steps: StartSetup returns a setup intent the FE completes with the
+// provider, then SavePaymentMethod stores the method the buyer entered.
+//
+//go:generate mockery --name=PaymentMethodService
+type PaymentMethodService interface {
+	ListPaymentMethods(ctx context.Context, userID string) ([]*model.PaymentMethod, error)
+	// StartSetup creates a setup intent with the named (or default) provider, creating the
+	// user's customer at the provider the first time.
+	StartSetup(ctx context.Context, userID, providerName string) (*payment.SetupIntent, error)
+	// SavePaymentMethod stores the payment method of a succeeded setup intent. Saving the
+	// same setup intent again returns the stored method.
+	SavePaymentMethod(ctx context.Context, userID string, req *domain.SavePaymentMethodReq) (*model.PaymentMethod, error)
+	// DeletePaymentMethod detaches the method at the provider and forgets it.
+	DeletePaymentMethod(ctx context.Context, id, userID string) error
+}
+
+// UserQuery looks up the user a provider-side customer is created for.
+type UserQuery interface {
+	GetUserByID(ctx context.Context, id string) (*orderModel.User, error)
+}
+
+type paymentMethodService struct {
+	providers *payment.Registry
+	methods   repository.PaymentMethodRepository
+	users     UserQuery
+}
+
+func NewPaymentMethodService(
+	providers *payment.Registry,
+	methods repository.PaymentMethodRepository,
+	users UserQuery,
+) PaymentMethodService {
+	return &paymentMethodService{providers: providers, methods: methods, users: users}
+}
+
+func (s *paymentMethodService) ListPaymentMethods(ctx context.Context, userID string) ([]*model.PaymentMethod, error) {
+	return s.methods.ListByUser(ctx, userID)
+}
+
+func (s *paymentMethodService) StartSetup(ctx context.Context, userID, providerName string) (*payment.SetupIntent, error) {
+	providerName, provider, err := s.savedMethods(providerName)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := s.customer(ctx, userID, providerName, provider)
+	if err != nil {
+		return nil, err
+	}
+	return provider.CreateSetupIntent(ctx, customer.ProviderCustomerID)
+}
+
+func (s *paymentMethodService) SavePaymentMethod(ctx context.Context, userID string, req *domain.SavePaymentMethodReq) (*model.PaymentMethod, error) {
+	providerName, provider, err := s.savedMethods(req.Provider)
+	if err != nil {
+		return nil, err
+	}
+	customer, err := s.methods.GetCustomer(ctx, userID, providerName)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, err, "setup intent not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	setup, err := provider.GetSetupIntent(ctx, req.SetupIntentID)
+	if err != nil {
+		return nil, err
+	}
+	// Someone else's setup intent is reported as missing rather than confirming it exists.
+	if setup.CustomerID != customer.ProviderCustomerID {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, nil, "setup intent not found")
+	}
+	if setup.Status != payment.SetupIntentStatusSucceeded || setup.PaymentMethod == nil {
+		return nil, apperror.WrapMessage(apperror.ErrInvalidStatus, nil,
+			fmt.Sprintf("setup intent is %s", setup.Status))
+	}
+
+	existing, err := s.methods.GetByProviderMethodID(ctx, providerName, setup.PaymentMethod.ID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	method := &model.PaymentMethod{
+		UserID:             userID,
+		Provider:           providerName,
+		ProviderCustomerID: customer.ProviderCustomerID,
+		ProviderMethodID:   setup.PaymentMethod.ID,
+		Brand:              setup.PaymentMethod.Brand,
+		Last4:              setup.PaymentMethod.Last4,
+	}
+	if err := s.methods.Create(ctx, method); err != nil {
+		return nil, err
+	}
+	return method, nil
+}
+
+func (s *paymentMethodService) DeletePaymentMethod(ctx context.Context, id, userID string) error {
+	method, err := s.methods.GetByID(ctx, id, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return apperror.WrapMessage(apperror.ErrNotFound, err, "payment method not found")
+	}
+	if err != nil {
+		return err
+	}
+	// A provider that has since been switched off can't charge the method anyway.
+	if _, provider, err := s.savedMethods(method.Provider); err == nil {
+		if err := provider.DetachPaymentMethod(ctx, method.ProviderMethodID); err != nil {
+			return err
+		}
+	}
+	return s.methods.Delete(ctx, id, userID)
+}
+
+// savedMethods resolves the named (or default) provider, which must be able to save payment
+// methods.
+func (s *paymentMethodService) savedMethods(providerName string) (string, payment.SavedMethods, error) {
+	if providerName == "" {
+		providerName = s.providers.DefaultName()
+	}
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return "", nil, apperror.WrapMessage(apperror.ErrBadRequest, err, err.Error())
+	}
+	saved, ok := provider.(payment.SavedMethods)
+	if !ok {
+		return "", nil, apperror.WrapMessage(apperror.ErrBadRequest, nil,
+			fmt.Sprintf("%s can't save payment methods", providerName))
+	}
+	return providerName, saved, nil
+}
+
+// customer returns the user's customer at the provider, creating it on first use. The
+// provider's idempotency key makes concurrent first uses create a single customer.
+func (s *paymentMethodService) customer(ctx context.Context, userID, providerName string, provider payment.SavedMethods) (*model.PaymentCustomer, error) {
+	customer, err := s.methods.GetCustomer(ctx, userID, providerName)
+	if err == nil || !errors.Is(err, gorm.ErrRecordNotFound) {
+		return customer, err
+	}
+
+	user, err := s.users.GetUserByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	customerID, err := provider.CreateCustomer(ctx, payment.CustomerParams{
+		UserID:         user.ID,
+		Email:          user.Email,
+		IdempotencyKey: "customer_" + user.ID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.methods.CreateCustomer(ctx, &model.PaymentCustomer{
+		UserID:             userID,
+		Provider:           providerName,
+		ProviderCustomerID: customerID,
+	}); err != nil {
+		return nil, err
+	}
+	return s.methods.GetCustomer(ctx, userID, providerName)
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	orderModel "goshop/internal/order/model"
+	"goshop/internal/payment/domain"
+	"goshop/internal/payment/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/money"
+	"goshop/pkg/payment"
+)
+
+// stubMethodRepo is a repository.PaymentMethodRepository; lookups without a stub find
+// nothing and writes succeed.
+type stubMethodRepo struct {
+	getCustomerFn    func(ctx context.Context, userID, provider string) (*model.PaymentCustomer, error)
+	createCustomerFn func(ctx context.Context, customer *model.PaymentCustomer) error
+	listFn           func(ctx context.Context, userID string) ([]*model.PaymentMethod, error)
+	getFn            func(ctx context.Context, id, userID string) (*model.PaymentMethod, error)
+	getByProvFn      func(ctx context.Context, provider, providerMethodID string) (*model.PaymentMethod, error)
+	createFn         func(ctx context.Context, method *model.PaymentMethod) error
+	deleteFn         func(ctx context.Context, id, userID string) error
+}
+
+func (s *stubMethodRepo) GetCustomer(ctx context.Context, userID, provider string) (*model.PaymentCustomer, error) {
+	if s.getCustomerFn == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return s.getCustomerFn(ctx, userID, provider)
+}
+func (s *stubMethodRepo) CreateCustomer(ctx context.Context, customer *model.PaymentCustomer) error {
+	if s.createCustomerFn == nil {
+		return nil
+	}
+	return s.createCustomerFn(ctx, customer)
+}
+func (s *stubMethodRepo) ListByUser(ctx context.Context, userID string) ([]*model.PaymentMethod, error) {
+	return s.listFn(ctx, userID)
+}
+func (s *stubMethodRepo) GetByID(ctx context.Context, id, userID string) (*model.PaymentMethod, error) {
+	if s.getFn == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return s.getFn(ctx, id, userID)
+}
+func (s *stubMethodRepo) GetByProviderMethodID(ctx context.Context, provider, providerMethodID string) (*model.PaymentMethod, error) {
+	if s.getByProvFn == nil {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return s.getByProvFn(ctx, provider, providerMethodID)
+}
+func (s *stubMethodRepo) Create(ctx context.Context, method *model.PaymentMethod) error {
+	if s.createFn == nil {
+		return nil
+	}
+	return s.createFn(ctx, method)
+}
+func (s *stubMethodRepo) Delete(ctx context.Context, id, userID string) error {
+	if s.deleteFn == nil {
+		return nil
+	}
+	return s.deleteFn(ctx, id, userID)
+}
+
+// stubSaving is a provider that can save payment methods (payment.SavedMethods).
+type stubSaving struct {
+	stubProvider
+	customerFn func(ctx context.Context, p payment.CustomerParams) (string, error)
+	setupFn    func(ctx context.Context, customerID string) (*payment.SetupIntent, error)
+	getSetupFn func(ctx context.Context, id string) (*payment.SetupIntent, error)
+	detachFn   func(ctx context.Context, id string) error
+}
+
+func (s *stubSaving) CreateCustomer(ctx context.Context, p payment.CustomerParams) (string, error) {
+	return s.customerFn(ctx, p)
+}
+func (s *stubSaving) CreateSetupIntent(ctx context.Context, customerID string) (*payment.SetupIntent, error) {
+	return s.setupFn(ctx, customerID)
+}
+func (s *stubSaving) GetSetupIntent(ctx context.Context, id string) (*payment.SetupIntent, error) {
+	return s.getSetupFn(ctx, id)
+}
+func (s *stubSaving) DetachPaymentMethod(ctx context.Context, id string) error {
+	return s.detachFn(ctx, id)
+}
+
+type stubUserQuery struct{}
+
+func (stubUserQuery) GetUserByID(_ context.Context, id string) (*orderModel.User, error) {
+	return &orderModel.User{ID: id, Email: id + "@example.com"}, nil
+}
+
+func knownCustomer(_ context.Context, userID, provider string) (*model.PaymentCustomer, error) {
+	return &model.PaymentCustomer{UserID: userID, Provider: provider, ProviderCustomerID: "cus_1"}, nil
+}
+
+func TestStartSetup_CreatesCustomerOnFirstUse(t *testing.T) {
+	var stored *model.PaymentCustomer
+	repo := &stubMethodRepo{}
+	repo.getCustomerFn = func(_ context.Context, _, _ string) (*model.PaymentCustomer, error) {
+		if stored == nil {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return stored, nil
+	}
+	repo.createCustomerFn = func(_ context.Context, customer *model.PaymentCustomer) error {
+		stored = customer
+		return nil
+	}
+	customers := 0
+	prov := &stubSaving{
+		customerFn: func(_ context.Context, p payment.CustomerParams) (string, error) {
+			customers++
+			require.Equal(t, payment.CustomerParams{UserID: "u1", Email: "u1@example.com", IdempotencyKey: "customer_u1"}, p)
+			return "cus_1", nil
+		},
+		setupFn: func(_ context.Context, customerID string) (*payment.SetupIntent, error) {
+			require.Equal(t, "cus_1", customerID)
+			return &payment.SetupIntent{ID: "seti_1", ClientSecret: "seti_1_secret", CustomerID: customerID}, nil
+		},
+	}
+	svc := NewPaymentMethodService(registryOf(prov), repo, stubUserQuery{})
+
+	for i := 0; i < 2; i++ {
+		setup, err := svc.StartSetup(context.Background(), "u1", "")
+		require.NoError(t, err)
+		require.Equal(t, "seti_1", setup.ID)
+	}
+	require.Equal(t, 1, customers, "the customer is created once")
+	require.Equal(t, &model.PaymentCustomer{UserID: "u1", Provider: "stripe", ProviderCustomerID: "cus_1"}, stored)
+}
+
+func TestStartSetup_ProviderCannotSave(t *testing.T) {
+	svc := NewPaymentMethodService(registryOf(&stubProvider{}), &stubMethodRepo{}, stubUserQuery{})
+	_, err := svc.StartSetup(context.Background(), "u1", "")
+	requireAppError(t, err, apperror.ErrBadRequest)
+
+	_, err = svc.StartSetup(context.Background(), "u1", "adyen")
+	requireAppError(t, err, apperror.ErrBadRequest)
+}
+
+func TestSavePaymentMethod(t *testing.T) {
+	var created *model.PaymentMethod
+	repo := &stubMethodRepo{
+		getCustomerFn: knownCustomer,
+		createFn: func(_ context.Context, method *model.PaymentMethod) error {
+			created = method
+			return nil
+		},
+	}
+	prov := &stubSaving{getSetupFn: func(_ context.Context, id string) (*payment.SetupIntent, error) {
+		require.Equal(t, "seti_1", id)
+		return &payment.SetupIntent{
+			ID: "seti_1", Status: payment.SetupIntentStatusSucceeded, CustomerID: "cus_1",
+			PaymentMethod: &payment.PaymentMethod{ID: "pm_card", Brand: "visa", Last4: "4242"},
+		}, nil
+	}}
+	svc := NewPaymentMethodService(registryOf(prov), repo, stubUserQuery{})
+
+	method, err := svc.SavePaymentMethod(context.Background(), "u1", &domain.SavePaymentMethodReq{SetupIntentID: "seti_1"})
+	require.NoError(t, err)
+	require.Same(t, created, method)
+	require.Equal(t, model.PaymentMethod{
+		UserID: "u1", Provider: "stripe", ProviderCustomerID: "cus_1", ProviderMethodID: "pm_card",
+		Brand: "visa", Last4: "4242",
+	}, *method)
+
+	// Saving the same setup intent again returns the stored method.
+	created = nil
+	repo.getByProvFn = func(_ context.Context, provider, id string) (*model.PaymentMethod, error) {
+		require.Equal(t, "stripe", provider)
+		require.Equal(t, "pm_card", id)
+		return &model.PaymentMethod{ID: "m1"}, nil
+	}
+	method, err = svc.SavePaymentMethod(context.Background(), "u1", &domain.SavePaymentMethodReq{SetupIntentID: "seti_1"})
+	require.NoError(t, err)
+	require.Equal(t, "m1", method.ID)
+	require.Nil(t, created)
+}
+
+func TestSavePaymentMethod_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		customer func(context.Context, string, string) (*model.PaymentCustomer, error)
+		setup    *payment.SetupIntent
+		want     *apperror.AppError
+	}{
+		{
+			name:  "no_customer_yet",
+			setup: &payment.SetupIntent{},
+			want:  apperror.ErrNotFound,
+		},
+		{
+			name:     "someone_elses_setup_intent",
+			customer: knownCustomer,
+			setup:    &payment.SetupIntent{Status: payment.SetupIntentStatusSucceeded, CustomerID: "cus_2"},
+			want:     apperror.ErrNotFound,
+		},
+		{
+			name:     "not_completed",
+			customer: knownCustomer,
+			setup:    &payment.SetupIntent{Status: "requires_payment_method", CustomerID: "cus_1"},
+			want:     apperror.ErrInvalidStatus,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prov := &stubSaving{getSetupFn: func(_ context.Context, _ string) (*payment.SetupIntent, error) {
+				return tt.setup, nil
+			}}
+			svc := NewPaymentMethodService(registryOf(prov), &stubMethodRepo{getCustomerFn: tt.customer}, stubUserQuery{})
+			_, err := svc.SavePaymentMethod(context.Background(), "u1", &domain.SavePaymentMethodReq{SetupIntentID: "seti_1"})
+			requireAppError(t, err, tt.want)
+		})
+	}
+}
+
+func TestDeletePaymentMethod(t *testing.T) {
+	var detached, deleted string
+	repo := &stubMethodRepo{
+		getFn: func(_ context.Context, id, userID string) (*model.PaymentMethod, error) {
+			require.Equal(t, "u1", userID)
+			return &model.PaymentMethod{ID: id, Provider: "stripe", ProviderMethodID: "pm_card"}, nil
+		},
+		deleteFn: func(_ context.Context, id, _ string) error {
+			deleted = id
+			return nil
+		},
+	}
+	prov := &stubSaving{detachFn: func(_ context.Context, id string) error {
+		detached = id
+		return nil
+	}}
+	svc := NewPaymentMethodService(registryOf(prov), repo, stubUserQuery{})
+	require.NoError(t, svc.DeletePaymentMethod(context.Background(), "m1", "u1"))
+	require.Equal(t, "pm_card", detached)
+	require.Equal(t, "m1", deleted)
+
+	prov.detachFn = func(_ context.Context, _ string) error { return errors.New("stripe down") }
+	deleted = ""
+	require.Error(t, svc.DeletePaymentMethod(context.Background(), "m1", "u1"))
+	require.Empty(t, deleted, "a method still attached at the provider is kept")
+
+	svc = NewPaymentMethodService(registryOf(prov), &stubMethodRepo{}, stubUserQuery{})
+	requireAppError(t, svc.DeletePaymentMethod(context.Background(), "m1", "u1"), apperror.ErrNotFound)
+}
+
+func TestCreateIntent_SavedPaymentMethod(t *testing.T) {
+	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
+		return &orderModel.Order{ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPendingPayment, FinalPrice: money.New(1000, "USD")}, nil
+	}}
+	methods := &stubMethodRepo{getFn: func(_ context.Context, id, userID string) (*model.PaymentMethod, error) {
+		require.Equal(t, "m1", id)
+		require.Equal(t, "u1", userID, "only the buyer's own methods can be charged")
+		return &model.PaymentMethod{ID: "m1", Provider: "stripe", ProviderCustomerID: "cus_1", ProviderMethodID: "pm_card"}, nil
+	}}
+	repo := &stubRepo{
+		getFn:    func(_ context.Context, _ string) (*model.Payment, error) { return nil, gorm.ErrRecordNotFound },
+		createFn: func(_ context.Context, _ *model.Payment) error { return nil },
+	}
+	prov := &stubProvider{createFn: func(_ context.Context, p payment.CreateIntentParams) (*payment.Intent, error) {
+		require.Equal(t, "order_o1_m1", p.IdempotencyKey)
+		require.Equal(t, "cus_1", p.CustomerID)
+		require.Equal(t, "pm_card", p.PaymentMethodID)
+		return &payment.Intent{ID: "pi_1", Status: "processing"}, nil
+	}}
+	svc := NewPaymentService(nil, registryOf(prov), repo, &stubRefundRepo{}, methods, q, newOrderSvcMock(t), &stubCreditNotes{}, payment.CaptureAutomatic)
+
+	intent, err := svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "m1")
+	require.NoError(t, err)
+	require.Equal(t, "stripe", intent.Provider)
+	require.Equal(t, 1, repo.createCall)
+}
+
+func TestCreateIntent_OtherBuyersOrder(t *testing.T) {
+	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
+		return &orderModel.Order{ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPendingPayment, FinalPrice: money.New(1000, "USD")}, nil
+	}}
+	methods := &stubMethodRepo{getFn: func(_ context.Context, _, _ string) (*model.PaymentMethod, error) {
+		t.Fatal("no saved method is looked up for someone else's order")
+		return nil, nil
+	}}
+	prov := &stubProvider{createFn: func(context.Context, payment.CreateIntentParams) (*payment.Intent, error) {
+		t.Fatal("no intent for someone else's order")
+		return nil, nil
+	}}
+	svc := NewPaymentService(nil, registryOf(prov), &stubRepo{}, &stubRefundRepo{}, methods, q, newOrderSvcMock(t), &stubCreditNotes{}, payment.CaptureAutomatic)
+
+	_, err := svc.CreateIntentForOrder(context.Background(), "o1", "u2", "", "m1")
+	requireAppError(t, err, apperror.ErrNotFound)
+}
+
+func TestCreateIntent_SavedPaymentMethodErrors(t *testing.T) {
+	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
+		return &orderModel.Order{ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPendingPayment}, nil
+	}}
+	svc := NewPaymentService(nil, registryOf(&stubProvider{}), &stubRepo{}, &stubRefundRepo{}, &stubMethodRepo{}, q,
+		newOrderSvcMock(t), &stubCreditNotes{}, payment.CaptureAutomatic)
+	_, err := svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "m1")
+	requireAppError(t, err, apperror.ErrNotFound)
+
+	methods := &stubMethodRepo{getFn: func(_ context.Context, _, _ string) (*model.PaymentMethod, error) {
+		return &model.PaymentMethod{ID: "m1", Provider: "stripe"}, nil
+	}}
+	svc = NewPaymentService(nil, registryOf(&stubProvider{}), &stubRepo{}, &stubRefundRepo{}, methods, q,
+		newOrderSvcMock(t), &stubCreditNotes{}, payment.CaptureAutomatic)
+	_, err = svc.CreateIntentForOrder(context.Background(), "o1", "u1", "paypal", "m1")
+	requireAppError(t, err, apperror.ErrBadRequest)
+}
@@ -0,0 +1,335 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	orderModel "goshop/internal/order/model"
+	orderSvcMocks "goshop/internal/order/service/mocks"
+	"goshop/internal/payment/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/config"
+	"goshop/pkg/money"
+	"goshop/pkg/payment"
+)
+
+// providerFixture registers a card provider ("stripe", the default), a redirect provider
+// ("paypal") and an offline one ("bank_transfer") around a single pending order o1.
+type providerFixture struct {
+	svc      PaymentService
+	stripe   *stubProvider
+	paypal   *stubProvider
+	bank     *stubOffline
+	repo     *stubRepo
+	osvc     *orderSvcMocks.OrderService
+	payment  *model.Payment
+	updated  []model.Payment
+	captured []string
+}
+
+func newProviderFixture(t *testing.T) *providerFixture {
+	logger.Initialize(config.ProductionEnv)
+	f := &providerFixture{}
+	intentFor := func(id string) func(context.Context, payment.CreateIntentParams) (*payment.Intent, error) {
+		return func(_ context.Context, p payment.CreateIntentParams) (*payment.Intent, error) {
+			return &payment.Intent{ID: id, Amount: p.Amount, Currency: p.Currency}, nil
+		}
+	}
+	f.stripe = &stubProvider{createFn: intentFor("pi_1")}
+	f.paypal = &stubProvider{
+		createFn: intentFor("PP-1"),
+		captureFn: func(_ context.Context, intentID string) error {
+			f.captured = append(f.captured, intentID)
+			return nil
+		},
+	}
+	f.bank = &stubOffline{stubProvider{createFn: intentFor("manual_o1")}}
+	f.repo = &stubRepo{
+		recordFn: func(_ context.Context, _ *model.ProviderEvent) error { return nil },
+		getFn: func(_ context.Context, _ string) (*model.Payment, error) {
+			if f.payment == nil {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return f.payment, nil
+		},
+		createFn: func(_ context.Context, p *model.Payment) error {
+			f.payment = p
+			return nil
+		},
+		updateFn: func(_ context.Context, p *model.Payment) error {
+			f.updated = append(f.updated, *p)
+			return nil
+		},
+	}
+	f.osvc = newOrderSvcMock(t)
+
+	providers := payment.NewRegistry("stripe")
+	providers.Register("stripe", f.stripe)
+	providers.Register("paypal", f.paypal)
+	providers.Register("bank_transfer", f.bank)
+	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
+		return &orderModel.Order{ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPendingPayment, FinalPrice: money.New(1500, "USD")}, nil
+	}}
+	f.svc = NewPaymentService(nil, providers, f.repo, &stubRefundRepo{}, &stubMethodRepo{}, q, f.osvc, &stubCreditNotes{}, payment.CaptureAutomatic)
+	return f
+}
+
+func (f *providerFixture) webhook(event *payment.Event) {
+	verify := func(_ []byte, _ http.Header) (*payment.Event, error) { return event, nil }
+	f.stripe.verifyFn = verify
+	f.paypal.verifyFn = verify
+}
+
+func TestCreateIntent_DefaultAndChosenProvider(t *testing.T) {
+	f := newProviderFixture(t)
+	intent, err := f.svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "pi_1", intent.ID)
+	require.Equal(t, "stripe", f.payment.Provider)
+
+	f = newProviderFixture(t)
+	intent, err = f.svc.CreateIntentForOrder(context.Background(), "o1", "u1", "paypal", "")
+	require.NoError(t, err)
+	require.Equal(t, "PP-1", intent.ID)
+	require.Equal(t, "paypal", f.payment.Provider)
+	require.Equal(t, "PP-1", f.payment.ProviderIntentID)
+}
+
+func TestCreateIntent_ChargesInOrderCurrency(t *testing.T) {
+	tests := []struct {
+		name       string
+		currency   string
+		finalPrice money.Money
+		wantAmount int64
+		wantCode   string
+	}{
+		{name: "legacy_order_is_usd", finalPrice: money.New(29, ""), wantAmount: 29, wantCode: "usd"},
+		{name: "euro", currency: "EUR", finalPrice: money.New(5517, "EUR"), wantAmount: 5517, wantCode: "eur"},
+		{name: "yen", currency: "JPY", finalPrice: money.New(6056, "JPY"), wantAmount: 6056, wantCode: "jpy"},
+		{name: "dinar", currency: "KWD", finalPrice: money.New(12294, "KWD"), wantAmount: 12294, wantCode: "kwd"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newProviderFixture(t)
+			q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
+				return &orderModel.Order{
+					ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPendingPayment, Currency: tt.currency, FinalPrice: tt.finalPrice,
+				}, nil
+			}}
+			f.svc = NewPaymentService(nil, registryOf(f.stripe), f.repo, &stubRefundRepo{}, &stubMethodRepo{}, q, f.osvc, &stubCreditNotes{}, payment.CaptureAutomatic)
+
+			intent, err := f.svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "")
+			require.NoError(t, err)
+			require.Equal(t, tt.wantAmount, intent.Amount)
+			require.Equal(t, tt.wantCode, intent.Currency)
+			require.Equal(t, tt.wantAmount, f.payment.Amount)
+			require.Equal(t, tt.wantCode, f.payment.Currency)
+		})
+	}
+}
+
+func TestCreateIntent_UnknownProvider(t *testing.T) {
+	f := newProviderFixture(t)
+	_, err := f.svc.CreateIntentForOrder(context.Background(), "o1", "u1", "bitcoin", "")
+	requireAppError(t, err, apperror.ErrBadRequest)
+	require.ErrorIs(t, err, payment.ErrUnknownProvider)
+}
+
+func TestCreateIntent_SwitchingProviderSupersedesIntent(t *testing.T) {
+	f := newProviderFixture(t)
+	f.payment = &model.Payment{ID: "p1", OrderID: "o1", Provider: "stripe", ProviderIntentID: "pi_1", Status: model.PaymentStatusRequiresAction}
+
+	_, err := f.svc.CreateIntentForOrder(context.Background(), "o1", "u1", "paypal", "")
+	require.NoError(t, err)
+	require.Len(t, f.updated, 1)
+	require.Equal(t, "paypal", f.updated[0].Provider)
+	require.Equal(t, "PP-1", f.updated[0].ProviderIntentID)
+	require.Equal(t, model.PaymentStatusPending, f.updated[0].Status)
+	require.Equal(t, int64(1500), f.updated[0].Amount)
+}
+
+func TestCreateIntent_SwitchingProviderAfterMoneyMovedConflicts(t *testing.T) {
+	for _, status := range []model.PaymentStatus{model.PaymentStatusProcessing, model.PaymentStatusSucceeded} {
+		t.Run(string(status), func(t *testing.T) {
+			f := newProviderFixture(t)
+			f.payment = &model.Payment{ID: "p1", OrderID: "o1", Provider: "paypal", ProviderIntentID: "PP-1", Status: status}
+
+			_, err := f.svc.CreateIntentForOrder(context.Background(), "o1", "u1", "stripe", "")
+			requireAppError(t, err, apperror.ErrConflict)
+			require.Empty(t, f.updated)
+		})
+	}
+}
+
+func TestCreateIntent_OfflineProviderExtendsReservation(t *testing.T) {
+	f := newProviderFixture(t)
+	until := time.Now().Add(72 * time.Hour)
+	f.bank.createFn = func(_ context.Context, p payment.CreateIntentParams) (*payment.Intent, error) {
+		return &payment.Intent{ID: "manual_o1", Instructions: "pay o1", ExpiresAt: until}, nil
+	}
+	f.osvc.On("ExtendReservations", mock.Anything, "o1", until).Return(nil).Once()
+
+	intent, err := f.svc.CreateIntentForOrder(context.Background(), "o1", "u1", "bank_transfer", "")
+	require.NoError(t, err)
+	require.Equal(t, "pay o1", intent.Instructions)
+	require.Equal(t, "bank_transfer", f.payment.Provider)
+}
+
+func TestCreateIntent_ExtendReservationsError(t *testing.T) {
+	f := newProviderFixture(t)
+	f.bank.createFn = func(_ context.Context, _ payment.CreateIntentParams) (*payment.Intent, error) {
+		return &payment.Intent{ID: "manual_o1", ExpiresAt: time.Now().Add(time.Hour)}, nil
+	}
+	f.osvc.On("ExtendReservations", mock.Anything, "o1", mock.Anything).Return(apperror.ErrInvalidStatus).Once()
+
+	_, err := f.svc.CreateIntentForOrder(context.Background(), "o1", "u1", "bank_transfer", "")
+	require.ErrorIs(t, err, apperror.ErrInvalidStatus)
+	require.Nil(t, f.payment)
+}
+
+func TestHandleWebhook_UnknownProvider(t *testing.T) {
+	f := newProviderFixture(t)
+	err := f.svc.HandleWebhook(context.Background(), "bitcoin", nil, nil)
+	require.ErrorIs(t, err, payment.ErrUnknownProvider)
+}
+
+func TestHandleWebhook_ApprovedCapturesPayment(t *testing.T) {
+	f := newProviderFixture(t)
+	f.payment = &model.Payment{ID: "p1", OrderID: "o1", Provider: "paypal", ProviderIntentID: "PP-1", Status: model.PaymentStatusPending}
+	f.webhook(&payment.Event{ID: "WH-1", Type: payment.EventPaymentApproved, OrderID: "o1", PaymentIntentID: "PP-1"})
+
+	require.NoError(t, f.svc.HandleWebhook(context.Background(), "paypal", nil, nil))
+	require.Equal(t, []string{"PP-1"}, f.captured)
+	require.Len(t, f.updated, 1)
+	require.Equal(t, model.PaymentStatusProcessing, f.updated[0].Status)
+}
+
+func TestHandleWebhook_ApprovedCaptureError(t *testing.T) {
+	f := newProviderFixture(t)
+	f.payment = &model.Payment{ID: "p1", OrderID: "o1", Provider: "paypal", ProviderIntentID: "PP-1", Status: model.PaymentStatusPending}
+	f.paypal.captureFn = func(_ context.Context, _ string) error { return errors.New("paypal down") }
+	f.webhook(&payment.Event{ID: "WH-1", Type: payment.EventPaymentApproved, OrderID: "o1", PaymentIntentID: "PP-1"})
+
+	require.NoError(t, f.svc.HandleWebhook(context.Background(), "paypal", nil, nil))
+	require.Empty(t, f.updated)
+	requireRetryPending(t, f.repo)
+}
+
+func TestHandleWebhook_ApprovedCaptureNotSupportedIgnored(t *testing.T) {
+	f := newProviderFixture(t)
+	f.payment = &model.Payment{ID: "p1", OrderID: "o1", Provider: "stripe", ProviderIntentID: "pi_1", Status: model.PaymentStatusPending}
+	f.webhook(&payment.Event{ID: "evt", Type: payment.EventPaymentApproved, OrderID: "o1", PaymentIntentID: "pi_1"})
+
+	require.NoError(t, f.svc.HandleWebhook(context.Background(), "stripe", nil, nil))
+	require.Empty(t, f.updated)
+}
+
+func TestHandleWebhook_SupersededIntent(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   model.PaymentStatus
+		event    payment.EventType
+		wantPaid bool
+	}{
+		// The customer switched to PayPal, so the abandoned Stripe intent's cancel mustn't
+		// cancel the order.
+		{name: "cancel_ignored", status: model.PaymentStatusPending, event: payment.EventPaymentCanceled},
+		{name: "failure_ignored", status: model.PaymentStatusPending, event: payment.EventPaymentFailed},
+		// They paid through the old intent after all: take it.
+		{name: "success_adopted", status: model.PaymentStatusPending, event: payment.EventPaymentSucceeded, wantPaid: true},
+		// Paid twice; the second charge needs a manual refund.
+		{name: "success_after_paid_ignored", status: model.PaymentStatusSucceeded, event: payment.EventPaymentSucceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newProviderFixture(t)
+			f.payment = &model.Payment{ID: "p1", OrderID: "o1", Provider: "paypal", ProviderIntentID: "PP-1", Status: tt.status}
+			f.webhook(&payment.Event{ID: "evt", Type: tt.event, OrderID: "o1", PaymentIntentID: "pi_old"})
+			if tt.wantPaid {
+				f.osvc.On("MarkOrderPaid", mock.Anything, "o1").Return(&orderModel.Order{}, nil).Once()
+			}
+
+			require.NoError(t, f.svc.HandleWebhook(context.Background(), "stripe", nil, nil))
+			if !tt.wantPaid {
+				require.Empty(t, f.updated)
+				return
+			}
+			require.Len(t, f.updated, 1)
+			require.Equal(t, "stripe", f.updated[0].Provider)
+			require.Equal(t, "pi_old", f.updated[0].ProviderIntentID)
+			require.Equal(t, model.PaymentStatusSucceeded, f.updated[0].Status)
+		})
+	}
+}
+
+func TestConfirmPayment(t *testing.T) {
+	f := newProviderFixture(t)
+	f.payment = &model.Payment{ID: "p1", OrderID: "o1", Provider: "bank_transfer", ProviderIntentID: "manual_o1", Status: model.PaymentStatusPending}
+	f.osvc.On("MarkOrderPaid", mock.Anything, "o1").Return(&orderModel.Order{}, nil).Once()
+
+	rec, err := f.svc.ConfirmPayment(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Equal(t, model.PaymentStatusSucceeded, rec.Status)
+	require.Len(t, f.updated, 1)
+
+	// Confirming again is a no-op.
+	_, err = f.svc.ConfirmPayment(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Len(t, f.updated, 1)
+}
+
+func TestConfirmPayment_Rejected(t *testing.T) {
+	tests := []struct {
+		name    string
+		payment *model.Payment
+		wantErr error
+	}{
+		{name: "no_payment", wantErr: apperror.ErrNotFound},
+		{
+			name:    "online_provider",
+			payment: &model.Payment{OrderID: "o1", Provider: "stripe", Status: model.PaymentStatusPending},
+			wantErr: apperror.ErrBadRequest,
+		},
+		{
+			name:    "canceled",
+			payment: &model.Payment{OrderID: "o1", Provider: "bank_transfer", Status: model.PaymentStatusCanceled},
+			wantErr: apperror.ErrInvalidStatus,
+		},
+		{
+			name:    "unknown_provider",
+			payment: &model.Payment{OrderID: "o1", Provider: "retired", Status: model.PaymentStatusPending},
+			wantErr: payment.ErrUnknownProvider,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newProviderFixture(t)
+			f.payment = tt.payment
+			_, err := f.svc.ConfirmPayment(context.Background(), "o1")
+			if want, ok := tt.wantErr.(*apperror.AppError); ok {
+				requireAppError(t, err, want)
+			} else {
+				require.ErrorIs(t, err, tt.wantErr)
+			}
+			require.Empty(t, f.updated)
+		})
+	}
+}
+
+func TestConfirmPayment_OrderNoLongerPayable(t *testing.T) {
+	f := newProviderFixture(t)
+	f.payment = &model.Payment{ID: "p1", OrderID: "o1", Provider: "bank_transfer", Status: model.PaymentStatusPending}
+	f.osvc.On("MarkOrderPaid", mock.Anything, "o1").Return(nil, apperror.ErrInvalidStatus).Once()
+
+	_, err := f.svc.ConfirmPayment(context.Background(), "o1")
+	require.ErrorIs(t, err, apperror.ErrInvalidStatus)
+	require.Empty(t, f.updated)
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+
+	orderService "goshop/internal/order/service"
+	"goshop/internal/payment/repository"
+	"goshop/pkg/config"
+	"goshop/pkg/dbs"
+	"goshop/pkg/payment"
+	manualProvider "goshop/pkg/payment/manual"
+	paypalProvider "goshop/pkg/payment/paypal"
+	stripeProvider "goshop/pkg/payment/stripe"
+)
+
+// NewProviders registers Stripe, plus PayPal and bank transfer when they're configured.
+func NewProviders(cfg *config.Schema) *payment.Registry {
+	providers := payment.NewRegistry(cfg.DefaultPaymentProvider)
+	providers.Register(stripeProvider.Name, stripeProvider.NewProvider(stripeProvider.Config{
+		SecretKey:     cfg.StripeSecretKey,
+		WebhookSecret: cfg.StripeWebhookSecret,
+		APIBase:       cfg.StripeAPIBase,
+	}))
+	if cfg.PayPalClientID != "" {
+		providers.Register(paypalProvider.Name, paypalProvider.NewProvider(paypalProvider.Config{
+			ClientID:     cfg.PayPalClientID,
+			ClientSecret: cfg.PayPalClientSecret,
+			WebhookID:    cfg.PayPalWebhookID,
+			ReturnURL:    cfg.PayPalReturnURL,
+			CancelURL:    cfg.PayPalCancelURL,
+			APIBase:      cfg.PayPalAPIBase,
+		}))
+	}
+	if cfg.BankTransferInstructions != "" {
+		providers.Register(manualProvider.Name, manualProvider.NewProvider(manualProvider.Config{
+			Instructions: cfg.BankTransferInstructions,
+			HoldFor:      time.Duration(cfg.ManualPaymentHoldHours) * time.Hour,
+		}))
+	}
+	if _, err := providers.Get(""); err != nil {
+		logger.Errorf("default_payment_provider is not configured: %s", err)
+	}
+	return providers
+}
+
+// NewSettler builds the PaymentSettler an OrderService uses to capture and void authorized
+// payments, with the configured providers.
+func NewSettler(cfg *config.Schema, db dbs.Database) orderService.PaymentSettler {
+	return NewPaymentSettler(NewProviders(cfg), repository.NewPaymentRepository(db))
+}
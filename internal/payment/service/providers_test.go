@@ -0,0 +1,23 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"goshop/pkg/config"
+)
+
+func TestNewProviders(t *testing.T) {
+	providers := NewProviders(&config.Schema{DefaultPaymentProvider: "stripe"})
+	require.Equal(t, []string{"stripe"}, providers.Names())
+
+	providers = NewProviders(&config.Schema{
+		DefaultPaymentProvider:   "paypal",
+		PayPalClientID:           "client",
+		BankTransferInstructions: "Pay to IBAN DE00 quoting {reference}",
+		ManualPaymentHoldHours:   72,
+	})
+	require.Equal(t, []string{"bank_transfer", "paypal", "stripe"}, providers.Names())
+	require.Equal(t, "paypal", providers.DefaultName())
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"gorm.io/gorm"
+
+	"goshop/internal/payment/domain"
+	"goshop/internal/payment/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/paging"
+	"goshop/pkg/payment"
+)
+
+// defaultReconcileLimit caps one reconciliation pass when ReconcileRequest.Limit is unset.
+const defaultReconcileLimit = 100
+
+// ReconcileRequest selects the payments to reconcile: those still waiting on the provider
+// that haven't changed for OlderThan. DryRun reports what would change without applying it.
+type ReconcileRequest struct {
+	OlderThan time.Duration
+	Limit     int
+	DryRun    bool
+}
+
+// ReconcileAction is what reconciliation did with one payment.
+type ReconcileAction string
+
+const (
+	ReconcileUpdated   ReconcileAction = "updated"
+	ReconcileUnchanged ReconcileAction = "unchanged"
+	ReconcileSkipped   ReconcileAction = "skipped"
+	ReconcileFailed    ReconcileAction = "failed"
+)
+
+// ReconcileEntry is one checked payment. ProviderStatus is a payment.IntentStatus* value;
+// Detail says why the payment was skipped or failed.
+type ReconcileEntry struct {
+	PaymentID      string
+	OrderID        string
+	Provider       string
+	IntentID       string
+	LocalStatus    model.PaymentStatus
+	ProviderStatus string
+	Action         ReconcileAction
+	Detail         string
+}
+
+// ReconcileReport summarizes a reconciliation pass. In a dry run Updated counts the
+// payments that would have been updated. RunID is the stored model.ReconcileRun; dry runs
+// and passes that found nothing to check aren't stored.
+type ReconcileReport struct {
+	RunID   string
+	DryRun  bool
+	Checked int
+	Updated int
+	Failed  int
+	Entries []ReconcileEntry
+}
+
+// reconcileEvents maps the provider's intent status onto the webhook event that reports it.
+// Pending intents have no event: the customer hasn't paid yet.
+var reconcileEvents = map[string]payment.EventType{
+	payment.IntentStatusApproved:       payment.EventPaymentApproved,
+	payment.IntentStatusAuthorized:     payment.EventPaymentAuthorized,
+	payment.IntentStatusSucceeded:      payment.EventPaymentSucceeded,
+	payment.IntentStatusFailed:         payment.EventPaymentFailed,
+	payment.IntentStatusCanceled:       payment.EventPaymentCanceled,
+	payment.IntentStatusProcessing:     payment.EventPaymentProcessing,
+	payment.IntentStatusRequiresAction: payment.EventPaymentRequiresAction,
+}
+
+func (s *paymentService) ReconcilePayments(ctx context.Context, req ReconcileRequest) (*ReconcileReport, error) {
+	limit := req.Limit
+	if limit <= 0 {
+		limit = defaultReconcileLimit
+	}
+	recs, err := s.repo.ListUnsettled(ctx, s.now().Add(-req.OlderThan), limit)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ReconcileReport{DryRun: req.DryRun, Checked: len(recs)}
+	for _, rec := range recs {
+		entry := s.reconcilePayment(ctx, rec, req.DryRun)
+		switch entry.Action {
+		case ReconcileUpdated:
+			report.Updated++
+		case ReconcileFailed:
+			report.Failed++
+		}
+		report.Entries = append(report.Entries, entry)
+	}
+	if req.DryRun || report.Checked == 0 {
+		return report, nil
+	}
+	// The payments have been updated by now, so a run that can't be stored is logged rather
+	// than reported as a failed pass.
+	run := reconcileRun(report)
+	if err := s.repo.CreateReconcileRun(ctx, run); err != nil {
+		logger.Errorf("store payment reconcile run: %s", err)
+		return report, nil
+	}
+	report.RunID = run.ID
+	return report, nil
+}
+
+// reconcileRun is the record of report kept in the database.
+func reconcileRun(report *ReconcileReport) *model.ReconcileRun {
+	run := &model.ReconcileRun{Checked: report.Checked, Updated: report.Updated, Failed: report.Failed}
+	for _, e := range report.Entries {
+		run.Entries = append(run.Entries, &model.ReconcileRunEntry{
+			PaymentID:      e.PaymentID,
+			OrderID:        e.OrderID,
+			Provider:       e.Provider,
+			IntentID:       e.IntentID,
+			LocalStatus:    e.LocalStatus,
+			ProviderStatus: e.ProviderStatus,
+			Action:         string(e.Action),
+			Detail:         e.Detail,
+		})
+	}
+	return run
+}
+
+func (s *paymentService) ListReconcileRuns(ctx context.Context, req *domain.ListReconcileRunsReq) ([]*model.ReconcileRun, *paging.Pagination, error) {
+	return s.repo.ListReconcileRuns(ctx, req)
+}
+
+func (s *paymentService) GetReconcileRun(ctx context.Context, id string) (*model.ReconcileRun, error) {
+	run, err := s.repo.GetReconcileRun(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, err, "reconcile run not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	return run, nil
+}
+
+// reconcilePayment fetches the payment's intent from its provider and, if the provider has
+// moved on, applies the transition the missed webhook would have.
+func (s *paymentService) reconcilePayment(ctx context.Context, rec *model.Payment, dryRun bool) ReconcileEntry {
+	entry := ReconcileEntry{
+		PaymentID:   rec.ID,
+		OrderID:     rec.OrderID,
+		Provider:    rec.Provider,
+		IntentID:    rec.ProviderIntentID,
+		LocalStatus: rec.Status,
+	}
+	provider, err := s.providers.Get(rec.Provider)
+	if err != nil {
+		entry.Action, entry.Detail = ReconcileSkipped, "provider not configured"
+		return entry
+	}
+	if _, ok := provider.(payment.Offline); ok {
+		entry.Action, entry.Detail = ReconcileSkipped, "confirmed by an admin"
+		return entry
+	}
+	if rec.ProviderIntentID == "" {
+		entry.Action, entry.Detail = ReconcileSkipped, "no provider intent"
+		return entry
+	}
+
+	intent, err := provider.GetIntent(ctx, rec.ProviderIntentID)
+	if err != nil {
+		entry.Action, entry.Detail = ReconcileFailed, err.Error()
+		return entry
+	}
+	entry.ProviderStatus = intent.Status
+	eventType, ok := reconcileEvents[intent.Status]
+	if !ok || string(rec.Status) == intent.Status {
+		entry.Action = ReconcileUnchanged
+		return entry
+	}
+
+	entry.Action = ReconcileUpdated
+	if dryRun {
+		return entry
+	}
+	event := &payment.Event{Type: eventType, OrderID: rec.OrderID, PaymentIntentID: rec.ProviderIntentID}
+	if err := s.applyPaymentEvent(ctx, provider, rec, event); err != nil {
+		entry.Action, entry.Detail = ReconcileFailed, err.Error()
+	}
+	return entry
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	orderModel "goshop/internal/order/model"
+	orderSvcMocks "goshop/internal/order/service/mocks"
+	"goshop/internal/payment/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/payment"
+	stripeProvider "goshop/pkg/payment/stripe"
+)
+
+// fakeStripe serves GET /v1/payment_intents/{id} from intents, a map of intent ID to the
+// PaymentIntent JSON Stripe would return. Unknown intents get Stripe's 404.
+func fakeStripe(t *testing.T, intents map[string]string) *stripeProvider.Provider {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodGet, r.Method)
+		require.Equal(t, "Bearer sk_test", r.Header.Get("Authorization"))
+		body, ok := intents[strings.TrimPrefix(r.URL.Path, "/v1/payment_intents/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = io.WriteString(w, `{"error":{"type":"invalid_request_error","code":"resource_missing"}}`)
+			return
+		}
+		_, _ = io.WriteString(w, body)
+	}))
+	t.Cleanup(srv.Close)
+	return stripeProvider.NewProvider(stripeProvider.Config{SecretKey: "sk_test", APIBase: srv.URL})
+}
+
+// newReconcileSvc reconciles recs against providers, recording status updates in updated.
+func newReconcileSvc(t *testing.T, providers *payment.Registry, recs ...*model.Payment) (*paymentService, *orderSvcMocks.OrderService, map[string]model.PaymentStatus) {
+	t.Helper()
+	updated := map[string]model.PaymentStatus{}
+	repo := &stubRepo{
+		listFn: func(_ context.Context, _ time.Time, _ int) ([]*model.Payment, error) { return recs, nil },
+		updateFn: func(_ context.Context, p *model.Payment) error {
+			updated[p.ID] = p.Status
+			return nil
+		},
+	}
+	osvc := newOrderSvcMock(t)
+	svc := NewPaymentService(nil, providers, repo, &stubRefundRepo{}, &stubMethodRepo{}, &stubOrderQuery{}, osvc, &stubCreditNotes{}, payment.CaptureAutomatic).(*paymentService)
+	svc.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return svc, osvc, updated
+}
+
+func stripePayment(id, intentID string, status model.PaymentStatus) *model.Payment {
+	return &model.Payment{ID: id, OrderID: "o_" + id, Provider: "stripe", ProviderIntentID: intentID, Status: status}
+}
+
+func TestReconcilePayments_AppliesProviderState(t *testing.T) {
+	stripe := fakeStripe(t, map[string]string{
+		"pi_paid":    `{"id":"pi_paid","status":"succeeded"}`,
+		"pi_failed":  `{"id":"pi_failed","status":"requires_payment_method","last_payment_error":{"code":"card_declined"}}`,
+		"pi_slow":    `{"id":"pi_slow","status":"processing"}`,
+		"pi_waiting": `{"id":"pi_waiting","status":"requires_payment_method"}`,
+		"pi_same":    `{"id":"pi_same","status":"processing"}`,
+	})
+	svc, osvc, updated := newReconcileSvc(t, registryOf(stripe),
+		stripePayment("p1", "pi_paid", model.PaymentStatusProcessing),
+		stripePayment("p2", "pi_failed", model.PaymentStatusPending),
+		stripePayment("p3", "pi_slow", model.PaymentStatusPending),
+		stripePayment("p4", "pi_waiting", model.PaymentStatusPending),
+		stripePayment("p5", "pi_same", model.PaymentStatusProcessing),
+		stripePayment("p6", "pi_gone", model.PaymentStatusPending),
+	)
+	osvc.On("MarkOrderPaid", mock.Anything, "o_p1").Return(&orderModel.Order{}, nil).Once()
+	osvc.On("UpdateOrderStatus", mock.Anything, "o_p2", orderModel.OrderStatusPaymentFailed).Return(&orderModel.Order{}, nil).Once()
+
+	report, err := svc.ReconcilePayments(context.Background(), ReconcileRequest{OlderThan: 30 * time.Minute})
+	require.NoError(t, err)
+	require.Equal(t, 6, report.Checked)
+	require.Equal(t, 3, report.Updated)
+	require.Equal(t, 1, report.Failed)
+
+	actions := map[string]ReconcileAction{}
+	for _, e := range report.Entries {
+		actions[e.PaymentID] = e.Action
+	}
+	require.Equal(t, map[string]ReconcileAction{
+		"p1": ReconcileUpdated, "p2": ReconcileUpdated, "p3": ReconcileUpdated,
+		"p4": ReconcileUnchanged, "p5": ReconcileUnchanged, "p6": ReconcileFailed,
+	}, actions)
+	require.Equal(t, map[string]model.PaymentStatus{
+		"p1": model.PaymentStatusSucceeded,
+		"p2": model.PaymentStatusFailed,
+		"p3": model.PaymentStatusProcessing,
+	}, updated)
+	require.Contains(t, report.Entries[5].Detail, "status=404")
+
+	// The run is stored with every payment it checked.
+	runs := svc.repo.(*stubRepo).runs
+	require.Len(t, runs, 1)
+	require.Equal(t, runs[0].ID, report.RunID)
+	require.Equal(t, 6, runs[0].Checked)
+	require.Equal(t, 3, runs[0].Updated)
+	require.Equal(t, 1, runs[0].Failed)
+	require.Len(t, runs[0].Entries, 6)
+	require.Equal(t, &model.ReconcileRunEntry{
+		PaymentID: "p1", OrderID: "o_p1", Provider: "stripe", IntentID: "pi_paid",
+		LocalStatus: model.PaymentStatusProcessing, ProviderStatus: payment.IntentStatusSucceeded, Action: "updated",
+	}, runs[0].Entries[0])
+	require.Equal(t, "failed", runs[0].Entries[5].Action)
+	require.Contains(t, runs[0].Entries[5].Detail, "status=404")
+}
+
+func TestReconcilePayments_StoreErrorKeepsReport(t *testing.T) {
+	stripe := fakeStripe(t, map[string]string{"pi_same": `{"id":"pi_same","status":"processing"}`})
+	svc, _, _ := newReconcileSvc(t, registryOf(stripe), stripePayment("p1", "pi_same", model.PaymentStatusProcessing))
+	svc.repo.(*stubRepo).runErr = errors.New("db down")
+
+	report, err := svc.ReconcilePayments(context.Background(), ReconcileRequest{})
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Checked)
+	require.Empty(t, report.RunID)
+}
+
+func TestGetReconcileRun(t *testing.T) {
+	svc, _, _ := newReconcileSvc(t, registryOf(fakeStripe(t, nil)))
+	svc.repo.(*stubRepo).runs = []*model.ReconcileRun{{ID: "run1", Checked: 1}}
+
+	run, err := svc.GetReconcileRun(context.Background(), "run1")
+	require.NoError(t, err)
+	require.Equal(t, 1, run.Checked)
+
+	_, err = svc.GetReconcileRun(context.Background(), "run2")
+	requireAppError(t, err, apperror.ErrNotFound)
+}
+
+func TestReconcilePayments_DryRunChangesNothing(t *testing.T) {
+	stripe := fakeStripe(t, map[string]string{"pi_paid": `{"id":"pi_paid","status":"succeeded"}`})
+	svc, _, updated := newReconcileSvc(t, registryOf(stripe), stripePayment("p1", "pi_paid", model.PaymentStatusPending))
+
+	report, err := svc.ReconcilePayments(context.Background(), ReconcileRequest{DryRun: true})
+	require.NoError(t, err)
+	require.True(t, report.DryRun)
+	require.Equal(t, 1, report.Updated)
+	require.Equal(t, payment.IntentStatusSucceeded, report.Entries[0].ProviderStatus)
+	require.Empty(t, updated)
+	require.Empty(t, svc.repo.(*stubRepo).runs)
+	require.Empty(t, report.RunID)
+}
+
+func TestReconcilePayments_SkipsOfflineAndUnknownProviders(t *testing.T) {
+	providers := registryOf(fakeStripe(t, nil))
+	providers.Register("bank_transfer", &stubOffline{})
+	svc, _, _ := newReconcileSvc(t, providers,
+		&model.Payment{ID: "p1", Provider: "bank_transfer", ProviderIntentID: "manual_o1", Status: model.PaymentStatusRequiresAction},
+		&model.Payment{ID: "p2", Provider: "retired", ProviderIntentID: "x_1", Status: model.PaymentStatusPending},
+		&model.Payment{ID: "p3", Provider: "stripe", Status: model.PaymentStatusPending},
+	)
+
+	report, err := svc.ReconcilePayments(context.Background(), ReconcileRequest{})
+	require.NoError(t, err)
+	for _, e := range report.Entries {
+		require.Equal(t, ReconcileSkipped, e.Action, e.PaymentID)
+	}
+	require.Zero(t, report.Updated)
+}
+
+func TestReconcilePayments_ListsStalePayments(t *testing.T) {
+	svc, _, _ := newReconcileSvc(t, registryOf(fakeStripe(t, nil)))
+	var gotBefore time.Time
+	var gotLimit int
+	svc.repo.(*stubRepo).listFn = func(_ context.Context, before time.Time, limit int) ([]*model.Payment, error) {
+		gotBefore, gotLimit = before, limit
+		return nil, nil
+	}
+
+	_, err := svc.ReconcilePayments(context.Background(), ReconcileRequest{OlderThan: time.Hour})
+	require.NoError(t, err)
+	require.Equal(t, time.Unix(1700000000, 0).Add(-time.Hour), gotBefore)
+	require.Equal(t, defaultReconcileLimit, gotLimit)
+	// Nothing was due, so there's no run to keep.
+	require.Empty(t, svc.repo.(*stubRepo).runs)
+
+	svc.repo.(*stubRepo).listFn = func(context.Context, time.Time, int) ([]*model.Payment, error) {
+		return nil, errors.New("db down")
+	}
+	_, err = svc.ReconcilePayments(context.Background(), ReconcileRequest{})
+	require.Error(t, err)
+}
+
+func TestReconcilePayments_AuthorizedIntentMarksOrderAuthorized(t *testing.T) {
+	stripe := fakeStripe(t, map[string]string{
+		"pi_held": `{"id":"pi_held","status":"requires_capture"}`,
+	})
+	svc, osvc, updated := newReconcileSvc(t, registryOf(stripe), stripePayment("p1", "pi_held", model.PaymentStatusPending))
+	osvc.On("MarkOrderAuthorized", mock.Anything, "o_p1").Return(&orderModel.Order{}, nil).Once()
+
+	report, err := svc.ReconcilePayments(context.Background(), ReconcileRequest{OlderThan: 30 * time.Minute})
+	require.NoError(t, err)
+	require.Equal(t, 1, report.Updated)
+	require.Equal(t, map[string]model.PaymentStatus{"p1": model.PaymentStatusAuthorized}, updated)
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/quangdangfit/gocommon/logger"
+	"gorm.io/gorm"
+
+	orderDomain "goshop/internal/order/domain"
+	orderModel "goshop/internal/order/model"
+	"goshop/internal/payment/model"
+	"goshop/internal/payment/repository"
+	"goshop/pkg/apperror"
+	"goshop/pkg/payment"
+)
+
+// RefundLine selects units of one order line to refund.
+type RefundLine struct {
+	OrderLineID string
+	Quantity    uint
+}
+
+// RefundRequest describes an admin refund. Without Lines, whatever is left on the payment is
+// refunded. A retry with the same IdempotencyKey returns the original refund.
+type RefundRequest struct {
+	Lines          []RefundLine
+	Reason         string
+	IdempotencyKey string
+	ActorID        string
+}
+
+func (s *paymentService) RefundOrder(ctx context.Context, orderID string, req RefundRequest) (*model.Refund, error) {
+	if req.IdempotencyKey != "" {
+		existing, err := s.refunds.GetByIdempotencyKey(ctx, req.IdempotencyKey)
+		if err == nil {
+			if existing.OrderID != orderID {
+				return nil, apperror.WrapMessage(apperror.ErrConflict, nil, "idempotency key already used for another order")
+			}
+			return existing, nil
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, err
+		}
+	}
+
+	order, err := s.orderQuery.GetOrderByID(ctx, orderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, err, "order not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	rec, err := s.repo.GetByOrderID(ctx, orderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, err, "order has no payment to refund")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if rec.Status != model.PaymentStatusSucceeded && rec.Status != model.PaymentStatusPartiallyRefunded {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil,
+			fmt.Sprintf("payment can't be refunded in status %s", rec.Status))
+	}
+	if rec.Provider == model.ProviderCashOnDelivery {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "cash on delivery payments are refunded in cash")
+	}
+	provider, err := s.providers.Get(rec.Provider)
+	if err != nil {
+		return nil, err
+	}
+
+	refund := &model.Refund{
+		ID:             uuid.New().String(),
+		PaymentID:      rec.ID,
+		OrderID:        orderID,
+		IdempotencyKey: req.IdempotencyKey,
+		Currency:       rec.Currency,
+		Status:         model.RefundStatusPending,
+		Reason:         req.Reason,
+		ActorID:        req.ActorID,
+	}
+	if refund.IdempotencyKey == "" {
+		refund.IdempotencyKey = "refund_" + refund.ID
+	}
+	linesByID := make(map[string]*orderModel.OrderLine, len(order.Lines))
+	for _, line := range order.Lines {
+		linesByID[line.ID] = line
+	}
+	if len(req.Lines) == 0 {
+		refund.Amount = rec.Refundable()
+	} else {
+		refund.Lines, refund.Amount, err = refundLines(order, linesByID, req.Lines)
+		if err != nil {
+			return nil, err
+		}
+		// Rounding per line can overshoot the captured amount by a cent on the last lines.
+		refund.Amount = min(refund.Amount, rec.Refundable())
+	}
+	if refund.Amount <= 0 {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "nothing left to refund on this payment")
+	}
+
+	// Count the refund against the payment before asking the provider, so concurrent refunds
+	// can't exceed it. AddRefunded locks the payment row, which also serializes the line check.
+	err = s.db.WithTransaction(func() error {
+		if err := s.repo.AddRefunded(ctx, rec.ID, refund.Amount); err != nil {
+			return err
+		}
+		if len(refund.Lines) > 0 {
+			refunded, err := s.refunds.RefundedQuantities(ctx, orderID)
+			if err != nil {
+				return err
+			}
+			for _, line := range refund.Lines {
+				if refunded[line.OrderLineID]+line.Quantity > linesByID[line.OrderLineID].Quantity {
+					return apperror.WrapMessage(apperror.ErrBadRequest, nil,
+						fmt.Sprintf("order line %s has fewer than %d units left to refund", line.OrderLineID, line.Quantity))
+				}
+			}
+		}
+		return s.refunds.Create(ctx, refund)
+	})
+	if errors.Is(err, repository.ErrRefundExceedsPayment) {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, err, "refund exceeds the amount left on the payment")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := provider.Refund(ctx, payment.RefundParams{
+		PaymentIntentID: rec.ProviderIntentID,
+		Amount:          refund.Amount,
+		OrderID:         orderID,
+		RefundID:        refund.ID,
+		Reason:          req.Reason,
+		IdempotencyKey:  "refund_" + refund.ID,
+	})
+	if err != nil {
+		// If the provider did refund after all, its refund.updated webhook finds this row by
+		// reference and counts it again.
+		if settleErr := s.settleRefund(ctx, refund, &payment.Refund{Status: payment.RefundStatusFailed}); settleErr != nil {
+			logger.Errorf("RefundOrder: failed to release refund %s: %s", refund.ID, settleErr)
+		}
+		return nil, fmt.Errorf("refund %s: %w", refund.ID, err)
+	}
+	if err := s.settleRefund(ctx, refund, result); err != nil {
+		return nil, err
+	}
+	return refund, nil
+}
+
+func (s *paymentService) ListRefunds(ctx context.Context, orderID string) ([]*model.Refund, error) {
+	return s.refunds.ListByOrderID(ctx, orderID)
+}
+
+// refundLines prices the requested units of each order line as the buyer paid for them: their
+// share of the line after the order's discount, plus their tax when prices exclude it. Amounts
+// are worked out exactly in minor units and rounded once per line.
+func refundLines(
+	order *orderModel.Order,
+	linesByID map[string]*orderModel.OrderLine,
+	reqLines []RefundLine,
+) ([]*model.RefundLine, int64, error) {
+	lines := make([]*model.RefundLine, 0, len(reqLines))
+	seen := make(map[string]struct{}, len(reqLines))
+	var total int64
+	for _, req := range reqLines {
+		line, ok := linesByID[req.OrderLineID]
+		if !ok {
+			return nil, 0, apperror.WrapMessage(apperror.ErrBadRequest, nil,
+				fmt.Sprintf("order line %s is not on this order", req.OrderLineID))
+		}
+		if _, dup := seen[req.OrderLineID]; dup {
+			return nil, 0, apperror.WrapMessage(apperror.ErrBadRequest, nil,
+				fmt.Sprintf("order line %s is listed more than once", req.OrderLineID))
+		}
+		seen[req.OrderLineID] = struct{}{}
+		if req.Quantity == 0 || req.Quantity > line.Quantity {
+			return nil, 0, apperror.WrapMessage(apperror.ErrBadRequest, nil,
+				fmt.Sprintf("order line %s has %d units", req.OrderLineID, line.Quantity))
+		}
+
+		amount := order.LineCharge(line, req.Quantity).Amount()
+		lines = append(lines, &model.RefundLine{
+			OrderLineID: line.ID,
+			ProductID:   line.ProductID,
+			Quantity:    req.Quantity,
+			Amount:      amount,
+		})
+		total += amount
+	}
+	return lines, total, nil
+}
+
+// settleRefund applies the provider's view of a refund. A refund that stops counting (failed,
+// canceled) gives its amount back to the payment; one that starts counting again takes it. A
+// refund that succeeds is credited against the order's invoice.
+func (s *paymentService) settleRefund(ctx context.Context, refund *model.Refund, result *payment.Refund) error {
+	status := refundStatus(result.Status)
+	succeeded := status == model.RefundStatusSucceeded && refund.Status != model.RefundStatusSucceeded
+	var delta int64
+	switch {
+	case refund.Status.Active() && !status.Active():
+		delta = -refund.Amount
+	case !refund.Status.Active() && status.Active():
+		delta = refund.Amount
+	}
+	if result.ID != "" {
+		providerRefundID := result.ID
+		refund.ProviderRefundID = &providerRefundID
+	}
+	refund.Status = status
+
+	return s.db.WithTransaction(func() error {
+		if delta != 0 {
+			if err := s.repo.AddRefunded(ctx, refund.PaymentID, delta); err != nil {
+				return err
+			}
+		}
+		if err := s.refunds.Update(ctx, refund); err != nil {
+			return err
+		}
+		if !succeeded {
+			return nil
+		}
+		if _, err := s.creditNotes.IssueCreditNote(ctx, creditNoteReq(refund)); err != nil {
+			return fmt.Errorf("issue credit note for refund %s: %w", refund.ID, err)
+		}
+		return nil
+	})
+}
+
+func creditNoteReq(refund *model.Refund) orderDomain.CreditNoteReq {
+	req := orderDomain.CreditNoteReq{
+		OrderID:  refund.OrderID,
+		RefundID: refund.ID,
+		Amount:   refund.Amount,
+		Reason:   refund.Reason,
+	}
+	for _, line := range refund.Lines {
+		req.Lines = append(req.Lines, orderDomain.CreditNoteLine{
+			OrderLineID: line.OrderLineID,
+			Quantity:    line.Quantity,
+			Amount:      line.Amount,
+		})
+	}
+	return req
+}
+
+func refundStatus(providerStatus string) model.RefundStatus {
+	switch providerStatus {
+	case payment.RefundStatusSucceeded:
+		return model.RefundStatusSucceeded
+	case payment.RefundStatusFailed:
+		return model.RefundStatusFailed
+	case payment.RefundStatusCanceled:
+		return model.RefundStatusCanceled
+	default:
+		// Stripe also reports requires_action; it still counts until it fails or succeeds.
+		return model.RefundStatusPending
+	}
+}
+
+// syncChargeRefunded records refunds issued outside the shop, e.g. from the provider's
+// dashboard. Refunds issued through RefundOrder are already counted; whatever the provider's
+// total adds on top becomes a succeeded refund of its own, credited against the invoice.
+func (s *paymentService) syncChargeRefunded(ctx context.Context, event *payment.Event) error {
+	rec, err := s.repo.GetByProviderIntentID(ctx, event.PaymentIntentID)
+	if err != nil {
+		return err
+	}
+	return s.db.WithTransaction(func() error {
+		raised, err := s.repo.SyncRefunded(ctx, rec.ID, event.AmountRefunded)
+		if err != nil || raised == 0 {
+			return err
+		}
+		refund := &model.Refund{
+			ID:             uuid.New().String(),
+			PaymentID:      rec.ID,
+			OrderID:        rec.OrderID,
+			IdempotencyKey: "charge_refunded_" + event.ID,
+			Amount:         raised,
+			Currency:       rec.Currency,
+			Status:         model.RefundStatusSucceeded,
+			Reason:         fmt.Sprintf("refunded at %s", rec.Provider),
+		}
+		if err := s.refunds.Create(ctx, refund); err != nil {
+			return err
+		}
+		if _, err := s.creditNotes.IssueCreditNote(ctx, creditNoteReq(refund)); err != nil {
+			return fmt.Errorf("issue credit note for refund %s: %w", refund.ID, err)
+		}
+		return nil
+	})
+}
+
+func (s *paymentService) applyRefundUpdate(ctx context.Context, event *payment.Event) error {
+	if event.Refund == nil {
+		return fmt.Errorf("webhook event %s missing refund", event.ID)
+	}
+	refund, err := s.refunds.GetByProviderRefundID(ctx, event.Refund.ID)
+	if errors.Is(err, gorm.ErrRecordNotFound) && event.Refund.Reference != "" {
+		// The webhook can beat RefundOrder to storing the provider's refund ID.
+		refund, err = s.refunds.GetByID(ctx, event.Refund.Reference)
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Not ours; charge.refunded keeps the payment's total in sync.
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return s.settleRefund(ctx, refund, event.Refund)
+}
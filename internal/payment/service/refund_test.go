@@ -0,0 +1,566 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	orderDomain "goshop/internal/order/domain"
+	orderModel "goshop/internal/order/model"
+	"goshop/internal/payment/model"
+	"goshop/internal/payment/repository"
+	"goshop/pkg/apperror"
+	dbsMocks "goshop/pkg/dbs/mocks"
+	"goshop/pkg/money"
+	"goshop/pkg/payment"
+	"goshop/pkg/tax"
+)
+
+type stubRefundRepo struct {
+	createFn      func(ctx context.Context, r *model.Refund) error
+	updateFn      func(ctx context.Context, r *model.Refund) error
+	getFn         func(ctx context.Context, id string) (*model.Refund, error)
+	getByKeyFn    func(ctx context.Context, key string) (*model.Refund, error)
+	getByProvFn   func(ctx context.Context, id string) (*model.Refund, error)
+	listFn        func(ctx context.Context, orderID string) ([]*model.Refund, error)
+	refundedQtyFn func(ctx context.Context, orderID string) (map[string]uint, error)
+}
+
+func (s *stubRefundRepo) Create(ctx context.Context, r *model.Refund) error {
+	return s.createFn(ctx, r)
+}
+func (s *stubRefundRepo) Update(ctx context.Context, r *model.Refund) error {
+	return s.updateFn(ctx, r)
+}
+func (s *stubRefundRepo) GetByID(ctx context.Context, id string) (*model.Refund, error) {
+	return s.getFn(ctx, id)
+}
+func (s *stubRefundRepo) GetByIdempotencyKey(ctx context.Context, key string) (*model.Refund, error) {
+	return s.getByKeyFn(ctx, key)
+}
+func (s *stubRefundRepo) GetByProviderRefundID(ctx context.Context, id string) (*model.Refund, error) {
+	return s.getByProvFn(ctx, id)
+}
+func (s *stubRefundRepo) ListByOrderID(ctx context.Context, orderID string) ([]*model.Refund, error) {
+	return s.listFn(ctx, orderID)
+}
+func (s *stubRefundRepo) RefundedQuantities(ctx context.Context, orderID string) (map[string]uint, error) {
+	return s.refundedQtyFn(ctx, orderID)
+}
+
+// stubCreditNotes records the credit notes requested; issueFn may be nil.
+type stubCreditNotes struct {
+	issued  []orderDomain.CreditNoteReq
+	issueFn func(ctx context.Context, req orderDomain.CreditNoteReq) (*orderModel.Invoice, error)
+}
+
+func (s *stubCreditNotes) IssueCreditNote(ctx context.Context, req orderDomain.CreditNoteReq) (*orderModel.Invoice, error) {
+	s.issued = append(s.issued, req)
+	if s.issueFn == nil {
+		return nil, nil
+	}
+	return s.issueFn(ctx, req)
+}
+
+// refundFixture wires a paid order o1 (lines l1: 2 units for 10.00, l2: 1 for 10.00, 25%
+// coupon) with a succeeded payment p1 of 15.00, recording the amount deltas and refund writes.
+type refundFixture struct {
+	svc      PaymentService
+	order    *orderModel.Order
+	payment  *model.Payment
+	prov     *stubProvider
+	repo     *stubRepo
+	refunds  *stubRefundRepo
+	credits  *stubCreditNotes
+	deltas   []int64
+	created  []*model.Refund
+	updated  []model.Refund
+	refunded map[string]uint
+}
+
+func newRefundFixture(t *testing.T) *refundFixture {
+	f := &refundFixture{
+		order: &orderModel.Order{ID: "o1", TotalPrice: money.New(2000, "USD"), DiscountAmount: money.New(500, "USD"), FinalPrice: money.New(1500, "USD"), Lines: []*orderModel.OrderLine{
+			{ID: "l1", ProductID: "prod1", Quantity: 2, Price: money.New(1000, "USD")},
+			{ID: "l2", ProductID: "prod2", Quantity: 1, Price: money.New(1000, "USD")},
+		}},
+		payment:  &model.Payment{ID: "p1", OrderID: "o1", Provider: "stripe", ProviderIntentID: "pi_1", Amount: 1500, Currency: "usd", Status: model.PaymentStatusSucceeded},
+		refunded: map[string]uint{},
+		credits:  &stubCreditNotes{},
+	}
+	f.prov = &stubProvider{refundFn: func(_ context.Context, p payment.RefundParams) (*payment.Refund, error) {
+		return &payment.Refund{ID: "re_1", PaymentIntentID: p.PaymentIntentID, Amount: p.Amount, Status: payment.RefundStatusSucceeded}, nil
+	}}
+	f.repo = &stubRepo{
+		getFn: func(_ context.Context, _ string) (*model.Payment, error) { return f.payment, nil },
+		addFn: func(_ context.Context, paymentID string, delta int64) error {
+			require.Equal(t, "p1", paymentID)
+			f.deltas = append(f.deltas, delta)
+			return nil
+		},
+	}
+	f.refunds = &stubRefundRepo{
+		getByKeyFn: func(_ context.Context, _ string) (*model.Refund, error) { return nil, gorm.ErrRecordNotFound },
+		createFn: func(_ context.Context, r *model.Refund) error {
+			f.created = append(f.created, r)
+			return nil
+		},
+		updateFn: func(_ context.Context, r *model.Refund) error {
+			f.updated = append(f.updated, *r)
+			return nil
+		},
+		refundedQtyFn: func(_ context.Context, _ string) (map[string]uint, error) { return f.refunded, nil },
+	}
+	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
+		if f.order == nil {
+			return nil, gorm.ErrRecordNotFound
+		}
+		return f.order, nil
+	}}
+	db := dbsMocks.NewDatabase(t)
+	db.On("WithTransaction", mock.Anything).Return(func(fn func() error) error { return fn() }).Maybe()
+	f.svc = NewPaymentService(db, registryOf(f.prov), f.repo, f.refunds, &stubMethodRepo{}, q, newOrderSvcMock(t), f.credits, payment.CaptureAutomatic)
+	return f
+}
+
+func requireAppError(t *testing.T, err error, want *apperror.AppError) {
+	t.Helper()
+	var appErr *apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, want.Code, appErr.Code)
+}
+
+func TestRefundOrder_FullRefundsWhatIsLeft(t *testing.T) {
+	f := newRefundFixture(t)
+	f.payment.AmountRefunded = 375
+	f.payment.Status = model.PaymentStatusPartiallyRefunded
+	var params payment.RefundParams
+	f.prov.refundFn = func(_ context.Context, p payment.RefundParams) (*payment.Refund, error) {
+		params = p
+		return &payment.Refund{ID: "re_1", Status: payment.RefundStatusSucceeded}, nil
+	}
+
+	refund, err := f.svc.RefundOrder(context.Background(), "o1", RefundRequest{Reason: "cancelled", ActorID: "admin1"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1125), refund.Amount)
+	require.Empty(t, refund.Lines)
+	require.Equal(t, model.RefundStatusSucceeded, refund.Status)
+	require.Equal(t, "re_1", *refund.ProviderRefundID)
+	require.Equal(t, "refund_"+refund.ID, refund.IdempotencyKey)
+	require.Equal(t, []int64{1125}, f.deltas)
+	require.Len(t, f.created, 1)
+	require.Equal(t, payment.RefundParams{
+		PaymentIntentID: "pi_1",
+		Amount:          1125,
+		OrderID:         "o1",
+		RefundID:        refund.ID,
+		Reason:          "cancelled",
+		IdempotencyKey:  "refund_" + refund.ID,
+	}, params)
+}
+
+func TestRefundOrder_LinesShareTheDiscount(t *testing.T) {
+	f := newRefundFixture(t)
+
+	refund, err := f.svc.RefundOrder(context.Background(), "o1", RefundRequest{
+		Lines: []RefundLine{{OrderLineID: "l1", Quantity: 1}, {OrderLineID: "l2", Quantity: 1}},
+	})
+	require.NoError(t, err)
+	// 5.00 + 10.00 at the order's 75% ratio.
+	require.Equal(t, int64(1125), refund.Amount)
+	require.Len(t, refund.Lines, 2)
+	require.Equal(t, model.RefundLine{OrderLineID: "l1", ProductID: "prod1", Quantity: 1, Amount: 375}, *refund.Lines[0])
+	require.Equal(t, model.RefundLine{OrderLineID: "l2", ProductID: "prod2", Quantity: 1, Amount: 750}, *refund.Lines[1])
+	require.Equal(t, []int64{1125}, f.deltas)
+}
+
+func TestRefundOrder_IssuesCreditNote(t *testing.T) {
+	f := newRefundFixture(t)
+
+	refund, err := f.svc.RefundOrder(context.Background(), "o1", RefundRequest{
+		Lines:  []RefundLine{{OrderLineID: "l1", Quantity: 1}},
+		Reason: "damaged",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []orderDomain.CreditNoteReq{{
+		OrderID: "o1", RefundID: refund.ID, Amount: 375, Reason: "damaged",
+		Lines: []orderDomain.CreditNoteLine{{OrderLineID: "l1", Quantity: 1, Amount: 375}},
+	}}, f.credits.issued)
+}
+
+func TestRefundOrder_CreditNoteErrorFailsSettle(t *testing.T) {
+	f := newRefundFixture(t)
+	f.credits.issueFn = func(_ context.Context, _ orderDomain.CreditNoteReq) (*orderModel.Invoice, error) {
+		return nil, errors.New("db")
+	}
+
+	_, err := f.svc.RefundOrder(context.Background(), "o1", RefundRequest{})
+	require.ErrorContains(t, err, "issue credit note")
+}
+
+func TestRefundOrder_LinesInOrderCurrency(t *testing.T) {
+	f := newRefundFixture(t)
+	f.order = &orderModel.Order{ID: "o1", Currency: "JPY", TotalPrice: money.New(3000, "JPY"), DiscountAmount: money.New(1000, "JPY"), FinalPrice: money.New(2000, "JPY"), Lines: []*orderModel.OrderLine{
+		{ID: "l1", ProductID: "prod1", Quantity: 3, Price: money.New(3000, "JPY"), Currency: "JPY"},
+	}}
+	f.payment.Amount, f.payment.Currency = 2000, "jpy"
+
+	refund, err := f.svc.RefundOrder(context.Background(), "o1", RefundRequest{
+		Lines: []RefundLine{{OrderLineID: "l1", Quantity: 1}},
+	})
+	require.NoError(t, err)
+	// A third of the 2000 yen paid, rounded to the yen.
+	require.Equal(t, int64(667), refund.Amount)
+}
+
+func TestRefundOrder_LinesIncludeTheirTax(t *testing.T) {
+	tests := []struct {
+		name string
+		mode tax.Mode
+		want int64
+	}{
+		// A third of the 20.00 line after the 25% discount, plus a third of its 3.00 tax.
+		{name: "exclusive", mode: tax.Exclusive, want: 500 + 100},
+		// The price already contained the tax.
+		{name: "inclusive", mode: tax.Inclusive, want: 500},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newRefundFixture(t)
+			// 20.00 of goods less 5.00 off, 3.00 tax and 4.99 shipping, which isn't refunded per line.
+			f.order = &orderModel.Order{
+				ID: "o1", TotalPrice: money.New(2000, "USD"), DiscountAmount: money.New(500, "USD"),
+				ShippingFee: money.New(499, "USD"), TaxAmount: money.New(300, "USD"), TaxMode: tt.mode,
+				FinalPrice: money.New(2299, "USD"),
+				Lines: []*orderModel.OrderLine{
+					{ID: "l1", ProductID: "prod1", Quantity: 3, Price: money.New(2000, "USD"), TaxRate: 2000, TaxAmount: money.New(300, "USD")},
+				},
+			}
+			f.payment.Amount = 2299
+
+			refund, err := f.svc.RefundOrder(context.Background(), "o1", RefundRequest{
+				Lines: []RefundLine{{OrderLineID: "l1", Quantity: 1}},
+			})
+			require.NoError(t, err)
+			require.Equal(t, tt.want, refund.Amount)
+		})
+	}
+}
+
+func TestRefundOrder_LineAmountCappedAtRefundable(t *testing.T) {
+	f := newRefundFixture(t)
+	f.payment.Amount = 1499 // the intent truncated the order's final price
+
+	refund, err := f.svc.RefundOrder(context.Background(), "o1", RefundRequest{
+		Lines: []RefundLine{{OrderLineID: "l1", Quantity: 2}, {OrderLineID: "l2", Quantity: 1}},
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1499), refund.Amount)
+}
+
+func TestRefundOrder_Rejected(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(f *refundFixture)
+		req   RefundRequest
+		want  *apperror.AppError
+	}{
+		{
+			name:  "order_not_found",
+			setup: func(f *refundFixture) { f.order = nil },
+			want:  apperror.ErrNotFound,
+		},
+		{
+			name: "no_payment",
+			setup: func(f *refundFixture) {
+				f.repo.getFn = func(_ context.Context, _ string) (*model.Payment, error) { return nil, gorm.ErrRecordNotFound }
+			},
+			want: apperror.ErrBadRequest,
+		},
+		{
+			name:  "payment_not_captured",
+			setup: func(f *refundFixture) { f.payment.Status = model.PaymentStatusPending },
+			want:  apperror.ErrBadRequest,
+		},
+		{
+			name: "fully_refunded",
+			setup: func(f *refundFixture) {
+				f.payment.Status = model.PaymentStatusPartiallyRefunded
+				f.payment.AmountRefunded = f.payment.Amount
+			},
+			want: apperror.ErrBadRequest,
+		},
+		{
+			name: "unknown_line",
+			req:  RefundRequest{Lines: []RefundLine{{OrderLineID: "nope", Quantity: 1}}},
+			want: apperror.ErrBadRequest,
+		},
+		{
+			name: "line_listed_twice",
+			req:  RefundRequest{Lines: []RefundLine{{OrderLineID: "l1", Quantity: 1}, {OrderLineID: "l1", Quantity: 1}}},
+			want: apperror.ErrBadRequest,
+		},
+		{
+			name: "more_units_than_ordered",
+			req:  RefundRequest{Lines: []RefundLine{{OrderLineID: "l1", Quantity: 3}}},
+			want: apperror.ErrBadRequest,
+		},
+		{
+			name:  "units_already_refunded",
+			setup: func(f *refundFixture) { f.refunded["l1"] = 2 },
+			req:   RefundRequest{Lines: []RefundLine{{OrderLineID: "l1", Quantity: 1}}},
+			want:  apperror.ErrBadRequest,
+		},
+		{
+			name: "concurrent_refund_took_the_balance",
+			setup: func(f *refundFixture) {
+				f.repo.addFn = func(_ context.Context, _ string, _ int64) error { return repository.ErrRefundExceedsPayment }
+			},
+			want: apperror.ErrBadRequest,
+		},
+		{
+			name: "idempotency_key_from_another_order",
+			setup: func(f *refundFixture) {
+				f.refunds.getByKeyFn = func(_ context.Context, _ string) (*model.Refund, error) {
+					return &model.Refund{ID: "rf_old", OrderID: "o2"}, nil
+				}
+			},
+			req:  RefundRequest{IdempotencyKey: "k1"},
+			want: apperror.ErrConflict,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newRefundFixture(t)
+			if tt.setup != nil {
+				tt.setup(f)
+			}
+			f.prov.refundFn = func(_ context.Context, _ payment.RefundParams) (*payment.Refund, error) {
+				t.Fatal("provider must not be called")
+				return nil, nil
+			}
+
+			_, err := f.svc.RefundOrder(context.Background(), "o1", tt.req)
+			requireAppError(t, err, tt.want)
+			require.Empty(t, f.created)
+		})
+	}
+}
+
+func TestRefundOrder_IdempotencyKeyReturnsOriginal(t *testing.T) {
+	f := newRefundFixture(t)
+	original := &model.Refund{ID: "rf_1", OrderID: "o1", IdempotencyKey: "k1", Status: model.RefundStatusSucceeded}
+	f.refunds.getByKeyFn = func(_ context.Context, key string) (*model.Refund, error) {
+		require.Equal(t, "k1", key)
+		return original, nil
+	}
+
+	refund, err := f.svc.RefundOrder(context.Background(), "o1", RefundRequest{IdempotencyKey: "k1"})
+	require.NoError(t, err)
+	require.Same(t, original, refund)
+	require.Empty(t, f.deltas)
+}
+
+func TestRefundOrder_ProviderErrorGivesTheAmountBack(t *testing.T) {
+	f := newRefundFixture(t)
+	f.prov.refundFn = func(_ context.Context, _ payment.RefundParams) (*payment.Refund, error) {
+		return nil, errors.New("stripe down")
+	}
+
+	_, err := f.svc.RefundOrder(context.Background(), "o1", RefundRequest{})
+	require.Error(t, err)
+	require.Equal(t, []int64{1500, -1500}, f.deltas)
+	require.Len(t, f.updated, 1)
+	require.Equal(t, model.RefundStatusFailed, f.updated[0].Status)
+	require.Nil(t, f.updated[0].ProviderRefundID)
+}
+
+func TestRefundOrder_ProviderReportsFailure(t *testing.T) {
+	f := newRefundFixture(t)
+	f.prov.refundFn = func(_ context.Context, _ payment.RefundParams) (*payment.Refund, error) {
+		return &payment.Refund{ID: "re_1", Status: payment.RefundStatusFailed}, nil
+	}
+
+	refund, err := f.svc.RefundOrder(context.Background(), "o1", RefundRequest{})
+	require.NoError(t, err)
+	require.Equal(t, model.RefundStatusFailed, refund.Status)
+	require.Equal(t, []int64{1500, -1500}, f.deltas)
+	require.Empty(t, f.credits.issued)
+}
+
+func TestRefundOrder_PendingAtProviderStillCounts(t *testing.T) {
+	f := newRefundFixture(t)
+	f.prov.refundFn = func(_ context.Context, _ payment.RefundParams) (*payment.Refund, error) {
+		return &payment.Refund{ID: "re_1", Status: "requires_action"}, nil
+	}
+
+	refund, err := f.svc.RefundOrder(context.Background(), "o1", RefundRequest{})
+	require.NoError(t, err)
+	require.Equal(t, model.RefundStatusPending, refund.Status)
+	require.Equal(t, []int64{1500}, f.deltas)
+	require.Empty(t, f.credits.issued)
+}
+
+func TestListRefunds(t *testing.T) {
+	f := newRefundFixture(t)
+	f.refunds.listFn = func(_ context.Context, orderID string) ([]*model.Refund, error) {
+		require.Equal(t, "o1", orderID)
+		return []*model.Refund{{ID: "rf_1"}}, nil
+	}
+	refunds, err := f.svc.ListRefunds(context.Background(), "o1")
+	require.NoError(t, err)
+	require.Len(t, refunds, 1)
+}
+
+func newRefundWebhookFixture(t *testing.T, event *payment.Event) *refundFixture {
+	f := newRefundFixture(t)
+	f.prov.verifyFn = func(_ []byte, _ http.Header) (*payment.Event, error) { return event, nil }
+	f.repo.recordFn = func(_ context.Context, _ *model.ProviderEvent) error { return nil }
+	return f
+}
+
+func TestHandleWebhook_ChargeRefundedSyncsTotal(t *testing.T) {
+	f := newRefundWebhookFixture(t, &payment.Event{
+		ID: "evt", Type: payment.EventChargeRefunded, PaymentIntentID: "pi_1", AmountRefunded: 400,
+	})
+	f.repo.getByPIFn = func(_ context.Context, intentID string) (*model.Payment, error) {
+		require.Equal(t, "pi_1", intentID)
+		return f.payment, nil
+	}
+	var synced int64
+	f.repo.syncFn = func(_ context.Context, paymentID string, total int64) (int64, error) {
+		require.Equal(t, "p1", paymentID)
+		synced = total
+		return 0, nil
+	}
+
+	require.NoError(t, f.svc.HandleWebhook(context.Background(), "stripe", nil, nil))
+	require.Equal(t, int64(400), synced)
+	// Nothing on top of the refunds the shop issued itself.
+	require.Empty(t, f.created)
+	require.Empty(t, f.credits.issued)
+}
+
+func TestHandleWebhook_ChargeRefundedCreditsOutsideRefund(t *testing.T) {
+	f := newRefundWebhookFixture(t, &payment.Event{
+		ID: "evt", Type: payment.EventChargeRefunded, PaymentIntentID: "pi_1", AmountRefunded: 400,
+	})
+	f.repo.getByPIFn = func(_ context.Context, _ string) (*model.Payment, error) { return f.payment, nil }
+	f.repo.syncFn = func(_ context.Context, _ string, _ int64) (int64, error) { return 150, nil }
+
+	require.NoError(t, f.svc.HandleWebhook(context.Background(), "stripe", nil, nil))
+	require.Len(t, f.created, 1)
+	refund := f.created[0]
+	require.Equal(t, "p1", refund.PaymentID)
+	require.Equal(t, "o1", refund.OrderID)
+	require.Equal(t, int64(150), refund.Amount)
+	require.Equal(t, model.RefundStatusSucceeded, refund.Status)
+	require.Equal(t, "charge_refunded_evt", refund.IdempotencyKey)
+	require.Len(t, f.credits.issued, 1)
+	require.Equal(t, refund.ID, f.credits.issued[0].RefundID)
+	require.Equal(t, int64(150), f.credits.issued[0].Amount)
+	require.Empty(t, f.credits.issued[0].Lines)
+}
+
+func TestHandleWebhook_ChargeRefundedCreditNoteError(t *testing.T) {
+	f := newRefundWebhookFixture(t, &payment.Event{
+		ID: "evt", Type: payment.EventChargeRefunded, PaymentIntentID: "pi_1", AmountRefunded: 400,
+	})
+	f.repo.getByPIFn = func(_ context.Context, _ string) (*model.Payment, error) { return f.payment, nil }
+	f.repo.syncFn = func(_ context.Context, _ string, _ int64) (int64, error) { return 150, nil }
+	f.credits.issueFn = func(_ context.Context, _ orderDomain.CreditNoteReq) (*orderModel.Invoice, error) {
+		return nil, errors.New("db")
+	}
+
+	require.NoError(t, f.svc.HandleWebhook(context.Background(), "stripe", nil, nil))
+	requireRetryPending(t, f.repo)
+}
+
+func TestHandleWebhook_ChargeRefundedUnknownIntent(t *testing.T) {
+	f := newRefundWebhookFixture(t, &payment.Event{ID: "evt", Type: payment.EventChargeRefunded, PaymentIntentID: "pi_x"})
+	f.repo.getByPIFn = func(_ context.Context, _ string) (*model.Payment, error) { return nil, gorm.ErrRecordNotFound }
+
+	require.NoError(t, f.svc.HandleWebhook(context.Background(), "stripe", nil, nil))
+	requireRetryPending(t, f.repo)
+}
+
+func TestHandleWebhook_RefundUpdated(t *testing.T) {
+	pending := func() *model.Refund {
+		providerID := "re_1"
+		return &model.Refund{ID: "rf_1", PaymentID: "p1", ProviderRefundID: &providerID, Amount: 375, Status: model.RefundStatusPending}
+	}
+	tests := []struct {
+		name        string
+		status      string
+		stored      func() *model.Refund
+		byReference bool
+		wantDeltas  []int64
+		wantStatus  model.RefundStatus
+		wantCredit  bool
+	}{
+		{name: "succeeded", status: payment.RefundStatusSucceeded, stored: pending, wantStatus: model.RefundStatusSucceeded,
+			wantCredit: true},
+		{name: "failed_gives_back", status: payment.RefundStatusFailed, stored: pending,
+			wantDeltas: []int64{-375}, wantStatus: model.RefundStatusFailed},
+		{name: "failed_then_succeeded_counts_again", status: payment.RefundStatusSucceeded,
+			stored: func() *model.Refund {
+				r := pending()
+				r.ProviderRefundID = nil
+				r.Status = model.RefundStatusFailed
+				return r
+			},
+			byReference: true, wantDeltas: []int64{375}, wantStatus: model.RefundStatusSucceeded, wantCredit: true},
+		{name: "succeeded_again_credits_once", status: payment.RefundStatusSucceeded,
+			stored: func() *model.Refund {
+				r := pending()
+				r.Status = model.RefundStatusSucceeded
+				return r
+			},
+			wantStatus: model.RefundStatusSucceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newRefundWebhookFixture(t, &payment.Event{ID: "evt", Type: payment.EventRefundUpdated, Refund: &payment.Refund{
+				ID: "re_1", Status: tt.status, Reference: "rf_1",
+			}})
+			stored := tt.stored()
+			f.refunds.getByProvFn = func(_ context.Context, id string) (*model.Refund, error) {
+				require.Equal(t, "re_1", id)
+				if tt.byReference {
+					return nil, gorm.ErrRecordNotFound
+				}
+				return stored, nil
+			}
+			f.refunds.getFn = func(_ context.Context, id string) (*model.Refund, error) {
+				require.Equal(t, "rf_1", id)
+				return stored, nil
+			}
+
+			require.NoError(t, f.svc.HandleWebhook(context.Background(), "stripe", nil, nil))
+			require.Equal(t, tt.wantDeltas, f.deltas)
+			require.Len(t, f.updated, 1)
+			require.Equal(t, tt.wantStatus, f.updated[0].Status)
+			require.Equal(t, "re_1", *f.updated[0].ProviderRefundID)
+			require.Equal(t, tt.wantCredit, len(f.credits.issued) == 1)
+		})
+	}
+}
+
+func TestHandleWebhook_RefundUpdatedNotOurs(t *testing.T) {
+	f := newRefundWebhookFixture(t, &payment.Event{ID: "evt", Type: payment.EventRefundUpdated, Refund: &payment.Refund{ID: "re_x"}})
+	f.refunds.getByProvFn = func(_ context.Context, _ string) (*model.Refund, error) { return nil, gorm.ErrRecordNotFound }
+
+	require.NoError(t, f.svc.HandleWebhook(context.Background(), "stripe", nil, nil))
+	require.Empty(t, f.updated)
+}
+
+func TestHandleWebhook_RefundUpdatedWithoutRefund(t *testing.T) {
+	f := newRefundWebhookFixture(t, &payment.Event{ID: "evt", Type: payment.EventRefundUpdated})
+	require.NoError(t, f.svc.HandleWebhook(context.Background(), "stripe", nil, nil))
+	requireRetryPending(t, f.repo)
+}
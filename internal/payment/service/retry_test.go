@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	orderModel "goshop/internal/order/model"
+	orderService "goshop/internal/order/service"
+	orderSvcMocks "goshop/internal/order/service/mocks"
+	"goshop/internal/payment/model"
+	"goshop/pkg/apperror"
+	"goshop/pkg/money"
+	"goshop/pkg/payment"
+)
+
+// retryFixture has o1 pending payment after a failed first attempt with Stripe.
+func retryFixture(t *testing.T, latest *model.Payment) (PaymentService, *stubRepo, *stubProvider, *[]*model.Payment) {
+	t.Helper()
+	var created []*model.Payment
+	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
+		return &orderModel.Order{ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPendingPayment, FinalPrice: money.New(1000, "USD")}, nil
+	}}
+	repo := &stubRepo{
+		getFn: func(_ context.Context, _ string) (*model.Payment, error) { return latest, nil },
+		createFn: func(_ context.Context, p *model.Payment) error {
+			created = append(created, p)
+			return nil
+		},
+	}
+	prov := &stubProvider{createFn: func(_ context.Context, p payment.CreateIntentParams) (*payment.Intent, error) {
+		return &payment.Intent{ID: "pi_" + p.IdempotencyKey, Amount: p.Amount, Currency: p.Currency}, nil
+	}}
+	svc := NewPaymentService(nil, registryOf(prov), repo, &stubRefundRepo{}, &stubMethodRepo{}, q, newOrderSvcMock(t), &stubCreditNotes{}, payment.CaptureAutomatic)
+	return svc, repo, prov, &created
+}
+
+func failedAttempt() *model.Payment {
+	return &model.Payment{ID: "pay1", OrderID: "o1", Attempt: 1, Provider: "stripe", ProviderIntentID: "pi_1",
+		Amount: 1000, Currency: "usd", Status: model.PaymentStatusFailed}
+}
+
+func TestCreateIntent_FailedAttemptStartsNewAttempt(t *testing.T) {
+	svc, repo, _, created := retryFixture(t, failedAttempt())
+
+	intent, err := svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "pi_order_o1_attempt_2", intent.ID)
+	require.Len(t, *created, 1)
+	require.Equal(t, 2, (*created)[0].Attempt)
+	require.Equal(t, "pi_order_o1_attempt_2", (*created)[0].ProviderIntentID)
+	require.Zero(t, repo.updateCall, "the failed attempt stays as it was")
+}
+
+func TestRetryPayment_ReopensOrderAndCreatesNewAttempt(t *testing.T) {
+	svc, _, _, created := retryFixture(t, failedAttempt())
+	svc.(*paymentService).orderService.(*orderSvcMocks.OrderService).
+		On("ReopenForPayment", mock.Anything, "o1").Return(&orderModel.Order{ID: "o1"}, nil).Once()
+
+	intent, err := svc.RetryPayment(context.Background(), "o1", "u1", "", "")
+	require.NoError(t, err)
+	require.Equal(t, "pi_order_o1_attempt_2", intent.ID)
+	require.Len(t, *created, 1)
+	require.Equal(t, 2, (*created)[0].Attempt)
+}
+
+func TestRetryPayment_RejectsActivePayment(t *testing.T) {
+	latest := failedAttempt()
+	latest.Status = model.PaymentStatusProcessing
+	svc, _, _, created := retryFixture(t, latest)
+
+	_, err := svc.RetryPayment(context.Background(), "o1", "u1", "", "")
+	requireAppError(t, err, apperror.ErrInvalidStatus)
+	require.Empty(t, *created)
+}
+
+func TestRetryPayment_RejectsOtherBuyer(t *testing.T) {
+	// No ReopenForPayment expectation: someone else's order is never reopened.
+	svc, _, _, created := retryFixture(t, failedAttempt())
+
+	_, err := svc.RetryPayment(context.Background(), "o1", "u2", "", "")
+	requireAppError(t, err, apperror.ErrNotFound)
+	require.Empty(t, *created)
+}
+
+func TestRetryPayment_StockGoneCreatesNoIntent(t *testing.T) {
+	svc, _, prov, created := retryFixture(t, failedAttempt())
+	prov.createFn = func(context.Context, payment.CreateIntentParams) (*payment.Intent, error) {
+		t.Fatal("no intent without stock")
+		return nil, nil
+	}
+	svc.(*paymentService).orderService.(*orderSvcMocks.OrderService).
+		On("ReopenForPayment", mock.Anything, "o1").
+		Return(nil, &orderService.InsufficientStockError{ProductID: "p1", Requested: 2}).Once()
+
+	_, err := svc.RetryPayment(context.Background(), "o1", "u1", "", "")
+	var stockErr *orderService.InsufficientStockError
+	require.ErrorAs(t, err, &stockErr)
+	require.Empty(t, *created)
+}
@@ -4,26 +4,76 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
+	"strings"
+	"time"
 
+	"github.com/quangdangfit/gocommon/logger"
 	"gorm.io/gorm"
 
+	orderDomain "goshop/internal/order/domain"
 	orderModel "goshop/internal/order/model"
 	orderService "goshop/internal/order/service"
+	"goshop/internal/payment/domain"
 	"goshop/internal/payment/model"
 	"goshop/internal/payment/repository"
+	"goshop/pkg/apperror"
+	"goshop/pkg/currency"
+	"goshop/pkg/dbs"
+	"goshop/pkg/paging"
 	"goshop/pkg/payment"
 )
 
-const stripeProvider = "stripe"
-
 //go:generate mockery --name=PaymentService
 type PaymentService interface {
-	// CreateIntentForOrder creates (or reuses) a payment intent for the given order. Idempotent
-	// per order: a second call returns the existing intent instead of charging twice.
-	CreateIntentForOrder(ctx context.Context, orderID string) (*payment.Intent, error)
-	// HandleWebhook verifies, deduplicates, and applies a provider webhook payload. Returns
-	// nil for events that are valid but unrelated (ignored types, duplicates).
-	HandleWebhook(ctx context.Context, payload []byte, signatureHeader string) error
+	// CreateIntentForOrder creates (or reuses) a payment intent for the given order with the
+	// named provider, or the default one when providerName is empty. Idempotent per order and
+	// provider: a second call returns the existing intent instead of charging twice. Picking
+	// another provider supersedes the order's earlier intent until money has moved.
+	// Once the order's latest attempt has failed or been canceled, the next call starts a new
+	// attempt with its own intent. A paymentMethodID charges one of the buyer's saved payment
+	// methods, with its provider. Only the buyer, userID, can pay for the order; to anyone
+	// else it is not found.
+	CreateIntentForOrder(ctx context.Context, orderID, userID, providerName, paymentMethodID string) (*payment.Intent, error)
+	// RetryPayment starts a new payment attempt for an order whose payment failed: it reopens
+	// the order for payment, reserving its stock again if the reservations lapsed, and creates
+	// a fresh intent with the named (or default) provider or saved payment method. Like
+	// CreateIntentForOrder it only lets the buyer retry.
+	RetryPayment(ctx context.Context, orderID, userID, providerName, paymentMethodID string) (*payment.Intent, error)
+	// HandleWebhook verifies a webhook payload sent by the named provider, stores it in the
+	// inbox, deduplicating it, and applies it. An event that fails to apply is kept for
+	// ProcessPendingEvents to retry, so only errors before it's stored are returned. Events
+	// that are valid but unrelated (ignored types, superseded intents) are processed as no-ops.
+	HandleWebhook(ctx context.Context, providerName string, payload []byte, headers http.Header) error
+	// ProcessPendingEvents applies up to batchSize due inbox events that failed before and
+	// returns how many succeeded. Each failure is retried with backoff until MaxEventAttempts,
+	// then the event is marked failed.
+	ProcessPendingEvents(ctx context.Context, batchSize int) (int, error)
+	// ListFailedEvents lists inbox events marked failed or waiting for another attempt.
+	ListFailedEvents(ctx context.Context, req *domain.ListFailedEventsReq) ([]*model.ProviderEvent, *paging.Pagination, error)
+	// ReplayEvent applies a stored, unprocessed inbox event again now, with a fresh retry
+	// budget, and returns it with the outcome recorded on it. An event that a webhook request
+	// or the worker is applying meanwhile is a conflict.
+	ReplayEvent(ctx context.Context, providerName, eventID string) (*model.ProviderEvent, error)
+	// ConfirmPayment marks an offline payment (e.g. bank transfer) as received and the order
+	// as paid. Idempotent once the payment has succeeded.
+	ConfirmPayment(ctx context.Context, orderID string) (*model.Payment, error)
+	// CollectCashOnDelivery records the cash a courier collected on delivering a cash-on-delivery
+	// order and marks the order done. Idempotent once the cash has been recorded.
+	CollectCashOnDelivery(ctx context.Context, orderID string) (*model.Payment, error)
+	// RefundOrder refunds an order's payment through the provider, in full or for the given
+	// order lines. Cancelling a paid order doesn't refund it; this does.
+	RefundOrder(ctx context.Context, orderID string, req RefundRequest) (*model.Refund, error)
+	ListRefunds(ctx context.Context, orderID string) ([]*model.Refund, error)
+	// ReconcilePayments settles payments whose webhooks went missing: it asks the provider
+	// for each stale unsettled payment's intent and applies the transition its webhook would
+	// have. Offline and unconfigured providers are skipped. Each pass that checked payments,
+	// other than a dry run, is stored as a model.ReconcileRun.
+	ReconcilePayments(ctx context.Context, req ReconcileRequest) (*ReconcileReport, error)
+	// ListReconcileRuns lists stored reconciliation runs, newest first, without their entries.
+	ListReconcileRuns(ctx context.Context, req *domain.ListReconcileRunsReq) ([]*model.ReconcileRun, *paging.Pagination, error)
+	// GetReconcileRun returns a stored reconciliation run with the payments it checked.
+	GetReconcileRun(ctx context.Context, id string) (*model.ReconcileRun, error)
 }
 
 // OrderQuery is the read-only slice of OrderService that PaymentService needs. Defined here
@@ -32,91 +82,258 @@ type OrderQuery interface {
 	GetOrderByID(ctx context.Context, id string) (*orderModel.Order, error)
 }
 
+// CreditNoteIssuer is the slice of InvoiceService that PaymentService needs: refunds that go
+// through are credited against the order's invoice.
+type CreditNoteIssuer interface {
+	IssueCreditNote(ctx context.Context, req orderDomain.CreditNoteReq) (*orderModel.Invoice, error)
+}
+
 type paymentService struct {
-	provider     payment.Provider
+	db           dbs.Database
+	providers    *payment.Registry
 	repo         repository.PaymentRepository
+	refunds      repository.RefundRepository
+	methods      repository.PaymentMethodRepository
 	orderQuery   OrderQuery
 	orderService orderService.OrderService
-	providerName string
+	creditNotes  CreditNoteIssuer
+	capture      payment.CaptureMode
+	now          func() time.Time
 }
 
 func NewPaymentService(
-	provider payment.Provider,
+	db dbs.Database,
+	providers *payment.Registry,
 	repo repository.PaymentRepository,
+	refunds repository.RefundRepository,
+	methods repository.PaymentMethodRepository,
 	orderQuery OrderQuery,
 	orderSvc orderService.OrderService,
+	creditNotes CreditNoteIssuer,
+	capture payment.CaptureMode,
 ) PaymentService {
 	return &paymentService{
-		provider:     provider,
+		db:           db,
+		providers:    providers,
 		repo:         repo,
+		refunds:      refunds,
+		methods:      methods,
 		orderQuery:   orderQuery,
 		orderService: orderSvc,
-		providerName: stripeProvider,
+		creditNotes:  creditNotes,
+		capture:      capture,
+		now:          time.Now,
 	}
 }
 
-func (s *paymentService) CreateIntentForOrder(ctx context.Context, orderID string) (*payment.Intent, error) {
-	order, err := s.orderQuery.GetOrderByID(ctx, orderID)
+func (s *paymentService) CreateIntentForOrder(ctx context.Context, orderID, userID, providerName, paymentMethodID string) (*payment.Intent, error) {
+	order, err := s.buyerOrder(ctx, orderID, userID)
 	if err != nil {
 		return nil, err
 	}
+	var method *model.PaymentMethod
+	if paymentMethodID != "" {
+		if method, err = s.savedMethodFor(ctx, userID, paymentMethodID, providerName); err != nil {
+			return nil, err
+		}
+		providerName = method.Provider
+	}
+	if providerName == "" {
+		providerName = s.providers.DefaultName()
+	}
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, err, err.Error())
+	}
+
+	if order.CashOnDelivery() {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "order is paid cash on delivery")
+	}
 	if order.Status != orderModel.OrderStatusPendingPayment {
 		return nil, fmt.Errorf("order %s is not pending payment (status=%s)", orderID, order.Status)
 	}
 
-	// Look up any existing payment row for this order. We deliberately do NOT short-circuit
-	// on the stored row alone — the row doesn't (and shouldn't) persist client_secret, so the
-	// FE needs us to re-issue the intent on every call. Stripe's idempotency key replays the
-	// same intent (with its client_secret) for 24h, so a duplicate POST is safe.
+	// Look up the order's latest payment attempt. We deliberately do NOT short-circuit on the
+	// stored row alone — the row doesn't (and shouldn't) persist client_secret, so the FE needs
+	// us to re-issue the intent on every call. Providers replay the same intent for a repeated
+	// idempotency key (Stripe for 24h), so a duplicate POST is safe.
 	existing, err := s.repo.GetByOrderID(ctx, orderID)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, err
 	}
+	if existing != nil && existing.Provider != providerName && existing.Committed() {
+		return nil, apperror.WrapMessage(apperror.ErrConflict, nil,
+			fmt.Sprintf("order is already being paid with %s", existing.Provider))
+	}
+	// A failed or canceled attempt is history: the next one gets a new row and a new
+	// idempotency key, so the provider creates a fresh intent instead of replaying the old one.
+	attempt := 1
+	if existing != nil {
+		attempt = existing.Attempt
+		if !existing.Active() {
+			attempt++
+			existing = nil
+		}
+	}
 
-	amount := int64(order.FinalPrice * 100) // assume USD-style minor units
-	currency := "usd"
-	intent, err := s.provider.CreateIntent(ctx, payment.CreateIntentParams{
+	amount, code := chargeFor(order)
+	params := payment.CreateIntentParams{
 		Amount:         amount,
-		Currency:       currency,
+		Currency:       code,
 		OrderID:        order.ID,
-		IdempotencyKey: "order_" + order.ID,
-	})
+		IdempotencyKey: intentIdempotencyKey(order.ID, attempt),
+		ManualCapture:  s.capture == payment.CaptureManual,
+	}
+	if method != nil {
+		// The provider rejects a key reused with other parameters, so each saved method gets
+		// its own intent.
+		params.IdempotencyKey += "_" + method.ID
+		params.CustomerID = method.ProviderCustomerID
+		params.PaymentMethodID = method.ProviderMethodID
+	}
+	intent, err := provider.CreateIntent(ctx, params)
 	if err != nil {
 		return nil, err
 	}
+	intent.Provider = providerName
+	// Slow payment methods get longer than the usual reservation TTL to pay.
+	if !intent.ExpiresAt.IsZero() {
+		if err := s.orderService.ExtendReservations(ctx, order.ID, intent.ExpiresAt); err != nil {
+			return nil, err
+		}
+	}
 
-	// First time for this order — persist a payment row so webhooks can look it up.
-	// On subsequent calls the row already exists and Stripe returned the same intent
-	// via idempotency replay, so there's nothing to write.
+	// First call for this attempt — persist a payment row so webhooks can look it up. On
+	// subsequent calls with the same provider the row already exists and the provider
+	// returned the same intent via idempotency replay, so there's nothing to write. A new
+	// provider (or intent) takes over the row; webhooks for the old intent are then ignored.
 	if existing == nil {
 		rec := &model.Payment{
 			OrderID:          order.ID,
-			Provider:         s.providerName,
+			Attempt:          attempt,
+			Provider:         providerName,
 			ProviderIntentID: intent.ID,
 			Amount:           amount,
-			Currency:         currency,
+			Currency:         code,
 			Status:           model.PaymentStatusPending,
 		}
 		if err := s.repo.Create(ctx, rec); err != nil {
 			return nil, err
 		}
+	} else if !existing.Committed() && (existing.Provider != providerName || existing.ProviderIntentID != intent.ID) {
+		existing.Provider = providerName
+		existing.ProviderIntentID = intent.ID
+		existing.Amount = amount
+		existing.Currency = code
+		existing.Status = model.PaymentStatusPending
+		if err := s.repo.Update(ctx, existing); err != nil {
+			return nil, err
+		}
 	}
 	return intent, nil
 }
 
-func (s *paymentService) HandleWebhook(ctx context.Context, payload []byte, signatureHeader string) error {
-	event, err := s.provider.VerifyWebhook(payload, signatureHeader)
+// buyerOrder loads an order for its buyer. Someone else's order is reported as not found, so
+// order IDs can't be probed.
+func (s *paymentService) buyerOrder(ctx context.Context, orderID, userID string) (*orderModel.Order, error) {
+	order, err := s.orderQuery.GetOrderByID(ctx, orderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, err, "order not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if order.UserID != userID {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, nil, "order not found")
+	}
+	return order, nil
+}
+
+// savedMethodFor looks up a payment method the buyer saved. It must be saved with
+// providerName when one is given.
+func (s *paymentService) savedMethodFor(ctx context.Context, userID, paymentMethodID, providerName string) (*model.PaymentMethod, error) {
+	method, err := s.methods.GetByID(ctx, paymentMethodID, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, err, "payment method not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if providerName != "" && providerName != method.Provider {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil,
+			fmt.Sprintf("payment method is saved with %s", method.Provider))
+	}
+	return method, nil
+}
+
+// intentIdempotencyKey keys an attempt's intent at the provider. The first attempt keeps the
+// key orders always used, so intents created before attempts existed are still replayed.
+func intentIdempotencyKey(orderID string, attempt int) string {
+	if attempt <= 1 {
+		return "order_" + orderID
+	}
+	return fmt.Sprintf("order_%s_attempt_%d", orderID, attempt)
+}
+
+func (s *paymentService) RetryPayment(ctx context.Context, orderID, userID, providerName, paymentMethodID string) (*payment.Intent, error) {
+	// Check the buyer before reopening: that reserves stock again on their behalf.
+	if _, err := s.buyerOrder(ctx, orderID, userID); err != nil {
+		return nil, err
+	}
+	latest, err := s.repo.GetByOrderID(ctx, orderID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if latest != nil && latest.Active() {
+		return nil, apperror.WrapMessage(apperror.ErrInvalidStatus, nil,
+			fmt.Sprintf("payment is %s", latest.Status))
+	}
+	if _, err := s.orderService.ReopenForPayment(ctx, orderID); err != nil {
+		return nil, err
+	}
+	return s.CreateIntentForOrder(ctx, orderID, userID, providerName, paymentMethodID)
+}
+
+func (s *paymentService) HandleWebhook(ctx context.Context, providerName string, payload []byte, headers http.Header) error {
+	provider, err := s.providers.Get(providerName)
+	if err != nil {
+		return err
+	}
+	event, err := provider.VerifyWebhook(ctx, payload, headers)
 	if err != nil {
 		return err
 	}
 
-	// Dedup before doing any side-effects.
-	if err := s.repo.RecordProviderEvent(ctx, s.providerName, event.ID); err != nil {
+	// Store the event before doing any side-effects: a duplicate is dropped here, and an
+	// event that fails to apply stays in the inbox for the retry worker. It's hidden from the
+	// worker, and from replays, while this request applies it.
+	inbox := &model.ProviderEvent{
+		Provider:      providerName,
+		EventID:       event.ID,
+		EventType:     string(event.Type),
+		Payload:       string(event.Raw),
+		Status:        model.ProviderEventStatusProcessing,
+		NextAttemptAt: s.now().Add(EventClaimLease),
+	}
+	if err := s.repo.RecordProviderEvent(ctx, inbox); err != nil {
 		if errors.Is(err, repository.ErrEventAlreadyProcessed) {
 			return nil
 		}
 		return err
 	}
+	return s.processEvent(ctx, inbox, provider, event)
+}
+
+// applyWebhookEvent applies a verified provider event to its payment and order.
+func (s *paymentService) applyWebhookEvent(ctx context.Context, providerName string, provider payment.Provider, event *payment.Event) error {
+	// Refund events are matched by payment intent and refund rather than by order metadata,
+	// which refunds issued from the provider's dashboard don't carry.
+	switch event.Type {
+	case payment.EventChargeRefunded:
+		return s.syncChargeRefunded(ctx, event)
+	case payment.EventRefundUpdated:
+		return s.applyRefundUpdate(ctx, event)
+	}
 
 	if event.OrderID == "" {
 		return fmt.Errorf("webhook event %s missing order_id metadata", event.ID)
@@ -125,8 +342,52 @@ func (s *paymentService) HandleWebhook(ctx context.Context, payload []byte, sign
 	if err != nil {
 		return err
 	}
+	if superseded(rec, providerName, event) {
+		// The customer switched to another provider or intent. Its events no longer apply,
+		// unless they paid through it after all.
+		if event.Type != payment.EventPaymentSucceeded || event.PaymentIntentID == "" {
+			return nil
+		}
+		if rec.Committed() {
+			logger.Errorf("HandleWebhook: order %s paid twice, %s intent %s also succeeded; refund it from the provider",
+				event.OrderID, providerName, event.PaymentIntentID)
+			return nil
+		}
+		rec.Provider = providerName
+		rec.ProviderIntentID = event.PaymentIntentID
+	}
+
+	return s.applyPaymentEvent(ctx, provider, rec, event)
+}
 
+// applyPaymentEvent moves the payment and its order along for a payment event. Webhooks
+// and the reconciler share it so both settle a payment the same way.
+func (s *paymentService) applyPaymentEvent(ctx context.Context, provider payment.Provider, rec *model.Payment, event *payment.Event) error {
 	switch event.Type {
+	case payment.EventPaymentApproved:
+		// Redirect flows: the customer approved the payment, now charge it. The capture
+		// result arrives as a later succeeded / failed event.
+		if err := provider.Capture(ctx, rec.ProviderIntentID); err != nil {
+			if errors.Is(err, payment.ErrCaptureNotSupported) {
+				return nil
+			}
+			return err
+		}
+		rec.Status = model.PaymentStatusProcessing
+		if err := s.repo.Update(ctx, rec); err != nil {
+			return err
+		}
+	case payment.EventPaymentAuthorized:
+		// Manual capture: the funds are held, so the order counts as paid and its stock is
+		// committed. The payment is captured when the order moves to in-progress, and the
+		// order is invoiced once that capture succeeds.
+		rec.Status = model.PaymentStatusAuthorized
+		if err := s.repo.Update(ctx, rec); err != nil {
+			return err
+		}
+		if _, err := s.orderService.MarkOrderAuthorized(ctx, event.OrderID); err != nil {
+			return err
+		}
 	case payment.EventPaymentSucceeded:
 		rec.Status = model.PaymentStatusSucceeded
 		if err := s.repo.Update(ctx, rec); err != nil {
@@ -172,3 +433,102 @@ func (s *paymentService) HandleWebhook(ctx context.Context, payload []byte, sign
 	}
 	return nil
 }
+
+// superseded reports whether event belongs to an intent the payment has moved away from.
+func superseded(rec *model.Payment, providerName string, event *payment.Event) bool {
+	if rec.Provider != providerName {
+		return true
+	}
+	return event.PaymentIntentID != "" && event.PaymentIntentID != rec.ProviderIntentID
+}
+
+func (s *paymentService) ConfirmPayment(ctx context.Context, orderID string) (*model.Payment, error) {
+	rec, err := s.repo.GetByOrderID(ctx, orderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, err, "order has no payment")
+	}
+	if err != nil {
+		return nil, err
+	}
+	provider, err := s.providers.Get(rec.Provider)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := provider.(payment.Offline); !ok {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil,
+			fmt.Sprintf("%s payments are confirmed by the provider", rec.Provider))
+	}
+	switch rec.Status {
+	case model.PaymentStatusSucceeded:
+		return rec, nil
+	case model.PaymentStatusPending, model.PaymentStatusRequiresAction:
+	default:
+		return nil, apperror.WrapMessage(apperror.ErrInvalidStatus, nil,
+			fmt.Sprintf("payment is %s", rec.Status))
+	}
+
+	// Pay the order first: if its reservation already lapsed, the payment stays unconfirmed.
+	if _, err := s.orderService.MarkOrderPaid(ctx, orderID); err != nil {
+		return nil, err
+	}
+	rec.Status = model.PaymentStatusSucceeded
+	if err := s.repo.Update(ctx, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (s *paymentService) CollectCashOnDelivery(ctx context.Context, orderID string) (*model.Payment, error) {
+	order, err := s.orderQuery.GetOrderByID(ctx, orderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apperror.WrapMessage(apperror.ErrNotFound, err, "order not found")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !order.CashOnDelivery() {
+		return nil, apperror.WrapMessage(apperror.ErrBadRequest, nil, "order is not cash on delivery")
+	}
+	existing, err := s.repo.GetByOrderID(ctx, orderID)
+	if err == nil {
+		return existing, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	// A shipped order has every parcel out with the courier. Done is accepted too, so a
+	// collection whose payment row failed to save can be retried.
+	switch order.Status {
+	case orderModel.OrderStatusInProgress, orderModel.OrderStatusShipped, orderModel.OrderStatusDone:
+	default:
+		return nil, apperror.WrapMessage(apperror.ErrInvalidStatus, nil,
+			fmt.Sprintf("order is %s, not out for delivery", order.Status))
+	}
+
+	if _, err := s.orderService.UpdateOrderStatus(ctx, orderID, orderModel.OrderStatusDone); err != nil {
+		return nil, err
+	}
+	amount, code := chargeFor(order)
+	rec := &model.Payment{
+		OrderID:          order.ID,
+		Provider:         model.ProviderCashOnDelivery,
+		ProviderIntentID: "cod_" + order.ID,
+		Amount:           amount,
+		Currency:         code,
+		Status:           model.PaymentStatusSucceeded,
+	}
+	if err := s.repo.Create(ctx, rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+// chargeFor is what order costs in minor units of its currency, with the lower-case code
+// payments and providers use.
+func chargeFor(order *orderModel.Order) (int64, string) {
+	code := order.Currency
+	if code == "" {
+		code = currency.Default
+	}
+	return order.FinalPrice.Amount(), strings.ToLower(code)
+}
@@ -3,7 +3,10 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
@@ -11,35 +14,93 @@ import (
 
 	orderModel "goshop/internal/order/model"
 	orderSvcMocks "goshop/internal/order/service/mocks"
+	"goshop/internal/payment/domain"
 	"goshop/internal/payment/model"
 	"goshop/internal/payment/repository"
+	"goshop/pkg/money"
+	"goshop/pkg/paging"
 	"goshop/pkg/payment"
 )
 
+// stubProvider is a payment.Provider; Capture and Void report ErrCaptureNotSupported unless
+// captureFn and voidFn are set.
 type stubProvider struct {
-	createFn func(ctx context.Context, p payment.CreateIntentParams) (*payment.Intent, error)
-	verifyFn func(payload []byte, sig string) (*payment.Event, error)
+	createFn  func(ctx context.Context, p payment.CreateIntentParams) (*payment.Intent, error)
+	refundFn  func(ctx context.Context, p payment.RefundParams) (*payment.Refund, error)
+	verifyFn  func(payload []byte, headers http.Header) (*payment.Event, error)
+	getFn     func(ctx context.Context, intentID string) (*payment.Intent, error)
+	captureFn func(ctx context.Context, intentID string) error
+	voidFn    func(ctx context.Context, intentID string) error
+	parseFn   func(payload []byte) (*payment.Event, error)
 }
 
 func (s *stubProvider) CreateIntent(ctx context.Context, p payment.CreateIntentParams) (*payment.Intent, error) {
 	return s.createFn(ctx, p)
 }
-func (s *stubProvider) VerifyWebhook(payload []byte, sig string) (*payment.Event, error) {
-	return s.verifyFn(payload, sig)
+func (s *stubProvider) GetIntent(ctx context.Context, intentID string) (*payment.Intent, error) {
+	return s.getFn(ctx, intentID)
+}
+func (s *stubProvider) Refund(ctx context.Context, p payment.RefundParams) (*payment.Refund, error) {
+	return s.refundFn(ctx, p)
+}
+func (s *stubProvider) VerifyWebhook(_ context.Context, payload []byte, headers http.Header) (*payment.Event, error) {
+	return s.verifyFn(payload, headers)
+}
+func (s *stubProvider) ParseWebhook(payload []byte) (*payment.Event, error) {
+	return s.parseFn(payload)
+}
+func (s *stubProvider) Capture(ctx context.Context, intentID string) error {
+	if s.captureFn == nil {
+		return payment.ErrCaptureNotSupported
+	}
+	return s.captureFn(ctx, intentID)
+}
+func (s *stubProvider) Void(ctx context.Context, intentID string) error {
+	if s.voidFn == nil {
+		return payment.ErrCaptureNotSupported
+	}
+	return s.voidFn(ctx, intentID)
+}
+
+// stubOffline is an offline provider (payment.Offline).
+type stubOffline struct{ stubProvider }
+
+func (s *stubOffline) Offline() {}
+
+// registryOf registers p as the default "stripe" provider.
+func registryOf(p payment.Provider) *payment.Registry {
+	r := payment.NewRegistry("stripe")
+	r.Register("stripe", p)
+	return r
 }
 
 type stubRepo struct {
 	getFn      func(ctx context.Context, orderID string) (*model.Payment, error)
+	getByPIFn  func(ctx context.Context, intentID string) (*model.Payment, error)
 	createFn   func(ctx context.Context, p *model.Payment) error
 	updateFn   func(ctx context.Context, p *model.Payment) error
-	recordFn   func(ctx context.Context, provider, eventID string) error
+	recordFn   func(ctx context.Context, event *model.ProviderEvent) error
+	getEventFn func(ctx context.Context, provider, eventID string) (*model.ProviderEvent, error)
+	saveFn     func(ctx context.Context, event *model.ProviderEvent) error
+	claimFn    func(ctx context.Context, limit int, lease time.Duration) ([]*model.ProviderEvent, error)
+	claimOneFn func(ctx context.Context, provider, eventID string, lease time.Duration) (*model.ProviderEvent, error)
+	failedFn   func(ctx context.Context, req *domain.ListFailedEventsReq) ([]*model.ProviderEvent, *paging.Pagination, error)
+	addFn      func(ctx context.Context, paymentID string, delta int64) error
+	syncFn     func(ctx context.Context, paymentID string, total int64) (int64, error)
+	listFn     func(ctx context.Context, before time.Time, limit int) ([]*model.Payment, error)
+	runs       []*model.ReconcileRun // stored by CreateReconcileRun
+	runErr     error                 // returned by CreateReconcileRun
 	createCall int
 	updateCall int
+	saved      []model.ProviderEvent
 }
 
 func (s *stubRepo) GetByOrderID(ctx context.Context, o string) (*model.Payment, error) {
 	return s.getFn(ctx, o)
 }
+func (s *stubRepo) GetByProviderIntentID(ctx context.Context, intentID string) (*model.Payment, error) {
+	return s.getByPIFn(ctx, intentID)
+}
 func (s *stubRepo) Create(ctx context.Context, p *model.Payment) error {
 	s.createCall++
 	return s.createFn(ctx, p)
@@ -48,8 +109,72 @@ func (s *stubRepo) Update(ctx context.Context, p *model.Payment) error {
 	s.updateCall++
 	return s.updateFn(ctx, p)
 }
-func (s *stubRepo) RecordProviderEvent(ctx context.Context, provider, eventID string) error {
-	return s.recordFn(ctx, provider, eventID)
+func (s *stubRepo) RecordProviderEvent(ctx context.Context, event *model.ProviderEvent) error {
+	return s.recordFn(ctx, event)
+}
+func (s *stubRepo) GetProviderEvent(ctx context.Context, provider, eventID string) (*model.ProviderEvent, error) {
+	return s.getEventFn(ctx, provider, eventID)
+}
+
+// UpdateProviderEvent keeps a copy of every saved inbox row, so tests can check the outcome.
+func (s *stubRepo) UpdateProviderEvent(ctx context.Context, event *model.ProviderEvent) error {
+	s.saved = append(s.saved, *event)
+	if s.saveFn == nil {
+		return nil
+	}
+	return s.saveFn(ctx, event)
+}
+func (s *stubRepo) ClaimDueEvents(ctx context.Context, limit int, lease time.Duration) ([]*model.ProviderEvent, error) {
+	return s.claimFn(ctx, limit, lease)
+}
+
+// ClaimEvent hands back the event GetProviderEvent finds unless claimOneFn is set.
+func (s *stubRepo) ClaimEvent(ctx context.Context, provider, eventID string, lease time.Duration) (*model.ProviderEvent, error) {
+	if s.claimOneFn == nil {
+		return s.getEventFn(ctx, provider, eventID)
+	}
+	return s.claimOneFn(ctx, provider, eventID, lease)
+}
+func (s *stubRepo) ListFailedEvents(ctx context.Context, req *domain.ListFailedEventsReq) ([]*model.ProviderEvent, *paging.Pagination, error) {
+	return s.failedFn(ctx, req)
+}
+
+func (s *stubRepo) AddRefunded(ctx context.Context, paymentID string, delta int64) error {
+	return s.addFn(ctx, paymentID, delta)
+}
+func (s *stubRepo) SyncRefunded(ctx context.Context, paymentID string, total int64) (int64, error) {
+	return s.syncFn(ctx, paymentID, total)
+}
+func (s *stubRepo) ListUnsettled(ctx context.Context, before time.Time, limit int) ([]*model.Payment, error) {
+	return s.listFn(ctx, before, limit)
+}
+func (s *stubRepo) CreateReconcileRun(_ context.Context, run *model.ReconcileRun) error {
+	if s.runErr != nil {
+		return s.runErr
+	}
+	run.ID = fmt.Sprintf("run%d", len(s.runs)+1)
+	s.runs = append(s.runs, run)
+	return nil
+}
+func (s *stubRepo) ListReconcileRuns(context.Context, *domain.ListReconcileRunsReq) ([]*model.ReconcileRun, *paging.Pagination, error) {
+	return s.runs, paging.New(1, 10, int64(len(s.runs))), nil
+}
+func (s *stubRepo) GetReconcileRun(_ context.Context, id string) (*model.ReconcileRun, error) {
+	for _, run := range s.runs {
+		if run.ID == id {
+			return run, nil
+		}
+	}
+	return nil, gorm.ErrRecordNotFound
+}
+
+// requireRetryPending checks the last inbox save kept the event pending with its apply error.
+func requireRetryPending(t *testing.T, repo *stubRepo) {
+	t.Helper()
+	require.NotEmpty(t, repo.saved)
+	last := repo.saved[len(repo.saved)-1]
+	require.Equal(t, model.ProviderEventStatusPending, last.Status)
+	require.NotEmpty(t, last.LastError)
 }
 
 type stubOrderQuery struct {
@@ -69,59 +194,82 @@ func TestCreateIntent_OrderNotFound(t *testing.T) {
 	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
 		return nil, errors.New("not found")
 	}}
-	svc := NewPaymentService(&stubProvider{}, &stubRepo{}, q, osvc)
-	_, err := svc.CreateIntentForOrder(context.Background(), "o1")
+	svc := NewPaymentService(nil, registryOf(&stubProvider{}), &stubRepo{}, &stubRefundRepo{}, &stubMethodRepo{}, q, osvc, &stubCreditNotes{}, payment.CaptureAutomatic)
+	_, err := svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "")
 	require.Error(t, err)
 }
 
 func TestCreateIntent_OrderNotPending(t *testing.T) {
 	osvc := newOrderSvcMock(t)
 	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
-		return &orderModel.Order{ID: "o1", Status: orderModel.OrderStatusPaid}, nil
+		return &orderModel.Order{ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPaid}, nil
 	}}
-	svc := NewPaymentService(&stubProvider{}, &stubRepo{}, q, osvc)
-	_, err := svc.CreateIntentForOrder(context.Background(), "o1")
+	svc := NewPaymentService(nil, registryOf(&stubProvider{}), &stubRepo{}, &stubRefundRepo{}, &stubMethodRepo{}, q, osvc, &stubCreditNotes{}, payment.CaptureAutomatic)
+	_, err := svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "")
 	require.Error(t, err)
 }
 
 func TestCreateIntent_ExistingRow_ReplaysProviderForFreshClientSecret(t *testing.T) {
 	osvc := newOrderSvcMock(t)
 	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
-		return &orderModel.Order{ID: "o1", Status: orderModel.OrderStatusPendingPayment, FinalPrice: 10}, nil
+		return &orderModel.Order{ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPendingPayment, FinalPrice: money.New(1000, "USD")}, nil
 	}}
 	repo := &stubRepo{getFn: func(_ context.Context, _ string) (*model.Payment, error) {
-		return &model.Payment{ProviderIntentID: "pi_1", Status: model.PaymentStatusPending, Amount: 1000, Currency: "usd"}, nil
+		return &model.Payment{Provider: "stripe", ProviderIntentID: "pi_1", Status: model.PaymentStatusPending, Amount: 1000, Currency: "usd"}, nil
 	}}
 	prov := &stubProvider{createFn: func(_ context.Context, p payment.CreateIntentParams) (*payment.Intent, error) {
 		require.Equal(t, "order_o1", p.IdempotencyKey)
 		// Stripe's idempotency replay returns the same intent with a fresh client_secret.
 		return &payment.Intent{ID: "pi_1", ClientSecret: "pi_1_secret_replay", Amount: 1000, Currency: "usd"}, nil
 	}}
-	svc := NewPaymentService(prov, repo, q, osvc)
-	intent, err := svc.CreateIntentForOrder(context.Background(), "o1")
+	svc := NewPaymentService(nil, registryOf(prov), repo, &stubRefundRepo{}, &stubMethodRepo{}, q, osvc, &stubCreditNotes{}, payment.CaptureAutomatic)
+	intent, err := svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "")
 	require.NoError(t, err)
 	require.Equal(t, "pi_1", intent.ID)
 	require.Equal(t, "pi_1_secret_replay", intent.ClientSecret, "must return a non-empty client_secret on repeat calls")
 	require.Equal(t, 0, repo.createCall, "must not write a second payment row on replay")
 }
 
+func TestCreateIntent_ManualCaptureOnlyAuthorizes(t *testing.T) {
+	for _, mode := range []payment.CaptureMode{payment.CaptureAutomatic, payment.CaptureManual} {
+		t.Run(string(mode), func(t *testing.T) {
+			q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
+				return &orderModel.Order{ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPendingPayment, FinalPrice: money.New(1000, "USD")}, nil
+			}}
+			repo := &stubRepo{
+				getFn:    func(_ context.Context, _ string) (*model.Payment, error) { return nil, gorm.ErrRecordNotFound },
+				createFn: func(_ context.Context, _ *model.Payment) error { return nil },
+			}
+			var got payment.CreateIntentParams
+			prov := &stubProvider{createFn: func(_ context.Context, p payment.CreateIntentParams) (*payment.Intent, error) {
+				got = p
+				return &payment.Intent{ID: "pi_1", Amount: p.Amount, Currency: p.Currency}, nil
+			}}
+			svc := NewPaymentService(nil, registryOf(prov), repo, &stubRefundRepo{}, &stubMethodRepo{}, q, newOrderSvcMock(t), &stubCreditNotes{}, mode)
+			_, err := svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "")
+			require.NoError(t, err)
+			require.Equal(t, mode == payment.CaptureManual, got.ManualCapture)
+		})
+	}
+}
+
 func TestCreateIntent_GetPaymentErrorPropagates(t *testing.T) {
 	osvc := newOrderSvcMock(t)
 	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
-		return &orderModel.Order{ID: "o1", Status: orderModel.OrderStatusPendingPayment}, nil
+		return &orderModel.Order{ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPendingPayment}, nil
 	}}
 	repo := &stubRepo{getFn: func(_ context.Context, _ string) (*model.Payment, error) {
 		return nil, errors.New("db down")
 	}}
-	svc := NewPaymentService(&stubProvider{}, repo, q, osvc)
-	_, err := svc.CreateIntentForOrder(context.Background(), "o1")
+	svc := NewPaymentService(nil, registryOf(&stubProvider{}), repo, &stubRefundRepo{}, &stubMethodRepo{}, q, osvc, &stubCreditNotes{}, payment.CaptureAutomatic)
+	_, err := svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "")
 	require.Error(t, err)
 }
 
 func TestCreateIntent_CreatesNewWhenNoExisting(t *testing.T) {
 	osvc := newOrderSvcMock(t)
 	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
-		return &orderModel.Order{ID: "o1", Status: orderModel.OrderStatusPendingPayment, FinalPrice: 1.5}, nil
+		return &orderModel.Order{ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPendingPayment, FinalPrice: money.New(150, "USD")}, nil
 	}}
 	repo := &stubRepo{
 		getFn: func(_ context.Context, _ string) (*model.Payment, error) { return nil, gorm.ErrRecordNotFound },
@@ -134,8 +282,8 @@ func TestCreateIntent_CreatesNewWhenNoExisting(t *testing.T) {
 		require.Equal(t, "order_o1", p.IdempotencyKey)
 		return &payment.Intent{ID: "pi_new", Amount: p.Amount, Currency: p.Currency}, nil
 	}}
-	svc := NewPaymentService(prov, repo, q, osvc)
-	intent, err := svc.CreateIntentForOrder(context.Background(), "o1")
+	svc := NewPaymentService(nil, registryOf(prov), repo, &stubRefundRepo{}, &stubMethodRepo{}, q, osvc, &stubCreditNotes{}, payment.CaptureAutomatic)
+	intent, err := svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "")
 	require.NoError(t, err)
 	require.Equal(t, "pi_new", intent.ID)
 }
@@ -143,21 +291,21 @@ func TestCreateIntent_CreatesNewWhenNoExisting(t *testing.T) {
 func TestCreateIntent_ProviderError(t *testing.T) {
 	osvc := newOrderSvcMock(t)
 	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
-		return &orderModel.Order{ID: "o1", Status: orderModel.OrderStatusPendingPayment}, nil
+		return &orderModel.Order{ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPendingPayment}, nil
 	}}
 	repo := &stubRepo{getFn: func(_ context.Context, _ string) (*model.Payment, error) { return nil, gorm.ErrRecordNotFound }}
 	prov := &stubProvider{createFn: func(_ context.Context, _ payment.CreateIntentParams) (*payment.Intent, error) {
 		return nil, errors.New("stripe down")
 	}}
-	svc := NewPaymentService(prov, repo, q, osvc)
-	_, err := svc.CreateIntentForOrder(context.Background(), "o1")
+	svc := NewPaymentService(nil, registryOf(prov), repo, &stubRefundRepo{}, &stubMethodRepo{}, q, osvc, &stubCreditNotes{}, payment.CaptureAutomatic)
+	_, err := svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "")
 	require.Error(t, err)
 }
 
 func TestCreateIntent_RepoCreateError(t *testing.T) {
 	osvc := newOrderSvcMock(t)
 	q := &stubOrderQuery{getFn: func(_ context.Context, _ string) (*orderModel.Order, error) {
-		return &orderModel.Order{ID: "o1", Status: orderModel.OrderStatusPendingPayment}, nil
+		return &orderModel.Order{ID: "o1", UserID: "u1", Status: orderModel.OrderStatusPendingPayment}, nil
 	}}
 	repo := &stubRepo{
 		getFn:    func(_ context.Context, _ string) (*model.Payment, error) { return nil, gorm.ErrRecordNotFound },
@@ -166,80 +314,90 @@ func TestCreateIntent_RepoCreateError(t *testing.T) {
 	prov := &stubProvider{createFn: func(_ context.Context, _ payment.CreateIntentParams) (*payment.Intent, error) {
 		return &payment.Intent{ID: "pi"}, nil
 	}}
-	svc := NewPaymentService(prov, repo, q, osvc)
-	_, err := svc.CreateIntentForOrder(context.Background(), "o1")
+	svc := NewPaymentService(nil, registryOf(prov), repo, &stubRefundRepo{}, &stubMethodRepo{}, q, osvc, &stubCreditNotes{}, payment.CaptureAutomatic)
+	_, err := svc.CreateIntentForOrder(context.Background(), "o1", "u1", "", "")
 	require.Error(t, err)
 }
 
 func TestHandleWebhook_VerifyError(t *testing.T) {
-	prov := &stubProvider{verifyFn: func(_ []byte, _ string) (*payment.Event, error) {
+	prov := &stubProvider{verifyFn: func(_ []byte, _ http.Header) (*payment.Event, error) {
 		return nil, payment.ErrInvalidSignature
 	}}
-	svc := NewPaymentService(prov, &stubRepo{}, &stubOrderQuery{}, newOrderSvcMock(t))
-	require.Error(t, svc.HandleWebhook(context.Background(), nil, "sig"))
+	svc := NewPaymentService(nil, registryOf(prov), &stubRepo{}, &stubRefundRepo{}, &stubMethodRepo{}, &stubOrderQuery{}, newOrderSvcMock(t), &stubCreditNotes{}, payment.CaptureAutomatic)
+	require.Error(t, svc.HandleWebhook(context.Background(), "stripe", nil, nil))
 }
 
 func TestHandleWebhook_DuplicateIsNoop(t *testing.T) {
-	prov := &stubProvider{verifyFn: func(_ []byte, _ string) (*payment.Event, error) {
+	prov := &stubProvider{verifyFn: func(_ []byte, _ http.Header) (*payment.Event, error) {
 		return &payment.Event{ID: "evt_1", OrderID: "o1", Type: payment.EventPaymentSucceeded}, nil
 	}}
-	repo := &stubRepo{recordFn: func(_ context.Context, _, _ string) error { return repository.ErrEventAlreadyProcessed }}
-	svc := NewPaymentService(prov, repo, &stubOrderQuery{}, newOrderSvcMock(t))
-	require.NoError(t, svc.HandleWebhook(context.Background(), nil, "sig"))
+	repo := &stubRepo{recordFn: func(_ context.Context, _ *model.ProviderEvent) error { return repository.ErrEventAlreadyProcessed }}
+	svc := NewPaymentService(nil, registryOf(prov), repo, &stubRefundRepo{}, &stubMethodRepo{}, &stubOrderQuery{}, newOrderSvcMock(t), &stubCreditNotes{}, payment.CaptureAutomatic)
+	require.NoError(t, svc.HandleWebhook(context.Background(), "stripe", nil, nil))
 }
 
 func TestHandleWebhook_RecordError(t *testing.T) {
-	prov := &stubProvider{verifyFn: func(_ []byte, _ string) (*payment.Event, error) {
+	prov := &stubProvider{verifyFn: func(_ []byte, _ http.Header) (*payment.Event, error) {
 		return &payment.Event{ID: "evt_1", OrderID: "o1"}, nil
 	}}
-	repo := &stubRepo{recordFn: func(_ context.Context, _, _ string) error { return errors.New("db down") }}
-	svc := NewPaymentService(prov, repo, &stubOrderQuery{}, newOrderSvcMock(t))
-	require.Error(t, svc.HandleWebhook(context.Background(), nil, "sig"))
+	repo := &stubRepo{recordFn: func(_ context.Context, _ *model.ProviderEvent) error { return errors.New("db down") }}
+	svc := NewPaymentService(nil, registryOf(prov), repo, &stubRefundRepo{}, &stubMethodRepo{}, &stubOrderQuery{}, newOrderSvcMock(t), &stubCreditNotes{}, payment.CaptureAutomatic)
+	require.Error(t, svc.HandleWebhook(context.Background(), "stripe", nil, nil))
 }
 
 func TestHandleWebhook_MissingOrderID(t *testing.T) {
-	prov := &stubProvider{verifyFn: func(_ []byte, _ string) (*payment.Event, error) {
+	prov := &stubProvider{verifyFn: func(_ []byte, _ http.Header) (*payment.Event, error) {
 		return &payment.Event{ID: "evt_1"}, nil
 	}}
-	repo := &stubRepo{recordFn: func(_ context.Context, _, _ string) error { return nil }}
-	svc := NewPaymentService(prov, repo, &stubOrderQuery{}, newOrderSvcMock(t))
-	require.Error(t, svc.HandleWebhook(context.Background(), nil, "sig"))
+	repo := &stubRepo{recordFn: func(_ context.Context, event *model.ProviderEvent) error {
+		// Recorded already claimed, so neither the worker nor a replay applies it meanwhile.
+		require.Equal(t, model.ProviderEventStatusProcessing, event.Status)
+		return nil
+	}}
+	svc := NewPaymentService(nil, registryOf(prov), repo, &stubRefundRepo{}, &stubMethodRepo{}, &stubOrderQuery{}, newOrderSvcMock(t), &stubCreditNotes{}, payment.CaptureAutomatic)
+	require.NoError(t, svc.HandleWebhook(context.Background(), "stripe", nil, nil))
+	requireRetryPending(t, repo)
 }
 
 func TestHandleWebhook_GetByOrderError(t *testing.T) {
-	prov := &stubProvider{verifyFn: func(_ []byte, _ string) (*payment.Event, error) {
+	prov := &stubProvider{verifyFn: func(_ []byte, _ http.Header) (*payment.Event, error) {
 		return &payment.Event{ID: "evt", OrderID: "o1", Type: payment.EventPaymentSucceeded}, nil
 	}}
 	repo := &stubRepo{
-		recordFn: func(_ context.Context, _, _ string) error { return nil },
+		recordFn: func(_ context.Context, _ *model.ProviderEvent) error { return nil },
 		getFn:    func(_ context.Context, _ string) (*model.Payment, error) { return nil, errors.New("gone") },
 	}
-	svc := NewPaymentService(prov, repo, &stubOrderQuery{}, newOrderSvcMock(t))
-	require.Error(t, svc.HandleWebhook(context.Background(), nil, "sig"))
+	svc := NewPaymentService(nil, registryOf(prov), repo, &stubRefundRepo{}, &stubMethodRepo{}, &stubOrderQuery{}, newOrderSvcMock(t), &stubCreditNotes{}, payment.CaptureAutomatic)
+	require.NoError(t, svc.HandleWebhook(context.Background(), "stripe", nil, nil))
+	requireRetryPending(t, repo)
 }
 
 func newWebhookSvc(t *testing.T, evt payment.EventType) (PaymentService, *stubRepo, *orderSvcMocks.OrderService) {
-	prov := &stubProvider{verifyFn: func(_ []byte, _ string) (*payment.Event, error) {
+	prov := &stubProvider{verifyFn: func(_ []byte, _ http.Header) (*payment.Event, error) {
 		return &payment.Event{ID: "evt", OrderID: "o1", Type: evt}, nil
 	}}
 	repo := &stubRepo{
-		recordFn: func(_ context.Context, _, _ string) error { return nil },
-		getFn:    func(_ context.Context, _ string) (*model.Payment, error) { return &model.Payment{ID: "p1"}, nil },
+		recordFn: func(_ context.Context, _ *model.ProviderEvent) error { return nil },
+		getFn: func(_ context.Context, _ string) (*model.Payment, error) {
+			return &model.Payment{ID: "p1", Provider: "stripe"}, nil
+		},
 		updateFn: func(_ context.Context, _ *model.Payment) error { return nil },
 	}
 	osvc := newOrderSvcMock(t)
-	return NewPaymentService(prov, repo, &stubOrderQuery{}, osvc), repo, osvc
+	return NewPaymentService(nil, registryOf(prov), repo, &stubRefundRepo{}, &stubMethodRepo{}, &stubOrderQuery{}, osvc, &stubCreditNotes{}, payment.CaptureAutomatic), repo, osvc
 }
 
 // TestHandleWebhook_PerEventType covers the per-event-type dispatch matrix in
 // HandleWebhook: for each provider event we exercise the happy path, the
 // repo.Update failure, and (where applicable) the downstream orderService
 // failure. The flow inside the handler is always repo.Update → orderService,
-// so an Update failure short-circuits before any orderService call.
+// so an Update failure short-circuits before any orderService call. Failures
+// are acknowledged and left pending in the inbox for a retry.
 func TestHandleWebhook_PerEventType(t *testing.T) {
 	const (
-		osvcMarkPaid = "MarkOrderPaid"
-		osvcUpdate   = "UpdateOrderStatus"
+		osvcMarkPaid       = "MarkOrderPaid"
+		osvcMarkAuthorized = "MarkOrderAuthorized"
+		osvcUpdate         = "UpdateOrderStatus"
 	)
 	type osvcCall struct {
 		method    string
@@ -251,7 +409,7 @@ func TestHandleWebhook_PerEventType(t *testing.T) {
 		event     payment.EventType
 		updateErr bool
 		osvc      *osvcCall
-		wantErr   bool
+		wantRetry bool
 	}{
 		{"succeeded_happy", payment.EventPaymentSucceeded, false, &osvcCall{method: osvcMarkPaid}, false},
 		{"succeeded_update_error", payment.EventPaymentSucceeded, true, nil, true},
@@ -265,6 +423,10 @@ func TestHandleWebhook_PerEventType(t *testing.T) {
 		{"canceled_update_error", payment.EventPaymentCanceled, true, nil, true},
 		{"canceled_order_update_error", payment.EventPaymentCanceled, false, &osvcCall{method: osvcUpdate, newStatus: orderModel.OrderStatusCancelled, returnErr: true}, true},
 
+		{"authorized_happy", payment.EventPaymentAuthorized, false, &osvcCall{method: osvcMarkAuthorized}, false},
+		{"authorized_update_error", payment.EventPaymentAuthorized, true, nil, true},
+		{"authorized_mark_authorized_error", payment.EventPaymentAuthorized, false, &osvcCall{method: osvcMarkAuthorized, returnErr: true}, true},
+
 		{"processing_happy", payment.EventPaymentProcessing, false, nil, false},
 		{"processing_update_error", payment.EventPaymentProcessing, true, nil, true},
 
@@ -293,19 +455,21 @@ func TestHandleWebhook_PerEventType(t *testing.T) {
 				switch tt.osvc.method {
 				case osvcMarkPaid:
 					osvc.On("MarkOrderPaid", mock.Anything, "o1").Return(retOrder, retErr).Once()
+				case osvcMarkAuthorized:
+					osvc.On("MarkOrderAuthorized", mock.Anything, "o1").Return(retOrder, retErr).Once()
 				case osvcUpdate:
 					osvc.On("UpdateOrderStatus", mock.Anything, "o1", tt.osvc.newStatus).Return(retOrder, retErr).Once()
 				}
 			}
 
-			err := svc.HandleWebhook(context.Background(), nil, "sig")
-			if tt.wantErr {
-				require.Error(t, err)
-			} else {
-				require.NoError(t, err)
-				if tt.event == payment.EventPaymentSucceeded {
-					require.Equal(t, 1, repo.updateCall)
-				}
+			require.NoError(t, svc.HandleWebhook(context.Background(), "stripe", nil, nil))
+			if tt.wantRetry {
+				requireRetryPending(t, repo)
+				return
+			}
+			require.Equal(t, model.ProviderEventStatusProcessed, repo.saved[len(repo.saved)-1].Status)
+			if tt.event == payment.EventPaymentSucceeded {
+				require.Equal(t, 1, repo.updateCall)
 			}
 		})
 	}
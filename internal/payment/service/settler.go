@@ -0,0 +1,72 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	orderService "goshop/internal/order/service"
+	"goshop/internal/payment/model"
+	"goshop/internal/payment/repository"
+	"goshop/pkg/payment"
+)
+
+// paymentSettler captures and voids authorized payments for the order service. It only needs
+// the payment rows and providers, so an OrderService can be built with one before the
+// PaymentService that depends on it.
+type paymentSettler struct {
+	providers *payment.Registry
+	repo      repository.PaymentRepository
+}
+
+func NewPaymentSettler(providers *payment.Registry, repo repository.PaymentRepository) orderService.PaymentSettler {
+	return &paymentSettler{providers: providers, repo: repo}
+}
+
+// CaptureOrderPayment charges the held funds. The payment waits in processing for the
+// provider's succeeded webhook, or the reconciler, like any other capture.
+func (s *paymentSettler) CaptureOrderPayment(ctx context.Context, orderID string) error {
+	rec, provider, err := s.authorized(ctx, orderID)
+	if rec == nil || err != nil {
+		return err
+	}
+	if err := provider.Capture(ctx, rec.ProviderIntentID); err != nil {
+		return err
+	}
+	rec.Status = model.PaymentStatusProcessing
+	return s.repo.Update(ctx, rec)
+}
+
+// VoidOrderPayment releases the held funds; nothing was charged, so nothing needs refunding.
+func (s *paymentSettler) VoidOrderPayment(ctx context.Context, orderID string) error {
+	rec, provider, err := s.authorized(ctx, orderID)
+	if rec == nil || err != nil {
+		return err
+	}
+	if err := provider.Void(ctx, rec.ProviderIntentID); err != nil {
+		return err
+	}
+	rec.Status = model.PaymentStatusCanceled
+	return s.repo.Update(ctx, rec)
+}
+
+// authorized returns the order's latest payment and its provider when the payment is
+// authorized, and a nil payment when there is nothing to settle.
+func (s *paymentSettler) authorized(ctx context.Context, orderID string) (*model.Payment, payment.Provider, error) {
+	rec, err := s.repo.GetByOrderID(ctx, orderID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if rec.Status != model.PaymentStatusAuthorized {
+		return nil, nil, nil
+	}
+	provider, err := s.providers.Get(rec.Provider)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rec, provider, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"goshop/internal/payment/model"
+)
+
+// newSettlerFixture settles rec, a payment through a stripe stub that records the intents it
+// captured and voided. A nil rec means the order has no payment.
+func newSettlerFixture(rec *model.Payment) (*paymentSettler, *stubRepo, *[]string) {
+	calls := []string{}
+	prov := &stubProvider{
+		captureFn: func(_ context.Context, intentID string) error {
+			calls = append(calls, "capture "+intentID)
+			return nil
+		},
+		voidFn: func(_ context.Context, intentID string) error {
+			calls = append(calls, "void "+intentID)
+			return nil
+		},
+	}
+	repo := &stubRepo{
+		getFn: func(_ context.Context, _ string) (*model.Payment, error) {
+			if rec == nil {
+				return nil, gorm.ErrRecordNotFound
+			}
+			return rec, nil
+		},
+		updateFn: func(_ context.Context, _ *model.Payment) error { return nil },
+	}
+	return NewPaymentSettler(registryOf(prov), repo).(*paymentSettler), repo, &calls
+}
+
+func TestSettler_CapturesAuthorizedPayment(t *testing.T) {
+	rec := &model.Payment{ID: "p1", Provider: "stripe", ProviderIntentID: "pi_1", Status: model.PaymentStatusAuthorized}
+	s, repo, calls := newSettlerFixture(rec)
+
+	require.NoError(t, s.CaptureOrderPayment(context.Background(), "o1"))
+	require.Equal(t, []string{"capture pi_1"}, *calls)
+	require.Equal(t, model.PaymentStatusProcessing, rec.Status, "settled by the succeeded webhook")
+	require.Equal(t, 1, repo.updateCall)
+}
+
+func TestSettler_VoidsAuthorizedPayment(t *testing.T) {
+	rec := &model.Payment{ID: "p1", Provider: "stripe", ProviderIntentID: "pi_1", Status: model.PaymentStatusAuthorized}
+	s, repo, calls := newSettlerFixture(rec)
+
+	require.NoError(t, s.VoidOrderPayment(context.Background(), "o1"))
+	require.Equal(t, []string{"void pi_1"}, *calls)
+	require.Equal(t, model.PaymentStatusCanceled, rec.Status)
+	require.Equal(t, 1, repo.updateCall)
+}
+
+func TestSettler_NothingToSettle(t *testing.T) {
+	tests := []struct {
+		name string
+		rec  *model.Payment
+	}{
+		{"no_payment", nil},
+		{"captured_immediately", &model.Payment{Provider: "stripe", ProviderIntentID: "pi_1", Status: model.PaymentStatusSucceeded}},
+		{"still_pending", &model.Payment{Provider: "stripe", ProviderIntentID: "pi_1", Status: model.PaymentStatusPending}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo, calls := newSettlerFixture(tt.rec)
+			require.NoError(t, s.CaptureOrderPayment(context.Background(), "o1"))
+			require.NoError(t, s.VoidOrderPayment(context.Background(), "o1"))
+			require.Empty(t, *calls)
+			require.Zero(t, repo.updateCall)
+		})
+	}
+}
+
+func TestSettler_ProviderErrorKeepsAuthorization(t *testing.T) {
+	rec := &model.Payment{ID: "p1", Provider: "stripe", ProviderIntentID: "pi_1", Status: model.PaymentStatusAuthorized}
+	s, repo, _ := newSettlerFixture(rec)
+	prov, err := s.providers.Get("stripe")
+	require.NoError(t, err)
+	prov.(*stubProvider).captureFn = func(context.Context, string) error { return errors.New("authorization expired") }
+
+	require.Error(t, s.CaptureOrderPayment(context.Background(), "o1"))
+	require.Equal(t, model.PaymentStatusAuthorized, rec.Status)
+	require.Zero(t, repo.updateCall)
+}
+
+func TestSettler_LookupErrorPropagates(t *testing.T) {
+	s, _, _ := newSettlerFixture(nil)
+	s.repo.(*stubRepo).getFn = func(context.Context, string) (*model.Payment, error) { return nil, errors.New("db down") }
+
+	require.Error(t, s.CaptureOrderPayment(context.Background(), "o1"))
+	require.Error(t, s.VoidOrderPayment(context.Background(), "o1"))
+}
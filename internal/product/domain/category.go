@@ -3,22 +3,32 @@ package domain
 import "time"
 
 type Category struct {
-	ID          string    `json:"id"`
-	Name        string    `json:"name"`
-	Slug        string    `json:"slug"`
-	Description string    `json:"description"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Slug        string `json:"slug"`
+	Description string `json:"description"`
+	// LowStockThreshold, ReorderQuantity and TaxClass apply to products that don't set their own.
+	LowStockThreshold *int      `json:"low_stock_threshold"`
+	ReorderQuantity   *int      `json:"reorder_quantity"`
+	TaxClass          string    `json:"tax_class,omitempty"`
+	CreatedAt         time.Time `json:"created_at"`
+	UpdatedAt         time.Time `json:"updated_at"`
 }
 
 type CreateCategoryReq struct {
-	Name        string `json:"name" validate:"required"`
-	Slug        string `json:"slug" validate:"required"`
-	Description string `json:"description"`
+	Name              string `json:"name" validate:"required"`
+	Slug              string `json:"slug" validate:"required"`
+	Description       string `json:"description"`
+	LowStockThreshold *int   `json:"low_stock_threshold,omitempty" validate:"omitempty,gte=0"`
+	ReorderQuantity   *int   `json:"reorder_quantity,omitempty" validate:"omitempty,gte=0"`
+	TaxClass          string `json:"tax_class,omitempty" validate:"omitempty,max=64"`
 }
 
 type UpdateCategoryReq struct {
-	Name        string `json:"name,omitempty"`
-	Slug        string `json:"slug,omitempty"`
-	Description string `json:"description,omitempty"`
+	Name              string `json:"name,omitempty"`
+	Slug              string `json:"slug,omitempty"`
+	Description       string `json:"description,omitempty"`
+	LowStockThreshold *int   `json:"low_stock_threshold,omitempty" validate:"omitempty,gte=0"`
+	ReorderQuantity   *int   `json:"reorder_quantity,omitempty" validate:"omitempty,gte=0"`
+	TaxClass          string `json:"tax_class,omitempty" validate:"omitempty,max=64"`
 }
@@ -16,6 +16,10 @@ func CategoryFromModel(m *model.Category) *Category {
 		Description: m.Description,
 		CreatedAt:   m.CreatedAt,
 		UpdatedAt:   m.UpdatedAt,
+
+		LowStockThreshold: m.LowStockThreshold,
+		ReorderQuantity:   m.ReorderQuantity,
+		TaxClass:          m.TaxClass,
 	}
 }
 
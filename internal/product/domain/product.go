@@ -3,23 +3,33 @@ package domain
 import (
 	"time"
 
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
 )
 
 type Product struct {
-	ID            string    `json:"id"`
-	Code          string    `json:"code"`
-	Name          string    `json:"name"`
-	Description   string    `json:"description"`
-	Price         float64   `json:"price"`
-	Active        bool      `json:"active"`
-	StockQuantity int       `json:"stock_quantity"`
-	AvgRating     float64   `json:"avg_rating"`
-	ReviewCount   int       `json:"review_count"`
-	Images        []string  `json:"images"`
-	CategoryID    string    `json:"category_id,omitempty"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
+	ID            string      `json:"id"`
+	Code          string      `json:"code"`
+	Name          string      `json:"name"`
+	Description   string      `json:"description"`
+	Price         money.Money `json:"price"`
+	Active        bool        `json:"active"`
+	StockQuantity int         `json:"stock_quantity"`
+	WeightGrams   int64       `json:"weight_grams"`
+	TaxClass      string      `json:"tax_class,omitempty"`
+	// LowStockThreshold and ReorderQuantity are the product's own settings (null inherits from
+	// the category); the effective values and LowStock are what the stock badge should read.
+	LowStockThreshold          *int      `json:"low_stock_threshold"`
+	ReorderQuantity            *int      `json:"reorder_quantity"`
+	EffectiveLowStockThreshold int       `json:"effective_low_stock_threshold"`
+	EffectiveReorderQuantity   int       `json:"effective_reorder_quantity"`
+	LowStock                   bool      `json:"low_stock"`
+	AvgRating                  float64   `json:"avg_rating"`
+	ReviewCount                int       `json:"review_count"`
+	Images                     []string  `json:"images"`
+	CategoryID                 string    `json:"category_id,omitempty"`
+	CreatedAt                  time.Time `json:"created_at"`
+	UpdatedAt                  time.Time `json:"updated_at"`
 }
 
 type ListProductReq struct {
@@ -38,19 +48,40 @@ type ListProductRes struct {
 }
 
 type CreateProductReq struct {
-	Name          string   `json:"name" validate:"required"`
-	Description   string   `json:"description" validate:"required"`
-	Price         float64  `json:"price" validate:"gt=0"`
-	StockQuantity int      `json:"stock_quantity" validate:"gte=0"`
-	Images        []string `json:"images,omitempty"`
-	CategoryID    string   `json:"category_id,omitempty"`
+	Name        string `json:"name" validate:"required"`
+	Description string `json:"description" validate:"required"`
+	// Price is in the shop's base currency.
+	Price         money.Money `json:"price"`
+	StockQuantity int         `json:"stock_quantity" validate:"gte=0"`
+	// WeightGrams is one unit's shipping weight.
+	WeightGrams int64 `json:"weight_grams,omitempty" validate:"gte=0"`
+	// TaxClass picks the product's tax rates; omit to inherit the category's.
+	TaxClass   string   `json:"tax_class,omitempty" validate:"omitempty,max=64"`
+	Images     []string `json:"images,omitempty"`
+	CategoryID string   `json:"category_id,omitempty"`
+	// LowStockThreshold and ReorderQuantity override the category's; omit to inherit.
+	LowStockThreshold *int `json:"low_stock_threshold,omitempty" validate:"omitempty,gte=0"`
+	ReorderQuantity   *int `json:"reorder_quantity,omitempty" validate:"omitempty,gte=0"`
+	// WarehouseID receives the opening stock; empty means the default warehouse.
+	WarehouseID string `json:"warehouse_id,omitempty"`
+	// ActorID is the admin making the change, recorded in the stock ledger.
+	ActorID string `json:"-"`
 }
 
 type UpdateProductReq struct {
-	Name          string   `json:"name,omitempty"`
-	Description   string   `json:"description,omitempty"`
-	Price         float64  `json:"price,omitempty" validate:"gte=0"`
-	StockQuantity *int     `json:"stock_quantity,omitempty" validate:"omitempty,gte=0"`
-	Images        []string `json:"images,omitempty"`
-	CategoryID    string   `json:"category_id,omitempty"`
+	Name              string      `json:"name,omitempty"`
+	Description       string      `json:"description,omitempty"`
+	Price             money.Money `json:"price,omitzero"`
+	StockQuantity     *int        `json:"stock_quantity,omitempty" validate:"omitempty,gte=0"`
+	WeightGrams       *int64      `json:"weight_grams,omitempty" validate:"omitempty,gte=0"`
+	TaxClass          string      `json:"tax_class,omitempty" validate:"omitempty,max=64"`
+	Images            []string    `json:"images,omitempty"`
+	CategoryID        string      `json:"category_id,omitempty"`
+	LowStockThreshold *int        `json:"low_stock_threshold,omitempty" validate:"omitempty,gte=0"`
+	ReorderQuantity   *int        `json:"reorder_quantity,omitempty" validate:"omitempty,gte=0"`
+	// StockReason explains a stock_quantity change in the stock ledger, and WarehouseID names
+	// the warehouse that absorbs it (empty means the default warehouse).
+	StockReason string `json:"stock_reason,omitempty"`
+	WarehouseID string `json:"warehouse_id,omitempty"`
+	ActorID     string `json:"-"`
 }
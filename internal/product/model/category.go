@@ -15,6 +15,13 @@ type Category struct {
 	Name        string     `json:"name" gorm:"uniqueIndex;not null"`
 	Slug        string     `json:"slug" gorm:"uniqueIndex;not null"`
 	Description string     `json:"description"`
+	// LowStockThreshold and ReorderQuantity apply to products in the category that don't set
+	// their own; nil falls back to the shop-wide default (no reorder point).
+	LowStockThreshold *int `json:"low_stock_threshold"`
+	ReorderQuantity   *int `json:"reorder_quantity"`
+	// TaxClass applies to products in the category that don't name one; empty is the standard
+	// class.
+	TaxClass string `json:"tax_class"`
 }
 
 func (c *Category) BeforeCreate(tx *gorm.DB) error {
@@ -6,27 +6,44 @@ import (
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
+	"goshop/pkg/currency"
+	"goshop/pkg/money"
+	"goshop/pkg/stock"
 	"goshop/pkg/utils"
 )
 
 type Product struct {
-	ID            string     `json:"id" gorm:"unique;not null;index;primary_key"`
-	CreatedAt     time.Time  `json:"created_at"`
-	UpdatedAt     time.Time  `json:"updated_at"`
-	DeletedAt     *time.Time `json:"deleted_at" gorm:"index"`
-	Code          string     `json:"code" gorm:"uniqueIndex:idx_product_code,not null"`
-	Name          string     `json:"name" gorm:"uniqueIndex:idx_product_name,not null"`
-	Description   string     `json:"description"`
-	Price         float64    `json:"price"`
-	Active        bool       `json:"active" gorm:"default:true"`
-	StockQuantity int        `json:"stock_quantity" gorm:"default:0;check:stock_quantity >= 0"`
+	ID          string      `json:"id" gorm:"unique;not null;index;primary_key"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+	DeletedAt   *time.Time  `json:"deleted_at" gorm:"index"`
+	Code        string      `json:"code" gorm:"uniqueIndex:idx_product_code,not null"`
+	Name        string      `json:"name" gorm:"uniqueIndex:idx_product_name,not null"`
+	Description string      `json:"description"`
+	Price       money.Money `json:"price" gorm:"column:price_minor"`
+	// Currency is Price's; prices are kept in the shop's base currency.
+	Currency      string `json:"currency"`
+	Active        bool   `json:"active" gorm:"default:true"`
+	StockQuantity int    `json:"stock_quantity" gorm:"default:0;check:stock_quantity >= 0"`
 	// ReservedQuantity is units held by in-flight orders. Available = StockQuantity - ReservedQuantity.
-	ReservedQuantity int       `json:"reserved_quantity" gorm:"default:0;check:reserved_quantity >= 0"`
-	AvgRating        float64   `json:"avg_rating" gorm:"default:0"`
-	ReviewCount      int       `json:"review_count" gorm:"default:0"`
-	Images           []string  `json:"images" gorm:"serializer:json"`
-	CategoryID       *string   `json:"category_id"`
-	Category         *Category `json:"category,omitempty"`
+	ReservedQuantity int `json:"reserved_quantity" gorm:"default:0;check:reserved_quantity >= 0"`
+	// WeightGrams is one unit's shipping weight, for weight-tiered shipping rates.
+	WeightGrams int64 `json:"weight_grams" gorm:"default:0;check:weight_grams >= 0"`
+	// TaxClass picks the tax rates the product is sold at; empty inherits the category's.
+	TaxClass string `json:"tax_class"`
+	// LowStockThreshold and ReorderQuantity override the category's; nil inherits.
+	LowStockThreshold *int      `json:"low_stock_threshold"`
+	ReorderQuantity   *int      `json:"reorder_quantity"`
+	AvgRating         float64   `json:"avg_rating" gorm:"default:0"`
+	ReviewCount       int       `json:"review_count" gorm:"default:0"`
+	Images            []string  `json:"images" gorm:"serializer:json"`
+	CategoryID        *string   `json:"category_id"`
+	Category          *Category `json:"category,omitempty"`
+
+	// Resolved from the product, its category and the shop-wide default; see ResolveStockLevel.
+	EffectiveLowStockThreshold int  `json:"effective_low_stock_threshold" gorm:"-"`
+	EffectiveReorderQuantity   int  `json:"effective_reorder_quantity" gorm:"-"`
+	LowStock                   bool `json:"low_stock" gorm:"-"`
 }
 
 func (m *Product) BeforeCreate(tx *gorm.DB) error {
@@ -35,3 +52,35 @@ func (m *Product) BeforeCreate(tx *gorm.DB) error {
 	m.Active = true
 	return nil
 }
+
+// BeforeSave records Price's currency in the currency column.
+func (m *Product) BeforeSave(tx *gorm.DB) error {
+	if code := m.Price.Currency(); code != "" {
+		m.Currency = code
+	}
+	if m.Currency == "" {
+		m.Currency = currency.Default
+	}
+	return nil
+}
+
+// AfterFind applies the row's currency to Price and resolves the stock level once the row
+// (and its preloaded Category) is loaded.
+func (m *Product) AfterFind(tx *gorm.DB) error {
+	m.Price = m.Price.WithCurrency(m.Currency)
+	m.ResolveStockLevel()
+	return nil
+}
+
+// ResolveStockLevel fills the effective threshold, reorder quantity and LowStock flag from the
+// product's own settings, then Category's when loaded, then the shop-wide default.
+func (m *Product) ResolveStockLevel() {
+	var categoryThreshold, categoryReorder *int
+	if m.Category != nil {
+		categoryThreshold = m.Category.LowStockThreshold
+		categoryReorder = m.Category.ReorderQuantity
+	}
+	m.EffectiveLowStockThreshold = stock.LowStockThreshold(m.LowStockThreshold, categoryThreshold)
+	m.EffectiveReorderQuantity = stock.ReorderQuantity(m.ReorderQuantity, categoryReorder)
+	m.LowStock = stock.IsLow(m.StockQuantity-m.ReservedQuantity, m.EffectiveLowStockThreshold)
+}
@@ -14,3 +14,47 @@ func TestProduct_BeforeCreate(t *testing.T) {
 	assert.NotEmpty(t, p.Code)
 	assert.True(t, p.Active)
 }
+
+func TestProduct_ResolveStockLevel(t *testing.T) {
+	two, ten, fifty := 2, 10, 50
+	tests := []struct {
+		name          string
+		product       *Product
+		wantThreshold int
+		wantReorder   int
+		wantLow       bool
+	}{
+		{
+			name:          "default",
+			product:       &Product{StockQuantity: 8, ReservedQuantity: 3},
+			wantThreshold: 5,
+			wantLow:       true,
+		},
+		{
+			name: "category",
+			product: &Product{
+				StockQuantity: 20, ReservedQuantity: 5,
+				Category: &Category{LowStockThreshold: &ten, ReorderQuantity: &fifty},
+			},
+			wantThreshold: 10,
+			wantReorder:   50,
+		},
+		{
+			name: "product overrides category",
+			product: &Product{
+				StockQuantity: 3, LowStockThreshold: &two,
+				Category: &Category{LowStockThreshold: &ten, ReorderQuantity: &fifty},
+			},
+			wantThreshold: 2,
+			wantReorder:   50,
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.NoError(t, tc.product.AfterFind(nil))
+			assert.Equal(t, tc.wantThreshold, tc.product.EffectiveLowStockThreshold)
+			assert.Equal(t, tc.wantReorder, tc.product.EffectiveReorderQuantity)
+			assert.Equal(t, tc.wantLow, tc.product.LowStock)
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package grpc
+
+import (
+	"goshop/internal/product/model"
+	"goshop/pkg/money"
+	moneypb "goshop/proto/gen/go/money"
+	pb "goshop/proto/gen/go/product"
+)
+
+// productInfoFromModel returns the gRPC ProductInfo for a storage product. The price goes
+// out exactly in price_money and, for older clients, as the deprecated float.
+func productInfoFromModel(m *model.Product) *pb.ProductInfo {
+	if m == nil {
+		return nil
+	}
+	return &pb.ProductInfo{
+		Id:                         m.ID,
+		Code:                       m.Code,
+		Name:                       m.Name,
+		Description:                m.Description,
+		Price:                      float32(m.Price.Float64()),
+		PriceMoney:                 moneyToPB(m.Price),
+		Active:                     m.Active,
+		LowStockThreshold:          optionalUint32(m.LowStockThreshold),
+		ReorderQuantity:            optionalUint32(m.ReorderQuantity),
+		EffectiveLowStockThreshold: uint32(m.EffectiveLowStockThreshold), //nolint:gosec // thresholds are small non-negative counts
+		EffectiveReorderQuantity:   uint32(m.EffectiveReorderQuantity),   //nolint:gosec // as above
+		LowStock:                   m.LowStock,
+	}
+}
+
+func productInfosFromModel(rows []*model.Product) []*pb.ProductInfo {
+	out := make([]*pb.ProductInfo, len(rows))
+	for i, r := range rows {
+		out[i] = productInfoFromModel(r)
+	}
+	return out
+}
+
+// priceFromRequest reads a request's price: price_money when set, else the deprecated float
+// in the shop's base currency. An unset price is the zero Money.
+func priceFromRequest(exact *moneypb.Money, legacy float32, base string) money.Money {
+	if exact != nil {
+		return money.New(exact.Amount, exact.Currency)
+	}
+	if legacy == 0 {
+		return money.Money{}
+	}
+	return money.FromFloat(float64(legacy), base)
+}
+
+func moneyToPB(m money.Money) *moneypb.Money {
+	return &moneypb.Money{Amount: m.Amount(), Currency: m.Currency()}
+}
+
+func optionalUint32(v *int) *uint32 {
+	if v == nil {
+		return nil
+	}
+	u := uint32(*v) //nolint:gosec // stock settings are validated non-negative
+	return &u
+}
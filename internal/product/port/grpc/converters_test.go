@@ -0,0 +1,38 @@
+package grpc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"goshop/internal/product/model"
+	"goshop/pkg/money"
+	moneypb "goshop/proto/gen/go/money"
+)
+
+func TestProductInfoFromModel(t *testing.T) {
+	assert.Nil(t, productInfoFromModel(nil))
+	threshold := 5
+	got := productInfoFromModel(&model.Product{
+		ID: "p1", Name: "n", Price: money.New(1999, "USD"), LowStockThreshold: &threshold,
+		EffectiveLowStockThreshold: 5, EffectiveReorderQuantity: 10, LowStock: true,
+	})
+	assert.Equal(t, "p1", got.Id)
+	assert.Equal(t, &moneypb.Money{Amount: 1999, Currency: "USD"}, got.PriceMoney)
+	assert.Equal(t, float32(19.99), got.Price)
+	assert.Equal(t, uint32(5), *got.LowStockThreshold)
+	assert.Nil(t, got.ReorderQuantity)
+	assert.Equal(t, uint32(10), got.EffectiveReorderQuantity)
+	assert.True(t, got.LowStock)
+
+	out := productInfosFromModel([]*model.Product{{ID: "a"}, nil})
+	assert.Len(t, out, 2)
+	assert.Nil(t, out[1])
+}
+
+func TestPriceFromRequest(t *testing.T) {
+	assert.Equal(t, money.New(1250, "EUR"), priceFromRequest(&moneypb.Money{Amount: 1250, Currency: "eur"}, 99, "USD"))
+	assert.Equal(t, money.New(1999, "USD"), priceFromRequest(nil, 19.99, "USD"))
+	assert.Equal(t, money.New(1500, "JPY"), priceFromRequest(nil, 1500, "JPY"))
+	assert.True(t, priceFromRequest(nil, 0, "USD").IsZero())
+}
@@ -8,7 +8,6 @@ import (
 	"goshop/internal/product/domain"
 	"goshop/internal/product/service"
 	"goshop/pkg/apperror"
-	"goshop/pkg/utils"
 	pb "goshop/proto/gen/go/product"
 )
 
@@ -16,11 +15,14 @@ type ProductHandler struct {
 	pb.UnimplementedProductServiceServer
 
 	service service.ProductService
+	// baseCurrency is what the deprecated float prices in requests are read as.
+	baseCurrency string
 }
 
-func NewProductHandler(service service.ProductService) *ProductHandler {
+func NewProductHandler(service service.ProductService, baseCurrency string) *ProductHandler {
 	return &ProductHandler{
-		service: service,
+		service:      service,
+		baseCurrency: baseCurrency,
 	}
 }
 
@@ -35,11 +37,7 @@ func (h *ProductHandler) GetProductByID(ctx context.Context, req *pb.GetProductB
 		return nil, apperror.ToGRPCStatus(err)
 	}
 
-	var res pb.GetProductByIDRes
-	if err := utils.Copy(&res.Product, &product); err != nil {
-		return nil, err
-	}
-	return &res, nil
+	return &pb.GetProductByIDRes{Product: productInfoFromModel(product)}, nil
 }
 
 func (h *ProductHandler) ListProducts(ctx context.Context, req *pb.ListProductsReq) (*pb.ListProductsRes, error) {
@@ -56,10 +54,7 @@ func (h *ProductHandler) ListProducts(ctx context.Context, req *pb.ListProductsR
 		return nil, apperror.ToGRPCStatus(err)
 	}
 
-	var res pb.ListProductsRes
-	if err := utils.Copy(&res.Products, &products); err != nil {
-		return nil, err
-	}
+	res := pb.ListProductsRes{Products: productInfosFromModel(products)}
 	if pagination != nil {
 		res.Total = pagination.Total
 		res.CurrentPage = pagination.CurrentPage
@@ -70,20 +65,18 @@ func (h *ProductHandler) ListProducts(ctx context.Context, req *pb.ListProductsR
 
 func (h *ProductHandler) CreateProduct(ctx context.Context, req *pb.CreateProductReq) (*pb.CreateProductRes, error) {
 	product, err := h.service.Create(ctx, &domain.CreateProductReq{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       float64(req.Price),
+		Name:              req.Name,
+		Description:       req.Description,
+		Price:             priceFromRequest(req.PriceMoney, req.Price, h.baseCurrency),
+		LowStockThreshold: optionalInt(req.LowStockThreshold),
+		ReorderQuantity:   optionalInt(req.ReorderQuantity),
 	})
 	if err != nil {
 		logger.Error("Failed to create product ", err)
 		return nil, apperror.ToGRPCStatus(err)
 	}
 
-	var res pb.CreateProductRes
-	if err := utils.Copy(&res.Product, &product); err != nil {
-		return nil, err
-	}
-	return &res, nil
+	return &pb.CreateProductRes{Product: productInfoFromModel(product)}, nil
 }
 
 func (h *ProductHandler) UpdateProduct(ctx context.Context, req *pb.UpdateProductReq) (*pb.UpdateProductRes, error) {
@@ -92,18 +85,26 @@ func (h *ProductHandler) UpdateProduct(ctx context.Context, req *pb.UpdateProduc
 	}
 
 	product, err := h.service.Update(ctx, req.Id, &domain.UpdateProductReq{
-		Name:        req.Name,
-		Description: req.Description,
-		Price:       float64(req.Price),
+		Name:              req.Name,
+		Description:       req.Description,
+		Price:             priceFromRequest(req.PriceMoney, req.Price, h.baseCurrency),
+		LowStockThreshold: optionalInt(req.LowStockThreshold),
+		ReorderQuantity:   optionalInt(req.ReorderQuantity),
 	})
 	if err != nil {
 		logger.Error("Failed to update product ", err)
 		return nil, apperror.ToGRPCStatus(err)
 	}
 
-	var res pb.UpdateProductRes
-	if err := utils.Copy(&res.Product, &product); err != nil {
-		return nil, err
+	return &pb.UpdateProductRes{Product: productInfoFromModel(product)}, nil
+}
+
+// optionalInt converts an optional proto field, keeping unset as nil so the service leaves
+// the setting alone (or falls back to the category/default).
+func optionalInt(v *uint32) *int {
+	if v == nil {
+		return nil
 	}
-	return &res, nil
+	i := int(*v)
+	return &i
 }
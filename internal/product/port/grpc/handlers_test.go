@@ -8,11 +8,15 @@ import (
 	"github.com/quangdangfit/gocommon/logger"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"google.golang.org/protobuf/proto"
 
+	"goshop/internal/product/domain"
 	"goshop/internal/product/model"
 	"goshop/internal/product/service/mocks"
 	"goshop/pkg/config"
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
+	moneypb "goshop/proto/gen/go/money"
 	pb "goshop/proto/gen/go/product"
 )
 
@@ -26,7 +30,7 @@ func (suite *ProductHandlerTestSuite) SetupTest() {
 	logger.Initialize(config.ProductionEnv)
 
 	suite.mockService = mocks.NewProductService(suite.T())
-	suite.handler = NewProductHandler(suite.mockService)
+	suite.handler = NewProductHandler(suite.mockService, "USD")
 }
 
 func TestProductHandlerTestSuite(t *testing.T) {
@@ -53,7 +57,7 @@ func (suite *ProductHandlerTestSuite) TestGetProductByID() {
 						ID:          "productId1",
 						Name:        "product",
 						Description: "description",
-						Price:       10.5,
+						Price:       money.New(1050, "USD"),
 					}, nil).Times(1)
 			},
 			req:       &pb.GetProductByIDReq{Id: "productId1"},
@@ -131,7 +135,7 @@ func (suite *ProductHandlerTestSuite) TestListProducts() {
 								ID:          "productId1",
 								Name:        "product",
 								Description: "description",
-								Price:       10.5,
+								Price:       money.New(1050, "USD"),
 							},
 						},
 						&paging.Pagination{
@@ -208,18 +212,20 @@ func (suite *ProductHandlerTestSuite) TestCreateProduct() {
 		{
 			name: "Success",
 			setup: func() {
-				suite.mockService.On("Create", mock.Anything, mock.Anything).
+				suite.mockService.On("Create", mock.Anything, mock.MatchedBy(func(req *domain.CreateProductReq) bool {
+					return req.Price == money.New(1050, "USD")
+				})).
 					Return(&model.Product{
 						ID:          "productId1",
 						Name:        "product",
 						Description: "description",
-						Price:       10.5,
+						Price:       money.New(1050, "USD"),
 					}, nil).Times(1)
 			},
 			req: &pb.CreateProductReq{
 				Name:        "product",
 				Description: "description",
-				Price:       10.5,
+				PriceMoney:  &moneypb.Money{Amount: 1050, Currency: "USD"},
 			},
 			expectNil: false,
 			expectErr: false,
@@ -229,6 +235,37 @@ func (suite *ProductHandlerTestSuite) TestCreateProduct() {
 				suite.Equal("description", res.Product.Description)
 			},
 		},
+		{
+			name: "Stock settings",
+			setup: func() {
+				threshold, reorder := 10, 40
+				suite.mockService.On("Create", mock.Anything, mock.MatchedBy(func(req *domain.CreateProductReq) bool {
+					return *req.LowStockThreshold == 10 && *req.ReorderQuantity == 40
+				})).Return(&model.Product{
+					ID:                         "productId1",
+					StockQuantity:              8,
+					LowStockThreshold:          &threshold,
+					ReorderQuantity:            &reorder,
+					EffectiveLowStockThreshold: threshold,
+					EffectiveReorderQuantity:   reorder,
+					LowStock:                   true,
+				}, nil).Times(1)
+			},
+			req: &pb.CreateProductReq{
+				Name:              "product",
+				Description:       "description",
+				Price:             10.5,
+				LowStockThreshold: proto.Uint32(10),
+				ReorderQuantity:   proto.Uint32(40),
+			},
+			validate: func(res *pb.CreateProductRes) {
+				suite.Equal(uint32(10), res.Product.GetLowStockThreshold())
+				suite.Equal(uint32(40), res.Product.GetReorderQuantity())
+				suite.Equal(uint32(10), res.Product.EffectiveLowStockThreshold)
+				suite.Equal(uint32(40), res.Product.EffectiveReorderQuantity)
+				suite.True(res.Product.LowStock)
+			},
+		},
 		{
 			name: "Fail",
 			setup: func() {
@@ -284,12 +321,14 @@ func (suite *ProductHandlerTestSuite) TestUpdateProduct() {
 		{
 			name: "Success",
 			setup: func() {
-				suite.mockService.On("Update", mock.Anything, "productId1", mock.Anything).
+				suite.mockService.On("Update", mock.Anything, "productId1", mock.MatchedBy(func(req *domain.UpdateProductReq) bool {
+					return req.Price == money.New(2000, "USD") // the deprecated float, read as the base currency
+				})).
 					Return(&model.Product{
 						ID:          "productId1",
 						Name:        "updated",
 						Description: "updated description",
-						Price:       20.0,
+						Price:       money.New(2000, "USD"),
 					}, nil).Times(1)
 			},
 			req: &pb.UpdateProductReq{
@@ -304,6 +343,19 @@ func (suite *ProductHandlerTestSuite) TestUpdateProduct() {
 				suite.Equal("productId1", res.Product.Id)
 				suite.Equal("updated", res.Product.Name)
 				suite.Equal(float32(20.0), res.Product.Price)
+				suite.Equal(int64(2000), res.Product.PriceMoney.Amount)
+			},
+		},
+		{
+			name: "Unset stock settings stay nil",
+			setup: func() {
+				suite.mockService.On("Update", mock.Anything, "productId1", mock.MatchedBy(func(req *domain.UpdateProductReq) bool {
+					return req.LowStockThreshold == nil && *req.ReorderQuantity == 0
+				})).Return(&model.Product{ID: "productId1"}, nil).Times(1)
+			},
+			req: &pb.UpdateProductReq{Id: "productId1", ReorderQuantity: proto.Uint32(0)},
+			validate: func(res *pb.UpdateProductRes) {
+				suite.Equal("productId1", res.Product.Id)
 			},
 		},
 		{
@@ -4,16 +4,20 @@ import (
 	"github.com/quangdangfit/gocommon/validation"
 	"google.golang.org/grpc"
 
+	inventoryRepository "goshop/internal/inventory/repository"
 	"goshop/internal/product/repository"
 	"goshop/internal/product/service"
+	"goshop/pkg/config"
+	"goshop/pkg/currency"
 	"goshop/pkg/dbs"
 	pb "goshop/proto/gen/go/product"
 )
 
 func RegisterHandlers(svr *grpc.Server, db dbs.Database, validator validation.Validation) {
 	productRepo := repository.NewProductRepository(db)
-	productSvc := service.NewProductService(validator, productRepo)
-	productHandler := NewProductHandler(productSvc)
+	rates := currency.MustParseRates(config.GetConfig().BaseCurrency, config.GetConfig().ExchangeRates)
+	productSvc := service.NewProductService(validator, db, productRepo, inventoryRepository.NewLedgerRepository(db), rates)
+	productHandler := NewProductHandler(productSvc, rates.Base())
 
 	pb.RegisterProductServiceServer(svr, productHandler)
 }
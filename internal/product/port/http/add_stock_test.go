@@ -50,7 +50,7 @@ func TestAddStock_InvalidQuantity(t *testing.T) {
 
 func TestAddStock_ServiceError(t *testing.T) {
 	h, svc, _ := newAddStockHandler(t)
-	svc.On("AddStock", mock.Anything, "p1", 10, "admin1").
+	svc.On("AddStock", mock.Anything, "p1", 10, "", "admin1").
 		Return(nil, errors.New("db down")).Once()
 	body, _ := json.Marshal(map[string]any{"quantity": 10})
 	w := httptest.NewRecorder()
@@ -64,10 +64,10 @@ func TestAddStock_ServiceError(t *testing.T) {
 
 func TestAddStock_Success(t *testing.T) {
 	h, svc, cache := newAddStockHandler(t)
-	svc.On("AddStock", mock.Anything, "p1", 10, "admin1").
+	svc.On("AddStock", mock.Anything, "p1", 10, "wh2", "admin1").
 		Return(&model.Product{ID: "p1", StockQuantity: 110}, nil).Once()
 	cache.On("RemovePattern", "*product*").Return(nil).Once()
-	body, _ := json.Marshal(map[string]any{"quantity": 10})
+	body, _ := json.Marshal(map[string]any{"quantity": 10, "warehouse_id": "wh2"})
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Set("userId", "admin1")
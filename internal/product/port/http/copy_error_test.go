@@ -17,15 +17,16 @@ import (
 	"goshop/internal/product/domain"
 	"goshop/internal/product/model"
 	"goshop/pkg/config"
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
 )
 
 var errCacheMiss = errors.New("cache miss")
 
-// nanProduct returns a Product whose Price is NaN; json.Marshal rejects NaN, which
+// nanProduct returns a Product whose AvgRating is NaN; json.Marshal rejects NaN, which
 // is the only practical way to drive utils.Copy into its error branch in handler tests.
 func nanProduct() *model.Product {
-	return &model.Product{ID: "p1", Price: math.NaN()}
+	return &model.Product{ID: "p1", AvgRating: math.NaN()}
 }
 
 func newProductHandlerSuite(t *testing.T) *ProductHandlerTestSuite {
@@ -54,7 +55,7 @@ func TestCreateProduct_CopyError(t *testing.T) {
 	s.mockRedis.On("RemovePattern", mock.Anything).Return(nil).Maybe()
 
 	body, _ := json.Marshal(domain.CreateProductReq{
-		Name: "x", Description: "x", Price: 1, StockQuantity: 1,
+		Name: "x", Description: "x", Price: money.New(100, "USD"), StockQuantity: 1,
 	})
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -79,7 +80,7 @@ func TestUpdateProduct_CopyError(t *testing.T) {
 
 func TestAddStock_CopyError(t *testing.T) {
 	s := newProductHandlerSuite(t)
-	s.mockService.On("AddStock", mock.Anything, "p1", 1, mock.Anything).Return(nanProduct(), nil).Once()
+	s.mockService.On("AddStock", mock.Anything, "p1", 1, mock.Anything, mock.Anything).Return(nanProduct(), nil).Once()
 	s.mockRedis.On("RemovePattern", mock.Anything).Return(nil).Maybe()
 
 	body, _ := json.Marshal(map[string]any{"quantity": 1})
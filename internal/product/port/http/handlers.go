@@ -117,6 +117,7 @@ func (p *ProductHandler) CreateProduct(c *gin.Context) {
 		return
 	}
 
+	req.ActorID = c.GetString("userId")
 	product, err := p.service.Create(c, &req)
 	if err != nil {
 		logger.Error("Failed to create product", err.Error())
@@ -151,6 +152,7 @@ func (p *ProductHandler) UpdateProduct(c *gin.Context) {
 		return
 	}
 
+	req.ActorID = c.GetString("userId")
 	product, err := p.service.Update(c, productId, &req)
 	if err != nil {
 		logger.Error("Failed to update product", err.Error())
@@ -186,7 +188,7 @@ func (p *ProductHandler) AddStock(c *gin.Context) {
 	}
 
 	adminID := c.GetString("userId")
-	product, err := p.service.AddStock(c, productId, req.Quantity, adminID)
+	product, err := p.service.AddStock(c, productId, req.Quantity, req.WarehouseID, adminID)
 	if err != nil {
 		logger.Error("Failed to add stock: ", err.Error())
 		apperror.ToHTTPError(c, err, http.StatusInternalServerError, "Something went wrong")
@@ -204,4 +206,6 @@ func (p *ProductHandler) AddStock(c *gin.Context) {
 
 type addStockReq struct {
 	Quantity int `json:"quantity" binding:"required,gt=0"`
+	// WarehouseID receives the units; empty means the default warehouse.
+	WarehouseID string `json:"warehouse_id,omitempty"`
 }
@@ -17,6 +17,7 @@ import (
 	"goshop/internal/product/model"
 	srvMocks "goshop/internal/product/service/mocks"
 	"goshop/pkg/config"
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
 	redisMocks "goshop/pkg/redis/mocks"
 	"goshop/pkg/response"
@@ -225,18 +226,18 @@ func (suite *ProductHandlerTestSuite) TestCreateProduct() {
 			body: &domain.CreateProductReq{
 				Name:        "product",
 				Description: "description",
-				Price:       10.5,
+				Price:       money.MustParse("10.5", "USD"),
 			},
 			setup: func() {
 				suite.mockService.On("Create", mock.Anything, &domain.CreateProductReq{
 					Name:        "product",
 					Description: "description",
-					Price:       10.5,
+					Price:       money.MustParse("10.5", "USD"),
 				}).Return(
 					&model.Product{
 						Name:        "product",
 						Description: "description",
-						Price:       10.5,
+						Price:       money.MustParse("10.5", "USD"),
 					},
 					nil,
 				).Times(1)
@@ -250,7 +251,7 @@ func (suite *ProductHandlerTestSuite) TestCreateProduct() {
 				_ = utils.Copy(&resData, &res.Result)
 				suite.Equal("product", resData.Name)
 				suite.Equal("description", resData.Description)
-				suite.Equal(10.5, resData.Price)
+				suite.Equal(money.MustParse("10.5", "USD"), resData.Price)
 			},
 		},
 		{
@@ -273,13 +274,13 @@ func (suite *ProductHandlerTestSuite) TestCreateProduct() {
 			body: &domain.CreateProductReq{
 				Name:        "product",
 				Description: "description",
-				Price:       10.5,
+				Price:       money.MustParse("10.5", "USD"),
 			},
 			setup: func() {
 				suite.mockService.On("Create", mock.Anything, &domain.CreateProductReq{
 					Name:        "product",
 					Description: "description",
-					Price:       10.5,
+					Price:       money.MustParse("10.5", "USD"),
 				}).Return(nil, errors.New("error")).Times(1)
 			},
 			expected: http.StatusInternalServerError,
@@ -318,19 +319,19 @@ func (suite *ProductHandlerTestSuite) TestUpdateProduct() {
 			body: &domain.UpdateProductReq{
 				Name:        "product",
 				Description: "description",
-				Price:       10.5,
+				Price:       money.MustParse("10.5", "USD"),
 			},
 			setup: func() {
 				suite.mockService.On("Update", mock.Anything, mock.Anything, &domain.UpdateProductReq{
 					Name:        "product",
 					Description: "description",
-					Price:       10.5,
+					Price:       money.MustParse("10.5", "USD"),
 				}).Return(
 					&model.Product{
 						ID:          "123456",
 						Name:        "product",
 						Description: "description",
-						Price:       10.5,
+						Price:       money.MustParse("10.5", "USD"),
 					},
 					nil,
 				).Times(1)
@@ -345,7 +346,7 @@ func (suite *ProductHandlerTestSuite) TestUpdateProduct() {
 				suite.Equal("123456", resData.ID)
 				suite.Equal("product", resData.Name)
 				suite.Equal("description", resData.Description)
-				suite.Equal(10.5, resData.Price)
+				suite.Equal(money.MustParse("10.5", "USD"), resData.Price)
 			},
 		},
 		{
@@ -368,13 +369,13 @@ func (suite *ProductHandlerTestSuite) TestUpdateProduct() {
 			body: &domain.UpdateProductReq{
 				Name:        "product",
 				Description: "description",
-				Price:       10.5,
+				Price:       money.MustParse("10.5", "USD"),
 			},
 			setup: func() {
 				suite.mockService.On("Update", mock.Anything, mock.Anything, &domain.UpdateProductReq{
 					Name:        "product",
 					Description: "description",
-					Price:       10.5,
+					Price:       money.MustParse("10.5", "USD"),
 				}).Return(nil, errors.New("error")).Times(1)
 			},
 			expected: http.StatusInternalServerError,
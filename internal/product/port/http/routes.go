@@ -4,8 +4,11 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/quangdangfit/gocommon/validation"
 
+	inventoryRepository "goshop/internal/inventory/repository"
 	"goshop/internal/product/repository"
 	"goshop/internal/product/service"
+	"goshop/pkg/config"
+	"goshop/pkg/currency"
 	"goshop/pkg/dbs"
 	"goshop/pkg/middleware"
 	"goshop/pkg/redis"
@@ -13,7 +16,8 @@ import (
 
 func Routes(r *gin.RouterGroup, db dbs.Database, validator validation.Validation, cache redis.Redis) {
 	productRepo := repository.NewProductRepository(db)
-	productSvc := service.NewProductService(validator, productRepo)
+	productSvc := service.NewProductService(validator, db, productRepo, inventoryRepository.NewLedgerRepository(db),
+		currency.MustParseRates(config.GetConfig().BaseCurrency, config.GetConfig().ExchangeRates))
 	productHandler := NewProductHandler(cache, productSvc)
 
 	categoryRepo := repository.NewCategoryRepository(db)
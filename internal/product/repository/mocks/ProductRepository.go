@@ -40,6 +40,138 @@ func (_m *ProductRepository) EXPECT() *ProductRepository_Expecter {
 	return &ProductRepository_Expecter{mock: &_m.Mock}
 }
 
+// AddStock provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) AddStock(ctx context.Context, id string, qty int) error {
+	ret := _mock.Called(ctx, id, qty)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddStock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
+		r0 = returnFunc(ctx, id, qty)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProductRepository_AddStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddStock'
+type ProductRepository_AddStock_Call struct {
+	*mock.Call
+}
+
+// AddStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - qty int
+func (_e *ProductRepository_Expecter) AddStock(ctx interface{}, id interface{}, qty interface{}) *ProductRepository_AddStock_Call {
+	return &ProductRepository_AddStock_Call{Call: _e.mock.On("AddStock", ctx, id, qty)}
+}
+
+func (_c *ProductRepository_AddStock_Call) Run(run func(ctx context.Context, id string, qty int)) *ProductRepository_AddStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_AddStock_Call) Return(err error) *ProductRepository_AddStock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProductRepository_AddStock_Call) RunAndReturn(run func(ctx context.Context, id string, qty int) error) *ProductRepository_AddStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// AdjustWarehouseStock provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) AdjustWarehouseStock(ctx context.Context, productID string, warehouseID string, delta int) error {
+	ret := _mock.Called(ctx, productID, warehouseID, delta)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AdjustWarehouseStock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = returnFunc(ctx, productID, warehouseID, delta)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// ProductRepository_AdjustWarehouseStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AdjustWarehouseStock'
+type ProductRepository_AdjustWarehouseStock_Call struct {
+	*mock.Call
+}
+
+// AdjustWarehouseStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID string
+//   - warehouseID string
+//   - delta int
+func (_e *ProductRepository_Expecter) AdjustWarehouseStock(ctx interface{}, productID interface{}, warehouseID interface{}, delta interface{}) *ProductRepository_AdjustWarehouseStock_Call {
+	return &ProductRepository_AdjustWarehouseStock_Call{Call: _e.mock.On("AdjustWarehouseStock", ctx, productID, warehouseID, delta)}
+}
+
+func (_c *ProductRepository_AdjustWarehouseStock_Call) Run(run func(ctx context.Context, productID string, warehouseID string, delta int)) *ProductRepository_AdjustWarehouseStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_AdjustWarehouseStock_Call) Return(err error) *ProductRepository_AdjustWarehouseStock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *ProductRepository_AdjustWarehouseStock_Call) RunAndReturn(run func(ctx context.Context, productID string, warehouseID string, delta int) error) *ProductRepository_AdjustWarehouseStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type ProductRepository
 func (_mock *ProductRepository) Create(ctx context.Context, product *model.Product) error {
 	ret := _mock.Called(ctx, product)
@@ -271,7 +403,7 @@ type ProductRepository_ListProducts_Call struct {
 
 // ListProducts is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *dto.ListProductReq
+//   - req *domain.ListProductReq
 func (_e *ProductRepository_Expecter) ListProducts(ctx interface{}, req interface{}) *ProductRepository_ListProducts_Call {
 	return &ProductRepository_ListProducts_Call{Call: _e.mock.On("ListProducts", ctx, req)}
 }
@@ -304,6 +436,72 @@ func (_c *ProductRepository_ListProducts_Call) RunAndReturn(run func(ctx context
 	return _c
 }
 
+// ResolveWarehouse provides a mock function for the type ProductRepository
+func (_mock *ProductRepository) ResolveWarehouse(ctx context.Context, warehouseID string) (string, error) {
+	ret := _mock.Called(ctx, warehouseID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ResolveWarehouse")
+	}
+
+	var r0 string
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return returnFunc(ctx, warehouseID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = returnFunc(ctx, warehouseID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = returnFunc(ctx, warehouseID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductRepository_ResolveWarehouse_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ResolveWarehouse'
+type ProductRepository_ResolveWarehouse_Call struct {
+	*mock.Call
+}
+
+// ResolveWarehouse is a helper method to define mock.On call
+//   - ctx context.Context
+//   - warehouseID string
+func (_e *ProductRepository_Expecter) ResolveWarehouse(ctx interface{}, warehouseID interface{}) *ProductRepository_ResolveWarehouse_Call {
+	return &ProductRepository_ResolveWarehouse_Call{Call: _e.mock.On("ResolveWarehouse", ctx, warehouseID)}
+}
+
+func (_c *ProductRepository_ResolveWarehouse_Call) Run(run func(ctx context.Context, warehouseID string)) *ProductRepository_ResolveWarehouse_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductRepository_ResolveWarehouse_Call) Return(s string, err error) *ProductRepository_ResolveWarehouse_Call {
+	_c.Call.Return(s, err)
+	return _c
+}
+
+func (_c *ProductRepository_ResolveWarehouse_Call) RunAndReturn(run func(ctx context.Context, warehouseID string) (string, error)) *ProductRepository_ResolveWarehouse_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function for the type ProductRepository
 func (_mock *ProductRepository) Update(ctx context.Context, product *model.Product) error {
 	ret := _mock.Called(ctx, product)
@@ -429,30 +627,3 @@ func (_c *ProductRepository_UpdateRating_Call) RunAndReturn(run func(ctx context
 	_c.Call.Return(run)
 	return _c
 }
-
-// AddStock provides a mock function for the type ProductRepository
-func (_mock *ProductRepository) AddStock(ctx context.Context, id string, qty int) error {
-	ret := _mock.Called(ctx, id, qty)
-	if len(ret) == 0 {
-		panic("no return value specified for AddStock")
-	}
-	var r0 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int) error); ok {
-		r0 = returnFunc(ctx, id, qty)
-	} else {
-		r0 = ret.Error(0)
-	}
-	return r0
-}
-
-// ProductRepository_AddStock_Call mirrors the existing call wrappers in this file.
-type ProductRepository_AddStock_Call struct{ *mock.Call }
-
-func (_e *ProductRepository_Expecter) AddStock(ctx interface{}, id interface{}, qty interface{}) *ProductRepository_AddStock_Call {
-	return &ProductRepository_AddStock_Call{Call: _e.mock.On("AddStock", ctx, id, qty)}
-}
-
-func (_c *ProductRepository_AddStock_Call) Return(err error) *ProductRepository_AddStock_Call {
-	_c.Call.Return(err)
-	return _c
-}
@@ -3,7 +3,9 @@ package repository
 import (
 	"context"
 	"errors"
+	"time"
 
+	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"goshop/internal/product/domain"
@@ -22,8 +24,21 @@ type ProductRepository interface {
 	DecrementStock(ctx context.Context, id string, qty int) error
 	UpdateRating(ctx context.Context, id string, avgRating float64, reviewCount int) error
 	AddStock(ctx context.Context, id string, qty int) error
+	// ResolveWarehouse returns warehouseID if it names an active warehouse, or the default
+	// warehouse's ID when it is empty. Returns ErrWarehouseNotFound otherwise.
+	ResolveWarehouse(ctx context.Context, warehouseID string) (string, error)
+	// AdjustWarehouseStock adds delta, which may be negative, to the product's stock at one
+	// warehouse, creating the row on the first restock. Call it in the same transaction as
+	// the matching change to the product's stock_quantity. Returns ErrWarehouseStockTooLow
+	// when the warehouse would hold fewer units than it has reserved.
+	AdjustWarehouseStock(ctx context.Context, productID, warehouseID string, delta int) error
 }
 
+var (
+	ErrWarehouseNotFound    = errors.New("warehouse not found")
+	ErrWarehouseStockTooLow = errors.New("warehouse stock below its reserved units")
+)
+
 type productRepo struct {
 	db dbs.Database
 }
@@ -70,6 +85,7 @@ func (r *productRepo) ListProducts(ctx context.Context, req *domain.ListProductR
 		dbs.WithLimit(int(pagination.Limit)),
 		dbs.WithOffset(int(pagination.Skip)),
 		dbs.WithOrder(order),
+		dbs.WithPreload([]string{"Category"}),
 	); err != nil {
 		return nil, nil, err
 	}
@@ -77,9 +93,14 @@ func (r *productRepo) ListProducts(ctx context.Context, req *domain.ListProductR
 	return products, pagination, nil
 }
 
+// GetProductByID loads the product with its category, which supplies inherited stock settings.
 func (r *productRepo) GetProductByID(ctx context.Context, id string) (*model.Product, error) {
 	var product model.Product
-	if err := r.db.FindById(ctx, id, &product); err != nil {
+	err := r.db.FindOne(ctx, &product,
+		dbs.WithQuery(dbs.NewQuery("id = ?", id)),
+		dbs.WithPreload([]string{"Category"}),
+	)
+	if err != nil {
 		return nil, err
 	}
 	return &product, nil
@@ -121,6 +142,46 @@ func (r *productRepo) AddStock(ctx context.Context, id string, qty int) error {
 	return nil
 }
 
+func (r *productRepo) ResolveWarehouse(ctx context.Context, warehouseID string) (string, error) {
+	query := r.db.GetDB().WithContext(ctx).Table("warehouses").Where("active AND deleted_at IS NULL")
+	if warehouseID != "" {
+		query = query.Where("id = ?", warehouseID)
+	} else {
+		query = query.Where("is_default")
+	}
+	var ids []string
+	if err := query.Limit(1).Pluck("id", &ids).Error; err != nil {
+		return "", err
+	}
+	if len(ids) == 0 {
+		return "", ErrWarehouseNotFound
+	}
+	return ids[0], nil
+}
+
+func (r *productRepo) AdjustWarehouseStock(ctx context.Context, productID, warehouseID string, delta int) error {
+	if delta == 0 {
+		return nil
+	}
+	db := r.db.GetDB().WithContext(ctx)
+	now := time.Now()
+	result := db.Exec(`UPDATE warehouse_stocks SET stock_quantity = stock_quantity + ?, updated_at = ?
+		WHERE warehouse_id = ? AND product_id = ? AND stock_quantity + ? >= reserved_quantity`,
+		delta, now, warehouseID, productID, delta)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected > 0 {
+		return nil
+	}
+	if delta < 0 {
+		return ErrWarehouseStockTooLow
+	}
+	return db.Exec(`INSERT INTO warehouse_stocks (id, created_at, updated_at, warehouse_id, product_id, stock_quantity, reserved_quantity)
+		VALUES (?, ?, ?, ?, ?, ?, 0)`,
+		uuid.New().String(), now, now, warehouseID, productID, delta).Error
+}
+
 func (r *productRepo) UpdateRating(ctx context.Context, id string, avgRating float64, reviewCount int) error {
 	return r.db.GetDB().WithContext(ctx).Model(&model.Product{}).
 		Where("id = ?", id).
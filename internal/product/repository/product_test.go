@@ -16,6 +16,7 @@ import (
 	"goshop/internal/product/model"
 	"goshop/pkg/config"
 	"goshop/pkg/dbs/mocks"
+	"goshop/pkg/money"
 )
 
 func newProductSQLMockGormDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
@@ -75,7 +76,7 @@ func (suite *ProductRepositoryTestSuite) TestCreate() {
 		suite.Run(tc.name, func() {
 			suite.SetupTest()
 			tc.setup()
-			product := &model.Product{Name: "product name", Description: "product description", Price: 10.5}
+			product := &model.Product{Name: "product name", Description: "product description", Price: money.MustParse("10.5", "USD")}
 			err := suite.repo.Create(context.Background(), product)
 			if tc.wantErr {
 				suite.NotNil(err)
@@ -110,7 +111,7 @@ func (suite *ProductRepositoryTestSuite) TestUpdate() {
 		suite.Run(tc.name, func() {
 			suite.SetupTest()
 			tc.setup()
-			product := &model.Product{ID: "productId1", Name: "product name", Description: "product description", Price: 10.5}
+			product := &model.Product{ID: "productId1", Name: "product name", Description: "product description", Price: money.MustParse("10.5", "USD")}
 			err := suite.repo.Update(context.Background(), product)
 			if tc.wantErr {
 				suite.NotNil(err)
@@ -130,13 +131,13 @@ func (suite *ProductRepositoryTestSuite) TestGetProductByID() {
 		{
 			name: "Success",
 			setup: func() {
-				suite.mockDB.On("FindById", mock.Anything, "productId1", &model.Product{}).Return(nil).Times(1)
+				suite.mockDB.On("FindOne", mock.Anything, &model.Product{}, mock.Anything, mock.Anything).Return(nil).Times(1)
 			},
 		},
 		{
 			name: "Not found",
 			setup: func() {
-				suite.mockDB.On("FindById", mock.Anything, "productId1", &model.Product{}).Return(errors.New("error")).Times(1)
+				suite.mockDB.On("FindOne", mock.Anything, &model.Product{}, mock.Anything, mock.Anything).Return(errors.New("error")).Times(1)
 			},
 			wantErr: true,
 		},
@@ -352,3 +353,127 @@ func (suite *ProductRepositoryTestSuite) TestUpdateRating() {
 		})
 	}
 }
+
+func (suite *ProductRepositoryTestSuite) TestResolveWarehouse() {
+	tests := []struct {
+		name        string
+		warehouseID string
+		setup       func(sqlMock sqlmock.Sqlmock)
+		want        string
+		wantErrIs   error
+		wantErr     bool
+	}{
+		{
+			name:        "By ID",
+			warehouseID: "wh2",
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectQuery(`SELECT "id" FROM "warehouses" WHERE \(active AND deleted_at IS NULL\) AND id = \$1`).
+					WithArgs("wh2", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("wh2"))
+			},
+			want: "wh2",
+		},
+		{
+			name: "Default",
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectQuery(`SELECT "id" FROM "warehouses" WHERE \(active AND deleted_at IS NULL\) AND is_default`).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("wh1"))
+			},
+			want: "wh1",
+		},
+		{
+			name:        "Not found",
+			warehouseID: "nope",
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectQuery(".*").WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			wantErrIs: ErrWarehouseNotFound,
+		},
+		{
+			name: "DB error",
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectQuery(".*").WillReturnError(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			gormDB, sqlMock := newProductSQLMockGormDB(suite.T())
+			tc.setup(sqlMock)
+			suite.mockDB.On("GetDB").Return(gormDB).Times(1)
+			got, err := suite.repo.ResolveWarehouse(context.Background(), tc.warehouseID)
+			switch {
+			case tc.wantErrIs != nil:
+				suite.ErrorIs(err, tc.wantErrIs)
+			case tc.wantErr:
+				suite.NotNil(err)
+			default:
+				suite.Nil(err)
+				suite.Equal(tc.want, got)
+			}
+			suite.Nil(sqlMock.ExpectationsWereMet())
+		})
+	}
+}
+
+func (suite *ProductRepositoryTestSuite) TestAdjustWarehouseStock() {
+	tests := []struct {
+		name      string
+		delta     int
+		setup     func(sqlMock sqlmock.Sqlmock)
+		wantErrIs error
+		wantErr   bool
+	}{
+		{
+			name:  "Existing row",
+			delta: -2,
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectExec("UPDATE warehouse_stocks").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "First stock at warehouse",
+			delta: 5,
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectExec("UPDATE warehouse_stocks").WillReturnResult(sqlmock.NewResult(0, 0))
+				sqlMock.ExpectExec("INSERT INTO warehouse_stocks").WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+		},
+		{
+			name:  "Below reserved",
+			delta: -5,
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectExec("UPDATE warehouse_stocks").WillReturnResult(sqlmock.NewResult(0, 0))
+			},
+			wantErrIs: ErrWarehouseStockTooLow,
+		},
+		{
+			name:  "DB error",
+			delta: 5,
+			setup: func(sqlMock sqlmock.Sqlmock) {
+				sqlMock.ExpectExec("UPDATE warehouse_stocks").WillReturnError(errors.New("db error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			gormDB, sqlMock := newProductSQLMockGormDB(suite.T())
+			tc.setup(sqlMock)
+			suite.mockDB.On("GetDB").Return(gormDB).Times(1)
+			err := suite.repo.AdjustWarehouseStock(context.Background(), "p1", "wh1", tc.delta)
+			switch {
+			case tc.wantErrIs != nil:
+				suite.ErrorIs(err, tc.wantErrIs)
+			case tc.wantErr:
+				suite.NotNil(err)
+			default:
+				suite.Nil(err)
+			}
+			suite.Nil(sqlMock.ExpectationsWereMet())
+		})
+	}
+}
@@ -41,9 +41,12 @@ func (s *categorySvc) Create(ctx context.Context, req *domain.CreateCategoryReq)
 		return nil, err
 	}
 	category := model.Category{
-		Name:        req.Name,
-		Slug:        req.Slug,
-		Description: req.Description,
+		Name:              req.Name,
+		Slug:              req.Slug,
+		Description:       req.Description,
+		LowStockThreshold: req.LowStockThreshold,
+		ReorderQuantity:   req.ReorderQuantity,
+		TaxClass:          req.TaxClass,
 	}
 	if err := s.repo.Create(ctx, &category); err != nil {
 		return nil, err
@@ -52,6 +55,9 @@ func (s *categorySvc) Create(ctx context.Context, req *domain.CreateCategoryReq)
 }
 
 func (s *categorySvc) Update(ctx context.Context, id string, req *domain.UpdateCategoryReq) (*model.Category, error) {
+	if err := s.validator.ValidateStruct(req); err != nil {
+		return nil, err
+	}
 	category, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -65,6 +71,15 @@ func (s *categorySvc) Update(ctx context.Context, id string, req *domain.UpdateC
 	if req.Description != "" {
 		category.Description = req.Description
 	}
+	if req.LowStockThreshold != nil {
+		category.LowStockThreshold = req.LowStockThreshold
+	}
+	if req.ReorderQuantity != nil {
+		category.ReorderQuantity = req.ReorderQuantity
+	}
+	if req.TaxClass != "" {
+		category.TaxClass = req.TaxClass
+	}
 	if err := s.repo.Update(ctx, category); err != nil {
 		return nil, err
 	}
@@ -217,6 +217,32 @@ func (suite *CategoryServiceTestSuite) TestUpdate() {
 	}
 }
 
+func (suite *CategoryServiceTestSuite) TestStockSettings() {
+	threshold, reorder := 10, 100
+	suite.mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Once()
+	category, err := suite.service.Create(context.Background(), &domain.CreateCategoryReq{
+		Name: "Electronics", Slug: "electronics", LowStockThreshold: &threshold, ReorderQuantity: &reorder, TaxClass: "reduced",
+	})
+	suite.Nil(err)
+	suite.Equal(threshold, *category.LowStockThreshold)
+	suite.Equal(reorder, *category.ReorderQuantity)
+	suite.Equal("reduced", category.TaxClass)
+
+	newThreshold := 3
+	suite.mockRepo.On("GetByID", mock.Anything, "cat1").
+		Return(&model.Category{ID: "cat1", LowStockThreshold: &threshold, ReorderQuantity: &reorder, TaxClass: "reduced"}, nil).Once()
+	suite.mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
+	category, err = suite.service.Update(context.Background(), "cat1", &domain.UpdateCategoryReq{LowStockThreshold: &newThreshold})
+	suite.Nil(err)
+	suite.Equal(newThreshold, *category.LowStockThreshold)
+	suite.Equal(reorder, *category.ReorderQuantity)
+	suite.Equal("reduced", category.TaxClass)
+
+	negative := -1
+	_, err = suite.service.Update(context.Background(), "cat1", &domain.UpdateCategoryReq{ReorderQuantity: &negative})
+	suite.NotNil(err)
+}
+
 func (suite *CategoryServiceTestSuite) TestDelete() {
 	tests := []struct {
 		name    string
@@ -12,44 +12,101 @@ import (
 
 	"goshop/internal/product/domain"
 	"goshop/internal/product/model"
+	"goshop/internal/product/repository"
 	"goshop/internal/product/repository/mocks"
+	serviceMocks "goshop/internal/product/service/mocks"
+	"goshop/pkg/apperror"
 	"goshop/pkg/config"
+	"goshop/pkg/currency"
+	"goshop/pkg/money"
+	"goshop/pkg/stock"
 )
 
-func newSvcExtras(t *testing.T) (ProductService, *mocks.ProductRepository) {
+func newSvcExtras(t *testing.T) (ProductService, *mocks.ProductRepository, *serviceMocks.StockLedger) {
 	logger.Initialize(config.ProductionEnv)
 	repo := mocks.NewProductRepository(t)
-	return NewProductService(validation.New(), repo), repo
+	ledger := serviceMocks.NewStockLedger(t)
+	return NewProductService(validation.New(), newTxDB(t), repo, ledger, currency.MustParseRates("USD", "")), repo, ledger
 }
 
 func TestAddStock_RejectsZeroOrNegative(t *testing.T) {
-	svc, _ := newSvcExtras(t)
+	svc, _, _ := newSvcExtras(t)
 	for _, q := range []int{0, -1, -100} {
-		_, err := svc.AddStock(context.Background(), "p1", q, "admin")
+		_, err := svc.AddStock(context.Background(), "p1", q, "", "admin")
 		require.ErrorIs(t, err, errInvalidStockQty)
 	}
 }
 
 func TestAddStock_RepoError(t *testing.T) {
-	svc, repo := newSvcExtras(t)
+	svc, repo, _ := newSvcExtras(t)
 	repo.On("AddStock", mock.Anything, "p1", 10).Return(errors.New("db")).Once()
-	_, err := svc.AddStock(context.Background(), "p1", 10, "admin")
+	_, err := svc.AddStock(context.Background(), "p1", 10, "", "admin")
 	require.Error(t, err)
 }
 
 func TestAddStock_Success(t *testing.T) {
-	svc, repo := newSvcExtras(t)
+	svc, repo, ledger := newSvcExtras(t)
 	repo.On("AddStock", mock.Anything, "p1", 10).Return(nil).Once()
+	repo.On("ResolveWarehouse", mock.Anything, "wh2").Return("wh2", nil).Once()
+	repo.On("AdjustWarehouseStock", mock.Anything, "p1", "wh2", 10).Return(nil).Once()
+	ledger.On("Record", mock.Anything, stock.Movement{
+		ProductID:   "p1",
+		Kind:        stock.MovementRestock,
+		StockDelta:  10,
+		Actor:       "admin-id",
+		Reason:      "admin restock",
+		WarehouseID: "wh2",
+	}).Return(nil).Once()
 	repo.On("GetProductByID", mock.Anything, "p1").
 		Return(&model.Product{ID: "p1", StockQuantity: 110}, nil).Once()
-	got, err := svc.AddStock(context.Background(), "p1", 10, "admin-id")
+	got, err := svc.AddStock(context.Background(), "p1", 10, "wh2", "admin-id")
 	require.NoError(t, err)
 	require.Equal(t, 110, got.StockQuantity)
 }
 
+func TestAddStock_LedgerErrorFails(t *testing.T) {
+	svc, repo, ledger := newSvcExtras(t)
+	repo.On("AddStock", mock.Anything, "p1", 10).Return(nil).Once()
+	repo.On("ResolveWarehouse", mock.Anything, "").Return("wh1", nil).Once()
+	repo.On("AdjustWarehouseStock", mock.Anything, "p1", "wh1", 10).Return(nil).Once()
+	ledger.On("Record", mock.Anything, mock.Anything).Return(errors.New("db")).Once()
+	_, err := svc.AddStock(context.Background(), "p1", 10, "", "admin")
+	require.Error(t, err)
+}
+
+func TestAddStock_UnknownWarehouse(t *testing.T) {
+	svc, repo, _ := newSvcExtras(t)
+	repo.On("AddStock", mock.Anything, "p1", 10).Return(nil).Once()
+	repo.On("ResolveWarehouse", mock.Anything, "nope").Return("", repository.ErrWarehouseNotFound).Once()
+	_, err := svc.AddStock(context.Background(), "p1", 10, "nope", "admin")
+	var appErr *apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperror.ErrNotFound.Code, appErr.Code)
+}
+
 func TestUpdate_GetError(t *testing.T) {
-	svc, repo := newSvcExtras(t)
+	svc, repo, _ := newSvcExtras(t)
 	repo.On("GetProductByID", mock.Anything, "p1").Return(nil, errors.New("not found")).Once()
 	_, err := svc.Update(context.Background(), "p1", &domain.UpdateProductReq{Name: "x"})
 	require.Error(t, err)
 }
+
+func TestCreate_PriceMustBePositiveBaseCurrency(t *testing.T) {
+	svc, _, _ := newSvcExtras(t)
+	for _, price := range []money.Money{{}, money.New(-100, "USD"), money.New(1000, "EUR")} {
+		_, err := svc.Create(context.Background(), &domain.CreateProductReq{
+			Name: "x", Description: "x", Price: price,
+		})
+		var appErr *apperror.AppError
+		require.ErrorAs(t, err, &appErr, price.String())
+		require.Equal(t, apperror.ErrBadRequest.Code, appErr.Code)
+	}
+}
+
+func TestUpdate_PriceInOtherCurrencyRejected(t *testing.T) {
+	svc, _, _ := newSvcExtras(t)
+	_, err := svc.Update(context.Background(), "p1", &domain.UpdateProductReq{Price: money.New(1000, "JPY")})
+	var appErr *apperror.AppError
+	require.ErrorAs(t, err, &appErr)
+	require.Equal(t, apperror.ErrBadRequest.Code, appErr.Code)
+}
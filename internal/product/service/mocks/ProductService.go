@@ -40,6 +40,92 @@ func (_m *ProductService) EXPECT() *ProductService_Expecter {
 	return &ProductService_Expecter{mock: &_m.Mock}
 }
 
+// AddStock provides a mock function for the type ProductService
+func (_mock *ProductService) AddStock(ctx context.Context, id string, qty int, warehouseID string, adminUserID string) (*model.Product, error) {
+	ret := _mock.Called(ctx, id, qty, warehouseID, adminUserID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddStock")
+	}
+
+	var r0 *model.Product
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string, string) (*model.Product, error)); ok {
+		return returnFunc(ctx, id, qty, warehouseID, adminUserID)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string, string) *model.Product); ok {
+		r0 = returnFunc(ctx, id, qty, warehouseID, adminUserID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Product)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, string, int, string, string) error); ok {
+		r1 = returnFunc(ctx, id, qty, warehouseID, adminUserID)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// ProductService_AddStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'AddStock'
+type ProductService_AddStock_Call struct {
+	*mock.Call
+}
+
+// AddStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id string
+//   - qty int
+//   - warehouseID string
+//   - adminUserID string
+func (_e *ProductService_Expecter) AddStock(ctx interface{}, id interface{}, qty interface{}, warehouseID interface{}, adminUserID interface{}) *ProductService_AddStock_Call {
+	return &ProductService_AddStock_Call{Call: _e.mock.On("AddStock", ctx, id, qty, warehouseID, adminUserID)}
+}
+
+func (_c *ProductService_AddStock_Call) Run(run func(ctx context.Context, id string, qty int, warehouseID string, adminUserID string)) *ProductService_AddStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 int
+		if args[2] != nil {
+			arg2 = args[2].(int)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+		)
+	})
+	return _c
+}
+
+func (_c *ProductService_AddStock_Call) Return(product *model.Product, err error) *ProductService_AddStock_Call {
+	_c.Call.Return(product, err)
+	return _c
+}
+
+func (_c *ProductService_AddStock_Call) RunAndReturn(run func(ctx context.Context, id string, qty int, warehouseID string, adminUserID string) (*model.Product, error)) *ProductService_AddStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Create provides a mock function for the type ProductService
 func (_mock *ProductService) Create(ctx context.Context, req *domain.CreateProductReq) (*model.Product, error) {
 	ret := _mock.Called(ctx, req)
@@ -75,7 +161,7 @@ type ProductService_Create_Call struct {
 
 // Create is a helper method to define mock.On call
 //   - ctx context.Context
-//   - req *dto.CreateProductReq
+//   - req *domain.CreateProductReq
 func (_e *ProductService_Expecter) Create(ctx interface{}, req interface{}) *ProductService_Create_Call {
 	return &ProductService_Create_Call{Call: _e.mock.On("Create", ctx, req)}
 }
@@ -219,7 +305,7 @@ type ProductService_ListProducts_Call struct {
 
 // ListProducts is a helper method to define mock.On call
 //   - c context.Context
-//   - req *dto.ListProductReq
+//   - req *domain.ListProductReq
 func (_e *ProductService_Expecter) ListProducts(c interface{}, req interface{}) *ProductService_ListProducts_Call {
 	return &ProductService_ListProducts_Call{Call: _e.mock.On("ListProducts", c, req)}
 }
@@ -288,7 +374,7 @@ type ProductService_Update_Call struct {
 // Update is a helper method to define mock.On call
 //   - ctx context.Context
 //   - id string
-//   - req *dto.UpdateProductReq
+//   - req *domain.UpdateProductReq
 func (_e *ProductService_Expecter) Update(ctx interface{}, id interface{}, req interface{}) *ProductService_Update_Call {
 	return &ProductService_Update_Call{Call: _e.mock.On("Update", ctx, id, req)}
 }
@@ -325,38 +411,3 @@ func (_c *ProductService_Update_Call) RunAndReturn(run func(ctx context.Context,
 	_c.Call.Return(run)
 	return _c
 }
-
-// AddStock provides a mock function for the type ProductService
-func (_mock *ProductService) AddStock(ctx context.Context, id string, qty int, adminUserID string) (*model.Product, error) {
-	ret := _mock.Called(ctx, id, qty, adminUserID)
-	if len(ret) == 0 {
-		panic("no return value specified for AddStock")
-	}
-	var r0 *model.Product
-	var r1 error
-	if returnFunc, ok := ret.Get(0).(func(context.Context, string, int, string) (*model.Product, error)); ok {
-		return returnFunc(ctx, id, qty, adminUserID)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, string) *model.Product); ok {
-		r0 = rf(ctx, id, qty, adminUserID)
-	} else if ret.Get(0) != nil {
-		r0 = ret.Get(0).(*model.Product)
-	}
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, string) error); ok {
-		r1 = rf(ctx, id, qty, adminUserID)
-	} else {
-		r1 = ret.Error(1)
-	}
-	return r0, r1
-}
-
-type ProductService_AddStock_Call struct{ *mock.Call }
-
-func (_e *ProductService_Expecter) AddStock(ctx interface{}, id interface{}, qty interface{}, adminUserID interface{}) *ProductService_AddStock_Call {
-	return &ProductService_AddStock_Call{Call: _e.mock.On("AddStock", ctx, id, qty, adminUserID)}
-}
-
-func (_c *ProductService_AddStock_Call) Return(p *model.Product, err error) *ProductService_AddStock_Call {
-	_c.Call.Return(p, err)
-	return _c
-}
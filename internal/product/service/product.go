@@ -10,32 +10,55 @@ import (
 	"goshop/internal/product/domain"
 	"goshop/internal/product/model"
 	"goshop/internal/product/repository"
+	"goshop/pkg/apperror"
+	"goshop/pkg/currency"
+	"goshop/pkg/dbs"
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
+	"goshop/pkg/stock"
 )
 
 var errInvalidStockQty = errors.New("stock quantity must be positive")
 
+// StockLedger appends a movement to the inventory ledger. Call it right after the counter
+// change it describes, inside the same transaction. Declared here so the product domain
+// doesn't depend on the inventory package.
+//
+//go:generate mockery --name=StockLedger
+type StockLedger interface {
+	Record(ctx context.Context, m stock.Movement) error
+}
+
 //go:generate mockery --name=ProductService
 type ProductService interface {
 	ListProducts(c context.Context, req *domain.ListProductReq) ([]*model.Product, *paging.Pagination, error)
 	GetProductByID(ctx context.Context, id string) (*model.Product, error)
 	Create(ctx context.Context, req *domain.CreateProductReq) (*model.Product, error)
 	Update(ctx context.Context, id string, req *domain.UpdateProductReq) (*model.Product, error)
-	AddStock(ctx context.Context, id string, qty int, adminUserID string) (*model.Product, error)
+	AddStock(ctx context.Context, id string, qty int, warehouseID, adminUserID string) (*model.Product, error)
 }
 
 type productSvc struct {
 	validator validation.Validation
+	db        dbs.Database
 	repo      repository.ProductRepository
+	ledger    StockLedger
+	rates     *currency.Rates
 }
 
 func NewProductService(
 	validator validation.Validation,
+	db dbs.Database,
 	repo repository.ProductRepository,
+	ledger StockLedger,
+	rates *currency.Rates,
 ) ProductService {
 	return &productSvc{
 		validator: validator,
+		db:        db,
 		repo:      repo,
+		ledger:    ledger,
+		rates:     rates,
 	}
 }
 
@@ -61,38 +84,81 @@ func (p *productSvc) Create(ctx context.Context, req *domain.CreateProductReq) (
 	if err := p.validator.ValidateStruct(req); err != nil {
 		return nil, err
 	}
+	if err := p.checkPrice(req.Price); err != nil {
+		return nil, err
+	}
 
 	product := model.Product{
-		Name:          req.Name,
-		Description:   req.Description,
-		Price:         req.Price,
-		StockQuantity: req.StockQuantity,
-		Images:        req.Images,
+		Name:              req.Name,
+		Description:       req.Description,
+		Price:             req.Price,
+		StockQuantity:     req.StockQuantity,
+		WeightGrams:       req.WeightGrams,
+		TaxClass:          req.TaxClass,
+		Images:            req.Images,
+		LowStockThreshold: req.LowStockThreshold,
+		ReorderQuantity:   req.ReorderQuantity,
 	}
 	if req.CategoryID != "" {
 		cid := req.CategoryID
 		product.CategoryID = &cid
 	}
 
-	if err := p.repo.Create(ctx, &product); err != nil {
+	err := p.db.WithTransaction(func() error {
+		if err := p.repo.Create(ctx, &product); err != nil {
+			return err
+		}
+		warehouseID, err := p.placeStock(ctx, product.ID, req.WarehouseID, product.StockQuantity)
+		if err != nil {
+			return err
+		}
+		return p.ledger.Record(ctx, stock.Movement{
+			ProductID:   product.ID,
+			Kind:        stock.MovementOpening,
+			StockDelta:  product.StockQuantity,
+			Actor:       req.ActorID,
+			Reason:      "product created",
+			WarehouseID: warehouseID,
+		})
+	})
+	if err != nil {
 		logger.Errorf("Create fail, error: %s", err)
 		return nil, err
 	}
 
-	return &product, nil
+	// Re-read so the effective stock settings reflect the category.
+	return p.repo.GetProductByID(ctx, product.ID)
 }
 
-// AddStock atomically increases a product's stock_quantity. The adminUserID is included in
-// the audit log so we can trace who restocked what; not persisted yet (a stock_audit table
-// is a follow-up).
-func (p *productSvc) AddStock(ctx context.Context, id string, qty int, adminUserID string) (*model.Product, error) {
+// AddStock atomically increases a product's stock_quantity, and its stock at warehouseID (the
+// default warehouse when empty), and records the restock, with adminUserID as its actor, in
+// the stock ledger.
+func (p *productSvc) AddStock(ctx context.Context, id string, qty int, warehouseID, adminUserID string) (*model.Product, error) {
 	if qty <= 0 {
 		return nil, errInvalidStockQty
 	}
-	if err := p.repo.AddStock(ctx, id, qty); err != nil {
+	err := p.db.WithTransaction(func() error {
+		if err := p.repo.AddStock(ctx, id, qty); err != nil {
+			return err
+		}
+		var err error
+		warehouseID, err = p.placeStock(ctx, id, warehouseID, qty)
+		if err != nil {
+			return err
+		}
+		return p.ledger.Record(ctx, stock.Movement{
+			ProductID:   id,
+			Kind:        stock.MovementRestock,
+			StockDelta:  qty,
+			Actor:       adminUserID,
+			Reason:      "admin restock",
+			WarehouseID: warehouseID,
+		})
+	})
+	if err != nil {
 		return nil, err
 	}
-	logger.Infof("admin restock: admin=%s product=%s qty=+%d", adminUserID, id, qty)
+	logger.Infof("admin restock: admin=%s product=%s warehouse=%s qty=+%d", adminUserID, id, warehouseID, qty)
 	return p.repo.GetProductByID(ctx, id)
 }
 
@@ -100,6 +166,11 @@ func (p *productSvc) Update(ctx context.Context, id string, req *domain.UpdatePr
 	if err := p.validator.ValidateStruct(req); err != nil {
 		return nil, err
 	}
+	if !req.Price.IsZero() {
+		if err := p.checkPrice(req.Price); err != nil {
+			return nil, err
+		}
+	}
 
 	product, err := p.repo.GetProductByID(ctx, id)
 	if err != nil {
@@ -113,10 +184,12 @@ func (p *productSvc) Update(ctx context.Context, id string, req *domain.UpdatePr
 	if req.Description != "" {
 		product.Description = req.Description
 	}
-	if req.Price != 0 {
+	if !req.Price.IsZero() {
 		product.Price = req.Price
 	}
+	stockDelta := 0
 	if req.StockQuantity != nil {
+		stockDelta = *req.StockQuantity - product.StockQuantity
 		product.StockQuantity = *req.StockQuantity
 	}
 	if req.Images != nil {
@@ -125,12 +198,84 @@ func (p *productSvc) Update(ctx context.Context, id string, req *domain.UpdatePr
 	if req.CategoryID != "" {
 		cid := req.CategoryID
 		product.CategoryID = &cid
+		product.Category = nil // don't let Save write back the previous category
 	}
-	err = p.repo.Update(ctx, product)
+	if req.LowStockThreshold != nil {
+		product.LowStockThreshold = req.LowStockThreshold
+	}
+	if req.ReorderQuantity != nil {
+		product.ReorderQuantity = req.ReorderQuantity
+	}
+	if req.WeightGrams != nil {
+		product.WeightGrams = *req.WeightGrams
+	}
+	if req.TaxClass != "" {
+		product.TaxClass = req.TaxClass
+	}
+	err = p.db.WithTransaction(func() error {
+		if err := p.repo.Update(ctx, product); err != nil {
+			return err
+		}
+		if stockDelta == 0 {
+			return nil
+		}
+		warehouseID, err := p.placeStock(ctx, id, req.WarehouseID, stockDelta)
+		if err != nil {
+			return err
+		}
+		reason := req.StockReason
+		if reason == "" {
+			reason = "manual adjustment"
+		}
+		return p.ledger.Record(ctx, stock.Movement{
+			ProductID:   id,
+			Kind:        stock.MovementAdjustment,
+			StockDelta:  stockDelta,
+			Actor:       req.ActorID,
+			Reason:      reason,
+			WarehouseID: warehouseID,
+		})
+	})
 	if err != nil {
 		logger.Errorf("Update fail, id: %s, error: %s", id, err)
 		return nil, err
 	}
 
-	return product, nil
+	// Re-read so the effective stock settings reflect the (possibly new) category.
+	return p.repo.GetProductByID(ctx, id)
+}
+
+// checkPrice rejects a price that isn't a positive amount of the shop's base currency, the
+// currency orders convert from.
+func (p *productSvc) checkPrice(price money.Money) error {
+	if !price.IsPositive() {
+		return apperror.WrapMessage(apperror.ErrBadRequest, nil, "price must be positive")
+	}
+	if price.Currency() != p.rates.Base() {
+		return apperror.WrapMessage(apperror.ErrBadRequest, nil, "price must be in "+p.rates.Base())
+	}
+	return nil
+}
+
+// placeStock applies a change to a product's stock_quantity to one warehouse, warehouseID or
+// the default warehouse when empty, and returns the warehouse it used.
+func (p *productSvc) placeStock(ctx context.Context, productID, warehouseID string, delta int) (string, error) {
+	if delta == 0 {
+		return "", nil
+	}
+	resolved, err := p.repo.ResolveWarehouse(ctx, warehouseID)
+	if errors.Is(err, repository.ErrWarehouseNotFound) {
+		return "", apperror.WrapMessage(apperror.ErrNotFound, err, "warehouse not found")
+	}
+	if err != nil {
+		return "", err
+	}
+	err = p.repo.AdjustWarehouseStock(ctx, productID, resolved, delta)
+	if errors.Is(err, repository.ErrWarehouseStockTooLow) {
+		return "", apperror.WrapMessage(apperror.ErrBadRequest, err, "the warehouse can't drop below its reserved units")
+	}
+	if err != nil {
+		return "", err
+	}
+	return resolved, nil
 }
@@ -12,15 +12,22 @@ import (
 
 	"goshop/internal/product/domain"
 	"goshop/internal/product/model"
+	"goshop/internal/product/repository"
 	"goshop/internal/product/repository/mocks"
+	serviceMocks "goshop/internal/product/service/mocks"
 	"goshop/pkg/config"
+	"goshop/pkg/currency"
+	dbsMocks "goshop/pkg/dbs/mocks"
+	"goshop/pkg/money"
 	"goshop/pkg/paging"
+	"goshop/pkg/stock"
 )
 
 type ProductServiceTestSuite struct {
 	suite.Suite
-	mockRepo *mocks.ProductRepository
-	service  ProductService
+	mockRepo   *mocks.ProductRepository
+	mockLedger *serviceMocks.StockLedger
+	service    ProductService
 }
 
 func (suite *ProductServiceTestSuite) SetupTest() {
@@ -28,7 +35,15 @@ func (suite *ProductServiceTestSuite) SetupTest() {
 
 	validator := validation.New()
 	suite.mockRepo = mocks.NewProductRepository(suite.T())
-	suite.service = NewProductService(validator, suite.mockRepo)
+	suite.mockLedger = serviceMocks.NewStockLedger(suite.T())
+	suite.service = NewProductService(validator, newTxDB(suite.T()), suite.mockRepo, suite.mockLedger, currency.MustParseRates("USD", ""))
+}
+
+// newTxDB returns a Database mock whose WithTransaction just runs the callback.
+func newTxDB(t *testing.T) *dbsMocks.Database {
+	db := dbsMocks.NewDatabase(t)
+	db.On("WithTransaction", mock.Anything).Return(func(fn func() error) error { return fn() }).Maybe()
+	return db
 }
 
 func TestProductServiceTestSuite(t *testing.T) {
@@ -45,7 +60,7 @@ func (suite *ProductServiceTestSuite) TestGetProductByID() {
 			name: "Success",
 			setup: func() {
 				suite.mockRepo.On("GetProductByID", mock.Anything, "productID").
-					Return(&model.Product{Name: "product", Description: "product description", Price: 1.1}, nil).Times(1)
+					Return(&model.Product{Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD")}, nil).Times(1)
 			},
 		},
 		{
@@ -69,7 +84,7 @@ func (suite *ProductServiceTestSuite) TestGetProductByID() {
 				suite.NotNil(product)
 				suite.Equal("product", product.Name)
 				suite.Equal("product description", product.Description)
-				suite.Equal(1.1, product.Price)
+				suite.Equal(money.MustParse("1.1", "USD"), product.Price)
 				suite.Nil(err)
 			}
 		})
@@ -86,7 +101,7 @@ func (suite *ProductServiceTestSuite) TestListProducts() {
 			name: "Success",
 			setup: func() {
 				suite.mockRepo.On("ListProducts", mock.Anything, mock.Anything).
-					Return([]*model.Product{{Name: "product", Description: "product description", Price: 1.1}},
+					Return([]*model.Product{{Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD")}},
 						&paging.Pagination{Total: 1, CurrentPage: 1, Limit: 10}, nil).Times(1)
 			},
 		},
@@ -129,14 +144,47 @@ func (suite *ProductServiceTestSuite) TestCreate() {
 	}{
 		{
 			name: "Success",
-			req:  &domain.CreateProductReq{Name: "product", Description: "product description", Price: 1.1},
+			req:  &domain.CreateProductReq{Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD"), StockQuantity: 5, ActorID: "admin"},
+			setup: func() {
+				suite.mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Times(1)
+				suite.mockRepo.On("ResolveWarehouse", mock.Anything, "").Return("wh1", nil).Times(1)
+				suite.mockRepo.On("AdjustWarehouseStock", mock.Anything, "", "wh1", 5).Return(nil).Times(1)
+				suite.mockLedger.On("Record", mock.Anything, stock.Movement{
+					Kind:        stock.MovementOpening,
+					StockDelta:  5,
+					Actor:       "admin",
+					Reason:      "product created",
+					WarehouseID: "wh1",
+				}).Return(nil).Times(1)
+				suite.mockRepo.On("GetProductByID", mock.Anything, mock.Anything).
+					Return(&model.Product{Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD")}, nil).Times(1)
+			},
+		},
+		{
+			name: "Ledger fail",
+			req:  &domain.CreateProductReq{Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD"), StockQuantity: 5},
+			setup: func() {
+				suite.mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Times(1)
+				suite.mockRepo.On("ResolveWarehouse", mock.Anything, "").Return("wh1", nil).Times(1)
+				suite.mockRepo.On("AdjustWarehouseStock", mock.Anything, "", "wh1", 5).Return(nil).Times(1)
+				suite.mockLedger.On("Record", mock.Anything, mock.Anything).Return(errors.New("error")).Times(1)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Warehouse not found",
+			req: &domain.CreateProductReq{
+				Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD"), StockQuantity: 5, WarehouseID: "nope",
+			},
 			setup: func() {
 				suite.mockRepo.On("Create", mock.Anything, mock.Anything).Return(nil).Times(1)
+				suite.mockRepo.On("ResolveWarehouse", mock.Anything, "nope").Return("", repository.ErrWarehouseNotFound).Times(1)
 			},
+			wantErr: true,
 		},
 		{
 			name: "DB fail",
-			req:  &domain.CreateProductReq{Name: "product", Description: "product description", Price: 1.1},
+			req:  &domain.CreateProductReq{Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD")},
 			setup: func() {
 				suite.mockRepo.On("Create", mock.Anything, mock.Anything).Return(errors.New("error")).Times(1)
 			},
@@ -144,7 +192,7 @@ func (suite *ProductServiceTestSuite) TestCreate() {
 		},
 		{
 			name:    "Missing product name",
-			req:     &domain.CreateProductReq{Description: "product description", Price: 1.1},
+			req:     &domain.CreateProductReq{Description: "product description", Price: money.MustParse("1.1", "USD")},
 			setup:   func() {},
 			wantErr: true,
 		},
@@ -169,6 +217,7 @@ func (suite *ProductServiceTestSuite) TestCreate() {
 }
 
 func (suite *ProductServiceTestSuite) TestUpdate() {
+	newStock := 7
 	tests := []struct {
 		name    string
 		req     *domain.UpdateProductReq
@@ -177,32 +226,80 @@ func (suite *ProductServiceTestSuite) TestUpdate() {
 	}{
 		{
 			name: "Success",
-			req:  &domain.UpdateProductReq{Name: "product", Description: "product description", Price: 1.1},
+			req:  &domain.UpdateProductReq{Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD")},
+			setup: func() {
+				suite.mockRepo.On("GetProductByID", mock.Anything, "productID").
+					Return(&model.Product{Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD")}, nil).Times(2)
+				suite.mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "Stock adjustment recorded",
+			req: &domain.UpdateProductReq{
+				Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD"),
+				StockQuantity: &newStock, StockReason: "cycle count", ActorID: "admin",
+			},
+			setup: func() {
+				suite.mockRepo.On("GetProductByID", mock.Anything, "productID").
+					Return(&model.Product{Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD"), StockQuantity: 10}, nil).Times(2)
+				suite.mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Times(1)
+				suite.mockRepo.On("ResolveWarehouse", mock.Anything, "").Return("wh1", nil).Times(1)
+				suite.mockRepo.On("AdjustWarehouseStock", mock.Anything, "productID", "wh1", -3).Return(nil).Times(1)
+				suite.mockLedger.On("Record", mock.Anything, stock.Movement{
+					ProductID:   "productID",
+					Kind:        stock.MovementAdjustment,
+					StockDelta:  -3,
+					Actor:       "admin",
+					Reason:      "cycle count",
+					WarehouseID: "wh1",
+				}).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "Stock adjustment below warehouse reserved",
+			req:  &domain.UpdateProductReq{StockQuantity: &newStock, WarehouseID: "wh2"},
 			setup: func() {
 				suite.mockRepo.On("GetProductByID", mock.Anything, "productID").
-					Return(&model.Product{Name: "product", Description: "product description", Price: 1.1}, nil).Times(1)
+					Return(&model.Product{StockQuantity: 10}, nil).Times(1)
 				suite.mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Times(1)
+				suite.mockRepo.On("ResolveWarehouse", mock.Anything, "wh2").Return("wh2", nil).Times(1)
+				suite.mockRepo.On("AdjustWarehouseStock", mock.Anything, "productID", "wh2", -3).
+					Return(repository.ErrWarehouseStockTooLow).Times(1)
 			},
+			wantErr: true,
+		},
+		{
+			name: "Stock adjustment ledger fail",
+			req:  &domain.UpdateProductReq{StockQuantity: &newStock},
+			setup: func() {
+				suite.mockRepo.On("GetProductByID", mock.Anything, "productID").
+					Return(&model.Product{StockQuantity: 10}, nil).Times(1)
+				suite.mockRepo.On("Update", mock.Anything, mock.Anything).Return(nil).Times(1)
+				suite.mockRepo.On("ResolveWarehouse", mock.Anything, "").Return("wh1", nil).Times(1)
+				suite.mockRepo.On("AdjustWarehouseStock", mock.Anything, "productID", "wh1", -3).Return(nil).Times(1)
+				suite.mockLedger.On("Record", mock.Anything, mock.Anything).Return(errors.New("error")).Times(1)
+			},
+			wantErr: true,
 		},
 		{
 			name: "Update DB fail",
-			req:  &domain.UpdateProductReq{Name: "product", Description: "product description", Price: 1.1},
+			req:  &domain.UpdateProductReq{Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD")},
 			setup: func() {
 				suite.mockRepo.On("GetProductByID", mock.Anything, "productID").
-					Return(&model.Product{Name: "product", Description: "product description", Price: 1.1}, nil).Times(1)
+					Return(&model.Product{Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD")}, nil).Times(1)
 				suite.mockRepo.On("Update", mock.Anything, mock.Anything).Return(errors.New("error")).Times(1)
 			},
 			wantErr: true,
 		},
 		{
 			name:    "Invalid price",
-			req:     &domain.UpdateProductReq{Name: "product", Description: "product description", Price: -1.1},
+			req:     &domain.UpdateProductReq{Name: "product", Description: "product description", Price: money.MustParse("-1.1", "USD")},
 			setup:   func() {},
 			wantErr: true,
 		},
 		{
 			name: "GetProductByID fail",
-			req:  &domain.UpdateProductReq{Name: "product", Description: "product description", Price: 1.1},
+			req:  &domain.UpdateProductReq{Name: "product", Description: "product description", Price: money.MustParse("1.1", "USD")},
 			setup: func() {
 				suite.mockRepo.On("GetProductByID", mock.Anything, "productID").
 					Return(nil, errors.New("error")).Times(1)
@@ -12,42 +12,96 @@ import (
 	"goshop/internal/product/domain"
 	"goshop/internal/product/model"
 	"goshop/internal/product/repository/mocks"
+	serviceMocks "goshop/internal/product/service/mocks"
 	"goshop/pkg/config"
+	"goshop/pkg/currency"
+	"goshop/pkg/money"
+	"goshop/pkg/stock"
 )
 
-func newProductSvc(t *testing.T) (ProductService, *mocks.ProductRepository) {
+func newProductSvc(t *testing.T) (ProductService, *mocks.ProductRepository, *serviceMocks.StockLedger) {
 	logger.Initialize(config.ProductionEnv)
 	repo := mocks.NewProductRepository(t)
-	return NewProductService(validation.New(), repo), repo
+	ledger := serviceMocks.NewStockLedger(t)
+	return NewProductService(validation.New(), newTxDB(t), repo, ledger, currency.MustParseRates("USD", "")), repo, ledger
 }
 
 func TestProductService_Create_WithCategory(t *testing.T) {
-	svc, repo := newProductSvc(t)
+	svc, repo, ledger := newProductSvc(t)
+	ledger.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
 	repo.On("Create", mock.Anything, mock.MatchedBy(func(p *model.Product) bool {
 		return p.CategoryID != nil && *p.CategoryID == "cat1"
 	})).Return(nil).Once()
+	repo.On("ResolveWarehouse", mock.Anything, "").Return("wh1", nil).Once()
+	repo.On("AdjustWarehouseStock", mock.Anything, "", "wh1", 1).Return(nil).Once()
+	repo.On("GetProductByID", mock.Anything, mock.Anything).Return(&model.Product{}, nil).Once()
 
 	_, err := svc.Create(context.Background(), &domain.CreateProductReq{
-		Name: "x", Description: "x", Price: 10, StockQuantity: 1, CategoryID: "cat1",
+		Name: "x", Description: "x", Price: money.MustParse("10", "USD"), StockQuantity: 1, CategoryID: "cat1",
 	})
 	require.NoError(t, err)
 }
 
+func TestProductService_Create_StockSettings(t *testing.T) {
+	svc, repo, ledger := newProductSvc(t)
+	ledger.On("Record", mock.Anything, mock.Anything).Return(nil).Once()
+	threshold, reorder := 3, 40
+	repo.On("Create", mock.Anything, mock.MatchedBy(func(p *model.Product) bool {
+		return *p.LowStockThreshold == threshold && *p.ReorderQuantity == reorder && p.WeightGrams == 250 && p.TaxClass == "books"
+	})).Return(nil).Once()
+	repo.On("GetProductByID", mock.Anything, mock.Anything).Return(&model.Product{}, nil).Once()
+
+	_, err := svc.Create(context.Background(), &domain.CreateProductReq{
+		Name: "x", Description: "x", Price: money.MustParse("10", "USD"), LowStockThreshold: &threshold, ReorderQuantity: &reorder,
+		WeightGrams: 250, TaxClass: "books",
+	})
+	require.NoError(t, err)
+}
+
+func TestProductService_Create_NegativeThresholdRejected(t *testing.T) {
+	svc, _, _ := newProductSvc(t)
+	threshold := -1
+	_, err := svc.Create(context.Background(), &domain.CreateProductReq{
+		Name: "x", Description: "x", Price: money.MustParse("10", "USD"), LowStockThreshold: &threshold,
+	})
+	require.Error(t, err)
+}
+
 func TestProductService_Update_AllFieldsMutated(t *testing.T) {
-	svc, repo := newProductSvc(t)
+	svc, repo, ledger := newProductSvc(t)
+	oldCategory := "cat-old"
 	repo.On("GetProductByID", mock.Anything, "p1").
-		Return(&model.Product{ID: "p1", Name: "old", Price: 1}, nil).Once()
-	repo.On("Update", mock.Anything, mock.Anything).Return(nil).Once()
-
-	qty := 50
+		Return(&model.Product{
+			ID: "p1", Name: "old", Price: money.MustParse("1", "USD"), CategoryID: &oldCategory, Category: &model.Category{ID: oldCategory},
+		}, nil).Twice()
+	qty, threshold, reorder := 50, 8, 30
+	weight := int64(1200)
 	cid := "cat-new"
+	repo.On("Update", mock.Anything, mock.MatchedBy(func(p *model.Product) bool {
+		return *p.CategoryID == cid && p.Category == nil &&
+			*p.LowStockThreshold == threshold && *p.ReorderQuantity == reorder && p.WeightGrams == weight && p.TaxClass == "food"
+	})).Return(nil).Once()
+	repo.On("ResolveWarehouse", mock.Anything, "").Return("wh1", nil).Once()
+	repo.On("AdjustWarehouseStock", mock.Anything, "p1", "wh1", 50).Return(nil).Once()
+	ledger.On("Record", mock.Anything, stock.Movement{
+		ProductID:   "p1",
+		Kind:        stock.MovementAdjustment,
+		StockDelta:  50,
+		Reason:      "manual adjustment",
+		WarehouseID: "wh1",
+	}).Return(nil).Once()
+
 	_, err := svc.Update(context.Background(), "p1", &domain.UpdateProductReq{
-		Name:          "new",
-		Description:   "new",
-		Price:         99,
-		StockQuantity: &qty,
-		Images:        []string{"img"},
-		CategoryID:    cid,
+		Name:              "new",
+		Description:       "new",
+		Price:             money.MustParse("99", "USD"),
+		StockQuantity:     &qty,
+		Images:            []string{"img"},
+		CategoryID:        cid,
+		LowStockThreshold: &threshold,
+		ReorderQuantity:   &reorder,
+		WeightGrams:       &weight,
+		TaxClass:          "food",
 	})
 	require.NoError(t, err)
 }
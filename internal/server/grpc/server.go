@@ -9,6 +9,7 @@ import (
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 
+	cartGRPC "goshop/internal/cart/port/grpc"
 	orderGRPC "goshop/internal/order/port/grpc"
 	productGRPC "goshop/internal/product/port/grpc"
 	userGRPC "goshop/internal/user/port/grpc"
@@ -48,6 +49,7 @@ func (s *Server) Run() error {
 	userGRPC.RegisterHandlers(s.engine, s.db, s.validator)
 	productGRPC.RegisterHandlers(s.engine, s.db, s.validator)
 	orderGRPC.RegisterHandlers(s.engine, s.db, s.validator)
+	cartGRPC.RegisterHandlers(s.engine, s.db, s.validator)
 
 	reflection.Register(s.engine)
 
@@ -14,8 +14,11 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	_ "goshop/docs"
+	cartHttp "goshop/internal/cart/port/http"
+	inventoryHttp "goshop/internal/inventory/port/http"
 	notificationHttp "goshop/internal/notification/port/http"
 	orderHttp "goshop/internal/order/port/http"
+	outboxHttp "goshop/internal/outbox/port/http"
 	paymentHttp "goshop/internal/payment/port/http"
 	productHttp "goshop/internal/product/port/http"
 	userHttp "goshop/internal/user/port/http"
@@ -91,7 +94,10 @@ func (s *Server) MapRoutes() error {
 	userHttp.Routes(v1, s.db, s.validator)
 	productHttp.Routes(v1, s.db, s.validator, s.cache)
 	orderHttp.Routes(v1, s.db, s.validator)
+	cartHttp.Routes(v1, s.db, s.validator)
 	paymentHttp.Routes(v1, s.db, s.validator)
 	notificationHttp.Routes(v1, s.db)
+	outboxHttp.Routes(v1, s.db)
+	inventoryHttp.Routes(v1, s.db, s.validator)
 	return nil
 }
@@ -9,17 +9,21 @@ type Address struct {
 	Phone     string    `json:"phone"`
 	Street    string    `json:"street"`
 	City      string    `json:"city"`
+	Region    string    `json:"region,omitempty"`
 	Country   string    `json:"country"`
 	IsDefault bool      `json:"is_default"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
 
+// CreateAddressReq adds an address. Region is the state or province, for tax rates that vary
+// within the country.
 type CreateAddressReq struct {
 	Name    string `json:"name" validate:"required"`
 	Phone   string `json:"phone" validate:"required"`
 	Street  string `json:"street" validate:"required"`
 	City    string `json:"city" validate:"required"`
+	Region  string `json:"region,omitempty"`
 	Country string `json:"country" validate:"required"`
 }
 
@@ -28,5 +32,6 @@ type UpdateAddressReq struct {
 	Phone   string `json:"phone,omitempty"`
 	Street  string `json:"street,omitempty"`
 	City    string `json:"city,omitempty"`
+	Region  string `json:"region,omitempty"`
 	Country string `json:"country,omitempty"`
 }
@@ -28,6 +28,7 @@ func AddressFromModel(m *model.Address) *Address {
 		Phone:     m.Phone,
 		Street:    m.Street,
 		City:      m.City,
+		Region:    m.Region,
 		Country:   m.Country,
 		IsDefault: m.IsDefault,
 		CreatedAt: m.CreatedAt,
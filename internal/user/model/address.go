@@ -17,6 +17,7 @@ type Address struct {
 	Phone     string     `json:"phone"`
 	Street    string     `json:"street"`
 	City      string     `json:"city"`
+	Region    string     `json:"region"`
 	Country   string     `json:"country"`
 	IsDefault bool       `json:"is_default" gorm:"default:false"`
 }
@@ -231,6 +231,74 @@ func (_c *UserRepository_GetUserByID_Call) RunAndReturn(run func(ctx context.Con
 	return _c
 }
 
+// ListByRole provides a mock function for the type UserRepository
+func (_mock *UserRepository) ListByRole(ctx context.Context, role model.UserRole) ([]*model.User, error) {
+	ret := _mock.Called(ctx, role)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListByRole")
+	}
+
+	var r0 []*model.User
+	var r1 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.UserRole) ([]*model.User, error)); ok {
+		return returnFunc(ctx, role)
+	}
+	if returnFunc, ok := ret.Get(0).(func(context.Context, model.UserRole) []*model.User); ok {
+		r0 = returnFunc(ctx, role)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+	if returnFunc, ok := ret.Get(1).(func(context.Context, model.UserRole) error); ok {
+		r1 = returnFunc(ctx, role)
+	} else {
+		r1 = ret.Error(1)
+	}
+	return r0, r1
+}
+
+// UserRepository_ListByRole_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ListByRole'
+type UserRepository_ListByRole_Call struct {
+	*mock.Call
+}
+
+// ListByRole is a helper method to define mock.On call
+//   - ctx context.Context
+//   - role model.UserRole
+func (_e *UserRepository_Expecter) ListByRole(ctx interface{}, role interface{}) *UserRepository_ListByRole_Call {
+	return &UserRepository_ListByRole_Call{Call: _e.mock.On("ListByRole", ctx, role)}
+}
+
+func (_c *UserRepository_ListByRole_Call) Run(run func(ctx context.Context, role model.UserRole)) *UserRepository_ListByRole_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 model.UserRole
+		if args[1] != nil {
+			arg1 = args[1].(model.UserRole)
+		}
+		run(
+			arg0,
+			arg1,
+		)
+	})
+	return _c
+}
+
+func (_c *UserRepository_ListByRole_Call) Return(users []*model.User, err error) *UserRepository_ListByRole_Call {
+	_c.Call.Return(users, err)
+	return _c
+}
+
+func (_c *UserRepository_ListByRole_Call) RunAndReturn(run func(ctx context.Context, role model.UserRole) ([]*model.User, error)) *UserRepository_ListByRole_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // Update provides a mock function for the type UserRepository
 func (_mock *UserRepository) Update(ctx context.Context, user *model.User) error {
 	ret := _mock.Called(ctx, user)
@@ -13,6 +13,7 @@ type UserRepository interface {
 	Update(ctx context.Context, user *model.User) error
 	GetUserByID(ctx context.Context, id string) (*model.User, error)
 	GetUserByEmail(ctx context.Context, email string) (*model.User, error)
+	ListByRole(ctx context.Context, role model.UserRole) ([]*model.User, error)
 }
 
 type userRepo struct {
@@ -49,3 +50,13 @@ func (r *userRepo) GetUserByEmail(ctx context.Context, email string) (*model.Use
 
 	return &user, nil
 }
+
+func (r *userRepo) ListByRole(ctx context.Context, role model.UserRole) ([]*model.User, error) {
+	var users []*model.User
+	query := dbs.NewQuery("role = ?", role)
+	if err := r.db.Find(ctx, &users, dbs.WithQuery(query)); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
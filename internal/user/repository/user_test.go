@@ -171,3 +171,37 @@ func (suite *UserRepositoryTestSuite) TestGetUserByEmail() {
 		})
 	}
 }
+
+func (suite *UserRepositoryTestSuite) TestListByRole() {
+	tests := []struct {
+		name    string
+		setup   func()
+		wantErr bool
+	}{
+		{
+			name: "Success",
+			setup: func() {
+				suite.mockDB.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(nil).Times(1)
+			},
+		},
+		{
+			name: "DB error",
+			setup: func() {
+				suite.mockDB.On("Find", mock.Anything, mock.Anything, mock.Anything).Return(errors.New("error")).Times(1)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tc := range tests {
+		suite.Run(tc.name, func() {
+			suite.SetupTest()
+			tc.setup()
+			_, err := suite.repo.ListByRole(context.Background(), model.UserRoleAdmin)
+			if tc.wantErr {
+				suite.NotNil(err)
+			} else {
+				suite.Nil(err)
+			}
+		})
+	}
+}
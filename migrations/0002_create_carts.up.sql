@@ -0,0 +1,30 @@
+-- Server-side shopping carts. A cart belongs to at most one user (user_id NULL for
+-- guest carts, which are addressed by id via the X-Cart-ID header until merged).
+-- Line prices are the price the shopper saw when adding; they are re-validated
+-- against products on every read.
+
+CREATE TABLE IF NOT EXISTS carts (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    user_id text,
+    CONSTRAINT uni_carts_id PRIMARY KEY (id),
+    CONSTRAINT fk_carts_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS cart_items (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    cart_id text NOT NULL,
+    product_id text NOT NULL,
+    quantity bigint NOT NULL,
+    unit_price numeric,
+    CONSTRAINT uni_cart_items_id PRIMARY KEY (id),
+    CONSTRAINT fk_carts_items FOREIGN KEY (cart_id) REFERENCES carts(id) ON DELETE CASCADE,
+    CONSTRAINT fk_cart_items_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_carts_user_id ON carts USING btree (user_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cart_item_cart_product ON cart_items USING btree (cart_id, product_id);
@@ -0,0 +1,3 @@
+ALTER TABLE carts DROP COLUMN IF EXISTS reminded_version;
+
+ALTER TABLE carts DROP COLUMN IF EXISTS version;
@@ -0,0 +1,7 @@
+-- Cart versioning for abandoned-cart reminders. version is bumped on every line
+-- change; reminded_version records the version the last reminder was sent for, so
+-- a cart is reminded at most once until the shopper touches it again.
+
+ALTER TABLE carts ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE carts ADD COLUMN IF NOT EXISTS reminded_version bigint NOT NULL DEFAULT 0;
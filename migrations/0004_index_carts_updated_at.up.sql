@@ -0,0 +1,4 @@
+-- Backs the abandoned-cart scan (user carts idle since before a cutoff, oldest first).
+-- Partial on user_id so guest carts, which are never reminded, stay out of the index.
+
+CREATE INDEX IF NOT EXISTS idx_carts_updated_at ON carts USING btree (updated_at) WHERE user_id IS NOT NULL;
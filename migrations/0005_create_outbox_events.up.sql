@@ -0,0 +1,20 @@
+-- Transactional outbox. Domain events are inserted in the same transaction as the
+-- state change that produced them; the relay publishes pending rows to the event
+-- bus and marks them delivered, so a crash between commit and publish can't drop
+-- an event.
+
+CREATE TABLE IF NOT EXISTS outbox_events (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    topic character varying(128) NOT NULL,
+    payload jsonb NOT NULL,
+    status character varying(16) NOT NULL DEFAULT 'pending',
+    attempts bigint NOT NULL DEFAULT 0,
+    next_attempt_at timestamp with time zone NOT NULL,
+    last_error text,
+    delivered_at timestamp with time zone,
+    CONSTRAINT uni_outbox_events_id PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_next_attempt_at ON outbox_events USING btree (next_attempt_at) WHERE status = 'pending';
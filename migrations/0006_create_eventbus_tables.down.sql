@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS eventbus_subscriptions CASCADE;
+DROP TABLE IF EXISTS eventbus_events        CASCADE;
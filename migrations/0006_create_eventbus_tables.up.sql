@@ -0,0 +1,32 @@
+-- Storage for the Postgres event bus backend (eventbus_backend=postgres). Events are
+-- appended to eventbus_events; each subscriber tracks its offset in
+-- eventbus_subscriptions, leased by one replica at a time.
+--
+-- tx_id records the publishing transaction (pg_current_xact_id) so consumers can read
+-- events in an order that never skips a transaction committing late; see
+-- pkg/eventbus/postgres.go. Unused with the inproc and redis backends.
+
+CREATE TABLE IF NOT EXISTS eventbus_events (
+    id bigserial NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    tx_id bigint NOT NULL DEFAULT (pg_current_xact_id()::text::bigint),
+    topic character varying(128) NOT NULL,
+    payload jsonb NOT NULL,
+    CONSTRAINT uni_eventbus_events_id PRIMARY KEY (id)
+);
+
+CREATE TABLE IF NOT EXISTS eventbus_subscriptions (
+    name character varying(255) NOT NULL,
+    topic character varying(128) NOT NULL,
+    last_tx_id bigint NOT NULL DEFAULT 0,
+    last_event_id bigint NOT NULL DEFAULT 0,
+    attempts bigint NOT NULL DEFAULT 0,
+    leased_by character varying(255),
+    lease_until timestamp with time zone,
+    updated_at timestamp with time zone,
+    CONSTRAINT uni_eventbus_subscriptions_name PRIMARY KEY (name)
+);
+
+CREATE INDEX IF NOT EXISTS idx_eventbus_events_topic_tx ON eventbus_events USING btree (topic, tx_id, id);
+
+CREATE INDEX IF NOT EXISTS idx_eventbus_events_created_at ON eventbus_events USING btree (created_at);
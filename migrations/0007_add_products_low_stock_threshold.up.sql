@@ -0,0 +1,4 @@
+-- Per-product low-stock threshold. NULL means the shop-wide default
+-- (LowStockThreshold in the order service) applies.
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS low_stock_threshold bigint;
@@ -0,0 +1,10 @@
+-- One row per product that has paged admins about low stock. alerted_at is only
+-- advanced once the cooldown has passed, so concurrent replicas handling the same
+-- LowStock event agree on which of them sends the alert.
+
+CREATE TABLE IF NOT EXISTS low_stock_alerts (
+    product_id text NOT NULL,
+    alerted_at timestamp with time zone NOT NULL,
+    available bigint NOT NULL,
+    CONSTRAINT low_stock_alerts_pkey PRIMARY KEY (product_id)
+);
@@ -0,0 +1,5 @@
+ALTER TABLE categories DROP COLUMN IF EXISTS reorder_quantity;
+
+ALTER TABLE categories DROP COLUMN IF EXISTS low_stock_threshold;
+
+ALTER TABLE products DROP COLUMN IF EXISTS reorder_quantity;
@@ -0,0 +1,9 @@
+-- Reorder points. Categories get a default low-stock threshold for their products,
+-- and both products and categories get a reorder quantity reported with low-stock
+-- alerts. NULL inherits: product, then category, then the shop-wide default.
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS reorder_quantity bigint;
+
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS low_stock_threshold bigint;
+
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS reorder_quantity bigint;
@@ -0,0 +1,33 @@
+-- Append-only inventory ledger. Every change to products.stock_quantity or
+-- products.reserved_quantity writes one row in the same transaction, with the
+-- counters before and after it, so replaying a product's deltas must land on its
+-- current counters.
+--
+-- Products that predate the ledger get an 'opening' row carrying their counters.
+
+CREATE TABLE IF NOT EXISTS stock_ledger_entries (
+    id text NOT NULL,
+    created_at timestamp with time zone NOT NULL,
+    product_id text NOT NULL,
+    kind character varying(32) NOT NULL,
+    stock_delta bigint NOT NULL DEFAULT 0,
+    reserved_delta bigint NOT NULL DEFAULT 0,
+    stock_before bigint NOT NULL,
+    stock_after bigint NOT NULL,
+    reserved_before bigint NOT NULL,
+    reserved_after bigint NOT NULL,
+    actor character varying(255) NOT NULL,
+    reason text,
+    order_id text,
+    reservation_id text,
+    CONSTRAINT uni_stock_ledger_entries_id PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_ledger_entries_product_created_at ON stock_ledger_entries USING btree (product_id, created_at);
+
+INSERT INTO stock_ledger_entries (id, created_at, product_id, kind, stock_delta, reserved_delta,
+    stock_before, stock_after, reserved_before, reserved_after, actor, reason)
+SELECT gen_random_uuid()::text, now(), p.id, 'opening', p.stock_quantity, p.reserved_quantity,
+    0, p.stock_quantity, 0, p.reserved_quantity, 'system', 'opening balance'
+FROM products p
+WHERE NOT EXISTS (SELECT 1 FROM stock_ledger_entries l WHERE l.product_id = p.id);
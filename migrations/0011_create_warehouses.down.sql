@@ -0,0 +1,7 @@
+ALTER TABLE stock_ledger_entries DROP COLUMN IF EXISTS warehouse_id;
+
+ALTER TABLE stock_reservations DROP COLUMN IF EXISTS warehouse_id;
+
+DROP TABLE IF EXISTS warehouse_stocks;
+
+DROP TABLE IF EXISTS warehouses;
@@ -0,0 +1,66 @@
+-- Multi-warehouse inventory. Stock is held per (warehouse, product) in
+-- warehouse_stocks; products.stock_quantity / reserved_quantity stay as the totals
+-- across warehouses and are updated in the same transaction, so catalog
+-- availability, low-stock alerts and the stock ledger keep reading one counter.
+-- Reservations and ledger rows record the warehouse that holds their units.
+--
+-- Existing stock and active reservations move to a 'DEFAULT' warehouse, which also
+-- receives restocks and adjustments that don't name a warehouse.
+
+CREATE TABLE IF NOT EXISTS warehouses (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    deleted_at timestamp with time zone,
+    code character varying(64) NOT NULL,
+    name character varying(255) NOT NULL,
+    country character varying(64),
+    city character varying(255),
+    priority bigint NOT NULL DEFAULT 0,
+    is_default boolean NOT NULL DEFAULT false,
+    active boolean NOT NULL DEFAULT true,
+    CONSTRAINT uni_warehouses_id PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_warehouses_code ON warehouses USING btree (code) WHERE (deleted_at IS NULL);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_warehouses_is_default ON warehouses USING btree (is_default) WHERE (is_default AND deleted_at IS NULL);
+
+CREATE TABLE IF NOT EXISTS warehouse_stocks (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    warehouse_id text NOT NULL,
+    product_id text NOT NULL,
+    stock_quantity bigint NOT NULL DEFAULT 0,
+    reserved_quantity bigint NOT NULL DEFAULT 0,
+    CONSTRAINT uni_warehouse_stocks_id PRIMARY KEY (id),
+    CONSTRAINT fk_warehouse_stocks_warehouse FOREIGN KEY (warehouse_id) REFERENCES warehouses(id),
+    CONSTRAINT fk_warehouse_stocks_product FOREIGN KEY (product_id) REFERENCES products(id) ON DELETE CASCADE,
+    CONSTRAINT chk_warehouse_stocks_stock_quantity CHECK ((stock_quantity >= 0)),
+    CONSTRAINT chk_warehouse_stocks_reserved_quantity CHECK ((reserved_quantity >= 0)),
+    CONSTRAINT chk_warehouse_stocks_reserved_lte_stock CHECK ((reserved_quantity <= stock_quantity))
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_warehouse_stocks_warehouse_product ON warehouse_stocks USING btree (warehouse_id, product_id);
+
+CREATE INDEX IF NOT EXISTS idx_warehouse_stocks_product_id ON warehouse_stocks USING btree (product_id);
+
+ALTER TABLE stock_reservations ADD COLUMN IF NOT EXISTS warehouse_id text;
+
+ALTER TABLE stock_ledger_entries ADD COLUMN IF NOT EXISTS warehouse_id text;
+
+INSERT INTO warehouses (id, created_at, updated_at, code, name, priority, is_default, active)
+SELECT gen_random_uuid()::text, now(), now(), 'DEFAULT', 'Default warehouse', 0, true, true
+WHERE NOT EXISTS (SELECT 1 FROM warehouses WHERE is_default AND deleted_at IS NULL);
+
+INSERT INTO warehouse_stocks (id, created_at, updated_at, warehouse_id, product_id, stock_quantity, reserved_quantity)
+SELECT gen_random_uuid()::text, now(), now(), w.id, p.id, p.stock_quantity, p.reserved_quantity
+FROM products p
+CROSS JOIN warehouses w
+WHERE w.is_default AND w.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM warehouse_stocks s WHERE s.product_id = p.id);
+
+UPDATE stock_reservations
+SET warehouse_id = (SELECT id FROM warehouses WHERE is_default AND deleted_at IS NULL)
+WHERE warehouse_id IS NULL AND status = 'active';
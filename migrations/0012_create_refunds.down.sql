@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS refund_lines;
+
+DROP TABLE IF EXISTS refunds;
+
+ALTER TABLE payments DROP CONSTRAINT IF EXISTS chk_payments_amount_refunded;
+
+ALTER TABLE payments DROP COLUMN IF EXISTS amount_refunded;
@@ -0,0 +1,58 @@
+-- Refunds issued against a payment, in full or for specific order lines.
+-- payments.amount_refunded counts pending and succeeded refunds so two concurrent
+-- refunds can't exceed the captured amount; a refund that fails gives its amount back.
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS amount_refunded bigint NOT NULL DEFAULT 0;
+
+DO $$
+BEGIN
+    IF NOT EXISTS (SELECT 1 FROM pg_constraint WHERE conname = 'chk_payments_amount_refunded') THEN
+        ALTER TABLE payments ADD CONSTRAINT chk_payments_amount_refunded
+            CHECK ((amount_refunded >= 0 AND amount_refunded <= amount));
+    END IF;
+END $$;
+
+CREATE TABLE IF NOT EXISTS refunds (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    payment_id text NOT NULL,
+    order_id text NOT NULL,
+    provider_refund_id text,
+    idempotency_key text NOT NULL,
+    amount bigint NOT NULL,
+    currency text NOT NULL,
+    status text NOT NULL,
+    reason text,
+    actor_id text,
+    CONSTRAINT refunds_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_refunds_payment FOREIGN KEY (payment_id) REFERENCES payments(id) ON DELETE CASCADE,
+    CONSTRAINT chk_refunds_amount CHECK ((amount > 0))
+);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_payment_id ON refunds USING btree (payment_id);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_order_id ON refunds USING btree (order_id);
+
+CREATE INDEX IF NOT EXISTS idx_refunds_status ON refunds USING btree (status);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refunds_provider_refund_id ON refunds USING btree (provider_refund_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_refunds_idempotency_key ON refunds USING btree (idempotency_key);
+
+CREATE TABLE IF NOT EXISTS refund_lines (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    refund_id text NOT NULL,
+    order_line_id text NOT NULL,
+    product_id text NOT NULL,
+    quantity bigint NOT NULL,
+    amount bigint NOT NULL,
+    CONSTRAINT refund_lines_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_refund_lines_refund FOREIGN KEY (refund_id) REFERENCES refunds(id) ON DELETE CASCADE,
+    CONSTRAINT chk_refund_lines_quantity CHECK ((quantity > 0))
+);
+
+CREATE INDEX IF NOT EXISTS idx_refund_lines_refund_id ON refund_lines USING btree (refund_id);
+
+CREATE INDEX IF NOT EXISTS idx_refund_lines_order_line_id ON refund_lines USING btree (order_line_id);
@@ -0,0 +1,5 @@
+-- How an order is paid. 'online' orders wait in pending_payment for a payment
+-- provider; 'cod' (cash on delivery) orders commit their stock at placement and
+-- record the cash as a payment when the courier delivers them.
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS payment_method text NOT NULL DEFAULT 'online';
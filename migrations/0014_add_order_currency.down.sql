@@ -0,0 +1,3 @@
+ALTER TABLE order_lines DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,7 @@
+-- Currency of an order and its lines (ISO 4217 code). Prices are converted from the
+-- product's base-currency price when the order is placed; payments and refunds charge
+-- this currency. Orders placed before currencies were recorded were priced in USD.
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency character varying(3) NOT NULL DEFAULT 'USD';
+
+ALTER TABLE order_lines ADD COLUMN IF NOT EXISTS currency character varying(3) NOT NULL DEFAULT 'USD';
@@ -0,0 +1,30 @@
+DROP TRIGGER IF EXISTS trg_coupons_sync_discount ON coupons;
+DROP TRIGGER IF EXISTS trg_coupons_sync_min_order ON coupons;
+DROP TRIGGER IF EXISTS trg_cart_items_sync_money ON cart_items;
+DROP TRIGGER IF EXISTS trg_order_lines_sync_money ON order_lines;
+DROP TRIGGER IF EXISTS trg_orders_sync_money ON orders;
+DROP TRIGGER IF EXISTS trg_products_sync_money ON products;
+
+DROP FUNCTION IF EXISTS sync_coupon_discount();
+DROP FUNCTION IF EXISTS sync_money_columns();
+
+ALTER TABLE coupons DROP COLUMN IF EXISTS min_order_amount_minor;
+ALTER TABLE coupons DROP COLUMN IF EXISTS discount_percent;
+ALTER TABLE coupons DROP COLUMN IF EXISTS discount_amount_minor;
+ALTER TABLE coupons DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE cart_items DROP COLUMN IF EXISTS unit_price_minor;
+ALTER TABLE cart_items DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE order_lines DROP COLUMN IF EXISTS price_minor;
+
+ALTER TABLE orders DROP COLUMN IF EXISTS final_price_minor;
+ALTER TABLE orders DROP COLUMN IF EXISTS discount_amount_minor;
+ALTER TABLE orders DROP COLUMN IF EXISTS total_price_minor;
+
+ALTER TABLE products DROP COLUMN IF EXISTS price_minor;
+ALTER TABLE products DROP COLUMN IF EXISTS currency;
+
+DROP FUNCTION IF EXISTS from_minor(bigint, text);
+DROP FUNCTION IF EXISTS to_minor(numeric, text);
+DROP FUNCTION IF EXISTS currency_exponent(text);
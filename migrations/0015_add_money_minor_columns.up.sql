@@ -0,0 +1,151 @@
+-- Money is stored exactly, as bigint minor units (cents, yen, fils) of the row's currency,
+-- instead of numeric major units that the app read through float64. Each amount gets a
+-- *_minor column backfilled from its numeric one; products, coupons and cart_items gain the
+-- currency their amounts are in. Coupons split discount_value into discount_amount_minor
+-- (fixed) and discount_percent (percentage).
+--
+-- The numeric columns are deprecated, not dropped: triggers keep them in step with the
+-- minor-unit columns in both directions so pods still on the old shape read and write
+-- correct prices during the deploy. A later migration drops them with the triggers.
+
+CREATE OR REPLACE FUNCTION currency_exponent(code text) RETURNS integer
+    LANGUAGE sql IMMUTABLE AS $$
+    SELECT CASE
+        WHEN upper(code) IN ('BHD', 'IQD', 'JOD', 'KWD', 'LYD', 'OMR', 'TND') THEN 3
+        WHEN upper(code) IN ('CLF', 'UYW') THEN 4
+        WHEN upper(code) IN ('BIF', 'CLP', 'DJF', 'GNF', 'ISK', 'JPY', 'KMF', 'KRW', 'PYG',
+                             'RWF', 'UGX', 'UYI', 'VND', 'VUV', 'XAF', 'XOF', 'XPF') THEN 0
+        ELSE 2
+    END
+$$;
+
+CREATE OR REPLACE FUNCTION to_minor(amount numeric, code text) RETURNS bigint
+    LANGUAGE sql IMMUTABLE AS $$
+    SELECT round(COALESCE(amount, 0) * 10::numeric ^ currency_exponent(code))::bigint
+$$;
+
+CREATE OR REPLACE FUNCTION from_minor(amount bigint, code text) RETURNS numeric
+    LANGUAGE sql IMMUTABLE AS $$
+    SELECT round(amount::numeric / 10::numeric ^ currency_exponent(code), currency_exponent(code))
+$$;
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS currency character varying(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE products ADD COLUMN IF NOT EXISTS price_minor bigint;
+UPDATE products SET price_minor = to_minor(price, currency) WHERE price_minor IS NULL;
+ALTER TABLE products ALTER COLUMN price_minor SET NOT NULL;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS total_price_minor bigint;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS discount_amount_minor bigint;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS final_price_minor bigint;
+UPDATE orders SET total_price_minor = to_minor(total_price, currency) WHERE total_price_minor IS NULL;
+UPDATE orders SET discount_amount_minor = to_minor(discount_amount, currency) WHERE discount_amount_minor IS NULL;
+UPDATE orders SET final_price_minor = to_minor(final_price, currency) WHERE final_price_minor IS NULL;
+ALTER TABLE orders ALTER COLUMN total_price_minor SET NOT NULL;
+ALTER TABLE orders ALTER COLUMN discount_amount_minor SET NOT NULL;
+ALTER TABLE orders ALTER COLUMN final_price_minor SET NOT NULL;
+
+ALTER TABLE order_lines ADD COLUMN IF NOT EXISTS price_minor bigint;
+UPDATE order_lines SET price_minor = to_minor(price, currency) WHERE price_minor IS NULL;
+ALTER TABLE order_lines ALTER COLUMN price_minor SET NOT NULL;
+
+ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS currency character varying(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS unit_price_minor bigint;
+UPDATE cart_items SET unit_price_minor = to_minor(unit_price, currency) WHERE unit_price_minor IS NULL;
+ALTER TABLE cart_items ALTER COLUMN unit_price_minor SET NOT NULL;
+
+ALTER TABLE coupons ADD COLUMN IF NOT EXISTS currency character varying(3) NOT NULL DEFAULT 'USD';
+ALTER TABLE coupons ADD COLUMN IF NOT EXISTS discount_amount_minor bigint;
+ALTER TABLE coupons ADD COLUMN IF NOT EXISTS discount_percent numeric(5,2);
+ALTER TABLE coupons ADD COLUMN IF NOT EXISTS min_order_amount_minor bigint;
+UPDATE coupons
+SET discount_amount_minor = CASE WHEN discount_type = 'fixed' THEN to_minor(discount_value, currency) ELSE 0 END,
+    discount_percent = CASE WHEN discount_type = 'percentage' THEN COALESCE(discount_value, 0) ELSE 0 END
+WHERE discount_amount_minor IS NULL;
+UPDATE coupons SET min_order_amount_minor = to_minor(min_order_amount, currency) WHERE min_order_amount_minor IS NULL;
+ALTER TABLE coupons ALTER COLUMN discount_amount_minor SET NOT NULL;
+ALTER TABLE coupons ALTER COLUMN discount_percent SET NOT NULL;
+ALTER TABLE coupons ALTER COLUMN min_order_amount_minor SET NOT NULL;
+
+-- sync_money_columns keeps deprecated numeric columns and their *_minor replacements in
+-- step. Arguments: the currency column, then (numeric column, minor column) pairs. Whichever
+-- side a statement wrote wins; an insert that leaves the minor column out came from the old
+-- shape.
+CREATE OR REPLACE FUNCTION sync_money_columns() RETURNS trigger
+    LANGUAGE plpgsql AS $$
+DECLARE
+    new_row jsonb := to_jsonb(NEW);
+    old_row jsonb;
+    code text := to_jsonb(NEW) ->> TG_ARGV[0];
+    legacy text;
+    minor text;
+BEGIN
+    IF TG_OP = 'UPDATE' THEN
+        old_row := to_jsonb(OLD);
+    END IF;
+    FOR i IN 1 .. array_length(TG_ARGV, 1) - 1 BY 2 LOOP
+        legacy := TG_ARGV[i];
+        minor := TG_ARGV[i + 1];
+        IF (TG_OP = 'INSERT' AND new_row ->> minor IS NULL)
+            OR (TG_OP = 'UPDATE' AND new_row -> minor = old_row -> minor
+                AND new_row -> legacy IS DISTINCT FROM old_row -> legacy) THEN
+            new_row := jsonb_set(new_row, ARRAY[minor],
+                to_jsonb(to_minor((new_row ->> legacy)::numeric, code)));
+        ELSIF TG_OP = 'INSERT' OR new_row -> minor IS DISTINCT FROM old_row -> minor THEN
+            new_row := jsonb_set(new_row, ARRAY[legacy],
+                to_jsonb(from_minor((new_row ->> minor)::bigint, code)));
+        END IF;
+    END LOOP;
+    NEW := jsonb_populate_record(NEW, new_row);
+    RETURN NEW;
+END
+$$;
+
+DROP TRIGGER IF EXISTS trg_products_sync_money ON products;
+CREATE TRIGGER trg_products_sync_money BEFORE INSERT OR UPDATE ON products
+    FOR EACH ROW EXECUTE FUNCTION sync_money_columns('currency', 'price', 'price_minor');
+
+DROP TRIGGER IF EXISTS trg_orders_sync_money ON orders;
+CREATE TRIGGER trg_orders_sync_money BEFORE INSERT OR UPDATE ON orders
+    FOR EACH ROW EXECUTE FUNCTION sync_money_columns('currency',
+        'total_price', 'total_price_minor',
+        'discount_amount', 'discount_amount_minor',
+        'final_price', 'final_price_minor');
+
+DROP TRIGGER IF EXISTS trg_order_lines_sync_money ON order_lines;
+CREATE TRIGGER trg_order_lines_sync_money BEFORE INSERT OR UPDATE ON order_lines
+    FOR EACH ROW EXECUTE FUNCTION sync_money_columns('currency', 'price', 'price_minor');
+
+DROP TRIGGER IF EXISTS trg_cart_items_sync_money ON cart_items;
+CREATE TRIGGER trg_cart_items_sync_money BEFORE INSERT OR UPDATE ON cart_items
+    FOR EACH ROW EXECUTE FUNCTION sync_money_columns('currency', 'unit_price', 'unit_price_minor');
+
+DROP TRIGGER IF EXISTS trg_coupons_sync_min_order ON coupons;
+CREATE TRIGGER trg_coupons_sync_min_order BEFORE INSERT OR UPDATE ON coupons
+    FOR EACH ROW EXECUTE FUNCTION sync_money_columns('currency', 'min_order_amount', 'min_order_amount_minor');
+
+-- A coupon's discount_value is a percentage or an amount depending on its type, so it has
+-- its own sync.
+CREATE OR REPLACE FUNCTION sync_coupon_discount() RETURNS trigger
+    LANGUAGE plpgsql AS $$
+BEGIN
+    IF (TG_OP = 'INSERT' AND NEW.discount_amount_minor IS NULL AND NEW.discount_percent IS NULL)
+        OR (TG_OP = 'UPDATE' AND NEW.discount_amount_minor IS NOT DISTINCT FROM OLD.discount_amount_minor
+            AND NEW.discount_percent IS NOT DISTINCT FROM OLD.discount_percent
+            AND NEW.discount_value IS DISTINCT FROM OLD.discount_value) THEN
+        NEW.discount_amount_minor := CASE WHEN NEW.discount_type = 'fixed'
+            THEN to_minor(NEW.discount_value, NEW.currency) ELSE 0 END;
+        NEW.discount_percent := CASE WHEN NEW.discount_type = 'percentage'
+            THEN COALESCE(NEW.discount_value, 0) ELSE 0 END;
+    ELSE
+        NEW.discount_amount_minor := COALESCE(NEW.discount_amount_minor, 0);
+        NEW.discount_percent := COALESCE(NEW.discount_percent, 0);
+        NEW.discount_value := CASE WHEN NEW.discount_type = 'percentage'
+            THEN NEW.discount_percent ELSE from_minor(NEW.discount_amount_minor, NEW.currency) END;
+    END IF;
+    RETURN NEW;
+END
+$$;
+
+DROP TRIGGER IF EXISTS trg_coupons_sync_discount ON coupons;
+CREATE TRIGGER trg_coupons_sync_discount BEFORE INSERT OR UPDATE ON coupons
+    FOR EACH ROW EXECUTE FUNCTION sync_coupon_discount();
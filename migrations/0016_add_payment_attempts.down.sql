@@ -0,0 +1,8 @@
+-- Brings back the one-payment-per-order unique index. Payment history is kept: while any order
+-- has more than one attempt this fails, and those rows have to be resolved by hand first.
+DROP INDEX IF EXISTS idx_payments_order_id_active;
+DROP INDEX IF EXISTS idx_payments_order_id_attempt;
+DROP INDEX IF EXISTS idx_payments_order_id;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_order_id ON payments USING btree (order_id);
+
+ALTER TABLE payments DROP COLUMN IF EXISTS attempt;
@@ -0,0 +1,14 @@
+-- An order can be paid in several attempts: when a payment fails the customer retries with a
+-- fresh provider intent, recorded as a new payments row with the next attempt number. The
+-- unique order_id index becomes a plain one, with a unique (order_id, attempt) and a partial
+-- unique order_id index allowing only one active (not failed or canceled) attempt.
+
+ALTER TABLE payments ADD COLUMN IF NOT EXISTS attempt integer NOT NULL DEFAULT 1;
+
+DROP INDEX IF EXISTS idx_payments_order_id;
+CREATE INDEX IF NOT EXISTS idx_payments_order_id ON payments USING btree (order_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_order_id_attempt ON payments USING btree (order_id, attempt);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payments_order_id_active ON payments USING btree (order_id)
+    WHERE status NOT IN ('failed', 'canceled') AND deleted_at IS NULL;
@@ -0,0 +1,8 @@
+ALTER TABLE provider_events DROP COLUMN IF EXISTS processed_at;
+ALTER TABLE provider_events DROP COLUMN IF EXISTS last_error;
+ALTER TABLE provider_events DROP COLUMN IF EXISTS next_attempt_at;
+ALTER TABLE provider_events DROP COLUMN IF EXISTS attempts;
+ALTER TABLE provider_events DROP COLUMN IF EXISTS status;
+ALTER TABLE provider_events DROP COLUMN IF EXISTS payload;
+ALTER TABLE provider_events DROP COLUMN IF EXISTS event_type;
+ALTER TABLE provider_events DROP COLUMN IF EXISTS updated_at;
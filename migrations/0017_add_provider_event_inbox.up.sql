@@ -0,0 +1,14 @@
+-- Webhook inbox. provider_events used to record only which deliveries had been seen, so an
+-- event whose processing failed was lost. It now keeps the verified payload with its
+-- processing state: pending rows are retried by a worker with backoff, failed ones wait for
+-- an admin to replay them. Rows recorded before this migration were processed and have no
+-- payload.
+
+ALTER TABLE provider_events ADD COLUMN IF NOT EXISTS updated_at timestamp with time zone;
+ALTER TABLE provider_events ADD COLUMN IF NOT EXISTS event_type character varying(64);
+ALTER TABLE provider_events ADD COLUMN IF NOT EXISTS payload text;
+ALTER TABLE provider_events ADD COLUMN IF NOT EXISTS status character varying(16) NOT NULL DEFAULT 'processed';
+ALTER TABLE provider_events ADD COLUMN IF NOT EXISTS attempts bigint NOT NULL DEFAULT 0;
+ALTER TABLE provider_events ADD COLUMN IF NOT EXISTS next_attempt_at timestamp with time zone;
+ALTER TABLE provider_events ADD COLUMN IF NOT EXISTS last_error text;
+ALTER TABLE provider_events ADD COLUMN IF NOT EXISTS processed_at timestamp with time zone;
@@ -0,0 +1 @@
+DROP INDEX IF EXISTS idx_provider_events_next_attempt_at;
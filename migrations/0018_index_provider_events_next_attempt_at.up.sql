@@ -0,0 +1,3 @@
+-- Partial index the webhook inbox worker claims due pending events from.
+
+CREATE INDEX IF NOT EXISTS idx_provider_events_next_attempt_at ON provider_events USING btree (next_attempt_at) WHERE status = 'pending';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS payment_methods;
+
+DROP TABLE IF EXISTS payment_customers;
@@ -0,0 +1,30 @@
+-- Provider-side customers and the payment methods returning buyers saved with them.
+-- Only the provider's tokens are stored, with the card brand and last 4 digits to show;
+-- card details stay with the provider.
+
+CREATE TABLE IF NOT EXISTS payment_customers (
+    user_id text NOT NULL,
+    provider text NOT NULL,
+    created_at timestamp with time zone,
+    provider_customer_id text NOT NULL,
+    CONSTRAINT payment_customers_pkey PRIMARY KEY (user_id, provider),
+    CONSTRAINT fk_payment_customers_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS payment_methods (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    user_id text NOT NULL,
+    provider text NOT NULL,
+    provider_customer_id text NOT NULL,
+    provider_method_id text NOT NULL,
+    brand text,
+    last4 varchar(4),
+    CONSTRAINT payment_methods_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_payment_methods_user FOREIGN KEY (user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_methods_user_id ON payment_methods USING btree (user_id);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_payment_methods_provider_method_id ON payment_methods USING btree (provider, provider_method_id);
@@ -0,0 +1,34 @@
+-- Shipments split an order into parcels, each carrying some units of some order lines.
+-- The order's status follows its shipments (partially_shipped, shipped, done).
+
+CREATE TABLE IF NOT EXISTS shipments (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    order_id text NOT NULL,
+    status text NOT NULL,
+    carrier text,
+    tracking_number text,
+    shipped_at timestamp with time zone,
+    delivered_at timestamp with time zone,
+    CONSTRAINT shipments_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_shipments_order FOREIGN KEY (order_id) REFERENCES orders(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipments_order_id ON shipments USING btree (order_id);
+
+CREATE TABLE IF NOT EXISTS shipment_lines (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    shipment_id text NOT NULL,
+    order_line_id text NOT NULL,
+    product_id text NOT NULL,
+    quantity bigint NOT NULL,
+    CONSTRAINT shipment_lines_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_shipment_lines_shipment FOREIGN KEY (shipment_id) REFERENCES shipments(id) ON DELETE CASCADE,
+    CONSTRAINT chk_shipment_lines_quantity CHECK ((quantity > 0))
+);
+
+CREATE INDEX IF NOT EXISTS idx_shipment_lines_shipment_id ON shipment_lines USING btree (shipment_id);
+
+CREATE INDEX IF NOT EXISTS idx_shipment_lines_order_line_id ON shipment_lines USING btree (order_line_id);
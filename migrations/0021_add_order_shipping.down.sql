@@ -0,0 +1,13 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS billing_country,
+    DROP COLUMN IF EXISTS billing_city,
+    DROP COLUMN IF EXISTS billing_street,
+    DROP COLUMN IF EXISTS billing_phone,
+    DROP COLUMN IF EXISTS billing_name,
+    DROP COLUMN IF EXISTS shipping_country,
+    DROP COLUMN IF EXISTS shipping_city,
+    DROP COLUMN IF EXISTS shipping_street,
+    DROP COLUMN IF EXISTS shipping_phone,
+    DROP COLUMN IF EXISTS shipping_name,
+    DROP COLUMN IF EXISTS shipping_fee_minor,
+    DROP COLUMN IF EXISTS shipping_method;
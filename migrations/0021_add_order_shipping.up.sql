@@ -0,0 +1,20 @@
+-- Where an order ships and how. The shipping and billing addresses are copied from
+-- the buyer's address book (or given inline) when the order is placed, so later edits
+-- to saved addresses don't move a placed order. shipping_fee_minor is what the chosen
+-- shipping method cost, in minor units of the order's currency; final_price_minor
+-- includes it. Orders placed before shipping was recorded have no address and no fee.
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_method text NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_fee_minor bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_name text NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_phone text NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_street text NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_city text NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_country text NOT NULL DEFAULT '';
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS billing_name text NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS billing_phone text NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS billing_street text NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS billing_city text NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS billing_country text NOT NULL DEFAULT '';
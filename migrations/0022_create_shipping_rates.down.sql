@@ -0,0 +1,3 @@
+ALTER TABLE products DROP COLUMN IF EXISTS weight_grams;
+
+DROP TABLE IF EXISTS shipping_rates;
@@ -0,0 +1,24 @@
+-- Shipping rates admins configure through the API. Each row is a shipping method;
+-- rule is its rate provider as JSON (flat, weight_tiers, price_tiers, free_over or
+-- zones), amounts in the shop's base currency. Active rates are offered by position,
+-- the first one being the default. With no active rates the shop falls back to the
+-- flat shipping_methods from configuration.
+--
+-- products.weight_grams is one unit's shipping weight, for weight-tiered rates.
+
+CREATE TABLE IF NOT EXISTS shipping_rates (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    code text NOT NULL,
+    name text NOT NULL DEFAULT '',
+    position bigint NOT NULL DEFAULT 0,
+    active boolean NOT NULL DEFAULT true,
+    rule jsonb NOT NULL,
+    CONSTRAINT shipping_rates_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_shipping_rates_code ON shipping_rates USING btree (code);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS weight_grams bigint NOT NULL DEFAULT 0
+    CONSTRAINT chk_products_weight_grams CHECK ((weight_grams >= 0));
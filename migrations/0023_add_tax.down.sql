@@ -0,0 +1,16 @@
+ALTER TABLE orders
+    DROP COLUMN IF EXISTS billing_region,
+    DROP COLUMN IF EXISTS shipping_region,
+    DROP COLUMN IF EXISTS tax_mode,
+    DROP COLUMN IF EXISTS tax_amount_minor;
+ALTER TABLE addresses DROP COLUMN IF EXISTS region;
+
+ALTER TABLE order_lines
+    DROP COLUMN IF EXISTS tax_amount_minor,
+    DROP COLUMN IF EXISTS tax_rate,
+    DROP COLUMN IF EXISTS tax_class;
+
+ALTER TABLE categories DROP COLUMN IF EXISTS tax_class;
+ALTER TABLE products DROP COLUMN IF EXISTS tax_class;
+
+DROP TABLE IF EXISTS tax_rates;
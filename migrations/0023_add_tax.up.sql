@@ -0,0 +1,40 @@
+-- Sales tax. tax_rates holds the rates admins configure through the API: a percentage
+-- for one tax class in a region of a country, the rest of a country when region is
+-- empty, or the rest of the world when country is empty too. Products name their
+-- tax_class, falling back to their category's and then to "standard".
+--
+-- Each order line records the class and rate it was taxed at and its tax in minor
+-- units of the order's currency; orders sum it in tax_amount_minor. tax_mode says
+-- whether prices included the tax (inclusive) or final_price_minor adds it
+-- (exclusive). Orders placed before tax was recorded are untaxed.
+--
+-- Addresses gain a region (state or province), copied onto orders with the rest of
+-- the address, since tax rates can vary within a country.
+
+CREATE TABLE IF NOT EXISTS tax_rates (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    updated_at timestamp with time zone,
+    country text NOT NULL DEFAULT '',
+    region text NOT NULL DEFAULT '',
+    tax_class text NOT NULL,
+    name text NOT NULL DEFAULT '',
+    rate numeric NOT NULL CONSTRAINT chk_tax_rates_rate CHECK ((rate >= 0 AND rate <= 100)),
+    CONSTRAINT tax_rates_pkey PRIMARY KEY (id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_tax_rates_destination ON tax_rates USING btree (country, region, tax_class);
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS tax_class text NOT NULL DEFAULT '';
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS tax_class text NOT NULL DEFAULT '';
+
+ALTER TABLE order_lines ADD COLUMN IF NOT EXISTS tax_class text NOT NULL DEFAULT '';
+ALTER TABLE order_lines ADD COLUMN IF NOT EXISTS tax_rate numeric NOT NULL DEFAULT 0;
+ALTER TABLE order_lines ADD COLUMN IF NOT EXISTS tax_amount_minor bigint NOT NULL DEFAULT 0;
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_amount_minor bigint NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax_mode text NOT NULL DEFAULT 'exclusive';
+
+ALTER TABLE addresses ADD COLUMN IF NOT EXISTS region text NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS shipping_region text NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS billing_region text NOT NULL DEFAULT '';
@@ -0,0 +1,41 @@
+-- Invoices and credit notes. An invoice is issued in the transaction that marks an
+-- order paid, and a credit note when one of its refunds succeeds. document is the
+-- issued copy (seller, buyer, billing address, lines, tax and totals) that the PDF
+-- and HTML renderings are made from, so later changes to the order, products or
+-- shop details don't alter it.
+--
+-- Each kind is numbered per calendar year: INV-2026-000001, CN-2026-000001.
+-- invoice_sequences holds the last number taken in each series. Taking a number
+-- locks its row until the document's transaction commits, and a rollback hands the
+-- number back, so a series has no gaps.
+
+CREATE TABLE IF NOT EXISTS invoice_sequences (
+    kind text NOT NULL,
+    year bigint NOT NULL,
+    last_number bigint NOT NULL,
+    CONSTRAINT invoice_sequences_pkey PRIMARY KEY (kind, year)
+);
+
+CREATE TABLE IF NOT EXISTS invoices (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    kind text NOT NULL CONSTRAINT chk_invoices_kind CHECK ((kind IN ('invoice', 'credit_note'))),
+    number text NOT NULL,
+    year bigint NOT NULL,
+    sequence bigint NOT NULL,
+    order_id text NOT NULL,
+    user_id text NOT NULL,
+    refund_id text,
+    currency text NOT NULL,
+    total_minor bigint NOT NULL,
+    document jsonb NOT NULL,
+    CONSTRAINT invoices_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_invoices_order FOREIGN KEY (order_id) REFERENCES orders(id),
+    CONSTRAINT fk_invoices_refund FOREIGN KEY (refund_id) REFERENCES refunds(id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_number ON invoices USING btree (number);
+CREATE INDEX IF NOT EXISTS idx_invoices_order_id ON invoices USING btree (order_id);
+-- One invoice per order, one credit note per refund.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_order_invoice ON invoices USING btree (order_id) WHERE kind = 'invoice';
+CREATE UNIQUE INDEX IF NOT EXISTS idx_invoices_refund_id ON invoices USING btree (refund_id);
@@ -0,0 +1,4 @@
+UPDATE provider_events SET status = 'pending' WHERE status = 'processing';
+
+DROP INDEX IF EXISTS idx_provider_events_next_attempt_at;
+CREATE INDEX IF NOT EXISTS idx_provider_events_next_attempt_at ON provider_events USING btree (next_attempt_at) WHERE status = 'pending';
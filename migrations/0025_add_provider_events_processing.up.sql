@@ -0,0 +1,7 @@
+-- Webhook inbox events being applied are marked processing, with next_attempt_at as the end of
+-- the claim's lease, so a replay can't apply an event the worker or a webhook request is
+-- applying. The worker claims processing events again once their lease runs out.
+
+DROP INDEX IF EXISTS idx_provider_events_next_attempt_at;
+CREATE INDEX IF NOT EXISTS idx_provider_events_next_attempt_at ON provider_events USING btree (next_attempt_at)
+    WHERE status IN ('pending', 'processing');
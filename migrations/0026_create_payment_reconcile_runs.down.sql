@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS payment_reconcile_run_entries;
+DROP TABLE IF EXISTS payment_reconcile_runs;
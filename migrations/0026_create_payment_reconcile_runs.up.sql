@@ -0,0 +1,33 @@
+-- Payment reconciliation passes and the payments each one checked, kept so that what a run
+-- found at the provider and changed locally can be reviewed later.
+
+CREATE TABLE IF NOT EXISTS payment_reconcile_runs (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    checked bigint NOT NULL,
+    updated bigint NOT NULL,
+    failed bigint NOT NULL,
+    CONSTRAINT payment_reconcile_runs_pkey PRIMARY KEY (id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_reconcile_runs_created_at ON payment_reconcile_runs USING btree (created_at);
+
+CREATE TABLE IF NOT EXISTS payment_reconcile_run_entries (
+    id text NOT NULL,
+    created_at timestamp with time zone,
+    run_id text NOT NULL,
+    payment_id text NOT NULL,
+    order_id text NOT NULL,
+    provider text NOT NULL,
+    intent_id text,
+    local_status text NOT NULL,
+    provider_status text,
+    action text NOT NULL,
+    detail text,
+    CONSTRAINT payment_reconcile_run_entries_pkey PRIMARY KEY (id),
+    CONSTRAINT fk_payment_reconcile_run_entries_run FOREIGN KEY (run_id) REFERENCES payment_reconcile_runs(id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_reconcile_run_entries_run_id ON payment_reconcile_run_entries USING btree (run_id);
+
+CREATE INDEX IF NOT EXISTS idx_payment_reconcile_run_entries_payment_id ON payment_reconcile_run_entries USING btree (payment_id);
@@ -145,4 +145,11 @@ var (
 		http.StatusBadRequest,
 		codes.FailedPrecondition,
 	)
+
+	ErrCartEmpty = New(
+		"CART_EMPTY",
+		"Cart is empty",
+		http.StatusBadRequest,
+		codes.FailedPrecondition,
+	)
 )
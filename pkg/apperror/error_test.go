@@ -145,6 +145,7 @@ func TestPredefinedErrors(t *testing.T) {
 		{ErrCouponExpired, "COUPON_EXPIRED", 400, codes.FailedPrecondition},
 		{ErrCouponMaxUsage, "COUPON_MAX_USAGE", 400, codes.FailedPrecondition},
 		{ErrCouponMinOrder, "COUPON_MIN_ORDER", 400, codes.FailedPrecondition},
+		{ErrCartEmpty, "CART_EMPTY", 400, codes.FailedPrecondition},
 	}
 
 	for _, tt := range tests {
@@ -40,11 +40,71 @@ type Schema struct {
 	StripePublishableKey string `env:"stripe_publishable_key"`
 	StripeAPIBase        string `env:"stripe_api_base"` // override for stripe-mock in tests
 
+	// BaseCurrency is the ISO 4217 currency product prices are kept in. ExchangeRates lists
+	// the other currencies customers may order in, as units per unit of base currency, e.g.
+	// "EUR:0.92,JPY:151.3".
+	BaseCurrency  string `env:"base_currency" envDefault:"USD"`
+	ExchangeRates string `env:"exchange_rates"`
+	// ShippingMethods lists the flat-rate methods buyers pick from at checkout while admins
+	// haven't configured shipping rates, rate in base currency, default first, e.g.
+	// "standard:4.99,express:14.99". Empty offers free standard shipping.
+	ShippingMethods string `env:"shipping_methods"`
+	// TaxMode is exclusive (tax is added to prices at checkout) or inclusive (prices already
+	// include it). Rates are configured through the API.
+	TaxMode string `env:"tax_mode" envDefault:"exclusive"`
+	// InvoiceSeller* name the shop on invoices and credit notes. The address takes one line of
+	// text per address line.
+	InvoiceSellerName    string `env:"invoice_seller_name" envDefault:"GoShop"`
+	InvoiceSellerAddress string `env:"invoice_seller_address"`
+	InvoiceSellerTaxID   string `env:"invoice_seller_tax_id"`
+
+	// DefaultPaymentProvider is used when the customer doesn't pick one: stripe, paypal or
+	// bank_transfer. It must be one of the configured providers.
+	DefaultPaymentProvider string `env:"default_payment_provider" envDefault:"stripe"`
+	// PaymentCapture is automatic (charge at checkout) or manual (authorize at checkout and
+	// capture when the order moves to in-progress). Only Stripe holds funds; other providers
+	// charge at checkout either way.
+	PaymentCapture string `env:"payment_capture" envDefault:"automatic"`
+
+	// PayPal is enabled when PayPalClientID is set. PayPalWebhookID identifies the webhook
+	// whose deliveries are verified; the return/cancel URLs are where PayPal sends the customer.
+	PayPalClientID     string `env:"paypal_client_id"`
+	PayPalClientSecret string `env:"paypal_client_secret"`
+	PayPalWebhookID    string `env:"paypal_webhook_id"`
+	PayPalAPIBase      string `env:"paypal_api_base"` // sandbox or a fake in tests
+	PayPalReturnURL    string `env:"paypal_return_url"`
+	PayPalCancelURL    string `env:"paypal_cancel_url"`
+
+	// BankTransferInstructions enables the bank_transfer provider; {reference} is replaced by
+	// the order ID. Stock stays reserved for ManualPaymentHoldHours while the transfer arrives.
+	BankTransferInstructions string `env:"bank_transfer_instructions"`
+	ManualPaymentHoldHours   int    `env:"manual_payment_hold_hours" envDefault:"72"`
+
 	SMTPHost     string `env:"smtp_host"`
 	SMTPPort     int    `env:"smtp_port" envDefault:"25"`
 	SMTPUser     string `env:"smtp_user"`
 	SMTPPassword string `env:"smtp_password"`
 	EmailFrom    string `env:"email_from"`
+
+	// AbandonedCartAfterMinutes is how long a logged-in user's cart must sit untouched before
+	// the abandoned-cart reminder goes out.
+	AbandonedCartAfterMinutes int `env:"abandoned_cart_after_minutes" envDefault:"1440"`
+	// PaymentReconcileAfterMinutes is how long a payment may wait on its provider's webhook
+	// before the reconciler asks the provider for the intent's state instead.
+	PaymentReconcileAfterMinutes int `env:"payment_reconcile_after_minutes" envDefault:"30"`
+	// LowStockAlertCooldownMinutes is the minimum gap between two admin low-stock emails for
+	// the same product.
+	LowStockAlertCooldownMinutes int `env:"low_stock_alert_cooldown_minutes" envDefault:"1440"`
+
+	// EventBusBackend selects how events reach subscribers: inproc (in memory, lost on
+	// restart), postgres or redis. The durable backends let replicas share events.
+	EventBusBackend string `env:"eventbus_backend" envDefault:"inproc"`
+	// EventBusGroup is the consumer group every replica of this service joins.
+	EventBusGroup string `env:"eventbus_group" envDefault:"goshop"`
+
+	// WarehouseAllocation picks which warehouses fill an order line: nearest (to the buyer's
+	// address, then by warehouse priority) or priority (warehouse priority alone).
+	WarehouseAllocation string `env:"warehouse_allocation" envDefault:"nearest"`
 }
 
 var (
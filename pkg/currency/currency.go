@@ -0,0 +1,126 @@
+// Package currency knows the minor units of ISO 4217 currencies and converts amounts between
+// major units, minor units and other currencies. Conversions go through exact decimals, so a
+// price like 0.29 becomes 29 cents rather than 28.
+package currency
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Default is the currency of orders placed before currencies were recorded.
+const Default = "USD"
+
+// exponents lists the ISO 4217 currencies whose minor unit isn't a hundredth.
+var exponents = map[string]int{
+	"BHD": 3, "BIF": 0, "CLF": 4, "CLP": 0, "DJF": 0, "GNF": 0, "IQD": 3, "ISK": 0,
+	"JOD": 3, "JPY": 0, "KMF": 0, "KRW": 0, "KWD": 3, "LYD": 3, "OMR": 3, "PYG": 0,
+	"RWF": 0, "TND": 3, "UGX": 0, "UYI": 0, "UYW": 4, "VND": 0, "VUV": 0, "XAF": 0,
+	"XOF": 0, "XPF": 0,
+}
+
+// Normalize upper-cases a currency code.
+func Normalize(code string) string {
+	return strings.ToUpper(strings.TrimSpace(code))
+}
+
+// Valid reports whether code looks like an ISO 4217 code: three letters.
+func Valid(code string) bool {
+	code = Normalize(code)
+	if len(code) != 3 {
+		return false
+	}
+	for _, c := range code {
+		if c < 'A' || c > 'Z' {
+			return false
+		}
+	}
+	return true
+}
+
+// Exponent is the number of decimal places of code's minor unit: 2 for USD, 0 for JPY, 3 for
+// KWD.
+func Exponent(code string) int {
+	if e, ok := exponents[Normalize(code)]; ok {
+		return e
+	}
+	return 2
+}
+
+// ToMinor converts an amount in major units to minor units, rounding half away from zero. The
+// float is read as the shortest decimal that represents it, so 0.29 is 29 cents.
+func ToMinor(amount float64, code string) int64 {
+	r, _ := new(big.Rat).SetString(strconv.FormatFloat(amount, 'f', -1, 64))
+	return round(r.Mul(r, scale(code)))
+}
+
+// FromMinor converts an amount in minor units to major units.
+func FromMinor(minor int64, code string) float64 {
+	f, _ := strconv.ParseFloat(Format(minor, code), 64)
+	return f
+}
+
+// Format renders minor units as a decimal string in major units: "12.34", "1500", "1.250".
+func Format(minor int64, code string) string {
+	exp := Exponent(code)
+	if exp == 0 {
+		return strconv.FormatInt(minor, 10)
+	}
+	sign := ""
+	if minor < 0 {
+		sign, minor = "-", -minor
+	}
+	pow := pow10(exp)
+	return fmt.Sprintf("%s%d.%0*d", sign, minor/pow, exp, minor%pow)
+}
+
+// Parse reads a decimal string in major units as minor units, rounding half away from zero
+// when it has more decimals than the currency.
+func Parse(value, code string) (int64, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return 0, fmt.Errorf("currency: invalid amount %q", value)
+	}
+	return round(r.Mul(r, scale(code))), nil
+}
+
+// Percent is percent of minor, rounded half away from zero.
+func Percent(minor int64, percent float64) int64 {
+	p, _ := new(big.Rat).SetString(strconv.FormatFloat(percent, 'f', -1, 64))
+	r := new(big.Rat).SetInt64(minor)
+	r.Mul(r, p)
+	return round(r.Quo(r, big.NewRat(100, 1)))
+}
+
+// Prorate is amount × num / den, rounded half away from zero. den must not be zero.
+func Prorate(amount, num, den int64) int64 {
+	r := new(big.Rat).SetInt64(amount)
+	return round(r.Mul(r, big.NewRat(num, den)))
+}
+
+func scale(code string) *big.Rat {
+	return new(big.Rat).SetInt64(pow10(Exponent(code)))
+}
+
+func pow10(exp int) int64 {
+	p := int64(1)
+	for range exp {
+		p *= 10
+	}
+	return p
+}
+
+// round rounds r to the nearest integer, halves away from zero.
+func round(r *big.Rat) int64 {
+	num := new(big.Int).Abs(r.Num())
+	q, m := new(big.Int).QuoRem(num, r.Denom(), new(big.Int))
+	if m.Lsh(m, 1).Cmp(r.Denom()) >= 0 {
+		q.Add(q, big.NewInt(1))
+	}
+	if r.Sign() < 0 {
+		q.Neg(q)
+	}
+	return q.Int64()
+}
@@ -0,0 +1,100 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExponent(t *testing.T) {
+	require.Equal(t, 2, Exponent("USD"))
+	require.Equal(t, 2, Exponent("eur"))
+	require.Equal(t, 0, Exponent("JPY"))
+	require.Equal(t, 3, Exponent("KWD"))
+	require.Equal(t, 2, Exponent("XYZ"), "unknown currencies use hundredths")
+}
+
+func TestValid(t *testing.T) {
+	require.True(t, Valid("USD"))
+	require.True(t, Valid(" jpy "))
+	require.False(t, Valid("US"))
+	require.False(t, Valid("US1"))
+	require.False(t, Valid(""))
+}
+
+func TestToMinor(t *testing.T) {
+	tests := []struct {
+		amount float64
+		code   string
+		want   int64
+	}{
+		{0.29, "USD", 29}, // int64(0.29*100) is 28
+		{19.99, "USD", 1999},
+		{1.005, "USD", 101}, // rounds the decimal, not the float's binary approximation
+		{-2.5, "USD", -250},
+		{1500, "JPY", 1500},
+		{1499.5, "JPY", 1500},
+		{1.2345, "KWD", 1235},
+		{0, "EUR", 0},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.want, ToMinor(tt.amount, tt.code), "%v %s", tt.amount, tt.code)
+	}
+}
+
+func TestFormatParse(t *testing.T) {
+	tests := []struct {
+		minor int64
+		code  string
+		value string
+	}{
+		{1234, "USD", "12.34"},
+		{5, "usd", "0.05"},
+		{-250, "EUR", "-2.50"},
+		{1500, "JPY", "1500"},
+		{1250, "KWD", "1.250"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.value, Format(tt.minor, tt.code))
+		got, err := Parse(tt.value, tt.code)
+		require.NoError(t, err)
+		require.Equal(t, tt.minor, got)
+		require.Equal(t, tt.minor, ToMinor(FromMinor(tt.minor, tt.code), tt.code))
+	}
+
+	got, err := Parse("12.345", "USD")
+	require.NoError(t, err)
+	require.Equal(t, int64(1235), got)
+	_, err = Parse("abc", "USD")
+	require.Error(t, err)
+}
+
+func TestPercent(t *testing.T) {
+	require.Equal(t, int64(150), Percent(1000, 15))
+	require.Equal(t, int64(333), Percent(999, 33.33)) // 332.967
+	require.Equal(t, int64(1), Percent(5, 10))        // 0.5 rounds up
+	require.Equal(t, int64(0), Percent(0, 50))
+}
+
+func TestProrate(t *testing.T) {
+	require.Equal(t, int64(667), Prorate(1000, 2, 3))
+	require.Equal(t, int64(333), Prorate(1000, 1, 3))
+	require.Equal(t, int64(-667), Prorate(-1000, 2, 3))
+	require.Equal(t, int64(900), Prorate(1000, 9000, 10000))
+}
+
+// TestPropertySumsDontDrift sums many prices in minor units and checks the total survives the
+// round trip through major units, which adding float64 prices doesn't guarantee.
+func TestPropertySumsDontDrift(t *testing.T) {
+	for _, code := range []string{"USD", "JPY", "KWD"} {
+		var total int64
+		var floatTotal float64
+		for i := int64(1); i <= 1000; i++ {
+			minor := i*7 + 3
+			total += minor
+			floatTotal += FromMinor(minor, code)
+		}
+		require.Equal(t, total, ToMinor(FromMinor(total, code), code), code)
+		require.InDelta(t, float64(total), float64(ToMinor(floatTotal, code)), 1, code)
+	}
+}
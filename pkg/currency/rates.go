@@ -0,0 +1,113 @@
+package currency
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+)
+
+// ErrUnsupported is returned for a currency the shop has no exchange rate for.
+var ErrUnsupported = errors.New("currency: unsupported currency")
+
+// Rates is the shop's exchange-rate table: prices are kept in the base currency and converted
+// into the others at a fixed rate.
+type Rates struct {
+	base  string
+	rates map[string]*big.Rat // units of the currency per unit of base
+}
+
+// NewRates builds a table from rates quoted as units of each currency per unit of base. The
+// base currency itself needs no entry.
+func NewRates(base string, rates map[string]*big.Rat) *Rates {
+	r := &Rates{base: Normalize(base), rates: make(map[string]*big.Rat, len(rates)+1)}
+	for code, rate := range rates {
+		r.rates[Normalize(code)] = new(big.Rat).Set(rate)
+	}
+	r.rates[r.base] = big.NewRat(1, 1)
+	return r
+}
+
+// ParseRates reads a table written as "EUR:0.92,JPY:151.3", each entry being units of that
+// currency per unit of base. An empty spec supports the base currency alone; an empty base is
+// Default.
+func ParseRates(base, spec string) (*Rates, error) {
+	if base == "" {
+		base = Default
+	}
+	if !Valid(base) {
+		return nil, fmt.Errorf("currency: invalid base currency %q", base)
+	}
+	rates := make(map[string]*big.Rat)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		code, value, ok := strings.Cut(entry, ":")
+		if !ok || !Valid(code) {
+			return nil, fmt.Errorf("currency: invalid exchange rate %q", entry)
+		}
+		rate, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+		if !ok || rate.Sign() <= 0 {
+			return nil, fmt.Errorf("currency: invalid exchange rate %q", entry)
+		}
+		rates[code] = rate
+	}
+	return NewRates(base, rates), nil
+}
+
+// MustParseRates is ParseRates for configuration validated at startup; it panics on error.
+func MustParseRates(base, spec string) *Rates {
+	r, err := ParseRates(base, spec)
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+// Base is the currency prices are kept in.
+func (r *Rates) Base() string {
+	return r.base
+}
+
+// Supported reports whether amounts can be converted into code.
+func (r *Rates) Supported(code string) bool {
+	_, ok := r.rates[Normalize(code)]
+	return ok
+}
+
+// Currencies lists the supported currencies, base first.
+func (r *Rates) Currencies() []string {
+	codes := make([]string, 0, len(r.rates))
+	for code := range r.rates {
+		if code != r.base {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return append([]string{r.base}, codes...)
+}
+
+// Convert converts minor units of from into minor units of to, rounding half away from zero
+// once, at the end.
+func (r *Rates) Convert(minor int64, from, to string) (int64, error) {
+	from, to = Normalize(from), Normalize(to)
+	if from == to {
+		return minor, nil
+	}
+	rateFrom, ok := r.rates[from]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupported, from)
+	}
+	rateTo, ok := r.rates[to]
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", ErrUnsupported, to)
+	}
+	amount := new(big.Rat).SetInt64(minor)
+	amount.Quo(amount, scale(from))
+	amount.Quo(amount, rateFrom)
+	amount.Mul(amount, rateTo)
+	return round(amount.Mul(amount, scale(to))), nil
+}
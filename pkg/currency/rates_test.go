@@ -0,0 +1,62 @@
+package currency
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRates(t *testing.T) {
+	r, err := ParseRates("usd", " EUR:0.92, jpy:151.5 ,")
+	require.NoError(t, err)
+	require.Equal(t, "USD", r.Base())
+	require.Equal(t, []string{"USD", "EUR", "JPY"}, r.Currencies())
+	require.True(t, r.Supported("eur"))
+	require.False(t, r.Supported("GBP"))
+
+	for _, spec := range []string{"EUR", "EUR:", "EUR:abc", "EUR:0", "EUR:-1", "EURO:1"} {
+		_, err := ParseRates("USD", spec)
+		require.Error(t, err, spec)
+	}
+	_, err = ParseRates("dollars", "")
+	require.Error(t, err)
+
+	r, err = ParseRates("EUR", "")
+	require.NoError(t, err)
+	require.Equal(t, []string{"EUR"}, r.Currencies())
+
+	r, err = ParseRates("", "")
+	require.NoError(t, err)
+	require.Equal(t, Default, r.Base())
+}
+
+func TestConvert(t *testing.T) {
+	r := MustParseRates("USD", "EUR:0.92,JPY:151.5,KWD:0.3075")
+	tests := []struct {
+		minor    int64
+		from, to string
+		want     int64
+	}{
+		{1999, "USD", "USD", 1999},
+		{1999, "USD", "EUR", 1839}, // 18.3908
+		{1999, "USD", "JPY", 3028}, // 3028.485
+		{1999, "USD", "KWD", 6147}, // 6.146925
+		{1839, "EUR", "USD", 1999}, // 19.98913
+		{3028, "JPY", "EUR", 1839}, // 18.38891
+		{-1000, "USD", "EUR", -920},
+	}
+	for _, tt := range tests {
+		got, err := r.Convert(tt.minor, tt.from, tt.to)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, got, "%d %s->%s", tt.minor, tt.from, tt.to)
+	}
+
+	_, err := r.Convert(100, "USD", "GBP")
+	require.ErrorIs(t, err, ErrUnsupported)
+	_, err = r.Convert(100, "GBP", "USD")
+	require.ErrorIs(t, err, ErrUnsupported)
+}
+
+func TestMustParseRates_Panics(t *testing.T) {
+	require.Panics(t, func() { MustParseRates("USD", "EUR:x") })
+}
@@ -0,0 +1,60 @@
+package eventbus
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrUnknownTopic is returned by Unmarshal for a topic with no registered event type.
+var ErrUnknownTopic = errors.New("eventbus: unknown topic")
+
+// decoders maps each topic to a function rebuilding its concrete event from JSON, so events
+// that leave the process (outbox rows, durable backends) come back as the same value types
+// subscribers type-assert on.
+var decoders = map[string]func(data []byte) (Event, error){}
+
+func init() {
+	register[OrderCreated]()
+	register[OrderPaid]()
+	register[OrderInvoiced]()
+	register[OrderPaymentFailed]()
+	register[OrderInProgress]()
+	register[OrderDone]()
+	register[OrderCancelled]()
+	register[OrderReservationExpired]()
+	register[OrderStatusChanged]()
+	register[ShipmentPacked]()
+	register[ShipmentShipped]()
+	register[ShipmentDelivered]()
+	register[LowStock]()
+}
+
+func register[T Event]() {
+	var zero T
+	decoders[zero.Topic()] = func(data []byte) (Event, error) {
+		var ev T
+		if err := json.Unmarshal(data, &ev); err != nil {
+			return nil, err
+		}
+		return ev, nil
+	}
+}
+
+// Marshal encodes an event's payload. The topic travels separately.
+func Marshal(ev Event) ([]byte, error) {
+	return json.Marshal(ev)
+}
+
+// Unmarshal decodes a payload produced by Marshal back into the event registered for topic.
+func Unmarshal(topic string, data []byte) (Event, error) {
+	decode, ok := decoders[topic]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTopic, topic)
+	}
+	ev, err := decode(data)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s: %w", topic, err)
+	}
+	return ev, nil
+}
@@ -0,0 +1,96 @@
+package eventbus
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"goshop/pkg/money"
+)
+
+func TestCodec_RoundTrip(t *testing.T) {
+	order := OrderPayload{
+		OrderID:        "o1",
+		Code:           "C-1",
+		UserID:         "u1",
+		UserEmail:      "u@x.com",
+		Status:         "paid",
+		TotalPrice:     money.New(3000, "USD"),
+		DiscountAmount: money.New(500, "USD"),
+		ShippingFee:    money.New(499, "USD"),
+		TaxAmount:      money.New(200, "USD"),
+		FinalPrice:     money.New(3199, "USD"),
+		Currency:       "USD",
+		CouponCode:     "SAVE5",
+		Lines:          []OrderLine{{ProductID: "p1", Quantity: 3, Price: money.New(3000, "USD")}},
+	}
+	shipment := ShipmentPayload{
+		ShipmentID: "s1", OrderID: "o1", OrderCode: "C-1", UserID: "u1", UserEmail: "u@x.com",
+		Status: "shipped", Carrier: "DHL", TrackingNumber: "JD0001",
+		Lines: []ShipmentLine{{OrderLineID: "l1", ProductID: "p1", Quantity: 2}},
+	}
+	cases := []Event{
+		OrderCreated{OrderPayload: order},
+		OrderPaid{OrderPayload: order},
+		OrderInvoiced{OrderPayload: order},
+		OrderPaymentFailed{OrderPayload: order},
+		OrderInProgress{OrderPayload: order},
+		OrderDone{OrderPayload: order},
+		OrderCancelled{OrderPayload: order, Reason: "reservation_expired"},
+		OrderReservationExpired{OrderPayload: order},
+		OrderStatusChanged{OrderPayload: order},
+		ShipmentPacked{ShipmentPayload: shipment},
+		ShipmentShipped{ShipmentPayload: shipment},
+		ShipmentDelivered{ShipmentPayload: shipment},
+		LowStock{ProductID: "p1", Available: 2, Threshold: 5},
+	}
+	for _, ev := range cases {
+		data, err := Marshal(ev)
+		if err != nil {
+			t.Fatalf("Marshal(%T): %v", ev, err)
+		}
+		got, err := Unmarshal(ev.Topic(), data)
+		if err != nil {
+			t.Fatalf("Unmarshal(%T): %v", ev, err)
+		}
+		if !reflect.DeepEqual(got, ev) {
+			t.Errorf("round trip %T: got %#v, want %#v", ev, got, ev)
+		}
+	}
+}
+
+func TestCodec_FlatOrderPayload(t *testing.T) {
+	data, err := Marshal(OrderCancelled{OrderPayload: OrderPayload{OrderID: "o1"}, Reason: "customer_request"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"order_id":"o1","user_id":"","user_email":"","total_price":{"amount":"0.00","currency":""},"discount_amount":{"amount":"0.00","currency":""},"shipping_fee":{"amount":"0.00","currency":""},"tax_amount":{"amount":"0.00","currency":""},"final_price":{"amount":"0.00","currency":""},"reason":"customer_request"}`
+	if string(data) != want {
+		t.Fatalf("got %s, want %s", data, want)
+	}
+}
+
+func TestCodec_LegacyFloatAmounts(t *testing.T) {
+	// Events recorded before amounts carried a currency still decode.
+	got, err := Unmarshal(TopicOrderPaid, []byte(`{"order_id":"o1","total_price":30.5,"discount_amount":0,"final_price":30.5,"lines":[{"product_id":"p1","quantity":1,"price":30.5}]}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := got.(OrderPaid).Payload()
+	if payload.FinalPrice.Amount() != 3050 || payload.Lines[0].Price.Amount() != 3050 {
+		t.Fatalf("got %v and %v, want 30.50", payload.FinalPrice, payload.Lines[0].Price)
+	}
+}
+
+func TestCodec_UnknownTopic(t *testing.T) {
+	_, err := Unmarshal("nope", []byte(`{}`))
+	if !errors.Is(err, ErrUnknownTopic) {
+		t.Fatalf("want ErrUnknownTopic, got %v", err)
+	}
+}
+
+func TestCodec_BadPayload(t *testing.T) {
+	if _, err := Unmarshal(TopicLowStock, []byte(`{`)); err == nil {
+		t.Fatal("expected decode error")
+	}
+}
@@ -0,0 +1,126 @@
+package eventbus
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+)
+
+const (
+	// BackendInproc keeps events in memory; they are lost on restart and never leave the process.
+	BackendInproc = "inproc"
+	// BackendPostgres stores events in the eventbus_events table and polls it.
+	BackendPostgres = "postgres"
+	// BackendRedis stores events in one Redis stream per topic.
+	BackendRedis = "redis"
+)
+
+// Runner is implemented by buses that consume from an external store. Run blocks, feeding
+// subscribers until ctx is cancelled; main.go starts it once every Subscribe call is done.
+type Runner interface {
+	Run(ctx context.Context)
+}
+
+// DurableOptions tunes the out-of-process backends. Zero values fall back to defaults.
+type DurableOptions struct {
+	// Group is shared by every replica of a service. Each subscriber consumes as its own
+	// consumer group under it, so all replicas together handle an event once per subscriber.
+	Group string
+	// Consumer identifies this replica within the group. Defaults to hostname-pid.
+	Consumer string
+	// BatchSize is how many events a subscriber reads per round trip.
+	BatchSize int
+	// PollInterval is how long a subscriber waits for new events before checking again.
+	PollInterval time.Duration
+	// ClaimAfter is how long an unacknowledged event (or a Postgres subscription lease) is left
+	// with its consumer before another replica takes it over.
+	ClaimAfter time.Duration
+	// MaxDeliveries caps how often an event whose handler panics is redelivered; after that it's
+	// logged and acknowledged so it can't wedge the subscriber.
+	MaxDeliveries int
+	// Retention is how long published events are kept for redelivery and late subscribers.
+	Retention time.Duration
+}
+
+func (o DurableOptions) withDefaults() DurableOptions {
+	if o.Group == "" {
+		o.Group = "goshop"
+	}
+	if o.Consumer == "" {
+		host, _ := os.Hostname()
+		o.Consumer = fmt.Sprintf("%s-%d", host, os.Getpid())
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = 50
+	}
+	if o.PollInterval <= 0 {
+		o.PollInterval = time.Second
+	}
+	if o.ClaimAfter <= 0 {
+		o.ClaimAfter = time.Minute
+	}
+	if o.MaxDeliveries <= 0 {
+		o.MaxDeliveries = 5
+	}
+	if o.Retention <= 0 {
+		o.Retention = 7 * 24 * time.Hour
+	}
+	return o
+}
+
+// subscription is one Subscribe call on a durable bus. Its name is the key its offset is
+// stored under, so it must be stable across restarts and identical on every replica: it's
+// derived from the topic and the call's position among that topic's subscriptions, which
+// holds as long as replicas run the same binary.
+type subscription struct {
+	name    string
+	topic   string
+	handler Handler
+}
+
+type subscriptions struct {
+	list    []*subscription
+	byTopic map[string]int
+}
+
+func (s *subscriptions) add(group, topic string, h Handler) *subscription {
+	if s.byTopic == nil {
+		s.byTopic = make(map[string]int)
+	}
+	sub := &subscription{
+		name:    fmt.Sprintf("%s:%s#%d", group, topic, s.byTopic[topic]),
+		topic:   topic,
+		handler: h,
+	}
+	s.byTopic[topic]++
+	s.list = append(s.list, sub)
+	return sub
+}
+
+// dispatch runs the handler and reports whether the event may be acknowledged. Handlers log
+// their own errors, so only a panic counts as a failed delivery.
+func dispatch(ctx context.Context, sub *subscription, ev Event) (ok bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			logger.Errorf("eventbus: subscriber %s panicked: %v", sub.name, r)
+			ok = false
+		}
+	}()
+	sub.handler(ctx, ev)
+	return true
+}
+
+// sleep waits for d or until ctx is done, reporting whether the caller should keep going.
+func sleep(ctx context.Context, d time.Duration) bool {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-t.C:
+		return true
+	}
+}
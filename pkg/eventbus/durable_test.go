@@ -0,0 +1,45 @@
+package eventbus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/require"
+
+	"goshop/pkg/config"
+)
+
+func TestDurableOptions_Defaults(t *testing.T) {
+	o := DurableOptions{}.withDefaults()
+	require.Equal(t, "goshop", o.Group)
+	require.NotEmpty(t, o.Consumer)
+	require.Equal(t, 50, o.BatchSize)
+	require.Equal(t, time.Second, o.PollInterval)
+	require.Equal(t, time.Minute, o.ClaimAfter)
+	require.Equal(t, 5, o.MaxDeliveries)
+	require.Equal(t, 7*24*time.Hour, o.Retention)
+
+	o = DurableOptions{Group: "g", Consumer: "c", BatchSize: 3}.withDefaults()
+	require.Equal(t, "g", o.Group)
+	require.Equal(t, "c", o.Consumer)
+	require.Equal(t, 3, o.BatchSize)
+}
+
+func TestSubscriptions_StableNamesPerTopic(t *testing.T) {
+	var s subscriptions
+	noop := func(context.Context, Event) {}
+	require.Equal(t, "g:order.created#0", s.add("g", TopicOrderCreated, noop).name)
+	require.Equal(t, "g:inventory.low_stock#0", s.add("g", TopicLowStock, noop).name)
+	require.Equal(t, "g:order.created#1", s.add("g", TopicOrderCreated, noop).name)
+	require.Len(t, s.list, 3)
+}
+
+func TestDispatch_RecoversPanic(t *testing.T) {
+	logger.Initialize(config.ProductionEnv)
+	ok := dispatch(context.Background(), &subscription{name: "s", handler: func(context.Context, Event) {}}, OrderPaid{})
+	require.True(t, ok)
+	ok = dispatch(context.Background(), &subscription{name: "s", handler: func(context.Context, Event) { panic("boom") }}, OrderPaid{})
+	require.False(t, ok)
+}
@@ -1,7 +1,8 @@
-// Package eventbus is a lightweight in-process pub/sub for decoupling domains
-// from notification transports. Domains publish typed events; subscribers (e.g. the
-// notification service) react asynchronously. The interface is intentionally
-// minimal so we can swap in NATS/Kafka later without touching publishers.
+// Package eventbus is a lightweight pub/sub for decoupling domains from notification
+// transports. Domains publish typed events; subscribers (e.g. the notification service)
+// react asynchronously. New returns the in-process bus; NewPostgres and NewRedis are
+// durable backends behind the same interface that survive restarts and share events
+// between replicas (see durable.go).
 package eventbus
 
 import (
@@ -16,12 +17,15 @@ type Event interface {
 
 // Handler reacts to a single event. It runs on a background goroutine; errors
 // should be logged by the handler — the bus will not surface them to the publisher.
+// Durable backends acknowledge an event once its handler returns and redeliver it if
+// the handler panics or the process dies first, so handlers must tolerate duplicates.
 type Handler func(ctx context.Context, ev Event)
 
-// Bus is the public contract. Publish never blocks the caller waiting on subscribers.
+// Bus is the public contract. Publish never blocks the caller waiting on subscribers; an
+// error means the bus did not accept the event and the caller should retry.
 type Bus interface {
 	Subscribe(topic string, h Handler)
-	Publish(ctx context.Context, ev Event)
+	Publish(ctx context.Context, ev Event) error
 }
 
 type inproc struct {
@@ -65,7 +69,7 @@ var (
 	defaultBus Bus
 )
 
-func (b *inproc) Publish(ctx context.Context, ev Event) {
+func (b *inproc) Publish(ctx context.Context, ev Event) error {
 	b.mu.RLock()
 	handlers := append([]Handler(nil), b.subs[ev.Topic()]...)
 	b.mu.RUnlock()
@@ -74,4 +78,5 @@ func (b *inproc) Publish(ctx context.Context, ev Event) {
 		h := h
 		go h(ctx, ev)
 	}
+	return nil
 }
@@ -22,7 +22,9 @@ func TestPublishFansOutToAllSubscribers(t *testing.T) {
 		got2 = ev.(OrderCreated).OrderID
 	})
 
-	bus.Publish(context.Background(), OrderCreated{OrderID: "abc"})
+	if err := bus.Publish(context.Background(), OrderCreated{OrderPayload: OrderPayload{OrderID: "abc"}}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
 
 	done := make(chan struct{})
 	go func() { wg.Wait(); close(done) }()
@@ -38,7 +40,9 @@ func TestPublishFansOutToAllSubscribers(t *testing.T) {
 
 func TestPublishWithNoSubscribersIsNoop(t *testing.T) {
 	bus := New()
-	bus.Publish(context.Background(), LowStock{ProductID: "x"})
+	if err := bus.Publish(context.Background(), LowStock{ProductID: "x"}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
 }
 
 func TestDefault_LazilyInitializesSingleton(t *testing.T) {
@@ -73,7 +77,16 @@ func TestEventTopics(t *testing.T) {
 	}{
 		{OrderCreated{}, TopicOrderCreated},
 		{OrderPaid{}, TopicOrderPaid},
+		{OrderInvoiced{}, TopicOrderInvoiced},
+		{OrderPaymentFailed{}, TopicOrderPaymentFailed},
+		{OrderInProgress{}, TopicOrderInProgress},
+		{OrderDone{}, TopicOrderDone},
 		{OrderCancelled{}, TopicOrderCancelled},
+		{OrderReservationExpired{}, TopicOrderReservationExpired},
+		{OrderStatusChanged{}, TopicOrderStatusChanged},
+		{ShipmentPacked{}, TopicShipmentPacked},
+		{ShipmentShipped{}, TopicShipmentShipped},
+		{ShipmentDelivered{}, TopicShipmentDelivered},
 		{LowStock{}, TopicLowStock},
 	}
 	for _, c := range cases {
@@ -90,7 +103,7 @@ func TestSlowSubscriberDoesNotBlockPublisher(t *testing.T) {
 	})
 
 	start := time.Now()
-	bus.Publish(context.Background(), OrderPaid{OrderID: "1"})
+	_ = bus.Publish(context.Background(), OrderPaid{OrderPayload: OrderPayload{OrderID: "1"}})
 	if time.Since(start) > 50*time.Millisecond {
 		t.Fatalf("Publish blocked on slow subscriber: %s", time.Since(start))
 	}
@@ -1,43 +1,179 @@
 package eventbus
 
+import "goshop/pkg/money"
+
 const (
-	TopicOrderCreated   = "order.created"
-	TopicOrderPaid      = "order.paid"
-	TopicOrderCancelled = "order.cancelled"
-	TopicLowStock       = "inventory.low_stock"
+	TopicOrderCreated            = "order.created"
+	TopicOrderPaid               = "order.paid"
+	TopicOrderInvoiced           = "order.invoiced"
+	TopicOrderPaymentFailed      = "order.payment_failed"
+	TopicOrderInProgress         = "order.in_progress"
+	TopicOrderDone               = "order.done"
+	TopicOrderCancelled          = "order.cancelled"
+	TopicOrderReservationExpired = "order.reservation_expired"
+	TopicOrderStatusChanged      = "order.status_changed"
+	TopicShipmentPacked          = "shipment.packed"
+	TopicShipmentShipped         = "shipment.shipped"
+	TopicShipmentDelivered       = "shipment.delivered"
+	TopicLowStock                = "inventory.low_stock"
 )
 
+// OrderLine is a line item as carried on order events.
+type OrderLine struct {
+	ProductID string      `json:"product_id"`
+	Quantity  uint        `json:"quantity"`
+	Price     money.Money `json:"price"` // line total: unit price × quantity
+}
+
+// OrderPayload is the common body of every order lifecycle event: the order as of the
+// transition, including its status, totals and line items, and where to reach the buyer.
+// UserEmail is empty when the lookup failed; email subscribers skip such events.
+type OrderPayload struct {
+	OrderID        string      `json:"order_id"`
+	Code           string      `json:"code,omitempty"`
+	UserID         string      `json:"user_id"`
+	UserEmail      string      `json:"user_email"`
+	Status         string      `json:"status,omitempty"`
+	TotalPrice     money.Money `json:"total_price"`
+	DiscountAmount money.Money `json:"discount_amount"`
+	ShippingFee    money.Money `json:"shipping_fee"`
+	TaxAmount      money.Money `json:"tax_amount"`
+	FinalPrice     money.Money `json:"final_price"`
+	Currency       string      `json:"currency,omitempty"`
+	CouponCode     string      `json:"coupon_code,omitempty"`
+	Lines          []OrderLine `json:"lines,omitempty"`
+}
+
+// Payload lets subscribers handle any order event without switching on its type.
+func (p OrderPayload) Payload() OrderPayload { return p }
+
+// OrderEvent is implemented by every order lifecycle event.
+type OrderEvent interface {
+	Event
+	Payload() OrderPayload
+}
+
 type OrderCreated struct {
-	OrderID   string
-	UserID    string
-	UserEmail string
+	OrderPayload
 }
 
 func (OrderCreated) Topic() string { return TopicOrderCreated }
 
 type OrderPaid struct {
-	OrderID   string
-	UserID    string
-	UserEmail string
+	OrderPayload
 }
 
 func (OrderPaid) Topic() string { return TopicOrderPaid }
 
+// OrderInvoiced is recorded when an order is invoiced after its OrderPaid event: once an
+// authorized payment is captured, or cash on delivery is collected.
+type OrderInvoiced struct {
+	OrderPayload
+}
+
+func (OrderInvoiced) Topic() string { return TopicOrderInvoiced }
+
+type OrderPaymentFailed struct {
+	OrderPayload
+}
+
+func (OrderPaymentFailed) Topic() string { return TopicOrderPaymentFailed }
+
+type OrderInProgress struct {
+	OrderPayload
+}
+
+func (OrderInProgress) Topic() string { return TopicOrderInProgress }
+
+type OrderDone struct {
+	OrderPayload
+}
+
+func (OrderDone) Topic() string { return TopicOrderDone }
+
+// OrderCancelled carries why the order was cancelled; see the CancelReason constants in the
+// order service.
 type OrderCancelled struct {
-	OrderID   string
-	UserID    string
-	UserEmail string
-	Reason    string
+	OrderPayload
+	Reason string `json:"reason"`
 }
 
 func (OrderCancelled) Topic() string { return TopicOrderCancelled }
 
+// OrderReservationExpired fires when the sweeper releases an unpaid order's stock
+// reservations. The order's OrderCancelled event follows in the same transaction.
+type OrderReservationExpired struct {
+	OrderPayload
+}
+
+func (OrderReservationExpired) Topic() string { return TopicOrderReservationExpired }
+
+// OrderStatusChanged covers transitions without a dedicated event, e.g. a failed payment
+// being retried (payment_failed -> pending_payment).
+type OrderStatusChanged struct {
+	OrderPayload
+}
+
+func (OrderStatusChanged) Topic() string { return TopicOrderStatusChanged }
+
+// ShipmentLine is the units of one order line a shipment carries.
+type ShipmentLine struct {
+	OrderLineID string `json:"order_line_id"`
+	ProductID   string `json:"product_id"`
+	Quantity    uint   `json:"quantity"`
+}
+
+// ShipmentPayload is the common body of every shipment event: the shipment as of the
+// transition and where to reach the buyer. UserEmail is empty when the lookup failed.
+type ShipmentPayload struct {
+	ShipmentID     string         `json:"shipment_id"`
+	OrderID        string         `json:"order_id"`
+	OrderCode      string         `json:"order_code,omitempty"`
+	UserID         string         `json:"user_id"`
+	UserEmail      string         `json:"user_email"`
+	Status         string         `json:"status"`
+	Carrier        string         `json:"carrier,omitempty"`
+	TrackingNumber string         `json:"tracking_number,omitempty"`
+	Lines          []ShipmentLine `json:"lines,omitempty"`
+}
+
+// Payload lets subscribers handle any shipment event without switching on its type.
+func (p ShipmentPayload) Payload() ShipmentPayload { return p }
+
+// ShipmentEvent is implemented by every shipment event.
+type ShipmentEvent interface {
+	Event
+	Payload() ShipmentPayload
+}
+
+// ShipmentPacked fires when an admin packs some of an order's lines into a new shipment.
+type ShipmentPacked struct {
+	ShipmentPayload
+}
+
+func (ShipmentPacked) Topic() string { return TopicShipmentPacked }
+
+type ShipmentShipped struct {
+	ShipmentPayload
+}
+
+func (ShipmentShipped) Topic() string { return TopicShipmentShipped }
+
+type ShipmentDelivered struct {
+	ShipmentPayload
+}
+
+func (ShipmentDelivered) Topic() string { return TopicShipmentDelivered }
+
 // LowStock fires when a product's available stock (stock - reserved) crosses below
-// the configured threshold. Carries enough context for an admin notification.
+// its low-stock threshold. Carries enough context for an admin notification.
 type LowStock struct {
-	ProductID string
-	Available int
-	Threshold int
+	ProductID   string `json:"product_id"`
+	ProductName string `json:"product_name,omitempty"`
+	Available   int    `json:"available"`
+	Threshold   int    `json:"threshold"`
+	// ReorderQuantity is how many units to reorder; 0 when no reorder point is configured.
+	ReorderQuantity int `json:"reorder_quantity,omitempty"`
 }
 
 func (LowStock) Topic() string { return TopicLowStock }
@@ -0,0 +1,231 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+	"gorm.io/gorm"
+)
+
+const pgPruneInterval = time.Hour
+
+type postgresBus struct {
+	db   *gorm.DB
+	opts DurableOptions
+
+	mu     sync.Mutex
+	subs   subscriptions
+	runCtx context.Context
+	wg     sync.WaitGroup
+}
+
+// NewPostgres returns a bus backed by the eventbus_events table (migration 0006). Each
+// subscriber keeps its offset in an eventbus_subscriptions row, which one replica at a time
+// leases and polls; if that replica dies, another takes over once the lease has gone
+// ClaimAfter without renewal and resumes from the last acknowledged event. Events are read in
+// commit-safe order (see pgFetchQuery), so a slow publisher's transaction can't be skipped.
+func NewPostgres(db *gorm.DB, opts DurableOptions) Bus {
+	return &postgresBus{db: db, opts: opts.withDefaults()}
+}
+
+func (b *postgresBus) Publish(ctx context.Context, ev Event) error {
+	payload, err := Marshal(ev)
+	if err != nil {
+		return err
+	}
+	return b.db.WithContext(ctx).
+		Exec(`INSERT INTO eventbus_events (topic, payload) VALUES (?, ?)`, ev.Topic(), string(payload)).
+		Error
+}
+
+func (b *postgresBus) Subscribe(topic string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sub := b.subs.add(b.opts.Group, topic, h)
+	if b.runCtx != nil {
+		b.start(b.runCtx, sub)
+	}
+}
+
+func (b *postgresBus) Run(ctx context.Context) {
+	b.mu.Lock()
+	b.runCtx = ctx
+	for _, sub := range b.subs.list {
+		b.start(ctx, sub)
+	}
+	b.mu.Unlock()
+
+	ticker := time.NewTicker(pgPruneInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			b.wg.Wait()
+			b.releaseLeases()
+			return
+		case <-ticker.C:
+			if err := b.prune(ctx); err != nil && ctx.Err() == nil {
+				logger.Errorf("eventbus: prune: %s", err)
+			}
+		}
+	}
+}
+
+func (b *postgresBus) start(ctx context.Context, sub *subscription) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.consume(ctx, sub)
+	}()
+}
+
+func (b *postgresBus) consume(ctx context.Context, sub *subscription) {
+	for {
+		err := b.register(ctx, sub)
+		if err == nil {
+			break
+		}
+		logger.Errorf("eventbus: register %s: %s", sub.name, err)
+		if !sleep(ctx, b.opts.PollInterval) {
+			return
+		}
+	}
+
+	for {
+		n, err := b.poll(ctx, sub)
+		if err != nil && ctx.Err() == nil {
+			logger.Errorf("eventbus: poll %s: %s", sub.name, err)
+		}
+		// A full batch means there's likely more waiting.
+		if err == nil && n == b.opts.BatchSize {
+			continue
+		}
+		if !sleep(ctx, b.opts.PollInterval) {
+			return
+		}
+	}
+}
+
+// pgRegisterQuery creates a subscription positioned at the newest event of its topic, so a
+// subscriber added later starts with new events rather than replaying history.
+const pgRegisterQuery = `
+INSERT INTO eventbus_subscriptions (name, topic, last_tx_id, last_event_id, updated_at)
+SELECT ?, ?, COALESCE(MAX(tx_id), 0), COALESCE(MAX(id), 0), now()
+FROM eventbus_events WHERE topic = ?
+ON CONFLICT (name) DO NOTHING`
+
+func (b *postgresBus) register(ctx context.Context, sub *subscription) error {
+	return b.db.WithContext(ctx).Exec(pgRegisterQuery, sub.name, sub.topic, sub.topic).Error
+}
+
+// pgLeaseQuery takes or renews the subscription for this consumer unless another holds a live
+// lease. Lease times use the database clock so replicas' clocks needn't agree.
+const pgLeaseQuery = `
+UPDATE eventbus_subscriptions
+SET leased_by = ?, lease_until = now() + ? * interval '1 second', updated_at = now()
+WHERE name = ? AND (leased_by IS NULL OR leased_by = ? OR lease_until < now())
+RETURNING last_tx_id, last_event_id, attempts`
+
+// pgFetchQuery reads events after the subscription's offset. Events are ordered by the
+// publishing transaction's id, and only transactions older than every one still running are
+// visible: ids from a sequence can commit out of order, but no transaction can commit below
+// that horizon any more, so the offset never moves past an event that shows up later.
+const pgFetchQuery = `
+SELECT id, tx_id, payload FROM eventbus_events
+WHERE topic = ? AND (tx_id, id) > (?, ?)
+AND tx_id < pg_snapshot_xmin(pg_current_snapshot())::text::bigint
+ORDER BY tx_id, id
+LIMIT ?`
+
+const pgAckQuery = `
+UPDATE eventbus_subscriptions
+SET last_tx_id = ?, last_event_id = ?, attempts = 0,
+	lease_until = now() + ? * interval '1 second', updated_at = now()
+WHERE name = ? AND leased_by = ?`
+
+const pgNackQuery = `
+UPDATE eventbus_subscriptions SET attempts = ?, updated_at = now()
+WHERE name = ? AND leased_by = ?`
+
+type pgCursor struct {
+	LastTxID    int64
+	LastEventID int64
+	Attempts    int
+}
+
+type pgEvent struct {
+	ID      int64
+	TxID    int64
+	Payload string
+}
+
+// poll delivers the next batch for sub if this consumer holds (or can take) its lease, and
+// returns how many events were acknowledged.
+func (b *postgresBus) poll(ctx context.Context, sub *subscription) (int, error) {
+	db := b.db.WithContext(ctx)
+	leaseSecs := b.opts.ClaimAfter.Seconds()
+
+	var cursors []pgCursor
+	if err := db.Raw(pgLeaseQuery, b.opts.Consumer, leaseSecs, sub.name, b.opts.Consumer).Scan(&cursors).Error; err != nil {
+		return 0, err
+	}
+	if len(cursors) == 0 {
+		// Another replica is consuming this subscriber.
+		return 0, nil
+	}
+	cursor := cursors[0]
+
+	var events []pgEvent
+	if err := db.Raw(pgFetchQuery, sub.topic, cursor.LastTxID, cursor.LastEventID, b.opts.BatchSize).
+		Scan(&events).Error; err != nil {
+		return 0, err
+	}
+
+	acked := 0
+	for _, e := range events {
+		if ev, err := Unmarshal(sub.topic, []byte(e.Payload)); err != nil {
+			// Redelivering won't make it decodable.
+			logger.Errorf("eventbus: %s dropping event %d: %s", sub.name, e.ID, err)
+		} else if !dispatch(ctx, sub, ev) {
+			cursor.Attempts++
+			if cursor.Attempts < b.opts.MaxDeliveries {
+				// Stop here; the event is redelivered on the next poll.
+				return acked, db.Exec(pgNackQuery, cursor.Attempts, sub.name, b.opts.Consumer).Error
+			}
+			logger.Errorf("eventbus: %s gave up on event %d after %d deliveries", sub.name, e.ID, cursor.Attempts)
+		}
+
+		res := db.Exec(pgAckQuery, e.TxID, e.ID, leaseSecs, sub.name, b.opts.Consumer)
+		if res.Error != nil {
+			return acked, res.Error
+		}
+		if res.RowsAffected == 0 {
+			// The lease lapsed and another replica took over from the last acknowledged event.
+			return acked, nil
+		}
+		cursor.Attempts = 0
+		acked++
+	}
+	return acked, nil
+}
+
+func (b *postgresBus) prune(ctx context.Context) error {
+	return b.db.WithContext(ctx).
+		Exec(`DELETE FROM eventbus_events WHERE created_at < now() - ? * interval '1 second'`, b.opts.Retention.Seconds()).
+		Error
+}
+
+// releaseLeases hands this consumer's subscriptions over right away on shutdown instead of
+// making the other replicas wait out ClaimAfter.
+func (b *postgresBus) releaseLeases() {
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err := b.db.WithContext(ctx).
+		Exec(`UPDATE eventbus_subscriptions SET leased_by = NULL, lease_until = NULL WHERE leased_by = ?`, b.opts.Consumer).
+		Error
+	if err != nil {
+		logger.Errorf("eventbus: release leases: %s", err)
+	}
+}
@@ -0,0 +1,199 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	sqlmock "github.com/DATA-DOG/go-sqlmock"
+	"github.com/quangdangfit/gocommon/logger"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"goshop/pkg/config"
+)
+
+func newPostgresTestBus(t *testing.T) (*postgresBus, *subscription, sqlmock.Sqlmock) {
+	t.Helper()
+	logger.Initialize(config.ProductionEnv)
+	sqlDB, m, err := sqlmock.New()
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = sqlDB.Close() })
+	g, err := gorm.Open(postgres.New(postgres.Config{Conn: sqlDB}), &gorm.Config{
+		SkipDefaultTransaction: true,
+	})
+	require.NoError(t, err)
+
+	bus := NewPostgres(g, testDurableOptions("a")).(*postgresBus)
+	return bus, &subscription{name: "test:order.created#0", topic: TopicOrderCreated}, m
+}
+
+var (
+	pgLeaseRe = regexp.QuoteMeta(`UPDATE eventbus_subscriptions
+SET leased_by = $1`)
+	pgFetchRe = regexp.QuoteMeta(`SELECT id, tx_id, payload FROM eventbus_events`)
+	pgAckRe   = regexp.QuoteMeta(`SET last_tx_id = $1, last_event_id = $2, attempts = 0`)
+	pgNackRe  = regexp.QuoteMeta(`UPDATE eventbus_subscriptions SET attempts = $1`)
+)
+
+func expectLease(m sqlmock.Sqlmock, lastTx, lastID int64, attempts int) {
+	m.ExpectQuery(pgLeaseRe).
+		WithArgs("a", sqlmock.AnyArg(), "test:order.created#0", "a").
+		WillReturnRows(sqlmock.NewRows([]string{"last_tx_id", "last_event_id", "attempts"}).AddRow(lastTx, lastID, attempts))
+}
+
+func TestPostgresBus_Publish(t *testing.T) {
+	bus, _, m := newPostgresTestBus(t)
+	m.ExpectExec(regexp.QuoteMeta(`INSERT INTO eventbus_events (topic, payload) VALUES ($1, $2)`)).
+		WithArgs(TopicOrderCreated, `{"order_id":"o1","user_id":"","user_email":"","total_price":{"amount":"0.00","currency":""},"discount_amount":{"amount":"0.00","currency":""},"shipping_fee":{"amount":"0.00","currency":""},"tax_amount":{"amount":"0.00","currency":""},"final_price":{"amount":"0.00","currency":""}}`).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+
+	require.NoError(t, bus.Publish(context.Background(), OrderCreated{OrderPayload: OrderPayload{OrderID: "o1"}}))
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPostgresBus_Register(t *testing.T) {
+	bus, sub, m := newPostgresTestBus(t)
+	m.ExpectExec(regexp.QuoteMeta(`INSERT INTO eventbus_subscriptions`)).
+		WithArgs(sub.name, sub.topic, sub.topic).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	require.NoError(t, bus.register(context.Background(), sub))
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPostgresBus_Poll_LeasedElsewhere(t *testing.T) {
+	bus, sub, m := newPostgresTestBus(t)
+	m.ExpectQuery(pgLeaseRe).WillReturnRows(sqlmock.NewRows([]string{"last_tx_id", "last_event_id", "attempts"}))
+
+	n, err := bus.poll(context.Background(), sub)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPostgresBus_Poll_DeliversAndAcks(t *testing.T) {
+	bus, sub, m := newPostgresTestBus(t)
+	var got collector
+	sub.handler = got.handle
+
+	expectLease(m, 10, 3, 0)
+	m.ExpectQuery(pgFetchRe).
+		WithArgs(TopicOrderCreated, int64(10), int64(3), 50).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tx_id", "payload"}).
+			AddRow(5, 11, `{"order_id":"o1"}`).
+			AddRow(4, 12, `{"order_id":"o2"}`))
+	m.ExpectExec(pgAckRe).WithArgs(int64(11), int64(5), sqlmock.AnyArg(), sub.name, "a").WillReturnResult(sqlmock.NewResult(0, 1))
+	m.ExpectExec(pgAckRe).WithArgs(int64(12), int64(4), sqlmock.AnyArg(), sub.name, "a").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := bus.poll(context.Background(), sub)
+	require.NoError(t, err)
+	require.Equal(t, 2, n)
+	require.Equal(t, []string{"o1", "o2"}, got.seen())
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPostgresBus_Poll_PanicLeavesOffsetForRedelivery(t *testing.T) {
+	bus, sub, m := newPostgresTestBus(t)
+	sub.handler = func(context.Context, Event) { panic("transient") }
+
+	expectLease(m, 10, 3, 0)
+	m.ExpectQuery(pgFetchRe).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tx_id", "payload"}).
+			AddRow(5, 11, `{"order_id":"o1"}`).
+			AddRow(6, 11, `{"order_id":"o2"}`))
+	m.ExpectExec(pgNackRe).WithArgs(1, sub.name, "a").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := bus.poll(context.Background(), sub)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPostgresBus_Poll_GivesUpAfterMaxDeliveries(t *testing.T) {
+	bus, sub, m := newPostgresTestBus(t)
+	sub.handler = func(context.Context, Event) { panic("permanent") }
+
+	expectLease(m, 10, 3, 2)
+	m.ExpectQuery(pgFetchRe).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tx_id", "payload"}).AddRow(5, 11, `{"order_id":"o1"}`))
+	m.ExpectExec(pgAckRe).WithArgs(int64(11), int64(5), sqlmock.AnyArg(), sub.name, "a").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := bus.poll(context.Background(), sub)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPostgresBus_Poll_DropsUndecodablePayload(t *testing.T) {
+	bus, sub, m := newPostgresTestBus(t)
+	var got collector
+	sub.handler = got.handle
+
+	expectLease(m, 0, 0, 0)
+	m.ExpectQuery(pgFetchRe).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tx_id", "payload"}).AddRow(1, 7, `not json`))
+	m.ExpectExec(pgAckRe).WithArgs(int64(7), int64(1), sqlmock.AnyArg(), sub.name, "a").WillReturnResult(sqlmock.NewResult(0, 1))
+
+	n, err := bus.poll(context.Background(), sub)
+	require.NoError(t, err)
+	require.Equal(t, 1, n)
+	require.Empty(t, got.seen())
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPostgresBus_Poll_StopsWhenLeaseLost(t *testing.T) {
+	bus, sub, m := newPostgresTestBus(t)
+	var got collector
+	sub.handler = got.handle
+
+	expectLease(m, 0, 0, 0)
+	m.ExpectQuery(pgFetchRe).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "tx_id", "payload"}).
+			AddRow(1, 7, `{"order_id":"o1"}`).
+			AddRow(2, 7, `{"order_id":"o2"}`))
+	m.ExpectExec(pgAckRe).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	n, err := bus.poll(context.Background(), sub)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	require.Equal(t, []string{"o1"}, got.seen())
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPostgresBus_Poll_Errors(t *testing.T) {
+	bus, sub, m := newPostgresTestBus(t)
+	m.ExpectQuery(pgLeaseRe).WillReturnError(errors.New("db down"))
+	_, err := bus.poll(context.Background(), sub)
+	require.Error(t, err)
+
+	expectLease(m, 0, 0, 0)
+	m.ExpectQuery(pgFetchRe).WillReturnError(errors.New("db down"))
+	_, err = bus.poll(context.Background(), sub)
+	require.Error(t, err)
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPostgresBus_Prune(t *testing.T) {
+	bus, _, m := newPostgresTestBus(t)
+	m.ExpectExec(regexp.QuoteMeta(`DELETE FROM eventbus_events WHERE created_at < now() - $1 * interval '1 second'`)).
+		WithArgs(bus.opts.Retention.Seconds()).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	require.NoError(t, bus.prune(context.Background()))
+	require.NoError(t, m.ExpectationsWereMet())
+}
+
+func TestPostgresBus_RunReleasesLeasesOnShutdown(t *testing.T) {
+	bus, _, m := newPostgresTestBus(t)
+	m.ExpectExec(regexp.QuoteMeta(`UPDATE eventbus_subscriptions SET leased_by = NULL, lease_until = NULL WHERE leased_by = $1`)).
+		WithArgs("a").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	bus.Run(ctx)
+	require.NoError(t, m.ExpectationsWereMet())
+}
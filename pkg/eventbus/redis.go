@@ -0,0 +1,187 @@
+package eventbus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/quangdangfit/gocommon/logger"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+type redisBus struct {
+	client goredis.Cmdable
+	opts   DurableOptions
+
+	mu     sync.Mutex
+	subs   subscriptions
+	runCtx context.Context
+	wg     sync.WaitGroup
+}
+
+// NewRedis returns a bus backed by Redis Streams, one stream per topic. Every subscriber reads
+// through its own consumer group, so its offset survives restarts and is shared by all
+// replicas: each event reaches one replica per subscriber. An event is acknowledged once its
+// handler returns; events left unacknowledged by a crashed or panicking consumer are claimed
+// by a live one after opts.ClaimAfter.
+func NewRedis(client goredis.Cmdable, opts DurableOptions) Bus {
+	return &redisBus{client: client, opts: opts.withDefaults()}
+}
+
+func redisStreamKey(topic string) string {
+	return "eventbus:" + topic
+}
+
+func (b *redisBus) Publish(ctx context.Context, ev Event) error {
+	payload, err := Marshal(ev)
+	if err != nil {
+		return err
+	}
+	// Stream IDs start with the millisecond timestamp, so MINID trims entries past Retention.
+	return b.client.XAdd(ctx, &goredis.XAddArgs{
+		Stream: redisStreamKey(ev.Topic()),
+		MinID:  fmt.Sprintf("%d-0", time.Now().Add(-b.opts.Retention).UnixMilli()),
+		Approx: true,
+		Values: map[string]any{"payload": string(payload)},
+	}).Err()
+}
+
+func (b *redisBus) Subscribe(topic string, h Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	sub := b.subs.add(b.opts.Group, topic, h)
+	if b.runCtx != nil {
+		b.start(b.runCtx, sub)
+	}
+}
+
+func (b *redisBus) Run(ctx context.Context) {
+	b.mu.Lock()
+	b.runCtx = ctx
+	for _, sub := range b.subs.list {
+		b.start(ctx, sub)
+	}
+	b.mu.Unlock()
+
+	<-ctx.Done()
+	b.wg.Wait()
+}
+
+func (b *redisBus) start(ctx context.Context, sub *subscription) {
+	b.wg.Add(1)
+	go func() {
+		defer b.wg.Done()
+		b.consume(ctx, sub)
+	}()
+}
+
+func (b *redisBus) consume(ctx context.Context, sub *subscription) {
+	stream := redisStreamKey(sub.topic)
+	for {
+		// "$": a subscriber added later starts with new events rather than replaying history.
+		err := b.client.XGroupCreateMkStream(ctx, stream, sub.name, "$").Err()
+		if err == nil || strings.HasPrefix(err.Error(), "BUSYGROUP") {
+			break
+		}
+		logger.Errorf("eventbus: create group %s: %s", sub.name, err)
+		if !sleep(ctx, b.opts.PollInterval) {
+			return
+		}
+	}
+
+	for ctx.Err() == nil {
+		if err := b.reclaim(ctx, sub); err != nil && ctx.Err() == nil {
+			logger.Errorf("eventbus: reclaim %s: %s", sub.name, err)
+		}
+
+		streams, err := b.client.XReadGroup(ctx, &goredis.XReadGroupArgs{
+			Group:    sub.name,
+			Consumer: b.opts.Consumer,
+			Streams:  []string{stream, ">"},
+			Count:    int64(b.opts.BatchSize),
+			Block:    b.opts.PollInterval,
+		}).Result()
+		if err != nil {
+			if errors.Is(err, goredis.Nil) || ctx.Err() != nil {
+				continue
+			}
+			logger.Errorf("eventbus: read %s: %s", sub.name, err)
+			sleep(ctx, b.opts.PollInterval)
+			continue
+		}
+		for _, s := range streams {
+			for _, msg := range s.Messages {
+				b.handle(ctx, sub, stream, msg)
+			}
+		}
+	}
+}
+
+// reclaim takes over events that have sat unacknowledged for ClaimAfter, whether their
+// consumer died or their handler panicked, and gives up on those out of deliveries.
+func (b *redisBus) reclaim(ctx context.Context, sub *subscription) error {
+	stream := redisStreamKey(sub.topic)
+	pending, err := b.client.XPendingExt(ctx, &goredis.XPendingExtArgs{
+		Stream: stream,
+		Group:  sub.name,
+		Idle:   b.opts.ClaimAfter,
+		Start:  "-",
+		End:    "+",
+		Count:  int64(b.opts.BatchSize),
+	}).Result()
+	if err != nil || len(pending) == 0 {
+		return err
+	}
+
+	var ids []string
+	for _, p := range pending {
+		if p.RetryCount >= int64(b.opts.MaxDeliveries) {
+			logger.Errorf("eventbus: %s gave up on %s after %d deliveries", sub.name, p.ID, p.RetryCount)
+			b.ack(ctx, sub, stream, p.ID)
+			continue
+		}
+		ids = append(ids, p.ID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	msgs, err := b.client.XClaim(ctx, &goredis.XClaimArgs{
+		Stream:   stream,
+		Group:    sub.name,
+		Consumer: b.opts.Consumer,
+		MinIdle:  b.opts.ClaimAfter,
+		Messages: ids,
+	}).Result()
+	if err != nil {
+		return err
+	}
+	for _, msg := range msgs {
+		b.handle(ctx, sub, stream, msg)
+	}
+	return nil
+}
+
+func (b *redisBus) handle(ctx context.Context, sub *subscription, stream string, msg goredis.XMessage) {
+	payload, _ := msg.Values["payload"].(string)
+	ev, err := Unmarshal(sub.topic, []byte(payload))
+	if err != nil {
+		// Redelivering won't make it decodable.
+		logger.Errorf("eventbus: %s dropping %s: %s", sub.name, msg.ID, err)
+		b.ack(ctx, sub, stream, msg.ID)
+		return
+	}
+	if dispatch(ctx, sub, ev) {
+		b.ack(ctx, sub, stream, msg.ID)
+	}
+}
+
+func (b *redisBus) ack(ctx context.Context, sub *subscription, stream, id string) {
+	if err := b.client.XAck(ctx, stream, sub.name, id).Err(); err != nil {
+		// Still pending, so it's redelivered after ClaimAfter.
+		logger.Errorf("eventbus: ack %s on %s: %s", id, sub.name, err)
+	}
+}
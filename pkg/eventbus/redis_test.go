@@ -0,0 +1,195 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/quangdangfit/gocommon/logger"
+	goredis "github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+
+	"goshop/pkg/config"
+)
+
+func newTestRedis(t *testing.T) goredis.Cmdable {
+	t.Helper()
+	logger.Initialize(config.ProductionEnv)
+	mr := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return client
+}
+
+func testDurableOptions(consumer string) DurableOptions {
+	return DurableOptions{
+		Group:         "test",
+		Consumer:      consumer,
+		PollInterval:  20 * time.Millisecond,
+		ClaimAfter:    50 * time.Millisecond,
+		MaxDeliveries: 3,
+	}
+}
+
+// collector records the order IDs a subscriber saw.
+type collector struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (c *collector) handle(_ context.Context, ev Event) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ids = append(c.ids, ev.(OrderCreated).OrderID)
+}
+
+func (c *collector) seen() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string(nil), c.ids...)
+}
+
+// runBus starts a durable bus and waits for its consumer groups to exist, so events published
+// afterwards aren't skipped by the "$" start position.
+func runBus(t *testing.T, bus Bus, client goredis.Cmdable, topic string) {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		bus.(Runner).Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	require.Eventually(t, func() bool {
+		groups, err := client.XInfoGroups(context.Background(), redisStreamKey(topic)).Result()
+		return err == nil && len(groups) > 0
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRedisBus_DeliversToEverySubscriber(t *testing.T) {
+	client := newTestRedis(t)
+	bus := NewRedis(client, testDurableOptions("a"))
+	var first, second collector
+	bus.Subscribe(TopicOrderCreated, first.handle)
+	bus.Subscribe(TopicOrderCreated, second.handle)
+	runBus(t, bus, client, TopicOrderCreated)
+
+	require.NoError(t, bus.Publish(context.Background(), OrderCreated{OrderPayload: OrderPayload{OrderID: "o1"}}))
+	require.NoError(t, bus.Publish(context.Background(), OrderCreated{OrderPayload: OrderPayload{OrderID: "o2"}}))
+
+	require.Eventually(t, func() bool { return len(first.seen()) == 2 && len(second.seen()) == 2 }, time.Second, 10*time.Millisecond)
+	require.Equal(t, []string{"o1", "o2"}, first.seen())
+	require.Equal(t, []string{"o1", "o2"}, second.seen())
+}
+
+func TestRedisBus_ReplicasShareEachSubscriber(t *testing.T) {
+	client := newTestRedis(t)
+	var a, b collector
+	replicaA := NewRedis(client, testDurableOptions("a"))
+	replicaA.Subscribe(TopicOrderCreated, a.handle)
+	replicaB := NewRedis(client, testDurableOptions("b"))
+	replicaB.Subscribe(TopicOrderCreated, b.handle)
+	runBus(t, replicaA, client, TopicOrderCreated)
+	runBus(t, replicaB, client, TopicOrderCreated)
+
+	for _, id := range []string{"o1", "o2", "o3", "o4"} {
+		require.NoError(t, replicaA.Publish(context.Background(), OrderCreated{OrderPayload: OrderPayload{OrderID: id}}))
+	}
+
+	require.Eventually(t, func() bool { return len(a.seen())+len(b.seen()) == 4 }, time.Second, 10*time.Millisecond)
+	time.Sleep(100 * time.Millisecond)
+	require.ElementsMatch(t, []string{"o1", "o2", "o3", "o4"}, append(a.seen(), b.seen()...))
+}
+
+func TestRedisBus_RedeliversAfterPanic(t *testing.T) {
+	client := newTestRedis(t)
+	bus := NewRedis(client, testDurableOptions("a"))
+	var mu sync.Mutex
+	calls := 0
+	var got collector
+	bus.Subscribe(TopicOrderCreated, func(ctx context.Context, ev Event) {
+		mu.Lock()
+		calls++
+		n := calls
+		mu.Unlock()
+		if n == 1 {
+			panic("transient")
+		}
+		got.handle(ctx, ev)
+	})
+	runBus(t, bus, client, TopicOrderCreated)
+
+	require.NoError(t, bus.Publish(context.Background(), OrderCreated{OrderPayload: OrderPayload{OrderID: "o1"}}))
+
+	require.Eventually(t, func() bool { return len(got.seen()) == 1 }, 2*time.Second, 10*time.Millisecond)
+	pending, err := client.XPending(context.Background(), redisStreamKey(TopicOrderCreated), "test:order.created#0").Result()
+	require.NoError(t, err)
+	require.Zero(t, pending.Count)
+}
+
+func TestRedisBus_GivesUpAfterMaxDeliveries(t *testing.T) {
+	client := newTestRedis(t)
+	bus := NewRedis(client, testDurableOptions("a"))
+	var mu sync.Mutex
+	calls := 0
+	bus.Subscribe(TopicOrderCreated, func(context.Context, Event) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		panic("permanent")
+	})
+	runBus(t, bus, client, TopicOrderCreated)
+
+	require.NoError(t, bus.Publish(context.Background(), OrderCreated{OrderPayload: OrderPayload{OrderID: "o1"}}))
+
+	require.Eventually(t, func() bool {
+		pending, err := client.XPending(context.Background(), redisStreamKey(TopicOrderCreated), "test:order.created#0").Result()
+		mu.Lock()
+		defer mu.Unlock()
+		return err == nil && pending.Count == 0 && calls > 0
+	}, 2*time.Second, 10*time.Millisecond)
+	mu.Lock()
+	defer mu.Unlock()
+	require.Equal(t, 3, calls)
+}
+
+func TestRedisBus_DropsUndecodablePayload(t *testing.T) {
+	client := newTestRedis(t)
+	bus := NewRedis(client, testDurableOptions("a"))
+	var got collector
+	bus.Subscribe(TopicOrderCreated, got.handle)
+	runBus(t, bus, client, TopicOrderCreated)
+
+	require.NoError(t, client.XAdd(context.Background(), &goredis.XAddArgs{
+		Stream: redisStreamKey(TopicOrderCreated),
+		Values: map[string]any{"payload": "not json"},
+	}).Err())
+	require.NoError(t, bus.Publish(context.Background(), OrderCreated{OrderPayload: OrderPayload{OrderID: "o1"}}))
+
+	require.Eventually(t, func() bool { return len(got.seen()) == 1 }, time.Second, 10*time.Millisecond)
+	pending, err := client.XPending(context.Background(), redisStreamKey(TopicOrderCreated), "test:order.created#0").Result()
+	require.NoError(t, err)
+	require.Zero(t, pending.Count)
+}
+
+func TestRedisBus_SubscribeAfterRun(t *testing.T) {
+	client := newTestRedis(t)
+	bus := NewRedis(client, testDurableOptions("a"))
+	var early, late collector
+	bus.Subscribe(TopicOrderCreated, early.handle)
+	runBus(t, bus, client, TopicOrderCreated)
+
+	bus.Subscribe(TopicOrderCreated, late.handle)
+	require.Eventually(t, func() bool {
+		groups, err := client.XInfoGroups(context.Background(), redisStreamKey(TopicOrderCreated)).Result()
+		return err == nil && len(groups) == 2
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, bus.Publish(context.Background(), OrderCreated{OrderPayload: OrderPayload{OrderID: "o1"}}))
+	require.Eventually(t, func() bool { return len(early.seen()) == 1 && len(late.seen()) == 1 }, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,64 @@
+package invoice
+
+import (
+	"bytes"
+	"html/template"
+)
+
+var htmlTemplate = template.Must(template.New("document").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Kind.Title}} {{.Number}}</title>
+<style>
+body { font-family: Helvetica, Arial, sans-serif; font-size: 14px; margin: 40px; color: #222; }
+table { border-collapse: collapse; width: 100%; margin-top: 24px; }
+th, td { padding: 6px 8px; border-bottom: 1px solid #ddd; text-align: left; }
+.num { text-align: right; }
+.parties { display: flex; justify-content: space-between; margin-top: 24px; }
+.totals td { border: none; }
+.grand td { font-weight: bold; border-top: 2px solid #222; }
+</style>
+</head>
+<body>
+<h1>{{.Kind.Title}} {{.Number}}</h1>
+<p>Issued {{.IssuedAt.Format "2006-01-02"}}{{if .OrderCode}} &middot; Order {{.OrderCode}}{{end}}{{if .Reference}} &middot; Corrects invoice {{.Reference}}{{end}}</p>
+<div class="parties">
+<div>
+<strong>{{.Seller.Name}}</strong><br>
+{{range .Seller.Address}}{{.}}<br>{{end}}
+{{if .Seller.TaxID}}Tax ID: {{.Seller.TaxID}}<br>{{end}}
+</div>
+<div>
+<strong>Billed to</strong><br>
+{{.Buyer.Name}}<br>
+{{range .Buyer.Address}}{{.}}<br>{{end}}
+{{if .Buyer.Email}}{{.Buyer.Email}}<br>{{end}}
+</div>
+</div>
+<table>
+<thead><tr><th>Item</th><th class="num">Qty</th><th class="num">Unit price</th><th class="num">Tax</th><th class="num">Amount</th></tr></thead>
+<tbody>
+{{range .Lines}}<tr><td>{{.Description}}</td><td class="num">{{.Quantity}}</td><td class="num">{{.UnitPrice.Decimal}}</td><td class="num">{{.TaxRate}}%</td><td class="num">{{.Amount.Decimal}}</td></tr>
+{{end}}</tbody>
+</table>
+<table class="totals">
+{{range .Totals}}<tr{{if .Grand}} class="grand"{{end}}><td class="num">{{.Label}}</td><td class="num">{{.Amount.Decimal}} {{$.Currency}}</td></tr>
+{{end}}</table>
+{{if .Note}}<p>{{.Note}}</p>{{end}}
+</body>
+</html>
+`))
+
+// HTML renders the document as a standalone HTML page.
+func HTML(doc *Document) ([]byte, error) {
+	var buf bytes.Buffer
+	err := htmlTemplate.Execute(&buf, struct {
+		*Document
+		Totals []total
+	}{doc, doc.totals()})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
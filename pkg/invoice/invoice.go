@@ -0,0 +1,123 @@
+// Package invoice lays out invoices and credit notes: the numbered documents an order's sale
+// and its refunds are recorded on, and their HTML and PDF renderings.
+package invoice
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"goshop/pkg/money"
+	"goshop/pkg/tax"
+)
+
+// Kind is the type of a document. Each kind is numbered in its own series.
+type Kind string
+
+const (
+	// KindInvoice bills a paid order.
+	KindInvoice Kind = "invoice"
+	// KindCreditNote credits back part or all of an invoice for a refund.
+	KindCreditNote Kind = "credit_note"
+)
+
+// Title is the document's heading.
+func (k Kind) Title() string {
+	if k == KindCreditNote {
+		return "Credit note"
+	}
+	return "Invoice"
+}
+
+// Number formats the seq-th document of kind issued in year, e.g. INV-2026-000042.
+func Number(kind Kind, year, seq int) string {
+	prefix := "INV"
+	if kind == KindCreditNote {
+		prefix = "CN"
+	}
+	return fmt.Sprintf("%s-%d-%06d", prefix, year, seq)
+}
+
+// Party is the seller or the buyer as the document names them.
+type Party struct {
+	Name    string   `json:"name"`
+	Address []string `json:"address,omitempty"`
+	TaxID   string   `json:"tax_id,omitempty"`
+	Email   string   `json:"email,omitempty"`
+}
+
+// AddressLines splits a multi-line address, dropping blank lines.
+func AddressLines(address string) []string {
+	var lines []string
+	for _, line := range strings.Split(address, "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// Line is one item on a document. Amount is what its units come to before tax when prices
+// exclude it, or with the tax when they include it; TaxAmount is the tax on them either way.
+type Line struct {
+	// ItemID identifies what the line bills, e.g. the order line.
+	ItemID      string        `json:"item_id,omitempty"`
+	Description string        `json:"description"`
+	Quantity    uint          `json:"quantity"`
+	UnitPrice   money.Money   `json:"unit_price"`
+	TaxRate     money.Percent `json:"tax_rate"`
+	TaxAmount   money.Money   `json:"tax_amount"`
+	Amount      money.Money   `json:"amount"`
+}
+
+// Document is an invoice or credit note as issued: everything on it is a copy, so later
+// changes to the order, its products or the shop's details don't alter it.
+type Document struct {
+	Kind     Kind      `json:"kind"`
+	Number   string    `json:"number"`
+	IssuedAt time.Time `json:"issued_at"`
+	// OrderCode is the order the document belongs to.
+	OrderCode string `json:"order_code,omitempty"`
+	// Reference is the number of the invoice a credit note corrects.
+	Reference string      `json:"reference,omitempty"`
+	Seller    Party       `json:"seller"`
+	Buyer     Party       `json:"buyer"`
+	Currency  string      `json:"currency"`
+	TaxMode   tax.Mode    `json:"tax_mode"`
+	Lines     []Line      `json:"lines"`
+	Subtotal  money.Money `json:"subtotal"`
+	Discount  money.Money `json:"discount"`
+	Shipping  money.Money `json:"shipping"`
+	Tax       money.Money `json:"tax"`
+	Total     money.Money `json:"total"`
+	Note      string      `json:"note,omitempty"`
+}
+
+// Filename is what a download of the document is called, e.g. INV-2026-000042.pdf.
+func (d *Document) Filename(ext string) string {
+	return d.Number + "." + ext
+}
+
+// total is a labelled amount in the document's summary.
+type total struct {
+	Label  string
+	Amount money.Money
+	Grand  bool
+}
+
+// totals is the document's summary, leaving out the discount and shipping when there are none.
+func (d *Document) totals() []total {
+	out := []total{{Label: "Subtotal", Amount: d.Subtotal}}
+	if d.Discount.IsPositive() {
+		out = append(out, total{Label: "Discount", Amount: d.Discount.Mul(-1)})
+	}
+	if d.Shipping.IsPositive() {
+		out = append(out, total{Label: "Shipping", Amount: d.Shipping})
+	}
+	taxLabel := "Tax"
+	if d.TaxMode.Inclusive() {
+		taxLabel = "Tax included"
+	}
+	out = append(out, total{Label: taxLabel, Amount: d.Tax})
+	return append(out, total{Label: "Total", Amount: d.Total, Grand: true})
+}
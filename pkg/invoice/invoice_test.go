@@ -0,0 +1,118 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"goshop/pkg/money"
+	"goshop/pkg/tax"
+)
+
+func testDocument() *Document {
+	return &Document{
+		Kind:      KindInvoice,
+		Number:    Number(KindInvoice, 2026, 42),
+		IssuedAt:  time.Date(2026, 3, 9, 12, 0, 0, 0, time.UTC),
+		OrderCode: "ORD-1",
+		Seller:    Party{Name: "GoShop Ltd", Address: AddressLines("1 Market St\n\n Springfield "), TaxID: "US123"},
+		Buyer:     Party{Name: "Ann <Lee>", Address: []string{"Müllerstraße 1 (rear)"}, Email: "ann@x.com"},
+		Currency:  "USD",
+		TaxMode:   tax.Exclusive,
+		Lines: []Line{{
+			ItemID: "l1", Description: "Book", Quantity: 2, UnitPrice: money.New(1000, "USD"),
+			TaxRate: 725, TaxAmount: money.New(109, "USD"), Amount: money.New(2000, "USD"),
+		}},
+		Subtotal: money.New(2000, "USD"),
+		Discount: money.New(500, "USD"),
+		Tax:      money.New(109, "USD"),
+		Total:    money.New(1609, "USD"),
+	}
+}
+
+func TestNumber(t *testing.T) {
+	require.Equal(t, "INV-2026-000042", Number(KindInvoice, 2026, 42))
+	require.Equal(t, "CN-2027-000001", Number(KindCreditNote, 2027, 1))
+	require.Equal(t, "Credit note", KindCreditNote.Title())
+	require.Equal(t, "INV-2026-000042.pdf", testDocument().Filename("pdf"))
+}
+
+func TestTotals(t *testing.T) {
+	doc := testDocument()
+	require.Equal(t, []total{
+		{Label: "Subtotal", Amount: money.New(2000, "USD")},
+		{Label: "Discount", Amount: money.New(-500, "USD")},
+		{Label: "Tax", Amount: money.New(109, "USD")},
+		{Label: "Total", Amount: money.New(1609, "USD"), Grand: true},
+	}, doc.totals())
+
+	doc.Discount = money.Zero("USD")
+	doc.Shipping = money.New(499, "USD")
+	doc.TaxMode = tax.Inclusive
+	require.Equal(t, []total{
+		{Label: "Subtotal", Amount: money.New(2000, "USD")},
+		{Label: "Shipping", Amount: money.New(499, "USD")},
+		{Label: "Tax included", Amount: money.New(109, "USD")},
+		{Label: "Total", Amount: money.New(1609, "USD"), Grand: true},
+	}, doc.totals())
+}
+
+func TestHTML(t *testing.T) {
+	out, err := HTML(testDocument())
+	require.NoError(t, err)
+	html := string(out)
+	require.Contains(t, html, "<h1>Invoice INV-2026-000042</h1>")
+	require.Contains(t, html, "Ann &lt;Lee&gt;")
+	require.Contains(t, html, "1 Market St<br>Springfield<br>")
+	require.Contains(t, html, `<td class="num">7.25%</td><td class="num">20.00</td>`)
+	require.Contains(t, html, `<tr class="grand"><td class="num">Total</td><td class="num">16.09 USD</td>`)
+}
+
+func TestPDF(t *testing.T) {
+	out, err := PDF(testDocument())
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	require.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	require.Contains(t, string(out), "(Invoice INV-2026-000042) Tj")
+	require.Contains(t, string(out), `(M\374llerstra\337e 1 \(rear\)) Tj`)
+	require.Contains(t, string(out), "(16.09 USD) Tj")
+	requireValidXref(t, out)
+}
+
+func TestPDF_FlowsOntoMorePages(t *testing.T) {
+	doc := testDocument()
+	for i := range 80 {
+		doc.Lines = append(doc.Lines, Line{Description: fmt.Sprintf("Item %d with a long name that does not fit its column", i), Quantity: 1})
+	}
+	out, err := PDF(doc)
+	require.NoError(t, err)
+	require.Contains(t, string(out), "/Count 2 >>")
+	require.Contains(t, string(out), "(Item 79 with a long name that does not fit...) Tj")
+	requireValidXref(t, out)
+}
+
+func TestPDFString(t *testing.T) {
+	require.Equal(t, `a\\b \(c\) ?`, pdfString(`a\b (c) 日`))
+}
+
+// requireValidXref checks each cross-reference entry points at its object.
+func requireValidXref(t *testing.T, pdf []byte) {
+	t.Helper()
+	start := regexp.MustCompile(`startxref\n(\d+)`).FindSubmatch(pdf)
+	require.NotNil(t, start)
+	xref, err := strconv.Atoi(string(start[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(pdf[xref:], []byte("xref\n")))
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(pdf[xref:], -1)
+	require.NotEmpty(t, entries)
+	for i, e := range entries {
+		off, err := strconv.Atoi(string(e[1]))
+		require.NoError(t, err)
+		require.True(t, bytes.HasPrefix(pdf[off:], fmt.Appendf(nil, "%d 0 obj\n", i+1)), "object %d", i+1)
+	}
+}
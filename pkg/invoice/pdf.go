@@ -0,0 +1,194 @@
+package invoice
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Page geometry in points: A4 with 50pt margins.
+const (
+	pageWidth  = 595
+	pageHeight = 842
+	margin     = 50
+	lineHeight = 14
+)
+
+// Table columns: the x offset each cell starts at.
+var pdfColumns = []float64{margin, 300, 345, 425, 480}
+
+// PDF renders the document as a PDF in the standard Helvetica fonts, so it needs no embedded
+// font files. Characters outside Latin-1 print as "?". Long documents flow onto more pages.
+func PDF(doc *Document) ([]byte, error) {
+	w := &pdfLayout{}
+	w.newPage()
+
+	w.text(margin, 20, true, doc.Kind.Title()+" "+doc.Number)
+	w.advance(10)
+	meta := "Issued " + doc.IssuedAt.Format("2006-01-02")
+	if doc.OrderCode != "" {
+		meta += "  -  Order " + doc.OrderCode
+	}
+	if doc.Reference != "" {
+		meta += "  -  Corrects invoice " + doc.Reference
+	}
+	w.text(margin, 10, false, meta)
+	w.advance(10)
+
+	w.party(doc.Seller, "")
+	w.advance(6)
+	w.party(doc.Buyer, "Billed to")
+	w.advance(10)
+
+	w.row(true, "Item", "Qty", "Unit price", "Tax", "Amount")
+	for _, l := range doc.Lines {
+		w.row(false, l.Description, strconv.FormatUint(uint64(l.Quantity), 10), l.UnitPrice.Decimal(),
+			l.TaxRate.String()+"%", l.Amount.Decimal())
+	}
+	w.advance(10)
+	for _, t := range doc.totals() {
+		w.line()
+		w.cell(pdfColumns[2], t.Grand, t.Label)
+		w.cell(pdfColumns[4], t.Grand, t.Amount.Decimal()+" "+doc.Currency)
+	}
+	if doc.Note != "" {
+		w.advance(10)
+		w.text(margin, 10, false, doc.Note)
+	}
+	return w.bytes(), nil
+}
+
+// pdfLayout writes text top-down, starting a new page when the current one is full.
+type pdfLayout struct {
+	pages []*bytes.Buffer
+	y     float64
+}
+
+func (w *pdfLayout) newPage() {
+	w.pages = append(w.pages, &bytes.Buffer{})
+	w.y = pageHeight - margin
+}
+
+func (w *pdfLayout) advance(points float64) {
+	w.y -= points
+	if w.y < margin {
+		w.newPage()
+	}
+}
+
+// line moves down to the next line of 10pt text.
+func (w *pdfLayout) line() {
+	w.advance(lineHeight)
+}
+
+// text writes s on a line of its own.
+func (w *pdfLayout) text(x, size float64, bold bool, s string) {
+	w.advance(size * 1.4)
+	w.write(x, size, bold, s)
+}
+
+// cell writes s at x on the current line.
+func (w *pdfLayout) cell(x float64, bold bool, s string) {
+	w.write(x, 10, bold, s)
+}
+
+func (w *pdfLayout) row(bold bool, cells ...string) {
+	w.line()
+	for i, c := range cells {
+		if i == 0 {
+			c = truncate(c, 45)
+		}
+		w.cell(pdfColumns[i], bold, c)
+	}
+}
+
+func (w *pdfLayout) party(p Party, heading string) {
+	if heading != "" {
+		w.text(margin, 10, true, heading)
+	}
+	w.text(margin, 10, heading == "", p.Name)
+	for _, l := range p.Address {
+		w.text(margin, 10, false, l)
+	}
+	if p.TaxID != "" {
+		w.text(margin, 10, false, "Tax ID: "+p.TaxID)
+	}
+	if p.Email != "" {
+		w.text(margin, 10, false, p.Email)
+	}
+}
+
+func (w *pdfLayout) write(x, size float64, bold bool, s string) {
+	font := "F1"
+	if bold {
+		font = "F2"
+	}
+	fmt.Fprintf(w.pages[len(w.pages)-1], "BT /%s %g Tf %g %g Td (%s) Tj ET\n", font, size, x, w.y, pdfString(s))
+}
+
+// bytes assembles the PDF objects: catalog, page tree, the two fonts, then each page and its
+// content stream, followed by the cross-reference table.
+func (w *pdfLayout) bytes() []byte {
+	var objects []string
+	pageIDs := make([]string, len(w.pages))
+	for i := range w.pages {
+		pageIDs[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(pageIDs, " "), len(w.pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	)
+	for i, content := range w.pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %d %d] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				pageWidth, pageHeight, 6+2*i),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", content.Len(), content.String()),
+		)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = out.Len()
+		fmt.Fprintf(&out, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := out.Len()
+	fmt.Fprintf(&out, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&out, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&out, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return out.Bytes()
+}
+
+// pdfString escapes s for a PDF string literal in WinAnsiEncoding, which matches Latin-1 for
+// the characters it covers.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\\' || r == '(' || r == ')':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= 0x20 && r < 0x7f:
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+	return b.String()
+}
+
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n-3]) + "..."
+}
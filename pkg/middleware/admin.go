@@ -6,10 +6,14 @@ import (
 	"goshop/pkg/apperror"
 )
 
+// IsAdmin reports whether the authenticated caller has the admin role.
+func IsAdmin(c *gin.Context) bool {
+	return c.GetString("role") == "admin"
+}
+
 func AdminOnly() gin.HandlerFunc {
 	return func(c *gin.Context) {
-		role := c.GetString("role")
-		if role != "admin" {
+		if !IsAdmin(c) {
 			apperror.ErrForbidden.HTTPError(c)
 			c.Abort()
 			return
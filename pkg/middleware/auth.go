@@ -15,6 +15,26 @@ func JWTRefresh() gin.HandlerFunc {
 	return JWT(jtoken.RefreshTokenType)
 }
 
+// OptionalJWTAuth identifies the caller when a valid access token is present but lets
+// anonymous requests through, for endpoints such as the cart that serve guests too. An
+// invalid token is treated the same as no token.
+func OptionalJWTAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := c.GetHeader("Authorization")
+		if token == "" {
+			c.Next()
+			return
+		}
+
+		payload, err := jtoken.ValidateToken(token)
+		if err == nil && payload != nil && payload["type"] == jtoken.AccessTokenType {
+			c.Set("userId", payload["id"])
+			c.Set("role", payload["role"])
+		}
+		c.Next()
+	}
+}
+
 func JWT(tokenType string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		token := c.GetHeader("Authorization")
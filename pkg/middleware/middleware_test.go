@@ -110,6 +110,46 @@ func TestJWTRefresh(t *testing.T) {
 	assert.Equal(t, http.StatusOK, w.Code)
 }
 
+func TestOptionalJWTAuth(t *testing.T) {
+	config.LoadConfig()
+	payload := map[string]interface{}{"id": "user-1", "email": "test@example.com", "role": "customer"}
+
+	tests := []struct {
+		name   string
+		token  string
+		userID string
+	}{
+		{name: "NoToken", token: "", userID: ""},
+		{name: "ValidToken", token: jtoken.GenerateAccessToken(payload), userID: "user-1"},
+		{name: "InvalidToken", token: "invalid.token.value", userID: ""},
+		{name: "RefreshToken", token: jtoken.GenerateRefreshToken(payload), userID: ""},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			setupGinTest()
+
+			var gotUserID string
+			w := httptest.NewRecorder()
+			_, engine := gin.CreateTestContext(w)
+			engine.Use(OptionalJWTAuth())
+			engine.GET("/test", func(c *gin.Context) {
+				gotUserID = c.GetString("userId")
+				c.Status(http.StatusOK)
+			})
+
+			req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", tc.token)
+			}
+			engine.ServeHTTP(w, req)
+
+			assert.Equal(t, http.StatusOK, w.Code)
+			assert.Equal(t, tc.userID, gotUserID)
+		})
+	}
+}
+
 func TestAdminOnly(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,306 @@
+// Package money is an exact amount of a currency: an integer number of the currency's minor
+// units (cents, yen, fils) and its ISO 4217 code. Sums, discounts and splits stay in whole
+// minor units, so they never drift the way float64 prices do.
+//
+// Amounts of different currencies don't mix: adding or comparing them panics, the same as an
+// out-of-range index. An amount with no currency — one just scanned from a column, before the
+// row's currency is applied — takes the currency of whatever it is combined with.
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+
+	"goshop/pkg/currency"
+)
+
+// Money is an amount in minor units of a currency. The zero value is zero with no currency.
+type Money struct {
+	amount   int64
+	currency string
+}
+
+// New is amount minor units of code.
+func New(amount int64, code string) Money {
+	return Money{amount: amount, currency: currency.Normalize(code)}
+}
+
+// Zero is no money in code.
+func Zero(code string) Money {
+	return New(0, code)
+}
+
+// Parse reads a decimal string in major units ("12.34"), rounding half away from zero past
+// the currency's minor unit.
+func Parse(value, code string) (Money, error) {
+	amount, err := currency.Parse(value, code)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(amount, code), nil
+}
+
+// MustParse is Parse for literals; it panics on error.
+func MustParse(value, code string) Money {
+	m, err := Parse(value, code)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+// FromFloat converts a float amount in major units, as sent by clients of the deprecated
+// float fields. The float is read as the shortest decimal that represents it.
+func FromFloat(amount float64, code string) Money {
+	return New(currency.ToMinor(amount, code), code)
+}
+
+// Amount is the amount in minor units.
+func (m Money) Amount() int64 {
+	return m.amount
+}
+
+// Currency is the ISO 4217 code, or empty when the amount has no currency yet.
+func (m Money) Currency() string {
+	return m.currency
+}
+
+// WithCurrency is m in code. Models use it to apply the row's currency to amounts scanned
+// from minor-unit columns.
+func (m Money) WithCurrency(code string) Money {
+	return New(m.amount, code)
+}
+
+// IsZero reports whether the amount is zero, whatever the currency.
+func (m Money) IsZero() bool {
+	return m.amount == 0
+}
+
+// IsPositive reports whether the amount is above zero.
+func (m Money) IsPositive() bool {
+	return m.amount > 0
+}
+
+// IsNegative reports whether the amount is below zero.
+func (m Money) IsNegative() bool {
+	return m.amount < 0
+}
+
+// Add is m + o.
+func (m Money) Add(o Money) Money {
+	return Money{amount: m.amount + o.amount, currency: m.common(o)}
+}
+
+// Sub is m - o.
+func (m Money) Sub(o Money) Money {
+	return Money{amount: m.amount - o.amount, currency: m.common(o)}
+}
+
+// Mul is m × n, e.g. a unit price times a quantity.
+func (m Money) Mul(n int64) Money {
+	return Money{amount: m.amount * n, currency: m.currency}
+}
+
+// Percent is p of m, rounded half away from zero.
+func (m Money) Percent(p Percent) Money {
+	return m.Prorate(int64(p), int64(Whole))
+}
+
+// Prorate is m × num / den, rounded half away from zero. den must not be zero.
+func (m Money) Prorate(num, den int64) Money {
+	return Money{amount: currency.Prorate(m.amount, num, den), currency: m.currency}
+}
+
+// Allocate splits m in proportion to weights without losing or inventing a minor unit: the
+// parts always sum to m. Units left over after rounding down go to the largest remainders,
+// earliest first. Weights must not be negative; all weights zero splits nothing off.
+func (m Money) Allocate(weights ...int64) []Money {
+	parts := make([]Money, len(weights))
+	total := new(big.Int)
+	for _, w := range weights {
+		total.Add(total, big.NewInt(w))
+	}
+	if total.Sign() == 0 {
+		for i := range parts {
+			parts[i] = Zero(m.currency)
+		}
+		return parts
+	}
+	sign, amount := int64(1), m.amount
+	if amount < 0 {
+		sign, amount = -1, -amount
+	}
+	remainders := make([]*big.Int, len(weights))
+	left := amount
+	for i, w := range weights {
+		share, rem := new(big.Int).QuoRem(new(big.Int).Mul(big.NewInt(amount), big.NewInt(w)), total, new(big.Int))
+		parts[i] = Money{amount: sign * share.Int64(), currency: m.currency}
+		remainders[i] = rem
+		left -= share.Int64()
+	}
+	for ; left > 0; left-- {
+		best := 0
+		for i := range remainders {
+			if remainders[i].Cmp(remainders[best]) > 0 {
+				best = i
+			}
+		}
+		parts[best].amount += sign
+		remainders[best] = big.NewInt(-1)
+	}
+	return parts
+}
+
+// Cmp compares m and o: -1 if m < o, 0 if equal, +1 if m > o.
+func (m Money) Cmp(o Money) int {
+	m.common(o)
+	switch {
+	case m.amount < o.amount:
+		return -1
+	case m.amount > o.amount:
+		return 1
+	}
+	return 0
+}
+
+// Min is the smaller of m and o.
+func (m Money) Min(o Money) Money {
+	if m.Cmp(o) <= 0 {
+		return m.WithCurrency(m.common(o))
+	}
+	return o.WithCurrency(m.common(o))
+}
+
+// Convert is m in another currency at the shop's exchange rates, rounded once.
+func (m Money) Convert(rates *currency.Rates, to string) (Money, error) {
+	amount, err := rates.Convert(m.amount, m.currency, to)
+	if err != nil {
+		return Money{}, err
+	}
+	return New(amount, to), nil
+}
+
+// Decimal renders the amount in major units: "12.34", "1500", "1.250".
+func (m Money) Decimal() string {
+	return currency.Format(m.amount, m.currency)
+}
+
+// Float64 is the amount in major units, for the deprecated float fields.
+func (m Money) Float64() float64 {
+	return currency.FromMinor(m.amount, m.currency)
+}
+
+// String is the amount and its currency: "12.34 USD".
+func (m Money) String() string {
+	if m.currency == "" {
+		return m.Decimal()
+	}
+	return m.Decimal() + " " + m.currency
+}
+
+// common is the currency of m combined with o. It panics when they differ.
+func (m Money) common(o Money) string {
+	switch {
+	case m.currency == "":
+		return o.currency
+	case o.currency == "" || o.currency == m.currency:
+		return m.currency
+	}
+	panic(fmt.Sprintf("money: %s and %s don't mix", m, o))
+}
+
+type jsonMoney struct {
+	Amount   json.RawMessage `json:"amount"`
+	Currency string          `json:"currency"`
+}
+
+// MarshalJSON writes {"amount":"12.34","currency":"USD"}. The amount is a decimal string so
+// no client reads it back through a float.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Amount   string `json:"amount"`
+		Currency string `json:"currency"`
+	}{m.Decimal(), m.currency})
+}
+
+// UnmarshalJSON reads what MarshalJSON writes; the amount may also be a JSON number. A
+// non-zero amount needs a currency, since the currency decides where the minor unit is.
+//
+// A bare JSON number, as event payloads carried before amounts had a currency, is read as
+// major units to two decimal places with no currency.
+func (m *Money) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if bytes.Equal(data, []byte("null")) {
+		*m = Money{}
+		return nil
+	}
+	if len(data) > 0 && (data[0] == '-' || (data[0] >= '0' && data[0] <= '9')) {
+		amount, err := currency.Parse(string(data), "")
+		if err != nil {
+			return fmt.Errorf("money: invalid amount %s", data)
+		}
+		*m = Money{amount: amount}
+		return nil
+	}
+	var v jsonMoney
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("money: %w", err)
+	}
+	value := string(bytes.TrimSpace(v.Amount))
+	if value == "" || value == "null" {
+		value = "0"
+	}
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	amount, err := currency.Parse(value, v.Currency)
+	if err != nil {
+		return fmt.Errorf("money: invalid amount %s", v.Amount)
+	}
+	if amount != 0 && !currency.Valid(v.Currency) {
+		return errors.New("money: amount needs a three-letter currency")
+	}
+	*m = New(amount, v.Currency)
+	return nil
+}
+
+// Value stores the minor units; the currency lives in its own column.
+func (m Money) Value() (driver.Value, error) {
+	return m.amount, nil
+}
+
+// Scan reads minor units. The amount has no currency until the model applies its row's.
+func (m *Money) Scan(src any) error {
+	var amount int64
+	switch v := src.(type) {
+	case nil:
+	case int64:
+		amount = v
+	case []byte:
+		n, err := strconv.ParseInt(string(v), 10, 64)
+		if err != nil {
+			return fmt.Errorf("money: scan %q: %w", v, err)
+		}
+		amount = n
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return fmt.Errorf("money: scan %q: %w", v, err)
+		}
+		amount = n
+	default:
+		return fmt.Errorf("money: cannot scan %T", src)
+	}
+	*m = Money{amount: amount, currency: m.currency}
+	return nil
+}
+
+// GormDataType is the column type of a Money field.
+func (Money) GormDataType() string {
+	return "bigint"
+}
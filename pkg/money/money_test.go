@@ -0,0 +1,203 @@
+package money
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/stretchr/testify/require"
+
+	"goshop/pkg/currency"
+)
+
+func TestParseAndDecimal(t *testing.T) {
+	tests := []struct {
+		value, code string
+		amount      int64
+		decimal     string
+	}{
+		{"12.34", "usd", 1234, "12.34"},
+		{"0.29", "USD", 29, "0.29"},
+		{"1500", "JPY", 1500, "1500"},
+		{"1.25", "KWD", 1250, "1.250"},
+		{"-2.5", "EUR", -250, "-2.50"},
+		{"0.125", "USD", 13, "0.13"},
+	}
+	for _, tt := range tests {
+		m, err := Parse(tt.value, tt.code)
+		require.NoError(t, err)
+		require.Equal(t, tt.amount, m.Amount())
+		require.Equal(t, currency.Normalize(tt.code), m.Currency())
+		require.Equal(t, tt.decimal, m.Decimal())
+	}
+	_, err := Parse("abc", "USD")
+	require.Error(t, err)
+	require.Equal(t, "12.34 USD", New(1234, "usd").String())
+}
+
+func TestArithmetic(t *testing.T) {
+	a, b := New(1099, "USD"), New(201, "USD")
+	require.Equal(t, New(1300, "USD"), a.Add(b))
+	require.Equal(t, New(898, "USD"), a.Sub(b))
+	require.Equal(t, New(3297, "USD"), a.Mul(3))
+	require.Equal(t, -1, b.Cmp(a))
+	require.Equal(t, b, a.Min(b))
+	require.Equal(t, New(165, "USD"), a.Percent(1500)) // 15% of 10.99 = 1.6485
+	require.Equal(t, New(366, "USD"), a.Prorate(1, 3)) // 3.663…
+	require.Equal(t, New(1099, "USD"), Money{}.Add(a)) // no currency takes the other's
+	require.Equal(t, New(5, "USD"), New(5, "").Min(a)) // scanned amount, currency applied
+	require.True(t, Zero("USD").IsZero())
+	require.True(t, b.IsPositive())
+	require.True(t, b.Sub(a).IsNegative())
+	require.Panics(t, func() { a.Add(New(1, "EUR")) })
+	require.Panics(t, func() { a.Cmp(New(1, "EUR")) })
+}
+
+func TestAllocate(t *testing.T) {
+	require.Equal(t, []Money{New(34, "USD"), New(33, "USD"), New(33, "USD")}, New(100, "USD").Allocate(1, 1, 1))
+	require.Equal(t, []Money{New(-34, "USD"), New(-33, "USD"), New(-33, "USD")}, New(-100, "USD").Allocate(1, 1, 1))
+	require.Equal(t, []Money{New(250, "JPY"), New(750, "JPY")}, New(1000, "JPY").Allocate(1000, 3000))
+	require.Equal(t, []Money{Zero("USD"), Zero("USD")}, New(100, "USD").Allocate(0, 0))
+}
+
+func TestConvert(t *testing.T) {
+	rates := currency.MustParseRates("USD", "JPY:151.5")
+	got, err := New(1999, "USD").Convert(rates, "jpy")
+	require.NoError(t, err)
+	require.Equal(t, New(3028, "JPY"), got)
+
+	_, err = New(1999, "USD").Convert(rates, "EUR")
+	require.ErrorIs(t, err, currency.ErrUnsupported)
+}
+
+func TestJSON(t *testing.T) {
+	data, err := json.Marshal(New(1250, "KWD"))
+	require.NoError(t, err)
+	require.JSONEq(t, `{"amount":"1.250","currency":"KWD"}`, string(data))
+
+	var m Money
+	require.NoError(t, json.Unmarshal(data, &m))
+	require.Equal(t, New(1250, "KWD"), m)
+	require.NoError(t, json.Unmarshal([]byte(`{"amount":19.99,"currency":"usd"}`), &m))
+	require.Equal(t, New(1999, "USD"), m)
+	require.NoError(t, json.Unmarshal([]byte(`null`), &m))
+	require.Equal(t, Money{}, m)
+
+	require.NoError(t, json.Unmarshal([]byte(`12.5`), &m), "legacy bare number")
+	require.Equal(t, int64(1250), m.Amount())
+	require.Empty(t, m.Currency())
+
+	require.Error(t, json.Unmarshal([]byte(`{"amount":"12.34"}`), &m), "currency required")
+	require.Error(t, json.Unmarshal([]byte(`"12.34"`), &m), "bare strings are not amounts")
+	require.Error(t, json.Unmarshal([]byte(`{"amount":"x","currency":"USD"}`), &m))
+
+	var omitted struct {
+		Discount Money `json:"discount,omitzero"`
+	}
+	data, err = json.Marshal(omitted)
+	require.NoError(t, err)
+	require.JSONEq(t, `{}`, string(data))
+}
+
+func TestScanAndValue(t *testing.T) {
+	var m Money
+	require.NoError(t, m.Scan(int64(1234)))
+	require.Equal(t, int64(1234), m.Amount())
+	require.Empty(t, m.Currency())
+	require.Equal(t, New(1234, "EUR"), m.WithCurrency("eur"))
+	require.NoError(t, m.Scan([]byte("-5")))
+	require.Equal(t, int64(-5), m.Amount())
+	require.NoError(t, m.Scan(nil))
+	require.Zero(t, m.Amount())
+	require.Error(t, m.Scan(1.5))
+
+	v, err := New(1234, "USD").Value()
+	require.NoError(t, err)
+	require.Equal(t, int64(1234), v)
+}
+
+// The properties below are what float64 prices broke: totals that don't match their lines,
+// discounts that leave the final price a cent off, and refunds that add up to more than was
+// paid.
+
+var quickConfig = &quick.Config{MaxCount: 2000}
+
+// price is a random unit price up to 10,000.00, in cents.
+func price(r *rand.Rand) Money {
+	return New(r.Int63n(1_000_000), "USD")
+}
+
+func TestProperty_LineTotalsSumExactly(t *testing.T) {
+	f := func(seed int64) bool {
+		r := rand.New(rand.NewSource(seed))
+		var total Money
+		var cents int64
+		for range r.Intn(20) + 1 {
+			unit, qty := price(r), r.Int63n(50)+1
+			line := unit.Mul(qty)
+			total = total.Add(line)
+			cents += unit.Amount() * qty
+			// A line read back through its JSON decimal is the same line.
+			data, _ := json.Marshal(line)
+			var back Money
+			if json.Unmarshal(data, &back) != nil || back != line {
+				return false
+			}
+		}
+		return total.Amount() == cents
+	}
+	require.NoError(t, quick.Check(f, quickConfig))
+}
+
+func TestProperty_DiscountNeverDrifts(t *testing.T) {
+	f := func(seed int64) bool {
+		r := rand.New(rand.NewSource(seed))
+		total := price(r)
+		discount := total.Percent(Percent(r.Int63n(10_001))) // 0.00% to 100.00%
+		final := total.Sub(discount)
+		return !discount.IsNegative() && discount.Cmp(total) <= 0 &&
+			!final.IsNegative() && final.Add(discount) == total
+	}
+	require.NoError(t, quick.Check(f, quickConfig))
+}
+
+func TestProperty_AllocateSumsToWhole(t *testing.T) {
+	f := func(seed int64) bool {
+		r := rand.New(rand.NewSource(seed))
+		whole := price(r)
+		if r.Intn(2) == 0 {
+			whole = whole.Mul(-1)
+		}
+		weights := make([]int64, r.Intn(10)+1)
+		var sumWeights int64
+		for i := range weights {
+			weights[i] = price(r).Amount()
+			sumWeights += weights[i]
+		}
+		parts := whole.Allocate(weights...)
+		var sum Money
+		for i, p := range parts {
+			sum = sum.Add(p)
+			// Each part is within one minor unit of its exact share.
+			if sumWeights > 0 {
+				exact := whole.Prorate(weights[i], sumWeights).Amount()
+				if d := p.Amount() - exact; d > 1 || d < -1 {
+					return false
+				}
+			}
+		}
+		return sumWeights == 0 || sum == whole
+	}
+	require.NoError(t, quick.Check(f, quickConfig))
+}
+
+func TestProperty_FloatRoundTrip(t *testing.T) {
+	// Legacy float values convert to the cent they were meant to be.
+	f := func(cents int64) bool {
+		cents %= 1_000_000_000_000
+		m := New(cents, "USD")
+		return FromFloat(m.Float64(), "USD") == m
+	}
+	require.NoError(t, quick.Check(f, quickConfig))
+}
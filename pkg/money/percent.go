@@ -0,0 +1,102 @@
+package money
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// percentScale is how many Percent units make one percent.
+const percentScale = 100
+
+// Percent is a percentage to two decimal places, kept exactly as hundredths of a percent:
+// 1250 is 12.5%.
+type Percent int64
+
+// Whole is 100%.
+const Whole Percent = 100 * percentScale
+
+// ParsePercent reads a decimal percentage such as "12.5". More than two decimal places is an
+// error rather than a silent rounding.
+func ParsePercent(value string) (Percent, error) {
+	r, ok := new(big.Rat).SetString(strings.TrimSpace(value))
+	if !ok {
+		return 0, fmt.Errorf("money: invalid percentage %q", value)
+	}
+	r.Mul(r, big.NewRat(percentScale, 1))
+	if !r.IsInt() || !r.Num().IsInt64() {
+		return 0, fmt.Errorf("money: percentage %q has more than two decimal places", value)
+	}
+	return Percent(r.Num().Int64()), nil
+}
+
+// String renders the percentage without trailing zeros: "12.5", "10".
+func (p Percent) String() string {
+	s := new(big.Rat).SetFrac64(int64(p), percentScale).FloatString(2)
+	if strings.Contains(s, ".") {
+		s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	}
+	return s
+}
+
+// MarshalJSON writes the percentage as a JSON number: 12.5.
+func (p Percent) MarshalJSON() ([]byte, error) {
+	return []byte(p.String()), nil
+}
+
+// UnmarshalJSON reads a JSON number or a decimal string.
+func (p *Percent) UnmarshalJSON(data []byte) error {
+	value := string(bytes.TrimSpace(data))
+	if value == "null" {
+		*p = 0
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	parsed, err := ParsePercent(value)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// Value stores the percentage as a decimal string for a numeric column.
+func (p Percent) Value() (driver.Value, error) {
+	return p.String(), nil
+}
+
+// Scan reads a numeric column.
+func (p *Percent) Scan(src any) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		*p = 0
+		return nil
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	case int64:
+		value = strconv.FormatInt(v, 10)
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("money: cannot scan %T into a percentage", src)
+	}
+	parsed, err := ParsePercent(value)
+	if err != nil {
+		return err
+	}
+	*p = parsed
+	return nil
+}
+
+// GormDataType is the column type of a Percent field.
+func (Percent) GormDataType() string {
+	return "numeric"
+}
@@ -0,0 +1,53 @@
+package money
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePercent(t *testing.T) {
+	tests := []struct {
+		value string
+		want  Percent
+		str   string
+	}{
+		{"12.5", 1250, "12.5"},
+		{"10", 1000, "10"},
+		{"0.01", 1, "0.01"},
+		{"100.00", 10000, "100"},
+	}
+	for _, tt := range tests {
+		p, err := ParsePercent(tt.value)
+		require.NoError(t, err)
+		require.Equal(t, tt.want, p)
+		require.Equal(t, tt.str, p.String())
+	}
+	_, err := ParsePercent("12.345")
+	require.Error(t, err)
+	_, err = ParsePercent("ten")
+	require.Error(t, err)
+}
+
+func TestPercentJSONAndSQL(t *testing.T) {
+	data, err := json.Marshal(Percent(1250))
+	require.NoError(t, err)
+	require.Equal(t, "12.5", string(data))
+
+	var p Percent
+	require.NoError(t, json.Unmarshal([]byte(`15`), &p))
+	require.Equal(t, Percent(1500), p)
+	require.NoError(t, json.Unmarshal([]byte(`"7.25"`), &p))
+	require.Equal(t, Percent(725), p)
+	require.Error(t, json.Unmarshal([]byte(`7.255`), &p))
+
+	v, err := Percent(1250).Value()
+	require.NoError(t, err)
+	require.Equal(t, "12.5", v)
+	require.NoError(t, p.Scan([]byte("12.50")))
+	require.Equal(t, Percent(1250), p)
+	require.NoError(t, p.Scan(nil))
+	require.Zero(t, p)
+	require.Error(t, p.Scan(true))
+}
@@ -3,17 +3,28 @@ package notification
 import (
 	"bytes"
 	"context"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"mime"
 	"net/smtp"
 	"strings"
 	"text/template"
+
+	"github.com/google/uuid"
 )
 
 // EmailSender is the minimal contract an email transport must satisfy. Real implementations
 // dial an SMTP server; tests can supply an in-memory fake to capture sent messages.
 type EmailSender interface {
-	Send(ctx context.Context, to, subject, body string) error
+	Send(ctx context.Context, to, subject, body string, attachments ...Attachment) error
+}
+
+// Attachment is a file sent along with an email, e.g. an invoice PDF.
+type Attachment struct {
+	Filename    string
+	ContentType string
+	Data        []byte
 }
 
 // SMTPConfig is the dial information for plain SMTP/STARTTLS. Auth is optional — leave User
@@ -37,12 +48,12 @@ func NewSMTPSender(cfg SMTPConfig) EmailSender {
 // Send dispatches one message synchronously. Callers that need fan-out, retries, or async
 // delivery should wrap this in a higher-level worker; this is intentionally bare-bones so it
 // can be exercised against a real MTA.
-func (s *smtpSender) Send(ctx context.Context, to, subject, body string) error {
+func (s *smtpSender) Send(ctx context.Context, to, subject, body string, attachments ...Attachment) error {
 	if s.cfg.Host == "" || s.cfg.From == "" {
 		return errors.New("smtp not configured")
 	}
 	addr := fmt.Sprintf("%s:%d", s.cfg.Host, s.cfg.Port)
-	msg := buildRFC5322Message(s.cfg.From, to, subject, body)
+	msg := buildRFC5322Message(s.cfg.From, to, subject, body, attachments...)
 
 	var auth smtp.Auth
 	if s.cfg.User != "" {
@@ -55,15 +66,44 @@ func (s *smtpSender) Send(ctx context.Context, to, subject, body string) error {
 	return smtp.SendMail(addr, auth, s.cfg.From, []string{to}, msg)
 }
 
-func buildRFC5322Message(from, to, subject, body string) []byte {
+// buildRFC5322Message lays out a plain-text message, as multipart/mixed when it carries
+// attachments.
+func buildRFC5322Message(from, to, subject, body string, attachments ...Attachment) []byte {
 	var b bytes.Buffer
 	fmt.Fprintf(&b, "From: %s\r\n", from)
 	fmt.Fprintf(&b, "To: %s\r\n", to)
 	fmt.Fprintf(&b, "Subject: %s\r\n", subject)
 	b.WriteString("MIME-Version: 1.0\r\n")
+	if len(attachments) == 0 {
+		b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+		b.WriteString("\r\n")
+		b.WriteString(body)
+		return b.Bytes()
+	}
+
+	boundary := "goshop-" + uuid.New().String()
+	fmt.Fprintf(&b, "Content-Type: multipart/mixed; boundary=%q\r\n", boundary)
+	b.WriteString("\r\n")
+	fmt.Fprintf(&b, "--%s\r\n", boundary)
 	b.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
 	b.WriteString("\r\n")
 	b.WriteString(body)
+	b.WriteString("\r\n")
+	for _, a := range attachments {
+		fmt.Fprintf(&b, "--%s\r\n", boundary)
+		fmt.Fprintf(&b, "Content-Type: %s\r\n", a.ContentType)
+		b.WriteString("Content-Transfer-Encoding: base64\r\n")
+		fmt.Fprintf(&b, "Content-Disposition: %s\r\n", mime.FormatMediaType("attachment", map[string]string{"filename": a.Filename}))
+		b.WriteString("\r\n")
+		// RFC 2045 caps encoded lines at 76 characters.
+		encoded := base64.StdEncoding.EncodeToString(a.Data)
+		for len(encoded) > 76 {
+			b.WriteString(encoded[:76] + "\r\n")
+			encoded = encoded[76:]
+		}
+		b.WriteString(encoded + "\r\n")
+	}
+	fmt.Fprintf(&b, "--%s--\r\n", boundary)
 	return b.Bytes()
 }
 
@@ -81,6 +121,26 @@ var emailTemplates = map[string]struct {
 		Subject: "Order #{{.OrderID}}: now {{.Status}}",
 		Body:    "Hi,\n\nYour order {{.OrderID}} is now in status: {{.Status}}.\n\nThanks,\nGoShop",
 	},
+	"order_paid": {
+		Subject: "Order #{{.OrderID}}: payment received",
+		Body:    "Hi,\n\nWe've received your payment for order {{.OrderID}} and are getting it ready.{{if .Invoice}} Your invoice {{.Invoice}} is attached.{{end}}\n\nThanks,\nGoShop",
+	},
+	"order_invoiced": {
+		Subject: "Order #{{.OrderID}}: your invoice {{.Invoice}}",
+		Body:    "Hi,\n\nYour invoice {{.Invoice}} for order {{.OrderID}} is attached.\n\nThanks,\nGoShop",
+	},
+	"abandoned_cart": {
+		Subject: "You left {{.ItemCount}} item(s) in your cart",
+		Body:    "Hi,\n\nYour cart is still waiting for you with {{.ItemCount}} item(s). Prices and availability can change, so check out soon.\n\nThanks,\nGoShop",
+	},
+	"low_stock": {
+		Subject: "Low stock: {{if .ProductName}}{{.ProductName}}{{else}}{{.ProductID}}{{end}} ({{.Available}} left)",
+		Body:    "Hi,\n\nProduct {{.ProductName}} ({{.ProductID}}) has {{.Available}} unit(s) available, at or below its low-stock threshold of {{.Threshold}}. {{if .Reorder}}Its reorder point is {{.Reorder}} unit(s).{{else}}Consider restocking it.{{end}}\n\nGoShop",
+	},
+	"shipment_update": {
+		Subject: "Order #{{.OrderID}}: a parcel is {{.Status}}",
+		Body:    "Hi,\n\nA parcel from your order {{.OrderID}} is now {{.Status}}.{{if .TrackingNumber}} {{if .Carrier}}{{.Carrier}} tracking{{else}}Tracking{{end}} number: {{.TrackingNumber}}.{{end}}\n\nThanks,\nGoShop",
+	},
 }
 
 func renderTemplate(name string, data any) (subject, body string, err error) {
@@ -2,6 +2,7 @@ package notification
 
 import (
 	"context"
+	"encoding/base64"
 	"strings"
 	"testing"
 
@@ -38,6 +39,20 @@ func TestBuildRFC5322Message_Format(t *testing.T) {
 	assert.True(t, strings.HasSuffix(msg, "hello"))
 }
 
+func TestBuildRFC5322Message_Attachments(t *testing.T) {
+	data := []byte(strings.Repeat("%PDF-1.4 ", 20))
+	msg := string(buildRFC5322Message("from@x", "to@y", "subj", "hello",
+		Attachment{Filename: "INV-2026-000001.pdf", ContentType: "application/pdf", Data: data}))
+	assert.Contains(t, msg, "Content-Type: multipart/mixed; boundary=")
+	assert.Contains(t, msg, "Content-Type: text/plain; charset=UTF-8\r\n\r\nhello\r\n")
+	assert.Contains(t, msg, "Content-Disposition: attachment; filename=INV-2026-000001.pdf\r\n")
+	assert.Contains(t, msg, "Content-Transfer-Encoding: base64\r\n")
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	assert.Contains(t, msg, encoded[:76]+"\r\n"+encoded[76:152]+"\r\n")
+	assert.True(t, strings.HasSuffix(msg, "--\r\n"))
+}
+
 func TestRenderTemplate_UnknownTemplate(t *testing.T) {
 	_, _, err := renderTemplate("does_not_exist", nil)
 	require.Error(t, err)
@@ -2,6 +2,9 @@ package notification
 
 import (
 	"context"
+	"path"
+	"strconv"
+	"strings"
 
 	"github.com/quangdangfit/gocommon/logger"
 )
@@ -24,8 +27,13 @@ func (AlwaysOnPreferences) IsEnabled(_ context.Context, _, _, _ string) (bool, e
 const (
 	channelEmail = "email"
 
-	eventOrderPlaced  = "order_placed"
-	eventOrderChanged = "order_status_changed"
+	eventOrderPlaced   = "order_placed"
+	eventOrderChanged  = "order_status_changed"
+	eventOrderPaid     = "order_paid"
+	eventOrderInvoiced = "order_invoiced"
+	eventAbandonedCart = "abandoned_cart"
+	eventLowStock      = "low_stock"
+	eventShipment      = "shipment_update"
 )
 
 type emailNotifier struct {
@@ -50,7 +58,64 @@ func (n *emailNotifier) SendOrderStatusChanged(ctx context.Context, orderID, use
 	return n.send(ctx, eventOrderChanged, userEmail, map[string]string{"OrderID": orderID, "Status": newStatus})
 }
 
+// SendOrderPaid honors the buyer's order_status_changed preference: it is the paid status
+// change, with the invoice attached.
+func (n *emailNotifier) SendOrderPaid(ctx context.Context, orderID, userEmail string, invoice *Attachment) error {
+	data := map[string]string{"OrderID": orderID}
+	var attachments []Attachment
+	if invoice != nil {
+		data["Invoice"] = strings.TrimSuffix(invoice.Filename, path.Ext(invoice.Filename))
+		attachments = append(attachments, *invoice)
+	}
+	return n.sendAs(ctx, eventOrderChanged, eventOrderPaid, userEmail, data, attachments...)
+}
+
+// SendInvoice honors the buyer's order_status_changed preference, like SendOrderPaid.
+func (n *emailNotifier) SendInvoice(ctx context.Context, orderID, userEmail string, invoice Attachment) error {
+	data := map[string]string{
+		"OrderID": orderID,
+		"Invoice": strings.TrimSuffix(invoice.Filename, path.Ext(invoice.Filename)),
+	}
+	return n.sendAs(ctx, eventOrderChanged, eventOrderInvoiced, userEmail, data, invoice)
+}
+
+func (n *emailNotifier) SendAbandonedCart(ctx context.Context, cartID, userEmail string, itemCount int) error {
+	return n.send(ctx, eventAbandonedCart, userEmail, map[string]string{"CartID": cartID, "ItemCount": strconv.Itoa(itemCount)})
+}
+
+func (n *emailNotifier) SendLowStock(ctx context.Context, productID, adminEmail, productName string, available, threshold, reorderQuantity int) error {
+	return n.send(ctx, eventLowStock, adminEmail, map[string]string{
+		"ProductID":   productID,
+		"ProductName": productName,
+		"Available":   strconv.Itoa(available),
+		"Threshold":   strconv.Itoa(threshold),
+		"Reorder":     reorderText(reorderQuantity),
+	})
+}
+
+func (n *emailNotifier) SendShipmentUpdate(ctx context.Context, orderID, userEmail, status, carrier, trackingNumber string) error {
+	return n.send(ctx, eventShipment, userEmail, map[string]string{
+		"OrderID":        orderID,
+		"Status":         status,
+		"Carrier":        carrier,
+		"TrackingNumber": trackingNumber,
+	})
+}
+
+// reorderText renders the reorder quantity for templates, empty when none is configured.
+func reorderText(qty int) string {
+	if qty <= 0 {
+		return ""
+	}
+	return strconv.Itoa(qty)
+}
+
 func (n *emailNotifier) send(ctx context.Context, event, userEmail string, data map[string]string) error {
+	return n.sendAs(ctx, event, event, userEmail, data)
+}
+
+// sendAs renders the named template for an email the user controls through event's preference.
+func (n *emailNotifier) sendAs(ctx context.Context, event, tmpl, userEmail string, data map[string]string, attachments ...Attachment) error {
 	enabled, err := n.prefs.IsEnabled(ctx, userEmail, event, channelEmail)
 	if err != nil {
 		// Don't block delivery on a preferences read failure — log and proceed.
@@ -59,11 +124,11 @@ func (n *emailNotifier) send(ctx context.Context, event, userEmail string, data
 	if err == nil && !enabled {
 		return nil
 	}
-	subject, body, err := renderTemplate(event, data)
+	subject, body, err := renderTemplate(tmpl, data)
 	if err != nil {
 		return err
 	}
-	return n.sender.Send(ctx, userEmail, subject, body)
+	return n.sender.Send(ctx, userEmail, subject, body, attachments...)
 }
 
 // MultiNotifier fans an event out to several Notifier implementations (e.g. logger + email).
@@ -93,3 +158,48 @@ func (m *MultiNotifier) SendOrderStatusChanged(ctx context.Context, orderID, use
 	}
 	return nil
 }
+
+func (m *MultiNotifier) SendOrderPaid(ctx context.Context, orderID, userEmail string, invoice *Attachment) error {
+	for _, c := range m.children {
+		if err := c.SendOrderPaid(ctx, orderID, userEmail, invoice); err != nil {
+			logger.Warnf("notifier child failed (order_paid): %s", err)
+		}
+	}
+	return nil
+}
+
+func (m *MultiNotifier) SendInvoice(ctx context.Context, orderID, userEmail string, invoice Attachment) error {
+	for _, c := range m.children {
+		if err := c.SendInvoice(ctx, orderID, userEmail, invoice); err != nil {
+			logger.Warnf("notifier child failed (order_invoiced): %s", err)
+		}
+	}
+	return nil
+}
+
+func (m *MultiNotifier) SendAbandonedCart(ctx context.Context, cartID, userEmail string, itemCount int) error {
+	for _, c := range m.children {
+		if err := c.SendAbandonedCart(ctx, cartID, userEmail, itemCount); err != nil {
+			logger.Warnf("notifier child failed (abandoned_cart): %s", err)
+		}
+	}
+	return nil
+}
+
+func (m *MultiNotifier) SendLowStock(ctx context.Context, productID, adminEmail, productName string, available, threshold, reorderQuantity int) error {
+	for _, c := range m.children {
+		if err := c.SendLowStock(ctx, productID, adminEmail, productName, available, threshold, reorderQuantity); err != nil {
+			logger.Warnf("notifier child failed (low_stock): %s", err)
+		}
+	}
+	return nil
+}
+
+func (m *MultiNotifier) SendShipmentUpdate(ctx context.Context, orderID, userEmail, status, carrier, trackingNumber string) error {
+	for _, c := range m.children {
+		if err := c.SendShipmentUpdate(ctx, orderID, userEmail, status, carrier, trackingNumber); err != nil {
+			logger.Warnf("notifier child failed (shipment_update): %s", err)
+		}
+	}
+	return nil
+}
@@ -10,13 +10,14 @@ import (
 
 type fakeSender struct {
 	to, subject, body string
+	attachments       []Attachment
 	err               error
 	called            int
 }
 
-func (f *fakeSender) Send(_ context.Context, to, subject, body string) error {
+func (f *fakeSender) Send(_ context.Context, to, subject, body string, attachments ...Attachment) error {
 	f.called++
-	f.to, f.subject, f.body = to, subject, body
+	f.to, f.subject, f.body, f.attachments = to, subject, body, attachments
 	return f.err
 }
 
@@ -53,6 +54,63 @@ func TestEmailNotifier_PreferenceLookupError_StillSends(t *testing.T) {
 	require.Equal(t, 1, sender.called)
 }
 
+func TestEmailNotifier_AbandonedCart(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewEmailNotifier(sender, AlwaysOnPreferences{})
+	require.NoError(t, n.SendAbandonedCart(context.Background(), "cart_1", "u@e.com", 3))
+	require.Equal(t, "u@e.com", sender.to)
+	require.Equal(t, "You left 3 item(s) in your cart", sender.subject)
+}
+
+func TestEmailNotifier_AbandonedCart_PreferenceDisabled_Skips(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewEmailNotifier(sender, stubPrefs{enabled: false})
+	require.NoError(t, n.SendAbandonedCart(context.Background(), "cart_1", "u@e.com", 3))
+	require.Equal(t, 0, sender.called)
+}
+
+func TestEmailNotifier_LowStock(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewEmailNotifier(sender, AlwaysOnPreferences{})
+	require.NoError(t, n.SendLowStock(context.Background(), "p1", "admin@e.com", "Mug", 2, 5, 0))
+	require.Equal(t, "admin@e.com", sender.to)
+	require.Equal(t, "Low stock: Mug (2 left)", sender.subject)
+	require.Contains(t, sender.body, "Consider restocking")
+}
+
+func TestEmailNotifier_LowStock_ReorderPoint(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewEmailNotifier(sender, AlwaysOnPreferences{})
+	require.NoError(t, n.SendLowStock(context.Background(), "p1", "admin@e.com", "Mug", 2, 5, 40))
+	require.Contains(t, sender.body, "reorder point is 40 unit(s)")
+}
+
+func TestEmailNotifier_LowStock_PreferenceDisabled_Skips(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewEmailNotifier(sender, stubPrefs{enabled: false})
+	require.NoError(t, n.SendLowStock(context.Background(), "p1", "admin@e.com", "Mug", 2, 5, 0))
+	require.Equal(t, 0, sender.called)
+}
+
+func TestEmailNotifier_ShipmentUpdate(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewEmailNotifier(sender, AlwaysOnPreferences{})
+	require.NoError(t, n.SendShipmentUpdate(context.Background(), "ord_1", "u@e.com", "shipped", "DHL", "JD0001"))
+	require.Equal(t, "u@e.com", sender.to)
+	require.Equal(t, "Order #ord_1: a parcel is shipped", sender.subject)
+	require.Contains(t, sender.body, "DHL tracking number: JD0001")
+
+	require.NoError(t, n.SendShipmentUpdate(context.Background(), "ord_1", "u@e.com", "packed", "", ""))
+	require.NotContains(t, sender.body, "racking")
+}
+
+func TestEmailNotifier_ShipmentUpdate_PreferenceDisabled_Skips(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewEmailNotifier(sender, stubPrefs{enabled: false})
+	require.NoError(t, n.SendShipmentUpdate(context.Background(), "ord_1", "u@e.com", "shipped", "DHL", "JD0001"))
+	require.Equal(t, 0, sender.called)
+}
+
 func TestEmailNotifier_StatusChanged(t *testing.T) {
 	sender := &fakeSender{}
 	n := NewEmailNotifier(sender, AlwaysOnPreferences{})
@@ -61,6 +119,44 @@ func TestEmailNotifier_StatusChanged(t *testing.T) {
 	require.Contains(t, sender.body, "paid")
 }
 
+func TestEmailNotifier_OrderPaid(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewEmailNotifier(sender, AlwaysOnPreferences{})
+	invoice := &Attachment{Filename: "INV-2026-000001.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4")}
+	require.NoError(t, n.SendOrderPaid(context.Background(), "ord_1", "u@e.com", invoice))
+	require.Equal(t, "Order #ord_1: payment received", sender.subject)
+	require.Contains(t, sender.body, "Your invoice INV-2026-000001 is attached.")
+	require.Equal(t, []Attachment{*invoice}, sender.attachments)
+
+	require.NoError(t, n.SendOrderPaid(context.Background(), "ord_1", "u@e.com", nil))
+	require.NotContains(t, sender.body, "invoice")
+	require.Empty(t, sender.attachments)
+}
+
+func TestEmailNotifier_Invoice(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewEmailNotifier(sender, AlwaysOnPreferences{})
+	invoice := Attachment{Filename: "INV-2026-000001.pdf", ContentType: "application/pdf", Data: []byte("%PDF-1.4")}
+	require.NoError(t, n.SendInvoice(context.Background(), "ord_1", "u@e.com", invoice))
+	require.Equal(t, "Order #ord_1: your invoice INV-2026-000001", sender.subject)
+	require.Contains(t, sender.body, "Your invoice INV-2026-000001 for order ord_1 is attached.")
+	require.Equal(t, []Attachment{invoice}, sender.attachments)
+}
+
+func TestEmailNotifier_OrderPaid_StatusPreferenceDisabled_Skips(t *testing.T) {
+	sender := &fakeSender{}
+	n := NewEmailNotifier(sender, eventPrefs{disabled: "order_status_changed"})
+	require.NoError(t, n.SendOrderPaid(context.Background(), "ord_1", "u@e.com", nil))
+	require.Equal(t, 0, sender.called)
+}
+
+// eventPrefs disables one event type.
+type eventPrefs struct{ disabled string }
+
+func (p eventPrefs) IsEnabled(_ context.Context, _, eventType, _ string) (bool, error) {
+	return eventType != p.disabled, nil
+}
+
 func TestMultiNotifier_FansOut(t *testing.T) {
 	a := &fakeSender{}
 	b := &fakeSender{}
@@ -116,6 +212,31 @@ func (a *alwaysFailingNotifier) SendOrderStatusChanged(_ context.Context, _, _,
 	return errors.New("boom")
 }
 
+func (a *alwaysFailingNotifier) SendOrderPaid(_ context.Context, _, _ string, _ *Attachment) error {
+	a.calls++
+	return errors.New("boom")
+}
+
+func (a *alwaysFailingNotifier) SendInvoice(_ context.Context, _, _ string, _ Attachment) error {
+	a.calls++
+	return errors.New("boom")
+}
+
+func (a *alwaysFailingNotifier) SendAbandonedCart(_ context.Context, _, _ string, _ int) error {
+	a.calls++
+	return errors.New("boom")
+}
+
+func (a *alwaysFailingNotifier) SendLowStock(_ context.Context, _, _, _ string, _, _, _ int) error {
+	a.calls++
+	return errors.New("boom")
+}
+
+func (a *alwaysFailingNotifier) SendShipmentUpdate(_ context.Context, _, _, _, _, _ string) error {
+	a.calls++
+	return errors.New("boom")
+}
+
 func TestMultiNotifier_StatusChanged_LogsButDoesNotShortCircuit(t *testing.T) {
 	bad := &alwaysFailingNotifier{}
 	good := &fakeSender{}
@@ -133,3 +254,48 @@ func TestMultiNotifier_OrderPlaced_LogsButDoesNotShortCircuit(t *testing.T) {
 	require.Equal(t, 1, bad.calls)
 	require.Equal(t, 1, good.called)
 }
+
+func TestMultiNotifier_OrderPaid_LogsButDoesNotShortCircuit(t *testing.T) {
+	bad := &alwaysFailingNotifier{}
+	good := &fakeSender{}
+	m := NewMultiNotifier(bad, NewEmailNotifier(good, AlwaysOnPreferences{}))
+	require.NoError(t, m.SendOrderPaid(context.Background(), "o", "u@e.com", nil))
+	require.Equal(t, 1, bad.calls)
+	require.Equal(t, 1, good.called)
+}
+
+func TestMultiNotifier_Invoice_LogsButDoesNotShortCircuit(t *testing.T) {
+	bad := &alwaysFailingNotifier{}
+	good := &fakeSender{}
+	m := NewMultiNotifier(bad, NewEmailNotifier(good, AlwaysOnPreferences{}))
+	require.NoError(t, m.SendInvoice(context.Background(), "o", "u@e.com", Attachment{Filename: "INV-2026-000001.pdf"}))
+	require.Equal(t, 1, bad.calls)
+	require.Equal(t, 1, good.called)
+}
+
+func TestMultiNotifier_AbandonedCart_LogsButDoesNotShortCircuit(t *testing.T) {
+	bad := &alwaysFailingNotifier{}
+	good := &fakeSender{}
+	m := NewMultiNotifier(bad, NewEmailNotifier(good, AlwaysOnPreferences{}))
+	require.NoError(t, m.SendAbandonedCart(context.Background(), "c", "u@e.com", 2))
+	require.Equal(t, 1, bad.calls)
+	require.Equal(t, 1, good.called)
+}
+
+func TestMultiNotifier_LowStock_LogsButDoesNotShortCircuit(t *testing.T) {
+	bad := &alwaysFailingNotifier{}
+	good := &fakeSender{}
+	m := NewMultiNotifier(bad, NewEmailNotifier(good, AlwaysOnPreferences{}))
+	require.NoError(t, m.SendLowStock(context.Background(), "p", "a@e.com", "Mug", 1, 5, 0))
+	require.Equal(t, 1, bad.calls)
+	require.Equal(t, 1, good.called)
+}
+
+func TestMultiNotifier_ShipmentUpdate_LogsButDoesNotShortCircuit(t *testing.T) {
+	bad := &alwaysFailingNotifier{}
+	good := &fakeSender{}
+	m := NewMultiNotifier(bad, NewEmailNotifier(good, AlwaysOnPreferences{}))
+	require.NoError(t, m.SendShipmentUpdate(context.Background(), "o", "u@e.com", "delivered", "", ""))
+	require.Equal(t, 1, bad.calls)
+	require.Equal(t, 1, good.called)
+}
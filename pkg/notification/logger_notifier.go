@@ -22,3 +22,34 @@ func (n *loggerNotifier) SendOrderStatusChanged(ctx context.Context, orderID, us
 	logger.Info(fmt.Sprintf("[Notification] Order status changed: orderID=%s, user=%s, status=%s", orderID, userEmail, newStatus))
 	return nil
 }
+
+func (n *loggerNotifier) SendOrderPaid(ctx context.Context, orderID, userEmail string, invoice *Attachment) error {
+	attached := ""
+	if invoice != nil {
+		attached = invoice.Filename
+	}
+	logger.Info(fmt.Sprintf("[Notification] Order paid: orderID=%s, user=%s, invoice=%s", orderID, userEmail, attached))
+	return nil
+}
+
+func (n *loggerNotifier) SendInvoice(ctx context.Context, orderID, userEmail string, invoice Attachment) error {
+	logger.Info(fmt.Sprintf("[Notification] Order invoiced: orderID=%s, user=%s, invoice=%s", orderID, userEmail, invoice.Filename))
+	return nil
+}
+
+func (n *loggerNotifier) SendAbandonedCart(ctx context.Context, cartID, userEmail string, itemCount int) error {
+	logger.Info(fmt.Sprintf("[Notification] Abandoned cart: cartID=%s, user=%s, items=%d", cartID, userEmail, itemCount))
+	return nil
+}
+
+func (n *loggerNotifier) SendLowStock(ctx context.Context, productID, adminEmail, productName string, available, threshold, reorderQuantity int) error {
+	logger.Info(fmt.Sprintf("[Notification] Low stock: productID=%s, name=%s, admin=%s, available=%d, threshold=%d, reorder=%d",
+		productID, productName, adminEmail, available, threshold, reorderQuantity))
+	return nil
+}
+
+func (n *loggerNotifier) SendShipmentUpdate(ctx context.Context, orderID, userEmail, status, carrier, trackingNumber string) error {
+	logger.Info(fmt.Sprintf("[Notification] Shipment update: orderID=%s, user=%s, status=%s, carrier=%s, tracking=%s",
+		orderID, userEmail, status, carrier, trackingNumber))
+	return nil
+}
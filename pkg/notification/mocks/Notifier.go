@@ -6,6 +6,7 @@ package mocks
 
 import (
 	"context"
+	"goshop/pkg/notification"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -37,6 +38,300 @@ func (_m *Notifier) EXPECT() *Notifier_Expecter {
 	return &Notifier_Expecter{mock: &_m.Mock}
 }
 
+// SendAbandonedCart provides a mock function for the type Notifier
+func (_mock *Notifier) SendAbandonedCart(ctx context.Context, cartID string, userEmail string, itemCount int) error {
+	ret := _mock.Called(ctx, cartID, userEmail, itemCount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendAbandonedCart")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, int) error); ok {
+		r0 = returnFunc(ctx, cartID, userEmail, itemCount)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Notifier_SendAbandonedCart_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendAbandonedCart'
+type Notifier_SendAbandonedCart_Call struct {
+	*mock.Call
+}
+
+// SendAbandonedCart is a helper method to define mock.On call
+//   - ctx context.Context
+//   - cartID string
+//   - userEmail string
+//   - itemCount int
+func (_e *Notifier_Expecter) SendAbandonedCart(ctx interface{}, cartID interface{}, userEmail interface{}, itemCount interface{}) *Notifier_SendAbandonedCart_Call {
+	return &Notifier_SendAbandonedCart_Call{Call: _e.mock.On("SendAbandonedCart", ctx, cartID, userEmail, itemCount)}
+}
+
+func (_c *Notifier_SendAbandonedCart_Call) Run(run func(ctx context.Context, cartID string, userEmail string, itemCount int)) *Notifier_SendAbandonedCart_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 int
+		if args[3] != nil {
+			arg3 = args[3].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Notifier_SendAbandonedCart_Call) Return(err error) *Notifier_SendAbandonedCart_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Notifier_SendAbandonedCart_Call) RunAndReturn(run func(ctx context.Context, cartID string, userEmail string, itemCount int) error) *Notifier_SendAbandonedCart_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendInvoice provides a mock function for the type Notifier
+func (_mock *Notifier) SendInvoice(ctx context.Context, orderID string, userEmail string, invoice notification.Attachment) error {
+	ret := _mock.Called(ctx, orderID, userEmail, invoice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendInvoice")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, notification.Attachment) error); ok {
+		r0 = returnFunc(ctx, orderID, userEmail, invoice)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Notifier_SendInvoice_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendInvoice'
+type Notifier_SendInvoice_Call struct {
+	*mock.Call
+}
+
+// SendInvoice is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID string
+//   - userEmail string
+//   - invoice notification.Attachment
+func (_e *Notifier_Expecter) SendInvoice(ctx interface{}, orderID interface{}, userEmail interface{}, invoice interface{}) *Notifier_SendInvoice_Call {
+	return &Notifier_SendInvoice_Call{Call: _e.mock.On("SendInvoice", ctx, orderID, userEmail, invoice)}
+}
+
+func (_c *Notifier_SendInvoice_Call) Run(run func(ctx context.Context, orderID string, userEmail string, invoice notification.Attachment)) *Notifier_SendInvoice_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 notification.Attachment
+		if args[3] != nil {
+			arg3 = args[3].(notification.Attachment)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Notifier_SendInvoice_Call) Return(err error) *Notifier_SendInvoice_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Notifier_SendInvoice_Call) RunAndReturn(run func(ctx context.Context, orderID string, userEmail string, invoice notification.Attachment) error) *Notifier_SendInvoice_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendLowStock provides a mock function for the type Notifier
+func (_mock *Notifier) SendLowStock(ctx context.Context, productID string, adminEmail string, productName string, available int, threshold int, reorderQuantity int) error {
+	ret := _mock.Called(ctx, productID, adminEmail, productName, available, threshold, reorderQuantity)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendLowStock")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, int, int, int) error); ok {
+		r0 = returnFunc(ctx, productID, adminEmail, productName, available, threshold, reorderQuantity)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Notifier_SendLowStock_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendLowStock'
+type Notifier_SendLowStock_Call struct {
+	*mock.Call
+}
+
+// SendLowStock is a helper method to define mock.On call
+//   - ctx context.Context
+//   - productID string
+//   - adminEmail string
+//   - productName string
+//   - available int
+//   - threshold int
+//   - reorderQuantity int
+func (_e *Notifier_Expecter) SendLowStock(ctx interface{}, productID interface{}, adminEmail interface{}, productName interface{}, available interface{}, threshold interface{}, reorderQuantity interface{}) *Notifier_SendLowStock_Call {
+	return &Notifier_SendLowStock_Call{Call: _e.mock.On("SendLowStock", ctx, productID, adminEmail, productName, available, threshold, reorderQuantity)}
+}
+
+func (_c *Notifier_SendLowStock_Call) Run(run func(ctx context.Context, productID string, adminEmail string, productName string, available int, threshold int, reorderQuantity int)) *Notifier_SendLowStock_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 int
+		if args[4] != nil {
+			arg4 = args[4].(int)
+		}
+		var arg5 int
+		if args[5] != nil {
+			arg5 = args[5].(int)
+		}
+		var arg6 int
+		if args[6] != nil {
+			arg6 = args[6].(int)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+			arg6,
+		)
+	})
+	return _c
+}
+
+func (_c *Notifier_SendLowStock_Call) Return(err error) *Notifier_SendLowStock_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Notifier_SendLowStock_Call) RunAndReturn(run func(ctx context.Context, productID string, adminEmail string, productName string, available int, threshold int, reorderQuantity int) error) *Notifier_SendLowStock_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SendOrderPaid provides a mock function for the type Notifier
+func (_mock *Notifier) SendOrderPaid(ctx context.Context, orderID string, userEmail string, invoice *notification.Attachment) error {
+	ret := _mock.Called(ctx, orderID, userEmail, invoice)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendOrderPaid")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, *notification.Attachment) error); ok {
+		r0 = returnFunc(ctx, orderID, userEmail, invoice)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Notifier_SendOrderPaid_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendOrderPaid'
+type Notifier_SendOrderPaid_Call struct {
+	*mock.Call
+}
+
+// SendOrderPaid is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID string
+//   - userEmail string
+//   - invoice *notification.Attachment
+func (_e *Notifier_Expecter) SendOrderPaid(ctx interface{}, orderID interface{}, userEmail interface{}, invoice interface{}) *Notifier_SendOrderPaid_Call {
+	return &Notifier_SendOrderPaid_Call{Call: _e.mock.On("SendOrderPaid", ctx, orderID, userEmail, invoice)}
+}
+
+func (_c *Notifier_SendOrderPaid_Call) Run(run func(ctx context.Context, orderID string, userEmail string, invoice *notification.Attachment)) *Notifier_SendOrderPaid_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 *notification.Attachment
+		if args[3] != nil {
+			arg3 = args[3].(*notification.Attachment)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+		)
+	})
+	return _c
+}
+
+func (_c *Notifier_SendOrderPaid_Call) Return(err error) *Notifier_SendOrderPaid_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Notifier_SendOrderPaid_Call) RunAndReturn(run func(ctx context.Context, orderID string, userEmail string, invoice *notification.Attachment) error) *Notifier_SendOrderPaid_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // SendOrderPlaced provides a mock function for the type Notifier
 func (_mock *Notifier) SendOrderPlaced(ctx context.Context, orderID string, userEmail string) error {
 	ret := _mock.Called(ctx, orderID, userEmail)
@@ -168,3 +463,84 @@ func (_c *Notifier_SendOrderStatusChanged_Call) RunAndReturn(run func(ctx contex
 	_c.Call.Return(run)
 	return _c
 }
+
+// SendShipmentUpdate provides a mock function for the type Notifier
+func (_mock *Notifier) SendShipmentUpdate(ctx context.Context, orderID string, userEmail string, status string, carrier string, trackingNumber string) error {
+	ret := _mock.Called(ctx, orderID, userEmail, status, carrier, trackingNumber)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendShipmentUpdate")
+	}
+
+	var r0 error
+	if returnFunc, ok := ret.Get(0).(func(context.Context, string, string, string, string, string) error); ok {
+		r0 = returnFunc(ctx, orderID, userEmail, status, carrier, trackingNumber)
+	} else {
+		r0 = ret.Error(0)
+	}
+	return r0
+}
+
+// Notifier_SendShipmentUpdate_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SendShipmentUpdate'
+type Notifier_SendShipmentUpdate_Call struct {
+	*mock.Call
+}
+
+// SendShipmentUpdate is a helper method to define mock.On call
+//   - ctx context.Context
+//   - orderID string
+//   - userEmail string
+//   - status string
+//   - carrier string
+//   - trackingNumber string
+func (_e *Notifier_Expecter) SendShipmentUpdate(ctx interface{}, orderID interface{}, userEmail interface{}, status interface{}, carrier interface{}, trackingNumber interface{}) *Notifier_SendShipmentUpdate_Call {
+	return &Notifier_SendShipmentUpdate_Call{Call: _e.mock.On("SendShipmentUpdate", ctx, orderID, userEmail, status, carrier, trackingNumber)}
+}
+
+func (_c *Notifier_SendShipmentUpdate_Call) Run(run func(ctx context.Context, orderID string, userEmail string, status string, carrier string, trackingNumber string)) *Notifier_SendShipmentUpdate_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		var arg0 context.Context
+		if args[0] != nil {
+			arg0 = args[0].(context.Context)
+		}
+		var arg1 string
+		if args[1] != nil {
+			arg1 = args[1].(string)
+		}
+		var arg2 string
+		if args[2] != nil {
+			arg2 = args[2].(string)
+		}
+		var arg3 string
+		if args[3] != nil {
+			arg3 = args[3].(string)
+		}
+		var arg4 string
+		if args[4] != nil {
+			arg4 = args[4].(string)
+		}
+		var arg5 string
+		if args[5] != nil {
+			arg5 = args[5].(string)
+		}
+		run(
+			arg0,
+			arg1,
+			arg2,
+			arg3,
+			arg4,
+			arg5,
+		)
+	})
+	return _c
+}
+
+func (_c *Notifier_SendShipmentUpdate_Call) Return(err error) *Notifier_SendShipmentUpdate_Call {
+	_c.Call.Return(err)
+	return _c
+}
+
+func (_c *Notifier_SendShipmentUpdate_Call) RunAndReturn(run func(ctx context.Context, orderID string, userEmail string, status string, carrier string, trackingNumber string) error) *Notifier_SendShipmentUpdate_Call {
+	_c.Call.Return(run)
+	return _c
+}
@@ -6,4 +6,16 @@ import "context"
 type Notifier interface {
 	SendOrderPlaced(ctx context.Context, orderID, userEmail string) error
 	SendOrderStatusChanged(ctx context.Context, orderID, userEmail, newStatus string) error
+	// SendOrderPaid confirms a buyer's payment, attaching the order's invoice unless it is nil.
+	SendOrderPaid(ctx context.Context, orderID, userEmail string, invoice *Attachment) error
+	// SendInvoice sends a buyer the invoice of an order invoiced after its payment confirmation.
+	SendInvoice(ctx context.Context, orderID, userEmail string, invoice Attachment) error
+	// SendAbandonedCart reminds a user about a cart they left idle with itemCount units in it.
+	SendAbandonedCart(ctx context.Context, cartID, userEmail string, itemCount int) error
+	// SendLowStock alerts an admin that a product's available stock fell to or below its threshold.
+	// reorderQuantity is the configured reorder point, 0 when none is set.
+	SendLowStock(ctx context.Context, productID, adminEmail, productName string, available, threshold, reorderQuantity int) error
+	// SendShipmentUpdate tells a buyer that a parcel of their order was packed, shipped or
+	// delivered. carrier and trackingNumber are empty until the parcel has them.
+	SendShipmentUpdate(ctx context.Context, orderID, userEmail, status, carrier, trackingNumber string) error
 }
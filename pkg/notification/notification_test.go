@@ -36,6 +36,36 @@ func TestLoggerNotifier_Send(t *testing.T) {
 				return n.SendOrderStatusChanged(context.Background(), "order-123", "user@example.com", "done")
 			},
 		},
+		{
+			name: "OrderPaid",
+			send: func(n Notifier) error {
+				return n.SendOrderPaid(context.Background(), "order-123", "user@example.com", &Attachment{Filename: "INV-2026-000001.pdf"})
+			},
+		},
+		{
+			name: "Invoice",
+			send: func(n Notifier) error {
+				return n.SendInvoice(context.Background(), "order-123", "user@example.com", Attachment{Filename: "INV-2026-000001.pdf"})
+			},
+		},
+		{
+			name: "AbandonedCart",
+			send: func(n Notifier) error {
+				return n.SendAbandonedCart(context.Background(), "cart-123", "user@example.com", 2)
+			},
+		},
+		{
+			name: "LowStock",
+			send: func(n Notifier) error {
+				return n.SendLowStock(context.Background(), "product-123", "admin@example.com", "Mug", 2, 5, 0)
+			},
+		},
+		{
+			name: "ShipmentUpdate",
+			send: func(n Notifier) error {
+				return n.SendShipmentUpdate(context.Background(), "order-123", "user@example.com", "shipped", "DHL", "JD0001")
+			},
+		},
 	}
 
 	for _, tc := range tests {
@@ -2,6 +2,7 @@ package notification
 
 import (
 	"context"
+	"strconv"
 	"time"
 
 	"github.com/quangdangfit/gocommon/logger"
@@ -55,6 +56,36 @@ func (r *RetryingNotifier) SendOrderStatusChanged(ctx context.Context, orderID,
 	})
 }
 
+func (r *RetryingNotifier) SendOrderPaid(ctx context.Context, orderID, userEmail string, invoice *Attachment) error {
+	return r.run(ctx, "order_paid", userEmail, orderID+"|paid", func() error {
+		return r.inner.SendOrderPaid(ctx, orderID, userEmail, invoice)
+	})
+}
+
+func (r *RetryingNotifier) SendInvoice(ctx context.Context, orderID, userEmail string, invoice Attachment) error {
+	return r.run(ctx, "order_invoiced", userEmail, orderID+"|"+invoice.Filename, func() error {
+		return r.inner.SendInvoice(ctx, orderID, userEmail, invoice)
+	})
+}
+
+func (r *RetryingNotifier) SendAbandonedCart(ctx context.Context, cartID, userEmail string, itemCount int) error {
+	return r.run(ctx, "abandoned_cart", userEmail, cartID+"|"+strconv.Itoa(itemCount), func() error {
+		return r.inner.SendAbandonedCart(ctx, cartID, userEmail, itemCount)
+	})
+}
+
+func (r *RetryingNotifier) SendLowStock(ctx context.Context, productID, adminEmail, productName string, available, threshold, reorderQuantity int) error {
+	return r.run(ctx, "low_stock", adminEmail, productID+"|"+strconv.Itoa(available)+"|"+strconv.Itoa(threshold)+"|"+strconv.Itoa(reorderQuantity), func() error {
+		return r.inner.SendLowStock(ctx, productID, adminEmail, productName, available, threshold, reorderQuantity)
+	})
+}
+
+func (r *RetryingNotifier) SendShipmentUpdate(ctx context.Context, orderID, userEmail, status, carrier, trackingNumber string) error {
+	return r.run(ctx, "shipment_update", userEmail, orderID+"|"+status+"|"+carrier+"|"+trackingNumber, func() error {
+		return r.inner.SendShipmentUpdate(ctx, orderID, userEmail, status, carrier, trackingNumber)
+	})
+}
+
 func (r *RetryingNotifier) run(ctx context.Context, eventType, userEmail, payload string, op func() error) error {
 	delay := r.cfg.InitialDelay
 	var lastErr error
@@ -3,6 +3,7 @@ package notification
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -41,6 +42,46 @@ func (s *stubNotifier) SendOrderStatusChanged(_ context.Context, _, _, _ string)
 	return nil
 }
 
+func (s *stubNotifier) SendOrderPaid(_ context.Context, _, _ string, _ *Attachment) error {
+	s.calls++
+	if s.calls <= s.failFor {
+		return errors.New("transient")
+	}
+	return nil
+}
+
+func (s *stubNotifier) SendInvoice(_ context.Context, _, _ string, _ Attachment) error {
+	s.calls++
+	if s.calls <= s.failFor {
+		return errors.New("transient")
+	}
+	return nil
+}
+
+func (s *stubNotifier) SendAbandonedCart(_ context.Context, _, _ string, _ int) error {
+	s.calls++
+	if s.calls <= s.failFor {
+		return errors.New("transient")
+	}
+	return nil
+}
+
+func (s *stubNotifier) SendLowStock(_ context.Context, _, _, _ string, _, _, _ int) error {
+	s.calls++
+	if s.calls <= s.failFor {
+		return errors.New("transient")
+	}
+	return nil
+}
+
+func (s *stubNotifier) SendShipmentUpdate(_ context.Context, _, _, _, _, _ string) error {
+	s.calls++
+	if s.calls <= s.failFor {
+		return errors.New("transient")
+	}
+	return nil
+}
+
 func TestRetryingNotifier_SucceedsAfterRetries(t *testing.T) {
 	inner := &stubNotifier{failFor: 1} // fail once, then succeed
 	dlq := &recordingDLQ{}
@@ -113,3 +154,55 @@ func TestRetryingNotifier_DLQOnExhaustion(t *testing.T) {
 		t.Fatalf("DLQ should record once, got %d", len(dlq.records))
 	}
 }
+
+func TestRetryingNotifier_AbandonedCart_DLQOnExhaustion(t *testing.T) {
+	inner := &stubNotifier{failFor: 5}
+	dlq := &recordingDLQ{}
+	n := NewRetryingNotifier(inner, RetryConfig{MaxAttempts: 2, InitialDelay: time.Millisecond}, dlq)
+
+	if err := n.SendAbandonedCart(context.Background(), "c1", "a@x.com", 4); err == nil {
+		t.Fatal("expected exhaustion error")
+	}
+	if len(dlq.records) != 1 || !strings.HasPrefix(dlq.records[0], "abandoned_cart|a@x.com|c1|4|") {
+		t.Fatalf("unexpected DLQ records: %v", dlq.records)
+	}
+}
+
+func TestRetryingNotifier_LowStock_DLQOnExhaustion(t *testing.T) {
+	inner := &stubNotifier{failFor: 5}
+	dlq := &recordingDLQ{}
+	n := NewRetryingNotifier(inner, RetryConfig{MaxAttempts: 2, InitialDelay: time.Millisecond}, dlq)
+
+	if err := n.SendLowStock(context.Background(), "p1", "admin@x.com", "Mug", 2, 5, 0); err == nil {
+		t.Fatal("expected exhaustion error")
+	}
+	if len(dlq.records) != 1 || !strings.HasPrefix(dlq.records[0], "low_stock|admin@x.com|p1|2|5|") {
+		t.Fatalf("unexpected DLQ records: %v", dlq.records)
+	}
+}
+
+func TestRetryingNotifier_ShipmentUpdate_DLQOnExhaustion(t *testing.T) {
+	inner := &stubNotifier{failFor: 5}
+	dlq := &recordingDLQ{}
+	n := NewRetryingNotifier(inner, RetryConfig{MaxAttempts: 2, InitialDelay: time.Millisecond}, dlq)
+
+	if err := n.SendShipmentUpdate(context.Background(), "o1", "a@x.com", "shipped", "DHL", "JD0001"); err == nil {
+		t.Fatal("expected exhaustion error")
+	}
+	if len(dlq.records) != 1 || !strings.HasPrefix(dlq.records[0], "shipment_update|a@x.com|o1|shipped|DHL|JD0001|") {
+		t.Fatalf("unexpected DLQ records: %v", dlq.records)
+	}
+}
+
+func TestRetryingNotifier_OrderPaid_DLQOnExhaustion(t *testing.T) {
+	inner := &stubNotifier{failFor: 5}
+	dlq := &recordingDLQ{}
+	n := NewRetryingNotifier(inner, RetryConfig{MaxAttempts: 2, InitialDelay: time.Millisecond}, dlq)
+
+	if err := n.SendOrderPaid(context.Background(), "o1", "a@x.com", nil); err == nil {
+		t.Fatal("expected exhaustion error")
+	}
+	if len(dlq.records) != 1 || !strings.HasPrefix(dlq.records[0], "order_paid|a@x.com|o1|paid|") {
+		t.Fatalf("unexpected DLQ records: %v", dlq.records)
+	}
+}
@@ -0,0 +1,100 @@
+// Package manual implements payment.Provider for offline payments such as bank transfer. No
+// money moves through an API: CreateIntent hands the customer payment instructions, and an
+// admin confirms the payment once it shows up on the shop's account.
+package manual
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"goshop/pkg/payment"
+)
+
+// Name is the provider's key in a payment.Registry and in payments.provider.
+const Name = "bank_transfer"
+
+// Intent statuses. Every intent waits on the customer until an admin confirms it.
+const (
+	statusRequiresAction = "requires_action"
+)
+
+// Provider is a payment.Provider for payments made outside the shop.
+type Provider struct {
+	instructions string
+	holdFor      time.Duration
+	now          func() time.Time
+}
+
+// Config holds the inputs to NewProvider. Instructions are shown to the customer, with
+// {reference} replaced by the order ID so the transfer can be matched to the order. HoldFor
+// is how long the order's stock stays reserved while the transfer is on its way.
+type Config struct {
+	Instructions string
+	HoldFor      time.Duration
+}
+
+func NewProvider(cfg Config) *Provider {
+	return &Provider{
+		instructions: cfg.Instructions,
+		holdFor:      cfg.HoldFor,
+		now:          time.Now,
+	}
+}
+
+// Offline marks Provider as payment.Offline: its payments are confirmed by an admin.
+func (p *Provider) Offline() {}
+
+// CreateIntent returns the transfer instructions for the order. The intent ID is derived
+// from the order ID, so repeated calls return the same intent.
+func (p *Provider) CreateIntent(_ context.Context, params payment.CreateIntentParams) (*payment.Intent, error) {
+	intent := &payment.Intent{
+		ID:           "manual_" + params.OrderID,
+		Instructions: strings.ReplaceAll(p.instructions, "{reference}", params.OrderID),
+		Status:       statusRequiresAction,
+		Amount:       params.Amount,
+		Currency:     params.Currency,
+	}
+	if p.holdFor > 0 {
+		intent.ExpiresAt = p.now().Add(p.holdFor)
+	}
+	return intent, nil
+}
+
+// GetIntent reports every intent as waiting on the customer: only an admin knows whether
+// the transfer arrived.
+func (p *Provider) GetIntent(_ context.Context, intentID string) (*payment.Intent, error) {
+	return &payment.Intent{ID: intentID, Status: payment.IntentStatusRequiresAction}, nil
+}
+
+// Refund records a refund the admin pays back by hand; it succeeds immediately.
+func (p *Provider) Refund(_ context.Context, params payment.RefundParams) (*payment.Refund, error) {
+	return &payment.Refund{
+		ID:              "manual_" + params.RefundID,
+		PaymentIntentID: params.PaymentIntentID,
+		Amount:          params.Amount,
+		Status:          payment.RefundStatusSucceeded,
+		Reference:       params.RefundID,
+	}, nil
+}
+
+// Capture always fails: a transfer is complete once it arrives, there is nothing to capture.
+func (p *Provider) Capture(context.Context, string) error {
+	return payment.ErrCaptureNotSupported
+}
+
+// Void always fails: no funds are held for a transfer.
+func (p *Provider) Void(context.Context, string) error {
+	return payment.ErrCaptureNotSupported
+}
+
+// VerifyWebhook always fails: offline payments have no webhooks.
+func (p *Provider) VerifyWebhook(context.Context, []byte, http.Header) (*payment.Event, error) {
+	return nil, payment.ErrWebhooksNotSupported
+}
+
+// ParseWebhook always fails: offline payments have no webhooks.
+func (p *Provider) ParseWebhook([]byte) (*payment.Event, error) {
+	return nil, payment.ErrWebhooksNotSupported
+}
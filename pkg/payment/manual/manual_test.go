@@ -0,0 +1,66 @@
+package manual
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"goshop/pkg/payment"
+)
+
+func TestCreateIntent(t *testing.T) {
+	p := NewProvider(Config{Instructions: "Pay to IBAN DE00 quoting {reference}", HoldFor: 72 * time.Hour})
+	now := time.Unix(1700000000, 0)
+	p.now = func() time.Time { return now }
+
+	intent, err := p.CreateIntent(context.Background(), payment.CreateIntentParams{Amount: 1500, Currency: "usd", OrderID: "o1"})
+	require.NoError(t, err)
+	require.Equal(t, &payment.Intent{
+		ID:           "manual_o1",
+		Instructions: "Pay to IBAN DE00 quoting o1",
+		Status:       "requires_action",
+		Amount:       1500,
+		Currency:     "usd",
+		ExpiresAt:    now.Add(72 * time.Hour),
+	}, intent)
+}
+
+func TestCreateIntent_NoHold(t *testing.T) {
+	intent, err := NewProvider(Config{}).CreateIntent(context.Background(), payment.CreateIntentParams{OrderID: "o1"})
+	require.NoError(t, err)
+	require.True(t, intent.ExpiresAt.IsZero())
+}
+
+func TestRefund_SucceedsImmediately(t *testing.T) {
+	refund, err := NewProvider(Config{}).Refund(context.Background(), payment.RefundParams{
+		PaymentIntentID: "manual_o1", Amount: 500, RefundID: "r1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, payment.RefundStatusSucceeded, refund.Status)
+	require.Equal(t, "r1", refund.Reference)
+	require.Equal(t, int64(500), refund.Amount)
+}
+
+func TestGetIntent_AwaitsConfirmation(t *testing.T) {
+	intent, err := NewProvider(Config{}).GetIntent(context.Background(), "manual_o1")
+	require.NoError(t, err)
+	require.Equal(t, &payment.Intent{ID: "manual_o1", Status: payment.IntentStatusRequiresAction}, intent)
+}
+
+func TestVerifyWebhook_NotSupported(t *testing.T) {
+	var p payment.Provider = NewProvider(Config{})
+	_, err := p.VerifyWebhook(context.Background(), nil, nil)
+	require.ErrorIs(t, err, payment.ErrWebhooksNotSupported)
+	_, err = p.ParseWebhook(nil)
+	require.ErrorIs(t, err, payment.ErrWebhooksNotSupported)
+	_, offline := p.(payment.Offline)
+	require.True(t, offline)
+}
+
+func TestCaptureAndVoid_NotSupported(t *testing.T) {
+	p := NewProvider(Config{})
+	require.ErrorIs(t, p.Capture(context.Background(), "manual_o1"), payment.ErrCaptureNotSupported)
+	require.ErrorIs(t, p.Void(context.Background(), "manual_o1"), payment.ErrCaptureNotSupported)
+}
@@ -1,23 +1,48 @@
 // Package payment defines a transport-neutral interface for charging customers via a payment
-// provider. The interface keeps domain code unaware of which provider is plugged in; today
-// the only implementation is Stripe (see pkg/payment/stripe).
+// provider. The interface keeps domain code unaware of which provider is plugged in; a
+// Registry holds the configured implementations by name: Stripe (pkg/payment/stripe), PayPal
+// (pkg/payment/paypal) and offline bank transfer (pkg/payment/manual).
 package payment
 
 import (
 	"context"
 	"errors"
+	"net/http"
+	"time"
 )
 
 // Intent is a provider-agnostic view of a created payment intent. Only the fields the order
-// flow actually needs are surfaced; Stripe's full PaymentIntent has many more.
+// flow actually needs are surfaced; Stripe's full PaymentIntent has many more. Which of
+// ClientSecret, RedirectURL and Instructions is set depends on how the customer pays.
 type Intent struct {
 	ID           string
-	ClientSecret string
+	ClientSecret string // card element flows (Stripe)
+	RedirectURL  string // redirect flows (PayPal): send the customer here to approve
+	Instructions string // offline flows (bank transfer): shown to the customer
 	Status       string
 	Amount       int64
 	Currency     string
+	// ExpiresAt is when the customer's window to pay closes, if longer than the order's
+	// stock reservation. Zero means the reservation's own TTL applies.
+	ExpiresAt time.Time
+	// Provider is the Registry name the intent was created with. Providers leave it empty;
+	// callers that pick the provider fill it in.
+	Provider string
 }
 
+// Intent statuses reported by Provider.GetIntent, normalized across providers. CreateIntent
+// returns the provider's own status instead.
+const (
+	IntentStatusPending        = "pending"         // waiting for the customer to pay
+	IntentStatusRequiresAction = "requires_action" // the customer must complete a challenge
+	IntentStatusApproved       = "approved"        // approved by the customer, not captured yet
+	IntentStatusAuthorized     = "authorized"      // funds held for a manual capture
+	IntentStatusProcessing     = "processing"
+	IntentStatusSucceeded      = "succeeded"
+	IntentStatusFailed         = "failed"
+	IntentStatusCanceled       = "canceled"
+)
+
 // EventType enumerates the webhook events we care about. Anything else is ignored.
 type EventType string
 
@@ -27,15 +52,57 @@ const (
 	EventPaymentCanceled       EventType = "payment_intent.canceled"
 	EventPaymentProcessing     EventType = "payment_intent.processing"
 	EventPaymentRequiresAction EventType = "payment_intent.requires_action"
+	EventChargeRefunded        EventType = "charge.refunded"
+	EventRefundUpdated         EventType = "refund.updated"
+	// EventPaymentApproved is sent by redirect providers once the customer approves the
+	// payment; it isn't charged until captured (see Provider.Capture).
+	EventPaymentApproved EventType = "payment.approved"
+	// EventPaymentAuthorized reports that the funds of a ManualCapture intent are held and
+	// can be captured.
+	EventPaymentAuthorized EventType = "payment_intent.amount_capturable_updated"
 )
 
+// CaptureMode picks when a customer's payment is charged.
+type CaptureMode string
+
+const (
+	// CaptureAutomatic charges the payment as soon as the customer pays.
+	CaptureAutomatic CaptureMode = "automatic"
+	// CaptureManual only authorizes the payment at checkout and captures it when the order
+	// ships, so nobody is charged for an order the shop can't fulfil.
+	CaptureManual CaptureMode = "manual"
+)
+
+// Refund statuses as reported by the provider. A pending refund can still fail or be
+// canceled; succeeded, failed and canceled are final.
+const (
+	RefundStatusPending   = "pending"
+	RefundStatusSucceeded = "succeeded"
+	RefundStatusFailed    = "failed"
+	RefundStatusCanceled  = "canceled"
+)
+
+// Refund is a provider-agnostic view of a refund against a payment intent.
+type Refund struct {
+	ID              string
+	PaymentIntentID string
+	Amount          int64
+	Currency        string
+	Status          string
+	Reference       string // RefundParams.RefundID, echoed back from the refund's metadata
+}
+
 // Event is the verified, normalized form of a provider webhook callback.
 type Event struct {
 	ID              string
 	Type            EventType
 	PaymentIntentID string
 	OrderID         string // pulled from the intent's metadata
-	Raw             []byte
+	// AmountRefunded is the total refunded on the charge so far (charge.refunded only).
+	AmountRefunded int64
+	// Refund is set for refund.* events.
+	Refund *Refund
+	Raw    []byte
 }
 
 // CreateIntentParams collects the inputs required to start a payment.
@@ -44,15 +111,106 @@ type CreateIntentParams struct {
 	Currency       string
 	OrderID        string
 	IdempotencyKey string
+	// ManualCapture only authorizes the payment; it's charged later by Capture or released by
+	// Void. Providers that can't hold funds ignore it and charge the customer right away.
+	ManualCapture bool
+	// CustomerID ties the intent to a customer created by SavedMethods.CreateCustomer.
+	CustomerID string
+	// PaymentMethodID charges a method saved for CustomerID instead of asking the customer for
+	// one; the intent is confirmed right away and may still need the customer's approval.
+	PaymentMethodID string
+}
+
+// RefundParams collects the inputs required to refund (part of) a payment.
+type RefundParams struct {
+	PaymentIntentID string
+	Amount          int64 // in minor currency units; 0 refunds whatever is left on the payment
+	OrderID         string
+	RefundID        string // our refund record, stored so refund webhooks can be matched to it
+	Reason          string
+	IdempotencyKey  string
 }
 
-// Provider abstracts a payment processor. Webhook verification must validate the signature
-// using a shared secret; callers should reject unverified events.
+// Provider abstracts a payment processor. Webhook verification must authenticate the
+// delivery from the request headers (a shared-secret signature, or the provider's own
+// verification API); callers should reject unverified events.
 type Provider interface {
 	CreateIntent(ctx context.Context, params CreateIntentParams) (*Intent, error)
-	VerifyWebhook(payload []byte, signatureHeader string) (*Event, error)
+	// GetIntent fetches the provider's current view of an intent, with Status normalized to
+	// one of the IntentStatus* values. Used to reconcile payments whose webhooks went missing.
+	GetIntent(ctx context.Context, intentID string) (*Intent, error)
+	// Refund returns money to the customer. Retrying with the same IdempotencyKey returns the
+	// original refund instead of refunding twice.
+	Refund(ctx context.Context, params RefundParams) (*Refund, error)
+	// Capture charges a payment the customer approved (EventPaymentApproved) or authorized
+	// (EventPaymentAuthorized). The capture result arrives as a later webhook. Returns
+	// ErrCaptureNotSupported if the provider's payments are charged without one.
+	Capture(ctx context.Context, intentID string) error
+	// Void releases the funds held for an authorized, uncaptured payment. Returns
+	// ErrCaptureNotSupported if the provider never holds funds.
+	Void(ctx context.Context, intentID string) error
+	VerifyWebhook(ctx context.Context, payload []byte, headers http.Header) (*Event, error)
+	// ParseWebhook normalizes a payload VerifyWebhook already accepted, without checking its
+	// signature again, so a stored event can be processed later. Returns
+	// ErrWebhooksNotSupported on providers that send none.
+	ParseWebhook(payload []byte) (*Event, error)
+}
+
+// CustomerParams collects the inputs required to create a provider-side customer.
+type CustomerParams struct {
+	UserID         string // stored in the customer's metadata
+	Email          string
+	IdempotencyKey string
+}
+
+// SetupIntent is a provider-agnostic view of a request to save a customer's payment method for
+// later payments. The customer completes it with ClientSecret, as they would an Intent.
+type SetupIntent struct {
+	ID           string
+	ClientSecret string
+	Status       string
+	CustomerID   string
+	// PaymentMethod is set once the customer has entered a payment method.
+	PaymentMethod *PaymentMethod
 }
 
-// ErrInvalidSignature is returned by VerifyWebhook when the provided signature header does not
-// match the expected HMAC of the body.
-var ErrInvalidSignature = errors.New("invalid webhook signature")
+// SetupIntentStatusSucceeded is the status of a SetupIntent whose payment method was saved.
+const SetupIntentStatusSucceeded = "succeeded"
+
+// PaymentMethod is a saved payment method. Only what's needed to show it to its owner is
+// surfaced; the card details stay with the provider.
+type PaymentMethod struct {
+	ID    string
+	Brand string
+	Last4 string
+}
+
+// SavedMethods is implemented by providers that can keep a customer's payment methods for
+// later payments, e.g. cards saved by returning buyers.
+type SavedMethods interface {
+	CreateCustomer(ctx context.Context, params CustomerParams) (customerID string, err error)
+	// CreateSetupIntent starts saving a payment method for the customer.
+	CreateSetupIntent(ctx context.Context, customerID string) (*SetupIntent, error)
+	// GetSetupIntent fetches a setup intent with the payment method the customer entered.
+	GetSetupIntent(ctx context.Context, setupIntentID string) (*SetupIntent, error)
+	// DetachPaymentMethod removes a saved payment method from its customer.
+	DetachPaymentMethod(ctx context.Context, paymentMethodID string) error
+}
+
+// Offline is implemented by providers whose payments happen outside the shop, e.g. by bank
+// transfer. They send no webhooks; an admin confirms each payment once the money arrives.
+type Offline interface {
+	Offline()
+}
+
+var (
+	// ErrInvalidSignature is returned by VerifyWebhook when the delivery can't be
+	// authenticated, e.g. its signature doesn't match the expected HMAC of the body.
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrWebhooksNotSupported is returned by VerifyWebhook and ParseWebhook on providers that
+	// send none.
+	ErrWebhooksNotSupported = errors.New("provider does not send webhooks")
+	// ErrCaptureNotSupported is returned by Capture and Void on providers that don't hold
+	// funds for a later capture.
+	ErrCaptureNotSupported = errors.New("provider does not support capture")
+)
@@ -0,0 +1,493 @@
+// Package paypal implements payment.Provider on PayPal's Orders v2 REST API. It is a redirect
+// flow: CreateIntent creates a PayPal order and returns its approval link, the customer
+// approves it on PayPal, and the resulting CHECKOUT.ORDER.APPROVED webhook tells us to
+// Capture it. The capture outcome arrives as a second webhook.
+package paypal
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"goshop/pkg/currency"
+	"goshop/pkg/payment"
+)
+
+// Name is the provider's key in a payment.Registry and in payments.provider.
+const Name = "paypal"
+
+const (
+	defaultAPIBase = "https://api-m.paypal.com"
+	// tokenLeeway refreshes the access token a little before PayPal expires it.
+	tokenLeeway = time.Minute
+)
+
+// Webhook event types we map; everything else passes through unmapped and is ignored.
+const (
+	eventOrderApproved   = "CHECKOUT.ORDER.APPROVED"
+	eventOrderVoided     = "CHECKOUT.ORDER.VOIDED"
+	eventCaptureComplete = "PAYMENT.CAPTURE.COMPLETED"
+	eventCaptureDenied   = "PAYMENT.CAPTURE.DENIED"
+	eventCapturePending  = "PAYMENT.CAPTURE.PENDING"
+	eventCaptureRefunded = "PAYMENT.CAPTURE.REFUNDED"
+)
+
+// Provider is a payment.Provider backed by PayPal's REST API.
+type Provider struct {
+	clientID     string
+	clientSecret string
+	webhookID    string
+	returnURL    string
+	cancelURL    string
+	apiBase      string
+	httpClient   *http.Client
+	now          func() time.Time
+
+	mu          sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
+}
+
+// Config holds the inputs to NewProvider. ReturnURL and CancelURL are where PayPal sends the
+// customer after approving or abandoning the payment. APIBase is optional and lets tests
+// point at a fake (or the sandbox); if empty, the live PayPal endpoint is used.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	WebhookID    string
+	ReturnURL    string
+	CancelURL    string
+	APIBase      string
+	HTTPClient   *http.Client
+}
+
+func NewProvider(cfg Config) *Provider {
+	base := cfg.APIBase
+	if base == "" {
+		base = defaultAPIBase
+	}
+	client := cfg.HTTPClient
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+	return &Provider{
+		clientID:     cfg.ClientID,
+		clientSecret: cfg.ClientSecret,
+		webhookID:    cfg.WebhookID,
+		returnURL:    cfg.ReturnURL,
+		cancelURL:    cfg.CancelURL,
+		apiBase:      strings.TrimRight(base, "/"),
+		httpClient:   client,
+		now:          time.Now,
+	}
+}
+
+type paypalAmount struct {
+	CurrencyCode string `json:"currency_code"`
+	Value        string `json:"value"`
+}
+
+type paypalLink struct {
+	Href string `json:"href"`
+	Rel  string `json:"rel"`
+}
+
+type paypalCapture struct {
+	ID                string       `json:"id"`
+	Status            string       `json:"status"`
+	Amount            paypalAmount `json:"amount"`
+	CustomID          string       `json:"custom_id"`
+	SupplementaryData struct {
+		RelatedIDs struct {
+			OrderID string `json:"order_id"`
+		} `json:"related_ids"`
+	} `json:"supplementary_data"`
+}
+
+type paypalOrder struct {
+	ID            string `json:"id"`
+	Status        string `json:"status"`
+	PurchaseUnits []struct {
+		CustomID string       `json:"custom_id"`
+		Amount   paypalAmount `json:"amount"`
+		Payments struct {
+			Captures []paypalCapture `json:"captures"`
+		} `json:"payments"`
+	} `json:"purchase_units"`
+	Links []paypalLink `json:"links"`
+}
+
+type paypalRefund struct {
+	ID       string       `json:"id"`
+	Status   string       `json:"status"`
+	Amount   paypalAmount `json:"amount"`
+	CustomID string       `json:"custom_id"`
+}
+
+type paypalError struct {
+	Name    string `json:"name"`
+	Message string `json:"message"`
+	DebugID string `json:"debug_id"`
+}
+
+// CreateIntent calls POST /v2/checkout/orders with our order ID as the purchase unit's
+// custom_id so webhooks can be matched back to the order, and returns the approval link as
+// the intent's RedirectURL.
+func (p *Provider) CreateIntent(ctx context.Context, params payment.CreateIntentParams) (*payment.Intent, error) {
+	currency := strings.ToUpper(params.Currency)
+	body := map[string]any{
+		"intent": "CAPTURE",
+		"purchase_units": []map[string]any{{
+			"reference_id": params.OrderID,
+			"custom_id":    params.OrderID,
+			"amount":       paypalAmount{CurrencyCode: currency, Value: formatAmount(params.Amount, currency)},
+		}},
+		"application_context": map[string]string{
+			"return_url":  p.returnURL,
+			"cancel_url":  p.cancelURL,
+			"user_action": "PAY_NOW",
+		},
+	}
+
+	var order paypalOrder
+	if err := p.do(ctx, http.MethodPost, "/v2/checkout/orders", body, params.IdempotencyKey, "create order", &order); err != nil {
+		return nil, err
+	}
+	intent := &payment.Intent{
+		ID:       order.ID,
+		Status:   strings.ToLower(order.Status),
+		Amount:   params.Amount,
+		Currency: strings.ToLower(currency),
+	}
+	for _, l := range order.Links {
+		if l.Rel == "approve" || l.Rel == "payer-action" {
+			intent.RedirectURL = l.Href
+		}
+	}
+	return intent, nil
+}
+
+// Capture calls POST /v2/checkout/orders/{id}/capture once the customer has approved the
+// order. PayPal replays the capture for a repeated request ID, so redelivered approval
+// webhooks don't charge twice.
+func (p *Provider) Capture(ctx context.Context, intentID string) error {
+	var order paypalOrder
+	path := "/v2/checkout/orders/" + url.PathEscape(intentID) + "/capture"
+	return p.do(ctx, http.MethodPost, path, map[string]any{}, "capture_"+intentID, "capture", &order)
+}
+
+// Void always fails: orders are created to be captured on approval, so PayPal holds no
+// authorization to release. An approved order that is never captured simply expires.
+func (p *Provider) Void(context.Context, string) error {
+	return payment.ErrCaptureNotSupported
+}
+
+// GetIntent calls GET /v2/checkout/orders/{id}. A completed order's outcome lives on its
+// capture, so the capture's status decides between succeeded, processing and failed.
+func (p *Provider) GetIntent(ctx context.Context, intentID string) (*payment.Intent, error) {
+	order, err := p.getOrder(ctx, intentID)
+	if err != nil {
+		return nil, err
+	}
+	intent := &payment.Intent{ID: order.ID, Status: intentStatus(order)}
+	if len(order.PurchaseUnits) > 0 {
+		amount := order.PurchaseUnits[0].Amount
+		intent.Amount = parseAmount(amount.Value, amount.CurrencyCode)
+		intent.Currency = strings.ToLower(amount.CurrencyCode)
+	}
+	return intent, nil
+}
+
+// intentStatus maps PayPal's order and capture statuses onto payment.IntentStatus*.
+func intentStatus(order *paypalOrder) string {
+	switch order.Status {
+	case "APPROVED":
+		return payment.IntentStatusApproved
+	case "PAYER_ACTION_REQUIRED":
+		return payment.IntentStatusRequiresAction
+	case "VOIDED":
+		return payment.IntentStatusCanceled
+	case "COMPLETED":
+		capture := order.firstCapture()
+		if capture == nil {
+			return payment.IntentStatusProcessing
+		}
+		switch capture.Status {
+		case "COMPLETED":
+			return payment.IntentStatusSucceeded
+		case "DECLINED", "FAILED":
+			return payment.IntentStatusFailed
+		default:
+			return payment.IntentStatusProcessing
+		}
+	default:
+		return payment.IntentStatusPending
+	}
+}
+
+func (p *Provider) getOrder(ctx context.Context, id string) (*paypalOrder, error) {
+	var order paypalOrder
+	if err := p.do(ctx, http.MethodGet, "/v2/checkout/orders/"+url.PathEscape(id), nil, "", "get order", &order); err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
+// firstCapture returns the order's first capture, or nil if it has none yet.
+func (o *paypalOrder) firstCapture() *paypalCapture {
+	for i := range o.PurchaseUnits {
+		if captures := o.PurchaseUnits[i].Payments.Captures; len(captures) > 0 {
+			return &captures[0]
+		}
+	}
+	return nil
+}
+
+// Refund refunds the capture behind a PayPal order. The order is looked up first because
+// refunds are issued against the capture, not the order, and a partial refund needs the
+// capture's currency.
+func (p *Provider) Refund(ctx context.Context, params payment.RefundParams) (*payment.Refund, error) {
+	order, err := p.getOrder(ctx, params.PaymentIntentID)
+	if err != nil {
+		return nil, err
+	}
+	capture := order.firstCapture()
+	if capture == nil {
+		return nil, fmt.Errorf("paypal refund: order %s has no capture", params.PaymentIntentID)
+	}
+
+	body := map[string]any{"custom_id": params.RefundID}
+	if params.Amount > 0 {
+		currency := capture.Amount.CurrencyCode
+		body["amount"] = paypalAmount{CurrencyCode: currency, Value: formatAmount(params.Amount, currency)}
+	}
+	if params.Reason != "" {
+		body["note_to_payer"] = params.Reason
+	}
+
+	var refund paypalRefund
+	path := "/v2/payments/captures/" + url.PathEscape(capture.ID) + "/refund"
+	if err := p.do(ctx, http.MethodPost, path, body, params.IdempotencyKey, "refund", &refund); err != nil {
+		return nil, err
+	}
+	if refund.CustomID == "" {
+		refund.CustomID = params.RefundID
+	}
+	return refund.toRefund(params.PaymentIntentID), nil
+}
+
+func (r *paypalRefund) toRefund(intentID string) *payment.Refund {
+	return &payment.Refund{
+		ID:              r.ID,
+		PaymentIntentID: intentID,
+		Amount:          parseAmount(r.Amount.Value, r.Amount.CurrencyCode),
+		Currency:        strings.ToLower(r.Amount.CurrencyCode),
+		Status:          refundStatus(r.Status),
+		Reference:       r.CustomID,
+	}
+}
+
+// refundStatus maps PayPal's refund statuses onto payment.RefundStatus*.
+func refundStatus(s string) string {
+	switch s {
+	case "COMPLETED":
+		return payment.RefundStatusSucceeded
+	case "FAILED":
+		return payment.RefundStatusFailed
+	case "CANCELLED":
+		return payment.RefundStatusCanceled
+	default:
+		return payment.RefundStatusPending
+	}
+}
+
+// webhookEvent mirrors the relevant subset of a PayPal webhook. The resource is an order,
+// capture or refund depending on the event type.
+type webhookEvent struct {
+	ID        string          `json:"id"`
+	EventType string          `json:"event_type"`
+	Resource  json.RawMessage `json:"resource"`
+}
+
+// VerifyWebhook asks PayPal to verify the delivery's transmission signature against the
+// configured webhook ID (POST /v1/notifications/verify-webhook-signature), then normalizes
+// the event.
+func (p *Provider) VerifyWebhook(ctx context.Context, payload []byte, headers http.Header) (*payment.Event, error) {
+	if !json.Valid(payload) {
+		return nil, fmt.Errorf("paypal webhook: decode body: invalid JSON")
+	}
+	if headers.Get("PAYPAL-TRANSMISSION-SIG") == "" {
+		return nil, payment.ErrInvalidSignature
+	}
+
+	verify := map[string]any{
+		"auth_algo":         headers.Get("PAYPAL-AUTH-ALGO"),
+		"cert_url":          headers.Get("PAYPAL-CERT-URL"),
+		"transmission_id":   headers.Get("PAYPAL-TRANSMISSION-ID"),
+		"transmission_sig":  headers.Get("PAYPAL-TRANSMISSION-SIG"),
+		"transmission_time": headers.Get("PAYPAL-TRANSMISSION-TIME"),
+		"webhook_id":        p.webhookID,
+		"webhook_event":     json.RawMessage(payload),
+	}
+	var result struct {
+		VerificationStatus string `json:"verification_status"`
+	}
+	if err := p.do(ctx, http.MethodPost, "/v1/notifications/verify-webhook-signature", verify, "", "verify webhook", &result); err != nil {
+		return nil, err
+	}
+	if result.VerificationStatus != "SUCCESS" {
+		return nil, payment.ErrInvalidSignature
+	}
+	return p.ParseWebhook(payload)
+}
+
+// ParseWebhook decodes a verified PayPal webhook into a payment.Event.
+func (p *Provider) ParseWebhook(payload []byte) (*payment.Event, error) {
+	var ev webhookEvent
+	if err := json.Unmarshal(payload, &ev); err != nil {
+		return nil, fmt.Errorf("paypal webhook: decode body: %w", err)
+	}
+	event := &payment.Event{ID: ev.ID, Type: payment.EventType(ev.EventType), Raw: payload}
+	switch ev.EventType {
+	case eventOrderApproved, eventOrderVoided:
+		var order paypalOrder
+		if err := json.Unmarshal(ev.Resource, &order); err != nil {
+			return nil, fmt.Errorf("paypal webhook: decode order: %w", err)
+		}
+		event.PaymentIntentID = order.ID
+		if len(order.PurchaseUnits) > 0 {
+			event.OrderID = order.PurchaseUnits[0].CustomID
+		}
+		event.Type = payment.EventPaymentApproved
+		if ev.EventType == eventOrderVoided {
+			event.Type = payment.EventPaymentCanceled
+		}
+	case eventCaptureComplete, eventCaptureDenied, eventCapturePending:
+		var capture paypalCapture
+		if err := json.Unmarshal(ev.Resource, &capture); err != nil {
+			return nil, fmt.Errorf("paypal webhook: decode capture: %w", err)
+		}
+		event.PaymentIntentID = capture.SupplementaryData.RelatedIDs.OrderID
+		event.OrderID = capture.CustomID
+		switch ev.EventType {
+		case eventCaptureComplete:
+			event.Type = payment.EventPaymentSucceeded
+		case eventCaptureDenied:
+			event.Type = payment.EventPaymentFailed
+		default:
+			event.Type = payment.EventPaymentProcessing
+		}
+	case eventCaptureRefunded:
+		var refund paypalRefund
+		if err := json.Unmarshal(ev.Resource, &refund); err != nil {
+			return nil, fmt.Errorf("paypal webhook: decode refund: %w", err)
+		}
+		event.Type = payment.EventRefundUpdated
+		event.Refund = refund.toRefund("")
+	}
+	return event, nil
+}
+
+// do sends a JSON request to the PayPal API with a bearer token and decodes the response
+// into out. A non-empty requestID is sent as PayPal-Request-Id, PayPal's idempotency key.
+func (p *Provider) do(ctx context.Context, method, path string, in any, requestID, op string, out any) error {
+	token, err := p.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, p.apiBase+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Prefer", "return=representation")
+	if requestID != "" {
+		req.Header.Set("PayPal-Request-Id", requestID)
+	}
+	return p.send(req, op, out)
+}
+
+// token returns a cached OAuth access token, fetching a new one with the client credentials
+// once the cached one is about to expire.
+func (p *Provider) token(ctx context.Context) (string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.accessToken != "" && p.now().Before(p.tokenExpiry) {
+		return p.accessToken, nil
+	}
+
+	form := url.Values{"grant_type": {"client_credentials"}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiBase+"/v1/oauth2/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(p.clientID, p.clientSecret)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var tok struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+	if err := p.send(req, "token", &tok); err != nil {
+		return "", err
+	}
+	p.accessToken = tok.AccessToken
+	p.tokenExpiry = p.now().Add(time.Duration(tok.ExpiresIn)*time.Second - tokenLeeway)
+	return p.accessToken, nil
+}
+
+func (p *Provider) send(req *http.Request, op string, out any) error {
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("paypal %s: %w", op, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= 400 {
+		var pe paypalError
+		_ = json.Unmarshal(body, &pe)
+		return fmt.Errorf("paypal %s: status=%d name=%s message=%s debug_id=%s",
+			op, resp.StatusCode, pe.Name, pe.Message, pe.DebugID)
+	}
+
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("paypal %s: decode body: %w", op, err)
+	}
+	return nil
+}
+
+// formatAmount renders minor units the way PayPal expects amounts: a decimal string with the
+// currency's ISO 4217 number of places.
+func formatAmount(minor int64, code string) string {
+	return currency.Format(minor, code)
+}
+
+// parseAmount is the inverse of formatAmount. Malformed values parse as 0.
+func parseAmount(value, code string) int64 {
+	n, err := currency.Parse(value, code)
+	if err != nil {
+		return 0
+	}
+	return n
+}
@@ -0,0 +1,292 @@
+package paypal
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"goshop/pkg/payment"
+)
+
+// fakePayPal serves the OAuth endpoint itself and hands every other request to handle.
+type fakePayPal struct {
+	tokenCalls int
+	handle     func(w http.ResponseWriter, r *http.Request, body map[string]any)
+}
+
+func newTestProvider(t *testing.T, f *fakePayPal) *Provider {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/oauth2/token" {
+			f.tokenCalls++
+			user, pass, ok := r.BasicAuth()
+			require.True(t, ok)
+			require.Equal(t, "client", user)
+			require.Equal(t, "secret", pass)
+			_, _ = io.WriteString(w, `{"access_token":"tok","expires_in":3600}`)
+			return
+		}
+		require.Equal(t, "Bearer tok", r.Header.Get("Authorization"))
+		var body map[string]any
+		if raw, _ := io.ReadAll(r.Body); len(raw) > 0 {
+			require.NoError(t, json.Unmarshal(raw, &body))
+		}
+		f.handle(w, r, body)
+	}))
+	t.Cleanup(srv.Close)
+	return NewProvider(Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		WebhookID:    "wh_1",
+		ReturnURL:    "https://shop.example/return",
+		CancelURL:    "https://shop.example/cancel",
+		APIBase:      srv.URL,
+	})
+}
+
+func TestCreateIntent_ReturnsApprovalLink(t *testing.T) {
+	f := &fakePayPal{handle: func(w http.ResponseWriter, r *http.Request, body map[string]any) {
+		require.Equal(t, "/v2/checkout/orders", r.URL.Path)
+		require.Equal(t, "order_o1", r.Header.Get("PayPal-Request-Id"))
+		require.Equal(t, "CAPTURE", body["intent"])
+		unit := body["purchase_units"].([]any)[0].(map[string]any)
+		require.Equal(t, "o1", unit["custom_id"])
+		require.Equal(t, map[string]any{"currency_code": "USD", "value": "12.34"}, unit["amount"])
+		_, _ = io.WriteString(w, `{"id":"PP-1","status":"PAYER_ACTION_REQUIRED","links":[
+			{"rel":"self","href":"https://api/self"},
+			{"rel":"payer-action","href":"https://paypal.example/checkoutnow?token=PP-1"}]}`)
+	}}
+	p := newTestProvider(t, f)
+
+	intent, err := p.CreateIntent(context.Background(), payment.CreateIntentParams{
+		Amount: 1234, Currency: "usd", OrderID: "o1", IdempotencyKey: "order_o1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, "PP-1", intent.ID)
+	require.Equal(t, "https://paypal.example/checkoutnow?token=PP-1", intent.RedirectURL)
+	require.Equal(t, int64(1234), intent.Amount)
+	require.Equal(t, "usd", intent.Currency)
+}
+
+func TestToken_IsCachedUntilExpiry(t *testing.T) {
+	f := &fakePayPal{handle: func(w http.ResponseWriter, _ *http.Request, _ map[string]any) {
+		_, _ = io.WriteString(w, `{"id":"PP-1"}`)
+	}}
+	p := newTestProvider(t, f)
+	now := time.Unix(1700000000, 0)
+	p.now = func() time.Time { return now }
+
+	require.NoError(t, p.Capture(context.Background(), "PP-1"))
+	require.NoError(t, p.Capture(context.Background(), "PP-1"))
+	require.Equal(t, 1, f.tokenCalls)
+
+	now = now.Add(time.Hour)
+	require.NoError(t, p.Capture(context.Background(), "PP-1"))
+	require.Equal(t, 2, f.tokenCalls)
+}
+
+func TestRefund_RefundsTheOrdersCapture(t *testing.T) {
+	f := &fakePayPal{handle: func(w http.ResponseWriter, r *http.Request, body map[string]any) {
+		switch r.URL.Path {
+		case "/v2/checkout/orders/PP-1":
+			require.Equal(t, http.MethodGet, r.Method)
+			_, _ = io.WriteString(w, `{"id":"PP-1","purchase_units":[{"payments":{"captures":[
+				{"id":"CAP-1","amount":{"currency_code":"EUR","value":"20.00"}}]}}]}`)
+		case "/v2/payments/captures/CAP-1/refund":
+			require.Equal(t, "refund_r1", r.Header.Get("PayPal-Request-Id"))
+			require.Equal(t, "r1", body["custom_id"])
+			require.Equal(t, "damaged", body["note_to_payer"])
+			require.Equal(t, map[string]any{"currency_code": "EUR", "value": "5.50"}, body["amount"])
+			_, _ = io.WriteString(w, `{"id":"RF-1","status":"COMPLETED","amount":{"currency_code":"EUR","value":"5.50"}}`)
+		default:
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+	}}
+	p := newTestProvider(t, f)
+
+	refund, err := p.Refund(context.Background(), payment.RefundParams{
+		PaymentIntentID: "PP-1", Amount: 550, RefundID: "r1", Reason: "damaged", IdempotencyKey: "refund_r1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &payment.Refund{
+		ID: "RF-1", PaymentIntentID: "PP-1", Amount: 550, Currency: "eur",
+		Status: payment.RefundStatusSucceeded, Reference: "r1",
+	}, refund)
+}
+
+func TestRefund_NoCapture(t *testing.T) {
+	f := &fakePayPal{handle: func(w http.ResponseWriter, _ *http.Request, _ map[string]any) {
+		_, _ = io.WriteString(w, `{"id":"PP-1","purchase_units":[{}]}`)
+	}}
+	_, err := newTestProvider(t, f).Refund(context.Background(), payment.RefundParams{PaymentIntentID: "PP-1"})
+	require.ErrorContains(t, err, "has no capture")
+}
+
+func TestVoid_NotSupported(t *testing.T) {
+	err := newTestProvider(t, &fakePayPal{}).Void(context.Background(), "PP-1")
+	require.ErrorIs(t, err, payment.ErrCaptureNotSupported)
+}
+
+func TestDo_HTTPError_DecodesPayPalError(t *testing.T) {
+	f := &fakePayPal{handle: func(w http.ResponseWriter, _ *http.Request, _ map[string]any) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = io.WriteString(w, `{"name":"UNPROCESSABLE_ENTITY","message":"The requested action could not be performed","debug_id":"dbg1"}`)
+	}}
+	err := newTestProvider(t, f).Capture(context.Background(), "PP-1")
+	require.ErrorContains(t, err, "paypal capture: status=422 name=UNPROCESSABLE_ENTITY")
+	require.ErrorContains(t, err, "debug_id=dbg1")
+}
+
+func webhookHeaders() http.Header {
+	h := http.Header{}
+	h.Set("PAYPAL-AUTH-ALGO", "SHA256withRSA")
+	h.Set("PAYPAL-CERT-URL", "https://api.paypal.com/cert")
+	h.Set("PAYPAL-TRANSMISSION-ID", "tx1")
+	h.Set("PAYPAL-TRANSMISSION-SIG", "sig")
+	h.Set("PAYPAL-TRANSMISSION-TIME", "2026-01-01T00:00:00Z")
+	return h
+}
+
+func verifyingPayPal(t *testing.T, status string) *fakePayPal {
+	return &fakePayPal{handle: func(w http.ResponseWriter, r *http.Request, body map[string]any) {
+		require.Equal(t, "/v1/notifications/verify-webhook-signature", r.URL.Path)
+		require.Equal(t, "wh_1", body["webhook_id"])
+		require.Equal(t, "sig", body["transmission_sig"])
+		require.NotNil(t, body["webhook_event"])
+		_, _ = io.WriteString(w, `{"verification_status":"`+status+`"}`)
+	}}
+}
+
+func TestVerifyWebhook_MapsEvents(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want payment.Event
+	}{
+		{
+			name: "order_approved",
+			body: `{"id":"WH-1","event_type":"CHECKOUT.ORDER.APPROVED","resource":{"id":"PP-1","purchase_units":[{"custom_id":"o1"}]}}`,
+			want: payment.Event{ID: "WH-1", Type: payment.EventPaymentApproved, PaymentIntentID: "PP-1", OrderID: "o1"},
+		},
+		{
+			name: "order_voided",
+			body: `{"id":"WH-2","event_type":"CHECKOUT.ORDER.VOIDED","resource":{"id":"PP-1","purchase_units":[{"custom_id":"o1"}]}}`,
+			want: payment.Event{ID: "WH-2", Type: payment.EventPaymentCanceled, PaymentIntentID: "PP-1", OrderID: "o1"},
+		},
+		{
+			name: "capture_completed",
+			body: `{"id":"WH-3","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":{"id":"CAP-1","custom_id":"o1","supplementary_data":{"related_ids":{"order_id":"PP-1"}}}}`,
+			want: payment.Event{ID: "WH-3", Type: payment.EventPaymentSucceeded, PaymentIntentID: "PP-1", OrderID: "o1"},
+		},
+		{
+			name: "capture_denied",
+			body: `{"id":"WH-4","event_type":"PAYMENT.CAPTURE.DENIED","resource":{"id":"CAP-1","custom_id":"o1","supplementary_data":{"related_ids":{"order_id":"PP-1"}}}}`,
+			want: payment.Event{ID: "WH-4", Type: payment.EventPaymentFailed, PaymentIntentID: "PP-1", OrderID: "o1"},
+		},
+		{
+			name: "capture_refunded",
+			body: `{"id":"WH-5","event_type":"PAYMENT.CAPTURE.REFUNDED","resource":{"id":"RF-1","status":"COMPLETED","custom_id":"r1","amount":{"currency_code":"USD","value":"3.00"}}}`,
+			want: payment.Event{ID: "WH-5", Type: payment.EventRefundUpdated, Refund: &payment.Refund{
+				ID: "RF-1", Amount: 300, Currency: "usd", Status: payment.RefundStatusSucceeded, Reference: "r1",
+			}},
+		},
+		{
+			name: "unmapped_passes_through",
+			body: `{"id":"WH-6","event_type":"CUSTOMER.DISPUTE.CREATED","resource":{}}`,
+			want: payment.Event{ID: "WH-6", Type: "CUSTOMER.DISPUTE.CREATED"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestProvider(t, verifyingPayPal(t, "SUCCESS"))
+			ev, err := p.VerifyWebhook(context.Background(), []byte(tt.body), webhookHeaders())
+			require.NoError(t, err)
+			tt.want.Raw = []byte(tt.body)
+			require.Equal(t, &tt.want, ev)
+		})
+	}
+}
+
+func TestParseWebhook_SkipsVerification(t *testing.T) {
+	// No PayPal API: a stored event is decoded without asking PayPal again.
+	body := []byte(`{"id":"WH-3","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":{"id":"CAP-1","custom_id":"o1","supplementary_data":{"related_ids":{"order_id":"PP-1"}}}}`)
+	ev, err := NewProvider(Config{}).ParseWebhook(body)
+	require.NoError(t, err)
+	require.Equal(t, &payment.Event{
+		ID: "WH-3", Type: payment.EventPaymentSucceeded, PaymentIntentID: "PP-1", OrderID: "o1", Raw: body,
+	}, ev)
+}
+
+func TestVerifyWebhook_Rejected(t *testing.T) {
+	body := []byte(`{"id":"WH-1","event_type":"PAYMENT.CAPTURE.COMPLETED","resource":{}}`)
+
+	p := newTestProvider(t, verifyingPayPal(t, "FAILURE"))
+	_, err := p.VerifyWebhook(context.Background(), body, webhookHeaders())
+	require.ErrorIs(t, err, payment.ErrInvalidSignature)
+
+	_, err = p.VerifyWebhook(context.Background(), body, http.Header{})
+	require.ErrorIs(t, err, payment.ErrInvalidSignature)
+
+	_, err = p.VerifyWebhook(context.Background(), []byte(`not json`), webhookHeaders())
+	require.ErrorContains(t, err, "decode body")
+}
+
+func TestAmounts(t *testing.T) {
+	tests := []struct {
+		minor    int64
+		currency string
+		value    string
+	}{
+		{1234, "USD", "12.34"},
+		{5, "usd", "0.05"},
+		{100000, "EUR", "1000.00"},
+		{1500, "JPY", "1500"},
+		{1250, "KWD", "1.250"},
+		{-250, "USD", "-2.50"},
+	}
+	for _, tt := range tests {
+		require.Equal(t, tt.value, formatAmount(tt.minor, tt.currency))
+		require.Equal(t, tt.minor, parseAmount(tt.value, tt.currency))
+	}
+	require.Equal(t, int64(1230), parseAmount("12.3", "USD"))
+	require.Zero(t, parseAmount("abc", "USD"))
+}
+
+func TestGetIntent_NormalizesStatus(t *testing.T) {
+	cases := map[string]string{
+		`{"id":"PP-1","status":"CREATED"}`:               payment.IntentStatusPending,
+		`{"id":"PP-1","status":"PAYER_ACTION_REQUIRED"}`: payment.IntentStatusRequiresAction,
+		`{"id":"PP-1","status":"APPROVED"}`:              payment.IntentStatusApproved,
+		`{"id":"PP-1","status":"VOIDED"}`:                payment.IntentStatusCanceled,
+		`{"id":"PP-1","status":"COMPLETED","purchase_units":[{"payments":{"captures":[{"id":"CAP-1","status":"COMPLETED"}]}}]}`: payment.IntentStatusSucceeded,
+		`{"id":"PP-1","status":"COMPLETED","purchase_units":[{"payments":{"captures":[{"id":"CAP-1","status":"PENDING"}]}}]}`:   payment.IntentStatusProcessing,
+		`{"id":"PP-1","status":"COMPLETED","purchase_units":[{"payments":{"captures":[{"id":"CAP-1","status":"DECLINED"}]}}]}`:  payment.IntentStatusFailed,
+	}
+	for body, want := range cases {
+		f := &fakePayPal{handle: func(w http.ResponseWriter, r *http.Request, _ map[string]any) {
+			require.Equal(t, http.MethodGet, r.Method)
+			require.Equal(t, "/v2/checkout/orders/PP-1", r.URL.Path)
+			_, _ = io.WriteString(w, body)
+		}}
+		intent, err := newTestProvider(t, f).GetIntent(context.Background(), "PP-1")
+		require.NoError(t, err)
+		require.Equal(t, "PP-1", intent.ID)
+		require.Equal(t, want, intent.Status, body)
+	}
+}
+
+func TestGetIntent_ReportsAmount(t *testing.T) {
+	f := &fakePayPal{handle: func(w http.ResponseWriter, _ *http.Request, _ map[string]any) {
+		_, _ = io.WriteString(w, `{"id":"PP-1","status":"APPROVED","purchase_units":[{"amount":{"currency_code":"EUR","value":"20.00"}}]}`)
+	}}
+	intent, err := newTestProvider(t, f).GetIntent(context.Background(), "PP-1")
+	require.NoError(t, err)
+	require.Equal(t, int64(2000), intent.Amount)
+	require.Equal(t, "eur", intent.Currency)
+}
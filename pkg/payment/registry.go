@@ -0,0 +1,55 @@
+package payment
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// ErrUnknownProvider is returned by Registry.Get for a name that isn't registered.
+var ErrUnknownProvider = errors.New("unknown payment provider")
+
+// Registry holds the configured providers by name. Names are what payments store in their
+// provider column and what webhook routes use, so they must stay stable.
+type Registry struct {
+	providers   map[string]Provider
+	defaultName string
+}
+
+// NewRegistry returns an empty registry whose Default is the provider registered as
+// defaultName.
+func NewRegistry(defaultName string) *Registry {
+	return &Registry{providers: map[string]Provider{}, defaultName: defaultName}
+}
+
+// Register adds a provider under name, replacing any provider already registered there.
+func (r *Registry) Register(name string, p Provider) {
+	r.providers[name] = p
+}
+
+// Get returns the provider registered as name, or the default provider when name is empty.
+func (r *Registry) Get(name string) (Provider, error) {
+	if name == "" {
+		name = r.defaultName
+	}
+	p, ok := r.providers[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, name)
+	}
+	return p, nil
+}
+
+// DefaultName is the provider used when a customer doesn't pick one.
+func (r *Registry) DefaultName() string {
+	return r.defaultName
+}
+
+// Names lists the registered providers in alphabetical order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.providers))
+	for name := range r.providers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
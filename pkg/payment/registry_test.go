@@ -0,0 +1,44 @@
+package payment
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type nopProvider struct{ name string }
+
+func (nopProvider) CreateIntent(context.Context, CreateIntentParams) (*Intent, error) {
+	return nil, nil
+}
+func (nopProvider) GetIntent(context.Context, string) (*Intent, error)    { return nil, nil }
+func (nopProvider) Refund(context.Context, RefundParams) (*Refund, error) { return nil, nil }
+func (nopProvider) Capture(context.Context, string) error                 { return nil }
+func (nopProvider) Void(context.Context, string) error                    { return nil }
+func (nopProvider) VerifyWebhook(context.Context, []byte, http.Header) (*Event, error) {
+	return nil, nil
+}
+func (nopProvider) ParseWebhook([]byte) (*Event, error) { return nil, nil }
+
+func TestRegistry(t *testing.T) {
+	r := NewRegistry("stripe")
+	stripe, paypal := &nopProvider{"stripe"}, &nopProvider{"paypal"}
+	r.Register("stripe", stripe)
+	r.Register("paypal", paypal)
+
+	got, err := r.Get("")
+	require.NoError(t, err)
+	require.Same(t, stripe, got)
+
+	got, err = r.Get("paypal")
+	require.NoError(t, err)
+	require.Same(t, paypal, got)
+
+	_, err = r.Get("bitcoin")
+	require.ErrorIs(t, err, ErrUnknownProvider)
+
+	require.Equal(t, "stripe", r.DefaultName())
+	require.Equal(t, []string{"paypal", "stripe"}, r.Names())
+}
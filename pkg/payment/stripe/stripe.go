@@ -20,6 +20,12 @@ import (
 	"goshop/pkg/payment"
 )
 
+// Name is the provider's key in a payment.Registry and in payments.provider.
+const Name = "stripe"
+
+// SignatureHeader carries Stripe's webhook signature.
+const SignatureHeader = "Stripe-Signature"
+
 const (
 	defaultAPIBase  = "https://api.stripe.com"
 	signatureMaxAge = 5 * time.Minute
@@ -62,11 +68,14 @@ func NewProvider(cfg Config) *Provider {
 }
 
 type stripePaymentIntent struct {
-	ID           string `json:"id"`
-	ClientSecret string `json:"client_secret"`
-	Status       string `json:"status"`
-	Amount       int64  `json:"amount"`
-	Currency     string `json:"currency"`
+	ID               string `json:"id"`
+	ClientSecret     string `json:"client_secret"`
+	Status           string `json:"status"`
+	Amount           int64  `json:"amount"`
+	Currency         string `json:"currency"`
+	LastPaymentError *struct {
+		Code string `json:"code"`
+	} `json:"last_payment_error"`
 }
 
 type stripeError struct {
@@ -78,7 +87,10 @@ type stripeError struct {
 }
 
 // CreateIntent calls POST /v1/payment_intents with the order_id stored in metadata so the
-// webhook handler can match the resulting event back to an order.
+// webhook handler can match the resulting event back to an order. A ManualCapture intent is
+// created with capture_method=manual: Stripe holds the funds for up to 7 days. An intent for a
+// saved payment method is confirmed at once; the FE only needs its client_secret if the card
+// asks for 3-D Secure.
 func (p *Provider) CreateIntent(ctx context.Context, params payment.CreateIntentParams) (*payment.Intent, error) {
 	form := url.Values{}
 	form.Set("amount", strconv.FormatInt(params.Amount, 10))
@@ -87,61 +99,281 @@ func (p *Provider) CreateIntent(ctx context.Context, params payment.CreateIntent
 	// Disable redirect-based methods so the integration works with PaymentElement out of the box.
 	form.Set("automatic_payment_methods[enabled]", "true")
 	form.Set("automatic_payment_methods[allow_redirects]", "never")
+	if params.ManualCapture {
+		form.Set("capture_method", "manual")
+	}
+	if params.CustomerID != "" {
+		form.Set("customer", params.CustomerID)
+	}
+	if params.PaymentMethodID != "" {
+		form.Set("payment_method", params.PaymentMethodID)
+		form.Set("confirm", "true")
+	}
 
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.apiBase+"/v1/payment_intents", strings.NewReader(form.Encode()))
-	if err != nil {
+	var pi stripePaymentIntent
+	if err := p.do(ctx, http.MethodPost, "/v1/payment_intents", form, params.IdempotencyKey, "create intent", &pi); err != nil {
+		return nil, err
+	}
+	return &payment.Intent{
+		ID:           pi.ID,
+		ClientSecret: pi.ClientSecret,
+		Status:       pi.Status,
+		Amount:       pi.Amount,
+		Currency:     pi.Currency,
+	}, nil
+}
+
+// GetIntent calls GET /v1/payment_intents/{id}. Stripe puts an intent whose payment attempt
+// failed back into requires_payment_method with a last_payment_error, which is reported as
+// failed, as the payment_intent.payment_failed webhook would have.
+func (p *Provider) GetIntent(ctx context.Context, intentID string) (*payment.Intent, error) {
+	var pi stripePaymentIntent
+	path := "/v1/payment_intents/" + url.PathEscape(intentID)
+	if err := p.do(ctx, http.MethodGet, path, nil, "", "get intent", &pi); err != nil {
+		return nil, err
+	}
+	return &payment.Intent{
+		ID:       pi.ID,
+		Status:   intentStatus(&pi),
+		Amount:   pi.Amount,
+		Currency: pi.Currency,
+	}, nil
+}
+
+// intentStatus maps Stripe's PaymentIntent statuses onto payment.IntentStatus*.
+func intentStatus(pi *stripePaymentIntent) string {
+	switch pi.Status {
+	case "succeeded":
+		return payment.IntentStatusSucceeded
+	case "canceled":
+		return payment.IntentStatusCanceled
+	case "requires_capture":
+		return payment.IntentStatusAuthorized
+	case "processing":
+		return payment.IntentStatusProcessing
+	case "requires_action":
+		return payment.IntentStatusRequiresAction
+	case "requires_payment_method":
+		if pi.LastPaymentError != nil {
+			return payment.IntentStatusFailed
+		}
+	}
+	return payment.IntentStatusPending
+}
+
+// Capture calls POST /v1/payment_intents/{id}/capture for an intent created with
+// ManualCapture, charging the full authorized amount. payment_intent.succeeded follows.
+func (p *Provider) Capture(ctx context.Context, intentID string) error {
+	var pi stripePaymentIntent
+	path := "/v1/payment_intents/" + url.PathEscape(intentID) + "/capture"
+	return p.do(ctx, http.MethodPost, path, url.Values{}, "capture_"+intentID, "capture", &pi)
+}
+
+// Void calls POST /v1/payment_intents/{id}/cancel, releasing the authorized funds.
+// payment_intent.canceled follows.
+func (p *Provider) Void(ctx context.Context, intentID string) error {
+	var pi stripePaymentIntent
+	path := "/v1/payment_intents/" + url.PathEscape(intentID) + "/cancel"
+	return p.do(ctx, http.MethodPost, path, url.Values{}, "void_"+intentID, "void", &pi)
+}
+
+type stripeRefund struct {
+	ID            string            `json:"id"`
+	PaymentIntent string            `json:"payment_intent"`
+	Amount        int64             `json:"amount"`
+	Currency      string            `json:"currency"`
+	Status        string            `json:"status"`
+	Metadata      map[string]string `json:"metadata"`
+}
+
+func (r *stripeRefund) toRefund() *payment.Refund {
+	return &payment.Refund{
+		ID:              r.ID,
+		PaymentIntentID: r.PaymentIntent,
+		Amount:          r.Amount,
+		Currency:        r.Currency,
+		Status:          r.Status,
+		Reference:       r.Metadata["refund_id"],
+	}
+}
+
+// Refund calls POST /v1/refunds against the payment intent. Stripe only accepts a fixed set of
+// reason codes, so the free-text reason travels in metadata along with our refund and order
+// IDs.
+func (p *Provider) Refund(ctx context.Context, params payment.RefundParams) (*payment.Refund, error) {
+	form := url.Values{}
+	form.Set("payment_intent", params.PaymentIntentID)
+	if params.Amount > 0 {
+		form.Set("amount", strconv.FormatInt(params.Amount, 10))
+	}
+	form.Set("metadata[order_id]", params.OrderID)
+	form.Set("metadata[refund_id]", params.RefundID)
+	if params.Reason != "" {
+		form.Set("metadata[reason]", params.Reason)
+	}
+
+	var refund stripeRefund
+	if err := p.do(ctx, http.MethodPost, "/v1/refunds", form, params.IdempotencyKey, "refund", &refund); err != nil {
+		return nil, err
+	}
+	return refund.toRefund(), nil
+}
+
+type stripeCustomer struct {
+	ID string `json:"id"`
+}
+
+// CreateCustomer calls POST /v1/customers with our user ID in metadata.
+func (p *Provider) CreateCustomer(ctx context.Context, params payment.CustomerParams) (string, error) {
+	form := url.Values{}
+	form.Set("email", params.Email)
+	form.Set("metadata[user_id]", params.UserID)
+
+	var customer stripeCustomer
+	if err := p.do(ctx, http.MethodPost, "/v1/customers", form, params.IdempotencyKey, "create customer", &customer); err != nil {
+		return "", err
+	}
+	return customer.ID, nil
+}
+
+// stripeSetupIntent mirrors a SetupIntent. Its payment_method is the method's ID unless
+// expanded into the object.
+type stripeSetupIntent struct {
+	ID            string          `json:"id"`
+	ClientSecret  string          `json:"client_secret"`
+	Status        string          `json:"status"`
+	Customer      string          `json:"customer"`
+	PaymentMethod json.RawMessage `json:"payment_method"`
+}
+
+type stripePaymentMethod struct {
+	ID   string `json:"id"`
+	Card struct {
+		Brand string `json:"brand"`
+		Last4 string `json:"last4"`
+	} `json:"card"`
+}
+
+func (si *stripeSetupIntent) toSetupIntent() (*payment.SetupIntent, error) {
+	res := &payment.SetupIntent{
+		ID:           si.ID,
+		ClientSecret: si.ClientSecret,
+		Status:       si.Status,
+		CustomerID:   si.Customer,
+	}
+	switch {
+	case len(si.PaymentMethod) == 0 || string(si.PaymentMethod) == "null":
+	case si.PaymentMethod[0] == '"':
+		res.PaymentMethod = &payment.PaymentMethod{}
+		if err := json.Unmarshal(si.PaymentMethod, &res.PaymentMethod.ID); err != nil {
+			return nil, fmt.Errorf("stripe setup intent: decode payment method: %w", err)
+		}
+	default:
+		var pm stripePaymentMethod
+		if err := json.Unmarshal(si.PaymentMethod, &pm); err != nil {
+			return nil, fmt.Errorf("stripe setup intent: decode payment method: %w", err)
+		}
+		res.PaymentMethod = &payment.PaymentMethod{ID: pm.ID, Brand: pm.Card.Brand, Last4: pm.Card.Last4}
+	}
+	return res, nil
+}
+
+// CreateSetupIntent calls POST /v1/setup_intents for the customer, with the same payment method
+// options as CreateIntent.
+func (p *Provider) CreateSetupIntent(ctx context.Context, customerID string) (*payment.SetupIntent, error) {
+	form := url.Values{}
+	form.Set("customer", customerID)
+	form.Set("automatic_payment_methods[enabled]", "true")
+	form.Set("automatic_payment_methods[allow_redirects]", "never")
+
+	var si stripeSetupIntent
+	if err := p.do(ctx, http.MethodPost, "/v1/setup_intents", form, "", "create setup intent", &si); err != nil {
+		return nil, err
+	}
+	return si.toSetupIntent()
+}
+
+// GetSetupIntent calls GET /v1/setup_intents/{id}, expanding the payment method for its card
+// brand and last 4 digits.
+func (p *Provider) GetSetupIntent(ctx context.Context, setupIntentID string) (*payment.SetupIntent, error) {
+	var si stripeSetupIntent
+	path := "/v1/setup_intents/" + url.PathEscape(setupIntentID) + "?" + url.Values{"expand[]": {"payment_method"}}.Encode()
+	if err := p.do(ctx, http.MethodGet, path, nil, "", "get setup intent", &si); err != nil {
 		return nil, err
 	}
+	return si.toSetupIntent()
+}
+
+// DetachPaymentMethod calls POST /v1/payment_methods/{id}/detach. The method can't be used
+// again afterwards.
+func (p *Provider) DetachPaymentMethod(ctx context.Context, paymentMethodID string) error {
+	var out struct{}
+	path := "/v1/payment_methods/" + url.PathEscape(paymentMethodID) + "/detach"
+	return p.do(ctx, http.MethodPost, path, url.Values{}, "", "detach payment method", &out)
+}
+
+// do sends a request to the Stripe API, with form as the form-encoded body when it isn't nil,
+// and decodes the response into out.
+func (p *Provider) do(ctx context.Context, method, path string, form url.Values, idempotencyKey, op string, out any) error {
+	var reqBody io.Reader
+	if form != nil {
+		reqBody = strings.NewReader(form.Encode())
+	}
+	req, err := http.NewRequestWithContext(ctx, method, p.apiBase+path, reqBody)
+	if err != nil {
+		return err
+	}
 	req.Header.Set("Authorization", "Bearer "+p.secretKey)
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
-	if params.IdempotencyKey != "" {
-		req.Header.Set("Idempotency-Key", params.IdempotencyKey)
+	if form != nil {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	if idempotencyKey != "" {
+		req.Header.Set("Idempotency-Key", idempotencyKey)
 	}
 
 	resp, err := p.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("stripe create intent: %w", err)
+		return fmt.Errorf("stripe %s: %w", op, err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if resp.StatusCode >= 400 {
 		var se stripeError
 		_ = json.Unmarshal(body, &se)
-		return nil, fmt.Errorf("stripe create intent: status=%d type=%s code=%s message=%s",
-			resp.StatusCode, se.Error.Type, se.Error.Code, se.Error.Message)
+		return fmt.Errorf("stripe %s: status=%d type=%s code=%s message=%s",
+			op, resp.StatusCode, se.Error.Type, se.Error.Code, se.Error.Message)
 	}
 
-	var pi stripePaymentIntent
-	if err := json.Unmarshal(body, &pi); err != nil {
-		return nil, fmt.Errorf("stripe create intent: decode body: %w", err)
+	if err := json.Unmarshal(body, out); err != nil {
+		return fmt.Errorf("stripe %s: decode body: %w", op, err)
 	}
-	return &payment.Intent{
-		ID:           pi.ID,
-		ClientSecret: pi.ClientSecret,
-		Status:       pi.Status,
-		Amount:       pi.Amount,
-		Currency:     pi.Currency,
-	}, nil
+	return nil
 }
 
-// stripeEvent mirrors the relevant subset of the Stripe webhook event envelope.
+// stripeEvent mirrors the relevant subset of the Stripe webhook event envelope. The object is
+// a PaymentIntent, Charge or Refund depending on the event type; the fields below cover all
+// three.
 type stripeEvent struct {
 	ID   string `json:"id"`
 	Type string `json:"type"`
 	Data struct {
-		Object stripePaymentIntent `json:"object"`
+		Object struct {
+			stripePaymentIntent
+			PaymentIntent  string `json:"payment_intent"`
+			AmountRefunded int64  `json:"amount_refunded"`
+		} `json:"object"`
 	} `json:"data"`
 }
 
 // VerifyWebhook parses the Stripe-Signature header (t=...,v1=...) and validates its HMAC
 // against the configured webhook secret. Rejects payloads older than signatureMaxAge to
 // blunt replay attacks.
-func (p *Provider) VerifyWebhook(payloadBytes []byte, signatureHeader string) (*payment.Event, error) {
-	timestamp, sigs, err := parseStripeSignature(signatureHeader)
+func (p *Provider) VerifyWebhook(_ context.Context, payloadBytes []byte, headers http.Header) (*payment.Event, error) {
+	timestamp, sigs, err := parseStripeSignature(headers.Get(SignatureHeader))
 	if err != nil {
 		return nil, err
 	}
@@ -164,7 +396,11 @@ func (p *Provider) VerifyWebhook(payloadBytes []byte, signatureHeader string) (*
 	if !matched {
 		return nil, payment.ErrInvalidSignature
 	}
+	return p.ParseWebhook(payloadBytes)
+}
 
+// ParseWebhook decodes a verified Stripe event into a payment.Event.
+func (p *Provider) ParseWebhook(payloadBytes []byte) (*payment.Event, error) {
 	var ev stripeEvent
 	if err := json.Unmarshal(payloadBytes, &ev); err != nil {
 		return nil, fmt.Errorf("stripe webhook: decode body: %w", err)
@@ -180,13 +416,31 @@ func (p *Provider) VerifyWebhook(payloadBytes []byte, signatureHeader string) (*
 	}
 	_ = json.Unmarshal(payloadBytes, &inner)
 
-	return &payment.Event{
+	obj := ev.Data.Object
+	event := &payment.Event{
 		ID:              ev.ID,
 		Type:            payment.EventType(ev.Type),
-		PaymentIntentID: ev.Data.Object.ID,
+		PaymentIntentID: obj.ID,
 		OrderID:         inner.Data.Object.Metadata["order_id"],
 		Raw:             payloadBytes,
-	}, nil
+	}
+	// Charge and refund events carry their own object; point back at the intent they belong to.
+	switch {
+	case strings.HasPrefix(ev.Type, "charge."):
+		event.PaymentIntentID = obj.PaymentIntent
+		event.AmountRefunded = obj.AmountRefunded
+	case strings.HasPrefix(ev.Type, "refund."):
+		event.PaymentIntentID = obj.PaymentIntent
+		event.Refund = (&stripeRefund{
+			ID:            obj.ID,
+			PaymentIntent: obj.PaymentIntent,
+			Amount:        obj.Amount,
+			Currency:      obj.Currency,
+			Status:        obj.Status,
+			Metadata:      inner.Data.Object.Metadata,
+		}).toRefund()
+	}
+	return event, nil
 }
 
 // parseStripeSignature extracts t=<unix> and one or more v1=<hex> fields from the header.
@@ -1,6 +1,7 @@
 package stripe
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -60,6 +61,20 @@ func TestCreateIntent_DecodeError_ReturnsError(t *testing.T) {
 	assert.Contains(t, err.Error(), "decode body")
 }
 
+func TestRefund_HTTPError_DecodesStripeError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"type":"invalid_request_error","code":"charge_already_refunded","message":"already refunded"}}`))
+	}))
+	defer srv.Close()
+
+	p := NewProvider(Config{SecretKey: "sk_test", APIBase: srv.URL})
+	_, err := p.Refund(t.Context(), payment.RefundParams{PaymentIntentID: "pi_1", Amount: 100})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "stripe refund: status=400")
+	assert.Contains(t, err.Error(), "charge_already_refunded")
+}
+
 func TestVerifyWebhook_BadBodyAfterValidSignature(t *testing.T) {
 	p := NewProvider(Config{WebhookSecret: "whsec_test"})
 	now := time.Unix(1700000000, 0)
@@ -68,7 +83,7 @@ func TestVerifyWebhook_BadBodyAfterValidSignature(t *testing.T) {
 	body := []byte(`not json`)
 	header := sign("whsec_test", now.Unix(), body)
 
-	_, err := p.VerifyWebhook(body, header)
+	_, err := p.VerifyWebhook(context.Background(), body, sigHeader(header))
 	require.Error(t, err)
 	assert.Contains(t, err.Error(), "decode body")
 }
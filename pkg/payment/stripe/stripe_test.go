@@ -1,6 +1,7 @@
 package stripe
 
 import (
+	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
@@ -25,6 +26,12 @@ func sign(secret string, ts int64, body []byte) string {
 	return fmt.Sprintf("t=%d,v1=%s", ts, hex.EncodeToString(mac.Sum(nil)))
 }
 
+func sigHeader(sig string) http.Header {
+	h := http.Header{}
+	h.Set(SignatureHeader, sig)
+	return h
+}
+
 func TestVerifyWebhook_Valid(t *testing.T) {
 	p := NewProvider(Config{WebhookSecret: "whsec_test"})
 	now := time.Unix(1700000000, 0)
@@ -33,7 +40,7 @@ func TestVerifyWebhook_Valid(t *testing.T) {
 	body := []byte(`{"id":"evt_1","type":"payment_intent.succeeded","data":{"object":{"id":"pi_1","metadata":{"order_id":"ord_42"}}}}`)
 	header := sign("whsec_test", now.Unix(), body)
 
-	ev, err := p.VerifyWebhook(body, header)
+	ev, err := p.VerifyWebhook(context.Background(), body, sigHeader(header))
 	require.NoError(t, err)
 	require.Equal(t, "evt_1", ev.ID)
 	require.Equal(t, payment.EventPaymentSucceeded, ev.Type)
@@ -41,11 +48,26 @@ func TestVerifyWebhook_Valid(t *testing.T) {
 	require.Equal(t, "ord_42", ev.OrderID)
 }
 
+func TestParseWebhook_SkipsSignature(t *testing.T) {
+	// A stored event is parsed long after its signature has gone stale.
+	body := []byte(`{"id":"evt_1","type":"payment_intent.payment_failed","data":{"object":{"id":"pi_1","metadata":{"order_id":"ord_42"}}}}`)
+
+	ev, err := NewProvider(Config{WebhookSecret: "whsec_test"}).ParseWebhook(body)
+	require.NoError(t, err)
+	require.Equal(t, payment.EventPaymentFailed, ev.Type)
+	require.Equal(t, "pi_1", ev.PaymentIntentID)
+	require.Equal(t, "ord_42", ev.OrderID)
+	require.Equal(t, body, ev.Raw)
+
+	_, err = NewProvider(Config{}).ParseWebhook([]byte(`not json`))
+	require.ErrorContains(t, err, "decode body")
+}
+
 func TestVerifyWebhook_BadSignature(t *testing.T) {
 	p := NewProvider(Config{WebhookSecret: "whsec_test"})
 	p.now = func() time.Time { return time.Unix(1700000000, 0) }
 
-	_, err := p.VerifyWebhook([]byte(`{}`), "t=1700000000,v1=deadbeef")
+	_, err := p.VerifyWebhook(context.Background(), []byte(`{}`), sigHeader("t=1700000000,v1=deadbeef"))
 	require.ErrorIs(t, err, payment.ErrInvalidSignature)
 }
 
@@ -55,13 +77,13 @@ func TestVerifyWebhook_StaleTimestamp(t *testing.T) {
 
 	body := []byte(`{}`)
 	stale := sign("whsec_test", 1700000000-int64(10*time.Minute/time.Second), body)
-	_, err := p.VerifyWebhook(body, stale)
+	_, err := p.VerifyWebhook(context.Background(), body, sigHeader(stale))
 	require.ErrorIs(t, err, payment.ErrInvalidSignature)
 }
 
 func TestVerifyWebhook_EmptyHeader(t *testing.T) {
 	p := NewProvider(Config{WebhookSecret: "whsec_test"})
-	_, err := p.VerifyWebhook([]byte(`{}`), "")
+	_, err := p.VerifyWebhook(context.Background(), []byte(`{}`), sigHeader(""))
 	require.ErrorIs(t, err, payment.ErrInvalidSignature)
 }
 
@@ -104,6 +126,168 @@ func TestCreateIntent_PostsExpectedForm(t *testing.T) {
 	require.Equal(t, []string{"1234"}, captured.form["amount"])
 	require.Equal(t, []string{"usd"}, captured.form["currency"])
 	require.Equal(t, []string{"ord_1"}, captured.form["metadata[order_id]"])
+	require.NotContains(t, captured.form, "capture_method")
+}
+
+func TestCreateIntent_ManualCapture(t *testing.T) {
+	var form map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		form = parseFormBody(string(body))
+		_, _ = w.Write([]byte(`{"id":"pi_1","status":"requires_payment_method"}`))
+	}))
+	defer srv.Close()
+
+	p := NewProvider(Config{SecretKey: "sk_test", APIBase: srv.URL})
+	_, err := p.CreateIntent(t.Context(), payment.CreateIntentParams{Amount: 1234, Currency: "usd", OrderID: "ord_1", ManualCapture: true})
+	require.NoError(t, err)
+	require.Equal(t, []string{"manual"}, form["capture_method"])
+}
+
+func TestCaptureAndVoid_PostToTheIntent(t *testing.T) {
+	type call struct{ path, idem string }
+	var calls []call
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		calls = append(calls, call{r.URL.Path, r.Header.Get("Idempotency-Key")})
+		_, _ = w.Write([]byte(`{"id":"pi_1","status":"succeeded"}`))
+	}))
+	defer srv.Close()
+
+	p := NewProvider(Config{SecretKey: "sk_test", APIBase: srv.URL})
+	require.NoError(t, p.Capture(t.Context(), "pi_1"))
+	require.NoError(t, p.Void(t.Context(), "pi_2"))
+	require.Equal(t, []call{
+		{"/v1/payment_intents/pi_1/capture", "capture_pi_1"},
+		{"/v1/payment_intents/pi_2/cancel", "void_pi_2"},
+	}, calls)
+}
+
+func TestRefund_PostsExpectedForm(t *testing.T) {
+	var captured struct {
+		path string
+		idem string
+		form map[string][]string
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		captured.path = r.URL.Path
+		captured.idem = r.Header.Get("Idempotency-Key")
+		body, _ := io.ReadAll(r.Body)
+		captured.form = parseFormBody(string(body))
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id":             "re_1",
+			"payment_intent": "pi_1",
+			"status":         "succeeded",
+			"amount":         500,
+			"currency":       "usd",
+			"metadata":       map[string]string{"refund_id": "rf_1"},
+		})
+	}))
+	defer srv.Close()
+
+	p := NewProvider(Config{SecretKey: "sk_test", APIBase: srv.URL})
+	refund, err := p.Refund(t.Context(), payment.RefundParams{
+		PaymentIntentID: "pi_1",
+		Amount:          500,
+		OrderID:         "ord_1",
+		RefundID:        "rf_1",
+		Reason:          "damaged",
+		IdempotencyKey:  "refund_rf_1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, &payment.Refund{
+		ID: "re_1", PaymentIntentID: "pi_1", Amount: 500, Currency: "usd", Status: "succeeded", Reference: "rf_1",
+	}, refund)
+	require.Equal(t, "/v1/refunds", captured.path)
+	require.Equal(t, "refund_rf_1", captured.idem)
+	require.Equal(t, []string{"pi_1"}, captured.form["payment_intent"])
+	require.Equal(t, []string{"500"}, captured.form["amount"])
+	require.Equal(t, []string{"rf_1"}, captured.form["metadata[refund_id]"])
+	require.Equal(t, []string{"damaged"}, captured.form["metadata[reason]"])
+}
+
+func TestRefund_FullOmitsAmount(t *testing.T) {
+	var form map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		form = parseFormBody(string(body))
+		_, _ = w.Write([]byte(`{"id":"re_1","status":"pending"}`))
+	}))
+	defer srv.Close()
+
+	p := NewProvider(Config{SecretKey: "sk_test", APIBase: srv.URL})
+	_, err := p.Refund(t.Context(), payment.RefundParams{PaymentIntentID: "pi_1", RefundID: "rf_1"})
+	require.NoError(t, err)
+	require.NotContains(t, form, "amount")
+	require.NotContains(t, form, "metadata[reason]")
+}
+
+func TestGetIntent_NormalizesStatus(t *testing.T) {
+	cases := map[string]string{
+		`{"id":"pi_1","status":"succeeded","amount":1000,"currency":"usd"}`:                              payment.IntentStatusSucceeded,
+		`{"id":"pi_1","status":"requires_capture"}`:                                                      payment.IntentStatusAuthorized,
+		`{"id":"pi_1","status":"processing"}`:                                                            payment.IntentStatusProcessing,
+		`{"id":"pi_1","status":"requires_action"}`:                                                       payment.IntentStatusRequiresAction,
+		`{"id":"pi_1","status":"canceled"}`:                                                              payment.IntentStatusCanceled,
+		`{"id":"pi_1","status":"requires_payment_method"}`:                                               payment.IntentStatusPending,
+		`{"id":"pi_1","status":"requires_payment_method","last_payment_error":{"code":"card_declined"}}`: payment.IntentStatusFailed,
+	}
+	for body, want := range cases {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.Equal(t, http.MethodGet, r.Method)
+			require.Equal(t, "/v1/payment_intents/pi_1", r.URL.Path)
+			require.Equal(t, "Bearer sk_test", r.Header.Get("Authorization"))
+			require.Empty(t, r.Header.Get("Content-Type"))
+			_, _ = w.Write([]byte(body))
+		}))
+
+		intent, err := NewProvider(Config{SecretKey: "sk_test", APIBase: srv.URL}).GetIntent(t.Context(), "pi_1")
+		srv.Close()
+		require.NoError(t, err)
+		require.Equal(t, "pi_1", intent.ID)
+		require.Equal(t, want, intent.Status, body)
+	}
+}
+
+func TestGetIntent_NotFound(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"type":"invalid_request_error","code":"resource_missing","message":"No such payment_intent"}}`))
+	}))
+	defer srv.Close()
+
+	_, err := NewProvider(Config{SecretKey: "sk_test", APIBase: srv.URL}).GetIntent(t.Context(), "pi_missing")
+	require.ErrorContains(t, err, "stripe get intent: status=404")
+}
+
+func TestVerifyWebhook_RefundEvents(t *testing.T) {
+	p := NewProvider(Config{WebhookSecret: "whsec_test"})
+	now := time.Unix(1700000000, 0)
+	p.now = func() time.Time { return now }
+
+	t.Run("charge_refunded", func(t *testing.T) {
+		body := []byte(`{"id":"evt_1","type":"charge.refunded","data":{"object":{"id":"ch_1","payment_intent":"pi_1",` +
+			`"amount":1000,"amount_refunded":400,"metadata":{"order_id":"ord_42"}}}}`)
+		ev, err := p.VerifyWebhook(context.Background(), body, sigHeader(sign("whsec_test", now.Unix(), body)))
+		require.NoError(t, err)
+		require.Equal(t, payment.EventChargeRefunded, ev.Type)
+		require.Equal(t, "pi_1", ev.PaymentIntentID)
+		require.Equal(t, "ord_42", ev.OrderID)
+		require.Equal(t, int64(400), ev.AmountRefunded)
+		require.Nil(t, ev.Refund)
+	})
+
+	t.Run("refund_updated", func(t *testing.T) {
+		body := []byte(`{"id":"evt_2","type":"refund.updated","data":{"object":{"id":"re_1","payment_intent":"pi_1",` +
+			`"amount":400,"currency":"usd","status":"failed","metadata":{"order_id":"ord_42","refund_id":"rf_1"}}}}`)
+		ev, err := p.VerifyWebhook(context.Background(), body, sigHeader(sign("whsec_test", now.Unix(), body)))
+		require.NoError(t, err)
+		require.Equal(t, payment.EventRefundUpdated, ev.Type)
+		require.Equal(t, "pi_1", ev.PaymentIntentID)
+		require.Equal(t, &payment.Refund{
+			ID: "re_1", PaymentIntentID: "pi_1", Amount: 400, Currency: "usd", Status: "failed", Reference: "rf_1",
+		}, ev.Refund)
+	})
 }
 
 func parseFormBody(raw string) map[string][]string {
@@ -122,3 +306,95 @@ func parseFormBody(raw string) map[string][]string {
 	}
 	return out
 }
+
+func TestCreateIntent_SavedPaymentMethod(t *testing.T) {
+	var form map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		form = parseFormBody(string(body))
+		_, _ = w.Write([]byte(`{"id":"pi_1","status":"succeeded"}`))
+	}))
+	defer srv.Close()
+
+	p := NewProvider(Config{SecretKey: "sk_test", APIBase: srv.URL})
+	_, err := p.CreateIntent(t.Context(), payment.CreateIntentParams{
+		Amount: 1234, Currency: "usd", OrderID: "ord_1", CustomerID: "cus_1", PaymentMethodID: "pm_1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, []string{"cus_1"}, form["customer"])
+	require.Equal(t, []string{"pm_1"}, form["payment_method"])
+	require.Equal(t, []string{"true"}, form["confirm"])
+}
+
+func TestCreateCustomer_PostsExpectedForm(t *testing.T) {
+	var form map[string][]string
+	var idem string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/customers", r.URL.Path)
+		idem = r.Header.Get("Idempotency-Key")
+		require.NoError(t, r.ParseForm())
+		form = r.PostForm
+		_, _ = w.Write([]byte(`{"id":"cus_1"}`))
+	}))
+	defer srv.Close()
+
+	var p payment.SavedMethods = NewProvider(Config{SecretKey: "sk_test", APIBase: srv.URL})
+	id, err := p.CreateCustomer(t.Context(), payment.CustomerParams{UserID: "u1", Email: "a@b.c", IdempotencyKey: "customer_u1"})
+	require.NoError(t, err)
+	require.Equal(t, "cus_1", id)
+	require.Equal(t, "customer_u1", idem)
+	require.Equal(t, []string{"a@b.c"}, form["email"])
+	require.Equal(t, []string{"u1"}, form["metadata[user_id]"])
+}
+
+func TestSetupIntents(t *testing.T) {
+	var form map[string][]string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.Path == "/v1/setup_intents":
+			body, _ := io.ReadAll(r.Body)
+			form = parseFormBody(string(body))
+			_, _ = w.Write([]byte(`{"id":"seti_1","client_secret":"seti_1_secret","status":"requires_payment_method","customer":"cus_1","payment_method":null}`))
+		case r.URL.Path == "/v1/setup_intents/seti_1":
+			require.Equal(t, []string{"payment_method"}, r.URL.Query()["expand[]"])
+			_, _ = w.Write([]byte(`{"id":"seti_1","status":"succeeded","customer":"cus_1",` +
+				`"payment_method":{"id":"pm_1","card":{"brand":"visa","last4":"4242"}}}`))
+		case r.URL.Path == "/v1/setup_intents/seti_2":
+			_, _ = w.Write([]byte(`{"id":"seti_2","status":"succeeded","customer":"cus_1","payment_method":"pm_2"}`))
+		default:
+			t.Fatalf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}))
+	defer srv.Close()
+	p := NewProvider(Config{SecretKey: "sk_test", APIBase: srv.URL})
+
+	created, err := p.CreateSetupIntent(t.Context(), "cus_1")
+	require.NoError(t, err)
+	require.Equal(t, "seti_1_secret", created.ClientSecret)
+	require.Nil(t, created.PaymentMethod)
+	require.Equal(t, []string{"cus_1"}, form["customer"])
+
+	got, err := p.GetSetupIntent(t.Context(), "seti_1")
+	require.NoError(t, err)
+	require.Equal(t, payment.SetupIntentStatusSucceeded, got.Status)
+	require.Equal(t, "cus_1", got.CustomerID)
+	require.Equal(t, &payment.PaymentMethod{ID: "pm_1", Brand: "visa", Last4: "4242"}, got.PaymentMethod)
+
+	unexpanded, err := p.GetSetupIntent(t.Context(), "seti_2")
+	require.NoError(t, err)
+	require.Equal(t, &payment.PaymentMethod{ID: "pm_2"}, unexpanded.PaymentMethod)
+}
+
+func TestDetachPaymentMethod(t *testing.T) {
+	var path string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		path = r.URL.Path
+		_, _ = w.Write([]byte(`{"id":"pm_1"}`))
+	}))
+	defer srv.Close()
+
+	p := NewProvider(Config{SecretKey: "sk_test", APIBase: srv.URL})
+	require.NoError(t, p.DetachPaymentMethod(t.Context(), "pm_1"))
+	require.Equal(t, "/v1/payment_methods/pm_1/detach", path)
+}
@@ -0,0 +1,114 @@
+// Package shipping prices delivery: the shipping methods a buyer picks from at checkout and
+// the rate providers that price them.
+package shipping
+
+import (
+	"fmt"
+	"strings"
+
+	"goshop/pkg/currency"
+	"goshop/pkg/money"
+)
+
+// Standard is the method a shop without configured methods offers, free of charge.
+const Standard = "standard"
+
+// Method is a way an order can be delivered. Provider prices it; Rule is how the provider was
+// configured.
+type Method struct {
+	Code     string
+	Name     string
+	Rule     Rule
+	Provider RateProvider
+}
+
+// NewMethod builds a method priced by rule, amounts in base.
+func NewMethod(code, name string, rule Rule, base string) (Method, error) {
+	provider, err := rule.Provider(base)
+	if err != nil {
+		return Method{}, err
+	}
+	return Method{Code: strings.ToLower(code), Name: name, Rule: rule, Provider: provider}, nil
+}
+
+// Rate prices the parcel in the shop's base currency; ok is false when the method doesn't ship
+// it.
+func (m Method) Rate(p Parcel) (money.Money, bool) {
+	return m.Provider.Rate(p)
+}
+
+// Methods is the shop's list of shipping methods; the first one is the default.
+type Methods struct {
+	list []Method
+}
+
+// NewMethods lists methods, the default first.
+func NewMethods(list []Method) *Methods {
+	return &Methods{list: append([]Method(nil), list...)}
+}
+
+// ParseMethods reads flat-rate methods written as "standard:4.99,express:14.99", each rate in
+// major units of base. An empty spec offers Standard alone, for free.
+func ParseMethods(base, spec string) (*Methods, error) {
+	if base == "" {
+		base = currency.Default
+	}
+	m := &Methods{}
+	seen := make(map[string]bool)
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		code, value, ok := strings.Cut(entry, ":")
+		code = strings.ToLower(strings.TrimSpace(code))
+		if !ok || code == "" || seen[code] {
+			return nil, fmt.Errorf("shipping: invalid shipping method %q", entry)
+		}
+		rate, err := money.Parse(strings.TrimSpace(value), base)
+		if err != nil || rate.IsNegative() {
+			return nil, fmt.Errorf("shipping: invalid shipping method %q", entry)
+		}
+		seen[code] = true
+		m.list = append(m.list, flatMethod(code, rate))
+	}
+	if len(m.list) == 0 {
+		m.list = []Method{flatMethod(Standard, money.Zero(base))}
+	}
+	return m, nil
+}
+
+// MustParseMethods is ParseMethods for configuration validated at startup; it panics on error.
+func MustParseMethods(base, spec string) *Methods {
+	m, err := ParseMethods(base, spec)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func flatMethod(code string, rate money.Money) Method {
+	return Method{Code: code, Rule: Flat(rate), Provider: FlatRate{Amount: rate}}
+}
+
+// Get returns the method with code; an empty code is the default method.
+func (m *Methods) Get(code string) (Method, bool) {
+	if len(m.list) == 0 {
+		return Method{}, false
+	}
+	if code == "" {
+		return m.list[0], true
+	}
+	code = strings.ToLower(code)
+	for _, method := range m.list {
+		if method.Code == code {
+			return method, true
+		}
+	}
+	return Method{}, false
+}
+
+// List returns the methods, default first.
+func (m *Methods) List() []Method {
+	return append([]Method(nil), m.list...)
+}
@@ -0,0 +1,55 @@
+package shipping
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"goshop/pkg/money"
+)
+
+func TestParseMethods(t *testing.T) {
+	m, err := ParseMethods("USD", " Standard:4.99, express:14.99 ,")
+	require.NoError(t, err)
+	require.Equal(t, []Method{
+		{Code: "standard", Rule: Flat(money.New(499, "USD")), Provider: FlatRate{Amount: money.New(499, "USD")}},
+		{Code: "express", Rule: Flat(money.New(1499, "USD")), Provider: FlatRate{Amount: money.New(1499, "USD")}},
+	}, m.List())
+
+	def, ok := m.Get("")
+	require.True(t, ok)
+	require.Equal(t, "standard", def.Code)
+	express, ok := m.Get("EXPRESS")
+	require.True(t, ok)
+	rate, ok := express.Rate(Parcel{Country: "VN"})
+	require.True(t, ok)
+	require.Equal(t, money.New(1499, "USD"), rate)
+	_, ok = m.Get("drone")
+	require.False(t, ok)
+
+	for _, spec := range []string{"standard", "standard:", "standard:abc", "standard:-1", ":1", "a:1,a:2"} {
+		_, err := ParseMethods("USD", spec)
+		require.Error(t, err, spec)
+	}
+}
+
+func TestParseMethods_EmptyIsFreeStandard(t *testing.T) {
+	m, err := ParseMethods("EUR", "")
+	require.NoError(t, err)
+	require.Equal(t, []Method{{Code: Standard, Rule: Flat(money.Zero("EUR")), Provider: FlatRate{Amount: money.Zero("EUR")}}}, m.List())
+	require.Panics(t, func() { MustParseMethods("USD", "x:y") })
+}
+
+func TestNewMethods(t *testing.T) {
+	express, err := NewMethod("Express", "Express", Flat(money.New(999, "USD")), "USD")
+	require.NoError(t, err)
+	m := NewMethods([]Method{express})
+	got, ok := m.Get("")
+	require.True(t, ok)
+	require.Equal(t, "express", got.Code)
+
+	_, ok = NewMethods(nil).Get("")
+	require.False(t, ok)
+	_, err = NewMethod("x", "", Rule{Type: "carrier"}, "USD")
+	require.Error(t, err)
+}
@@ -0,0 +1,256 @@
+package shipping
+
+import (
+	"fmt"
+	"strings"
+
+	"goshop/pkg/money"
+)
+
+// Parcel is what a rate is computed for: where it goes, what its lines cost in the shop's base
+// currency, and what they weigh.
+type Parcel struct {
+	Country  string
+	Subtotal money.Money
+	Grams    int64
+}
+
+// RateProvider prices a parcel in the shop's base currency. ok is false when the provider
+// doesn't ship the parcel, such as to a country outside its zones or above its last tier.
+type RateProvider interface {
+	Rate(p Parcel) (rate money.Money, ok bool)
+}
+
+// FlatRate charges the same for every parcel.
+type FlatRate struct {
+	Amount money.Money
+}
+
+func (f FlatRate) Rate(Parcel) (money.Money, bool) {
+	return f.Amount, true
+}
+
+// WeightTier charges Rate for parcels up to UpToGrams; 0 has no upper bound.
+type WeightTier struct {
+	UpToGrams int64
+	Rate      money.Money
+}
+
+// WeightTiers charges by the first tier the parcel's weight fits in, tiers in ascending order.
+type WeightTiers []WeightTier
+
+func (t WeightTiers) Rate(p Parcel) (money.Money, bool) {
+	for _, tier := range t {
+		if tier.UpToGrams == 0 || p.Grams <= tier.UpToGrams {
+			return tier.Rate, true
+		}
+	}
+	return money.Money{}, false
+}
+
+// PriceTier charges Rate for parcels whose subtotal is up to UpTo; zero has no upper bound.
+type PriceTier struct {
+	UpTo money.Money
+	Rate money.Money
+}
+
+// PriceTiers charges by the first tier the parcel's subtotal fits in, tiers in ascending order.
+type PriceTiers []PriceTier
+
+func (t PriceTiers) Rate(p Parcel) (money.Money, bool) {
+	for _, tier := range t {
+		if tier.UpTo.IsZero() || p.Subtotal.Cmp(tier.UpTo) <= 0 {
+			return tier.Rate, true
+		}
+	}
+	return money.Money{}, false
+}
+
+// FreeOver ships parcels whose subtotal reaches Threshold for free and prices the rest with
+// Below.
+type FreeOver struct {
+	Threshold money.Money
+	Below     RateProvider
+}
+
+func (f FreeOver) Rate(p Parcel) (money.Money, bool) {
+	rate, ok := f.Below.Rate(p)
+	if !ok {
+		return money.Money{}, false
+	}
+	if p.Subtotal.Cmp(f.Threshold) >= 0 {
+		return money.Zero(rate.Currency()), true
+	}
+	return rate, true
+}
+
+// Zone prices parcels to its countries; a zone without countries covers the rest of the world.
+type Zone struct {
+	Countries []string
+	Provider  RateProvider
+}
+
+// Zones prices a parcel by the first zone listing its country, else by the first zone without
+// countries. Parcels to no zone aren't shipped.
+type Zones []Zone
+
+func (z Zones) Rate(p Parcel) (money.Money, bool) {
+	var rest RateProvider
+	for _, zone := range z {
+		if len(zone.Countries) == 0 {
+			if rest == nil {
+				rest = zone.Provider
+			}
+			continue
+		}
+		for _, country := range zone.Countries {
+			if strings.EqualFold(country, p.Country) {
+				return zone.Provider.Rate(p)
+			}
+		}
+	}
+	if rest == nil {
+		return money.Money{}, false
+	}
+	return rest.Rate(p)
+}
+
+// Rule types, one per RateProvider.
+const (
+	RuleFlat        = "flat"
+	RuleWeightTiers = "weight_tiers"
+	RulePriceTiers  = "price_tiers"
+	RuleFreeOver    = "free_over"
+	RuleZones       = "zones"
+)
+
+// Rule is a RateProvider as admins write it. Type picks the provider and which of the other
+// fields it reads; free_over and zones nest further rules.
+type Rule struct {
+	Type string `json:"type"`
+	// Amount is the flat rate.
+	Amount money.Money `json:"amount"`
+	// Tiers are weight or price tiers, in ascending order.
+	Tiers []Tier `json:"tiers,omitempty"`
+	// Threshold and Below make a free_over rule.
+	Threshold money.Money `json:"threshold"`
+	Below     *Rule       `json:"below,omitempty"`
+	// Zones make a zones rule.
+	Zones []ZoneRule `json:"zones,omitempty"`
+}
+
+// Tier is one row of a tier table: up_to_grams for weight tiers, up_to for price tiers. The
+// last tier may leave its bound out to catch everything above.
+type Tier struct {
+	UpToGrams int64       `json:"up_to_grams,omitempty"`
+	UpTo      money.Money `json:"up_to"`
+	Rate      money.Money `json:"rate"`
+}
+
+// ZoneRule is a zone as admins write it; no countries is the rest of the world.
+type ZoneRule struct {
+	Countries []string `json:"countries,omitempty"`
+	Rule      Rule     `json:"rule"`
+}
+
+// Flat is the rule for a flat rate.
+func Flat(amount money.Money) Rule {
+	return Rule{Type: RuleFlat, Amount: amount}
+}
+
+// Provider builds the rule's RateProvider. Every amount must be non-negative and in base, and
+// tier bounds must ascend.
+func (r Rule) Provider(base string) (RateProvider, error) {
+	switch r.Type {
+	case RuleFlat:
+		if err := checkAmount("amount", r.Amount, base); err != nil {
+			return nil, err
+		}
+		return FlatRate{Amount: r.Amount.WithCurrency(base)}, nil
+	case RuleWeightTiers:
+		return r.weightTiers(base)
+	case RulePriceTiers:
+		return r.priceTiers(base)
+	case RuleFreeOver:
+		if r.Below == nil {
+			return nil, fmt.Errorf("shipping: a %s rule needs a below rule", RuleFreeOver)
+		}
+		if err := checkAmount("threshold", r.Threshold, base); err != nil {
+			return nil, err
+		}
+		below, err := r.Below.Provider(base)
+		if err != nil {
+			return nil, err
+		}
+		return FreeOver{Threshold: r.Threshold.WithCurrency(base), Below: below}, nil
+	case RuleZones:
+		if len(r.Zones) == 0 {
+			return nil, fmt.Errorf("shipping: a %s rule needs zones", RuleZones)
+		}
+		zones := make(Zones, len(r.Zones))
+		for i, zone := range r.Zones {
+			provider, err := zone.Rule.Provider(base)
+			if err != nil {
+				return nil, err
+			}
+			countries := make([]string, len(zone.Countries))
+			for j, country := range zone.Countries {
+				countries[j] = strings.ToUpper(strings.TrimSpace(country))
+			}
+			zones[i] = Zone{Countries: countries, Provider: provider}
+		}
+		return zones, nil
+	}
+	return nil, fmt.Errorf("shipping: unknown rule type %q", r.Type)
+}
+
+func (r Rule) weightTiers(base string) (RateProvider, error) {
+	if len(r.Tiers) == 0 {
+		return nil, fmt.Errorf("shipping: a %s rule needs tiers", RuleWeightTiers)
+	}
+	tiers := make(WeightTiers, len(r.Tiers))
+	var last int64
+	for i, tier := range r.Tiers {
+		if err := checkAmount("rate", tier.Rate, base); err != nil {
+			return nil, err
+		}
+		open := tier.UpToGrams == 0
+		if tier.UpToGrams < 0 || (open && i != len(r.Tiers)-1) || (!open && tier.UpToGrams <= last) {
+			return nil, fmt.Errorf("shipping: weight tiers need ascending up_to_grams, open only on the last tier")
+		}
+		last = tier.UpToGrams
+		tiers[i] = WeightTier{UpToGrams: tier.UpToGrams, Rate: tier.Rate.WithCurrency(base)}
+	}
+	return tiers, nil
+}
+
+func (r Rule) priceTiers(base string) (RateProvider, error) {
+	if len(r.Tiers) == 0 {
+		return nil, fmt.Errorf("shipping: a %s rule needs tiers", RulePriceTiers)
+	}
+	tiers := make(PriceTiers, len(r.Tiers))
+	last := money.Zero(base)
+	for i, tier := range r.Tiers {
+		if err := checkAmount("rate", tier.Rate, base); err != nil {
+			return nil, err
+		}
+		if err := checkAmount("up_to", tier.UpTo, base); err != nil {
+			return nil, err
+		}
+		open := tier.UpTo.IsZero()
+		if (open && i != len(r.Tiers)-1) || (!open && tier.UpTo.Cmp(last) <= 0) {
+			return nil, fmt.Errorf("shipping: price tiers need ascending up_to, open only on the last tier")
+		}
+		last = tier.UpTo.WithCurrency(base)
+		tiers[i] = PriceTier{UpTo: last, Rate: tier.Rate.WithCurrency(base)}
+	}
+	return tiers, nil
+}
+
+// checkAmount requires a non-negative amount in base; a zero amount may leave the currency out.
+func checkAmount(field string, m money.Money, base string) error {
+	if m.IsNegative() || (!m.IsZero() && m.Currency() != base) {
+		return fmt.Errorf("shipping: %s must be a non-negative amount in %s", field, base)
+	}
+	return nil
+}
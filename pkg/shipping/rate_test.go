@@ -0,0 +1,135 @@
+package shipping
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"goshop/pkg/money"
+)
+
+func usd(amount int64) money.Money { return money.New(amount, "USD") }
+
+func TestWeightTiers(t *testing.T) {
+	tiers := WeightTiers{{UpToGrams: 500, Rate: usd(300)}, {UpToGrams: 2000, Rate: usd(700)}}
+	tests := []struct {
+		grams int64
+		want  money.Money
+		ok    bool
+	}{
+		{grams: 0, want: usd(300), ok: true},
+		{grams: 500, want: usd(300), ok: true},
+		{grams: 501, want: usd(700), ok: true},
+		{grams: 2001, ok: false},
+	}
+	for _, tt := range tests {
+		rate, ok := tiers.Rate(Parcel{Grams: tt.grams})
+		require.Equal(t, tt.ok, ok, tt.grams)
+		require.Equal(t, tt.want, rate, tt.grams)
+	}
+
+	open := append(tiers, WeightTier{Rate: usd(1500)})
+	rate, ok := open.Rate(Parcel{Grams: 90000})
+	require.True(t, ok)
+	require.Equal(t, usd(1500), rate)
+}
+
+func TestPriceTiers(t *testing.T) {
+	tiers := PriceTiers{{UpTo: usd(2000), Rate: usd(599)}, {Rate: usd(299)}}
+	rate, _ := tiers.Rate(Parcel{Subtotal: usd(2000)})
+	require.Equal(t, usd(599), rate)
+	rate, _ = tiers.Rate(Parcel{Subtotal: usd(2001)})
+	require.Equal(t, usd(299), rate)
+}
+
+func TestFreeOver(t *testing.T) {
+	f := FreeOver{Threshold: usd(5000), Below: FlatRate{Amount: usd(499)}}
+	rate, ok := f.Rate(Parcel{Subtotal: usd(4999)})
+	require.True(t, ok)
+	require.Equal(t, usd(499), rate)
+	rate, ok = f.Rate(Parcel{Subtotal: usd(5000)})
+	require.True(t, ok)
+	require.Equal(t, money.Zero("USD"), rate)
+
+	// Free shipping doesn't reach parcels the rate below doesn't ship.
+	_, ok = FreeOver{Threshold: usd(1), Below: WeightTiers{{UpToGrams: 10, Rate: usd(1)}}}.Rate(Parcel{Subtotal: usd(100), Grams: 11})
+	require.False(t, ok)
+}
+
+func TestZones(t *testing.T) {
+	zones := Zones{
+		{Countries: []string{"VN", "TH"}, Provider: FlatRate{Amount: usd(200)}},
+		{Countries: []string{"US"}, Provider: FlatRate{Amount: usd(500)}},
+	}
+	rate, ok := zones.Rate(Parcel{Country: "vn"})
+	require.True(t, ok)
+	require.Equal(t, usd(200), rate)
+	_, ok = zones.Rate(Parcel{Country: "DE"})
+	require.False(t, ok)
+
+	world := append(Zones{{Provider: FlatRate{Amount: usd(1500)}}}, zones...)
+	rate, _ = world.Rate(Parcel{Country: "US"})
+	require.Equal(t, usd(500), rate)
+	rate, ok = world.Rate(Parcel{Country: "DE"})
+	require.True(t, ok)
+	require.Equal(t, usd(1500), rate)
+}
+
+func TestRuleProvider(t *testing.T) {
+	var rule Rule
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"type": "zones",
+		"zones": [
+			{"countries": ["vn"], "rule": {"type": "flat", "amount": {"amount": "2.00", "currency": "USD"}}},
+			{"rule": {
+				"type": "free_over",
+				"threshold": {"amount": "50", "currency": "USD"},
+				"below": {"type": "weight_tiers", "tiers": [
+					{"up_to_grams": 1000, "rate": {"amount": "5", "currency": "USD"}},
+					{"rate": {"amount": "12", "currency": "USD"}}
+				]}
+			}}
+		]
+	}`), &rule))
+	provider, err := rule.Provider("USD")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		parcel Parcel
+		want   money.Money
+	}{
+		{name: "zone", parcel: Parcel{Country: "VN", Subtotal: usd(100), Grams: 5000}, want: usd(200)},
+		{name: "light", parcel: Parcel{Country: "DE", Subtotal: usd(1000), Grams: 800}, want: usd(500)},
+		{name: "heavy", parcel: Parcel{Country: "DE", Subtotal: usd(1000), Grams: 8000}, want: usd(1200)},
+		{name: "free", parcel: Parcel{Country: "DE", Subtotal: usd(5000), Grams: 8000}, want: money.Zero("USD")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, ok := provider.Rate(tt.parcel)
+			require.True(t, ok)
+			require.Equal(t, tt.want, rate)
+		})
+	}
+}
+
+func TestRuleProvider_Invalid(t *testing.T) {
+	tests := map[string]Rule{
+		"unknown_type":       {Type: "carrier"},
+		"negative_flat":      Flat(usd(-1)),
+		"other_currency":     Flat(money.New(100, "EUR")),
+		"no_tiers":           {Type: RuleWeightTiers},
+		"descending_weights": {Type: RuleWeightTiers, Tiers: []Tier{{UpToGrams: 500, Rate: usd(1)}, {UpToGrams: 100, Rate: usd(2)}}},
+		"open_tier_first":    {Type: RulePriceTiers, Tiers: []Tier{{Rate: usd(1)}, {UpTo: usd(100), Rate: usd(2)}}},
+		"free_over_no_below": {Type: RuleFreeOver, Threshold: usd(100)},
+		"no_zones":           {Type: RuleZones},
+		"bad_zone_rule":      {Type: RuleZones, Zones: []ZoneRule{{Rule: Rule{Type: RuleFlat, Amount: usd(-5)}}}},
+	}
+	for name, rule := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := rule.Provider("USD")
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package stock
+
+import (
+	"sort"
+	"strings"
+)
+
+// AllocationStrategy picks the order in which warehouses are drawn from to fill a line.
+type AllocationStrategy string
+
+const (
+	// AllocateNearest prefers warehouses in the destination's city, then its country, falling
+	// back to warehouse priority within each tier. It is the default.
+	AllocateNearest AllocationStrategy = "nearest"
+	// AllocatePriority ignores the destination and draws by warehouse priority alone.
+	AllocatePriority AllocationStrategy = "priority"
+)
+
+// Destination is where an order ships to. Empty fields match no warehouse.
+type Destination struct {
+	Country string
+	City    string
+}
+
+// Location is one warehouse's available stock of a product.
+type Location struct {
+	WarehouseID string
+	Country     string
+	City        string
+	// Priority ranks warehouses, lowest first.
+	Priority  int
+	Available int
+}
+
+// Allocation is the quantity to reserve at one warehouse.
+type Allocation struct {
+	WarehouseID string
+	Quantity    int
+}
+
+// Allocate splits qty across locations, drawing each warehouse dry in strategy order before
+// moving to the next, so a line ships from as few warehouses as the ranking allows. It
+// reports false, with no allocations, when the locations together hold less than qty.
+func Allocate(locations []Location, qty int, dest Destination, strategy AllocationStrategy) ([]Allocation, bool) {
+	ranked := make([]Location, 0, len(locations))
+	total := 0
+	for _, l := range locations {
+		if l.Available > 0 {
+			ranked = append(ranked, l)
+			total += l.Available
+		}
+	}
+	if qty <= 0 || total < qty {
+		return nil, false
+	}
+
+	sort.SliceStable(ranked, func(i, j int) bool {
+		if strategy != AllocatePriority {
+			if di, dj := distance(ranked[i], dest), distance(ranked[j], dest); di != dj {
+				return di < dj
+			}
+		}
+		if ranked[i].Priority != ranked[j].Priority {
+			return ranked[i].Priority < ranked[j].Priority
+		}
+		return ranked[i].WarehouseID < ranked[j].WarehouseID
+	})
+
+	out := make([]Allocation, 0, 1)
+	for _, l := range ranked {
+		take := min(l.Available, qty)
+		out = append(out, Allocation{WarehouseID: l.WarehouseID, Quantity: take})
+		qty -= take
+		if qty == 0 {
+			break
+		}
+	}
+	return out, true
+}
+
+// distance buckets a warehouse relative to dest: 0 same city, 1 same country, 2 elsewhere.
+func distance(l Location, dest Destination) int {
+	if dest.Country == "" || !strings.EqualFold(l.Country, dest.Country) {
+		return 2
+	}
+	if dest.City != "" && strings.EqualFold(l.City, dest.City) {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,71 @@
+package stock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var locations = []Location{
+	{WarehouseID: "hcm", Country: "VN", City: "Ho Chi Minh City", Priority: 1, Available: 5},
+	{WarehouseID: "han", Country: "VN", City: "Hanoi", Priority: 2, Available: 5},
+	{WarehouseID: "sg", Country: "SG", City: "Singapore", Priority: 0, Available: 10},
+	{WarehouseID: "empty", Country: "VN", City: "Hanoi", Priority: 0, Available: 0},
+}
+
+func TestAllocate(t *testing.T) {
+	tests := []struct {
+		name     string
+		qty      int
+		dest     Destination
+		strategy AllocationStrategy
+		want     []Allocation
+	}{
+		{
+			name: "same city first",
+			qty:  3, dest: Destination{Country: "vn", City: "hanoi"}, strategy: AllocateNearest,
+			want: []Allocation{{WarehouseID: "han", Quantity: 3}},
+		},
+		{
+			name: "spills to same country, then abroad",
+			qty:  12, dest: Destination{Country: "VN", City: "Hanoi"}, strategy: AllocateNearest,
+			want: []Allocation{{WarehouseID: "han", Quantity: 5}, {WarehouseID: "hcm", Quantity: 5}, {WarehouseID: "sg", Quantity: 2}},
+		},
+		{
+			name: "same country ranks by priority",
+			qty:  6, dest: Destination{Country: "VN", City: "Da Nang"}, strategy: AllocateNearest,
+			want: []Allocation{{WarehouseID: "hcm", Quantity: 5}, {WarehouseID: "han", Quantity: 1}},
+		},
+		{
+			name: "no destination falls back to priority",
+			qty:  4, strategy: AllocateNearest,
+			want: []Allocation{{WarehouseID: "sg", Quantity: 4}},
+		},
+		{
+			name: "empty strategy means nearest",
+			qty:  1, dest: Destination{Country: "VN", City: "Hanoi"},
+			want: []Allocation{{WarehouseID: "han", Quantity: 1}},
+		},
+		{
+			name: "priority ignores destination",
+			qty:  11, dest: Destination{Country: "VN", City: "Hanoi"}, strategy: AllocatePriority,
+			want: []Allocation{{WarehouseID: "sg", Quantity: 10}, {WarehouseID: "hcm", Quantity: 1}},
+		},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			got, ok := Allocate(locations, tc.qty, tc.dest, tc.strategy)
+			assert.True(t, ok)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestAllocate_Insufficient(t *testing.T) {
+	got, ok := Allocate(locations, 21, Destination{}, AllocateNearest)
+	assert.False(t, ok)
+	assert.Nil(t, got)
+
+	_, ok = Allocate(nil, 1, Destination{}, AllocateNearest)
+	assert.False(t, ok)
+}
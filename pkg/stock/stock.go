@@ -0,0 +1,81 @@
+// Package stock holds the stock-level rules shared by the product and order domains, so the
+// customer-facing "Low stock" badge and the admin LowStock alert agree on the same boundary,
+// plus the ledger movement types and the warehouse allocation rules.
+package stock
+
+// DefaultLowStockThreshold applies to products whose product and category set none.
+const DefaultLowStockThreshold = 5
+
+// LowStockThreshold resolves the effective threshold: the product's own, else its category's,
+// else DefaultLowStockThreshold.
+func LowStockThreshold(product, category *int) int {
+	if product != nil {
+		return *product
+	}
+	if category != nil {
+		return *category
+	}
+	return DefaultLowStockThreshold
+}
+
+// ReorderQuantity resolves how many units to reorder when a product runs low: the product's
+// own, else its category's. Zero means no reorder point is configured.
+func ReorderQuantity(product, category *int) int {
+	if product != nil {
+		return *product
+	}
+	if category != nil {
+		return *category
+	}
+	return 0
+}
+
+// IsLow reports whether available units are at or below threshold.
+func IsLow(available, threshold int) bool {
+	return available <= threshold
+}
+
+// MovementKind classifies a change to a product's stock_quantity or reserved_quantity.
+type MovementKind string
+
+const (
+	// MovementOpening seeds the ledger with a product's counters: at creation, or for
+	// products that existed before the ledger.
+	MovementOpening MovementKind = "opening"
+	// MovementRestock is an admin adding units through the restock endpoint.
+	MovementRestock MovementKind = "restock"
+	// MovementAdjustment is an admin overwriting stock_quantity on a product update.
+	MovementAdjustment MovementKind = "adjustment"
+	// MovementReserve holds units for a placed order.
+	MovementReserve MovementKind = "reserve"
+	// MovementCommit turns a reservation into a sale once payment clears.
+	MovementCommit MovementKind = "commit"
+	// MovementRelease returns reserved units when an order is cancelled.
+	MovementRelease MovementKind = "release"
+	// MovementReturn puts committed units back on hand when an order that had taken them is
+	// cancelled before they shipped.
+	MovementReturn MovementKind = "return"
+	// MovementExpire returns reserved units the sweeper reclaimed from an unpaid order.
+	MovementExpire MovementKind = "expire"
+	// MovementRepair corrects reserved_quantity to match the product's active reservations.
+	MovementRepair MovementKind = "repair"
+)
+
+// ActorSystem is recorded as the actor of movements no user initiated.
+const ActorSystem = "system"
+
+// Movement is one change to a product's counters, recorded in the stock ledger after the
+// counters are updated and in the same transaction. Deltas are signed.
+type Movement struct {
+	ProductID     string
+	Kind          MovementKind
+	StockDelta    int
+	ReservedDelta int
+	Actor         string
+	Reason        string
+	OrderID       string
+	ReservationID string
+	// WarehouseID is the warehouse whose stock moved; empty for movements of the product total
+	// only, like the opening balance.
+	WarehouseID string
+}
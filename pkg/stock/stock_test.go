@@ -0,0 +1,39 @@
+package stock
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intPtr(v int) *int { return &v }
+
+func TestLowStockThreshold(t *testing.T) {
+	tests := []struct {
+		name              string
+		product, category *int
+		want              int
+	}{
+		{"product wins", intPtr(2), intPtr(10), 2},
+		{"product zero wins", intPtr(0), intPtr(10), 0},
+		{"category fallback", nil, intPtr(10), 10},
+		{"default", nil, nil, DefaultLowStockThreshold},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, LowStockThreshold(tc.product, tc.category))
+		})
+	}
+}
+
+func TestReorderQuantity(t *testing.T) {
+	assert.Equal(t, 20, ReorderQuantity(intPtr(20), intPtr(50)))
+	assert.Equal(t, 50, ReorderQuantity(nil, intPtr(50)))
+	assert.Zero(t, ReorderQuantity(nil, nil))
+}
+
+func TestIsLow(t *testing.T) {
+	assert.True(t, IsLow(5, 5))
+	assert.True(t, IsLow(0, 0))
+	assert.False(t, IsLow(6, 5))
+}
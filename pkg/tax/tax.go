@@ -0,0 +1,76 @@
+// Package tax works out the sales tax on order lines: which rate applies to a product class at
+// a destination, and how much of a price it comes to.
+package tax
+
+import (
+	"strings"
+
+	"goshop/pkg/money"
+)
+
+// Mode says whether the shop's prices include tax.
+type Mode string
+
+const (
+	// Exclusive prices are net: tax is added on top at checkout. It is the default.
+	Exclusive Mode = "exclusive"
+	// Inclusive prices already contain their tax, which is carved out of them at checkout.
+	Inclusive Mode = "inclusive"
+)
+
+// Inclusive reports whether prices include tax; any mode but Inclusive is Exclusive.
+func (m Mode) Inclusive() bool {
+	return m == Inclusive
+}
+
+// Standard is the class of products and categories that don't name one.
+const Standard = "standard"
+
+// Rate is the tax on one class of products at a destination. An empty Region covers the rest of
+// Country, and an empty Country every destination no other rate covers.
+type Rate struct {
+	Country string
+	Region  string
+	Class   string
+	Percent money.Percent
+}
+
+// Lookup returns the percentage for class at country and region: a rate for the region wins over
+// one for the whole country, which wins over one for every country. ok is false when no rate
+// covers the destination, which is untaxed.
+func Lookup(rates []Rate, country, region, class string) (p money.Percent, ok bool) {
+	if class == "" {
+		class = Standard
+	}
+	best := -1
+	for _, rate := range rates {
+		if !strings.EqualFold(rate.Class, class) {
+			continue
+		}
+		var score int
+		switch {
+		case rate.Country == "" && rate.Region == "":
+		case !strings.EqualFold(rate.Country, country):
+			continue
+		case rate.Region == "":
+			score = 1
+		case strings.EqualFold(rate.Region, region):
+			score = 2
+		default:
+			continue
+		}
+		if score > best {
+			best, p = score, rate.Percent
+		}
+	}
+	return p, best >= 0
+}
+
+// Of is the tax in amount at p: p of it when amount is net, the share of it that is tax when
+// amount already includes it.
+func Of(amount money.Money, p money.Percent, mode Mode) money.Money {
+	if mode.Inclusive() {
+		return amount.Prorate(int64(p), int64(money.Whole+p))
+	}
+	return amount.Percent(p)
+}
@@ -0,0 +1,50 @@
+package tax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"goshop/pkg/money"
+)
+
+func TestLookup(t *testing.T) {
+	rates := []Rate{
+		{Country: "US", Class: Standard, Percent: 500},
+		{Country: "US", Region: "CA", Class: Standard, Percent: 725},
+		{Country: "US", Region: "CA", Class: "food", Percent: 0},
+		{Country: "DE", Class: Standard, Percent: 1900},
+		{Country: "DE", Class: "books", Percent: 700},
+		{Class: Standard, Percent: 1000},
+	}
+	tests := []struct {
+		name    string
+		country string
+		region  string
+		class   string
+		want    money.Percent
+		ok      bool
+	}{
+		{name: "region", country: "us", region: "ca", class: Standard, want: 725, ok: true},
+		{name: "rest_of_country", country: "US", region: "NY", class: Standard, want: 500, ok: true},
+		{name: "class_in_region", country: "US", region: "CA", class: "food", want: 0, ok: true},
+		{name: "class_elsewhere", country: "US", region: "NY", class: "food"},
+		{name: "empty_class_is_standard", country: "DE", want: 1900, ok: true},
+		{name: "class", country: "DE", class: "books", want: 700, ok: true},
+		{name: "rest_of_world", country: "VN", class: Standard, want: 1000, ok: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := Lookup(rates, tt.country, tt.region, tt.class)
+			require.Equal(t, tt.ok, ok)
+			require.Equal(t, tt.want, p)
+		})
+	}
+}
+
+func TestOf(t *testing.T) {
+	require.Equal(t, money.New(190, "EUR"), Of(money.New(1000, "EUR"), 1900, Exclusive))
+	require.Equal(t, money.New(160, "EUR"), Of(money.New(1000, "EUR"), 1900, Inclusive))
+	require.Equal(t, money.New(72, "USD"), Of(money.New(999, "USD"), 725, ""))
+	require.Equal(t, money.Zero("USD"), Of(money.New(999, "USD"), 0, Inclusive))
+}
@@ -244,6 +244,27 @@ ON CONFLICT DO NOTHING;
 
 UPDATE products SET reserved_quantity = reserved_quantity + 1 WHERE id IN ('prod-001', 'prod-004');
 
+-- All seeded stock sits in the default warehouse created by migration 0011.
+INSERT INTO warehouse_stocks (id, warehouse_id, product_id, stock_quantity, reserved_quantity, created_at, updated_at)
+SELECT gen_random_uuid()::text, w.id, p.id, p.stock_quantity, p.reserved_quantity, NOW(), NOW()
+FROM products p
+CROSS JOIN warehouses w
+WHERE w.is_default AND w.deleted_at IS NULL
+  AND NOT EXISTS (SELECT 1 FROM warehouse_stocks s WHERE s.product_id = p.id);
+
+UPDATE stock_reservations
+SET warehouse_id = (SELECT id FROM warehouses WHERE is_default AND deleted_at IS NULL)
+WHERE id IN ('res-demo-1a', 'res-demo-1b') AND warehouse_id IS NULL;
+
+-- Opening stock ledger rows for the seeded counters, so the admin reconciliation
+-- check starts clean.
+INSERT INTO stock_ledger_entries (id, created_at, product_id, kind, stock_delta, reserved_delta,
+  stock_before, stock_after, reserved_before, reserved_after, actor, reason)
+SELECT gen_random_uuid()::text, NOW(), p.id, 'opening', p.stock_quantity, p.reserved_quantity,
+  0, p.stock_quantity, 0, p.reserved_quantity, 'system', 'seed'
+FROM products p
+WHERE NOT EXISTS (SELECT 1 FROM stock_ledger_entries l WHERE l.product_id = p.id);
+
 -- Stripe payment record (pending — webhook will flip status to 'succeeded')
 INSERT INTO payments (id, order_id, provider, provider_intent_id, amount, currency, status, created_at, updated_at)
 VALUES
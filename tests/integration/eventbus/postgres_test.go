@@ -0,0 +1,90 @@
+//go:build integration
+
+package tests_eventbus
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"goshop/pkg/eventbus"
+	"goshop/tests/testutil"
+)
+
+type seen struct {
+	mu  sync.Mutex
+	ids []string
+}
+
+func (s *seen) handle(_ context.Context, ev eventbus.Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ids = append(s.ids, ev.(eventbus.OrderCreated).OrderID)
+}
+
+func (s *seen) get() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.ids...)
+}
+
+func run(t *testing.T, bus eventbus.Bus) context.CancelFunc {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		bus.(eventbus.Runner).Run(ctx)
+		close(done)
+	}()
+	t.Cleanup(func() {
+		cancel()
+		<-done
+	})
+	return cancel
+}
+
+// TestPostgresBus_SharedAcrossReplicasAndRestarts: two replicas subscribed to the same topic
+// handle every event exactly once between them, and a replica that comes back after
+// shutdown resumes from the stored offset instead of replaying or skipping events.
+func TestPostgresBus_SharedAcrossReplicasAndRestarts(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.StartPostgres(ctx, t)
+	require.NoError(t, testutil.ApplyMigrations(db))
+
+	opts := func(consumer string) eventbus.DurableOptions {
+		return eventbus.DurableOptions{
+			Group:        "it",
+			Consumer:     consumer,
+			PollInterval: 50 * time.Millisecond,
+			ClaimAfter:   time.Second,
+		}
+	}
+
+	var a, b seen
+	replicaA := eventbus.NewPostgres(db.GetDB(), opts("a"))
+	replicaA.Subscribe(eventbus.TopicOrderCreated, a.handle)
+	replicaB := eventbus.NewPostgres(db.GetDB(), opts("b"))
+	replicaB.Subscribe(eventbus.TopicOrderCreated, b.handle)
+	stopA := run(t, replicaA)
+	run(t, replicaB)
+
+	require.Eventually(t, func() bool {
+		var n int64
+		db.GetDB().Raw(`SELECT count(*) FROM eventbus_subscriptions`).Scan(&n)
+		return n == 1
+	}, 5*time.Second, 50*time.Millisecond)
+
+	for _, id := range []string{"o1", "o2", "o3"} {
+		require.NoError(t, replicaA.Publish(ctx, eventbus.OrderCreated{OrderPayload: eventbus.OrderPayload{OrderID: id}}))
+	}
+	require.Eventually(t, func() bool { return len(a.get())+len(b.get()) == 3 }, 5*time.Second, 50*time.Millisecond)
+
+	stopA()
+	require.NoError(t, replicaB.Publish(ctx, eventbus.OrderCreated{OrderPayload: eventbus.OrderPayload{OrderID: "o4"}}))
+	require.Eventually(t, func() bool { return len(a.get())+len(b.get()) == 4 }, 5*time.Second, 50*time.Millisecond)
+
+	time.Sleep(200 * time.Millisecond)
+	require.ElementsMatch(t, []string{"o1", "o2", "o3", "o4"}, append(a.get(), b.get()...))
+}
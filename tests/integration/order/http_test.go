@@ -17,6 +17,8 @@ import (
 	productModel "goshop/internal/product/model"
 	userModel "goshop/internal/user/model"
 	"goshop/pkg/jtoken"
+	"goshop/pkg/money"
+	"goshop/tests/testutil"
 )
 
 // Place Order
@@ -28,7 +30,7 @@ func TestOrderAPI_PlaceOrderSuccess(t *testing.T) {
 	p1 := productModel.Product{
 		Name:          "test-product-1",
 		Description:   "test-product-1",
-		Price:         1,
+		Price:         money.New(100, "USD"),
 		StockQuantity: 100,
 	}
 	_ = dbTest.Create(context.Background(), &p1)
@@ -36,10 +38,11 @@ func TestOrderAPI_PlaceOrderSuccess(t *testing.T) {
 	p2 := productModel.Product{
 		Name:          "test-product-2",
 		Description:   "test-product-2",
-		Price:         2,
+		Price:         money.New(200, "USD"),
 		StockQuantity: 100,
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	req := &domain.PlaceOrderReq{
 		Lines: []domain.PlaceOrderLineReq{
@@ -58,15 +61,16 @@ func TestOrderAPI_PlaceOrderSuccess(t *testing.T) {
 	parseResponseResult(writer.Body.Bytes(), &res)
 	assert.Equal(t, http.StatusOK, writer.Code)
 	assert.Equal(t, "pending_payment", res.Status)
-	assert.Equal(t, float64(8), res.TotalPrice)
+	assert.Equal(t, money.New(800, "USD"), res.TotalPrice)
+	assert.Equal(t, "USD", res.Currency)
 	assert.Equal(t, 2, len(res.Lines))
 	assert.Equal(t, req.Lines[0].ProductID, res.Lines[0].Product.ID)
 	assert.Equal(t, req.Lines[0].Quantity, res.Lines[0].Quantity)
-	assert.Equal(t, float64(2), res.Lines[0].Price)
+	assert.Equal(t, money.New(200, "USD"), res.Lines[0].Price)
 
 	assert.Equal(t, req.Lines[1].ProductID, res.Lines[1].Product.ID)
 	assert.Equal(t, req.Lines[1].Quantity, res.Lines[1].Quantity)
-	assert.Equal(t, float64(6), res.Lines[1].Price)
+	assert.Equal(t, money.New(600, "USD"), res.Lines[1].Price)
 }
 
 func TestOrderAPI_PlaceOrderInvalidFieldType(t *testing.T) {
@@ -75,16 +79,17 @@ func TestOrderAPI_PlaceOrderInvalidFieldType(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	req := map[string]interface{}{
 		"lines": []map[string]interface{}{
@@ -111,16 +116,17 @@ func TestOrderAPI_PlaceOrderInvalidMissProductID(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	req := &domain.PlaceOrderReq{
 		Lines: []domain.PlaceOrderLineReq{
@@ -146,16 +152,17 @@ func TestOrderAPI_PlaceOrderInvalidMissQuantity(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	req := &domain.PlaceOrderReq{
 		Lines: []domain.PlaceOrderLineReq{
@@ -181,16 +188,17 @@ func TestOrderAPI_PlaceOrderInvalidProductNotFound(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	req := &domain.PlaceOrderReq{
 		Lines: []domain.PlaceOrderLineReq{
@@ -211,22 +219,43 @@ func TestOrderAPI_PlaceOrderInvalidProductNotFound(t *testing.T) {
 	assert.Equal(t, "Something went wrong", response["error"]["message"])
 }
 
+func TestOrderAPI_PlaceOrderUnsupportedCurrency(t *testing.T) {
+	defer cleanData()
+
+	p1 := productModel.Product{
+		Name:          "test-product-1",
+		Description:   "test-product-1",
+		Price:         money.New(100, "USD"),
+		StockQuantity: 100,
+	}
+	_ = dbTest.Create(context.Background(), &p1)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID)
+
+	req := &domain.PlaceOrderReq{
+		Lines:    []domain.PlaceOrderLineReq{{ProductID: p1.ID, Quantity: 1}},
+		Currency: "XTS",
+	}
+	writer := makeRequest("POST", "/api/v1/orders", req, accessToken())
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
 func TestOrderAPI_PlaceOrderUnauthorized(t *testing.T) {
 	defer cleanData()
 
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	req := &domain.PlaceOrderReq{
 		Lines: []domain.PlaceOrderLineReq{
@@ -260,16 +289,17 @@ func TestOrderAPI_GetOrderByIDSuccess(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o := model.Order{
 		UserID: u.ID,
@@ -322,16 +352,17 @@ func TestOrderAPI_CancelOrderSuccess(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o := model.Order{
 		UserID: u.ID,
@@ -372,16 +403,17 @@ func TestOrderAPI_CancelOrderStatusDone(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o := model.Order{
 		UserID: u.ID,
@@ -418,16 +450,17 @@ func TestOrderAPI_CancelOrderStatusCancelled(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o := model.Order{
 		UserID: u.ID,
@@ -463,16 +496,17 @@ func TestOrderAPI_CancelOrderNotMine(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o := model.Order{
 		UserID: u.ID,
@@ -512,16 +546,17 @@ func TestOrderAPI_ListOrdersSuccess(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o1 := model.Order{
 		UserID: u.ID,
@@ -598,16 +633,17 @@ func TestOrderAPI_ListMyOrdersFindByStatusSuccess(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o1 := model.Order{
 		UserID: u.ID,
@@ -658,16 +694,17 @@ func TestOrderAPI_ListOrdersFindByStatusNotFound(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o1 := model.Order{
 		UserID: u.ID,
@@ -712,16 +749,17 @@ func TestOrderAPI_ListOrdersFindByCodeSuccess(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o1 := model.Order{
 		UserID: u.ID,
@@ -772,16 +810,17 @@ func TestOrderAPI_ListOrdersFindByCodeNotFound(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o1 := model.Order{
 		UserID: u.ID,
@@ -826,16 +865,17 @@ func TestOrderAPI_ListOrdersWithPagination(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o1 := model.Order{
 		UserID: u.ID,
@@ -889,16 +929,17 @@ func TestOrderAPI_ListOrdersWithOrder(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o1 := model.Order{
 		UserID: u.ID,
@@ -952,16 +993,17 @@ func TestOrderAPI_GetMyOrdersNotMine(t *testing.T) {
 	p1 := productModel.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := productModel.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
+	_ = testutil.StockDefaultWarehouse(context.Background(), dbTest, p1.ID, p2.ID)
 
 	o1 := model.Order{
 		UserID: u.ID,
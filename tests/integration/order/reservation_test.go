@@ -13,6 +13,7 @@ import (
 
 	"goshop/internal/order/repository"
 	productModel "goshop/internal/product/model"
+	"goshop/pkg/money"
 	"goshop/tests/testutil"
 )
 
@@ -34,7 +35,7 @@ func TestReserveStock_Concurrency(t *testing.T) {
 	product := &productModel.Product{
 		Name:          "race-product",
 		Code:          "RACE-1",
-		Price:         9.99,
+		Price:         money.New(999, "USD"),
 		StockQuantity: initialStock,
 		Active:        true,
 	}
@@ -83,7 +84,7 @@ func TestReserveCommitRelease(t *testing.T) {
 	require.NoError(t, testutil.ApplyMigrations(db))
 
 	product := &productModel.Product{
-		Name: "lifecycle", Code: "LC-1", Price: 1, StockQuantity: 10, Active: true,
+		Name: "lifecycle", Code: "LC-1", Price: money.New(100, "USD"), StockQuantity: 10, Active: true,
 	}
 	require.NoError(t, db.Create(ctx, product))
 
@@ -10,12 +10,23 @@ import (
 	"github.com/quangdangfit/gocommon/validation"
 	"github.com/stretchr/testify/require"
 
+	inventoryRepo "goshop/internal/inventory/repository"
 	orderModel "goshop/internal/order/model"
 	orderRepo "goshop/internal/order/repository"
 	orderSvc "goshop/internal/order/service"
+	outboxRepo "goshop/internal/outbox/repository"
+	paymentRepo "goshop/internal/payment/repository"
+	paymentSvc "goshop/internal/payment/service"
 	productModel "goshop/internal/product/model"
 	userModel "goshop/internal/user/model"
-	"goshop/pkg/notification"
+	"goshop/pkg/currency"
+	"goshop/pkg/invoice"
+	"goshop/pkg/money"
+	"goshop/pkg/payment"
+	"goshop/pkg/payment/stripe"
+	"goshop/pkg/shipping"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
 	"goshop/tests/testutil"
 )
 
@@ -29,19 +40,25 @@ func TestSweepExpiredReservations_ReleasesAndCancels(t *testing.T) {
 
 	user := &userModel.User{Email: "buyer@test.com", Password: "x"}
 	require.NoError(t, db.Create(ctx, user))
-	product := &productModel.Product{Name: "p", Code: "P-1", Price: 5, StockQuantity: 4, Active: true}
+	product := &productModel.Product{Name: "p", Code: "P-1", Price: money.New(500, "USD"), StockQuantity: 4, Active: true}
 	require.NoError(t, db.Create(ctx, product))
 
 	oRepo := orderRepo.NewOrderRepository(db)
 	pRepo := orderRepo.NewProductRepository(db)
 	uRepo := orderRepo.NewUserRepository(db)
 	rRepo := orderRepo.NewReservationRepository(db)
-	cSvc := orderSvc.NewCouponService(validation.New(), orderRepo.NewCouponRepository(db))
-	svc := orderSvc.NewOrderService(validation.New(), db, oRepo, pRepo, uRepo, rRepo, cSvc, notification.NewLoggerNotifier())
+	rates := currency.NewRates(currency.Default, nil)
+	cSvc := orderSvc.NewCouponService(validation.New(), orderRepo.NewCouponRepository(db), rates)
+	svc := orderSvc.NewOrderService(validation.New(), db, oRepo, pRepo, uRepo, rRepo, cSvc, outboxRepo.NewOutboxRepository(db),
+		inventoryRepo.NewLedgerRepository(db), orderRepo.NewWarehouseRepository(db), stock.AllocateNearest,
+		rates, orderSvc.NewShippingService(validation.New(), orderRepo.NewShippingRateRepository(db), rates, shipping.MustParseMethods(currency.Default, "")),
+		orderSvc.NewTaxService(validation.New(), orderRepo.NewTaxRateRepository(db), tax.Exclusive),
+		orderSvc.NewInvoiceService(orderRepo.NewInvoiceRepository(db), invoice.Party{Name: "GoShop"}),
+		paymentSvc.NewPaymentSettler(payment.NewRegistry(stripe.Name), paymentRepo.NewPaymentRepository(db)))
 
 	order, err := oRepo.CreateOrder(ctx, user.ID, []*orderModel.OrderLine{{
-		ProductID: product.ID, Quantity: 2, Price: 10,
-	}}, "", 0)
+		ProductID: product.ID, Quantity: 2, Price: money.New(1000, "USD"),
+	}}, "", money.New(0, "USD"))
 	require.NoError(t, err)
 	order.Status = orderModel.OrderStatusPendingPayment
 	require.NoError(t, oRepo.UpdateOrder(ctx, order))
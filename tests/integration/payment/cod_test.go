@@ -0,0 +1,96 @@
+//go:build integration
+
+package tests_payment
+
+import (
+	"context"
+	"testing"
+
+	"github.com/quangdangfit/gocommon/validation"
+	"github.com/stretchr/testify/require"
+
+	inventoryRepo "goshop/internal/inventory/repository"
+	orderDomain "goshop/internal/order/domain"
+	orderModel "goshop/internal/order/model"
+	orderRepo "goshop/internal/order/repository"
+	orderSvc "goshop/internal/order/service"
+	outboxRepo "goshop/internal/outbox/repository"
+	paymentModel "goshop/internal/payment/model"
+	paymentRepo "goshop/internal/payment/repository"
+	paymentSvc "goshop/internal/payment/service"
+	productModel "goshop/internal/product/model"
+	userModel "goshop/internal/user/model"
+	"goshop/pkg/currency"
+	"goshop/pkg/invoice"
+	"goshop/pkg/money"
+	"goshop/pkg/payment"
+	"goshop/pkg/payment/stripe"
+	"goshop/pkg/shipping"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
+	"goshop/tests/testutil"
+)
+
+// TestCashOnDelivery_CommitAtPlacementCollectOnDelivery places a cash-on-delivery order,
+// checks its stock is committed straight away, then ships it and records the courier's cash.
+func TestCashOnDelivery_CommitAtPlacementCollectOnDelivery(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.StartPostgres(ctx, t)
+	require.NoError(t, testutil.ApplyMigrations(db))
+
+	user := &userModel.User{Email: "cod@test.com", Password: "x"}
+	require.NoError(t, db.Create(ctx, user))
+	product := &productModel.Product{Name: "p1", Code: "P-1", Price: money.New(1000, "USD"), StockQuantity: 5, Active: true}
+	require.NoError(t, db.Create(ctx, product))
+	require.NoError(t, testutil.StockDefaultWarehouse(ctx, db, product.ID))
+
+	validator := validation.New()
+	oRepo := orderRepo.NewOrderRepository(db)
+	rRepo := orderRepo.NewReservationRepository(db)
+	rates := currency.NewRates(currency.Default, nil)
+	orderService := orderSvc.NewOrderService(validator, db, oRepo, orderRepo.NewProductRepository(db),
+		orderRepo.NewUserRepository(db), rRepo,
+		orderSvc.NewCouponService(validator, orderRepo.NewCouponRepository(db), rates), outboxRepo.NewOutboxRepository(db),
+		inventoryRepo.NewLedgerRepository(db), orderRepo.NewWarehouseRepository(db), stock.AllocateNearest,
+		rates, orderSvc.NewShippingService(validator, orderRepo.NewShippingRateRepository(db), rates, shipping.MustParseMethods(currency.Default, "")),
+		orderSvc.NewTaxService(validator, orderRepo.NewTaxRateRepository(db), tax.Exclusive), orderSvc.NewInvoiceService(orderRepo.NewInvoiceRepository(db), invoice.Party{Name: "GoShop"}), paymentSvc.NewPaymentSettler(stripeRegistry(stripe.NewProvider(stripe.Config{})), paymentRepo.NewPaymentRepository(db)))
+
+	order, err := orderService.PlaceOrder(ctx, &orderDomain.PlaceOrderReq{
+		UserID:        user.ID,
+		Lines:         []orderDomain.PlaceOrderLineReq{{ProductID: product.ID, Quantity: 2}},
+		PaymentMethod: "cod",
+	})
+	require.NoError(t, err)
+	require.Equal(t, orderModel.OrderStatusNew, order.Status)
+
+	var fresh productModel.Product
+	require.NoError(t, db.GetDB().First(&fresh, "id = ?", product.ID).Error)
+	require.Equal(t, 3, fresh.StockQuantity, "stock committed at placement")
+	require.Zero(t, fresh.ReservedQuantity)
+	active, err := rRepo.FindActiveByOrderID(ctx, order.ID)
+	require.NoError(t, err)
+	require.Empty(t, active, "nothing left for the sweeper to expire")
+
+	providers := payment.NewRegistry(stripe.Name)
+	providers.Register(stripe.Name, stripe.NewProvider(stripe.Config{}))
+	pSvc := paymentSvc.NewPaymentService(db, providers, paymentRepo.NewPaymentRepository(db),
+		paymentRepo.NewRefundRepository(db), paymentRepo.NewPaymentMethodRepository(db), orderService, orderService,
+		orderSvc.NewInvoiceService(orderRepo.NewInvoiceRepository(db), invoice.Party{Name: "GoShop"}), payment.CaptureAutomatic)
+
+	_, err = pSvc.CreateIntentForOrder(ctx, order.ID, order.UserID, "", "")
+	require.Error(t, err, "cash-on-delivery orders skip the payment intent")
+
+	_, err = orderService.UpdateOrderStatus(ctx, order.ID, orderModel.OrderStatusInProgress)
+	require.NoError(t, err)
+
+	pay, err := pSvc.CollectCashOnDelivery(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, paymentModel.ProviderCashOnDelivery, pay.Provider)
+	require.Equal(t, paymentModel.PaymentStatusSucceeded, pay.Status)
+	require.Equal(t, int64(2000), pay.Amount)
+
+	var done orderModel.Order
+	require.NoError(t, db.GetDB().First(&done, "id = ?", order.ID).Error)
+	require.Equal(t, orderModel.OrderStatusDone, done.Status)
+	require.Equal(t, orderModel.PaymentMethodCOD, done.PaymentMethod)
+}
@@ -13,19 +13,27 @@ import (
 	"github.com/quangdangfit/gocommon/validation"
 	"github.com/stretchr/testify/require"
 
-	notificationPkg "goshop/pkg/notification"
+	inventoryRepo "goshop/internal/inventory/repository"
 	orderModel "goshop/internal/order/model"
 	orderRepo "goshop/internal/order/repository"
 	orderSvc "goshop/internal/order/service"
+	outboxRepo "goshop/internal/outbox/repository"
 	paymentModel "goshop/internal/payment/model"
 	paymentHTTP "goshop/internal/payment/port/http"
 	paymentRepo "goshop/internal/payment/repository"
 	paymentSvc "goshop/internal/payment/service"
 	productModel "goshop/internal/product/model"
-	"goshop/tests/testutil"
 	userModel "goshop/internal/user/model"
+	"goshop/pkg/currency"
+	"goshop/pkg/invoice"
 	"goshop/pkg/jtoken"
+	"goshop/pkg/money"
+	"goshop/pkg/payment"
 	"goshop/pkg/payment/stripe"
+	"goshop/pkg/shipping"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
+	"goshop/tests/testutil"
 )
 
 // TestCreatePaymentIntent_HTTPRoute exercises the full Gin route for POST
@@ -39,7 +47,7 @@ func TestCreatePaymentIntent_HTTPRoute(t *testing.T) {
 
 	user := &userModel.User{Email: "buyer@test.com", Password: "x", Role: "user"}
 	require.NoError(t, db.Create(ctx, user))
-	product := &productModel.Product{Name: "p", Code: "P-1", Price: 9, StockQuantity: 5, Active: true}
+	product := &productModel.Product{Name: "p", Code: "P-1", Price: money.New(900, "USD"), StockQuantity: 5, Active: true}
 	require.NoError(t, db.Create(ctx, product))
 
 	// Place an order via the order service so all invariants (status=pending_payment,
@@ -49,15 +57,19 @@ func TestCreatePaymentIntent_HTTPRoute(t *testing.T) {
 	pRepo := orderRepo.NewProductRepository(db)
 	uRepo := orderRepo.NewUserRepository(db)
 	rRepo := orderRepo.NewReservationRepository(db)
-	cSvc := orderSvc.NewCouponService(validator, orderRepo.NewCouponRepository(db))
-	orderService := orderSvc.NewOrderService(validator, db, oRepo, pRepo, uRepo, rRepo, cSvc, notificationPkg.NewLoggerNotifier())
+	rates := currency.NewRates(currency.Default, nil)
+	cSvc := orderSvc.NewCouponService(validator, orderRepo.NewCouponRepository(db), rates)
+	orderService := orderSvc.NewOrderService(validator, db, oRepo, pRepo, uRepo, rRepo, cSvc, outboxRepo.NewOutboxRepository(db),
+		inventoryRepo.NewLedgerRepository(db), orderRepo.NewWarehouseRepository(db), stock.AllocateNearest,
+		rates, orderSvc.NewShippingService(validator, orderRepo.NewShippingRateRepository(db), rates, shipping.MustParseMethods(currency.Default, "")),
+		orderSvc.NewTaxService(validator, orderRepo.NewTaxRateRepository(db), tax.Exclusive), orderSvc.NewInvoiceService(orderRepo.NewInvoiceRepository(db), invoice.Party{Name: "GoShop"}), paymentSvc.NewPaymentSettler(stripeRegistry(stripe.NewProvider(stripe.Config{})), paymentRepo.NewPaymentRepository(db)))
 
 	order, err := oRepo.CreateOrder(ctx, user.ID, []*orderModel.OrderLine{{
-		ProductID: product.ID, Quantity: 1, Price: 9,
-	}}, "", 0)
+		ProductID: product.ID, Quantity: 1, Price: money.New(900, "USD"),
+	}}, "", money.New(0, "USD"))
 	require.NoError(t, err)
 	order.Status = orderModel.OrderStatusPendingPayment
-	order.FinalPrice = 9
+	order.FinalPrice = money.New(900, "USD")
 	require.NoError(t, oRepo.UpdateOrder(ctx, order))
 
 	// Fake Stripe API.
@@ -74,8 +86,10 @@ func TestCreatePaymentIntent_HTTPRoute(t *testing.T) {
 		WebhookSecret: "whsec_test",
 		APIBase:       stripeAPI.URL,
 	})
-	pSvc := paymentSvc.NewPaymentService(provider, paymentRepo.NewPaymentRepository(db), orderService, orderService)
-	handler := paymentHTTP.NewHandler(pSvc)
+	providers := stripeRegistry(provider)
+	pSvc := paymentSvc.NewPaymentService(db, providers, paymentRepo.NewPaymentRepository(db), paymentRepo.NewRefundRepository(db), paymentRepo.NewPaymentMethodRepository(db), orderService, orderService,
+		orderSvc.NewInvoiceService(orderRepo.NewInvoiceRepository(db), invoice.Party{Name: "GoShop"}), payment.CaptureAutomatic)
+	handler := paymentHTTP.NewHandler(pSvc, providers)
 
 	gin.SetMode(gin.TestMode)
 	router := gin.New()
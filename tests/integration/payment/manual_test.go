@@ -0,0 +1,98 @@
+//go:build integration
+
+package tests_payment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/quangdangfit/gocommon/validation"
+	"github.com/stretchr/testify/require"
+
+	inventoryRepo "goshop/internal/inventory/repository"
+	orderModel "goshop/internal/order/model"
+	orderRepo "goshop/internal/order/repository"
+	orderSvc "goshop/internal/order/service"
+	outboxRepo "goshop/internal/outbox/repository"
+	paymentModel "goshop/internal/payment/model"
+	paymentRepo "goshop/internal/payment/repository"
+	paymentSvc "goshop/internal/payment/service"
+	productModel "goshop/internal/product/model"
+	userModel "goshop/internal/user/model"
+	"goshop/pkg/currency"
+	"goshop/pkg/invoice"
+	"goshop/pkg/money"
+	"goshop/pkg/payment"
+	"goshop/pkg/payment/manual"
+	"goshop/pkg/payment/stripe"
+	"goshop/pkg/shipping"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
+	"goshop/tests/testutil"
+)
+
+// TestBankTransfer_HoldThenConfirm picks the bank transfer provider for an order, checks its
+// stock reservation is held for the transfer window, then confirms the payment as an admin.
+func TestBankTransfer_HoldThenConfirm(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.StartPostgres(ctx, t)
+	require.NoError(t, testutil.ApplyMigrations(db))
+
+	user := &userModel.User{Email: "transfer@test.com", Password: "x"}
+	require.NoError(t, db.Create(ctx, user))
+	product := &productModel.Product{Name: "p1", Code: "P-1", Price: money.New(1000, "USD"), StockQuantity: 5, Active: true}
+	require.NoError(t, db.Create(ctx, product))
+
+	validator := validation.New()
+	oRepo := orderRepo.NewOrderRepository(db)
+	pRepo := orderRepo.NewProductRepository(db)
+	rRepo := orderRepo.NewReservationRepository(db)
+	rates := currency.NewRates(currency.Default, nil)
+	orderService := orderSvc.NewOrderService(validator, db, oRepo, pRepo, orderRepo.NewUserRepository(db), rRepo,
+		orderSvc.NewCouponService(validator, orderRepo.NewCouponRepository(db), rates), outboxRepo.NewOutboxRepository(db),
+		inventoryRepo.NewLedgerRepository(db), orderRepo.NewWarehouseRepository(db), stock.AllocateNearest,
+		rates, orderSvc.NewShippingService(validator, orderRepo.NewShippingRateRepository(db), rates, shipping.MustParseMethods(currency.Default, "")),
+		orderSvc.NewTaxService(validator, orderRepo.NewTaxRateRepository(db), tax.Exclusive), orderSvc.NewInvoiceService(orderRepo.NewInvoiceRepository(db), invoice.Party{Name: "GoShop"}), paymentSvc.NewPaymentSettler(stripeRegistry(stripe.NewProvider(stripe.Config{})), paymentRepo.NewPaymentRepository(db)))
+
+	order, err := oRepo.CreateOrder(ctx, user.ID, []*orderModel.OrderLine{{
+		ProductID: product.ID, Quantity: 1, Price: money.New(1000, "USD"),
+	}}, "", money.New(0, "USD"))
+	require.NoError(t, err)
+	order.Status = orderModel.OrderStatusPendingPayment
+	order.FinalPrice = money.New(1000, "USD")
+	require.NoError(t, oRepo.UpdateOrder(ctx, order))
+	require.NoError(t, pRepo.ReserveStock(ctx, product.ID, 1))
+	require.NoError(t, rRepo.CreateMany(ctx, []*orderModel.StockReservation{{
+		OrderID: order.ID, ProductID: product.ID, Quantity: 1,
+		Status: orderModel.ReservationStatusActive, ExpiresAt: time.Now().Add(15 * time.Minute),
+	}}))
+
+	providers := payment.NewRegistry(stripe.Name)
+	providers.Register(stripe.Name, stripe.NewProvider(stripe.Config{}))
+	providers.Register(manual.Name, manual.NewProvider(manual.Config{
+		Instructions: "Transfer to IBAN DE00 1234 quoting {reference}",
+		HoldFor:      72 * time.Hour,
+	}))
+	pSvc := paymentSvc.NewPaymentService(db, providers, paymentRepo.NewPaymentRepository(db),
+		paymentRepo.NewRefundRepository(db), paymentRepo.NewPaymentMethodRepository(db), orderService, orderService,
+		orderSvc.NewInvoiceService(orderRepo.NewInvoiceRepository(db), invoice.Party{Name: "GoShop"}), payment.CaptureAutomatic)
+
+	intent, err := pSvc.CreateIntentForOrder(ctx, order.ID, order.UserID, manual.Name, "")
+	require.NoError(t, err)
+	require.Equal(t, "Transfer to IBAN DE00 1234 quoting "+order.ID, intent.Instructions)
+
+	reservations, err := rRepo.FindActiveByOrderID(ctx, order.ID)
+	require.NoError(t, err)
+	require.Len(t, reservations, 1)
+	require.True(t, reservations[0].ExpiresAt.After(time.Now().Add(71*time.Hour)), "reservation held for the transfer")
+
+	pay, err := pSvc.ConfirmPayment(ctx, order.ID)
+	require.NoError(t, err)
+	require.Equal(t, paymentModel.PaymentStatusSucceeded, pay.Status)
+	require.Equal(t, manual.Name, pay.Provider)
+
+	var fresh orderModel.Order
+	require.NoError(t, db.GetDB().First(&fresh, "id = ?", order.ID).Error)
+	require.Equal(t, orderModel.OrderStatusPaid, fresh.Status)
+}
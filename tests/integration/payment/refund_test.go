@@ -0,0 +1,166 @@
+//go:build integration
+
+package tests_payment
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	orderModel "goshop/internal/order/model"
+	orderRepo "goshop/internal/order/repository"
+	orderSvc "goshop/internal/order/service"
+	paymentModel "goshop/internal/payment/model"
+	paymentRepo "goshop/internal/payment/repository"
+	paymentSvc "goshop/internal/payment/service"
+	productModel "goshop/internal/product/model"
+	userModel "goshop/internal/user/model"
+	"goshop/pkg/invoice"
+	"goshop/pkg/money"
+	"goshop/pkg/payment"
+	"goshop/pkg/payment/stripe"
+	"goshop/tests/testutil"
+)
+
+// TestRefund_PartialThenRest refunds one line of a paid order, then the rest, against a fake
+// Stripe, and checks the payment's running total, the line accounting and a failed refund
+// webhook giving its amount back.
+func TestRefund_PartialThenRest(t *testing.T) {
+	ctx := context.Background()
+	db := testutil.StartPostgres(ctx, t)
+	require.NoError(t, testutil.ApplyMigrations(db))
+
+	user := &userModel.User{Email: "refund@test.com", Password: "x"}
+	require.NoError(t, db.Create(ctx, user))
+	product := &productModel.Product{Name: "p1", Code: "P-1", Price: money.New(1000, "USD"), StockQuantity: 5, Active: true}
+	require.NoError(t, db.Create(ctx, product))
+
+	refundCalls := 0
+	stripeAPI := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v1/refunds", r.URL.Path)
+		require.NoError(t, r.ParseForm())
+		refundCalls++
+		amount, _ := strconv.ParseInt(r.PostForm.Get("amount"), 10, 64)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id": fmt.Sprintf("re_%d", refundCalls), "payment_intent": r.PostForm.Get("payment_intent"),
+			"amount": amount, "currency": "usd", "status": "succeeded",
+		})
+	}))
+	defer stripeAPI.Close()
+	provider := stripe.NewProvider(stripe.Config{SecretKey: "sk_test", WebhookSecret: "whsec_test", APIBase: stripeAPI.URL})
+
+	oRepo := orderRepo.NewOrderRepository(db)
+	order, err := oRepo.CreateOrder(ctx, user.ID, []*orderModel.OrderLine{{
+		ProductID: product.ID, Quantity: 2, Price: money.New(2000, "USD"),
+	}}, "", money.New(0, "USD"))
+	require.NoError(t, err)
+	order.Status = orderModel.OrderStatusPaid
+	require.NoError(t, oRepo.UpdateOrder(ctx, order))
+	require.NoError(t, db.Create(ctx, &paymentModel.Payment{
+		OrderID: order.ID, Provider: "stripe", ProviderIntentID: "pi_1", Amount: 2000, Currency: "usd",
+		Status: paymentModel.PaymentStatusSucceeded,
+	}))
+
+	invoiceRepo := orderRepo.NewInvoiceRepository(db)
+	invoices := orderSvc.NewInvoiceService(invoiceRepo, invoice.Party{Name: "GoShop"})
+	_, err = invoices.IssueInvoice(ctx, order, user.Email)
+	require.NoError(t, err)
+
+	orderQuery := &orderByID{repo: oRepo}
+	pSvc := paymentSvc.NewPaymentService(db, stripeRegistry(provider), paymentRepo.NewPaymentRepository(db),
+		paymentRepo.NewRefundRepository(db), paymentRepo.NewPaymentMethodRepository(db), orderQuery, nil, invoices,
+		payment.CaptureAutomatic)
+	lineID := order.Lines[0].ID
+
+	first, err := pSvc.RefundOrder(ctx, order.ID, paymentSvc.RefundRequest{
+		Lines:          []paymentSvc.RefundLine{{OrderLineID: lineID, Quantity: 1}},
+		IdempotencyKey: "k1",
+	})
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), first.Amount)
+	require.Equal(t, paymentModel.RefundStatusSucceeded, first.Status)
+	note, err := invoiceRepo.GetByRefundID(ctx, first.ID)
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), note.Total.Amount())
+	require.Len(t, note.Document.Lines, 1)
+
+	// A retried request returns the same refund without calling Stripe again.
+	replay, err := pSvc.RefundOrder(ctx, order.ID, paymentSvc.RefundRequest{IdempotencyKey: "k1"})
+	require.NoError(t, err)
+	require.Equal(t, first.ID, replay.ID)
+	require.Equal(t, 1, refundCalls)
+
+	// Only one unit of the line is left.
+	_, err = pSvc.RefundOrder(ctx, order.ID, paymentSvc.RefundRequest{
+		Lines: []paymentSvc.RefundLine{{OrderLineID: lineID, Quantity: 2}},
+	})
+	require.Error(t, err)
+
+	pay := loadPayment(t, db.GetDB(), order.ID)
+	require.Equal(t, int64(1000), pay.AmountRefunded)
+	require.Equal(t, paymentModel.PaymentStatusPartiallyRefunded, pay.Status)
+
+	rest, err := pSvc.RefundOrder(ctx, order.ID, paymentSvc.RefundRequest{})
+	require.NoError(t, err)
+	require.Equal(t, int64(1000), rest.Amount)
+	pay = loadPayment(t, db.GetDB(), order.ID)
+	require.Equal(t, int64(2000), pay.AmountRefunded)
+	require.Equal(t, paymentModel.PaymentStatusRefunded, pay.Status)
+
+	// Stripe later reports the second refund failed: the amount goes back on the payment.
+	body := []byte(`{"id":"evt_r1","type":"refund.updated","data":{"object":{"id":"re_2","payment_intent":"pi_1",` +
+		`"amount":1000,"currency":"usd","status":"failed","metadata":{"refund_id":"` + rest.ID + `"}}}}`)
+	require.NoError(t, pSvc.HandleWebhook(ctx, stripe.Name, body, signWebhook(body)))
+	pay = loadPayment(t, db.GetDB(), order.ID)
+	require.Equal(t, int64(1000), pay.AmountRefunded)
+	require.Equal(t, paymentModel.PaymentStatusPartiallyRefunded, pay.Status)
+
+	refunds, err := pSvc.ListRefunds(ctx, order.ID)
+	require.NoError(t, err)
+	require.Len(t, refunds, 2)
+	require.Len(t, refunds[0].Lines, 1)
+	require.Equal(t, paymentModel.RefundStatusFailed, refunds[1].Status)
+}
+
+type orderByID struct {
+	repo orderRepo.OrderRepository
+}
+
+func (q *orderByID) GetOrderByID(ctx context.Context, id string) (*orderModel.Order, error) {
+	return q.repo.GetOrderByID(ctx, id, true)
+}
+
+func loadPayment(t *testing.T, db *gorm.DB, orderID string) paymentModel.Payment {
+	t.Helper()
+	var pay paymentModel.Payment
+	require.NoError(t, db.First(&pay, "order_id = ?", orderID).Error)
+	return pay
+}
+
+// stripeRegistry registers p as the only, and default, provider.
+func stripeRegistry(p payment.Provider) *payment.Registry {
+	providers := payment.NewRegistry(stripe.Name)
+	providers.Register(stripe.Name, p)
+	return providers
+}
+
+func signWebhook(body []byte) http.Header {
+	ts := time.Now().Unix()
+	mac := hmac.New(sha256.New, []byte("whsec_test"))
+	_, _ = fmt.Fprintf(mac, "%d.", ts)
+	_, _ = mac.Write(body)
+	headers := http.Header{}
+	headers.Set(stripe.SignatureHeader, fmt.Sprintf("t=%d,v1=%s", ts, hex.EncodeToString(mac.Sum(nil))))
+	return headers
+}
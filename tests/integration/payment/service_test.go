@@ -18,18 +18,25 @@ import (
 	"github.com/quangdangfit/gocommon/validation"
 	"github.com/stretchr/testify/require"
 
-	notificationPkg "goshop/pkg/notification"
+	inventoryRepo "goshop/internal/inventory/repository"
 	orderModel "goshop/internal/order/model"
 	orderRepo "goshop/internal/order/repository"
 	orderSvc "goshop/internal/order/service"
+	outboxRepo "goshop/internal/outbox/repository"
 	paymentModel "goshop/internal/payment/model"
 	paymentRepo "goshop/internal/payment/repository"
 	paymentSvc "goshop/internal/payment/service"
 	productModel "goshop/internal/product/model"
-	"goshop/tests/testutil"
 	userModel "goshop/internal/user/model"
+	"goshop/pkg/currency"
+	"goshop/pkg/invoice"
+	"goshop/pkg/money"
 	"goshop/pkg/payment"
 	"goshop/pkg/payment/stripe"
+	"goshop/pkg/shipping"
+	"goshop/pkg/stock"
+	"goshop/pkg/tax"
+	"goshop/tests/testutil"
 )
 
 // TestStripeWebhook_PaidFlow exercises: place order (pending_payment + reservation) →
@@ -46,7 +53,7 @@ func TestStripeWebhook_PaidFlow(t *testing.T) {
 	// Seed a user + product.
 	user := &userModel.User{Email: "buyer@test.com", Password: "x"}
 	require.NoError(t, db.Create(ctx, user))
-	product := &productModel.Product{Name: "p1", Code: "P-1", Price: 10, StockQuantity: 5, Active: true}
+	product := &productModel.Product{Name: "p1", Code: "P-1", Price: money.New(1000, "USD"), StockQuantity: 5, Active: true}
 	require.NoError(t, db.Create(ctx, product))
 
 	// Fake Stripe API: the only call is POST /v1/payment_intents during CreateIntentForOrder.
@@ -72,15 +79,19 @@ func TestStripeWebhook_PaidFlow(t *testing.T) {
 	pRepo := orderRepo.NewProductRepository(db)
 	uRepo := orderRepo.NewUserRepository(db)
 	rRepo := orderRepo.NewReservationRepository(db)
-	cSvc := orderSvc.NewCouponService(validator, orderRepo.NewCouponRepository(db))
-	orderService := orderSvc.NewOrderService(validator, db, oRepo, pRepo, uRepo, rRepo, cSvc, notificationPkg.NewLoggerNotifier())
+	rates := currency.NewRates(currency.Default, nil)
+	cSvc := orderSvc.NewCouponService(validator, orderRepo.NewCouponRepository(db), rates)
+	orderService := orderSvc.NewOrderService(validator, db, oRepo, pRepo, uRepo, rRepo, cSvc, outboxRepo.NewOutboxRepository(db),
+		inventoryRepo.NewLedgerRepository(db), orderRepo.NewWarehouseRepository(db), stock.AllocateNearest,
+		rates, orderSvc.NewShippingService(validator, orderRepo.NewShippingRateRepository(db), rates, shipping.MustParseMethods(currency.Default, "")),
+		orderSvc.NewTaxService(validator, orderRepo.NewTaxRateRepository(db), tax.Exclusive), orderSvc.NewInvoiceService(orderRepo.NewInvoiceRepository(db), invoice.Party{Name: "GoShop"}), paymentSvc.NewPaymentSettler(stripeRegistry(stripe.NewProvider(stripe.Config{})), paymentRepo.NewPaymentRepository(db)))
 
 	order, err := oRepo.CreateOrder(ctx, user.ID, []*orderModel.OrderLine{{
-		ProductID: product.ID, Quantity: 2, Price: 20,
-	}}, "", 0)
+		ProductID: product.ID, Quantity: 2, Price: money.New(2000, "USD"),
+	}}, "", money.New(0, "USD"))
 	require.NoError(t, err)
 	order.Status = orderModel.OrderStatusPendingPayment
-	order.FinalPrice = 20
+	order.FinalPrice = money.New(2000, "USD")
 	require.NoError(t, oRepo.UpdateOrder(ctx, order))
 	// Reserve manually since we bypassed the full service for setup brevity.
 	require.NoError(t, pRepo.ReserveStock(ctx, product.ID, 2))
@@ -89,9 +100,10 @@ func TestStripeWebhook_PaidFlow(t *testing.T) {
 		Status: orderModel.ReservationStatusActive, ExpiresAt: time.Now().Add(15 * time.Minute),
 	}}))
 
-	pSvc := paymentSvc.NewPaymentService(provider, paymentRepo.NewPaymentRepository(db), orderService, orderService)
+	pSvc := paymentSvc.NewPaymentService(db, stripeRegistry(provider), paymentRepo.NewPaymentRepository(db), paymentRepo.NewRefundRepository(db), paymentRepo.NewPaymentMethodRepository(db), orderService, orderService,
+		orderSvc.NewInvoiceService(orderRepo.NewInvoiceRepository(db), invoice.Party{Name: "GoShop"}), payment.CaptureAutomatic)
 
-	intent, err := pSvc.CreateIntentForOrder(ctx, order.ID)
+	intent, err := pSvc.CreateIntentForOrder(ctx, order.ID, order.UserID, "", "")
 	require.NoError(t, err)
 	require.Equal(t, "pi_test_1", intent.ID)
 
@@ -101,12 +113,13 @@ func TestStripeWebhook_PaidFlow(t *testing.T) {
 	mac := hmac.New(sha256.New, []byte("whsec_test"))
 	_, _ = fmt.Fprintf(mac, "%d.", ts)
 	_, _ = mac.Write(body)
-	header := fmt.Sprintf("t=%d,v1=%s", ts, hex.EncodeToString(mac.Sum(nil)))
+	header := http.Header{}
+	header.Set(stripe.SignatureHeader, fmt.Sprintf("t=%d,v1=%s", ts, hex.EncodeToString(mac.Sum(nil))))
 
-	require.NoError(t, pSvc.HandleWebhook(ctx, body, header))
+	require.NoError(t, pSvc.HandleWebhook(ctx, stripe.Name, body, header))
 
 	// Replaying the same event must be a no-op (idempotency).
-	require.NoError(t, pSvc.HandleWebhook(ctx, body, header))
+	require.NoError(t, pSvc.HandleWebhook(ctx, stripe.Name, body, header))
 
 	// Assertions: order paid, stock decremented, reserved cleared, payment succeeded.
 	var fresh orderModel.Order
@@ -122,6 +135,13 @@ func TestStripeWebhook_PaidFlow(t *testing.T) {
 	require.NoError(t, db.GetDB().First(&pay, "order_id = ?", order.ID).Error)
 	require.Equal(t, paymentModel.PaymentStatusSucceeded, pay.Status)
 
+	// The inbox keeps the verified payload, marked processed.
+	var inbox paymentModel.ProviderEvent
+	require.NoError(t, db.GetDB().First(&inbox, "provider = ? AND event_id = ?", stripe.Name, "evt_1").Error)
+	require.Equal(t, paymentModel.ProviderEventStatusProcessed, inbox.Status)
+	require.Equal(t, 1, inbox.Attempts)
+	require.JSONEq(t, string(body), inbox.Payload)
+
 	// drain a body to silence unused-io warning
 	_, _ = io.Discard.Write(nil)
 	_ = payment.ErrInvalidSignature
@@ -13,6 +13,7 @@ import (
 
 	"goshop/internal/product/domain"
 	"goshop/internal/product/model"
+	"goshop/pkg/money"
 )
 
 // Get Product Detail
@@ -24,7 +25,7 @@ func TestProductAPI_GetProductByIDSuccess(t *testing.T) {
 	p := model.Product{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p)
 
@@ -34,7 +35,7 @@ func TestProductAPI_GetProductByIDSuccess(t *testing.T) {
 	assert.Equal(t, http.StatusOK, writer.Code)
 	assert.Equal(t, "test-product", res.Name)
 	assert.Equal(t, "test-product", res.Description)
-	assert.Equal(t, float64(1), res.Price)
+	assert.Equal(t, money.New(100, "USD"), res.Price)
 }
 
 func TestProductAPI_GetProductByIDSuccessFromCache(t *testing.T) {
@@ -43,7 +44,7 @@ func TestProductAPI_GetProductByIDSuccessFromCache(t *testing.T) {
 	p := model.Product{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p)
 
@@ -53,14 +54,14 @@ func TestProductAPI_GetProductByIDSuccessFromCache(t *testing.T) {
 	assert.Equal(t, http.StatusOK, writer.Code)
 	assert.Equal(t, "test-product", res.Name)
 	assert.Equal(t, "test-product", res.Description)
-	assert.Equal(t, float64(1), res.Price)
+	assert.Equal(t, money.New(100, "USD"), res.Price)
 
 	writer = makeRequest("GET", fmt.Sprintf("/api/v1/products/%s", p.ID), nil, accessToken())
 	parseResponseResult(writer.Body.Bytes(), &res)
 	assert.Equal(t, http.StatusOK, writer.Code)
 	assert.Equal(t, "test-product", res.Name)
 	assert.Equal(t, "test-product", res.Description)
-	assert.Equal(t, float64(1), res.Price)
+	assert.Equal(t, money.New(100, "USD"), res.Price)
 }
 
 func TestProductAPI_GetProductByIDNotFound(t *testing.T) {
@@ -81,7 +82,7 @@ func TestProductAPI_ListProductsSuccess(t *testing.T) {
 	p := model.Product{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p)
 
@@ -96,7 +97,7 @@ func TestProductAPI_ListProductsSuccess(t *testing.T) {
 	assert.Equal(t, 1, len(res.Products))
 	assert.Equal(t, "test-product", res.Products[0].Name)
 	assert.Equal(t, "test-product", res.Products[0].Description)
-	assert.Equal(t, float64(1), res.Products[0].Price)
+	assert.Equal(t, money.New(100, "USD"), res.Products[0].Price)
 }
 
 func TestProductAPI_ListProductsSuccessFromCache(t *testing.T) {
@@ -105,7 +106,7 @@ func TestProductAPI_ListProductsSuccessFromCache(t *testing.T) {
 	p := model.Product{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p)
 
@@ -120,7 +121,7 @@ func TestProductAPI_ListProductsSuccessFromCache(t *testing.T) {
 	assert.Equal(t, 1, len(res.Products))
 	assert.Equal(t, "test-product", res.Products[0].Name)
 	assert.Equal(t, "test-product", res.Products[0].Description)
-	assert.Equal(t, float64(1), res.Products[0].Price)
+	assert.Equal(t, money.New(100, "USD"), res.Products[0].Price)
 
 	writer = makeRequest("GET", "/api/v1/products", nil, accessToken())
 	parseResponseResult(writer.Body.Bytes(), &res)
@@ -132,7 +133,7 @@ func TestProductAPI_ListProductsSuccessFromCache(t *testing.T) {
 	assert.Equal(t, 1, len(res.Products))
 	assert.Equal(t, "test-product", res.Products[0].Name)
 	assert.Equal(t, "test-product", res.Products[0].Description)
-	assert.Equal(t, float64(1), res.Products[0].Price)
+	assert.Equal(t, money.New(100, "USD"), res.Products[0].Price)
 }
 
 func TestProductAPI_ListProductsNotFound(t *testing.T) {
@@ -159,7 +160,7 @@ func TestProductAPI_ListProductsFindByNameSuccess(t *testing.T) {
 	p := model.Product{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p)
 
@@ -174,7 +175,7 @@ func TestProductAPI_ListProductsFindByNameSuccess(t *testing.T) {
 	assert.Equal(t, 1, len(res.Products))
 	assert.Equal(t, "test-product", res.Products[0].Name)
 	assert.Equal(t, "test-product", res.Products[0].Description)
-	assert.Equal(t, float64(1), res.Products[0].Price)
+	assert.Equal(t, money.New(100, "USD"), res.Products[0].Price)
 }
 
 func TestProductAPI_ListProductsFindByNameNotFound(t *testing.T) {
@@ -183,7 +184,7 @@ func TestProductAPI_ListProductsFindByNameNotFound(t *testing.T) {
 	p := model.Product{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p)
 
@@ -200,7 +201,7 @@ func TestProductAPI_ListProductsFindByCodeSuccess(t *testing.T) {
 	p := model.Product{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p)
 
@@ -215,7 +216,7 @@ func TestProductAPI_ListProductsFindByCodeSuccess(t *testing.T) {
 	assert.Equal(t, 1, len(res.Products))
 	assert.Equal(t, "test-product", res.Products[0].Name)
 	assert.Equal(t, "test-product", res.Products[0].Description)
-	assert.Equal(t, float64(1), res.Products[0].Price)
+	assert.Equal(t, money.New(100, "USD"), res.Products[0].Price)
 }
 
 func TestProductAPI_ListProductsFindByCodeNotFound(t *testing.T) {
@@ -224,7 +225,7 @@ func TestProductAPI_ListProductsFindByCodeNotFound(t *testing.T) {
 	p := model.Product{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p)
 
@@ -241,21 +242,21 @@ func TestProductAPI_ListProductsWithPagination(t *testing.T) {
 	p1 := model.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := model.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
 
 	p3 := model.Product{
 		Name:        "test-product-3",
 		Description: "test-product-3",
-		Price:       3,
+		Price:       money.New(300, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p3)
 
@@ -270,7 +271,7 @@ func TestProductAPI_ListProductsWithPagination(t *testing.T) {
 	assert.Equal(t, 1, len(res.Products))
 	assert.Equal(t, "test-product-3", res.Products[0].Name)
 	assert.Equal(t, "test-product-3", res.Products[0].Description)
-	assert.Equal(t, float64(3), res.Products[0].Price)
+	assert.Equal(t, money.New(300, "USD"), res.Products[0].Price)
 }
 
 func TestProductAPI_ListProductsWithOrder(t *testing.T) {
@@ -279,21 +280,21 @@ func TestProductAPI_ListProductsWithOrder(t *testing.T) {
 	p1 := model.Product{
 		Name:        "test-product-1",
 		Description: "test-product-1",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p1)
 
 	p2 := model.Product{
 		Name:        "test-product-2",
 		Description: "test-product-2",
-		Price:       2,
+		Price:       money.New(200, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p2)
 
 	p3 := model.Product{
 		Name:        "test-product-3",
 		Description: "test-product-3",
-		Price:       3,
+		Price:       money.New(300, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p3)
 
@@ -308,7 +309,7 @@ func TestProductAPI_ListProductsWithOrder(t *testing.T) {
 	assert.Equal(t, 3, len(res.Products))
 	assert.Equal(t, "test-product-3", res.Products[0].Name)
 	assert.Equal(t, "test-product-3", res.Products[0].Description)
-	assert.Equal(t, float64(3), res.Products[0].Price)
+	assert.Equal(t, money.New(300, "USD"), res.Products[0].Price)
 }
 
 // Create Product
@@ -320,7 +321,7 @@ func TestProductAPI_CreateProductSuccess(t *testing.T) {
 	p := &domain.CreateProductReq{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	writer := makeRequest("POST", "/api/v1/products", p, adminAccessToken())
 	var res model.Product
@@ -328,7 +329,7 @@ func TestProductAPI_CreateProductSuccess(t *testing.T) {
 	assert.Equal(t, http.StatusOK, writer.Code)
 	assert.Equal(t, "test-product", res.Name)
 	assert.Equal(t, "test-product", res.Description)
-	assert.Equal(t, float64(1), res.Price)
+	assert.Equal(t, money.New(100, "USD"), res.Price)
 }
 
 func TestProductAPI_CreateProductInvalidFieldType(t *testing.T) {
@@ -351,7 +352,7 @@ func TestProductAPI_CreateProductMissingName(t *testing.T) {
 
 	p := &domain.CreateProductReq{
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	writer := makeRequest("POST", "/api/v1/products", p, adminAccessToken())
 	var response map[string]map[string]string
@@ -365,7 +366,7 @@ func TestProductAPI_CreateProductMissingDescription(t *testing.T) {
 
 	p := &domain.CreateProductReq{
 		Name:  "test-product",
-		Price: 1,
+		Price: money.New(100, "USD"),
 	}
 	writer := makeRequest("POST", "/api/v1/products", p, adminAccessToken())
 	var response map[string]map[string]string
@@ -380,13 +381,13 @@ func TestProductAPI_CreateProductPriceLessThanZero(t *testing.T) {
 	p := &domain.CreateProductReq{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       -1,
+		Price:       money.New(-100, "USD"),
 	}
 	writer := makeRequest("POST", "/api/v1/products", p, adminAccessToken())
 	var response map[string]map[string]string
 	_ = json.Unmarshal(writer.Body.Bytes(), &response)
-	assert.Equal(t, http.StatusInternalServerError, writer.Code)
-	assert.Equal(t, "Something went wrong", response["error"]["message"])
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Equal(t, "price must be positive", response["error"]["message"])
 }
 
 func TestProductAPI_CreateProductPriceEqualZero(t *testing.T) {
@@ -395,13 +396,13 @@ func TestProductAPI_CreateProductPriceEqualZero(t *testing.T) {
 	p := &domain.CreateProductReq{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       0,
+		Price:       money.New(0, "USD"),
 	}
 	writer := makeRequest("POST", "/api/v1/products", p, adminAccessToken())
 	var response map[string]map[string]string
 	_ = json.Unmarshal(writer.Body.Bytes(), &response)
-	assert.Equal(t, http.StatusInternalServerError, writer.Code)
-	assert.Equal(t, "Something went wrong", response["error"]["message"])
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Equal(t, "price must be positive", response["error"]["message"])
 }
 
 func TestProductAPI_CreateProductDuplicateName(t *testing.T) {
@@ -410,7 +411,7 @@ func TestProductAPI_CreateProductDuplicateName(t *testing.T) {
 	p := model.Product{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p)
 
@@ -430,7 +431,7 @@ func TestProductAPI_UpdateProductSuccess(t *testing.T) {
 	p := model.Product{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p)
 
@@ -443,7 +444,7 @@ func TestProductAPI_UpdateProductSuccess(t *testing.T) {
 	assert.Equal(t, http.StatusOK, writer.Code)
 	assert.Equal(t, "update-test-product", res.Name)
 	assert.Equal(t, "test-product", res.Description)
-	assert.Equal(t, float64(1), res.Price)
+	assert.Equal(t, money.New(100, "USD"), res.Price)
 }
 
 func TestProductAPI_UpdateProductInvalidFieldType(t *testing.T) {
@@ -452,7 +453,7 @@ func TestProductAPI_UpdateProductInvalidFieldType(t *testing.T) {
 	p := model.Product{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p)
 
@@ -472,24 +473,24 @@ func TestProductAPI_UpdateProductPriceLessThanZero(t *testing.T) {
 	p := model.Product{
 		Name:        "test-product",
 		Description: "test-product",
-		Price:       1,
+		Price:       money.New(100, "USD"),
 	}
 	_ = dbTest.Create(context.Background(), &p)
 
 	update := &domain.UpdateProductReq{
-		Price: -1,
+		Price: money.New(-100, "USD"),
 	}
 	writer := makeRequest("PUT", fmt.Sprintf("/api/v1/products/%s", p.ID), update, adminAccessToken())
 	var response map[string]map[string]string
 	_ = json.Unmarshal(writer.Body.Bytes(), &response)
-	assert.Equal(t, http.StatusInternalServerError, writer.Code)
-	assert.Equal(t, "Something went wrong", response["error"]["message"])
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+	assert.Equal(t, "price must be positive", response["error"]["message"])
 }
 
 func TestProductAPI_UpdateProductNotFound(t *testing.T) {
 	defer cleanData()
 	update := &domain.UpdateProductReq{
-		Price: 1,
+		Price: money.New(100, "USD"),
 	}
 	writer := makeRequest("PUT", "/api/v1/products/notfound", update, adminAccessToken())
 	var response map[string]map[string]string
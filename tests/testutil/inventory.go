@@ -0,0 +1,18 @@
+package testutil
+
+import (
+	"context"
+
+	"goshop/pkg/dbs"
+)
+
+// StockDefaultWarehouse places each product's whole stock_quantity at the default warehouse,
+// mirroring what the product service does on create. Suites that insert products directly
+// need it before placing orders, since reservations are allocated from warehouse stock.
+func StockDefaultWarehouse(ctx context.Context, db dbs.Database, productIDs ...string) error {
+	return db.GetDB().WithContext(ctx).Exec(`INSERT INTO warehouse_stocks (id, created_at, updated_at, warehouse_id, product_id, stock_quantity)
+SELECT gen_random_uuid()::text, NOW(), NOW(), w.id, p.id, p.stock_quantity
+FROM products p CROSS JOIN warehouses w
+WHERE p.id IN ? AND w.is_default AND w.deleted_at IS NULL
+ON CONFLICT (warehouse_id, product_id) DO UPDATE SET stock_quantity = EXCLUDED.stock_quantity`, productIDs).Error
+}